@@ -0,0 +1,105 @@
+package markdown
+
+// Abbreviations, defined like *[HTML]: HyperText Markup Language
+
+import (
+	"sort"
+	"unicode"
+	"utf8"
+)
+
+/* markAbbreviations - wrap each occurrence of a defined abbreviation
+ * in the text of list and its descendants into an ABBR element.  An
+ * abbreviation must not be part of a longer word; abbreviations
+ * containing spaces are not recognized.
+ */
+func (d *Doc) markAbbreviations(list *Element) {
+	titles := make(map[string]string)
+	for e := d.abbreviations; e != nil; e = e.next {
+		name := e.children.contents.str
+		if _, ok := titles[name]; !ok {
+			titles[name] = e.contents.str
+		}
+	}
+	names := make(byLength, 0, len(titles))
+	for name := range titles {
+		names = append(names, name)
+	}
+	sort.Sort(names)
+	markAbbr(list, titles, names)
+}
+
+func markAbbr(list *Element, titles map[string]string, names []string) {
+	for e := list; e != nil; e = e.next {
+		switch e.key {
+		case STR:
+			e = splitAbbr(e, titles, names)
+		case ABBR, CODE, HTML, VERBATIM, HTMLBLOCK, MATH, DISPLAYMATH:
+		default:
+			if l := e.Label(); l != nil {
+				markAbbr(l, titles, names)
+			}
+			markAbbr(e.children, titles, names)
+		}
+	}
+}
+
+/* splitAbbr - replace the STR element e by a list of STR and ABBR
+ * elements, returning the last one.
+ */
+func splitAbbr(e *Element, titles map[string]string, names []string) *Element {
+	s := e.contents.str
+	for i := 0; i < len(s); i++ {
+		if i > 0 && isWordChar(s[:i], false) {
+			continue
+		}
+		for _, name := range names {
+			if len(s)-i < len(name) || s[i:i+len(name)] != name {
+				continue
+			}
+			rest := s[i+len(name):]
+			if rest != "" && isWordChar(rest, true) {
+				continue
+			}
+			abbr := mk_element(ABBR)
+			abbr.contents.str = titles[name]
+			abbr.children = mk_str(name)
+			abbr.next = e.next
+			if i > 0 {
+				e.contents.str = s[:i]
+				e.next = abbr
+			} else {
+				*e = *abbr
+				abbr = e
+			}
+			if rest == "" {
+				return abbr
+			}
+			r := mk_str(rest)
+			r.next = abbr.next
+			abbr.next = r
+			return splitAbbr(r, titles, names)
+		}
+	}
+	return e
+}
+
+/* isWordChar - report whether the first, or the last rune of s is a
+ * letter or digit
+ */
+func isWordChar(s string, first bool) bool {
+	c, _ := utf8.DecodeRuneInString(s)
+	if !first {
+		c, _ = utf8.DecodeLastRuneInString(s)
+	}
+	return unicode.IsLetter(c) || unicode.IsDigit(c)
+}
+
+/* byLength sorts abbreviations longest first, so that the longest
+ * matching one is used
+ */
+type byLength []string
+
+func (l byLength) Len() int			{ return len(l) }
+func (l byLength) Swap(i, j int)		{ l[i], l[j] = l[j], l[i] }
+func (l byLength) Less(i, j int) bool	{ return len(l[i]) > len(l[j]) }
@@ -0,0 +1,70 @@
+package markdown
+
+// Admonitions like !!! note "Title", and alerts like > [!WARNING]
+
+import (
+	"strings"
+)
+
+/* kinds of the alerts of GitHub */
+var alertKinds = map[string]bool{
+	"note":			true,
+	"tip":			true,
+	"important":	true,
+	"warning":		true,
+	"caution":		true,
+}
+
+/* admonition - make an ADMONITION element of the specified kind, with
+ * the contents of the RAW element body, which may be nil.  If title
+ * is nil, the title is derived from the kind; if it is empty, there
+ * is none.
+ */
+func (d *Doc) admonition(kind, title, body *Element) *Element {
+	e := mk_element(ADMONITION)
+	e.contents.str = strings.ToLower(kind.contents.str)
+	s := defaultTitle(e.contents.str)
+	if title != nil {
+		s = title.contents.str
+	}
+	if s != "" {
+		/* parsed by processRawBlocks */
+		raw := mk_str(s)
+		raw.key = RAW
+		e.contents.link = &link{label: raw}
+	}
+	if body != nil {
+		body.key = RAW
+		e.children = body
+	}
+	return e
+}
+
+/* defaultTitle - return the title of an admonition of the specified
+ * kind without an explicit one: the first word, capitalized
+ */
+func defaultTitle(kind string) string {
+	if i := strings.Index(kind, " "); i != -1 {
+		kind = kind[:i]
+	}
+	if kind == "" {
+		return ""
+	}
+	return strings.ToUpper(kind[:1]) + kind[1:]
+}
+
+func (w *htmlOut) Admonition(kind string, entering bool) {
+	if entering {
+		w.pad(2).s(`<div class="admonition `).str(kind).s(`"`).position().s(">").nest(1).nl().pset(2)
+	} else {
+		w.nest(-1).pad(1).s("</div>").pset(0)
+	}
+}
+
+func (w *htmlOut) AdmonitionTitle(entering bool) {
+	if entering {
+		w.pad(2).s(`<p class="admonition-title">`)
+	} else {
+		w.s("</p>").pset(0)
+	}
+}
@@ -0,0 +1,128 @@
+package markdown
+
+// JSON dump of the document tree
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+type astOut struct {
+	Writer
+	numbers	map[*Element]string	/* See Doc.HeadingNumbers. */
+}
+
+// WriteAST prints the document tree in JSON format to the specified
+// Writer, as an array of the top level elements.  Each element is an
+// object with a member "kind", the name returned by KindName, and,
+// where applicable, "lines" (see Element.Lines; those of the
+// enclosing top level block for nested elements), "text", "url",
+// "title", "attributes", "tight" (see Element.Tight), "comment" (see
+// Element.Comment), "number" (see Doc.HeadingNumbers), "label", and
+// "children".
+// It returns the number of bytes written, and the first error
+// returned by w, if any, like WriteHtml.
+//
+func (d *Doc) WriteAST(w Writer) (n int, err os.Error) {
+	dest := &errWriter{w: w}
+	out := &astOut{dest, d.HeadingNumbers()}
+	out.list(d.tree, "")
+	out.WriteByte('\n')
+	return dest.n, dest.err
+}
+
+func (w *astOut) list(e *Element, indent string) {
+	if e == nil {
+		w.WriteString("[]")
+		return
+	}
+	w.WriteString("[\n")
+	for ; e != nil; e = e.next {
+		w.elem(e, indent+"\t")
+		if e.next != nil {
+			w.WriteByte(',')
+		}
+		w.WriteByte('\n')
+	}
+	w.WriteString(indent + "]")
+}
+
+func (w *astOut) elem(e *Element, indent string) {
+	w.WriteString(indent + `{"kind": ` + jsonString(e.KindName()))
+	if first, last := e.Lines(); first != 0 {
+		w.WriteString(`, "lines": [` + strconv.Itoa(first) + ", " + strconv.Itoa(last) + "]")
+	}
+	if e.contents.str != "" {
+		w.WriteString(`, "text": ` + jsonString(e.contents.str))
+	}
+	if l := e.contents.link; l != nil && e.key != ADMONITION {
+		w.WriteString(`, "url": ` + jsonString(l.url))
+		if l.title != "" {
+			w.WriteString(`, "title": ` + jsonString(l.title))
+		}
+	}
+	if a := e.attr; a != nil {
+		w.WriteString(`, "attributes": {"id": ` + jsonString(a.ID) + `, "classes": [`)
+		for i, c := range a.Classes {
+			if i > 0 {
+				w.WriteString(", ")
+			}
+			w.WriteString(jsonString(c))
+		}
+		w.WriteString(`], "attrs": {`)
+		for i, kv := range a.Attrs {
+			if i > 0 {
+				w.WriteString(", ")
+			}
+			w.WriteString(jsonString(kv.Key) + ": " + jsonString(kv.Value))
+		}
+		w.WriteString("}}")
+	}
+	if e.key == BULLETLIST || e.key == ORDEREDLIST {
+		w.WriteString(`, "tight": ` + strconv.Btoa(e.Tight()))
+	}
+	if text, ok := e.Comment(); ok {
+		w.WriteString(`, "comment": ` + jsonString(text))
+	}
+	if n := w.numbers[e]; n != "" {
+		w.WriteString(`, "number": ` + jsonString(n))
+	}
+	if l := e.Label(); l != nil {
+		w.WriteString(`, "label": `)
+		w.list(l, indent)
+	}
+	if e.children != nil {
+		w.WriteString(`, "children": `)
+		w.list(e.children, indent)
+	}
+	w.WriteString("}")
+}
+
+/* jsonString - quote s as a JSON string
+ */
+func jsonString(s string) string {
+	var b []string
+	i0 := 0
+	for i := 0; i < len(s); i++ {
+		var esc string
+		switch c := s[i]; {
+		case c == '"':
+			esc = `\"`
+		case c == '\\':
+			esc = `\\`
+		case c == '\n':
+			esc = `\n`
+		case c == '\t':
+			esc = `\t`
+		case c < 0x20:
+			esc = `\u00` + string("0123456789abcdef"[c>>4]) + string("0123456789abcdef"[c&15])
+		default:
+			continue
+		}
+		b = append(b, s[i0:i], esc)
+		i0 = i + 1
+	}
+	b = append(b, s[i0:])
+	return `"` + strings.Join(b, "") + `"`
+}
@@ -0,0 +1,150 @@
+package markdown
+
+// Attribute blocks like {#id .class key=value}
+
+import (
+	"strings"
+)
+
+// Attributes are set on headings, fenced code blocks, links and
+// images by an attribute block, if the Attributes extension is
+// enabled:
+//
+//	# Heading {#id .class key=value key2="quoted value"}
+//
+// For a heading, the ID replaces the one derived from its text.
+type Attributes struct {
+	ID		string
+	Classes	[]string
+	Attrs	[]Attr	// other attributes, in the order written
+}
+
+// An Attr is a key=value pair of an attribute block.
+type Attr struct {
+	Key, Value string
+}
+
+// An AttributeRenderer is a Renderer that is passed the attributes of
+// elements. If implemented, SetAttributes is called by Render right
+// before the method starting an element having attributes.
+type AttributeRenderer interface {
+	SetAttributes(a *Attributes)
+}
+
+/* parseAttributes - parse the text between the braces of an attribute
+ * block; nil is returned if it is not valid.
+ */
+func parseAttributes(s string) *Attributes {
+	a := new(Attributes)
+	for {
+		s = strings.TrimLeft(s, " \t")
+		if s == "" {
+			break
+		}
+		n := strings.IndexAny(s, " \t=")
+		if n == -1 {
+			n = len(s)
+		}
+		word := s[:n]
+		s = s[n:]
+		switch {
+		case len(word) > 1 && word[0] == '#':
+			a.ID = word[1:]
+		case len(word) > 1 && word[0] == '.':
+			a.Classes = append(a.Classes, word[1:])
+		case isAttrKey(word) && strings.HasPrefix(s, "="):
+			s = s[1:]
+			var val string
+			if strings.HasPrefix(s, `"`) {
+				n = strings.Index(s[1:], `"`)
+				if n == -1 {
+					return nil
+				}
+				val = s[1 : n+1]
+				s = s[n+2:]
+			} else {
+				if n = strings.IndexAny(s, " \t"); n == -1 {
+					n = len(s)
+				}
+				val = s[:n]
+				s = s[n:]
+			}
+			a.Attrs = append(a.Attrs, Attr{word, val})
+		default:
+			return nil
+		}
+	}
+	return a
+}
+
+func isAttrKey(s string) bool {
+	for _, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == ':', c == '.':
+		default:
+			return false
+		}
+	}
+	return s != ""
+}
+
+/* setAttributes - attach the attributes in the text of the STR element
+ * a, which may be nil, to element e.  In safe mode, event handlers,
+ * styles, and attributes holding URLs are dropped.
+ */
+func (d *Doc) setAttributes(e, a *Element) {
+	if a == nil {
+		return
+	}
+	attr := parseAttributes(a.contents.str)
+	if d.extension.Safe {
+		list := attr.Attrs[:0]
+		for _, kv := range attr.Attrs {
+			switch k := strings.ToLower(kv.Key); {
+			case strings.HasPrefix(k, "on"), k == "style", k == "href", k == "src":
+			default:
+				list = append(list, kv)
+			}
+		}
+		attr.Attrs = list
+	}
+	e.attr = attr
+	if e.key >= H1 && e.key <= H6 {
+		e.contents.str = attr.ID
+	}
+}
+
+/* fenced - make a VERBATIM element like mk_fenced, moving an attribute
+ * block at the end of the info string into the element's attributes.
+ * If nothing else is left of the info string, the first class is used
+ * as the language.
+ */
+func (d *Doc) fenced(info, lines *Element) *Element {
+	e := mk_fenced(info, lines)
+	if !d.extension.Attributes || e.children == nil {
+		return e
+	}
+	s := e.children.contents.str
+	i := strings.LastIndex(s, "{")
+	if i == -1 || !strings.HasSuffix(s, "}") {
+		return e
+	}
+	block := s[i+1 : len(s)-1]
+	if parseAttributes(block) == nil {
+		return e
+	}
+	d.setAttributes(e, mk_str(block))
+	a := e.attr
+	s = strings.TrimSpace(s[:i])
+	if s == "" && len(a.Classes) > 0 {
+		s = a.Classes[0]
+		a.Classes = a.Classes[1:]
+	}
+	if s == "" {
+		e.children = nil
+	} else {
+		e.children.contents.str = s
+	}
+	return e
+}
@@ -0,0 +1,69 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"runtime"
+	"testing"
+)
+
+/* benchText - a document of several MB, made of copies of the README */
+func benchText() []byte {
+	b, err := ioutil.ReadFile("README.markdown")
+	if err != nil {
+		panic(err)
+	}
+	return bytes.Repeat(append(b, '\n'), 100)
+}
+
+/* memStats - return the number of allocations so far, and the bytes
+ * allocated
+ */
+func memStats() (mallocs, total uint64) {
+	runtime.UpdateMemStats()
+	return runtime.MemStats.Mallocs, runtime.MemStats.TotalAlloc
+}
+
+/* reportAllocs - print the allocations per op since those of memStats,
+ * as the benchmark results show the time only
+ */
+func reportAllocs(b *testing.B, mallocs, total uint64) {
+	mallocs1, total1 := memStats()
+	fmt.Printf("\t%d ops\t%d allocs/op\t%d B/op\n", b.N, (mallocs1-mallocs)/uint64(b.N), (total1-total)/uint64(b.N))
+}
+
+// BenchmarkParse parses a multi-MB document, and reports the
+// allocations per document: parsing it one top level block at a time
+// keeps those of the pending parser actions small.
+func BenchmarkParse(b *testing.B) {
+	b.StopTimer()
+	text := benchText()
+	p := NewParser(Extensions{Smart: true, Notes: true})
+	b.SetBytes(int64(len(text)))
+	mallocs, total := memStats()
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		p.ParseBytes(text)
+	}
+	b.StopTimer()
+	reportAllocs(b, mallocs, total)
+}
+
+// BenchmarkWriteHtml prints a multi-MB document, parsed before, as
+// HTML.
+func BenchmarkWriteHtml(b *testing.B) {
+	b.StopTimer()
+	text := benchText()
+	d := NewParser(Extensions{Smart: true, Notes: true}).ParseBytes(text)
+	b.SetBytes(int64(len(text)))
+	var out bytes.Buffer
+	mallocs, total := memStats()
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		out.Reset()
+		d.WriteHtml(&out)
+	}
+	b.StopTimer()
+	reportAllocs(b, mallocs, total)
+}
@@ -0,0 +1,474 @@
+package markdown
+
+// Bibliographies, read from CSL JSON or BibTeX
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"json"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// A Bibliography holds the works that may be cited by documents, if
+// the Citations extension is enabled, indexed by their keys.
+type Bibliography map[string]*Work
+
+// A Work is an entry of a Bibliography.  The fields follow CSL, the
+// Citation Style Language; BibTeX fields are mapped to them.
+type Work struct {
+	Key			string
+	Type		string	// e.g. "book", "article-journal", "chapter"
+	Authors		[]Name
+	Editors		[]Name
+	Title		string
+	Container	string	// title of the journal, or book, the work is part of
+	Publisher	string
+	Place		string	// place of the publisher
+	Year		string
+	Volume		string
+	Issue		string
+	Pages		string
+	URL			string
+	DOI			string
+}
+
+// A Name is the name of an author or editor.  Names of organizations
+// have the Family part only.
+type Name struct {
+	Family, Given	string
+}
+
+// ReadCSLJSON reads a bibliography in CSL JSON format, as exported
+// e.g. by Zotero: an array of objects having an "id".
+func ReadCSLJSON(r io.Reader) (Bibliography, os.Error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var list []interface{}
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	b := make(Bibliography)
+	for _, v := range list {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, os.NewError("markdown: CSL JSON: entry is not an object")
+		}
+		w := &Work{
+			Key:		cslString(m["id"]),
+			Type:		cslString(m["type"]),
+			Authors:	cslNames(m["author"]),
+			Editors:	cslNames(m["editor"]),
+			Title:		cslString(m["title"]),
+			Container:	cslString(m["container-title"]),
+			Publisher:	cslString(m["publisher"]),
+			Place:		cslString(m["publisher-place"]),
+			Year:		cslYear(m["issued"]),
+			Volume:		cslString(m["volume"]),
+			Issue:		cslString(m["issue"]),
+			Pages:		strings.Replace(cslString(m["page"]), "-", "–", -1),
+			URL:		cslString(m["URL"]),
+			DOI:		cslString(m["DOI"]),
+		}
+		if w.Key == "" {
+			return nil, os.NewError("markdown: CSL JSON: entry without id")
+		}
+		b[w.Key] = w
+	}
+	return b, nil
+}
+
+/* cslString - return a string, or number, of CSL JSON as a string */
+func cslString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.Ftoa64(v, 'f', -1)
+	}
+	return ""
+}
+
+/* cslNames - return the names of a CSL JSON list of authors */
+func cslNames(v interface{}) []Name {
+	list, _ := v.([]interface{})
+	var names []Name
+	for _, v := range list {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		n := Name{Family: cslString(m["family"]), Given: cslString(m["given"])}
+		if n.Family == "" {
+			n.Family = cslString(m["literal"])
+		}
+		if n.Family != "" {
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+/* cslYear - return the year of a CSL JSON date, like
+ * {"date-parts": [[2004, 3]]}
+ */
+func cslYear(v interface{}) string {
+	m, _ := v.(map[string]interface{})
+	if parts, ok := m["date-parts"].([]interface{}); ok && len(parts) > 0 {
+		if first, ok := parts[0].([]interface{}); ok && len(first) > 0 {
+			return cslString(first[0])
+		}
+	}
+	if s := cslString(m["literal"]); s != "" {
+		return s
+	}
+	s := cslString(m["raw"])
+	if len(s) > 4 {
+		s = s[:4]
+	}
+	return s
+}
+
+/* types of BibTeX entries, and the CSL types they are mapped to */
+var bibtexTypes = map[string]string{
+	"article":			"article-journal",
+	"book":				"book",
+	"booklet":			"book",
+	"inbook":			"chapter",
+	"incollection":		"chapter",
+	"inproceedings":	"paper-conference",
+	"conference":		"paper-conference",
+	"mastersthesis":	"thesis",
+	"phdthesis":		"thesis",
+	"techreport":		"report",
+	"manual":			"report",
+	"online":			"webpage",
+}
+
+// ReadBibTeX reads a bibliography in BibTeX format.  @string
+// definitions are expanded; TeX commands other than escaped special
+// characters are dropped, with their arguments kept.
+func ReadBibTeX(r io.Reader) (Bibliography, os.Error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := &bibtexParser{s: string(data), strings: make(map[string]string)}
+	b := make(Bibliography)
+	for {
+		i := strings.Index(p.s[p.pos:], "@")
+		if i == -1 {
+			break
+		}
+		p.pos += i + 1
+		typ := strings.ToLower(p.word())
+		p.space()
+		if !p.next("{") && !p.next("(") {
+			return nil, p.error("expected { after @" + typ)
+		}
+		switch typ {
+		case "comment", "preamble":
+			p.pos--
+			if _, err = p.value(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		var key string
+		if typ != "string" {
+			key = p.word()
+			p.space()
+			if !p.next(",") {
+				return nil, p.error("expected , after the key " + key)
+			}
+		}
+		fields, err := p.fields()
+		if err != nil {
+			return nil, err
+		}
+		if typ == "string" {
+			for k, v := range fields {
+				p.strings[k] = v
+			}
+			continue
+		}
+		w := &Work{
+			Key:		key,
+			Type:		bibtexTypes[typ],
+			Authors:	bibtexNames(fields["author"]),
+			Editors:	bibtexNames(fields["editor"]),
+			Title:		texText(fields["title"]),
+			Container:	texText(fields["journal"]),
+			Publisher:	texText(fields["publisher"]),
+			Place:		texText(fields["address"]),
+			Year:		texText(fields["year"]),
+			Volume:		texText(fields["volume"]),
+			Issue:		texText(fields["number"]),
+			Pages:		strings.Replace(texText(fields["pages"]), "-", "–", -1),
+			URL:		fields["url"],
+			DOI:		fields["doi"],
+		}
+		for strings.Index(w.Pages, "––") != -1 {
+			w.Pages = strings.Replace(w.Pages, "––", "–", -1)
+		}
+		if w.Container == "" {
+			w.Container = texText(fields["booktitle"])
+		}
+		if w.Publisher == "" {
+			w.Publisher = texText(fields["institution"] + fields["school"] + fields["organization"])
+		}
+		if w.Type == "" {
+			w.Type = "book"
+			if w.Container != "" {
+				w.Type = "chapter"
+			}
+		}
+		b[key] = w
+	}
+	return b, nil
+}
+
+type bibtexParser struct {
+	s		string
+	pos		int
+	strings	map[string]string	/* Macros defined by @string. */
+}
+
+func (p *bibtexParser) error(msg string) os.Error {
+	line := 1 + strings.Count(p.s[:p.pos], "\n")
+	return os.NewError("markdown: BibTeX: line " + strconv.Itoa(line) + ": " + msg)
+}
+
+func (p *bibtexParser) space() {
+	for p.pos < len(p.s) && strings.Index(" \t\r\n", p.s[p.pos:p.pos+1]) != -1 {
+		p.pos++
+	}
+}
+
+/* next - skip s, if the text continues with it */
+func (p *bibtexParser) next(s string) bool {
+	if strings.HasPrefix(p.s[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+/* word - return the key or name at the current position */
+func (p *bibtexParser) word() string {
+	i := p.pos
+	for p.pos < len(p.s) && strings.Index(" \t\r\n{}(),=#\"", p.s[p.pos:p.pos+1]) == -1 {
+		p.pos++
+	}
+	return p.s[i:p.pos]
+}
+
+/* fields - parse the fields of an entry, up to the closing brace */
+func (p *bibtexParser) fields() (map[string]string, os.Error) {
+	fields := make(map[string]string)
+	for p.space(); !p.next("}") && !p.next(")"); p.space() {
+		name := strings.ToLower(p.word())
+		p.space()
+		if name == "" || !p.next("=") {
+			return nil, p.error("expected a field")
+		}
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		fields[name] = v
+		p.space()
+		p.next(",")
+	}
+	return fields, nil
+}
+
+/* value - parse a value: strings in braces or quotes, numbers, and
+ * macros, concatenated by #
+ */
+func (p *bibtexParser) value() (string, os.Error) {
+	var s string
+	for more := true; more; more = p.next("#") {
+		p.space()
+		switch {
+		case p.pos == len(p.s):
+			return "", p.error("unexpected end of file")
+		case p.s[p.pos] == '{', p.s[p.pos] == '(':
+			open, close := p.s[p.pos], byte('}')
+			if open == '(' {
+				close = ')'
+			}
+			depth := 1
+			i := p.pos + 1
+			for p.pos++; p.pos < len(p.s); p.pos++ {
+				if c := p.s[p.pos]; c == open {
+					depth++
+				} else if c == close {
+					if depth--; depth == 0 {
+						break
+					}
+				}
+			}
+			if p.pos == len(p.s) {
+				return "", p.error("unbalanced braces")
+			}
+			s += p.s[i:p.pos]
+			p.pos++
+		case p.s[p.pos] == '"':
+			i := p.pos + 1
+			depth := 0
+			for p.pos++; p.pos < len(p.s) && (p.s[p.pos] != '"' || depth > 0); p.pos++ {
+				switch p.s[p.pos] {
+				case '{':
+					depth++
+				case '}':
+					depth--
+				}
+			}
+			if p.pos == len(p.s) {
+				return "", p.error("unterminated string")
+			}
+			s += p.s[i:p.pos]
+			p.pos++
+		default:
+			w := p.word()
+			if w == "" {
+				return "", p.error("expected a value")
+			}
+			if m, ok := p.strings[strings.ToLower(w)]; ok {
+				w = m
+			}
+			s += w
+		}
+		p.space()
+	}
+	return strings.Join(strings.Fields(s), " "), nil
+}
+
+/* bibtexNames - split a list of names separated by "and", each either
+ * "Family, Given", or "Given Family"
+ */
+func bibtexNames(s string) []Name {
+	var names []Name
+	for _, n := range splitBraced(s, " and ") {
+		n = strings.TrimSpace(n)
+		if n == "" {
+			continue
+		}
+		var name Name
+		if parts := splitBraced(n, ","); len(parts) > 1 {
+			name.Family = texText(parts[0])
+			name.Given = texText(strings.Join(parts[1:], ","))
+		} else if words := splitBraced(n, " "); len(words) > 1 {
+			name.Family = texText(words[len(words)-1])
+			name.Given = texText(strings.Join(words[:len(words)-1], " "))
+		} else {
+			name.Family = texText(n)
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+/* splitBraced - split s at the occurrences of sep that are not
+ * enclosed in braces
+ */
+func splitBraced(s, sep string) []string {
+	var list []string
+	depth := 0
+	i0 := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '{':
+			depth++
+		case s[i] == '}':
+			depth--
+		case depth == 0 && strings.HasPrefix(s[i:], sep):
+			list = append(list, s[i0:i])
+			i += len(sep) - 1
+			i0 = i + 1
+		}
+	}
+	return append(list, s[i0:])
+}
+
+/* TeX accents, like \"o, and symbols, like \ss, replaced by texText */
+var texAccents = map[string]string{
+	`"a`: "ä", `"e`: "ë", `"i`: "ï", `"o`: "ö", `"u`: "ü", `"y`: "ÿ",
+	`"A`: "Ä", `"E`: "Ë", `"I`: "Ï", `"O`: "Ö", `"U`: "Ü",
+	"'a": "á", "'e": "é", "'i": "í", "'o": "ó", "'u": "ú", "'y": "ý",
+	"'A": "Á", "'E": "É", "'I": "Í", "'O": "Ó", "'U": "Ú",
+	"`a": "à", "`e": "è", "`i": "ì", "`o": "ò", "`u": "ù",
+	"`A": "À", "`E": "È", "`I": "Ì", "`O": "Ò", "`U": "Ù",
+	"^a": "â", "^e": "ê", "^i": "î", "^o": "ô", "^u": "û",
+	"^A": "Â", "^E": "Ê", "^I": "Î", "^O": "Ô", "^U": "Û",
+	"~a": "ã", "~n": "ñ", "~o": "õ", "~A": "Ã", "~N": "Ñ", "~O": "Õ",
+	"ss": "ß", "o": "ø", "O": "Ø", "aa": "å", "AA": "Å", "ae": "æ", "AE": "Æ",
+	"l": "ł", "L": "Ł", "i": "ı",
+}
+
+/* TeX commands dropped by texText, keeping their arguments: those
+ * changing the font, and accents, like \H{o}, that are not replaced
+ */
+var texFormatting = map[string]bool{
+	"emph": true, "textit": true, "textbf": true, "textsc": true, "texttt": true,
+	"textrm": true, "textsf": true, "mbox": true, "url": true,
+	"em": true, "it": true, "bf": true, "sc": true, "tt": true, "rm": true,
+	"H": true, "c": true, "v": true, "u": true, "r": true, "k": true, "d": true, "b": true, "t": true,
+}
+
+/* texText - return the text of a BibTeX value: braces are removed,
+ * accents and escaped special characters replaced by the characters,
+ * and formatting commands dropped; other commands are replaced by
+ * their names, like \TeX
+ */
+func texText(s string) string {
+	var b bytes.Buffer
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '{', c == '}':
+		case c == '~':
+			b.WriteByte(' ')
+		case c != '\\' || i+1 == len(s):
+			b.WriteByte(c)
+		case strings.Index("&%$#_{}\\", s[i+1:i+2]) != -1:
+			i++
+			b.WriteByte(s[i])
+		case strings.Index(`"'`+"`^~", s[i+1:i+2]) != -1:
+			/* an accent, followed by a letter, possibly in braces */
+			accent := s[i+1 : i+2]
+			i += 2
+			for i < len(s) && s[i] == '{' {
+				i++
+			}
+			if i < len(s) {
+				if t, ok := texAccents[accent+s[i:i+1]]; ok {
+					b.WriteString(t)
+				} else {
+					b.WriteByte(s[i])
+				}
+			}
+		default:
+			j := i + 1
+			for j < len(s) && (s[j] >= 'a' && s[j] <= 'z' || s[j] >= 'A' && s[j] <= 'Z') {
+				j++
+			}
+			name := s[i+1 : j]
+			if t, ok := texAccents[name]; ok {
+				b.WriteString(t)
+			} else if !texFormatting[name] {
+				b.WriteString(name)
+			}
+			/* spaces following the name of a command are skipped */
+			for j < len(s) && name != "" && s[j] == ' ' {
+				j++
+			}
+			i = j - 1
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
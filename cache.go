@@ -0,0 +1,184 @@
+package markdown
+
+// Caching the HTML output of documents
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// A Cache stores the HTML output of documents converted by
+// ToHTMLCached, under keys returned by CacheKey.  A Cache shared by
+// goroutines must be safe for concurrent use, like an LRUCache.
+type Cache interface {
+	Get(key string) (html []byte, ok bool)
+	Set(key string, html []byte)
+}
+
+// CacheKey returns a hash of text, the extensions ext, and options,
+// which identifies the other settings affecting the output, as key
+// of a Cache.
+func CacheKey(text []byte, ext Extensions, options string) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "%q %v %d\n", options, ext, len(text))
+	h.Write(text)
+	return fmt.Sprintf("%x", h.Sum())
+}
+
+// ToHTMLCached converts text, parsed with the extensions ext, into
+// HTML, like ParseBytes and WriteHtml, unless c holds the output
+// already.  The output returned must not be modified.
+func ToHTMLCached(c Cache, text []byte, ext Extensions) []byte {
+	key := CacheKey(text, ext, "")
+	if html, ok := c.Get(key); ok {
+		return html
+	}
+	return toHTML(c, key, NewParser(ext).ParseBytes(text), nil)
+}
+
+// CacheKey is like the function CacheKey, hashing the extensions and
+// the options of p, like Smart, References, TabWidth or Limits, too.
+// Functions, like FilterHTML or WikiLink, can't be compared; only
+// whether they are set is part of the key, so options must tell apart
+// different ones, if a Cache is shared by Parsers using them.
+func (p *Parser) CacheKey(text []byte, options string) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "%q %v\n", options, p.ext)
+	p.writeOptions(h)
+	fmt.Fprintf(h, "%d\n", len(text))
+	h.Write(text)
+	return fmt.Sprintf("%x", h.Sum())
+}
+
+/* writeOptions - write the options of p affecting the output to w,
+ * maps sorted by their keys, as their order is random
+ */
+func (p *Parser) writeOptions(w io.Writer) {
+	s := p.Smart
+	if s.Quotes != nil {
+		fmt.Fprintf(w, "quotes %q\n", *s.Quotes)
+		s.Quotes = nil
+	}
+	fmt.Fprintf(w, "smart %v\nemoji %q\n", s, p.Emoji.Images)
+	emoji := make([]string, 0, len(p.Emoji.Map))
+	for k := range p.Emoji.Map {
+		emoji = append(emoji, k)
+	}
+	sort.Strings(emoji)
+	for _, k := range emoji {
+		fmt.Fprintf(w, "%q %q\n", k, p.Emoji.Map[k])
+	}
+	refs := make([]string, 0, len(p.References))
+	for k := range p.References {
+		refs = append(refs, k)
+	}
+	sort.Strings(refs)
+	for _, k := range refs {
+		fmt.Fprintf(w, "ref %q %q %q\n", k, p.References[k].URL, p.References[k].Title)
+	}
+	works := make([]string, 0, len(p.Bibliography))
+	for k := range p.Bibliography {
+		works = append(works, k)
+	}
+	sort.Strings(works)
+	for _, k := range works {
+		if wk := p.Bibliography[k]; wk != nil {
+			fmt.Fprintf(w, "work %q %q\n", k, *wk)
+		}
+	}
+	fmt.Fprintf(w, "templates %q\nhtml %d %d\ntabs %d %v\nnormalize %v\nlimits %v\n",
+		p.TemplateDelimiters, p.HTMLPolicy, p.CommentPolicy,
+		p.TabWidth, p.LiteralTabs, p.NormalizeInput, p.Limits)
+	fmt.Fprintf(w, "funcs %v %v %v %v %v %v\n",
+		p.WikiLink != nil, p.Include != nil, p.FilterHTML != nil,
+		p.FilterComment != nil, p.FilterText != nil, p.Slugger != nil)
+}
+
+// ToHTMLCached is like the function ToHTMLCached, using the extensions
+// and options of p, which are part of the key, see Parser.CacheKey.
+// If setup is not nil, it is called with each document parsed before
+// it is printed, e.g. to set Doc.XHTML.  As setup is not part of the
+// key, options must tell apart the settings the output is made with,
+// if c is used for more than one, like "xhtml".
+func (p *Parser) ToHTMLCached(c Cache, text []byte, options string, setup func(d *Doc)) []byte {
+	key := p.CacheKey(text, options)
+	if html, ok := c.Get(key); ok {
+		return html
+	}
+	return toHTML(c, key, p.ParseBytes(text), setup)
+}
+
+/* toHTML - return the HTML output of d, and store it in c under key,
+ * unless parsing has been stopped by a limit
+ */
+func toHTML(c Cache, key string, d *Doc, setup func(d *Doc)) []byte {
+	if setup != nil {
+		setup(d)
+	}
+	buf := new(bytes.Buffer)
+	d.WriteHtml(buf)
+	html := buf.Bytes()
+	if d.Err() == nil {
+		c.Set(key, html)
+	}
+	return html
+}
+
+// An LRUCache is a Cache in memory holding up to a number of
+// entries; once it is full, the least recently used one is evicted.
+// It is safe for concurrent use.
+type LRUCache struct {
+	mu		sync.Mutex
+	max		int
+	order	*list.List	/* Entries, most recently used first. */
+	entries	map[string]*list.Element
+}
+
+type lruEntry struct {
+	key		string
+	html	[]byte
+}
+
+// NewLRUCache returns an empty LRUCache holding up to max entries.
+func NewLRUCache(max int) *LRUCache {
+	return &LRUCache{max: max, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+func (c *LRUCache) Get(key string) (html []byte, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*lruEntry).html, true
+}
+
+func (c *LRUCache) Set(key string, html []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*lruEntry).html = html
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key, html})
+	for c.order.Len() > c.max {
+		e := c.order.Back()
+		c.order.Remove(e)
+		c.entries[e.Value.(*lruEntry).key] = nil, false
+	}
+}
+
+// Len returns the number of entries held by c.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,46 @@
+package markdown
+
+import (
+	"testing"
+)
+
+// TestParserCacheKey checks that the options of a Parser changing the
+// output change the key of ToHTMLCached.
+func TestParserCacheKey(t *testing.T) {
+	text := []byte("\"Quoted\" -- [link]\n")
+	key := NewParser(Extensions{Smart: true}).CacheKey(text, "")
+	for name, setup := range map[string]func(p *Parser){
+		"Smart":			func(p *Parser) { p.Smart.NoDashes = true },
+		"Quotes":			func(p *Parser) { p.Smart.Quotes = &QuoteStyle{"«", "»", "‹", "›"} },
+		"References":		func(p *Parser) { p.References = map[string]Reference{"link": Reference{URL: "/a"}} },
+		"TabWidth":			func(p *Parser) { p.TabWidth = 8 },
+		"HTMLPolicy":		func(p *Parser) { p.HTMLPolicy = HTMLEscape },
+		"Limits":			func(p *Parser) { p.Limits.Depth = DefaultDepth },
+		"NormalizeInput":	func(p *Parser) { p.NormalizeInput = true },
+		"FilterText":		func(p *Parser) { p.FilterText = func(s string, parents []*Element) string { return s } },
+	} {
+		p := NewParser(Extensions{Smart: true})
+		setup(p)
+		if p.CacheKey(text, "") == key {
+			t.Errorf("%s: key unchanged", name)
+		}
+	}
+
+	refs := make(map[string]Reference)
+	for _, label := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		refs[label] = Reference{URL: "/" + label}
+	}
+	p := NewParser(Extensions{})
+	p.References = refs
+	key = p.CacheKey(text, "")
+	for i := 0; i < 20; i++ {
+		q := NewParser(Extensions{})
+		q.References = make(map[string]Reference)
+		for label, r := range refs {
+			q.References[label] = r
+		}
+		if q.CacheKey(text, "") != key {
+			t.Fatal("key depends on the order of References")
+		}
+	}
+}
@@ -0,0 +1,414 @@
+package markdown
+
+// Citations like [see @smith04, p. 33], and the list of works cited
+
+import (
+	"sort"
+	"strings"
+)
+
+/* a work cited by a citation, with the text around its key;
+ * suppress is set for -@key, which omits the author
+ */
+type citeItem struct {
+	prefix, key, suffix	string
+	suppress			bool
+}
+
+/* citationKey - return the length of the citation key at the start
+ * of s: letters, digits and _, with single punctuation characters
+ * within, or 0
+ */
+func citationKey(s string) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_':
+			n = i + 1
+		case i > 0 && n == i && strings.Index(":.#$%&-+?<>~/", s[i:i+1]) != -1:
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+/* parseCitation - split the text between the brackets of a citation
+ * into its items, separated by semicolons; nil is returned, if one
+ * of them has no key
+ */
+func parseCitation(s string) []citeItem {
+	var items []citeItem
+	for _, t := range strings.Split(s, ";", -1) {
+		var it citeItem
+		for i := 0; i < len(t); i++ {
+			if t[i] != '@' || i > 0 && strings.Index(" \t\n-", t[i-1:i]) == -1 {
+				continue
+			}
+			n := citationKey(t[i+1:])
+			if n == 0 {
+				continue
+			}
+			it.prefix = t[:i]
+			if strings.HasSuffix(it.prefix, "-") {
+				it.prefix = it.prefix[:len(it.prefix)-1]
+				it.suppress = true
+			}
+			it.prefix = strings.TrimSpace(it.prefix)
+			it.key = t[i+1 : i+1+n]
+			it.suffix = strings.TrimSpace(t[i+1+n:])
+			break
+		}
+		if it.key == "" {
+			return nil
+		}
+		items = append(items, it)
+	}
+	return items
+}
+
+/* isCitation - return true if s, a text in brackets, is a citation */
+func isCitation(s string) bool {
+	return parseCitation(s[1:len(s)-1]) != nil
+}
+
+/* textCitation - return true if a citation in running text, a key
+ * of the bibliography preceded by @, starts at pos
+ */
+func (d *Doc) textCitation(pos int) bool {
+	s := d.parser.Buffer
+	if pos > 0 && citationKey(s[pos-1:pos]) == 1 {
+		return false
+	}
+	if !strings.HasPrefix(s[pos:], "@") {
+		return false
+	}
+	n := citationKey(s[pos+1:])
+	return n > 0 && d.bibliography[s[pos+1:pos+1+n]] != nil
+}
+
+/* citation - make a CITATION element for the text s, in brackets, or,
+ * if inText is set, the key following @, and optionally a suffix in
+ * brackets; its children are the formatted citation
+ */
+func (d *Doc) citation(s string, inText bool) *Element {
+	e := mk_element(CITATION)
+	e.contents.str = s
+	var text string
+	if inText {
+		n := citationKey(s[1:])
+		it := citeItem{key: s[1 : 1+n]}
+		if i := strings.Index(s, "["); i != -1 {
+			it.suffix = strings.TrimSpace(s[i+1 : len(s)-1])
+		}
+		w := d.cite(e, it.key)
+		text = authorShort(w, it.key) + " ("
+		it.suppress = true
+		if it.suffix != "" && !strings.HasPrefix(it.suffix, ",") {
+			it.suffix = ", " + it.suffix
+		}
+		text += citeItemText(it, w) + ")"
+	} else {
+		var list []string
+		for _, it := range parseCitation(s[1 : len(s)-1]) {
+			list = append(list, citeItemText(it, d.cite(e, it.key)))
+		}
+		text = "(" + strings.Join(list, "; ") + ")"
+	}
+	e.children = mk_str(text)
+	return e
+}
+
+/* cite - return the work of the bibliography with the specified key,
+ * recording that it is cited, or nil, if there is none
+ */
+func (d *Doc) cite(e *Element, key string) *Work {
+	w := d.bibliography[key]
+	if w == nil {
+		d.warn(e, "undefined citation: "+key)
+		return nil
+	}
+	if d.cited == nil {
+		d.cited = make(map[string]bool)
+	}
+	d.cited[key] = true
+	return w
+}
+
+/* citeItemText - return the text of an item of a citation of the
+ * work w, like "see Smith 2004, p. 33"
+ */
+func citeItemText(it citeItem, w *Work) string {
+	if w == nil {
+		return it.key + "?"
+	}
+	s := it.prefix
+	if !it.suppress {
+		s += " " + authorShort(w, it.key)
+	}
+	s += " " + workYear(w)
+	if it.suffix != "" {
+		if !strings.HasPrefix(it.suffix, ",") {
+			s += " "
+		}
+		s += it.suffix
+	}
+	return strings.TrimSpace(s)
+}
+
+/* authorShort - return the family names of the authors of w, as
+ * printed in a citation
+ */
+func authorShort(w *Work, key string) string {
+	if w == nil {
+		return key + "?"
+	}
+	names := w.Authors
+	if len(names) == 0 {
+		names = w.Editors
+	}
+	switch len(names) {
+	case 0:
+		if w.Title != "" {
+			return w.Title
+		}
+		return key
+	case 1:
+		return names[0].Family
+	case 2:
+		return names[0].Family + " and " + names[1].Family
+	}
+	return names[0].Family + " et al."
+}
+
+func workYear(w *Work) string {
+	if w.Year == "" {
+		return "n.d."
+	}
+	return w.Year
+}
+
+/* addBibliography - add the list of the works cited to the document:
+ * at its end, or into the div with the id "refs", if there is one
+ */
+func (d *Doc) addBibliography() {
+	if len(d.cited) == 0 {
+		return
+	}
+	var works workList
+	for key := range d.cited {
+		works = append(works, d.bibliography[key])
+	}
+	sort.Sort(works)
+	bib := mk_element(BIBLIOGRAPHY)
+	var list *Element
+	for _, w := range works {
+		entry := mk_element(DIV)
+		entry.attr = &Attributes{ID: "ref-" + w.Key, Classes: []string{"csl-entry"}}
+		entry.contents.str = "csl-entry"
+		entry.children = mk_list(PARA, reference(w))
+		list = cons(entry, list)
+	}
+	bib.children = reverse(list)
+	if refs := findDiv(d.tree, "refs"); refs != nil {
+		refs.children = appendElements(refs.children, bib)
+	} else {
+		d.tree = appendElements(d.tree, bib)
+	}
+}
+
+/* findDiv - return the DIV element of list, or of its descendants,
+ * with the specified id, or nil
+ */
+func findDiv(list *Element, id string) *Element {
+	for e := list; e != nil; e = e.next {
+		if e.key == DIV && e.attr != nil && e.attr.ID == id {
+			return e
+		}
+		if div := findDiv(e.children, id); div != nil {
+			return div
+		}
+	}
+	return nil
+}
+
+func appendElements(list, e *Element) *Element {
+	if list == nil {
+		return e
+	}
+	last := list
+	for last.next != nil {
+		last = last.next
+	}
+	last.next = e
+	return list
+}
+
+/* works, sorted by their authors, year, and title */
+type workList []*Work
+
+func (l workList) Len() int			{ return len(l) }
+func (l workList) Swap(i, j int)	{ l[i], l[j] = l[j], l[i] }
+func (l workList) Less(i, j int) bool {
+	a, b := sortKey(l[i]), sortKey(l[j])
+	if a != b {
+		return a < b
+	}
+	return l[i].Key < l[j].Key
+}
+
+func sortKey(w *Work) string {
+	s := ""
+	names := w.Authors
+	if len(names) == 0 {
+		names = w.Editors
+	}
+	for _, n := range names {
+		s += strings.ToLower(n.Family+" "+n.Given) + "\000"
+	}
+	if len(names) == 0 {
+		s = strings.ToLower(w.Title) + "\000"
+	}
+	return s + w.Year + "\000" + strings.ToLower(w.Title)
+}
+
+/* reference - return the reversed list of inline elements of the
+ * entry for w in the list of works cited, in author-date style:
+ *
+ *	Smith, John, and Jane Doe. 2004. "Title." Journal 12 (3): 33–45.
+ */
+func reference(w *Work) *Element {
+	var list *Element
+	text := func(s string) {
+		list = cons(mk_str(s), list)
+	}
+	/* end a part of the entry with a period, unless it has one */
+	period := func(s string) string {
+		if strings.HasSuffix(s, ".") || strings.HasSuffix(s, "?") || strings.HasSuffix(s, "!") {
+			return s
+		}
+		return s + "."
+	}
+	title := func(s string, quoted bool) {
+		if quoted {
+			q := mk_element(DOUBLEQUOTED)
+			q.children = mk_str(period(s))
+			list = cons(q, list)
+		} else {
+			list = cons(mk_list(EMPH, mk_str(s)), list)
+			text(".")
+		}
+	}
+
+	switch {
+	case len(w.Authors) > 0:
+		text(period(nameList(w.Authors, true)) + " " + workYear(w) + ". ")
+	case len(w.Editors) > 0:
+		eds := ", ed. "
+		if len(w.Editors) > 1 {
+			eds = ", eds. "
+		}
+		text(nameList(w.Editors, true) + eds + workYear(w) + ". ")
+	case w.Title != "":
+		title(w.Title, false)
+		text(" " + workYear(w) + ".")
+	default:
+		text(w.Key + ". " + workYear(w) + ".")
+	}
+	part := len(w.Authors) > 0 || len(w.Editors) > 0
+	part = part && w.Container != "" && w.Type != "book"
+	if w.Title != "" && (len(w.Authors) > 0 || len(w.Editors) > 0) {
+		title(w.Title, part)
+	}
+	switch {
+	case !part:
+	case w.Type == "article-journal", w.Type == "article-magazine", w.Type == "article-newspaper":
+		text(" ")
+		list = cons(mk_list(EMPH, mk_str(w.Container)), list)
+		if w.Volume != "" {
+			text(" " + w.Volume)
+		}
+		if w.Issue != "" {
+			text(" (" + w.Issue + ")")
+		}
+		if w.Pages != "" {
+			text(": " + w.Pages)
+		}
+		text(".")
+	default:
+		text(" In ")
+		list = cons(mk_list(EMPH, mk_str(w.Container)), list)
+		if len(w.Editors) > 0 && len(w.Authors) > 0 {
+			text(", edited by " + nameList(w.Editors, false))
+		}
+		if w.Pages != "" {
+			text(", " + w.Pages)
+		}
+		text(".")
+	}
+	switch {
+	case w.Place != "" && w.Publisher != "":
+		text(" " + w.Place + ": " + period(w.Publisher))
+	case w.Publisher != "":
+		text(" " + period(w.Publisher))
+	}
+	url := w.URL
+	if w.DOI != "" {
+		url = "https://doi.org/" + w.DOI
+	}
+	if url != "" {
+		text(" ")
+		list = cons(mk_link(mk_str(url), url, ""), list)
+		text(".")
+	}
+	return list
+}
+
+/* nameList - return names separated by commas, and "and" before the
+ * last one; if inverted is set, the first one is printed family name
+ * first
+ */
+func nameList(names []Name, inverted bool) string {
+	var list []string
+	for i, n := range names {
+		switch {
+		case n.Given == "":
+			list = append(list, n.Family)
+		case i == 0 && inverted:
+			list = append(list, n.Family+", "+n.Given)
+		default:
+			list = append(list, n.Given+" "+n.Family)
+		}
+	}
+	switch n := len(list); {
+	case n == 1:
+		return list[0]
+	case n == 2 && !inverted:
+		return list[0] + " and " + list[1]
+	}
+	return strings.Join(list[:len(list)-1], ", ") + ", and " + list[len(list)-1]
+}
+
+func (w *htmlOut) Citation(source string, entering bool) {
+	if !entering {
+		w.s("</span>")
+		return
+	}
+	var keys []string
+	if strings.HasPrefix(source, "@") {
+		keys = append(keys, source[1:1+citationKey(source[1:])])
+	} else {
+		for _, it := range parseCitation(source[1 : len(source)-1]) {
+			keys = append(keys, it.key)
+		}
+	}
+	w.s(`<span class="citation" data-cites="`).value(strings.Join(keys, " ")).s(`">`)
+}
+
+func (w *htmlOut) Bibliography(entering bool) {
+	if entering {
+		w.pad(2).s(`<div class="references">`).nest(1).nl().pset(2)
+	} else {
+		w.nest(-1).pad(1).s("</div>").pset(0)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+// Embedding and copying local images, see options -embed-images and
+// -copy-assets
+
+import (
+	"../_obj/github.com/knieriem/markdown"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/* assets - how the local images of a document are handled */
+type assets struct {
+	embed	bool	/* Inline images as data: URIs. */
+	copyDir	string	/* If not "", copy images below this directory. */
+}
+
+/* resolver - return a markdown.URLResolver replacing the relative URLs
+ * of the images of the file name, which are local files relative to
+ * its directory, by data: URIs, or by the URLs of their copies; if a
+ * file can't be read or copied, its URL is kept, and the error is
+ * reported to diag
+ */
+func (a *assets) resolver(name string, diag io.Writer) markdown.URLResolver {
+	dir := "."
+	if name != "<stdin>" {
+		dir = filepath.Dir(name)
+	}
+	return func(kind int, url string) string {
+		if kind != markdown.IMAGE {
+			return url
+		}
+		rel := localPath(url)
+		if rel == "" {
+			return url
+		}
+		var err os.Error
+		var s string
+		if a.embed {
+			s, err = dataURI(filepath.Join(dir, rel))
+		} else {
+			s, err = a.copy(dir, rel)
+		}
+		if err != nil {
+			fmt.Fprintf(diag, "%s: %s\n", name, err)
+			return url
+		}
+		return s
+	}
+}
+
+/* localPath - return the file path of the relative URL url, without
+ * query and fragment, or "" if url is absolute, or has a scheme
+ */
+func localPath(url string) string {
+	if i := strings.IndexAny(url, "?#"); i >= 0 {
+		url = url[:i]
+	}
+	switch {
+	case url == "", strings.HasPrefix(url, "/"):
+		return ""
+	}
+	if i := strings.IndexAny(url, ":/"); i > 0 && url[i] == ':' {
+		return ""
+	}
+	return filepath.FromSlash(url)
+}
+
+/* dataURI - return the contents of file as data: URI, with the MIME
+ * type of its suffix
+ */
+func dataURI(file string) (string, os.Error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	typ := mime.TypeByExtension(filepath.Ext(file))
+	if typ == "" {
+		typ = "application/octet-stream"
+	}
+	enc := make([]byte, base64.StdEncoding.EncodedLen(len(b)))
+	base64.StdEncoding.Encode(enc, b)
+	return "data:" + typ + ";base64," + string(enc), nil
+}
+
+/* copy - copy the file rel below dir to the same relative path below
+ * the directory of option -copy-assets, or, if rel leads out of dir,
+ * to its base name there, and return the URL of the copy
+ */
+func (a *assets) copy(dir, rel string) (string, os.Error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, rel))
+	if err != nil {
+		return "", err
+	}
+	rel = filepath.Clean(rel)
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		rel = filepath.Base(rel)
+	}
+	target := filepath.Join(a.copyDir, rel)
+	if err = os.MkdirAll(filepath.Dir(target), 0777); err != nil {
+		return "", err
+	}
+	if err = ioutil.WriteFile(target, b, 0666); err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(target), nil
+}
@@ -0,0 +1,131 @@
+package main
+
+// Converting files concurrently, see option -j
+
+import (
+	"../_obj/github.com/knieriem/markdown"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"sync"
+)
+
+/* a convertFunc parses the text b of the file name, and writes the
+ * output to w, and messages about it to diag; it returns false if the
+ * file has not been converted successfully
+ */
+type convertFunc func(p *markdown.Parser, name string, b []byte, w markdown.Writer, diag io.Writer) (ok bool)
+
+/* exitStatus is set to 1 once a file could not be read or converted,
+ * or, with -strict, has warnings
+ */
+var exitStatus int
+
+/* conversion - a file converted by a batch */
+type conversion struct {
+	name	string
+	path	string	/* The file the input is read from, or "" if text holds it. */
+	target	string	/* The output file, or "" for the output of the batch. */
+	text	[]byte
+	out		bytes.Buffer
+	diag	bytes.Buffer	/* Messages for stderr. */
+	err		os.Error	/* Set if the input could not be read. */
+	ok		bool
+	done	chan bool
+}
+
+/* batch - run the conversions of list, by up to jobs workers, each
+ * using a clone of p; if jobs is 0, GOMAXPROCS workers.  Once a
+ * conversion and those preceding it have finished, its messages are
+ * printed, in the order of list, and finish is called for it, to write
+ * the output.  Files that can't be read are reported, and skipped; the
+ * first error returned by finish stops the batch.
+ */
+func batch(p *markdown.Parser, jobs int, list []*conversion, convert convertFunc, finish func(c *conversion) os.Error) os.Error {
+	if jobs <= 0 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+	if jobs > len(list) {
+		jobs = len(list)
+	}
+	if jobs <= 1 {
+		for _, c := range list {
+			if err := c.run(p, convert, finish); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if runtime.GOMAXPROCS(0) < jobs {
+		runtime.GOMAXPROCS(jobs)
+	}
+
+	/* the queue holds all conversions, so that no worker blocks
+	 * if the batch is stopped early
+	 */
+	queue := make(chan *conversion, len(list))
+	for _, c := range list {
+		c.done = make(chan bool, 1)
+		queue <- c
+	}
+	close(queue)
+	/* the clones share the functions set up by main, like that of
+	 * -allowtags, which keep no state
+	 */
+	var wg sync.WaitGroup
+	for i := 0; i < jobs; i++ {
+		wg.Add(1)
+		go func(p *markdown.Parser) {
+			for c := range queue {
+				c.convert(p, convert)
+				c.done <- true
+			}
+			wg.Done()
+		}(p.Clone())
+	}
+	for _, c := range list {
+		<-c.done
+		if err := c.report(finish); err != nil {
+			return err
+		}
+	}
+	wg.Wait()
+	return nil
+}
+
+/* run - convert and report c, within the goroutine of the batch */
+func (c *conversion) run(p *markdown.Parser, convert convertFunc, finish func(c *conversion) os.Error) os.Error {
+	c.convert(p, convert)
+	return c.report(finish)
+}
+
+func (c *conversion) convert(p *markdown.Parser, convert convertFunc) {
+	if c.path != "" {
+		if c.text, c.err = ioutil.ReadFile(c.path); c.err != nil {
+			return
+		}
+	}
+	c.ok = convert(p, c.name, c.text, &c.out, &c.diag)
+	c.text = nil
+}
+
+/* report - print the messages of c to stderr, and, if the input has
+ * been read, pass it to finish
+ */
+func (c *conversion) report(finish func(c *conversion) os.Error) os.Error {
+	os.Stderr.Write(c.diag.Bytes())
+	if c.err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", os.Args[0], c.err)
+		exitStatus = 1
+		return nil
+	}
+	if !c.ok {
+		exitStatus = 1
+	}
+	err := finish(c)
+	c.out.Reset()
+	return err
+}
@@ -0,0 +1,92 @@
+package main
+
+// Measuring the time and memory conversions take, see option -bench
+
+import (
+	"../_obj/github.com/knieriem/markdown"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"syscall"
+	"time"
+)
+
+/* benchmark - convert each of the files named, or stdin, if there are
+ * none, n times, with the options given, and print the time and the
+ * allocations per conversion, and the peak resident set size of the
+ * program, to stderr, instead of the output.  If cpuProfile or
+ * memProfile are not "", a CPU profile of the conversions, or a heap
+ * profile taken after them is written to these files, for gopprof.
+ */
+func benchmark(names []string, n int, p *markdown.Parser, convert convertFunc, cpuProfile, memProfile string) os.Error {
+	if len(names) == 0 {
+		names = []string{"<stdin>"}
+	}
+	if cpuProfile != "" {
+		f, err := os.Create(cpuProfile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err = pprof.StartCPUProfile(f); err != nil {
+			return err
+		}
+		defer pprof.StopCPUProfile()
+	}
+	var out bytes.Buffer
+	for _, name := range names {
+		var b []byte
+		var err os.Error
+		if name == "<stdin>" {
+			b, err = ioutil.ReadAll(os.Stdin)
+		} else {
+			b, err = ioutil.ReadFile(name)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", os.Args[0], err)
+			exitStatus = 1
+			continue
+		}
+
+		/* a first run, reporting problems once */
+		if !convert(p, name, b, &out, os.Stderr) {
+			exitStatus = 1
+			continue
+		}
+		mallocs, total := memStats()
+		t := time.Nanoseconds()
+		for i := 0; i < n; i++ {
+			out.Reset()
+			convert(p, name, b, &out, ioutil.Discard)
+		}
+		t = time.Nanoseconds() - t
+		mallocs1, total1 := memStats()
+		fmt.Fprintf(os.Stderr, "%s: %d runs, %d ns/op, %.2f MB/s, %d allocs/op, %d B/op\n",
+			name, n, t/int64(n), float64(len(b))*float64(n)/float64(t)*1e3,
+			(mallocs1-mallocs)/uint64(n), (total1-total)/uint64(n))
+	}
+	var ru syscall.Rusage
+	if syscall.Getrusage(syscall.RUSAGE_SELF, &ru) == 0 {
+		fmt.Fprintf(os.Stderr, "%s: peak RSS %d kB\n", os.Args[0], ru.Maxrss)
+	}
+	if memProfile != "" {
+		f, err := os.Create(memProfile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return pprof.WriteHeapProfile(f)
+	}
+	return nil
+}
+
+/* memStats - return the number of allocations so far, and the bytes
+ * allocated
+ */
+func memStats() (mallocs, total uint64) {
+	runtime.UpdateMemStats()
+	return runtime.MemStats.Mallocs, runtime.MemStats.TotalAlloc
+}
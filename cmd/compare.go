@@ -0,0 +1,81 @@
+package main
+
+// Comparing the output with that of another implementation, see
+// option -compare
+
+import (
+	"../_obj/github.com/knieriem/markdown"
+	"../mdtest/_obj/github.com/knieriem/markdown/mdtest"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+/* compare - convert each of the files named, the Markdown files of
+ * the directories named (see mdtest.Cases), or stdin, if there are
+ * none, by convert, and by the command line ref of another
+ * implementation, like "cmark", and print the differences of their
+ * outputs, normalized by mdtest.Normalize, to stderr, followed by the
+ * number of files that differ; exitStatus is set to 1 if there are any
+ */
+func compare(names []string, ref string, p *markdown.Parser, convert convertFunc) {
+	args := strings.Fields(ref)
+	reference := mdtest.Command(args[0], args[1:]...)
+	var files []string
+	for _, name := range names {
+		fi, err := os.Stat(name)
+		if err == nil && fi.IsDirectory() {
+			cases, err := mdtest.Cases(name)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %s\n", os.Args[0], err)
+				exitStatus = 1
+			}
+			for _, c := range cases {
+				files = append(files, c.Input)
+			}
+			continue
+		}
+		files = append(files, name)
+	}
+	if len(names) == 0 {
+		files = []string{"<stdin>"}
+	}
+
+	n, diverged := 0, 0
+	for _, name := range files {
+		var b []byte
+		var err os.Error
+		if name == "<stdin>" {
+			b, err = ioutil.ReadAll(os.Stdin)
+		} else {
+			b, err = ioutil.ReadFile(name)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", os.Args[0], err)
+			exitStatus = 1
+			continue
+		}
+		var out bytes.Buffer
+		if !convert(p, name, b, &out, os.Stderr) {
+			exitStatus = 1
+			continue
+		}
+		expected, err := reference(string(b))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s: %s\n", name, args[0], err)
+			exitStatus = 1
+			continue
+		}
+		n++
+		if d := mdtest.Diff(mdtest.Normalize(expected), mdtest.Normalize(out.String())); d != "" {
+			fmt.Fprintf(os.Stderr, "%s: output differs from %s (-%s +markdown):\n%s", name, args[0], args[0], d)
+			diverged++
+		}
+	}
+	fmt.Fprintf(os.Stderr, "%s: %d of %d files differ from %s\n", os.Args[0], diverged, n, args[0])
+	if diverged > 0 {
+		exitStatus = 1
+	}
+}
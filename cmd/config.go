@@ -0,0 +1,72 @@
+package main
+
+// Options read from a configuration file, see option -config
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"json"
+	"os"
+	"strings"
+)
+
+/* readConfig - set the options not given on the command line to the
+ * members of the JSON object in the file name, which are named like
+ * the options, as in
+ *
+ *	{"t": "html", "smart": true, "tabwidth": 8, "typo": ["arrows", "units"]}
+ *
+ * An array stands for a comma separated list.
+ */
+func readConfig(name string) os.Error {
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	var m map[string]interface{}
+	if err = json.Unmarshal(b, &m); err != nil {
+		return os.NewError(name + ": " + err.String())
+	}
+	given := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { given[f.Name] = true })
+	for key, v := range m {
+		if flag.Lookup(key) == nil || key == "config" {
+			return os.NewError(name + ": unknown option " + key)
+		}
+		if given[key] {
+			continue
+		}
+		s, ok := configValue(v)
+		if !ok || !flag.Set(key, s) {
+			return os.NewError(name + ": invalid value for option " + key)
+		}
+	}
+	return nil
+}
+
+/* configValue - return the text of the value v of an option, as
+ * given on the command line
+ */
+func configValue(v interface{}) (s string, ok bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case bool:
+		return fmt.Sprint(v), true
+	case float64:
+		if v == float64(int64(v)) {
+			return fmt.Sprint(int64(v)), true
+		}
+		return fmt.Sprint(v), true
+	case []interface{}:
+		list := make([]string, len(v))
+		for i, elem := range v {
+			if list[i], ok = elem.(string); !ok {
+				return "", false
+			}
+		}
+		return strings.Join(list, ","), true
+	}
+	return "", false
+}
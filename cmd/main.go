@@ -6,35 +6,802 @@ import (
 	"fmt"
 	"os"
 	"bufio"
+	"strings"
+	"path/filepath"
+	"http"
+	"io"
 	"io/ioutil"
+	"time"
 )
 
-func main() {
-	var b []byte
+/* quotation marks selectable by option -quotes */
+var quotes = map[string]*markdown.QuoteStyle{
+	"en":	markdown.EnglishQuotes,
+	"de":	markdown.GermanQuotes,
+	"fr":	markdown.FrenchQuotes,
+	"sv":	markdown.SwedishQuotes,
+}
+
+/* placements of footnotes selectable by option -noteplace */
+var placements = map[string]int{
+	"end":		markdown.NotesAtEnd,
+	"section":	markdown.NotesPerSection,
+	"marker":	markdown.NotesAtMarker,
+}
+
+/* treatments of raw HTML selectable by option -rawhtml */
+var htmlPolicies = map[string]int{
+	"":			markdown.HTMLDefault,
+	"allow":	markdown.HTMLAllow,
+	"escape":	markdown.HTMLEscape,
+	"drop":		markdown.HTMLDrop,
+}
+
+/* treatments of HTML comments selectable by option -comments */
+var commentPolicies = map[string]int{
+	"":			markdown.CommentsDefault,
+	"keep":		markdown.CommentsKeep,
+	"strip":	markdown.CommentsStrip,
+}
+
+/* obfuscations of mailto: links selectable by option -mailto */
+var mailtoObfuscations = map[string]int{
+	"entities":	markdown.MailtoEntities,
+	"plain":	markdown.MailtoPlain,
+	"mixed":	markdown.MailtoMixed,
+}
+
+/* ways of printing character references selectable by options
+ * -entities and -punctentities
+ */
+var entityPolicies = map[string]int{
+	"named":	markdown.EntitiesNamed,
+	"numeric":	markdown.EntitiesNumeric,
+	"utf8":		markdown.EntitiesUTF8,
+	"escaped":	markdown.EntitiesEscaped,
+}
 
+/* places where documents are split by -t slides, selectable by
+ * option -slidesplit
+ */
+var slideSplits = map[string]int{
+	"rules":	markdown.SlidesAtRules,
+	"headings":	markdown.SlidesAtHeadings,
+	"both":		markdown.SlidesAtRules | markdown.SlidesAtHeadings,
+}
+
+/* kinds of spans whose HTML elements can be replaced by option
+ * -spantags, by the names of their default elements
+ */
+var spanKinds = map[string]int{
+	"em":		markdown.EMPH,
+	"strong":	markdown.STRONG,
+	"code":		markdown.CODE,
+	"del":		markdown.STRIKE,
+	"sup":		markdown.SUPERSCRIPT,
+	"sub":		markdown.SUBSCRIPT,
+}
+
+const pollInterval = 500e6	/* ns between checks for modified files with -watch */
+
+/* file name suffixes of the output formats, used in directory mode */
+var suffix = map[string]string{
+	"html":		".html",
+	"groff-mm":	".mm",
+	"groff":	".mm",
+	"latex":	".tex",
+	"markdown":	".md",
+	"text":		".txt",
+	"flowed":	".txt",
+	"slides":	".html",
+	"ast":		".json",
+	"term":		".txt",
+	"man":		".man",
+	"docbook":	".xml",
+}
+
+func main() {
 	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: %s [FILE]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage: %s [FILE|DIR ...]\n", os.Args[0])
 		flag.PrintDefaults()
 	}
 	optNotes := flag.Bool("notes", false, "turn on footnote syntax")
 	optSmart := flag.Bool("smart", false, "turn on smart quotes, dashes, and ellipses")
+	optQuotes := flag.String("quotes", "", "quotation marks printed with -smart: en, de, fr, sv")
+	optTypo := flag.String("typo", "", "further conversions of -smart, comma separated: fractions, arrows, symbols, french (no-break spaces before ! ? ; :), units (no-break spaces in 10 kg), or nodashes, noellipsis to turn conversions off")
 	optDlists := flag.Bool("dlists", false, "support definitions lists")
+	optListStart := flag.Bool("liststart", false, "start ordered lists at the number of their first item, which may be followed by ) instead of .")
+	optStrictLists := flag.Bool("strictlists", false, "nest list items, and decide whether lists are loose, like CommonMark")
+	optTaskLists := flag.Bool("tasks", false, "render list items starting with [ ] or [x] as checkboxes")
+	optAbbreviations := flag.Bool("abbr", false, "support abbreviations, defined like *[HTML]: HyperText Markup Language")
+	optTables := flag.Bool("tables", false, "support tables")
+	optFenced := flag.Bool("fenced", false, "support fenced code blocks")
+	optFrontMatter := flag.Bool("frontmatter", false, "skip YAML or TOML front matter")
+	optTabWidth := flag.Int("tabwidth", 4, "width of tab stops, used to expand tabs")
+	optLiteralTabs := flag.Bool("literaltabs", false, "keep tabs in code blocks, instead of expanding them into spaces")
+	optNormalize := flag.Bool("normalize", false, "remove byte order marks, convert UTF-16 input into UTF-8, and CR LF or CR line endings into newlines")
+	optInline := flag.Bool("inline", false, "parse the input as span-level content only, like a title, without paragraphs")
+	optSafe := flag.Bool("safe", false, "escape raw HTML, drop unsafe URLs")
+	optFilterHTML := flag.Bool("filterhtml", false, "drop raw HTML, except <style> blocks")
+	optFilterStyles := flag.Bool("filterstyles", false, "drop <style> blocks")
+	optRawHTML := flag.String("rawhtml", "", "treatment of raw HTML, overriding -safe: allow, escape, drop")
+	optAllowTags := flag.String("allowtags", "", "keep the raw HTML tags of these comma separated elements only, like kbd,sup")
+	optComments := flag.String("comments", "", "treatment of HTML comments, overriding -rawhtml: keep, strip")
+	optHeadingIDs := flag.Bool("ids", false, "add ids derived from their text to headings")
+	optAttributes := flag.Bool("attrs", false, "support attribute blocks {#id .class key=value} on headings, code blocks, links, and images")
+	optTOC := flag.Bool("toc", false, "add ids to headings, replace [TOC] by a table of contents")
+	optStrike := flag.Bool("strike", false, "support ~~strikethrough~~")
+	optSupSub := flag.Bool("supsub", false, "support ^superscript^ and ~subscript~")
+	optEmoji := flag.Bool("emoji", false, "replace shortcodes like :smile: by emoji")
+	optEmojiImages := flag.String("emojiimages", "", "with -emoji, print images loaded from this URL in HTML output, %s is replaced by the shortcode")
+	optWikiLinks := flag.Bool("wiki", false, "support wiki links: [[target]] and [[target|label]]")
+	optInclude := flag.String("include", "", "replace lines <!--#include file=\"x.md\"--> and !include x.md by the files named, found below this directory")
+	optAdmonitions := flag.Bool("admonitions", false, "support admonitions: !!! note \"Title\" followed by indented blocks, and > [!NOTE]")
+	optDivs := flag.Bool("divs", false, "support fenced divs: blocks between lines ::: class and :::")
+	optTemplates := flag.Bool("templates", false, "pass spans like {{ .Title }}, {% if x %} and {{< shortcode >}} through unchanged")
+	optTemplateDelims := flag.String("templatedelims", "", "with -templates, comma separated pairs of the delimiters of template spans, like {{,}},<%,%>")
+	optBib := flag.String("bib", "", "support citations like [@key, p. 33] of the works in this bibliography file, CSL JSON (.json) or BibTeX")
+	optHardWraps := flag.Bool("hardwraps", false, "turn each newline within a paragraph into a line break, like in comments on GitHub")
+	optAutolink := flag.Bool("autolink", false, "turn URLs and email addresses in running text into links")
+	optMath := flag.Bool("math", false, "support TeX math: $...$ and $$...$$")
+	optCommonMark := flag.Bool("commonmark", false, "follow CommonMark where it differs from Markdown.pl (partially), implies -fenced, -liststart and -strictlists")
+	optGFM := flag.Bool("gfm", false, "GitHub Flavored Markdown: fenced code, tables, ~~strike~~, bare URLs")
+	optNoteStyle := flag.Bool("notestyle", false, "HTML footnotes with superscript markers and return links")
+	optSourcePos := flag.Bool("sourcepos", false, "give the top level blocks of HTML output data-sourcepos attributes, like 3:1-5:12, holding their lines and columns")
+	optDir := flag.String("dir", "", "the direction printed as dir attribute of HTML paragraphs, headings, list items, etc.: auto, rtl, ltr")
+	optBidi := flag.Bool("bidi", false, "isolate HTML code spans and links from the text around them, for right-to-left text")
+	optPopovers := flag.Bool("popovers", false, "HTML footnote references and links carrying the text of the notes and the titles in data- attributes, for popovers")
+	optPermalinks := flag.String("permalinks", "", "print links of HTML headings with an id to themselves, before or after their text: before, after")
+	optNumbers := flag.Int("numbers", 0, "if not 0, prefix headings of this level and below by section numbers, like 1.2")
+	optNumberSep := flag.String("numbersep", ".", "with -numbers, the separator of the numbers of the levels")
+	optNumberSuffix := flag.String("numbersuffix", "", "with -numbers, the text following the section numbers, like .")
+	optPermalinkSymbol := flag.String("permalinksymbol", "", "with -permalinks, the HTML text of the links (default pilcrow)")
+	optMailto := flag.String("mailto", "entities", "how the addresses of mailto: links are hidden in HTML output: entities (decimal character references), plain, mixed (decimal, hexadecimal, and plain, as by Markdown.pl)")
+	optSpanTags := flag.String("spantags", "", "comma separated replacements of the HTML elements of spans, like em=i,strong=b,code=kbd; del, sup and sub can be replaced as well")
+	optHeadingOffset := flag.Int("headingoffset", 0, "shift the levels of headings by this number, e.g. 2 to print # Title as <h3>")
+	optMaxHeading := flag.Int("maxheading", 0, "if not 0, print headings of deeper levels at this level")
+	optNoteStart := flag.Int("notestart", 1, "number of the first footnote")
+	optNoteSymbols := flag.Bool("notesymbols", false, "mark footnotes by symbols like * and †, instead of numbers")
+	optNotePlace := flag.String("noteplace", "end", "where HTML output prints footnotes: end, section (before each heading of level 1 or 2), marker (at <!-- footnotes -->)")
+	optHtml5 := flag.Bool("html5", false, "HTML5 output: <br> instead of <br />, no align attributes")
+	optUnwrap := flag.Bool("unwrap", false, "print a document consisting of a single paragraph without <p> tags")
+	optBlank := flag.Bool("blank", false, "open external links in a new tab, with target=\"_blank\" rel=\"noopener\"")
+	optUGC := flag.Bool("ugc", false, "mark external links with rel=\"nofollow ugc\", as user generated content")
+	optRel := flag.String("rel", "", "with -ugc, the rel attribute of external links, instead of \"nofollow ugc\"")
+	optLazy := flag.Bool("lazy", false, "let browsers load images lazily, when scrolled into view")
+	optFigures := flag.Bool("figures", false, "print a paragraph consisting of an image only as a figure, with the title as caption")
+	optXHTML := flag.Bool("xhtml", false, "well-formed XHTML output, e.g. for EPUB: numeric character references, closed void elements")
+	optCompact := flag.Bool("compact", false, "HTML output without newlines between block elements")
+	optIndent := flag.Int("indent", 0, "indent nested block elements of HTML output by this number of spaces per level")
+	optExcerpt := flag.Int("excerpt", 0, "if not 0, print only the blocks before a line <!--more-->, or else the first paragraphs, up to this number")
+	optSection := flag.String("section", "", "print only the section of this heading, given by its text or anchor")
+	optNoFinalNewline := flag.Bool("nofinalnewline", false, "HTML output without a newline at the end")
+	optEncodeURLs := flag.Bool("encodeurls", false, "percent-encode spaces and non-ASCII characters in the URLs of HTML links and images")
+	optNumeric := flag.Bool("numericrefs", false, "numeric character references in HTML attribute values, like &#38; instead of &amp;")
+	optEntities := flag.String("entities", "named", "how HTML output prints the character references of the source, like &copy;: named (as written), numeric, utf8 (as characters), escaped (as text)")
+	optPunctEntities := flag.String("punctentities", "named", "how HTML output prints dashes, ellipses and quotation marks of -smart: named, like &hellip;, numeric, utf8")
+	optStandalone := flag.Bool("standalone", false, "print a complete HTML document")
+	optTitle := flag.String("title", "", "title of a standalone HTML document")
+	optCSS := flag.String("css", "", "comma separated URLs of style sheets for a standalone HTML document")
+	optEmbedImages := flag.Bool("embed-images", false, "inline local images as data: URIs, e.g. for a self-contained standalone HTML document")
+	optCopyAssets := flag.String("copy-assets", "", "copy local images below this directory, and refer to the copies")
+	optFormat := flag.String("t", "html", "output format: html, groff-mm, man, latex, docbook, markdown, text, flowed (format=flowed plain text, for emails), slides (HTML sections for reveal.js), term (styled for terminals), ast (the document tree as JSON)")
+	optWrap := flag.Int("wrap", 0, "with -t markdown or flowed, wrap paragraphs at this column (flowed: 0 for 66); -1: don't break lines")
+	optSetext := flag.Bool("setext", false, "with -t markdown, underline headings of level 1 and 2")
+	optBullet := flag.String("bullet", "-", "with -t markdown, the marker of bullet list items")
+	optURLs := flag.Bool("urls", false, "with -t text, print the destination of links after their text")
+	optSlideSplit := flag.String("slidesplit", "rules", "with -t slides, where slides start: rules (at horizontal rules, which are dropped), headings (at headings of level 1 and 2), both")
+	optBook := flag.Bool("book", false, "parse the FILE arguments as chapters of a book, sharing link definitions, footnote numbers, and heading ids")
+	optOutput := flag.String("o", "", "write the output of FILE arguments to this file instead of stdout")
+	optRecursive := flag.Bool("r", false, "without arguments, convert the current directory")
+	optDestDir := flag.String("d", "", "in directory mode, write the output files below this directory")
+	optWatch := flag.Bool("watch", false, "convert again whenever an input file changes")
+	optJobs := flag.Int("j", 0, "number of files converted at the same time; 0: GOMAXPROCS")
+	optWarn := flag.Bool("w", false, "print warnings about undefined references and similar problems to stderr")
+	optStrict := flag.Bool("strict", false, "like -w, but exit with status 1 if there are warnings")
+	optCheck := flag.Bool("check", false, "only check for undefined or unused references and notes, duplicate ids, and links to undefined #fragments; exit with status 1 if there are problems")
+	optStats := flag.Bool("stats", false, "print the number of words, headings per level, links, etc. and the reading time to stderr")
+	optMaxSize := flag.Int("maxsize", 0, "if not 0, the maximum length of a document in bytes")
+	optMaxDepth := flag.Int("maxdepth", 0, "if not 0, the maximum depth of the parser's recursion, counting nested elements like block quotes, lists, or emphasis, and unclosed brackets or emphasis markers; see markdown.DefaultDepth")
+	optTimeout := flag.Int("timeout", 0, "if not 0, the maximum time in milliseconds spent parsing a document")
+	optBench := flag.Int("bench", 0, "if not 0, convert each input this number of times, and print the time and allocations per conversion instead of the output")
+	optCPUProfile := flag.String("cpuprofile", "", "with -bench, write a CPU profile of the conversions to this file")
+	optMemProfile := flag.String("memprofile", "", "with -bench, write a heap profile to this file")
+	optCompare := flag.String("compare", "", "convert the inputs by this command line of another implementation as well, like cmark, and print the differences of the normalized HTML to stderr")
+	optServe := flag.String("serve", "", "serve the Markdown files of DIR, or the current directory, over HTTP at this address, e.g. :8080")
+	optConfig := flag.String("config", "", "read the options not given on the command line from this JSON file, like {\"smart\": true, \"t\": \"latex\"}")
 	flag.Parse()
+	if *optConfig != "" {
+		if err := readConfig(*optConfig); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", os.Args[0], err)
+			os.Exit(2)
+		}
+	}
 
-	if flag.NArg() > 0 {
-		b, _ = ioutil.ReadFile(flag.Arg(0))
-	} else {
-		b, _ = ioutil.ReadAll(os.Stdin)
+	if _, ok := suffix[*optFormat]; !ok {
+		fmt.Fprintf(os.Stderr, "%s: unknown output format: %s\n", os.Args[0], *optFormat)
+		os.Exit(2)
 	}
 
 	e := markdown.Extensions{
 		Notes: *optNotes,
 		Smart: *optSmart,
 		Dlists: *optDlists,
+		Tables: *optTables,
+		TaskLists: *optTaskLists,
+		ListStart: *optListStart,
+		StrictLists: *optStrictLists,
+		Abbreviations: *optAbbreviations,
+		FencedCode: *optFenced,
+		FrontMatter: *optFrontMatter,
+		Safe: *optSafe,
+		FilterHTML: *optFilterHTML,
+		FilterStyles: *optFilterStyles,
+		TOC: *optTOC,
+		HeadingIDs: *optHeadingIDs,
+		Attributes: *optAttributes,
+		GFM: *optGFM,
+		Strike: *optStrike,
+		Autolink: *optAutolink,
+		HardWraps: *optHardWraps,
+		SupSub: *optSupSub,
+		Emoji: *optEmoji,
+		WikiLinks: *optWikiLinks,
+		Admonitions: *optAdmonitions,
+		FencedDivs: *optDivs,
+		Templates: *optTemplates,
+		Citations: *optBib != "",
+		Math: *optMath,
+		CommonMark: *optCommonMark,
+	}
+	p := markdown.NewParser(e)
+	p.Emoji.Images = *optEmojiImages
+	if *optBib != "" {
+		p.Bibliography = readBibliography(*optBib)
+	}
+	if *optInclude != "" {
+		p.Include = markdown.IncludeDir(*optInclude)
+	}
+	p.TabWidth = *optTabWidth
+	p.LiteralTabs = *optLiteralTabs
+	p.NormalizeInput = *optNormalize
+	if *optTemplateDelims != "" {
+		p.TemplateDelimiters = strings.Split(*optTemplateDelims, ",", -1)
+		if len(p.TemplateDelimiters)%2 != 0 {
+			fmt.Fprintf(os.Stderr, "%s: -templatedelims needs pairs of delimiters\n", os.Args[0])
+			os.Exit(2)
+		}
+	}
+	p.Limits = markdown.Limits{Size: *optMaxSize, Depth: *optMaxDepth, Time: int64(*optTimeout) * 1e6}
+	notePlace, ok := placements[*optNotePlace]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "%s: unknown footnote placement: %s\n", os.Args[0], *optNotePlace)
+		os.Exit(2)
+	}
+	if p.HTMLPolicy, ok = htmlPolicies[*optRawHTML]; !ok {
+		fmt.Fprintf(os.Stderr, "%s: unknown raw HTML treatment: %s\n", os.Args[0], *optRawHTML)
+		os.Exit(2)
+	}
+	if *optAllowTags != "" {
+		p.HTMLPolicy = markdown.HTMLFilter
+		p.FilterHTML = markdown.AllowTags(strings.Split(*optAllowTags, ",", -1)...)
+	}
+	if *optPermalinks != "" && *optPermalinks != "before" && *optPermalinks != "after" {
+		fmt.Fprintf(os.Stderr, "%s: unknown permalink position: %s\n", os.Args[0], *optPermalinks)
+		os.Exit(2)
+	}
+	mailto, ok := mailtoObfuscations[*optMailto]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "%s: unknown mailto obfuscation: %s\n", os.Args[0], *optMailto)
+		os.Exit(2)
+	}
+	entities, ok := entityPolicies[*optEntities]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "%s: unknown entity policy: %s\n", os.Args[0], *optEntities)
+		os.Exit(2)
+	}
+	punctEntities, ok := entityPolicies[*optPunctEntities]
+	if !ok || punctEntities == markdown.EntitiesEscaped {
+		fmt.Fprintf(os.Stderr, "%s: unknown entity policy: %s\n", os.Args[0], *optPunctEntities)
+		os.Exit(2)
+	}
+	slideSplit, ok := slideSplits[*optSlideSplit]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "%s: unknown slide split: %s\n", os.Args[0], *optSlideSplit)
+		os.Exit(2)
+	}
+	switch *optDir {
+	case "", "auto", "rtl", "ltr":
+	default:
+		fmt.Fprintf(os.Stderr, "%s: unknown direction: %s\n", os.Args[0], *optDir)
+		os.Exit(2)
+	}
+	var spanTags map[int]string
+	if *optSpanTags != "" {
+		spanTags = make(map[int]string)
+		for _, pair := range strings.Split(*optSpanTags, ",", -1) {
+			i := strings.Index(pair, "=")
+			if i == -1 || i == len(pair)-1 || spanKinds[pair[:i]] == 0 {
+				fmt.Fprintf(os.Stderr, "%s: invalid span element replacement: %s\n", os.Args[0], pair)
+				os.Exit(2)
+			}
+			spanTags[spanKinds[pair[:i]]] = pair[i+1:]
+		}
+	}
+	if p.CommentPolicy, ok = commentPolicies[*optComments]; !ok {
+		fmt.Fprintf(os.Stderr, "%s: unknown comment treatment: %s\n", os.Args[0], *optComments)
+		os.Exit(2)
+	}
+	if *optQuotes != "" {
+		if p.Smart.Quotes = quotes[*optQuotes]; p.Smart.Quotes == nil {
+			fmt.Fprintf(os.Stderr, "%s: unknown quote style: %s\n", os.Args[0], *optQuotes)
+			os.Exit(2)
+		}
+	}
+
+	for _, name := range strings.Split(*optTypo, ",", -1) {
+		switch name {
+		case "":
+		case "fractions":
+			p.Smart.Fractions = true
+		case "arrows":
+			p.Smart.Arrows = true
+		case "symbols":
+			p.Smart.Symbols = true
+		case "french":
+			p.Smart.French = true
+		case "units":
+			p.Smart.Units = true
+		case "nodashes":
+			p.Smart.NoDashes = true
+		case "noellipsis":
+			p.Smart.NoEllipsis = true
+		default:
+			fmt.Fprintf(os.Stderr, "%s: unknown typographic conversion: %s\n", os.Args[0], name)
+			os.Exit(2)
+		}
+	}
+
+	var book *markdown.Session	/* set while converting the FILE arguments with -book */
+	convert := func(p *markdown.Parser, name string, b []byte, w markdown.Writer, diag io.Writer) (ok bool) {
+		var doc *markdown.Doc
+		switch {
+		case *optInline:
+			doc = p.ParseInline(string(b))
+		case book != nil:
+			doc = book.Parse(string(b))
+		default:
+			doc = p.ParseBytes(b)
+		}
+		if err := doc.Err(); err != nil {
+			fmt.Fprintf(diag, "%s: %s\n", name, err)
+			return false
+		}
+		if *optSection != "" {
+			if doc = doc.Section(*optSection); doc == nil {
+				fmt.Fprintf(diag, "%s: no section %s\n", name, *optSection)
+				return false
+			}
+		}
+		ok = true
+		if *optExcerpt != 0 {
+			doc, _ = doc.Excerpt(*optExcerpt)
+		}
+		if *optCheck {
+			for _, problem := range doc.Validate() {
+				fmt.Fprintf(diag, "%s:%d: %s\n", name, problem.Line, problem.Msg)
+				ok = false
+			}
+			return
+		}
+		if *optWarn || *optStrict {
+			for _, warning := range doc.Warnings() {
+				fmt.Fprintf(diag, "%s:%d: %s\n", name, warning.Line, warning.Msg)
+				ok = !*optStrict
+			}
+		}
+		if *optStats {
+			st := doc.Stats()
+			fmt.Fprintf(diag, "%s: %d words, %d characters, %d min reading time, headings %v, %d links, %d images, %d code blocks\n",
+				name, st.Words, st.Chars, st.ReadingTime, st.Headings, st.Links, st.Images, st.CodeBlocks)
+		}
+		if *optNoteStyle {
+			doc.NoteStyle = new(markdown.NoteStyle)
+		}
+		doc.Popovers = *optPopovers
+		doc.SourcePos = *optSourcePos
+		doc.Dir = *optDir
+		doc.BidiIsolate = *optBidi
+		if *optNumbers > 0 {
+			doc.Numbering = &markdown.HeadingNumbering{Start: *optNumbers, Separator: *optNumberSep, Suffix: *optNumberSuffix}
+		}
+		if *optPermalinks != "" {
+			doc.Permalink = &markdown.Permalink{Symbol: *optPermalinkSymbol, Before: *optPermalinks == "before"}
+		}
+		if book != nil {
+			doc.NoteStart += *optNoteStart - 1
+		} else {
+			doc.NoteStart = *optNoteStart
+		}
+		doc.NoteSymbols = *optNoteSymbols
+		doc.SpanTags = spanTags
+		doc.MailtoObfuscation = mailto
+		doc.HeadingOffset = *optHeadingOffset
+		doc.MaxHeadingLevel = *optMaxHeading
+		doc.NotePlacement = notePlace
+		doc.Html5 = *optHtml5
+		doc.NoObsolete = *optHtml5
+		doc.XHTML = *optXHTML
+		doc.UnwrapPara = *optUnwrap
+		doc.CompactHTML = *optCompact
+		doc.IndentHTML = strings.Repeat(" ", *optIndent)
+		doc.NoFinalNewline = *optNoFinalNewline
+		if *optEncodeURLs {
+			doc.URLEscaping = markdown.URLEncode
+		}
+		doc.NumericEntities = *optNumeric
+		doc.SourceEntities = entities
+		doc.PunctuationEntities = punctEntities
+		doc.Figures = *optFigures
+		doc.LazyImages = *optLazy
+		doc.TargetBlankExternal = *optBlank
+		doc.RelNofollowExternal = *optUGC
+		doc.ExternalRel = *optRel
+		if *optEmbedImages || *optCopyAssets != "" {
+			a := &assets{embed: *optEmbedImages, copyDir: *optCopyAssets}
+			doc.ResolveURL = a.resolver(name, diag)
+		}
+		switch *optFormat {
+		case "html":
+			if !*optStandalone {
+				doc.WriteHtml(w)
+				break
+			}
+			page := &markdown.Page{Title: *optTitle}
+			if *optCSS != "" {
+				page.CSS = strings.Split(*optCSS, ",", -1)
+			}
+			if _, err := doc.WriteHtmlDocument(w, page); err != nil {
+				fmt.Fprintf(diag, "%s: %s\n", name, err)
+				return false
+			}
+		case "slides":
+			doc.WriteSlides(w, slideSplit)
+		case "groff-mm", "groff":
+			doc.WriteGroffMm(w)
+		case "man":
+			doc.WriteMan(w, manPage(name, doc))
+		case "latex":
+			doc.WriteLatex(w)
+		case "docbook":
+			doc.WriteDocBook(w)
+		case "markdown":
+			doc.WriteMarkdown(w, &markdown.MarkdownStyle{Setext: *optSetext, Bullet: *optBullet, Wrap: *optWrap})
+		case "text":
+			doc.WriteText(w, *optURLs)
+		case "flowed":
+			doc.WriteFlowed(w, *optWrap)
+		case "term":
+			doc.WriteTerm(w)
+		case "ast":
+			doc.WriteAST(w)
+		}
+		return
+	}
+
+	args := flag.Args()
+	output := *optOutput
+	if *optCheck {
+		output = ""	/* nothing is written */
+	}
+	if *optServe != "" {
+		h := &markdown.Handler{Root: ".", Ext: e, Limits: p.Limits, Reload: *optWatch}
+		if len(args) > 0 {
+			h.Root = args[0]
+		}
+		h.Page = &markdown.Page{Title: *optTitle}
+		if *optCSS != "" {
+			h.Page.CSS = strings.Split(*optCSS, ",", -1)
+		}
+		fatal(http.ListenAndServe(*optServe, h))
+	}
+	if strings.TrimSpace(*optCompare) != "" {
+		compare(args, *optCompare, p, convert)
+		os.Exit(exitStatus)
+	}
+	if *optBench > 0 {
+		if err := benchmark(args, *optBench, p, convert, *optCPUProfile, *optMemProfile); err != nil {
+			fatal(err)
+		}
+		os.Exit(exitStatus)
+	}
+	if len(args) == 0 && *optRecursive {
+		args = []string{"."}
+	}
+	if len(args) == 0 {
+		if *optWatch {
+			fmt.Fprintf(os.Stderr, "%s: -watch needs FILE or DIR arguments\n", os.Args[0])
+			os.Exit(2)
+		}
+		b, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			fatal(err)
+		}
+		if err = convertFiles(output, nil, b, p, 1, convert); err != nil {
+			fatal(err)
+		}
+		os.Exit(exitStatus)
 	}
 
-	doc := markdown.Parse(string(b), e)
-	w := bufio.NewWriter(os.Stdout)
-	doc.WriteHtml(w)
-	w.Flush()
+	var files, dirs []string
+	for _, name := range args {
+		fi, err := os.Stat(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", os.Args[0], err)
+			exitStatus = 1
+			continue
+		}
+		if fi.IsDirectory() {
+			dirs = append(dirs, name)
+		} else {
+			files = append(files, name)
+		}
+	}
+	if len(dirs) > 0 && *optFormat == "markdown" && *optDestDir == "" && !*optCheck {
+		/* the output files would replace the input files */
+		fmt.Fprintf(os.Stderr, "%s: -t markdown needs -d in directory mode\n", os.Args[0])
+		os.Exit(2)
+	}
+
+	/* convert the inputs modified after time since */
+	build := func(since int64) (n int, err os.Error) {
+		if modified(files, since) {
+			if *optBook {
+				if book, err = newBook(p, files); err != nil {
+					return
+				}
+			}
+			jobs := *optJobs
+			if book != nil {
+				jobs = 1	/* the chapters are numbered on one after another */
+			}
+			err = convertFiles(output, files, nil, p, jobs, convert)
+			book = nil
+			if err != nil {
+				return
+			}
+			n += len(files)
+		}
+		for _, dir := range dirs {
+			var m int
+			if *optCheck {
+				m, err = checkDir(dir, since, p, *optJobs, convert)
+			} else {
+				m, err = convertDir(dir, *optDestDir, suffix[*optFormat], since, p, *optJobs, convert)
+			}
+			n += m
+			if err != nil {
+				return n, err
+			}
+		}
+		return
+	}
+
+	t := time.Nanoseconds()
+	if _, err := build(0); err != nil {
+		fatal(err)
+	}
+	if !*optWatch {
+		os.Exit(exitStatus)
+	}
+	for {
+		time.Sleep(pollInterval)
+		t0 := time.Nanoseconds()
+		n, err := build(t)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", os.Args[0], err)
+		}
+		if n > 0 {
+			fmt.Fprintf(os.Stderr, "%s: %d file(s) converted in %.1f ms\n",
+				time.LocalTime().Format(time.Kitchen), n, float64(time.Nanoseconds()-t0)/1e6)
+		}
+		t = t0
+	}
+}
+
+/* newBook - return a Session for the chapters in the files named,
+ * knowing the link definitions of all of them
+ */
+func newBook(p *markdown.Parser, names []string) (*markdown.Session, os.Error) {
+	s := markdown.NewSession(p)
+	for _, name := range names {
+		b, err := ioutil.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		s.Define(string(b))
+	}
+	return s, nil
+}
+
+/* convertFiles - convert the files named, or text, if there are no
+ * names, by up to jobs workers, writing the output to the file out, or
+ * to stdout, in the order of the names.
+ */
+func convertFiles(out string, names []string, text []byte, p *markdown.Parser, jobs int, convert convertFunc) os.Error {
+	f := os.Stdout
+	if out != "" {
+		var err os.Error
+		if f, err = os.Create(out); err != nil {
+			return err
+		}
+		defer f.Close()
+	}
+	w := bufio.NewWriter(f)
+	var list []*conversion
+	if names == nil {
+		list = append(list, &conversion{name: "<stdin>", text: text})
+	}
+	for _, name := range names {
+		list = append(list, &conversion{name: name, path: name})
+	}
+	err := batch(p, jobs, list, convert, func(c *conversion) os.Error {
+		_, err := w.Write(c.out.Bytes())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+/* modified - report whether any of the files has been modified
+ * after time since, or can't be examined
+ */
+func modified(names []string, since int64) bool {
+	for _, name := range names {
+		fi, err := os.Stat(name)
+		if err != nil || fi.Mtime_ns > since {
+			return true
+		}
+	}
+	return false
+}
+
+/* convertDir - convert each Markdown file found below dir, which has
+ * been modified after time since, into a file with the same name, but
+ * the suffix of the output format, by up to jobs workers.  If destDir
+ * is not empty, the output files are placed below it, at the same
+ * relative paths, otherwise next to the Markdown files.  The number of
+ * files converted is returned.
+ */
+func convertDir(dir, destDir, suffix string, since int64, p *markdown.Parser, jobs int, convert convertFunc) (n int, err os.Error) {
+	list, err := findFiles(dir, "", destDir, suffix, since, nil)
+	if err != nil {
+		return
+	}
+	err = batch(p, jobs, list, convert, func(c *conversion) os.Error {
+		if err := os.MkdirAll(filepath.Dir(c.target), 0777); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(c.target, c.out.Bytes(), 0666); err != nil {
+			return err
+		}
+		n++
+		return nil
+	})
+	return
+}
+
+/* checkDir - like convertDir, but for -check, which writes no output
+ * files
+ */
+func checkDir(dir string, since int64, p *markdown.Parser, jobs int, convert convertFunc) (n int, err os.Error) {
+	list, err := findFiles(dir, "", "", "", since, nil)
+	if err != nil {
+		return
+	}
+	err = batch(p, jobs, list, convert, func(c *conversion) os.Error {
+		n++
+		return nil
+	})
+	return
+}
+
+/* findFiles - return found with the conversions of the files below
+ * dir/rel, which convertDir is to convert, appended
+ */
+func findFiles(dir, rel, destDir, suffix string, since int64, found []*conversion) ([]*conversion, os.Error) {
+	d, err := os.Open(filepath.Join(dir, rel))
+	if err != nil {
+		return found, err
+	}
+	list, err := d.Readdir(-1)
+	d.Close()
+	if err != nil {
+		return found, err
+	}
+	for i := range list {
+		fi := &list[i]
+		name := filepath.Join(rel, fi.Name)
+		switch {
+		case strings.HasPrefix(fi.Name, "."):
+			/* skip hidden files, and directories like .git */
+		case fi.IsDirectory():
+			if found, err = findFiles(dir, name, destDir, suffix, since, found); err != nil {
+				return found, err
+			}
+		case isMarkdown(fi.Name) && fi.Mtime_ns > since:
+			target := name[:len(name)-len(filepath.Ext(name))] + suffix
+			if destDir != "" {
+				target = filepath.Join(destDir, target)
+			} else {
+				target = filepath.Join(dir, target)
+			}
+			path := filepath.Join(dir, name)
+			found = append(found, &conversion{name: path, path: path, target: target})
+		}
+	}
+	return found, nil
+}
+
+/* manPage - take the name and section of a man page from the name of
+ * its source file, like "prog.1.md", unless they are set in the front
+ * matter
+ */
+func manPage(file string, doc *markdown.Doc) *markdown.ManPage {
+	page := new(markdown.ManPage)
+	if file == "<stdin>" {
+		return page
+	}
+	name := filepath.Base(file)
+	name = name[:len(name)-len(filepath.Ext(name))]
+	if ext := filepath.Ext(name); len(ext) > 1 && ext[1] >= '0' && ext[1] <= '9' {
+		page.Section = ext[1:]
+		name = name[:len(name)-len(ext)]
+	}
+	page.Name = name
+	if m := doc.Meta(); m != nil {
+		if m.Values["title"] != "" {
+			page.Name = ""
+		}
+		if m.Values["section"] != "" {
+			page.Section = ""
+		}
+	}
+	return page
+}
+
+/* readBibliography - read a bibliography file, in CSL JSON format,
+ * if its name ends with .json, otherwise in BibTeX format
+ */
+func readBibliography(name string) markdown.Bibliography {
+	f, err := os.Open(name)
+	if err != nil {
+		fatal(err)
+	}
+	defer f.Close()
+	var b markdown.Bibliography
+	if filepath.Ext(name) == ".json" {
+		b, err = markdown.ReadCSLJSON(f)
+	} else {
+		b, err = markdown.ReadBibTeX(f)
+	}
+	if err != nil {
+		fatal(os.NewError(name + ": " + err.String()))
+	}
+	return b
+}
+
+func isMarkdown(name string) bool {
+	switch filepath.Ext(name) {
+	case ".md", ".markdown", ".mdown", ".mkd":
+		return true
+	}
+	return false
+}
+
+func fatal(err os.Error) {
+	fmt.Fprintf(os.Stderr, "%s: %s\n", os.Args[0], err)
+	os.Exit(1)
 }
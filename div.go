@@ -0,0 +1,63 @@
+package markdown
+
+// Fenced divs, like ::: class ... :::
+
+import (
+	"strings"
+)
+
+/* divInfo - return the attribute block for the text following the
+ * colons of the opening fence of a div, up to the end of the line: a
+ * class name, or an attribute block, optionally followed by colons
+ */
+func divInfo(s string) (attr string, ok bool) {
+	if i := strings.IndexAny(s, "\r\n"); i != -1 {
+		s = s[:i]
+	}
+	s = strings.TrimRight(strings.TrimRight(s, " \t:"), " \t")
+	switch {
+	case s == "":
+		return "", false
+	case s[0] == '{':
+		if !strings.HasSuffix(s, "}") || strings.Index(s[1:len(s)-1], "}") != -1 {
+			return "", false
+		}
+		attr = s[1 : len(s)-1]
+		return attr, parseAttributes(attr) != nil
+	case strings.IndexAny(s, " \t{}:") != -1:
+		return "", false
+	}
+	return "." + s, true
+}
+
+/* isDivStart - return true if the text following the colons of a
+ * fence starts a div
+ */
+func isDivStart(s string) bool {
+	_, ok := divInfo(s)
+	return ok
+}
+
+/* fencedDiv - make a DIV element for the info following the opening
+ * fence, and the lines of its contents
+ */
+func (d *Doc) fencedDiv(info *Element, body string) *Element {
+	e := mk_element(DIV)
+	attr, _ := divInfo(info.contents.str)
+	d.setAttributes(e, mk_str(attr))
+	e.contents.str = strings.Join(e.attr.Classes, " ")
+	if body != "" {
+		/* parsed by processRawBlocks */
+		e.children = mk_str(body + "\n")
+		e.children.key = RAW
+	}
+	return e
+}
+
+func (w *htmlOut) Div(class string, entering bool) {
+	if entering {
+		w.pad(2).s("<div").position().attributes(true).s(">").nest(1).nl().pset(2)
+	} else {
+		w.nest(-1).pad(1).s("</div>").pset(0)
+	}
+}
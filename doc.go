@@ -6,14 +6,11 @@ Usage example:
 	import (
 		md "markdown"
 		"os"
-		"io/ioutil"
 		"bufio"
 	)
 
 	func main() {
-		b, _ := ioutil.ReadAll(os.Stdin)
-
-		doc := md.Parse(string(b), md.Extensions{Smart: true})
+		doc, _ := md.ParseReader(os.Stdin, md.Extensions{Smart: true})
 
 		w := bufio.NewWriter(os.Stdout)
 		doc.WriteHtml(w)	
@@ -0,0 +1,448 @@
+package markdown
+
+// DocBook output functions
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type docbookOut struct {
+	Writer
+	sections	[]int	/* Levels of the headings of the open sections. */
+	depth		int		/* Nesting level of lists and block quotes. */
+	head		int		/* 1 within the head of a table, 2 after its start tag. */
+	entry		int		/* In a definition list: 1 within terms, 2 within the definitions. */
+	outer		[]int	/* States of enclosing definition lists. */
+}
+
+// WriteDocBook prints a document tree as a DocBook 5 article to the
+// specified Writer.  Each heading starts a section, which contains
+// the following blocks, up to the next heading of the same or a
+// higher level.
+// It returns the number of bytes written, and the first error
+// returned by w, if any, like WriteHtml.
+//
+func (d *Doc) WriteDocBook(w Writer) (n int, err os.Error) {
+	out := new(docbookOut)
+	dest := &errWriter{w: w}
+	out.Writer = dest
+	out.s(`<?xml version="1.0" encoding="utf-8"?>` + "\n")
+	out.s(`<article xmlns="http://docbook.org/ns/docbook" xmlns:xlink="http://www.w3.org/1999/xlink" version="5.0">` + "\n")
+	if m := d.Meta(); m != nil && m.Values["title"] != "" {
+		out.s("<info><title>").str(m.Values["title"]).s("</title></info>\n")
+	}
+	d.Render(out)
+	out.closeSections(1)
+	out.s("</article>\n")
+	return dest.n, dest.err
+}
+
+// print a string
+func (w *docbookOut) s(s string) *docbookOut {
+	w.WriteString(s)
+	return w
+}
+
+/* print string, escaping for XML
+ */
+func (w *docbookOut) str(s string) *docbookOut {
+	i0 := 0
+	for i := 0; i < len(s); i++ {
+		var ws string
+		switch s[i] {
+		case '&':
+			ws = "&amp;"
+		case '<':
+			ws = "&lt;"
+		case '>':
+			ws = "&gt;"
+		case '"':
+			ws = "&quot;"
+		default:
+			continue
+		}
+		w.WriteString(s[i0:i])
+		w.WriteString(ws)
+		i0 = i + 1
+	}
+	w.WriteString(s[i0:])
+	return w
+}
+
+// print a start or end tag
+func (w *docbookOut) tag(name string, entering bool) *docbookOut {
+	if entering {
+		return w.s("<" + name + ">")
+	}
+	if i := strings.Index(name, " "); i != -1 {
+		name = name[:i]
+	}
+	return w.s("</" + name + ">")
+}
+
+// print a start or end tag of a block containing blocks
+func (w *docbookOut) block(name string, entering bool) {
+	if entering {
+		w.s("<" + name + ">\n")
+		w.depth++
+	} else {
+		w.depth--
+		w.tag(name, false).s("\n")
+	}
+}
+
+/* closeSections - end the open sections of the specified and deeper
+ * levels
+ */
+func (w *docbookOut) closeSections(level int) {
+	for n := len(w.sections); n > 0 && w.sections[n-1] >= level; n-- {
+		w.s("</section>\n")
+		w.sections = w.sections[:n-1]
+	}
+}
+
+/* Renderer methods
+ */
+
+func (w *docbookOut) Str(s string) {
+	w.str(s)
+}
+
+func (w *docbookOut) Space(s string) {
+	w.s(s)
+}
+
+func (w *docbookOut) LineBreak() {
+	w.s("\n")
+}
+
+func (w *docbookOut) Ellipsis() {
+	w.s("…")
+}
+
+func (w *docbookOut) EmDash() {
+	w.s("—")
+}
+
+func (w *docbookOut) EnDash() {
+	w.s("–")
+}
+
+func (w *docbookOut) Apostrophe() {
+	w.s("’")
+}
+
+func (w *docbookOut) SingleQuoted(entering bool) {
+	if entering {
+		w.s("‘")
+	} else {
+		w.s("’")
+	}
+}
+
+func (w *docbookOut) DoubleQuoted(entering bool) {
+	w.tag("quote", entering)
+}
+
+func (w *docbookOut) Code(s string) {
+	w.s("<literal>").str(s).s("</literal>")
+}
+
+func (w *docbookOut) Math(s string, display bool) {
+	if display {
+		w.s("<informalequation><mathphrase>").str(s).s("</mathphrase></informalequation>")
+	} else {
+		w.s("<inlineequation><mathphrase>").str(s).s("</mathphrase></inlineequation>")
+	}
+}
+
+func (w *docbookOut) Html(s string) {
+	/* print entities only */
+	if strings.HasPrefix(s, "&") {
+		if t := entityText(s); t != "" {
+			s = t
+		}
+		w.str(s)
+	}
+}
+
+func (w *docbookOut) Format() string {
+	return "docbook"
+}
+
+func (w *docbookOut) Raw(s string) {
+	w.s(s)
+}
+
+func (w *docbookOut) Link(url, title string, entering bool) {
+	if !entering {
+		w.s("</link>")
+		return
+	}
+	if len(url) > 0 && url[0] == '#' {
+		w.s(`<link linkend="`).str(url[1:]).s(`">`)
+	} else {
+		w.s(`<link xlink:href="`).str(url).s(`">`)
+	}
+}
+
+func (w *docbookOut) Image(url, title string, entering bool) {
+	if entering {
+		w.s(`<inlinemediaobject><imageobject><imagedata fileref="`).str(url).s(`"/></imageobject>`)
+		w.s("<textobject><phrase>")
+	} else {
+		w.s("</phrase></textobject></inlinemediaobject>")
+	}
+}
+
+func (w *docbookOut) Emph(entering bool) {
+	w.tag("emphasis", entering)
+}
+
+func (w *docbookOut) Strong(entering bool) {
+	w.tag(`emphasis role="strong"`, entering)
+}
+
+func (w *docbookOut) Strike(entering bool) {
+	w.tag(`emphasis role="strikethrough"`, entering)
+}
+
+func (w *docbookOut) Superscript(entering bool) {
+	w.tag("superscript", entering)
+}
+
+func (w *docbookOut) Subscript(entering bool) {
+	w.tag("subscript", entering)
+}
+
+func (w *docbookOut) Abbr(title string, entering bool) {
+	w.tag("abbrev", entering)
+}
+
+func (w *docbookOut) Emoji(name, s string) {
+	w.str(s)
+}
+
+func (w *docbookOut) Citation(source string, entering bool) {
+	/* print the formatted citation only */
+}
+
+func (w *docbookOut) Note(n int, body func()) {
+	/* collect the body, to put an inline note into a para */
+	outer := w.Writer
+	b := new(bytes.Buffer)
+	w.Writer = b
+	body()
+	w.Writer = outer
+	s := strings.TrimSpace(b.String())
+	if !strings.HasPrefix(s, "<") || strings.HasPrefix(s, "</") {
+		s = "<para>" + s + "</para>"
+	}
+	w.s("<footnote>").s(s).s("</footnote>")
+}
+
+func (w *docbookOut) Heading(level int, id string, entering bool) {
+	if w.depth > 0 {
+		/* sections can't be nested into other blocks */
+		w.tag("bridgehead", entering)
+		if !entering {
+			w.s("\n")
+		}
+		return
+	}
+	if !entering {
+		w.s("</title>\n")
+		return
+	}
+	w.closeSections(level)
+	w.sections = append(w.sections, level)
+	w.s("<section")
+	if id != "" {
+		w.s(` xml:id="`).str(id).s(`"`)
+	}
+	w.s(">\n<title>")
+}
+
+func (w *docbookOut) Plain(entering bool) {
+	w.Para(entering)
+}
+
+func (w *docbookOut) Para(entering bool) {
+	w.tag("para", entering)
+	if !entering {
+		w.s("\n")
+	}
+}
+
+func (w *docbookOut) Figure(caption string, entering bool) {
+	w.Para(entering)
+}
+
+func (w *docbookOut) HRule() {
+	/* not supported */
+}
+
+func (w *docbookOut) HtmlBlock(s string) {
+	/* don't print HTML block */
+}
+
+func (w *docbookOut) Verbatim(s, lang string) {
+	w.s("<programlisting")
+	if lang != "" {
+		w.s(` language="`).str(lang).s(`"`)
+	}
+	w.s(">").str(s).s("</programlisting>\n")
+}
+
+func (w *docbookOut) BlockQuote(entering bool) {
+	w.block("blockquote", entering)
+}
+
+func (w *docbookOut) Admonition(kind string, entering bool) {
+	name := strings.Fields(kind + " note")[0]
+	switch name {
+	case "note", "tip", "important", "warning", "caution":
+	case "danger", "error":
+		name = "warning"
+	default:
+		name = "note"
+	}
+	w.block(name, entering)
+}
+
+func (w *docbookOut) AdmonitionTitle(entering bool) {
+	w.tag("title", entering)
+	if !entering {
+		w.s("\n")
+	}
+}
+
+func (w *docbookOut) Div(class string, entering bool) {
+	/* print the contents only */
+}
+
+func (w *docbookOut) Bibliography(entering bool) {
+	/* print the entries only */
+}
+
+func (w *docbookOut) BulletList(entering bool) {
+	w.block("itemizedlist", entering)
+}
+
+func (w *docbookOut) OrderedList(start int, delim string, entering bool) {
+	if start != 1 {
+		w.block(`orderedlist startingnumber="`+strconv.Itoa(start)+`"`, entering)
+		return
+	}
+	w.block("orderedlist", entering)
+}
+
+func (w *docbookOut) ListItem(entering bool) {
+	w.block("listitem", entering)
+}
+
+func (w *docbookOut) TaskItem(done bool, entering bool) {
+	if done {
+		w.block(`listitem role="task done"`, entering)
+	} else {
+		w.block(`listitem role="task"`, entering)
+	}
+}
+
+/* endEntry - finish the terms, or the definitions, of an entry of
+ * a definition list
+ */
+func (w *docbookOut) endEntry(next int) {
+	switch {
+	case w.entry == next:
+		return
+	case w.entry == 2:
+		w.s("</listitem>\n</varlistentry>\n")
+	case w.entry == 1:
+		w.s("<listitem>\n")
+	}
+	if next == 1 {
+		w.s("<varlistentry>\n")
+	}
+	w.entry = next
+}
+
+func (w *docbookOut) DefinitionList(entering bool) {
+	if entering {
+		w.outer = append(w.outer, w.entry)
+		w.entry = 0
+	} else {
+		w.endEntry(0)
+		w.entry = w.outer[len(w.outer)-1]
+		w.outer = w.outer[:len(w.outer)-1]
+	}
+	w.block("variablelist", entering)
+}
+
+func (w *docbookOut) DefTitle(entering bool) {
+	if entering {
+		w.endEntry(1)
+		w.s("<term>")
+	} else {
+		w.s("</term>\n")
+	}
+}
+
+func (w *docbookOut) DefData(entering bool) {
+	if entering {
+		w.endEntry(2)
+	}
+}
+
+func (w *docbookOut) Table(align []string, entering bool) {
+	if !entering {
+		w.s("</tgroup>\n</informaltable>\n")
+		return
+	}
+	w.s(`<informaltable><tgroup cols="` + strconv.Itoa(len(align)) + `">` + "\n")
+	for i, a := range align {
+		w.s(`<colspec colname="c` + strconv.Itoa(i+1) + `"`)
+		if a != "" {
+			w.s(` align="` + a + `"`)
+		}
+		w.s("/>\n")
+	}
+}
+
+func (w *docbookOut) TableHead(entering bool) {
+	if entering {
+		w.head = 1
+		return
+	}
+	if w.head == 2 {
+		w.s("</thead>\n")
+	}
+	w.head = 0
+}
+
+func (w *docbookOut) TableBody(entering bool) {
+	w.tag("tbody", entering).s("\n")
+}
+
+func (w *docbookOut) TableRow(entering bool) {
+	if entering && w.head == 1 {
+		/* thead must not be empty */
+		w.s("<thead>\n")
+		w.head = 2
+	}
+	w.block("row", entering)
+}
+
+func (w *docbookOut) TableCell(align string, header bool, entering bool) {
+	w.tag("entry", entering)
+	if !entering {
+		w.s("\n")
+	}
+}
+
+func (w *docbookOut) TOC(toc []*TOCItem) {
+	w.s("<toc/>\n")
+}
@@ -0,0 +1,283 @@
+package markdown
+
+// Serialization of document trees
+
+import (
+	"gob"
+	"io"
+	"os"
+	"strconv"
+)
+
+/* treeVersion - the version of the format written by Encode */
+const treeVersion = 1
+
+/* docCode - a document, as written by Encode: elements and links
+ * refer to each other by their index in Elements and Links plus 1,
+ * or 0 for nil, so that shared ones, like the contents of a footnote
+ * referred to, stay shared, and kinds by their names, so that the
+ * format doesn't depend on the values of the constants, or on the
+ * order plugins have been registered in
+ */
+type docCode struct {
+	Version			int
+	Kinds			[]string	/* Names of the kinds used, see KindName. */
+	Elements		[]elementCode
+	Links			[]linkCode
+	Tree			int
+	References		int
+	Notes			int
+	Abbreviations	int
+	Ext				Extensions
+	Smart			SmartOptions
+	Emoji			EmojiOptions
+	Meta			*Meta
+	Spans			[]int
+	Blocks			[]int
+	Warnings		[]warningCode
+	Cited			[]string
+}
+
+type elementCode struct {
+	Kind					int	/* Index in Kinds. */
+	Str						string
+	Link, Children, Next	int
+	Line, EndLine			int
+	Col, EndCol				int
+	Attr					*Attributes
+	Loose					bool
+}
+
+type linkCode struct {
+	Label		int
+	URL, Title	string
+	Ref			int
+}
+
+type warningCode struct {
+	Element, Kind, Seq	int
+	Msg					string
+}
+
+// Encode writes the document tree to w, in gob format, so that it
+// can be stored, e.g. in a cache on disk, or sent to another process,
+// and printed in any format after Decode without parsing the text
+// again.  Besides the tree, the link definitions, footnotes, front
+// matter, warnings, and the extensions and options of the Parser
+// affecting the output, like Parser.Smart, are written.  The exported
+// fields of Doc, like XHTML, are not; they are set by the program
+// printing the document.
+func (d *Doc) Encode(w io.Writer) os.Error {
+	e := &treeEncoder{
+		elems:	make(map[*Element]int),
+		links:	make(map[*link]int),
+		kinds:	make(map[int]int),
+	}
+	c := &e.code
+	c.Version = treeVersion
+	c.Tree = e.elem(d.tree)
+	c.References = e.elem(d.references)
+	c.Notes = e.elem(d.notes)
+	c.Abbreviations = e.elem(d.abbreviations)
+	for _, wr := range d.warnings {
+		c.Warnings = append(c.Warnings, warningCode{e.elem(wr.e), wr.kind, wr.seq, wr.msg})
+	}
+
+	/* elem only numbers the elements, which are written here, in
+	 * order, so that long lists don't nest calls
+	 */
+	for i := 0; i < len(e.queue); i++ {
+		el := e.queue[i]
+		c.Elements = append(c.Elements, elementCode{
+			Kind:		e.kind(el.key),
+			Str:		el.contents.str,
+			Link:		e.link(el.contents.link),
+			Children:	e.elem(el.children),
+			Next:		e.elem(el.next),
+			Line:		el.line,
+			EndLine:	el.endLine,
+			Col:		el.col,
+			EndCol:		el.endCol,
+			Attr:		el.attr,
+			Loose:		el.loose,
+		})
+	}
+	c.Ext = d.extension
+	c.Smart = d.smart
+	c.Emoji = d.emoji
+	c.Meta = d.meta
+	c.Spans = d.spans
+	c.Blocks = d.blocks
+	for key := range d.cited {
+		c.Cited = append(c.Cited, key)
+	}
+	return gob.NewEncoder(w).Encode(c)
+}
+
+type treeEncoder struct {
+	code	docCode
+	elems	map[*Element]int
+	queue	[]*Element	/* The elements numbered, in order. */
+	links	map[*link]int
+	kinds	map[int]int
+}
+
+/* elem - return the number of el, numbering it if it is new */
+func (e *treeEncoder) elem(el *Element) int {
+	if el == nil {
+		return 0
+	}
+	if n, ok := e.elems[el]; ok {
+		return n
+	}
+	e.queue = append(e.queue, el)
+	e.elems[el] = len(e.queue)
+	return len(e.queue)
+}
+
+/* link - return the number of l, writing it if it is new */
+func (e *treeEncoder) link(l *link) int {
+	if l == nil {
+		return 0
+	}
+	if n, ok := e.links[l]; ok {
+		return n
+	}
+	c := &e.code
+	c.Links = append(c.Links, linkCode{URL: l.url, Title: l.title})
+	n := len(c.Links)
+	e.links[l] = n
+	label := e.elem(l.label)
+	ref := e.link(l.ref)
+	c.Links[n-1].Label = label
+	c.Links[n-1].Ref = ref
+	return n
+}
+
+/* kind - return the index of the name of kind in Kinds */
+func (e *treeEncoder) kind(key int) int {
+	if n, ok := e.kinds[key]; ok {
+		return n
+	}
+	e.code.Kinds = append(e.code.Kinds, (&Element{key: key}).KindName())
+	n := len(e.code.Kinds) - 1
+	e.kinds[key] = n
+	return n
+}
+
+// Decode reads a document tree written by Encode from r.  The kinds
+// of the elements of plugins are found by the names of the plugins,
+// which must have been registered.  The document returned can be
+// printed like one returned by a Parser, and passed to Reparse.
+func Decode(r io.Reader) (*Doc, os.Error) {
+	var c docCode
+	if err := gob.NewDecoder(r).Decode(&c); err != nil {
+		return nil, err
+	}
+	if c.Version != treeVersion {
+		return nil, os.NewError("markdown: unknown version " + strconv.Itoa(c.Version) + " of a document tree")
+	}
+	kinds, err := decodeKinds(c.Kinds)
+	if err != nil {
+		return nil, err
+	}
+
+	elems := make([]Element, len(c.Elements))
+	links := make([]link, len(c.Links))
+	bad := false
+	elem := func(n int) *Element {
+		if n < 0 || n > len(elems) {
+			bad = true
+			return nil
+		}
+		if n == 0 {
+			return nil
+		}
+		return &elems[n-1]
+	}
+	linkOf := func(n int) *link {
+		if n < 0 || n > len(links) {
+			bad = true
+			return nil
+		}
+		if n == 0 {
+			return nil
+		}
+		return &links[n-1]
+	}
+	for i, ec := range c.Elements {
+		if ec.Kind < 0 || ec.Kind >= len(kinds) {
+			bad = true
+			break
+		}
+		el := &elems[i]
+		el.key = kinds[ec.Kind]
+		el.contents.str = ec.Str
+		el.contents.link = linkOf(ec.Link)
+		el.children = elem(ec.Children)
+		el.next = elem(ec.Next)
+		el.line, el.endLine = ec.Line, ec.EndLine
+		el.col, el.endCol = ec.Col, ec.EndCol
+		el.attr = ec.Attr
+		el.loose = ec.Loose
+	}
+	for i, lc := range c.Links {
+		l := &links[i]
+		l.label = elem(lc.Label)
+		l.url, l.title = lc.URL, lc.Title
+		l.ref = linkOf(lc.Ref)
+	}
+
+	d := new(Doc)
+	d.tree = elem(c.Tree)
+	d.references = elem(c.References)
+	d.notes = elem(c.Notes)
+	d.abbreviations = elem(c.Abbreviations)
+	for _, wc := range c.Warnings {
+		if e := elem(wc.Element); e != nil {
+			d.warnings = append(d.warnings, warning{e, wc.Kind, wc.Msg, wc.Seq})
+		}
+	}
+	if bad {
+		return nil, os.NewError("markdown: invalid document tree")
+	}
+	d.extension = c.Ext
+	d.smart = c.Smart
+	d.emoji = c.Emoji
+	d.meta = c.Meta
+	d.spans = c.Spans
+	d.blocks = c.Blocks
+	if len(c.Cited) > 0 {
+		d.cited = make(map[string]bool)
+		for _, key := range c.Cited {
+			d.cited[key] = true
+		}
+	}
+	d.templates = DefaultTemplateDelimiters
+	d.plugins = registered()
+	return d, nil
+}
+
+/* decodeKinds - return the kinds of the names of Element.KindName */
+func decodeKinds(names []string) ([]int, os.Error) {
+	byName := make(map[string]int)
+	for key, name := range keynames {
+		if name != "" {
+			byName[name] = key
+		}
+	}
+	if set := registered(); set != nil {
+		for i, pl := range set.list {
+			byName[pl.Name] = numVAL + i
+		}
+	}
+	kinds := make([]int, len(names))
+	for i, name := range names {
+		key, ok := byName[name]
+		if !ok {
+			return nil, os.NewError("markdown: unknown kind of elements: " + name)
+		}
+		kinds[i] = key
+	}
+	return kinds, nil
+}
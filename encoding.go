@@ -0,0 +1,126 @@
+package markdown
+
+// Normalizing the input, see Parser.NormalizeInput: byte order marks,
+// UTF-16, and line endings
+
+import (
+	"bytes"
+	"utf16"
+)
+
+var (
+	bomUTF8		= []byte{0xef, 0xbb, 0xbf}
+	bomUTF16LE	= []byte{0xff, 0xfe}
+	bomUTF16BE	= []byte{0xfe, 0xff}
+)
+
+/* byte orders of UTF-16 input */
+const (
+	notUTF16	= iota
+	littleEndian
+	bigEndian
+)
+
+/* normalizer - convert the input of a preformatter, which may be
+ * written in several pieces
+ */
+type normalizer struct {
+	on		bool
+	start	bool	/* True until the byte order mark, if any, has been read. */
+	cr		bool	/* True if the last piece ended with a CR. */
+	order	int		/* The byte order of UTF-16 input. */
+	rest	[]byte	/* Bytes of the last piece left for the next one. */
+	b		bytes.Buffer
+}
+
+func (n *normalizer) reset(on bool) {
+	n.on = on
+	n.start = true
+	n.cr = false
+	n.order = notUTF16
+	n.rest = nil
+}
+
+/* normalize - return the next piece of the input, text, with a byte
+ * order mark at the start of the input removed, UTF-16 converted into
+ * UTF-8, and CR LF and CR line endings replaced by LF; final is set
+ * after the last piece, to return the bytes left
+ */
+func (n *normalizer) normalize(text []byte, final bool) []byte {
+	if len(n.rest) > 0 {
+		text = append(n.rest, text...)
+		n.rest = nil
+	}
+	if n.start {
+		if !final && len(text) < len(bomUTF8) && (bytes.HasPrefix(bomUTF8, text) || bytes.HasPrefix(bomUTF16LE, text) || bytes.HasPrefix(bomUTF16BE, text)) {
+			n.rest = append([]byte(nil), text...)
+			return nil
+		}
+		n.start = false
+		switch {
+		case bytes.HasPrefix(text, bomUTF8):
+			text = text[len(bomUTF8):]
+		case bytes.HasPrefix(text, bomUTF16LE):
+			n.order = littleEndian
+			text = text[len(bomUTF16LE):]
+		case bytes.HasPrefix(text, bomUTF16BE):
+			n.order = bigEndian
+			text = text[len(bomUTF16BE):]
+		}
+	}
+	if n.order != notUTF16 {
+		text = n.decode(text, final)
+	}
+	return n.lines(text)
+}
+
+/* decode - convert UTF-16 text into UTF-8; an odd byte, or the first
+ * half of a surrogate pair, at the end is kept for the next piece
+ */
+func (n *normalizer) decode(text []byte, final bool) []byte {
+	u := make([]uint16, 0, len(text)/2)
+	for i := 0; i+1 < len(text); i += 2 {
+		if n.order == littleEndian {
+			u = append(u, uint16(text[i])|uint16(text[i+1])<<8)
+		} else {
+			u = append(u, uint16(text[i])<<8|uint16(text[i+1]))
+		}
+	}
+	used := 2 * len(u)
+	if !final && len(u) > 0 && u[len(u)-1] >= 0xd800 && u[len(u)-1] < 0xdc00 {
+		u = u[:len(u)-1]
+		used -= 2
+	}
+	n.b.Reset()
+	for _, c := range utf16.Decode(u) {
+		n.b.WriteRune(c)
+	}
+	if used < len(text) {
+		if final {
+			n.b.WriteRune(0xfffd)
+		} else {
+			n.rest = append([]byte(nil), text[used:]...)
+		}
+	}
+	return n.b.Bytes()
+}
+
+/* lines - return text with CR LF and CR replaced by LF */
+func (n *normalizer) lines(text []byte) []byte {
+	if !n.cr && bytes.IndexByte(text, '\r') == -1 {
+		return text
+	}
+	t := make([]byte, 0, len(text))
+	for _, c := range text {
+		switch {
+		case c == '\r':
+			t = append(t, '\n')
+		case c == '\n' && n.cr:
+			/* the LF of a CR LF */
+		default:
+			t = append(t, c)
+		}
+		n.cr = c == '\r'
+	}
+	return t
+}
@@ -0,0 +1,65 @@
+package markdown
+
+// Escaping of URLs and attribute values in HTML output
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Values of Doc.URLEscaping.
+const (
+	URLAsIs		= iota	// printed as written, only escaped for HTML, like & as &amp;
+	URLEncode			// percent-encoded where needed, like spaces as %20, see EncodeURL
+)
+
+// EncodeURL returns url with spaces, control characters, the bytes
+// of non-ASCII characters, and characters not allowed in URLs, like
+// < and ", percent-encoded, e.g. as %20. Escapes present already,
+// like %C3%A4, are kept, as are reserved characters like ? and #.
+func EncodeURL(url string) string {
+	const hex = "0123456789ABCDEF"
+	b := make([]byte, 0, len(url))
+	for i := 0; i < len(url); i++ {
+		c := url[i]
+		switch {
+		case c == '%' && i+2 < len(url) && isHexDigit(url[i+1]) && isHexDigit(url[i+2]):
+		case c <= ' ' || c >= 0x7f || c == '%' || strings.Index("\"<>\\^`{|}", url[i:i+1]) != -1:
+			b = append(b, '%', hex[c>>4], hex[c&15])
+			continue
+		}
+		b = append(b, c)
+	}
+	return string(b)
+}
+
+func isHexDigit(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+/* numericEntity - return the character reference s, like &amp;,
+ * as a numeric one
+ */
+func numericEntity(s string) string {
+	if c, ok := htmlEntities[s[1:len(s)-1]]; ok {
+		return "&#" + strconv.Itoa(c) + ";"
+	}
+	return s
+}
+
+// print an attribute value, escaped for HTML
+func (w *htmlOut) value(s string) *htmlOut {
+	in := w.inAttr
+	w.inAttr = true
+	w.str(s)
+	w.inAttr = in
+	return w
+}
+
+// print a URL as an attribute value
+func (w *htmlOut) url(url string) *htmlOut {
+	if w.urlEscaping == URLEncode {
+		url = EncodeURL(url)
+	}
+	return w.value(url)
+}
@@ -0,0 +1,130 @@
+package markdown
+
+// Excerpts of documents, like the summaries of blog posts
+
+import (
+	"strings"
+)
+
+// MoreMarker is the HTML block ending the excerpt of a document.
+const MoreMarker = "<!--more-->"
+
+// Excerpt returns the beginning of the document as a document of its
+// own, e.g. as the summary of a blog post: the blocks before a line
+// <!--more--> (see MoreMarker), if there is one, or else those up to
+// the n-th paragraph. more reports whether anything has been left out.
+func (d *Doc) Excerpt(n int) (excerpt *Doc, more bool) {
+	if list, ok := d.beforeMore(); ok {
+		return d.part(list), true
+	}
+	var list []*Element
+	for e := d.tree; e != nil; e = e.next {
+		if n <= 0 {
+			return d.part(list), printed(e)
+		}
+		list = append(list, e)
+		if e.key == PARA {
+			n--
+		}
+	}
+	return d.part(list), false
+}
+
+// ExcerptSentences returns the beginning of the document like
+// Excerpt, but up to the end of the n-th sentence of its paragraphs.
+// A sentence ends with a word ending in ., ! or ?, followed by a
+// space, or with the paragraph.
+func (d *Doc) ExcerptSentences(n int) (excerpt *Doc, more bool) {
+	if list, ok := d.beforeMore(); ok {
+		return d.part(list), true
+	}
+	var list []*Element
+	for e := d.tree; e != nil; e = e.next {
+		if n <= 0 {
+			return d.part(list), printed(e)
+		}
+		if e.key != PARA {
+			list = append(list, e)
+			continue
+		}
+		inlines, k := sentences(e.children, n)
+		if inlines == nil {
+			list = append(list, e)
+			n -= k
+			continue
+		}
+		p := *e
+		p.children = inlines
+		return d.part(append(list, &p)), true
+	}
+	return d.part(list), false
+}
+
+/* beforeMore - return the top level elements before the more marker,
+ * and whether there is one
+ */
+func (d *Doc) beforeMore() ([]*Element, bool) {
+	var list []*Element
+	for e := d.tree; e != nil; e = e.next {
+		if e.key == HTMLBLOCK && isMoreMarker(e.contents.str) {
+			return list, true
+		}
+		list = append(list, e)
+	}
+	return nil, false
+}
+
+/* isMoreMarker - report whether an HTML block is <!--more-->, allowing
+ * for spaces and upper case letters, like <!-- More -->
+ */
+func isMoreMarker(html string) bool {
+	s := strings.ToLower(strings.TrimSpace(html))
+	if !strings.HasPrefix(s, "<!--") || !strings.HasSuffix(s, "-->") {
+		return false
+	}
+	return strings.TrimSpace(s[4:len(s)-3]) == "more"
+}
+
+/* printed - report whether a list contains elements printed, other
+ * than link and abbreviation definitions, and footnotes
+ */
+func printed(list *Element) bool {
+	for e := list; e != nil; e = e.next {
+		switch {
+		case e.key == REFERENCE, e.key == ABBREVIATION:
+		case e.key == NOTE && e.contents.str != "":
+		case e.key == LIST && e.children == nil:
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+/* sentences - return copies of the inline elements of list up to the
+ * end of its n-th sentence, and the number of sentences of list; the
+ * elements are nil, unless the n-th sentence ends before the list
+ */
+func sentences(list *Element, n int) (*Element, int) {
+	k := 0
+	var head []*Element
+	for e := list; e != nil; e = e.next {
+		head = append(head, e)
+		if e.next == nil || e.next.key != SPACE && e.next.key != LINEBREAK || !endsSentence(e) || !printed(e.next.next) {
+			continue
+		}
+		if k++; k == n {
+			return chain(head), k
+		}
+	}
+	return nil, k + 1
+}
+
+/* endsSentence - report whether an inline element ends a sentence */
+func endsSentence(e *Element) bool {
+	if e.key != STR {
+		return false
+	}
+	s := strings.TrimRight(e.contents.str, ")\"'")
+	return s != "" && strings.Index(".!?", s[len(s)-1:]) != -1
+}
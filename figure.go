@@ -0,0 +1,77 @@
+package markdown
+
+// Figures, and the size of images
+
+import (
+	"strings"
+)
+
+/* figure - return the image, if e is a paragraph consisting of an
+ * image only, and Doc.Figures is set, or nil
+ */
+func (w *walker) figure(e *Element) *Element {
+	if !w.d.Figures {
+		return nil
+	}
+	var img *Element
+	for c := e.children; c != nil; c = c.next {
+		switch {
+		case c.key == SPACE:
+		case c.key == IMAGE && img == nil:
+			img = c
+		default:
+			return nil
+		}
+	}
+	return img
+}
+
+/* imageSize - return the attributes of an image, with a width or
+ * height given in other units than pixels, like 50%, moved into its
+ * style, as HTML allows a number of pixels only
+ */
+func imageSize(a *Attributes) *Attributes {
+	if a == nil {
+		return nil
+	}
+	b := *a
+	b.Attrs = nil
+	style := ""
+	for _, kv := range a.Attrs {
+		if kv.Key == "width" || kv.Key == "height" {
+			n := len(kv.Value) - len(strings.TrimLeft(kv.Value, "0123456789."))
+			switch unit := kv.Value[n:]; {
+			case n == 0:
+			case unit == "" || unit == "px":
+				kv.Value = kv.Value[:n]
+			case unit == "%", unit == "em", unit == "rem", unit == "ex", unit == "vw", unit == "vh",
+				unit == "cm", unit == "mm", unit == "in", unit == "pt", unit == "pc":
+				style += kv.Key + ":" + kv.Value + ";"
+				continue
+			}
+		}
+		b.Attrs = append(b.Attrs, kv)
+	}
+	if style == "" {
+		return a
+	}
+	for i, kv := range b.Attrs {
+		if kv.Key == "style" {
+			b.Attrs[i].Value = strings.TrimRight(kv.Value, "; ") + ";" + style
+			return &b
+		}
+	}
+	b.Attrs = append(b.Attrs, Attr{"style", style})
+	return &b
+}
+
+func (w *htmlOut) Figure(caption string, entering bool) {
+	if entering {
+		w.pad(2).s("<figure").position().s(">").nest(1).nl()
+		return
+	}
+	if caption != "" {
+		w.nl().s("<figcaption>").str(caption).s("</figcaption>")
+	}
+	w.nest(-1).nl().s("</figure>").pset(0)
+}
@@ -0,0 +1,40 @@
+package markdown
+
+// Filtering the text of a document, see Parser.FilterText
+
+/* filterTexts - replace the text of the STR elements of list, and of
+ * those below it, by the results of d.filterText; parents are the
+ * elements containing list.  Adjacent STR elements are joined first.
+ * The contents of a note, which are shared by its references and its
+ * definition, are filtered once, where they are found first.
+ */
+func (d *Doc) filterTexts(list *Element, parents []*Element) {
+	for e := list; e != nil; e = e.next {
+		switch e.key {
+		case STR:
+			for e.next != nil && e.next.key == STR {
+				e.contents.str += e.next.contents.str
+				e.next = e.next.next
+			}
+			e.contents.str = d.filterText(e.contents.str, parents)
+			continue
+		case CODE, HTML, VERBATIM, HTMLBLOCK, MATH, DISPLAYMATH:
+			continue
+		case NOTE:
+			if e.children != nil {
+				if d.filtered == nil {
+					d.filtered = make(map[*Element]bool)
+				}
+				if d.filtered[e.children] {
+					continue
+				}
+				d.filtered[e.children] = true
+			}
+		}
+		p := append(parents, e)
+		if l := e.Label(); l != nil {
+			d.filterTexts(l, p)
+		}
+		d.filterTexts(e.children, p)
+	}
+}
@@ -0,0 +1,565 @@
+package markdown
+
+// Plain text output in format=flowed, for the text/plain parts of emails
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// FlowedWidth is the width of the lines printed by WriteFlowed, if it
+// is passed a width of 0, as recommended by RFC 3676.
+const FlowedWidth = 66
+
+type flowedOut struct {
+	Writer
+	width	int
+
+	buf		bytes.Buffer	/* Inline text of the current block. */
+	frames	[]*mdFrame	/* Block quotes, with first and rest ">", and indented blocks. */
+	lists	[]mdList
+	started	bool	/* True after the first line has been printed. */
+	quoted	int		/* Number of quote marks of the last line. */
+	blank	bool	/* True if an empty line is needed before the next block. */
+	notes	[]func()
+	symbols	bool	/* Mark notes by symbols, instead of numbers. */
+	opened	[]int	/* Offsets of the open links and images in buf. */
+	urls	[]string			/* Destinations of the links, in order of their numbers. */
+	linkNum	map[string]int	/* Numbers of the destinations. */
+	first	int				/* Number of the first link. */
+
+	row		[]string
+	rows	[][]string
+	nhead	int
+}
+
+// WriteFlowed prints a document tree as plain text to the specified
+// Writer, in the format=flowed of RFC 3676, e.g. as the text/plain
+// alternative of an email generated from Markdown: the lines of
+// paragraphs end with a space where a mail reader may join them
+// again, to fit its window.  They are wrapped at width, or, if width
+// is 0, at FlowedWidth, or, if it is less than 0, not at all.
+//
+// Block quotes are quoted by ">", list items marked by "*" or their
+// numbers, emphasis is printed as _text_, strong emphasis as *text*,
+// and headings of level 1 and 2 are underlined.  Links and images are
+// followed by a number in brackets, like footnotes are, and their
+// destinations are listed by their numbers at the end, after the
+// notes.  Code blocks, tables, and the lines of indented blocks, like
+// list items, are not flowed, and so keep their layout.  The part
+// should be sent with a header like
+//
+//	Content-Type: text/plain; charset=utf-8; format=flowed
+//
+// It returns the number of bytes written, and the first error
+// returned by w, if any, like WriteHtml.
+//
+func (d *Doc) WriteFlowed(w Writer, width int) (n int, err os.Error) {
+	out := new(flowedOut)
+	dest := &errWriter{w: w}
+	out.Writer = dest
+	out.width = width
+	if width == 0 {
+		out.width = FlowedWidth
+	}
+	out.symbols = d.NoteSymbols
+	out.linkNum = make(map[string]int)
+	out.first = d.firstNote() + d.NoteCount()
+	d.Render(out)
+	for i := 0; i < len(out.notes); i++ {
+		body := out.notes[i]
+		out.blank = true
+		out.frames = append(out.frames, &mdFrame{first: "[" + noteMarker(d.firstNote()+i, d.NoteSymbols) + "] "})
+		body()
+		out.para(false)	/* an inline note */
+		out.frames = out.frames[:len(out.frames)-1]
+	}
+	if len(out.urls) > 0 {
+		lines := make([]string, len(out.urls))
+		for i, url := range out.urls {
+			lines[i] = "[" + strconv.Itoa(out.first+i) + "] " + url
+		}
+		out.block(lines, false)
+	}
+	return dest.n, dest.err
+}
+
+/* line - print a line, preceded by the quote marks of the block quotes
+ * it is part of, and the prefixes of the other containers; it is
+ * space-stuffed, if it starts with a space, ">", or "From "
+ */
+func (w *flowedOut) line(s string) {
+	quote, p := "", ""
+	for _, f := range w.frames {
+		switch {
+		case f.first == ">":
+			quote += ">"
+		case f.used:
+			p += f.rest
+		default:
+			p += f.first
+			f.used = true
+		}
+	}
+	w.quoted = len(quote)
+	s = p + s
+	if strings.TrimSpace(s) == "" {
+		s = ""
+	}
+	switch {
+	case quote != "" && s != "":
+		s = quote + " " + s
+	case quote != "":
+		s = quote
+	case s != "" && (s[0] == ' ' || s[0] == '>' || strings.HasPrefix(s, "From ")):
+		s = " " + s
+	}
+	w.WriteString(s + "\n")
+}
+
+/* block - print the lines of a block, separated by an empty line from
+ * the preceding one, unless both are tight; the empty line is quoted
+ * as deeply as both blocks are
+ */
+func (w *flowedOut) block(lines []string, tight bool) {
+	if w.started && (w.blank || !tight) {
+		n := 0
+		for _, f := range w.frames {
+			if f.first == ">" {
+				n++
+			}
+		}
+		if n > w.quoted {
+			n = w.quoted
+		}
+		w.WriteString(strings.Repeat(">", n) + "\n")
+	}
+	for _, l := range lines {
+		w.line(l)
+	}
+	w.started = true
+	w.blank = !tight
+}
+
+/* text - return the inline text collected for the current block */
+func (w *flowedOut) text() string {
+	s := strings.TrimSpace(w.buf.String())
+	w.buf.Reset()
+	return s
+}
+
+func (w *flowedOut) para(tight bool) {
+	if s := w.text(); s != "" {
+		w.block(w.wrap(s), tight)
+	}
+}
+
+/* wrap - split text into lines at hard line breaks, and at the width
+ * left by the prefixes of the containers.  Lines ending at a soft
+ * break get a trailing space, unless the containers indent the lines
+ * following the first one, or the line would be the signature
+ * separator "-- ".  The other lines must not end with a space.
+ */
+func (w *flowedOut) wrap(s string) []string {
+	width := w.width
+	flowing := true
+	quotes := 0
+	for _, f := range w.frames {
+		if f.first == ">" {
+			quotes++
+		} else {
+			width -= textWidth(f.rest)
+			flowing = flowing && f.rest == ""
+		}
+	}
+	if quotes > 0 {
+		width -= quotes + 1
+	}
+	if width < 20 {
+		width = 20
+	}
+	var lines []string
+	for _, seg := range strings.Split(s, "\n", -1) {
+		line, n := "", 0
+		for _, word := range strings.Split(seg, " ", -1) {
+			if word == "" {
+				continue
+			}
+			m := textWidth(word)
+			switch {
+			case line == "":
+				line, n = word, m
+			case w.width > 0 && n+1+m > width:
+				if flowing && line != "--" {
+					line += " "
+				}
+				lines = append(lines, line)
+				line, n = word, m
+			default:
+				line += " " + word
+				n += 1 + m
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+/* link - mark the end of a link or an image by the number of its
+ * destination, unless its text shows the destination already
+ */
+func (w *flowedOut) link(url string) {
+	start := w.opened[len(w.opened)-1]
+	w.opened = w.opened[:len(w.opened)-1]
+	text := w.buf.String()[start:]
+	if url == "" || url[0] == '#' || text == url || "mailto:"+text == url {
+		return
+	}
+	n, ok := w.linkNum[url]
+	if !ok {
+		n = w.first + len(w.urls)
+		w.linkNum[url] = n
+		w.urls = append(w.urls, url)
+	}
+	w.buf.WriteString(" [" + strconv.Itoa(n) + "]")
+}
+
+func (w *flowedOut) style(on, off string, entering bool) {
+	if entering {
+		w.buf.WriteString(on)
+	} else {
+		w.buf.WriteString(off)
+	}
+}
+
+/* Renderer methods
+ */
+
+func (w *flowedOut) Str(s string) {
+	w.buf.WriteString(s)
+}
+
+func (w *flowedOut) Space(s string) {
+	w.buf.WriteString(" ")
+}
+
+func (w *flowedOut) LineBreak() {
+	w.buf.WriteString("\n")
+}
+
+func (w *flowedOut) Ellipsis() {
+	w.buf.WriteString("…")
+}
+
+func (w *flowedOut) EmDash() {
+	w.buf.WriteString("—")
+}
+
+func (w *flowedOut) EnDash() {
+	w.buf.WriteString("–")
+}
+
+func (w *flowedOut) Apostrophe() {
+	w.buf.WriteString("’")
+}
+
+func (w *flowedOut) SingleQuoted(entering bool) {
+	w.style("‘", "’", entering)
+}
+
+func (w *flowedOut) DoubleQuoted(entering bool) {
+	w.style("“", "”", entering)
+}
+
+func (w *flowedOut) Code(s string) {
+	w.buf.WriteString(s)
+}
+
+func (w *flowedOut) Math(s string, display bool) {
+	w.buf.WriteString(s)
+}
+
+func (w *flowedOut) Html(s string) {
+	/* print entities only */
+	if strings.HasPrefix(s, "&") {
+		if t := entityText(s); t != "" {
+			s = t
+		}
+		w.buf.WriteString(s)
+	}
+}
+
+func (w *flowedOut) Format() string {
+	return "flowed"
+}
+
+func (w *flowedOut) Raw(s string) {
+	w.buf.WriteString(s)
+}
+
+func (w *flowedOut) Link(url, title string, entering bool) {
+	if entering {
+		w.opened = append(w.opened, w.buf.Len())
+	} else {
+		w.link(url)
+	}
+}
+
+func (w *flowedOut) Image(url, title string, entering bool) {
+	if entering {
+		w.opened = append(w.opened, w.buf.Len())
+		return
+	}
+	if w.buf.Len() == w.opened[len(w.opened)-1] {
+		w.buf.WriteString("[image]")
+	}
+	w.link(url)
+}
+
+func (w *flowedOut) Emph(entering bool) {
+	w.style("_", "_", entering)
+}
+
+func (w *flowedOut) Strong(entering bool) {
+	w.style("*", "*", entering)
+}
+
+func (w *flowedOut) Strike(entering bool) {
+}
+
+func (w *flowedOut) Superscript(entering bool) {
+	w.style("^", "", entering)
+}
+
+func (w *flowedOut) Subscript(entering bool) {
+	w.style("_", "", entering)
+}
+
+func (w *flowedOut) Abbr(title string, entering bool) {
+}
+
+func (w *flowedOut) Emoji(name, s string) {
+	w.buf.WriteString(s)
+}
+
+func (w *flowedOut) Citation(source string, entering bool) {
+	/* print the formatted citation only */
+}
+
+func (w *flowedOut) Note(n int, body func()) {
+	w.buf.WriteString("[" + noteMarker(n, w.symbols) + "]")
+	w.notes = append(w.notes, body)
+}
+
+func (w *flowedOut) Heading(level int, id string, entering bool) {
+	if entering {
+		return
+	}
+	s := strings.Replace(w.text(), "\n", " ", -1)
+	lines := []string{s}
+	if level <= 2 {
+		n := textWidth(s)
+		if w.width > 0 && n > w.width {
+			n = w.width
+		}
+		lines = append(lines, strings.Repeat("=-"[level-1:level], n))
+	}
+	w.block(lines, false)
+}
+
+func (w *flowedOut) Plain(entering bool) {
+	if !entering {
+		w.para(true)
+	}
+}
+
+func (w *flowedOut) Para(entering bool) {
+	if !entering {
+		w.para(false)
+	}
+}
+
+func (w *flowedOut) Figure(caption string, entering bool) {
+	w.Para(entering)
+}
+
+func (w *flowedOut) HRule() {
+	n := 40
+	if w.width > 0 && n > w.width {
+		n = w.width
+	}
+	w.block([]string{strings.Repeat("-", n)}, false)
+}
+
+func (w *flowedOut) HtmlBlock(s string) {
+	/* don't print HTML block */
+}
+
+func (w *flowedOut) Verbatim(s, lang string) {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n", -1)
+	for i, l := range lines {
+		lines[i] = "    " + strings.TrimRight(l, " ")
+	}
+	w.block(lines, false)
+}
+
+func (w *flowedOut) BlockQuote(entering bool) {
+	if entering {
+		w.frames = append(w.frames, &mdFrame{first: ">", rest: ">"})
+	} else {
+		w.frames = w.frames[:len(w.frames)-1]
+	}
+	w.blank = true
+}
+
+func (w *flowedOut) Admonition(kind string, entering bool) {
+	w.blank = true
+}
+
+func (w *flowedOut) AdmonitionTitle(entering bool) {
+	if !entering {
+		w.block([]string{w.text()}, true)
+	}
+}
+
+func (w *flowedOut) Div(class string, entering bool) {
+	/* print the contents only */
+}
+
+func (w *flowedOut) Bibliography(entering bool) {
+	/* print the entries only */
+}
+
+func (w *flowedOut) list(l mdList, entering bool) {
+	if entering {
+		w.lists = append(w.lists, l)
+	} else {
+		w.lists = w.lists[:len(w.lists)-1]
+		w.blank = true
+	}
+}
+
+func (w *flowedOut) BulletList(entering bool) {
+	w.list(mdList{}, entering)
+}
+
+func (w *flowedOut) OrderedList(start int, delim string, entering bool) {
+	w.list(mdList{ordered: true, n: start - 1, delim: delim}, entering)
+}
+
+func (w *flowedOut) item(task string, entering bool) {
+	if !entering {
+		w.frames = w.frames[:len(w.frames)-1]
+		return
+	}
+	l := &w.lists[len(w.lists)-1]
+	l.n++
+	marker := "* "
+	if l.ordered {
+		marker = strconv.Itoa(l.n) + l.delim + " "
+	}
+	w.frames = append(w.frames, &mdFrame{first: marker + task, rest: strings.Repeat(" ", len(marker))})
+}
+
+func (w *flowedOut) ListItem(entering bool) {
+	w.item("", entering)
+}
+
+func (w *flowedOut) TaskItem(done bool, entering bool) {
+	if done {
+		w.item("[x] ", entering)
+	} else {
+		w.item("[ ] ", entering)
+	}
+}
+
+func (w *flowedOut) DefinitionList(entering bool) {
+	w.blank = true
+}
+
+func (w *flowedOut) DefTitle(entering bool) {
+	if !entering {
+		w.block([]string{strings.Replace(w.text(), "\n", " ", -1)}, true)
+	}
+}
+
+func (w *flowedOut) DefData(entering bool) {
+	if entering {
+		w.frames = append(w.frames, &mdFrame{first: "    ", rest: "    "})
+	} else {
+		w.frames = w.frames[:len(w.frames)-1]
+		w.blank = true
+	}
+}
+
+func (w *flowedOut) Table(align []string, entering bool) {
+	if entering {
+		w.rows = nil
+		w.nhead = 0
+		return
+	}
+	ncol := len(align)
+	width := make([]int, ncol)
+	for _, row := range w.rows {
+		for i, cell := range row {
+			if n := textWidth(cell); i < ncol && n > width[i] {
+				width[i] = n
+			}
+		}
+	}
+	var lines []string
+	for i, row := range w.rows {
+		cells := make([]string, ncol)
+		for j := range cells {
+			if j < len(row) {
+				cells[j] = row[j]
+			}
+			pad := width[j] - textWidth(cells[j])
+			switch align[j] {
+			case "right":
+				cells[j] = strings.Repeat(" ", pad) + cells[j]
+			case "center":
+				cells[j] = strings.Repeat(" ", pad/2) + cells[j] + strings.Repeat(" ", pad-pad/2)
+			default:
+				cells[j] += strings.Repeat(" ", pad)
+			}
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(cells, " | "), " "))
+		if i+1 == w.nhead {
+			sep := make([]string, ncol)
+			for j := range sep {
+				sep[j] = strings.Repeat("-", width[j])
+			}
+			lines = append(lines, strings.Join(sep, "-+-"))
+		}
+	}
+	w.block(lines, false)
+}
+
+func (w *flowedOut) TableHead(entering bool) {
+	if !entering {
+		w.nhead = len(w.rows)
+	}
+}
+
+func (w *flowedOut) TableBody(entering bool) {
+}
+
+func (w *flowedOut) TableRow(entering bool) {
+	if entering {
+		w.row = nil
+	} else {
+		w.rows = append(w.rows, w.row)
+	}
+}
+
+func (w *flowedOut) TableCell(align string, header bool, entering bool) {
+	if !entering {
+		w.row = append(w.row, strings.Replace(w.text(), "\n", " ", -1))
+	}
+}
+
+func (w *flowedOut) TOC(toc []*TOCItem) {
+}
@@ -0,0 +1,60 @@
+// +build gofuzz
+
+package markdown
+
+// Entry point for go-fuzz, see https://github.com/dvyukov/go-fuzz
+
+import (
+	"bytes"
+)
+
+/* all extensions, except those depending on outside data */
+var fuzzExtensions = Extensions{
+	Smart: true, Notes: true, Dlists: true, Tables: true, FencedCode: true,
+	FrontMatter: true, TOC: true, Strike: true, Autolink: true, Math: true,
+	HeadingIDs: true, Attributes: true, TaskLists: true, Abbreviations: true,
+	SupSub: true, Emoji: true, WikiLinks: true, Admonitions: true,
+	FencedDivs: true, Citations: true, ListStart: true, StrictLists: true,
+	Templates: true,
+}
+
+/* works cited by [@a] and @doe */
+var fuzzBibliography = Bibliography{
+	"a":	&Work{Key: "a", Type: "book", Authors: []Name{{Family: "A"}}, Title: "T", Year: "2000"},
+	"doe":	&Work{Key: "doe", Authors: []Name{{Family: "Doe"}, {Family: "Roe"}, {Family: "Poe"}}},
+}
+
+// Fuzz parses data, as Markdown.pl would, with the extensions, and in
+// CommonMark mode, and renders the documents in each output format.
+// It returns 1, as all inputs are valid documents; limits of the
+// nesting and of the parse time keep inputs quick to check.
+func Fuzz(data []byte) int {
+	cm := fuzzExtensions
+	cm.CommonMark = true
+	for _, ext := range []Extensions{{}, fuzzExtensions, cm} {
+		p := NewParser(ext)
+		p.Limits.Depth = DefaultDepth
+		p.Limits.Time = 1e9
+		p.Bibliography = fuzzBibliography
+		d := p.ParseBytes(data)
+		var b bytes.Buffer
+		d.WriteHtml(&b)
+		d.XHTML = true
+		d.WriteHtml(&b)
+		d.WriteSlides(&b, SlidesAtRules|SlidesAtHeadings)
+		d.XHTML = false
+		d.WriteAST(&b)
+		d.WriteDocBook(&b)
+		d.WriteGroffMm(&b)
+		d.WriteLatex(&b)
+		d.WriteMan(&b, nil)
+		d.WriteMarkdown(&b, nil)
+		d.WriteTerm(&b)
+		d.WriteText(&b, true)
+		d.WriteFlowed(&b, 40)
+		d.Stats()
+		d.TOC()
+		d.Validate()
+	}
+	return 1
+}
@@ -0,0 +1,35 @@
+// +build gofuzz
+
+package markdown
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+/* the corpus of go-fuzz, run with -workdir testdata/fuzz, which also
+ * holds the inputs that crashed the parser, like label-note
+ */
+const fuzzCorpus = "testdata/fuzz/corpus"
+
+// TestFuzzCorpus passes each input of the corpus to Fuzz, which
+// panics, or exits, where parsing or printing it fails.
+func TestFuzzCorpus(t *testing.T) {
+	names, err := filepath.Glob(filepath.Join(fuzzCorpus, "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) == 0 {
+		t.Fatalf("no inputs in %s", fuzzCorpus)
+	}
+	for _, name := range names {
+		data, err := ioutil.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if Fuzz(data) != 1 {
+			t.Errorf("%s: Fuzz returned 0", name)
+		}
+	}
+}
@@ -0,0 +1,446 @@
+/*  Original C version https://github.com/jgm/peg-markdown/
+ *	Copyright 2008 John MacFarlane (jgm at berkeley dot edu).
+ *
+ *  Modifications and translation from C into Go
+ *  based on markdown_output.c
+ *	Copyright 2010 Michael Teichgräber (mt at wmipf dot de)
+ *
+ *  This program is free software; you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License or the MIT
+ *  license.  See LICENSE for details.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ */
+
+package markdown
+
+// groff mm output functions
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+type groffOut struct {
+	Writer
+	padded	int
+
+	inListItem	bool		/* True if we're printing contents of a list item. */
+	firstBlock	bool		/* True until the first block of a list item has been started. */
+	url			string	/* Destination of the current link. */
+	col			int		/* Number of cells printed in the current table row. */
+	bol			bool	/* True at the beginning of an output line. */
+	quoted		bool	/* True while printing a quoted argument of a macro. */
+}
+
+// WriteGroffMm prints a document tree in groff format, using
+// mm macros, to the specified Writer.
+// It returns the number of bytes written, and the first error
+// returned by w, if any, like WriteHtml.
+//
+func (d *Doc) WriteGroffMm(w Writer) (n int, err os.Error) {
+	out := new(groffOut)
+	dest := &errWriter{w: w}
+	out.Writer = dest
+	out.padded = 2
+	out.bol = true
+	d.Render(out)
+	out.WriteByte('\n')
+	return dest.n, dest.err
+}
+
+// pad - add newlines if needed
+func (w *groffOut) pad(n int) *groffOut {
+	for ; n > w.padded; n-- {
+		w.WriteByte('\n')
+		w.bol = true
+	}
+	w.padded = n
+	return w
+}
+
+func (w *groffOut) pset(n int) *groffOut {
+	w.padded = n
+	return w
+}
+
+// print a string
+func (w *groffOut) s(s string) *groffOut {
+	if s != "" {
+		w.WriteString(s)
+		w.bol = s[len(s)-1] == '\n'
+	}
+	return w
+}
+
+/* print string, escaping for groff: backslashes, dots and
+ * apostrophes at the beginning of a line, which would start a
+ * control line, and, in quoted arguments of macros, like .SH "...",
+ * double quotes
+ */
+func (w *groffOut) str(s string) *groffOut {
+	i0 := 0
+	for i := 0; i < len(s); i++ {
+		var esc string
+		switch c := s[i]; {
+		case c == '\\':
+			esc = `\e`
+		case c == '"' && w.quoted:
+			esc = `\(dq`
+		case (c == '.' || c == '\'') && (i == 0 && w.bol || i > 0 && s[i-1] == '\n'):
+			w.WriteString(s[i0:i])
+			w.WriteString(`\&`)
+			i0 = i
+			continue
+		default:
+			continue
+		}
+		w.WriteString(s[i0:i])
+		w.WriteString(esc)
+		i0 = i + 1
+	}
+	w.WriteString(s[i0:])
+	if s != "" {
+		w.bol = s[len(s)-1] == '\n'
+	}
+	return w
+}
+
+/* arg - start or end a quoted argument of a macro */
+func (w *groffOut) arg(entering bool) *groffOut {
+	w.quoted = entering
+	return w.s(`"`)
+}
+
+// block - start a block element on a new line
+func (w *groffOut) block() *groffOut {
+	w.firstBlock = false
+	return w.pad(1)
+}
+
+
+/* Renderer methods
+ */
+
+func (w *groffOut) Str(s string) {
+	w.str(s).pset(0)
+}
+
+func (w *groffOut) Space(s string) {
+	w.s(s).pset(0)
+}
+
+func (w *groffOut) LineBreak() {
+	w.pad(1).s(".br\n").pset(1)
+}
+
+func (w *groffOut) Ellipsis() {
+	w.str("...")
+}
+
+func (w *groffOut) EmDash() {
+	w.s(`\[em]`)
+}
+
+func (w *groffOut) EnDash() {
+	w.s(`\[en]`)
+}
+
+func (w *groffOut) Apostrophe() {
+	w.str("'")
+}
+
+func (w *groffOut) SingleQuoted(entering bool) {
+	if entering {
+		w.s("`")
+	} else {
+		w.str("'")
+	}
+}
+
+func (w *groffOut) DoubleQuoted(entering bool) {
+	if entering {
+		w.s(`\[lq]`)
+	} else {
+		w.s(`\[rq]`)
+	}
+}
+
+func (w *groffOut) Code(s string) {
+	w.s(`\fC`).str(s).s(`\fR`).pset(0)
+}
+
+func (w *groffOut) Math(s string, display bool) {
+	/* not supported; print the TeX source */
+	w.str(s).pset(0)
+}
+
+func (w *groffOut) Html(s string) {
+	/* don't print HTML */
+}
+
+func (w *groffOut) Format() string {
+	return "groff-mm"
+}
+
+func (w *groffOut) numbersHeadings() bool {
+	return true
+}
+
+func (w *groffOut) Raw(s string) {
+	w.s(s).pset(0)
+}
+
+func (w *groffOut) Link(url, title string, entering bool) {
+	if entering {
+		w.url = url
+		return
+	}
+	w.s(" (").s(w.url).s(")").pset(0)
+}
+
+func (w *groffOut) Image(url, title string, entering bool) {
+	/* not supported; print the alternate text only */
+	if entering {
+		w.s("[IMAGE: ")
+	} else {
+		w.s("]").pset(0)
+	}
+}
+
+func (w *groffOut) Emph(entering bool) {
+	w.font(`\fI`, entering)
+}
+
+func (w *groffOut) Strong(entering bool) {
+	w.font(`\fB`, entering)
+}
+
+func (w *groffOut) Strike(entering bool) {
+	/* not supported; print the text only */
+}
+
+func (w *groffOut) Superscript(entering bool) {
+	if entering {
+		w.s(`\u\s-2`)
+	} else {
+		w.s(`\s+2\d`)
+	}
+}
+
+func (w *groffOut) Subscript(entering bool) {
+	if entering {
+		w.s(`\d\s-2`)
+	} else {
+		w.s(`\s+2\u`)
+	}
+}
+
+func (w *groffOut) Emoji(name, s string) {
+	w.Str(s)
+}
+
+func (w *groffOut) Citation(source string, entering bool) {
+	/* print the formatted citation only */
+}
+
+func (w *groffOut) Abbr(title string, entering bool) {
+	/* print the abbreviation only */
+}
+
+func (w *groffOut) font(f string, entering bool) {
+	if entering {
+		w.s(f)
+	} else {
+		w.s(`\fR`).pset(0)
+	}
+}
+
+func (w *groffOut) Note(n int, body func()) {
+	w.s("\\*F\n")
+	w.s(".FS\n").pset(2)
+	body()
+	w.pad(1).s(".FE\n").pset(1)
+}
+
+func (w *groffOut) Heading(level int, id string, entering bool) {
+	if entering {
+		w.block().s(fmt.Sprintf(".H %d ", level)).arg(true)
+	} else {
+		w.arg(false).pset(0)
+	}
+}
+
+func (w *groffOut) Plain(entering bool) {
+	if entering {
+		w.block()
+	} else {
+		w.pset(0)
+	}
+}
+
+func (w *groffOut) Para(entering bool) {
+	if !entering {
+		w.pset(0)
+		return
+	}
+	first := w.inListItem && w.firstBlock
+	w.block()
+	if !first {
+		w.s(".P\n")
+	}
+}
+
+func (w *groffOut) Figure(caption string, entering bool) {
+	w.Para(entering)
+}
+
+func (w *groffOut) HRule() {
+	w.block().s(`\l'\n(.lu*8u/10u'`).pset(0)
+}
+
+func (w *groffOut) HtmlBlock(s string) {
+	/* don't print HTML block */
+}
+
+func (w *groffOut) Verbatim(s, lang string) {
+	w.block().s(".VERBON 2\n").str(s).s(".VERBOFF").pset(0)
+}
+
+func (w *groffOut) BlockQuote(entering bool) {
+	if entering {
+		w.block().s(".DS I\n").pset(2)
+	} else {
+		w.pad(1).s(".DE").pset(0)
+	}
+}
+
+func (w *groffOut) Admonition(kind string, entering bool) {
+	w.BlockQuote(entering)
+}
+
+func (w *groffOut) AdmonitionTitle(entering bool) {
+	if entering {
+		w.block().s(".P\n\\fB")
+	} else {
+		w.s(`\fP`).pset(0)
+	}
+}
+
+func (w *groffOut) Div(class string, entering bool) {
+	/* print the contents only */
+}
+
+func (w *groffOut) Bibliography(entering bool) {
+	/* print the entries only */
+}
+
+func (w *groffOut) BulletList(entering bool) {
+	w.list(".BL", entering)
+}
+
+func (w *groffOut) OrderedList(start int, delim string, entering bool) {
+	w.list(".AL", entering)
+}
+
+func (w *groffOut) DefinitionList(entering bool) {
+	w.list(".VL 4", entering)
+}
+
+func (w *groffOut) list(macro string, entering bool) {
+	if entering {
+		w.block().s(macro).pset(0)
+	} else {
+		w.pad(1).s(".LE 1").pset(0)
+	}
+}
+
+func (w *groffOut) ListItem(entering bool) {
+	w.item(".LI\n", entering)
+}
+
+func (w *groffOut) TaskItem(done bool, entering bool) {
+	if done {
+		w.item(".LI \\[OK]\n", entering)
+	} else {
+		w.item(".LI \\[sq]\n", entering)
+	}
+}
+
+func (w *groffOut) DefTitle(entering bool) {
+	if entering {
+		w.block().s(".LI ").arg(true)
+	} else {
+		w.arg(false).s("\n").pset(1)
+	}
+}
+
+func (w *groffOut) DefData(entering bool) {
+	w.item("", entering)
+}
+
+func (w *groffOut) item(macro string, entering bool) {
+	if entering {
+		w.block().s(macro).pset(2)
+		w.inListItem = true
+		w.firstBlock = true
+	} else {
+		w.inListItem = false
+	}
+}
+
+func (w *groffOut) Table(align []string, entering bool) {
+	if !entering {
+		w.pad(1).s(".TE").pset(0)
+		return
+	}
+	/* tbl format: a line for the header row in bold, then one for the body rows */
+	format := make([]string, len(align))
+	for i, a := range align {
+		switch a {
+		case "center":
+			format[i] = "c"
+		case "right":
+			format[i] = "r"
+		default:
+			format[i] = "l"
+		}
+	}
+	w.block().s(".TS\n")
+	w.s(strings.Join(format, "B ")).s("B\n")
+	w.s(strings.Join(format, " ")).s(" .\n").pset(1)
+}
+
+func (w *groffOut) TableHead(entering bool) {
+	if !entering {
+		w.s("_\n").pset(1)
+	}
+}
+
+func (w *groffOut) TableBody(entering bool) {
+}
+
+func (w *groffOut) TableRow(entering bool) {
+	if entering {
+		w.col = 0
+	} else {
+		w.s("\n").pset(1)
+	}
+}
+
+func (w *groffOut) TableCell(align string, header bool, entering bool) {
+	if entering {
+		if w.col > 0 {
+			w.s("\t")
+		}
+		w.col++
+	}
+}
+
+func (w *groffOut) TOC(toc []*TOCItem) {
+	/* the mm macros print the table of contents using .TC */
+}
@@ -0,0 +1,163 @@
+package markdown
+
+// Serving Markdown files over HTTP
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"http"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// A Handler serves the Markdown files in the directory tree at Root
+// as HTML documents, converting a file each time it is requested. A
+// file "a.md" is served for the paths /a.md and /a.html; other files
+// are served as they are.  For a directory, its file index.md is
+// served, or a list of the Markdown files and directories in it.
+type Handler struct {
+	Root	string
+	Ext		Extensions
+	Page	*Page	// passed to WriteHtmlDocument; may be nil
+	Limits	Limits	// for files that can't be trusted
+
+	// If set, a script is added to each page, that reloads it
+	// when the Markdown file has been modified.
+	Reload	bool
+}
+
+/* the reload script asks for the modification time of the file, using
+ * the query "?modified", and reloads the page if it has changed
+ */
+const reloadScript = `<script>
+(function() {
+	var t = null;
+	setInterval(function() {
+		var r = new XMLHttpRequest();
+		r.onreadystatechange = function() {
+			if (r.readyState != 4 || r.status != 200) return;
+			if (t != null && r.responseText != t) location.reload();
+			t = r.responseText;
+		};
+		r.open("GET", location.pathname + "?modified", true);
+		r.send(null);
+	}, 1000);
+})();
+</script>
+`
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	upath := path.Clean("/" + r.URL.Path)
+	name := filepath.Join(h.Root, filepath.FromSlash(upath))
+
+	fi, err := os.Stat(name)
+	if err == nil && fi.IsDirectory() {
+		index := filepath.Join(name, "index.md")
+		if _, err := os.Stat(index); err != nil {
+			h.serveDir(w, upath, name)
+			return
+		}
+		name = index
+	} else if strings.HasSuffix(name, ".html") {
+		md := name[:len(name)-len(".html")] + ".md"
+		if _, err := os.Stat(md); err == nil {
+			name = md
+		}
+	}
+	if !isMarkdown(name) {
+		http.ServeFile(w, r, name)
+		return
+	}
+	if fi, err = os.Stat(name); err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if r.URL.RawQuery == "modified" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprint(w, fi.Mtime_ns)
+		return
+	}
+	text, err := ioutil.ReadFile(name)
+	if err != nil {
+		http.Error(w, err.String(), http.StatusInternalServerError)
+		return
+	}
+
+	p := NewParser(h.Ext)
+	p.Limits = h.Limits
+	doc := p.ParseBytes(text)
+	if err := doc.Err(); err != nil {
+		http.Error(w, err.String(), http.StatusInternalServerError)
+		return
+	}
+	buf := new(bytes.Buffer)
+	if _, err := doc.WriteHtmlDocument(buf, h.Page); err != nil {
+		http.Error(w, err.String(), http.StatusInternalServerError)
+		return
+	}
+	page := buf.String()
+	if h.Reload {
+		i := strings.LastIndex(page, "</body>")
+		if i == -1 {
+			i = len(page)
+		}
+		page = page[:i] + reloadScript + page[i:]
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, page)
+}
+
+/* serveDir - print a list of the Markdown files and subdirectories
+ * of directory dir, which is served at path upath
+ */
+func (h *Handler) serveDir(w http.ResponseWriter, upath, dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		http.Error(w, err.String(), http.StatusInternalServerError)
+		return
+	}
+	list, err := d.Readdir(-1)
+	d.Close()
+	if err != nil {
+		http.Error(w, err.String(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	bw := bufio.NewWriter(w)
+	out := &htmlOut{Writer: bw}
+	out.s("<!DOCTYPE html>\n<html>\n<head>\n<title>").str(upath).s("</title>\n</head>\n<body>\n")
+	out.s("<h1>").str(upath).s("</h1>\n<ul>\n")
+	for i := range list {
+		fi := &list[i]
+		name := fi.Name
+		switch {
+		case strings.HasPrefix(name, "."):
+			continue
+		case fi.IsDirectory():
+			name += "/"
+		case !isMarkdown(name):
+			continue
+		}
+		href := path.Join(upath, fi.Name)
+		if fi.IsDirectory() {
+			href += "/"
+		}
+		out.s(`<li><a href="`).str(href).s(`">`).str(name).s("</a></li>\n")
+	}
+	out.s("</ul>\n</body>\n</html>\n")
+	bw.Flush()
+}
+
+/* isMarkdown - report whether a file name has the suffix of a Markdown file
+ */
+func isMarkdown(name string) bool {
+	switch filepath.Ext(name) {
+	case ".md", ".markdown", ".mdown", ".mkd":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+package markdown
+
+// Including other files, by lines like <!--#include file="x.md"-->
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IncludeDir returns a function for Parser.Include that loads the
+// files below dir. Names are slash-separated paths relative to dir;
+// absolute names, and those leading out of dir, are rejected, as are
+// symbolic links within dir pointing to files outside of it.
+func IncludeDir(dir string) func(name string) ([]byte, os.Error) {
+	return func(name string) ([]byte, os.Error) {
+		clean := path.Clean(name)
+		if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+			return nil, os.NewError("outside of " + dir)
+		}
+		root, err := resolve(dir)
+		if err != nil {
+			return nil, err
+		}
+		file, err := resolve(filepath.Join(root, filepath.FromSlash(clean)))
+		if err != nil {
+			return nil, err
+		}
+		if sep := string(filepath.Separator); !strings.HasSuffix(root, sep) {
+			root += sep
+		}
+		if !strings.HasPrefix(file, root) {
+			return nil, os.NewError("outside of " + dir)
+		}
+		return ioutil.ReadFile(file)
+	}
+}
+
+/* resolve - return the absolute path of name, with symbolic links
+ * replaced by their targets
+ */
+func resolve(name string) (string, os.Error) {
+	if !filepath.IsAbs(name) {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", err
+		}
+		name = filepath.Join(wd, name)
+	}
+	return filepath.EvalSymlinks(name)
+}
+
+/* expandIncludes - return s, with the lines of include directives
+ * replaced by the files they name, and the problems found
+ */
+func (p *Parser) expandIncludes(s string) (string, []Warning) {
+	if p.Include == nil {
+		return s, nil
+	}
+	var x includer
+	x.p = p
+	x.b = bytes.NewBuffer(make([]byte, 0, len(s)))
+	x.expand(s, nil)
+	return x.b.String(), x.warnings
+}
+
+type includer struct {
+	p			*Parser
+	b			*bytes.Buffer	/* The text with the files included. */
+	warnings	[]Warning
+	full		bool	/* Set, if the size limit has been reached. */
+}
+
+/* expand - write s, including the files named by its directives;
+ * stack holds the names of the files being included
+ */
+func (x *includer) expand(s string, stack []string) {
+	fence := ""
+	for s != "" {
+		line := s
+		if i := strings.Index(s, "\n"); i != -1 {
+			line = s[:i+1]
+		}
+		s = s[len(line):]
+		name := ""
+		switch {
+		case fence != "":
+			if closesFence(line, fence) {
+				fence = ""
+			}
+		case x.p.ext.FencedCode && openFence(line) != "":
+			fence = openFence(line)
+		default:
+			name = includeName(line)
+		}
+		if name == "" {
+			x.b.WriteString(line)
+			continue
+		}
+		if len(stack) > 0 {
+			name = path.Join(path.Dir(stack[len(stack)-1]), name)
+		}
+		x.include(name, stack)
+	}
+}
+
+/* include - write the text of the file name */
+func (x *includer) include(name string, stack []string) {
+	for i, s := range stack {
+		if s == name {
+			x.warn("include cycle: " + strings.Join(append(stack[i:], name), " -> "))
+			return
+		}
+	}
+	if x.full {
+		return
+	}
+	b, err := x.p.Include(name)
+	if err != nil {
+		x.warn("include " + name + ": " + err.String())
+		return
+	}
+	if max := x.p.Limits.Size; max > 0 && x.b.Len()+len(b) > max {
+		x.warn("include " + name + ": document too large")
+		x.full = true
+		return
+	}
+	pf := newPreformatter(len(b), x.p.TabWidth, x.p.LiteralTabs, x.p.NormalizeInput)
+	pf.Write(b)
+	text := pf.text()
+	if x.p.ext.FrontMatter {
+		_, text = splitFrontMatter(text)
+	}
+	x.expand(text, append(stack, name))
+}
+
+func (x *includer) warn(msg string) {
+	line := bytes.Count(x.b.Bytes(), []byte("\n")) + 1
+	x.warnings = append(x.warnings, Warning{line, msg})
+}
+
+/* includeName - return the name of the file included by line, if it
+ * is a directive <!--#include file="name"--> or !include name, or ""
+ */
+func includeName(line string) string {
+	if len(line)-len(strings.TrimLeft(line, " ")) > 3 {
+		return ""
+	}
+	s := strings.TrimSpace(line)
+	switch {
+	case strings.HasPrefix(s, "<!--#include") && strings.HasSuffix(s, "-->"):
+		s = strings.TrimSpace(s[len("<!--#include") : len(s)-len("-->")])
+		if !strings.HasPrefix(s, "file=") {
+			return ""
+		}
+		s = s[len("file="):]
+		if len(s) < 2 || s[0] != s[len(s)-1] || s[0] != '"' && s[0] != '\'' {
+			return ""
+		}
+		return s[1 : len(s)-1]
+	case strings.HasPrefix(s, "!include "):
+		return strings.TrimSpace(s[len("!include "):])
+	}
+	return ""
+}
+
+/* openFence - return the fence, if line opens a fenced code block,
+ * or ""
+ */
+func openFence(line string) string {
+	s := strings.TrimLeft(line, " ")
+	if len(line)-len(s) > 3 || !strings.HasPrefix(s, "```") && !strings.HasPrefix(s, "~~~") {
+		return ""
+	}
+	return s[:len(s)-len(strings.TrimLeft(s, s[:1]))]
+}
+
+/* closesFence - report whether line closes a code block opened by fence */
+func closesFence(line, fence string) bool {
+	s := strings.TrimLeft(line, " ")
+	if len(line)-len(s) > 3 || !strings.HasPrefix(s, fence) {
+		return false
+	}
+	return strings.TrimSpace(strings.TrimLeft(s, fence[:1])) == ""
+}
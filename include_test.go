@@ -0,0 +1,44 @@
+package markdown
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestIncludeDir checks that files outside of the directory can't be
+// included, neither by name nor through symbolic links.
+func TestIncludeDir(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "markdown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	dir := filepath.Join(tmp, "doc")
+	os.Mkdir(dir, 0755)
+	os.Mkdir(filepath.Join(dir, "sub"), 0755)
+	ioutil.WriteFile(filepath.Join(tmp, "secret.md"), []byte("secret\n"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "sub", "a.md"), []byte("a\n"), 0644)
+	os.Symlink(filepath.Join(tmp, "secret.md"), filepath.Join(dir, "out.md"))
+	os.Symlink(tmp, filepath.Join(dir, "up"))
+	os.Symlink("sub/a.md", filepath.Join(dir, "in.md"))
+
+	include := IncludeDir(dir)
+	for _, c := range []struct {
+		name	string
+		ok		bool
+	}{
+		{"sub/a.md", true},
+		{"in.md", true},
+		{"../secret.md", false},
+		{"/etc/passwd", false},
+		{"out.md", false},
+		{"up/secret.md", false},
+	} {
+		b, err := include(c.name)
+		if ok := err == nil; ok != c.ok || ok && string(b) != "a\n" {
+			t.Errorf("%s: %q, %v", c.name, b, err)
+		}
+	}
+}
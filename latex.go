@@ -0,0 +1,451 @@
+/*  Original C version https://github.com/jgm/peg-markdown/
+ *	Copyright 2008 John MacFarlane (jgm at berkeley dot edu).
+ *
+ *  Modifications and translation from C into Go
+ *  based on markdown_output.c
+ *	Copyright 2010 Michael Teichgräber (mt at wmipf dot de)
+ *
+ *  This program is free software; you can redistribute it and/or modify
+ *  it under the terms of the GNU General Public License or the MIT
+ *  license.  See LICENSE for details.
+ *
+ *  This program is distributed in the hope that it will be useful,
+ *  but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *  GNU General Public License for more details.
+ */
+
+package markdown
+
+// LaTeX output functions
+
+import (
+	"os"
+	"strconv"
+)
+
+type latexOut struct {
+	Writer
+	padded	int
+
+	saved	Writer	/* Writer to restore after an image's alternate text. */
+	col		int		/* Number of cells printed in the current table row. */
+}
+
+// WriteLatex prints a document tree in LaTeX format
+// to the specified Writer.
+// It returns the number of bytes written, and the first error
+// returned by w, if any, like WriteHtml.
+//
+func (d *Doc) WriteLatex(w Writer) (n int, err os.Error) {
+	out := new(latexOut)
+	dest := &errWriter{w: w}
+	out.Writer = dest
+	out.padded = 2
+	d.Render(out)
+	out.WriteByte('\n')
+	return dest.n, dest.err
+}
+
+// pad - add newlines if needed
+func (w *latexOut) pad(n int) *latexOut {
+	for ; n > w.padded; n-- {
+		w.WriteByte('\n')
+	}
+	w.padded = n
+	return w
+}
+
+func (w *latexOut) pset(n int) *latexOut {
+	w.padded = n
+	return w
+}
+
+// print a string
+func (w *latexOut) s(s string) *latexOut {
+	w.WriteString(s)
+	return w
+}
+
+/* print string, escaping for LaTeX
+ */
+func (w *latexOut) str(s string) *latexOut {
+	for _, r := range s {
+		switch r {
+		case '{', '}', '$', '%', '&', '_', '#':
+			w.WriteByte('\\')
+			w.WriteRune(r)
+		case '^':
+			w.WriteString(`\^{}`)
+		case '\\':
+			w.WriteString(`\textbackslash{}`)
+		case '~':
+			w.WriteString(`\ensuremath{\sim}`)
+		case '|':
+			w.WriteString(`\textbar{}`)
+		case '<':
+			w.WriteString(`\textless{}`)
+		case '>':
+			w.WriteString(`\textgreater{}`)
+		default:
+			w.WriteRune(r)
+		}
+	}
+	return w
+}
+
+func (w *latexOut) env(name string, entering bool) {
+	if entering {
+		w.pad(1).s(`\begin{`).s(name).s("}").pset(0)
+	} else {
+		w.pad(1).s(`\end{`).s(name).s("}").pset(0)
+	}
+}
+
+func (w *latexOut) cmd(name string, entering bool) {
+	if entering {
+		w.s(`\`).s(name).s("{")
+	} else {
+		w.s("}").pset(0)
+	}
+}
+
+
+/* Renderer methods
+ */
+
+func (w *latexOut) Str(s string) {
+	w.str(s).pset(0)
+}
+
+func (w *latexOut) Space(s string) {
+	w.s(s).pset(0)
+}
+
+func (w *latexOut) LineBreak() {
+	w.s("\\\\\n").pset(1)
+}
+
+func (w *latexOut) Ellipsis() {
+	w.s(`\ldots{}`)
+}
+
+func (w *latexOut) EmDash() {
+	w.s("---")
+}
+
+func (w *latexOut) EnDash() {
+	w.s("--")
+}
+
+func (w *latexOut) Apostrophe() {
+	w.s("'")
+}
+
+func (w *latexOut) SingleQuoted(entering bool) {
+	if entering {
+		w.s("`")
+	} else {
+		w.s("'")
+	}
+}
+
+func (w *latexOut) DoubleQuoted(entering bool) {
+	if entering {
+		w.s("``")
+	} else {
+		w.s("''")
+	}
+}
+
+func (w *latexOut) Code(s string) {
+	w.s(`\texttt{`).str(s).s("}").pset(0)
+}
+
+func (w *latexOut) Math(s string, display bool) {
+	if display {
+		w.s(`\[`).s(s).s(`\]`)
+	} else {
+		w.s("$").s(s).s("$")
+	}
+	w.pset(0)
+}
+
+func (w *latexOut) Html(s string) {
+	/* don't print HTML */
+}
+
+func (w *latexOut) Format() string {
+	return "latex"
+}
+
+func (w *latexOut) numbersHeadings() bool {
+	return true
+}
+
+func (w *latexOut) Raw(s string) {
+	w.s(s).pset(0)
+}
+
+func (w *latexOut) Link(url, title string, entering bool) {
+	if !entering {
+		w.s("}").pset(0)
+		return
+	}
+	if len(url) > 0 && url[0] == '#' {
+		/* treat as internal link */
+		w.s(`\hyperlink{`).s(url[1:]).s("}{")
+	} else {
+		w.s(`\href{`).s(url).s("}{")
+	}
+}
+
+func (w *latexOut) Image(url, title string, entering bool) {
+	/* the alternate text is not printed */
+	if entering {
+		w.s(`\includegraphics{`).s(url).s("}").pset(0)
+		w.saved = w.Writer
+		w.Writer = discard{}
+	} else {
+		w.Writer = w.saved
+	}
+}
+
+func (w *latexOut) Emph(entering bool) {
+	w.cmd("emph", entering)
+}
+
+func (w *latexOut) Strong(entering bool) {
+	w.cmd("textbf", entering)
+}
+
+func (w *latexOut) Strike(entering bool) {
+	w.cmd("sout", entering)	/* needs package ulem */
+}
+
+func (w *latexOut) Superscript(entering bool) {
+	w.cmd("textsuperscript", entering)
+}
+
+func (w *latexOut) Subscript(entering bool) {
+	w.cmd("textsubscript", entering)	/* needs LaTeX 2015 or package fixltx2e */
+}
+
+func (w *latexOut) Emoji(name, s string) {
+	w.Str(s)
+}
+
+func (w *latexOut) Citation(source string, entering bool) {
+	/* print the formatted citation only */
+}
+
+func (w *latexOut) Abbr(title string, entering bool) {
+	/* print the abbreviation only */
+}
+
+func (w *latexOut) Note(n int, body func()) {
+	w.s(`\footnote{`).pset(2)
+	body()
+	w.s("}").pset(0)
+}
+
+func (w *latexOut) Heading(level int, id string, entering bool) {
+	if !entering {
+		w.s("}").pset(0)
+		return
+	}
+	w.pad(2)
+	switch level {
+	case 1:
+		w.s(`\section{`)
+	case 2:
+		w.s(`\subsection{`)
+	case 3:
+		w.s(`\subsubsection{`)
+	case 4:
+		w.s(`\paragraph{`)
+	default:
+		w.s(`\subparagraph{`)
+	}
+}
+
+func (w *latexOut) Plain(entering bool) {
+	if entering {
+		w.pad(1)
+	} else {
+		w.pset(0)
+	}
+}
+
+func (w *latexOut) Para(entering bool) {
+	if entering {
+		w.pad(2)
+	} else {
+		w.pset(0)
+	}
+}
+
+func (w *latexOut) Figure(caption string, entering bool) {
+	if entering {
+		w.env("figure", true)
+		w.s("\n\\centering\n")
+		return
+	}
+	if caption != "" {
+		w.s("\n\\caption{").str(caption).s("}")
+	}
+	w.env("figure", false)
+}
+
+func (w *latexOut) HRule() {
+	w.pad(2).s("\\begin{center}\\rule{3in}{0.4pt}\\end{center}\n").pset(0)
+}
+
+func (w *latexOut) HtmlBlock(s string) {
+	/* don't print HTML block */
+}
+
+func (w *latexOut) Verbatim(s, lang string) {
+	w.pad(1).s("\\begin{verbatim}\n").s(s).s(`\end{verbatim}`).pset(0)
+}
+
+func (w *latexOut) BlockQuote(entering bool) {
+	w.env("quote", entering)
+}
+
+func (w *latexOut) Admonition(kind string, entering bool) {
+	w.env("quote", entering)
+}
+
+func (w *latexOut) AdmonitionTitle(entering bool) {
+	if entering {
+		w.pad(2)
+	}
+	w.cmd("textbf", entering)
+}
+
+func (w *latexOut) Div(class string, entering bool) {
+	/* print the contents only */
+}
+
+func (w *latexOut) Bibliography(entering bool) {
+	/* print the entries only */
+}
+
+func (w *latexOut) BulletList(entering bool) {
+	w.env("itemize", entering)
+}
+
+func (w *latexOut) OrderedList(start int, delim string, entering bool) {
+	w.env("enumerate", entering)
+	if entering && start != 1 {
+		/* the counter of the current level: enumi, enumii, ... */
+		w.s("\n" + `\setcounter{enum\romannumeral\the\enumdepth}{` + strconv.Itoa(start-1) + "}")
+	}
+}
+
+func (w *latexOut) ListItem(entering bool) {
+	if entering {
+		w.pad(1).s(`\item `).pset(2)
+	} else {
+		w.s("\n")
+	}
+}
+
+func (w *latexOut) TaskItem(done bool, entering bool) {
+	switch {
+	case !entering:
+		w.s("\n")
+	case done:
+		w.pad(1).s(`\item[{[x]}] `).pset(2)
+	default:
+		w.pad(1).s(`\item[{[ ]}] `).pset(2)
+	}
+}
+
+func (w *latexOut) DefinitionList(entering bool) {
+	w.env("description", entering)
+}
+
+func (w *latexOut) DefTitle(entering bool) {
+	if entering {
+		w.pad(1).s(`\item[{`)
+	} else {
+		w.s("}]").pset(0)
+	}
+}
+
+func (w *latexOut) DefData(entering bool) {
+	if !entering {
+		w.pad(2).pset(2)	/* separate multiple definitions of a term */
+	}
+}
+
+func (w *latexOut) Table(align []string, entering bool) {
+	if !entering {
+		w.pad(1).s(`\end{tabular}`).pset(0)
+		return
+	}
+	w.pad(2).s(`\begin{tabular}{`)
+	for _, a := range align {
+		switch a {
+		case "center":
+			w.s("c")
+		case "right":
+			w.s("r")
+		default:
+			w.s("l")
+		}
+	}
+	w.s("}").pset(0)
+}
+
+func (w *latexOut) TableHead(entering bool) {
+	if !entering {
+		w.pad(1).s(`\hline`).pset(0)
+	}
+}
+
+func (w *latexOut) TableBody(entering bool) {
+}
+
+func (w *latexOut) TableRow(entering bool) {
+	if entering {
+		w.pad(1)
+		w.col = 0
+	} else {
+		w.s(` \\`).pset(0)
+	}
+}
+
+func (w *latexOut) TableCell(align string, header bool, entering bool) {
+	if entering {
+		if w.col > 0 {
+			w.s(" & ")
+		}
+		w.col++
+	}
+	if header {
+		w.cmd("textbf", entering)
+	}
+}
+
+
+func (w *latexOut) TOC(toc []*TOCItem) {
+	w.pad(2).s(`\tableofcontents`).pset(0)
+}
+
+// discard is a Writer that drops everything written to it.
+type discard struct{}
+
+func (discard) WriteString(s string) (int, os.Error) {
+	return len(s), nil
+}
+
+func (discard) WriteRune(r int) (int, os.Error) {
+	return 1, nil
+}
+
+func (discard) WriteByte(c byte) os.Error {
+	return nil
+}
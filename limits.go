@@ -0,0 +1,146 @@
+package markdown
+
+// Limits for parsing untrusted documents
+
+import (
+	"io"
+	"os"
+	"time"
+)
+
+// Limits protect a program parsing untrusted documents, like a
+// server, against hostile input: the parser backtracks a lot on
+// deeply nested brackets or emphasis markers, which may take very
+// long (see Time), or exhaust the stack (see Depth).  A zero value
+// means no limit.
+//
+// Depth bounds the recursion of the parser, not the nesting of the
+// elements in the result: each inline element the parser attempts
+// counts while those within it are parsed, even if it turns out not
+// to be one, so that a [ or * that is never closed counts like a
+// link or emphasis would; block quotes and list items count once
+// per level.  Plain text with 20 unclosed brackets in a row needs a
+// depth of 21.
+type Limits struct {
+	Size	int		// maximum length of a document, in bytes
+	Depth	int		// maximum depth of the recursion of the parser, see DefaultDepth
+	Time	int64	// maximum time spent parsing a document, in nanoseconds
+}
+
+// DefaultDepth is a Depth limit leaving enough room for the nesting
+// found in documents written by people, like a link within emphasis
+// in a list item of a block quote nested in several others, which
+// need less than 16.
+const DefaultDepth = 32
+
+// Errors reported by Doc.Err if a limit has been exceeded, or, for
+// ErrCanceled, if parsing or printing has been canceled.
+var (
+	ErrTooLarge	= os.NewError("markdown: document too large")
+	ErrTooDeep	= os.NewError("markdown: elements nested too deeply")
+	ErrTimeout	= os.NewError("markdown: parse time limit exceeded")
+	ErrCanceled	= os.NewError("markdown: canceled")
+)
+
+// Err returns the reason why parsing has been stopped, if one of the
+// Limits of the Parser has been exceeded, or parsing has been
+// canceled, or nil.  The document is empty then.
+func (d *Doc) Err() os.Error {
+	return d.err
+}
+
+/* setLimits - prepare checking the limits l, and whether cancel has
+ * been closed, while parsing
+ */
+func (d *Doc) setLimits(l Limits, cancel <-chan struct{}) {
+	d.limits = l
+	if l.Time > 0 {
+		d.deadline = time.Nanoseconds() + l.Time
+	}
+	d.cancel = cancel
+	if canceled(cancel) {
+		d.err = ErrCanceled
+	}
+}
+
+/* canceled - report whether the channel c has been closed; c may be
+ * nil
+ */
+func canceled(c <-chan struct{}) bool {
+	select {
+	case <-c:
+		return true
+	default:
+	}
+	return false
+}
+
+// ParseCancel is like Parse, but stops parsing once cancel has been
+// closed, e.g. by a server whose client has gone away, and returns
+// an empty document then, for which Err reports ErrCanceled.  The
+// channel returned by the Done method of a context can be passed.
+func (p *Parser) ParseCancel(text string, cancel <-chan struct{}) *Doc {
+	p.cancel = cancel
+	d := p.Parse(text)
+	p.cancel = nil
+	return d
+}
+
+/* enter - called when the parser descends into an inline element, or
+ * when the children of a block are processed; returns false, if a
+ * limit has been exceeded, so that parsing fails quickly from then
+ * on.  Each call must be matched by a call of leave.
+ */
+func (d *Doc) enter() bool {
+	d.depth++
+	switch {
+	case d.err != nil:
+		return false
+	case d.limits.Depth > 0 && d.depth > d.limits.Depth:
+		d.err = ErrTooDeep
+		return false
+	case d.deadline != 0 || d.cancel != nil:
+		/* don't read the clock, or check the channel, each time */
+		if d.steps++; d.steps%1024 != 0 {
+			break
+		}
+		if d.deadline != 0 && time.Nanoseconds() > d.deadline {
+			d.err = ErrTimeout
+			return false
+		}
+		if canceled(d.cancel) {
+			d.err = ErrCanceled
+			return false
+		}
+	}
+	return true
+}
+
+/* leave - return from an element entered before, passing on ok
+ */
+func (d *Doc) leave(ok bool) bool {
+	d.depth--
+	return ok
+}
+
+/* checkSize - return an empty document reporting ErrTooLarge, if the
+ * length n of a text exceeds the size limit, or nil
+ */
+func (p *Parser) checkSize(n int64) *Doc {
+	if p.Limits.Size <= 0 || n <= int64(p.Limits.Size) {
+		return nil
+	}
+	d := p.newDoc()
+	d.err = ErrTooLarge
+	return d
+}
+
+/* limitReader - return a Reader that stops reading r after one byte
+ * more than the size limit, so that exceeding it can be detected
+ */
+func (p *Parser) limitReader(r io.Reader) io.Reader {
+	if p.Limits.Size <= 0 {
+		return r
+	}
+	return io.LimitReader(r, int64(p.Limits.Size)+1)
+}
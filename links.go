@@ -0,0 +1,69 @@
+package markdown
+
+// Extracting the links and images of a document
+
+// A Link is a link or an image found by Doc.Links or Doc.Images.
+type Link struct {
+	URL		string		// the destination of a link, the source of an image
+	Title	string		// the title, or ""
+	Text	string		// the text of a link, the alternate text of an image, without markup
+	Label	string		// the label of the link definition used, or ""
+	Line	int			// the first line of the top level block it is part of, or 0
+	Element	*Element	// the LINK or IMAGE element
+}
+
+// Links returns the links of the document in order, including
+// autolinks and the links within footnotes, with their destinations
+// as parsed, not as rewritten by ResolveURL.  The links within a
+// footnote are returned at its first reference, with the line of
+// the block referring to it.
+func (d *Doc) Links() []Link {
+	return d.collectLinks(LINK)
+}
+
+// Images returns the images of the document in order, like Links.
+func (d *Doc) Images() []Link {
+	return d.collectLinks(IMAGE)
+}
+
+func (d *Doc) collectLinks(kind int) []Link {
+	c := &linkCollector{kind: kind, notes: make(map[*Element]bool)}
+	d.Walk(c)
+	return c.list
+}
+
+/* a linkCollector collects the elements of a kind, LINK or IMAGE,
+ * visiting the contents of each footnote once
+ */
+type linkCollector struct {
+	kind	int
+	line	int	/* The line of the top level block being visited. */
+	notes	map[*Element]bool
+	list	[]Link
+}
+
+func (c *linkCollector) Visit(e *Element) Visitor {
+	if e == nil {
+		return nil
+	}
+	if e.line != 0 {
+		c.line = e.line
+	}
+	switch e.key {
+	case REFERENCE:
+		return nil
+	case NOTE:
+		if e.contents.str != "" || c.notes[e.children] {
+			return nil
+		}
+		c.notes[e.children] = true
+	case c.kind:
+		l := e.contents.link
+		link := Link{URL: l.url, Title: l.title, Text: plainText(l.label), Line: c.line, Element: e}
+		if l.ref != nil {
+			link.Label = plainText(l.ref.label)
+		}
+		c.list = append(c.list, link)
+	}
+	return c
+}
@@ -0,0 +1,263 @@
+package markdown
+
+// Lists following CommonMark more closely, see Extensions.StrictLists
+
+import (
+	"bytes"
+	"strings"
+)
+
+/* strictIndent - return text with the lines of list items indented
+ * again, so that the grammar, which nests blocks by four spaces per
+ * level, nests them like CommonMark: the blocks of an item, including
+ * nested lists, are the lines indented at least as far as the text
+ * following its marker, as in
+ *
+ *	1. item
+ *	   - nested item
+ *
+ * and continued paragraphs.  Lines are neither added nor removed,
+ * and those outside of lists are left alone.  If parens is set, items
+ * may be numbered like 1), too.
+ */
+func strictIndent(text string, parens bool) string {
+	var b bytes.Buffer
+	var cols []int	/* columns of the contents of the open items */
+	para := false	/* true if the previous line continues a paragraph */
+	fence := ""		/* the fence of an open fenced code block */
+	fenceDepth := 0	/* the number of items containing it */
+
+	for _, line := range strings.SplitAfter(text, "\n", -1) {
+		body := strings.TrimRight(line, "\r\n")
+		n, rest := indentation(body)
+		if rest == "" {
+			b.WriteString(line)
+			para = false
+			continue
+		}
+
+		/* close the items the line isn't indented for, unless it
+		 * continues a paragraph
+		 */
+		k := len(cols)
+		for k > 0 && n < cols[k-1] {
+			k--
+		}
+		lazy := k < len(cols) && para && fence == "" && !startsBlock(rest) && listMarker(rest, parens) == 0
+		if lazy {
+			k = len(cols)
+		}
+		if fence != "" && k < fenceDepth {
+			fence = ""
+		}
+		cols = cols[:k]
+		extra := n
+		if k > 0 {
+			extra = n - cols[k-1]
+		}
+		if lazy || extra < 0 {
+			extra = 0
+		}
+
+		switch {
+		case fence != "":
+			if extra <= 3 && closesFence(rest, fence) {
+				fence = ""
+			}
+		case extra <= 3 && (k > 0 || !para) && !isHRule(rest) && listMarker(rest, parens) > 0:
+			/* a new item, within the k items left */
+			w := listMarker(rest, parens)
+			r := rest[w:]
+			col := n + w + 1
+			if s := len(r) - len(strings.TrimLeft(r, " ")); s <= 4 && s < len(r) {
+				col = n + w + s
+			}
+			cols = append(cols, col)
+			extra = 0
+			para = !startsBlock(strings.TrimLeft(r, " ")) && strings.TrimSpace(r) != ""
+		case extra <= 3 && openFence(rest) != "":
+			fence = openFence(rest)
+			fenceDepth = k
+			para = false
+		default:
+			para = !startsBlock(rest) && (extra < 4 || para)
+		}
+		if k == 0 {
+			b.WriteString(line)
+			continue
+		}
+		b.WriteString(strings.Repeat(" ", 4*k+extra))
+		b.WriteString(rest)
+		b.WriteString(line[len(body):])
+	}
+	return b.String()
+}
+
+/* indentation - return the column of the first character of line
+ * other than a space or tab, and the text starting there
+ */
+func indentation(line string) (col int, rest string) {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case ' ':
+			col++
+		case '\t':
+			col += TABSTOP - col%TABSTOP
+		default:
+			return col, line[i:]
+		}
+	}
+	return col, ""
+}
+
+/* listMarker - return the length of the list marker s starts with, a
+ * bullet, or a number followed by '.', or, if parens is set, ')', or 0
+ */
+func listMarker(s string, parens bool) int {
+	n := 0
+	switch {
+	case s[0] == '-' || s[0] == '+' || s[0] == '*':
+		n = 1
+	case s[0] >= '0' && s[0] <= '9':
+		for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+			n++
+		}
+		if n == len(s) || s[n] != '.' && (!parens || s[n] != ')') {
+			return 0
+		}
+		n++
+	default:
+		return 0
+	}
+	if n < len(s) && s[n] != ' ' && s[n] != '\t' {
+		return 0
+	}
+	return n
+}
+
+/* startsBlock - report whether a line starting with s can't continue
+ * a paragraph: a heading, block quote, horizontal rule, or fence
+ */
+func startsBlock(s string) bool {
+	return s == "" || s[0] == '#' || s[0] == '>' || isHRule(s) || openFence(s) != ""
+}
+
+/* isHRule - report whether s is a horizontal rule, like - - - */
+func isHRule(s string) bool {
+	s = strings.TrimSpace(s)
+	if len(s) < 3 || strings.Index("-*_", s[:1]) == -1 {
+		return false
+	}
+	return len(strings.Replace(strings.Replace(s, s[:1], "", -1), " ", "", -1)) == 0 && strings.Count(s, s[:1]) >= 3
+}
+
+/* startList - remember the kind of marker of the list starting at
+ * pos
+ */
+func (d *Doc) startList(pos int) bool {
+	d.listKind = markerKind(d.parser.Buffer[pos:])
+	return true
+}
+
+/* sameKind - report whether the item at pos has the same kind of
+ * marker as the first one of its list, if the StrictLists extension
+ * is enabled: the same bullet, or the same delimiter of the number
+ */
+func (d *Doc) sameKind(pos int) bool {
+	return !d.extension.StrictLists || markerKind(d.parser.Buffer[pos:]) == d.listKind
+}
+
+func markerKind(s string) string {
+	s = strings.TrimLeft(s, " ")
+	if n := strings.IndexAny(s, "-+*:~.)"); n != -1 && strings.Trim(s[:n], "0123456789") == "" {
+		return s[n : n+1]
+	}
+	return ""
+}
+
+/* looseList - decide, for the StrictLists extension, whether a list
+ * is loose, like CommonMark does: if blank lines separate two of its
+ * items, or two blocks directly contained in an item, but not if they
+ * are found in nested lists or fenced code only
+ */
+func looseList(list *Element) {
+	loose := false
+	for item := list.children; item != nil; item = item.next {
+		raw := item.children.contents.str
+		t := strings.TrimRight(raw, " \t\n")
+		if i := strings.Index(raw[len(t):], "\n"); i != -1 {
+			t = raw[:len(t)+i+1]
+		}
+		if item.next != nil && len(t) < len(raw) || looseItem(t) {
+			loose = true
+		}
+		item.children.contents.str = t
+	}
+	endItems(list, loose)
+}
+
+/* endItems - terminate the raw text of the items of list by a blank
+ * line, if it is loose, so that their paragraphs are printed with <p>
+ * tags
+ */
+func endItems(list *Element, loose bool) {
+	if loose {
+		for item := list.children; item != nil; item = item.next {
+			item.children.contents.str += "\n\n"
+		}
+	}
+	list.loose = loose
+}
+
+/* tightList - print the paragraphs of the items of a tight list
+ * without <p> tags, like those followed by a fenced code block
+ */
+func tightList(list *Element) {
+	for item := list.children; item != nil; item = item.next {
+		for l := item.children; l != nil; l = l.next {
+			if l.key != LIST {
+				continue
+			}
+			for e := l.children; e != nil; e = e.next {
+				if e.key == PARA {
+					e.key = PLAIN
+				}
+			}
+		}
+	}
+}
+
+/* looseItem - report whether the raw text of an item, without blank
+ * lines at its end, has blank lines between the blocks it contains
+ * directly
+ */
+func looseItem(raw string) bool {
+	blank := false
+	nested := false	/* true within a nested list */
+	fence := ""
+	for _, line := range strings.Split(strings.Replace(raw, "\001", "", -1), "\n", -1) {
+		n, rest := indentation(line)
+		if fence != "" {
+			if closesFence(line, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if rest == "" {
+			blank = true
+			continue
+		}
+		marker := n < 4 && !isHRule(rest) && listMarker(rest, true) > 0
+		if blank && !(nested && (n >= 4 || marker)) {
+			return true
+		}
+		blank = false
+		if marker {
+			nested = true
+		}
+		if n < 4 && openFence(rest) != "" {
+			fence = openFence(rest)
+		}
+	}
+	return false
+}
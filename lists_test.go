@@ -0,0 +1,30 @@
+package markdown
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestCommonMarkLists checks that a change of the bullet, or of the
+// delimiter of the numbers, starts a new list in CommonMark mode.
+func TestCommonMarkLists(t *testing.T) {
+	for _, c := range []struct {
+		text	string
+		ul, ol	int
+	}{
+		{"- a\n- b\n", 1, 0},
+		{"- a\n- b\n+ c\n* d\n", 3, 0},
+		{"- a\n\n- b\n\n+ c\n", 2, 0},
+		{"1. a\n2. b\n", 0, 1},
+		{"1. a\n2) b\n3) c\n", 0, 2},
+		{"- a\n  * b\n- c\n", 2, 0},
+	} {
+		var b bytes.Buffer
+		NewParser(Extensions{CommonMark: true}).Parse(c.text).WriteHtml(&b)
+		s := b.String()
+		if ul, ol := strings.Count(s, "<ul>"), strings.Count(s, "<ol"); ul != c.ul || ol != c.ol {
+			t.Errorf("%q: %q, %d <ul> and %d <ol>, want %d and %d", c.text, s, ul, ol, c.ul, c.ol)
+		}
+	}
+}
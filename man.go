@@ -0,0 +1,306 @@
+package markdown
+
+// Man page output functions, using the man macros of groff
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// A ManPage describes the header of the man page written by WriteMan.
+type ManPage struct {
+	Name	string	// if empty, the front matter title
+	Section	string	// if empty, the front matter section, or "1"
+	Date	string	// if empty, the front matter date
+	Source	string	// e.g. the name and version of the program
+	Manual	string	// title of the manual, e.g. "User Commands"
+}
+
+/* manOut prints inline elements like groffOut, and block elements
+ * using the man macros
+ */
+type manOut struct {
+	groffOut
+	depth	int		/* Nesting level of lists, whose paragraphs are indented. */
+	first	bool	/* True until the first block of an item has been started. */
+	term	bool	/* True after the term of a definition. */
+	lists	[]mdList
+	quotes	[]int	/* Nesting levels of lists enclosing block quotes. */
+	notes	[]func()
+}
+
+// WriteMan prints a document tree as a man page to the specified
+// Writer, with the header described by page, which may be nil.
+// Level 1 headings start sections (.SH), level 2 headings
+// subsections (.SS); lists, and definition lists, are printed as
+// indented paragraphs (.IP and .TP), and notes in a section NOTES
+// at the end.
+// It returns the number of bytes written, and the first error
+// returned by w, if any, like WriteHtml.
+//
+func (d *Doc) WriteMan(w Writer, page *ManPage) (n int, err os.Error) {
+	var p ManPage
+	if page != nil {
+		p = *page
+	}
+	if m := d.Meta(); m != nil {
+		if p.Name == "" {
+			p.Name = m.Values["title"]
+		}
+		if p.Section == "" {
+			p.Section = m.Values["section"]
+		}
+		if p.Date == "" {
+			p.Date = m.Values["date"]
+		}
+	}
+	if p.Section == "" {
+		p.Section = "1"
+	}
+
+	out := new(manOut)
+	dest := &errWriter{w: w}
+	out.Writer = dest
+	out.bol = true
+	if hasTable(d.tree) {
+		/* tell man to run tbl */
+		out.s("'\\\" t\n")
+	}
+	args := []string{strings.ToUpper(p.Name), p.Section, p.Date, p.Source, p.Manual}
+	for args[len(args)-1] == "" {
+		args = args[:len(args)-1]
+	}
+	out.s(".TH")
+	for _, a := range args {
+		out.s(" ").s(manArg(a))
+	}
+	out.s("\n").pset(1)
+	d.Render(out)
+	if len(out.notes) > 0 {
+		out.block().s(".SH NOTES").pset(0)
+		out.depth = 1
+		for i := 0; i < len(out.notes); i++ {
+			body := out.notes[i]
+			out.block().s(".IP [" + strconv.Itoa(i+1) + "] 5\n").pset(1)
+			out.first = true
+			body()
+		}
+	}
+	out.WriteByte('\n')
+	return dest.n, dest.err
+}
+
+/* manArg - quote s as an argument of a macro
+ */
+func manArg(s string) string {
+	s = strings.Replace(s, `\`, `\e`, -1)
+	return `"` + strings.Replace(s, `"`, `\(dq`, -1) + `"`
+}
+
+func hasTable(list *Element) bool {
+	for e := list; e != nil; e = e.next {
+		if e.key == TABLE || hasTable(e.children) {
+			return true
+		}
+	}
+	return false
+}
+
+/* para - start a paragraph: the first one of a list item follows
+ * the item's tag, later ones are indented like it
+ */
+func (w *manOut) para() {
+	w.block()
+	switch {
+	case w.first:
+		w.first = false
+	case w.depth > 0:
+		w.s(".IP\n")
+	default:
+		w.s(".PP\n")
+	}
+}
+
+/* Renderer methods
+ */
+
+func (w *manOut) Code(s string) {
+	w.s(`\fB`).str(s).s(`\fR`).pset(0)
+}
+
+func (w *manOut) Format() string {
+	return "man"
+}
+
+func (w *manOut) Note(n int, body func()) {
+	w.s("[" + strconv.Itoa(n) + "]").pset(0)
+	w.notes = append(w.notes, body)
+}
+
+func (w *manOut) Heading(level int, id string, entering bool) {
+	if !entering {
+		if level <= 2 {
+			w.arg(false).pset(0)
+		} else {
+			w.s(`\fR`).pset(0)
+		}
+		return
+	}
+	w.block()
+	switch level {
+	case 1:
+		w.s(".SH ").arg(true)
+	case 2:
+		w.s(".SS ").arg(true)
+	default:
+		w.s(".PP\n\\fB")
+	}
+	w.depth = 0
+	w.first = false
+}
+
+func (w *manOut) Plain(entering bool) {
+	if entering {
+		w.para()
+	} else {
+		w.pset(0)
+	}
+}
+
+func (w *manOut) Para(entering bool) {
+	if entering {
+		w.para()
+	} else {
+		w.pset(0)
+	}
+}
+
+func (w *manOut) Figure(caption string, entering bool) {
+	w.Para(entering)
+}
+
+func (w *manOut) Verbatim(s, lang string) {
+	w.para()
+	w.s(".RS 4\n.nf\n")
+	for _, l := range strings.SplitAfter(strings.TrimRight(s, "\n")+"\n", "\n", -1) {
+		if strings.HasPrefix(l, ".") || strings.HasPrefix(l, "'") {
+			w.s(`\&`)
+		}
+		w.s(strings.Replace(l, `\`, `\e`, -1))
+	}
+	w.s(".fi\n.RE").pset(0)
+}
+
+func (w *manOut) BlockQuote(entering bool) {
+	if entering {
+		w.para()
+		w.s(".RS 4").pset(0)
+		w.quotes = append(w.quotes, w.depth)
+		w.depth = 0
+		return
+	}
+	w.depth = w.quotes[len(w.quotes)-1]
+	w.quotes = w.quotes[:len(w.quotes)-1]
+	w.pad(1).s(".RE").pset(0)
+}
+
+func (w *manOut) Admonition(kind string, entering bool) {
+	w.BlockQuote(entering)
+}
+
+func (w *manOut) AdmonitionTitle(entering bool) {
+	if entering {
+		w.para()
+		w.s(`\fB`)
+	} else {
+		w.s(`\fR`).pset(0)
+	}
+}
+
+func (w *manOut) Div(class string, entering bool) {
+	/* print the contents only */
+}
+
+func (w *manOut) list(l mdList, entering bool) {
+	if entering {
+		if w.depth > 0 {
+			/* a nested list */
+			w.block().s(".RS").pset(0)
+		}
+		w.depth++
+		w.lists = append(w.lists, l)
+		return
+	}
+	w.lists = w.lists[:len(w.lists)-1]
+	w.first = false
+	if w.depth--; w.depth > 0 {
+		w.pad(1).s(".RE").pset(0)
+	}
+}
+
+func (w *manOut) BulletList(entering bool) {
+	w.list(mdList{}, entering)
+}
+
+func (w *manOut) OrderedList(start int, delim string, entering bool) {
+	w.list(mdList{ordered: true, n: start - 1, delim: delim}, entering)
+}
+
+func (w *manOut) item(tag string, entering bool) {
+	if !entering {
+		return
+	}
+	l := &w.lists[len(w.lists)-1]
+	l.n++
+	switch {
+	case tag != "":
+	case l.ordered:
+		tag = strconv.Itoa(l.n) + l.delim + " 4"
+	default:
+		tag = `\(bu 2`
+	}
+	w.block().s(".IP " + tag + "\n").pset(1)
+	w.first = true
+}
+
+func (w *manOut) ListItem(entering bool) {
+	w.item("", entering)
+}
+
+func (w *manOut) TaskItem(done bool, entering bool) {
+	if done {
+		w.item(`\[OK] 2`, entering)
+	} else {
+		w.item(`\[sq] 2`, entering)
+	}
+}
+
+func (w *manOut) DefinitionList(entering bool) {
+	w.list(mdList{}, entering)
+}
+
+func (w *manOut) DefTitle(entering bool) {
+	if !entering {
+		w.s("\n").pset(1)
+		w.term = true
+		return
+	}
+	w.block()
+	if w.term {
+		/* another term of the same definition */
+		w.s(".TQ\n")
+	} else {
+		w.s(".TP\n")
+	}
+}
+
+func (w *manOut) DefData(entering bool) {
+	if entering {
+		w.first = w.term
+		w.term = false
+	}
+}
+
+func (w *manOut) TOC(toc []*TOCItem) {
+}
@@ -19,10 +19,14 @@ package markdown
 
 // implements Parse()
 
+//go:generate make parser.leg.go
+
 import (
 	"strings"
 	"bytes"
+	"io"
 	"log"
+	"os"
 )
 
 // Markdown Extensions:
@@ -32,29 +36,384 @@ type Extensions struct {
 	FilterHTML		bool
 	FilterStyles	bool
 	Dlists			bool
+	Tables			bool
+	FencedCode		bool
+	FrontMatter		bool
+	Safe			bool
+	TOC				bool
+	GFM				bool
+	Strike			bool
+	Autolink		bool
+	HardWraps		bool
+	Math			bool
+	CommonMark		bool
+	HeadingIDs		bool
+	Attributes		bool
+	TaskLists		bool
+	Abbreviations	bool
+	SupSub			bool
+	Emoji			bool
+	WikiLinks		bool
+	Admonitions		bool
+	FencedDivs		bool
+	Citations		bool
+	ListStart		bool
+	StrictLists		bool
+	Templates		bool
 }
 
 
+// A Parser converts Markdown documents into trees, using a fixed
+// set of extensions. Its internal state is allocated once and
+// reused by subsequent calls of its Parse methods. A Parser must not
+// be used by multiple goroutines at the same time, but separate
+// Parsers may run concurrently.
+type Parser struct {
+	yy	*yyParser
+	ext	Extensions
+	pf	*preformatter	/* Reused for each document. */
+
+	cancel	<-chan struct{}	/* Set while ParseCancel is running. */
+
+	anchors	map[string]bool	/* Ids of headings used by earlier documents of a Session. */
+
+	// Link definitions made available to each document parsed,
+	// indexed by label. Definitions within a document take
+	// precedence.
+	References	map[string]Reference
+
+	// Options of the Smart extension.
+	Smart	SmartOptions
+
+	// Options of the Emoji extension.
+	Emoji	EmojiOptions
+
+	// Pairs of the opening and closing delimiters of the template
+	// spans passed through unchanged, if the Templates extension is
+	// enabled, like "{{", "}}"; if empty, DefaultTemplateDelimiters.
+	TemplateDelimiters	[]string
+
+	// If not nil, WikiLink is called with the target of each link
+	// [[target]] or [[target|label]], if the WikiLinks extension is
+	// enabled, and returns its URL; in HTML output, links reported
+	// as missing get the class "missing". By default, the target is
+	// used as URL.
+	WikiLink	func(target string) (url string, missing bool)
+
+	// If not nil, Include is called for each line of a document,
+	// outside of code blocks, consisting of a directive
+	//
+	//	<!--#include file="name"-->
+	//
+	// or !include name, to load the named file, whose text
+	// replaces the line before the document is parsed. Names in
+	// included files are relative to their directory; IncludeDir
+	// loads the files below a directory only.  Cycles, and files
+	// that can't be loaded, are reported by Doc.Warnings.  Lines,
+	// like those of Element.Lines, count the lines included.
+	Include	func(name string) ([]byte, os.Error)
+
+	// HTMLPolicy selects how raw HTML, blocks as well as tags and
+	// comments within text, is treated; see HTMLDefault. If it is
+	// HTMLFilter, FilterHTML is called for each of them, and returns
+	// the HTML to print in its place, or "" to drop it; the end tag
+	// of a start tag dropped within text is dropped, too.
+	HTMLPolicy	int
+	FilterHTML	func(html string, block bool) string
+
+	// CommentPolicy selects how HTML comments, like <!-- toc -->
+	// or <!-- vale off -->, are treated, overriding HTMLPolicy; see
+	// CommentsDefault. If it is CommentsFilter, FilterComment is
+	// called for each of them with its text, like "toc", and
+	// returns the HTML to print in its place, or "" to drop it.
+	// Comments kept are found in the tree as HTML and HTMLBLOCK
+	// elements; see Element.Comment. MoreMarker and NotesMarker
+	// are comments, too.
+	CommentPolicy	int
+	FilterComment	func(text string, block bool) string
+
+	// If not nil, FilterText is called after parsing for the text
+	// of each STR element, a word, or text not interrupted by
+	// spaces, with the elements containing it, outermost first, and
+	// returns the text replacing it, e.g. to mask words, or to
+	// redact secrets.  Its output is seen by all output formats,
+	// and by Doc.TOC.  The text of code spans and blocks, raw HTML
+	// and math is not passed; parents must not be retained.
+	FilterText	func(text string, parents []*Element) string
+
+	// Works that may be cited, if the Citations extension is
+	// enabled; see ReadCSLJSON and ReadBibTeX.
+	Bibliography	Bibliography
+
+	// If not nil, Slugger is used instead of Slug to derive
+	// the ids of headings from their text, if the HeadingIDs
+	// extension is enabled. Duplicates are made unique by
+	// appending a number.
+	Slugger	func(text string) string
+
+	// Width of the tab stops used to expand tabs into spaces before
+	// parsing; if zero, 4.  Code blocks and nested lists are still
+	// indented by four columns.
+	TabWidth	int
+
+	// If true, tabs are not expanded, except within the indentation
+	// of a line where they don't start at a tab stop, so tabs in code
+	// blocks are printed literally.  A tab at the start of a line,
+	// or following other indentation at a tab stop, counts as one
+	// level of indentation, regardless of TabWidth.
+	LiteralTabs	bool
+
+	// If true, the input is normalized before parsing: a UTF-8 byte
+	// order mark at its start is removed, input starting with a
+	// UTF-16 byte order mark is converted into UTF-8, and CR LF and
+	// CR line endings are replaced by newlines, as in files saved
+	// by some Windows editors.
+	NormalizeInput	bool
+
+	// Limits for the size of documents, the nesting of their
+	// elements, and the time spent parsing; see Doc.Err.
+	Limits	Limits
+}
+
+// NewParser returns a Parser for documents using the extensions ext.
+func NewParser(ext Extensions) *Parser {
+	p := new(Parser)
+	if ext.GFM {
+		ext.FencedCode = true
+		ext.Tables = true
+		ext.Strike = true
+		ext.Autolink = true
+	}
+	if ext.CommonMark {
+		ext.FencedCode = true
+		ext.ListStart = true
+		ext.StrictLists = true
+	}
+	if ext.TOC {
+		ext.HeadingIDs = true
+	}
+	p.ext = ext
+	p.yy = new(yyParser)
+	p.yy.Init()
+	return p
+}
+
+// Reset clears the options of p, like References, Smart, Limits, or
+// WikiLink, so that it is like a Parser returned by NewParser for
+// its extensions, but keeps the state and the buffers allocated by
+// earlier calls; it is meant for servers that keep Parsers for
+// reuse, e.g. in a sync.Pool, instead of creating one for each
+// request.  Reset also recovers the parser from a parse that has
+// been interrupted by a panic, e.g. from a WikiLink function.
+func (p *Parser) Reset() {
+	*p = Parser{yy: p.yy, ext: p.ext, pf: p.pf}
+	p.yy.ResetBuffer("")
+	p.yy.Doc = nil
+}
+
+// Clone returns a new Parser with the extensions and options of p,
+// e.g. for another goroutine converting documents at the same time.
+// Options like References and Bibliography are shared, and must not
+// be modified while either Parser is in use.  So are the functions,
+// like FilterHTML, Include or WikiLink, which are then called by
+// several goroutines; those keeping state must be replaced in the
+// clone.  The filters of AllowTags and IncludeDir keep none.  The
+// heading ids used by a Session are not copied.
+func (p *Parser) Clone() *Parser {
+	q := new(Parser)
+	*q = *p
+	q.yy = new(yyParser)
+	q.yy.Init()
+	q.pf = nil
+	q.anchors = nil
+	return q
+}
+
 // Parse converts a Markdown document into a tree for later output processing.
 func Parse(text string, ext Extensions) *Doc {
-	d := new(Doc)
-	d.extension = ext
+	return NewParser(ext).Parse(text)
+}
 
-	d.parser = new(yyParser)
-	d.parser.Doc = d
-	d.parser.Init()
+// ParseBytes is like Parse, but reads the document from a byte slice.
+func ParseBytes(text []byte, ext Extensions) *Doc {
+	return NewParser(ext).ParseBytes(text)
+}
 
-	s := preformat(text)
+// ParseReader is like Parse, but reads the document from r until EOF.
+// Tabs are expanded while reading, without holding the input as a whole;
+// the expanded text is copied once more into the string the parser
+// works on, as with Parse and ParseBytes.
+func ParseReader(r io.Reader, ext Extensions) (*Doc, os.Error) {
+	return NewParser(ext).ParseReader(r)
+}
 
-	d.parseRule(ruleReferences, s)
-	if ext.Notes {
-		d.parseRule(ruleNotes, s)
+// Parse converts a Markdown document into a tree for later output processing.
+func (p *Parser) Parse(text string) *Doc {
+	if d := p.checkSize(int64(len(text))); d != nil {
+		return d
+	}
+	pf := p.preformat(len(text))
+	io.Copy(pf, strings.NewReader(text))
+	return p.parse(pf.text())
+}
+
+// ParseBytes is like Parse, but reads the document from a byte slice.
+func (p *Parser) ParseBytes(text []byte) *Doc {
+	if d := p.checkSize(int64(len(text))); d != nil {
+		return d
+	}
+	pf := p.preformat(len(text))
+	pf.Write(text)
+	return p.parse(pf.text())
+}
+
+// ParseReader is like Parse, but reads the document from r until EOF.
+// If a limit has been exceeded, the error reported by Doc.Err is
+// returned together with the empty document.
+func (p *Parser) ParseReader(r io.Reader) (*Doc, os.Error) {
+	pf := p.preformat(0)
+	n, err := io.Copy(pf, p.limitReader(r))
+	if err != nil {
+		return nil, err
+	}
+	if d := p.checkSize(n); d != nil {
+		return d, d.err
+	}
+	d := p.parse(pf.text())
+	return d, d.err
+}
+
+// ParseInline parses text, typically a single line like a title, as
+// span-level content only: the top level list of the tree holds
+// inline elements, which WriteHtml prints without enclosing <p>
+// tags, followed by a newline.  Line breaks in text are treated like
+// spaces.
+func ParseInline(text string, ext Extensions) *Doc {
+	return NewParser(ext).ParseInline(text)
+}
+
+// ParseInline is like the function ParseInline, using the
+// extensions and options of p.
+func (p *Parser) ParseInline(text string) *Doc {
+	if d := p.checkSize(int64(len(text))); d != nil {
+		return d
+	}
+	pf := p.preformat(len(text))
+	io.Copy(pf, strings.NewReader(text))
+	s := strings.TrimSpace(strings.Replace(pf.text(), "\n", " ", -1))
+	d, _, _ := p.start(s + "\n")
+	if s != "" {
+		d.parseRule(ruleInlineDoc, s)
+		d.parser.ResetBuffer("")
+		d.tree = d.processRawBlocks(d.tree)
+	}
+	if d.abbreviations != nil {
+		d.markAbbreviations(d.tree)
+	}
+	d.typeset(d.tree)
+	d.checkReferences()
+	if d.err != nil {
+		d.tree = nil
+	}
+	d.detach()
+	return d
+}
+
+func (p *Parser) parse(s string) *Doc {
+	s, included := p.expandIncludes(s)
+	d, s, line0 := p.start(s)
+	for _, w := range included {
+		e := mk_element(LIST)
+		e.line = w.Line
+		d.warn(e, w.Msg)
 	}
 	raw := d.parseMarkdown(s)
+	spans := d.spans
+	d.spans = nil
 	d.tree = d.processRawBlocks(raw)
+	if d.abbreviations != nil {
+		d.markAbbreviations(d.tree)
+	}
+	d.typeset(d.tree)
+	setLines(d.tree, spans, s, line0)
+	d.blocks = blockLines(spans, s, line0)
+	d.addBibliography()
+	d.checkReferences()
+	if p.ext.HeadingIDs {
+		used := p.anchors
+		if used == nil {
+			used = make(map[string]bool)
+		}
+		d.setAnchors(used)
+	}
+	if d.err != nil {
+		d.tree = nil
+	}
+	d.detach()
 	return d
 }
 
+/* start - create a Doc attached to the parser, and collect the front
+ * matter, link references and notes of the text.  Returned are the
+ * text following the front matter, and the number of lines skipped.
+ */
+func (p *Parser) start(s string) (d *Doc, body string, line0 int) {
+	d = p.newDoc()
+	d.setLimits(p.Limits, p.cancel)
+	d.parser = p.yy
+	d.parser.Doc = d
+
+	if p.ext.FrontMatter {
+		d.meta, body = splitFrontMatter(s)
+		line0 = strings.Count(s[:len(s)-len(body)], "\n")
+		s = body
+	}
+	if p.ext.StrictLists {
+		s = strictIndent(s, p.ext.ListStart)
+	}
+	d.parseRule(ruleReferences, s)
+	if p.References != nil {
+		d.addReferences(p.References)
+	}
+	if p.ext.Notes {
+		d.parseRule(ruleNotes, s)
+	}
+	if p.ext.Abbreviations {
+		d.parseRule(ruleAbbreviations, s)
+	}
+	d.warnings = nil	/* the document will be parsed again */
+	return d, s, line0
+}
+
+/* newDoc - create an empty Doc using the extensions and options of p */
+func (p *Parser) newDoc() *Doc {
+	d := new(Doc)
+	d.extension = p.ext
+	d.smart = p.Smart
+	d.slugger = p.Slugger
+	d.emoji = p.Emoji
+	d.wikiResolver = p.WikiLink
+	d.bibliography = p.Bibliography
+	d.htmlPolicy = p.HTMLPolicy
+	d.filterHTML = p.FilterHTML
+	d.commentPolicy = p.CommentPolicy
+	d.filterComment = p.FilterComment
+	d.filterText = p.FilterText
+	d.templates = p.TemplateDelimiters
+	if len(d.templates) == 0 {
+		d.templates = DefaultTemplateDelimiters
+	}
+	d.plugins = registered()
+	return d
+}
+
+/* detach the parser, so that it can be reused */
+func (d *Doc) detach() {
+	d.parser.ResetBuffer("")
+	d.parser.Doc = nil
+	d.parser = nil
+}
+
 func (d *Doc) parseRule(rule int, s string) {
 	m := d.parser
 	if m.ResetBuffer(s) != "" {
@@ -65,19 +424,152 @@ func (d *Doc) parseRule(rule int, s string) {
 	}
 }
 
-func (d *Doc) parseMarkdown(text string) *element {
-	d.parseRule(ruleDoc, text)
+/* parseInlines - replace the text of the RAW element raw by the
+ * inline elements parsed from it
+ */
+func (d *Doc) parseInlines(raw *Element) {
+	d.parseRule(ruleInlineDoc, raw.contents.str)
+	d.parser.ResetBuffer("")
+	raw.key = LIST
+	raw.contents.str = ""
+	raw.children = d.tree
+	d.tree = nil
+}
+
+/* parseMarkdown - parse the blocks of text, and return them as a list.
+ * The parser is invoked for each block, which is committed before
+ * the next one is parsed; so, unlike with a rule matching the whole
+ * text, the buffer of pending actions stays small.
+ */
+func (d *Doc) parseMarkdown(text string) *Element {
+	d.spans = d.spans[:0]
+	m := d.parser
+	if m.ResetBuffer(text) != "" {
+		log.Fatalf("Buffer not empty")
+	}
+	var list *Element
+	for pos := 0; pos < len(text) && m.Parse(ruleDocBlock) && m.Min > pos; pos = m.Min {
+		list = cons(d.tree, list)
+	}
+	m.ResetBuffer("")
+	d.tree = reverse(list)
 	return d.tree
 }
 
+/* mark - called by the Doc rule before and after each top level
+ * block, records the current position of the parser.
+ */
+func (d *Doc) mark(pos int) bool {
+	d.spans = append(d.spans, pos)
+	return true
+}
+
+/* setLines - assigns source lines to the top level blocks in list,
+ * using the offsets recorded by mark, and to their descendants, and
+ * columns to the top level blocks.  The lines of a block don't include
+ * surrounding blank lines.
+ */
+func setLines(list *Element, spans []int, text string, line0 int) {
+	line := line0 + 1
+	pos := 0
+	bol := 0	/* Offset of the start of the line. */
+	advance := func(to int) {
+		line += strings.Count(text[pos:to], "\n")
+		if i := strings.LastIndex(text[pos:to], "\n"); i != -1 {
+			bol = pos + i + 1
+		}
+		pos = to
+	}
+	for e := list; e != nil && len(spans) >= 2; e = e.next {
+		t := text[spans[0]:spans[1]]
+		first := spans[0] + len(t) - len(strings.TrimLeft(t, " \r\n"))
+		last := spans[0] + len(strings.TrimRight(t, " \r\n"))
+		if last < first {
+			last = first
+		}
+		advance(first)
+		e.line = line
+		e.col = first - bol + 1
+		if last > first {
+			advance(last - 1)
+		}
+		e.endLine = line
+		e.endCol = pos - bol + 1
+		inheritLines(e.children, e.line, e.endLine)
+		if l := e.Label(); l != nil {
+			inheritLines(l, e.line, e.endLine)
+		}
+		pos = last
+		spans = spans[2:]
+	}
+}
+
+/* blockLines - return the lines at which the spans of the top level
+ * blocks start, including preceding blank lines, followed by the line
+ * at which the last one ends.  A line is negated, if the span starts,
+ * or ends, within it.
+ */
+func blockLines(spans []int, text string, line0 int) []int {
+	lines := make([]int, 0, len(spans)/2+1)
+	line := line0 + 1
+	pos := 0
+	mark := func(p int) {
+		line += strings.Count(text[pos:p], "\n")
+		pos = p
+		if pos > 0 && text[pos-1] != '\n' {
+			lines = append(lines, -line)
+		} else {
+			lines = append(lines, line)
+		}
+	}
+	for i := 0; i+1 < len(spans); i += 2 {
+		mark(spans[i])
+	}
+	if n := len(spans); n >= 2 {
+		mark(spans[n-1])
+	}
+	return lines
+}
+
+func inheritLines(list *Element, first, last int) {
+	for e := list; e != nil; e = e.next {
+		e.line, e.endLine = first, last
+		if e.key == NOTE && e.contents.str == "" && e.children != nil && e.children.line != 0 {
+			/* the contents are shared with the list of notes,
+			 * and have the lines of the definition, or of an
+			 * earlier reference, which the definition overrides
+			 */
+			continue
+		}
+		if l := e.Label(); l != nil {
+			inheritLines(l, first, last)
+		}
+		inheritLines(e.children, first, last)
+	}
+}
+
 
 /* process_raw_blocks - traverses an element list, replacing any RAW elements with
  * the result of parsing them as markdown text, and recursing into the children
  * of parent elements.  The result should be a tree of elements without any RAWs.
  */
-func (d *Doc) processRawBlocks(input *element) *element {
+func (d *Doc) processRawBlocks(input *Element) *Element {
 
 	for current := input; current != nil; current = current.next {
+		if current.key == NOTE && current.contents.str != "" {
+			/* a definition takes the contents of the note the
+			 * references share, so that they are parsed once, and
+			 * problems found in them have the lines of the
+			 * definition, see setLines
+			 */
+			if n, ok := d.find_note(current.contents.str); ok && !d.shared[n] {
+				if d.shared == nil {
+					d.shared = make(map[*Element]bool)
+				}
+				d.shared[n] = true
+				current.children = n.children
+			}
+		}
 		if current.key == RAW {
 			/* \001 is used to indicate boundaries between nested lists when there
 			 * is no blank line.  We split the string by \001 and parse
@@ -86,7 +578,11 @@ func (d *Doc) processRawBlocks(input *element) *element {
 			current.key = LIST
 			current.children = nil
 			listEnd := &current.children
-			for _, contents := range strings.Split(current.contents.str, "\001", -1) {
+			chunks := []string{current.contents.str}
+			if strings.Index(current.contents.str, "\001") != -1 {
+				chunks = strings.Split(current.contents.str, "\001", -1)
+			}
+			for _, contents := range chunks {
 				if list := d.parseMarkdown(contents); list != nil {
 					*listEnd = list
 					for list.next != nil {
@@ -96,9 +592,37 @@ func (d *Doc) processRawBlocks(input *element) *element {
 				}
 			}
 			current.contents.str = ""
+			/* the parsed blocks take the place of the raw text */
+			current.children = d.processRawBlocks(current.children)
+			continue
+		}
+		note := current.key == NOTE && current.children != nil
+		switch {
+		case current.key == ADMONITION && current.Label() != nil:
+			/* the title */
+			d.parseInlines(current.Label())
+		case current.key >= numVAL:
+			d.pluginInlines(current)
+		case note:
+			/* a note referring to itself, directly or through
+			 * other notes, would make a cycle; see NoteReference
+			 */
+			if d.expanding == nil {
+				d.expanding = make(map[*Element]bool)
+			}
+			d.expanding[current.children] = true
 		}
 		if current.children != nil {
-			current.children = d.processRawBlocks(current.children)
+			if d.enter() {
+				current.children = d.processRawBlocks(current.children)
+			}
+			d.leave(true)
+		}
+		if note {
+			d.expanding[current.children] = false, false
+		}
+		if d.extension.StrictLists && current.Tight() {
+			tightList(current)
 		}
 	}
 	return input
@@ -109,34 +633,107 @@ const (
 	TABSTOP = 4
 )
 
-/* preformat - allocate and copy text buffer while
- * performing tab expansion.
+/* preformatter - copy text into a buffer while
+ * performing tab expansion. Text can be written
+ * in pieces, the current column is kept between
+ * calls to Write.
+ */
+type preformatter struct {
+	b			*bytes.Buffer
+	tabstop		int
+	charstotab	int
+	literal		bool	/* Keep tabs that the grammar accepts as indentation, or that follow the indentation. */
+	indent		bool	/* True within the leading white space of a line. */
+	norm		normalizer
+}
+
+func newPreformatter(size, tabstop int, literal, normalize bool) *preformatter {
+	p := &preformatter{b: bytes.NewBuffer(make([]byte, 0, size+256))}
+	p.reset(tabstop, literal, normalize)
+	return p
+}
+
+/* reset - empty the buffer, to preformat another text
+ */
+func (p *preformatter) reset(tabstop int, literal, normalize bool) {
+	if tabstop <= 0 {
+		tabstop = TABSTOP
+	}
+	p.b.Reset()
+	p.tabstop = tabstop
+	p.charstotab = tabstop
+	p.literal = literal
+	p.indent = true
+	p.norm.reset(normalize)
+}
+
+/* preformat - return a preformatter for a text of about size bytes,
+ * using the tab settings of p; its buffer is reused for each text.
  */
-func preformat(text string) (s string) {
-	charstotab := TABSTOP
+func (p *Parser) preformat(size int) *preformatter {
+	if p.pf == nil {
+		p.pf = newPreformatter(size, p.TabWidth, p.LiteralTabs, p.NormalizeInput)
+	} else {
+		p.pf.reset(p.TabWidth, p.LiteralTabs, p.NormalizeInput)
+	}
+	return p.pf
+}
+
+func (p *preformatter) Write(text []byte) (n int, err os.Error) {
+	if p.norm.on {
+		p.write(p.norm.normalize(text, false))
+	} else {
+		p.write(text)
+	}
+	return len(text), nil
+}
+
+func (p *preformatter) write(text []byte) {
+	b := p.b
+	charstotab := p.charstotab
+	indent := p.indent
 	i0 := 0
 
-	b := bytes.NewBuffer(make([]byte, 0, len(text)+256))
-	for i, _ := range text {
-		switch text[i] {
+	for i, c := range text {
+		switch c {
 		case '\t':
-			b.WriteString(text[i0:i])
+			if p.literal && (!indent || charstotab == p.tabstop) {
+				/* keep the tab, and continue at the next tab stop */
+				charstotab = 0
+				break
+			}
+			b.Write(text[i0:i])
 			for ; charstotab > 0; charstotab-- {
 				b.WriteByte(' ')
 			}
 			i0 = i + 1
 		case '\n':
-			b.WriteString(text[i0 : i+1])
+			b.Write(text[i0 : i+1])
 			i0 = i + 1
-			charstotab = TABSTOP
-		default:
+			charstotab = p.tabstop
+			indent = true
+		case ' ':
 			charstotab--
+		default:
+			if c&0xC0 != 0x80 {	/* count runes, not UTF-8 continuation bytes */
+				charstotab--
+			}
+			indent = false
 		}
 		if charstotab == 0 {
-			charstotab = TABSTOP
+			charstotab = p.tabstop
 		}
 	}
-	b.WriteString(text[i0:])
-	b.WriteString("\n\n")
-	return b.String()
+	b.Write(text[i0:])
+	p.charstotab = charstotab
+	p.indent = indent
+}
+
+// text returns the preformatted text, terminated by two newlines.
+func (p *preformatter) text() string {
+	if p.norm.on {
+		p.write(p.norm.normalize(nil, true))
+	}
+	p.b.WriteString("\n\n")
+	return p.b.String()
 }
@@ -0,0 +1,786 @@
+package markdown
+
+// Markdown output functions
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// A MarkdownStyle selects how WriteMarkdown prints a document.
+type MarkdownStyle struct {
+	Setext	bool	// underline headings of level 1 and 2 with = and -, instead of using #
+	Bullet	string	// marker of bullet list items: "-" (default), "*", or "+"
+	Fenced	bool	// print all code blocks fenced, not only those having a language
+
+	// If Wrap is greater than 0, paragraphs are wrapped to
+	// lines of at most Wrap characters; if it is 0, the line
+	// breaks of the source are kept; if it is less than 0,
+	// each paragraph is printed on a single line.
+	Wrap	int
+}
+
+/* a container block, which gets a prefix in front of its lines; first
+ * is used for the first line, e.g. the marker of a list item
+ */
+type mdFrame struct {
+	first, rest	string
+	used		bool
+}
+
+type mdList struct {
+	ordered	bool
+	n		int		/* Number of the last item. */
+	delim	string	/* Delimiter following the numbers of an ordered list. */
+}
+
+type mdOut struct {
+	Writer
+	style	MarkdownStyle
+	ext		Extensions
+
+	buf			bytes.Buffer	/* Inline text of the current block. */
+	frames		[]*mdFrame
+	lists		[]mdList
+	started		bool	/* True after the first line has been printed. */
+	blank		bool	/* True if a blank line is needed before the next block. */
+	afterList	bool	/* True right after the end of a list. */
+	afterDef	bool	/* True after the data of a definition. */
+	admonition	string	/* Kind of an admonition whose first line is pending. */
+	links		[]int	/* Offsets of the open links in buf. */
+	notes		[]func()
+	attr		*Attributes	/* Attributes of the next element, see Div. */
+	cite		int			/* Offset of the current citation in buf. */
+	outer		Writer		/* The output, while the bibliography is dropped. */
+
+	inCell	bool
+	row		[]string
+	rows	[][]string
+	nhead	int
+}
+
+// WriteMarkdown prints a document tree in Markdown format to the
+// specified Writer, normalizing its syntax according to style, which
+// may be nil.  Reference links are printed as inline links, notes,
+// including inline ones, as numbered notes at the end, and some
+// details of extensions, like abbreviation definitions, are not
+// reproduced.
+// It returns the number of bytes written, and the first error
+// returned by w, if any, like WriteHtml.
+//
+func (d *Doc) WriteMarkdown(w Writer, style *MarkdownStyle) (n int, err os.Error) {
+	out := new(mdOut)
+	dest := &errWriter{w: w}
+	out.Writer = dest
+	if style != nil {
+		out.style = *style
+	}
+	switch out.style.Bullet {
+	case "*", "+":
+	default:
+		out.style.Bullet = "-"
+	}
+	out.ext = d.extension
+	d.Render(out)
+	for i := 0; i < len(out.notes); i++ {
+		body := out.notes[i]
+		out.blank = true
+		out.frames = append(out.frames, &mdFrame{first: "[^" + strconv.Itoa(d.firstNote()+i) + "]: ", rest: "    "})
+		body()
+		if s := out.text(); s != "" {
+			/* an inline note */
+			out.block(out.paragraph(s), false)
+		}
+		out.frames = out.frames[:len(out.frames)-1]
+	}
+	return dest.n, dest.err
+}
+
+/* line - print a line, preceded by the prefixes of the containers
+ */
+func (w *mdOut) line(s string) {
+	p := ""
+	for _, f := range w.frames {
+		if f.used {
+			p += f.rest
+		} else {
+			p += f.first
+			f.used = true
+		}
+	}
+	if s == "" {
+		p = strings.TrimRight(p, " ")
+	}
+	w.WriteString(p + s + "\n")
+}
+
+/* blankLine - print an empty line within the containers already started
+ */
+func (w *mdOut) blankLine() {
+	p := ""
+	for _, f := range w.frames {
+		if f.used {
+			p += f.rest
+		}
+	}
+	w.WriteString(strings.TrimRight(p, " ") + "\n")
+}
+
+/* block - print the lines of a block, separated by an empty line from
+ * the preceding one; after a tight block, like the Plain contents of an
+ * item of a tight list, the next block follows without an empty line.
+ */
+func (w *mdOut) block(lines []string, tight bool) {
+	if w.admonition != "" {
+		w.admonitionStart("")
+	}
+	if w.started && w.blank {
+		w.blankLine()
+	}
+	for _, l := range lines {
+		w.line(l)
+	}
+	w.started = true
+	w.blank = !tight
+	w.afterList = false
+	w.afterDef = false
+}
+
+/* text - return the inline text collected for the current block */
+func (w *mdOut) text() string {
+	s := w.buf.String()
+	w.buf.Reset()
+	return s
+}
+
+func (w *mdOut) push(first, rest string) {
+	if w.admonition != "" {
+		w.admonitionStart("")
+	}
+	w.frames = append(w.frames, &mdFrame{first: first, rest: rest})
+}
+
+func (w *mdOut) pop() {
+	w.frames = w.frames[:len(w.frames)-1]
+}
+
+/* paragraph - split text into lines at hard line breaks, and, depending
+ * on the style, at the source's line breaks or at the wrap width
+ */
+func (w *mdOut) paragraph(text string) []string {
+	var lines []string
+	for _, seg := range strings.Split(text, "\n", -1) {
+		hard := strings.HasSuffix(seg, "  ")
+		seg = strings.Trim(seg, " ")
+		n := len(lines)
+		lines = append(lines, w.wrap(seg)...)
+		if hard {
+			lines[len(lines)-1] += "  "
+		}
+		lines[n] = escapeLineStart(lines[n])
+	}
+	return lines
+}
+
+func (w *mdOut) wrap(s string) []string {
+	width := w.style.Wrap
+	if width <= 0 {
+		return []string{s}
+	}
+	for _, f := range w.frames {
+		width -= len(f.rest)
+	}
+	if width < 20 {
+		width = 20
+	}
+	var lines []string
+	line, n := "", 0
+	for _, word := range strings.Split(s, " ", -1) {
+		if word == "" {
+			continue
+		}
+		m := textWidth(word)
+		switch {
+		case line == "":
+			line, n = word, m
+		case n+1+m > width && !noBreakBefore(word):
+			lines = append(lines, line)
+			line, n = word, m
+		default:
+			line += " " + word
+			n += 1 + m
+		}
+	}
+	return append(lines, line)
+}
+
+/* noBreakBefore - report whether word would be taken as block syntax
+ * at the start of a line
+ */
+func noBreakBefore(word string) bool {
+	return escapeLineStart(word) != word ||
+		strings.Trim(word, "=") == "" || strings.Trim(word, "-") == ""
+}
+
+/* escapeLineStart - escape the first character of a line of text, if it
+ * would otherwise start a heading, block quote, list item, or rule
+ */
+func escapeLineStart(s string) string {
+	word := s
+	if i := strings.Index(s, " "); i != -1 {
+		word = s[:i]
+	}
+	switch {
+	case word == "":
+	case s[0] == '#', s[0] == '>':
+		return `\` + s
+	case word == "-", word == "+", word == "*":
+		return `\` + s
+	case strings.Trim(s, "-") == "", strings.Trim(s, "=") == "":
+		return `\` + s
+	case strings.Trim(s, "- ") == "" && strings.Count(s, "-") >= 3:
+		return `\` + s
+	case strings.HasSuffix(word, ".") && isNumber(word[:len(word)-1]):
+		return word[:len(word)-1] + `\` + s[len(word)-1:]
+	}
+	return s
+}
+
+func isNumber(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+/* escape - print text, escaping characters that would be taken as markup
+ */
+func (w *mdOut) escape(s string) {
+	for i, c := range s {
+		switch c {
+		case '\\', '`', '*', '[', ']', '<':
+			w.buf.WriteByte('\\')
+		case '_':
+			if i == 0 || i == len(s)-1 || !isAlnum(s[i-1]) || !isAlnum(s[i+1]) {
+				w.buf.WriteByte('\\')
+			}
+		case '|':
+			if w.inCell {
+				w.buf.WriteByte('\\')
+			}
+		case '$':
+			if w.ext.Math {
+				w.buf.WriteByte('\\')
+			}
+		case '~':
+			if w.ext.Strike || w.ext.SupSub {
+				w.buf.WriteByte('\\')
+			}
+		case '^':
+			if w.ext.SupSub {
+				w.buf.WriteByte('\\')
+			}
+		}
+		w.buf.WriteRune(c)
+	}
+}
+
+func isAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
+
+/* fence - return a run of backticks longer than any within s, but at
+ * least min long
+ */
+func fence(s string, min int) string {
+	n, run := 0, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '`' {
+			if run++; run > n {
+				n = run
+			}
+		} else {
+			run = 0
+		}
+	}
+	if n+1 > min {
+		min = n + 1
+	}
+	return strings.Repeat("`", min)
+}
+
+/* Renderer methods
+ */
+
+func (w *mdOut) Str(s string) {
+	w.escape(s)
+}
+
+func (w *mdOut) Space(s string) {
+	if strings.Index(s, "\n") != -1 && w.style.Wrap == 0 && !w.inCell {
+		w.buf.WriteString("\n")
+	} else {
+		w.buf.WriteString(" ")
+	}
+}
+
+func (w *mdOut) LineBreak() {
+	w.buf.WriteString("  \n")
+}
+
+func (w *mdOut) Ellipsis() {
+	w.buf.WriteString("...")
+}
+
+func (w *mdOut) EmDash() {
+	w.buf.WriteString("---")
+}
+
+func (w *mdOut) EnDash() {
+	w.buf.WriteString("-")
+}
+
+func (w *mdOut) Apostrophe() {
+	w.buf.WriteString("'")
+}
+
+func (w *mdOut) SingleQuoted(entering bool) {
+	w.buf.WriteString("'")
+}
+
+func (w *mdOut) DoubleQuoted(entering bool) {
+	w.buf.WriteString(`"`)
+}
+
+func (w *mdOut) Code(s string) {
+	f := fence(s, 1)
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+	w.buf.WriteString(f + s + f)
+}
+
+func (w *mdOut) Math(s string, display bool) {
+	if display {
+		w.buf.WriteString("$$" + s + "$$")
+	} else {
+		w.buf.WriteString("$" + s + "$")
+	}
+}
+
+func (w *mdOut) Html(s string) {
+	w.buf.WriteString(s)
+}
+
+func (w *mdOut) Format() string {
+	return "markdown"
+}
+
+func (w *mdOut) Raw(s string) {
+	w.buf.WriteString(s)
+}
+
+func (w *mdOut) Link(url, title string, entering bool) {
+	if entering {
+		w.links = append(w.links, w.buf.Len())
+		w.buf.WriteString("[")
+		return
+	}
+	start := w.links[len(w.links)-1]
+	w.links = w.links[:len(w.links)-1]
+	text := strings.Replace(w.buf.String()[start+1:], `\`, "", -1)
+	if title == "" && (text == url || "mailto:"+text == url) && strings.IndexAny(url, " <>") == -1 {
+		/* an autolink */
+		w.buf.Truncate(start)
+		w.buf.WriteString("<" + text + ">")
+		return
+	}
+	w.buf.WriteString("](" + mdDestination(url, title) + ")")
+}
+
+func (w *mdOut) Image(url, title string, entering bool) {
+	if entering {
+		w.buf.WriteString("![")
+	} else {
+		w.buf.WriteString("](" + mdDestination(url, title) + ")")
+	}
+}
+
+func mdDestination(url, title string) string {
+	if strings.IndexAny(url, " ()") != -1 {
+		url = "<" + url + ">"
+	}
+	switch {
+	case title == "":
+	case strings.Index(title, `"`) == -1:
+		url += ` "` + title + `"`
+	default:
+		url += ` '` + title + `'`
+	}
+	return url
+}
+
+func (w *mdOut) Emph(entering bool) {
+	w.buf.WriteString("*")
+}
+
+func (w *mdOut) Strong(entering bool) {
+	w.buf.WriteString("**")
+}
+
+func (w *mdOut) Strike(entering bool) {
+	w.buf.WriteString("~~")
+}
+
+func (w *mdOut) Superscript(entering bool) {
+	w.buf.WriteString("^")
+}
+
+func (w *mdOut) Subscript(entering bool) {
+	w.buf.WriteString("~")
+}
+
+func (w *mdOut) Abbr(title string, entering bool) {
+	/* print the abbreviation only */
+}
+
+func (w *mdOut) Emoji(name, s string) {
+	w.buf.WriteString(":" + name + ":")
+}
+
+func (w *mdOut) Citation(source string, entering bool) {
+	if entering {
+		w.cite = w.buf.Len()
+	} else {
+		/* print the source instead of the formatted citation */
+		w.buf.Truncate(w.cite)
+		w.buf.WriteString(source)
+	}
+}
+
+func (w *mdOut) Note(n int, body func()) {
+	w.buf.WriteString("[^" + strconv.Itoa(n) + "]")
+	w.notes = append(w.notes, body)
+}
+
+func (w *mdOut) Heading(level int, id string, entering bool) {
+	if entering {
+		return
+	}
+	s := w.text()
+	if w.style.Setext && level <= 2 {
+		u := "="
+		if level == 2 {
+			u = "-"
+		}
+		n := textWidth(s)
+		if n < 3 {
+			n = 3
+		}
+		w.block([]string{escapeLineStart(s), strings.Repeat(u, n)}, false)
+		return
+	}
+	if strings.HasSuffix(s, "#") {
+		s = s[:len(s)-1] + `\#`
+	}
+	w.block([]string{strings.Repeat("#", level) + " " + s}, false)
+}
+
+func (w *mdOut) Plain(entering bool) {
+	if entering {
+		return
+	}
+	if s := w.text(); s != "" {
+		w.block(w.paragraph(s), true)
+	}
+}
+
+func (w *mdOut) Para(entering bool) {
+	if entering {
+		return
+	}
+	if s := w.text(); s != "" {
+		w.blank = true
+		w.block(w.paragraph(s), false)
+	}
+}
+
+func (w *mdOut) Figure(caption string, entering bool) {
+	w.Para(entering)
+}
+
+func (w *mdOut) HRule() {
+	w.block([]string{"* * *"}, false)
+}
+
+func (w *mdOut) HtmlBlock(s string) {
+	w.block(strings.Split(strings.TrimRight(s, "\n"), "\n", -1), false)
+}
+
+func (w *mdOut) Verbatim(s, lang string) {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n", -1)
+	if lang != "" || w.style.Fenced {
+		f := fence(s, 3)
+		lines = append([]string{f + lang}, lines...)
+		lines = append(lines, f)
+	} else {
+		if w.afterList {
+			/* keep the code block from continuing the list */
+			w.block([]string{"<!-- -->"}, false)
+		}
+		for i, l := range lines {
+			if l != "" {
+				lines[i] = "    " + l
+			}
+		}
+	}
+	w.block(lines, false)
+}
+
+func (w *mdOut) BlockQuote(entering bool) {
+	if entering {
+		w.push("> ", "> ")
+	} else {
+		w.pop()
+		w.blank = true
+	}
+}
+
+func (w *mdOut) Admonition(kind string, entering bool) {
+	if w.admonition != "" {
+		w.admonitionStart("")
+	}
+	if entering {
+		w.admonition = kind
+		return
+	}
+	w.pop()
+	w.blank = true
+}
+
+func (w *mdOut) AdmonitionTitle(entering bool) {
+	if !entering {
+		w.admonitionStart(w.text())
+	}
+}
+
+func (w *mdOut) Div(class string, entering bool) {
+	if !entering {
+		w.block([]string{":::"}, false)
+		return
+	}
+	a := w.attr
+	w.attr = nil
+	info := "{}"
+	switch {
+	case a == nil:
+	case a.ID == "" && len(a.Classes) == 1 && len(a.Attrs) == 0:
+		info = a.Classes[0]
+	default:
+		info = "{" + attributeBlock(a) + "}"
+	}
+	w.block([]string{"::: " + info}, false)
+}
+
+func (w *mdOut) Bibliography(entering bool) {
+	/* generated from the citations, so it is dropped */
+	if entering {
+		w.outer, w.Writer = w.Writer, new(bytes.Buffer)
+	} else {
+		w.Writer = w.outer
+	}
+}
+
+/* SetAttributes - keep the attributes of the next element; only those
+ * of divs are printed
+ */
+func (w *mdOut) SetAttributes(a *Attributes) {
+	w.attr = a
+}
+
+/* attributeBlock - return the text of an attribute block for a
+ */
+func attributeBlock(a *Attributes) string {
+	var list []string
+	if a.ID != "" {
+		list = append(list, "#"+a.ID)
+	}
+	for _, c := range a.Classes {
+		list = append(list, "."+c)
+	}
+	for _, kv := range a.Attrs {
+		v := kv.Value
+		if v == "" || strings.IndexAny(v, " \t}") != -1 {
+			v = `"` + v + `"`
+		}
+		list = append(list, kv.Key+"="+v)
+	}
+	return strings.Join(list, " ")
+}
+
+/* admonitionStart - print the first line of an admonition, with the
+ * specified title, omitted if it is the default one, and start its
+ * indented body
+ */
+func (w *mdOut) admonitionStart(title string) {
+	line := "!!! " + w.admonition
+	if title != defaultTitle(w.admonition) {
+		line += ` "` + title + `"`
+	}
+	w.admonition = ""
+	w.block([]string{line}, true)
+	w.push("    ", "    ")
+}
+
+func (w *mdOut) list(l mdList, entering bool) {
+	if entering {
+		if w.afterList {
+			/* keep adjacent lists apart */
+			w.block([]string{"<!-- -->"}, false)
+		}
+		w.lists = append(w.lists, l)
+	} else {
+		w.lists = w.lists[:len(w.lists)-1]
+		w.blank = true
+		w.afterList = true
+	}
+}
+
+func (w *mdOut) BulletList(entering bool) {
+	w.list(mdList{}, entering)
+}
+
+func (w *mdOut) OrderedList(start int, delim string, entering bool) {
+	w.list(mdList{ordered: true, n: start - 1, delim: delim}, entering)
+}
+
+func (w *mdOut) item(task string, entering bool) {
+	if !entering {
+		w.pop()
+		return
+	}
+	l := &w.lists[len(w.lists)-1]
+	l.n++
+	marker := w.style.Bullet + " "
+	if l.ordered {
+		marker = strconv.Itoa(l.n) + l.delim + " "
+	}
+	w.push(marker+task, "    ")
+}
+
+func (w *mdOut) ListItem(entering bool) {
+	w.item("", entering)
+}
+
+func (w *mdOut) TaskItem(done bool, entering bool) {
+	if done {
+		w.item("[x] ", entering)
+	} else {
+		w.item("[ ] ", entering)
+	}
+}
+
+func (w *mdOut) DefinitionList(entering bool) {
+	if !entering {
+		w.blank = true
+	}
+}
+
+func (w *mdOut) DefTitle(entering bool) {
+	if entering {
+		if w.afterDef {
+			w.blank = true
+		}
+		return
+	}
+	w.block([]string{escapeLineStart(w.text())}, true)
+}
+
+func (w *mdOut) DefData(entering bool) {
+	if entering {
+		w.push(":   ", "    ")
+	} else {
+		w.pop()
+		w.afterDef = true
+	}
+}
+
+func (w *mdOut) Table(align []string, entering bool) {
+	if entering {
+		w.rows = nil
+		w.nhead = 0
+		return
+	}
+	ncol := len(align)
+	width := make([]int, ncol)
+	for _, row := range w.rows {
+		for i, cell := range row {
+			if n := textWidth(cell); i < ncol && n > width[i] {
+				width[i] = n
+			}
+		}
+	}
+	sep := make([]string, ncol)
+	for i, a := range align {
+		if width[i] < 3 {
+			width[i] = 3
+		}
+		s := strings.Repeat("-", width[i])
+		switch a {
+		case "left":
+			s = ":" + s[1:]
+		case "right":
+			s = s[1:] + ":"
+		case "center":
+			s = ":" + s[2:] + ":"
+		}
+		sep[i] = s
+	}
+	var lines []string
+	for i, row := range w.rows {
+		cells := make([]string, ncol)
+		for j := range cells {
+			if j < len(row) {
+				cells[j] = row[j]
+			}
+			cells[j] += strings.Repeat(" ", width[j]-textWidth(cells[j]))
+		}
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+		if i+1 == w.nhead {
+			lines = append(lines, "| "+strings.Join(sep, " | ")+" |")
+		}
+	}
+	w.block(lines, false)
+}
+
+func (w *mdOut) TableHead(entering bool) {
+	if !entering {
+		w.nhead = len(w.rows)
+	}
+}
+
+func (w *mdOut) TableBody(entering bool) {
+}
+
+func (w *mdOut) TableRow(entering bool) {
+	if entering {
+		w.row = nil
+	} else {
+		w.rows = append(w.rows, w.row)
+	}
+}
+
+func (w *mdOut) TableCell(align string, header bool, entering bool) {
+	w.inCell = entering
+	if !entering {
+		w.row = append(w.row, strings.TrimSpace(w.text()))
+	}
+}
+
+func (w *mdOut) TOC(toc []*TOCItem) {
+	w.block([]string{"[TOC]"}, false)
+}
@@ -0,0 +1,252 @@
+package mdtest
+
+// Comparing the output with that of another implementation
+
+import (
+	"bytes"
+	"exec"
+	"os"
+	"strings"
+
+	"../_obj/github.com/knieriem/markdown"
+)
+
+// Command returns a conversion running the program name with the
+// arguments args, like "cmark" or the markdown binary of peg-markdown,
+// which reads the input from stdin, and writes HTML to stdout, as
+// reference of Compare.
+func Command(name string, args ...string) func(input string) (string, os.Error) {
+	return func(input string) (string, os.Error) {
+		cmd := exec.Command(name, args...)
+		cmd.Stdin = strings.NewReader(input)
+		b, err := cmd.Output()
+		return string(b), err
+	}
+}
+
+// Compare converts the Markdown files of dir, like RunFunc, by convert,
+// and by reference, another implementation, and reports each case
+// whose outputs differ after Normalize to t, together with a diff.
+// As the MarkdownTests of Markdown 1.0.3 have a file per construct,
+// like "Links, inline style", the divergences are reported per
+// construct.  Compare returns the number of cases, and the number of
+// those that differ.
+func Compare(t Reporter, dir string, convert func(input string) string, reference func(input string) (string, os.Error)) (n, diverged int) {
+	cases, err := Cases(dir)
+	if err != nil {
+		t.Errorf("%s", err)
+		return
+	}
+	for _, c := range cases {
+		input, err := readFile(c.Input)
+		if err != nil {
+			t.Errorf("%s", err)
+			continue
+		}
+		ref, err := reference(input)
+		if err != nil {
+			t.Errorf("%s: reference: %s", c.Input, err)
+			continue
+		}
+		n++
+		if d := Diff(Normalize(ref), Normalize(convert(input))); d != "" {
+			t.Errorf("%s: output differs from the reference (-reference +got):\n%s", c.Name, d)
+			diverged++
+		}
+	}
+	return
+}
+
+/* elements of HTML printed on lines of their own by Normalize */
+var blockElements = map[string]bool{
+	"address":		true,
+	"article":		true,
+	"aside":		true,
+	"blockquote":	true,
+	"dd":			true,
+	"div":			true,
+	"dl":			true,
+	"dt":			true,
+	"figcaption":	true,
+	"figure":		true,
+	"footer":		true,
+	"h1":			true,
+	"h2":			true,
+	"h3":			true,
+	"h4":			true,
+	"h5":			true,
+	"h6":			true,
+	"header":		true,
+	"hr":			true,
+	"li":			true,
+	"nav":			true,
+	"ol":			true,
+	"p":			true,
+	"pre":			true,
+	"section":		true,
+	"table":		true,
+	"tbody":		true,
+	"td":			true,
+	"tfoot":		true,
+	"th":			true,
+	"thead":		true,
+	"tr":			true,
+	"ul":			true,
+}
+
+// Normalize returns HTML text in a form that differs only where the
+// HTML differs in more than the layout and the spelling of markup:
+// the tags of block elements, like <p> and <li>, are put on lines of
+// their own, runs of white space outside of <pre> elements become a
+// single space, and none is kept next to block tags; tag names are
+// lower-cased, void elements are written like <br>, and character
+// references like &quot; or &hellip; are replaced by the characters
+// they stand for, except for &amp;, &lt;, and &gt;.
+func Normalize(html string) string {
+	var b bytes.Buffer
+	pre := 0	/* Nesting of <pre> elements. */
+	bol := true	/* At the start of a line. */
+	trim := func() {
+		if n := b.Len(); n > 0 && b.Bytes()[n-1] == ' ' {
+			b.Truncate(n - 1)
+		}
+	}
+	for html != "" {
+		if html[0] == '<' {
+			n := strings.Index(html, ">") + 1
+			if n == 0 {
+				n = len(html)
+			}
+			tag := normalTag(html[:n])
+			html = html[n:]
+			name, closing := tagName(tag)
+			if !blockElements[name] {
+				b.WriteString(tag)
+				bol = false
+				continue
+			}
+			if name == "pre" {
+				if closing {
+					pre--
+				} else {
+					pre++
+				}
+			}
+			trim()
+			if !bol {
+				b.WriteByte('\n')
+			}
+			b.WriteString(tag)
+			b.WriteByte('\n')
+			bol = true
+			continue
+		}
+		n := strings.Index(html, "<")
+		if n == -1 {
+			n = len(html)
+		}
+		text := unescape(html[:n])
+		html = html[n:]
+		if pre == 0 {
+			f := strings.Fields(text)
+			s := strings.Join(f, " ")
+			if text != "" && isSpace(text[0]) && !bol {
+				s = " " + s
+			}
+			if len(f) > 0 && isSpace(text[len(text)-1]) {
+				s += " "
+			}
+			text = s
+		}
+		if text != "" {
+			b.WriteString(text)
+			bol = false
+		}
+	}
+	trim()
+	return b.String()
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+/* normalTag - return a tag with its name in lower case, runs of
+ * white space replaced by a space, and without the / of <br />
+ */
+func normalTag(tag string) string {
+	name, closing := tagName(tag)
+	if name == "" {
+		/* a comment, or a declaration */
+		return tag
+	}
+	i := 1 + len(name)
+	if closing {
+		i++
+	}
+	s := strings.Join(strings.Fields(tag[:1]+strings.ToLower(tag[1:i])+tag[i:]), " ")
+	if strings.HasSuffix(s, "/>") {
+		s = strings.TrimRight(s[:len(s)-2], " ") + ">"
+	}
+	return s
+}
+
+/* tagName - return the name of the element of a tag in lower case,
+ * and whether it is an end tag
+ */
+func tagName(tag string) (name string, closing bool) {
+	s := tag[1:]
+	if strings.HasPrefix(s, "/") {
+		s = s[1:]
+		closing = true
+	}
+	n := 0
+	for n < len(s) && (s[n] >= 'a' && s[n] <= 'z' || s[n] >= 'A' && s[n] <= 'Z' || s[n] >= '0' && s[n] <= '9') {
+		n++
+	}
+	return strings.ToLower(s[:n]), closing
+}
+
+/* unescape - replace the character references of text by the
+ * characters they stand for, except for &amp;, &lt; and &gt;, which
+ * replace the numeric references of &, <, and > as well
+ */
+func unescape(text string) string {
+	if strings.Index(text, "&") == -1 {
+		return text
+	}
+	var b bytes.Buffer
+	for {
+		i := strings.Index(text, "&")
+		if i == -1 {
+			break
+		}
+		b.WriteString(text[:i])
+		text = text[i:]
+		if n := strings.Index(text, ";"); n > 1 && n < 10 {
+			if c, ok := charRef(text[1:n]); ok {
+				b.WriteString(c)
+				text = text[n+1:]
+				continue
+			}
+		}
+		b.WriteByte('&')
+		text = text[1:]
+	}
+	b.WriteString(text)
+	return b.String()
+}
+
+/* charRef - return the text of the character reference &name; */
+func charRef(name string) (string, bool) {
+	c, ok := markdown.UnescapeEntity("&" + name + ";")
+	switch c {
+	case "&":
+		return "&amp;", ok
+	case "<":
+		return "&lt;", ok
+	case ">":
+		return "&gt;", ok
+	}
+	return c, ok
+}
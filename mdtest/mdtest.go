@@ -0,0 +1,216 @@
+// Package mdtest runs golden tests of Markdown conversions: the
+// Markdown files of a directory are converted, and the results are
+// compared with files holding the output expected. This way, the
+// behavior of package markdown, and of extensions built on it, can
+// be pinned across upgrades.
+//
+// A test of a downstream package might look like this:
+//
+//	func TestGolden(t *testing.T) {
+//		mdtest.Run(t, "testdata", markdown.Extensions{Smart: true})
+//	}
+package mdtest
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"../_obj/github.com/knieriem/markdown"
+)
+
+// If Update is set, Run writes the output of each conversion to the
+// file of the expected output, instead of comparing them, e.g. after
+// a change of the output has been reviewed.
+var Update = false
+
+// A Reporter receives the mismatches found by Run, like a *testing.T.
+type Reporter interface {
+	Errorf(format string, args ...interface{})
+}
+
+// A Case is a test of a directory: a Markdown file, with the suffix
+// .text or .md, and the file of the output expected, with the same
+// name, but the suffix .html.
+type Case struct {
+	Name		string	// name of the Markdown file, without the suffix
+	Input		string	// path of the Markdown file
+	Expected	string	// path of the file of the output expected
+}
+
+// Cases returns the cases of the Markdown files of dir, sorted by name.
+func Cases(dir string) ([]Case, os.Error) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	names, err := f.Readdirnames(-1)
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+	var list caseList
+	for _, name := range names {
+		ext := filepath.Ext(name)
+		if ext != ".text" && ext != ".md" || strings.HasPrefix(name, ".") {
+			continue
+		}
+		base := name[:len(name)-len(ext)]
+		list = append(list, Case{base, filepath.Join(dir, name), filepath.Join(dir, base+".html")})
+	}
+	sort.Sort(list)
+	return list, nil
+}
+
+type caseList []Case
+
+func (l caseList) Len() int				{ return len(l) }
+func (l caseList) Swap(i, j int)		{ l[i], l[j] = l[j], l[i] }
+func (l caseList) Less(i, j int) bool	{ return l[i].Name < l[j].Name }
+
+// Run converts the Markdown files of dir by markdown.Parse, with
+// the extensions ext, and Doc.WriteHtml, and reports each result
+// differing from the output expected to t, together with a diff.
+func Run(t Reporter, dir string, ext markdown.Extensions) {
+	RunFunc(t, dir, HTML(ext))
+}
+
+// HTML returns the conversion done by Run, for use with RunFunc.
+func HTML(ext markdown.Extensions) func(input string) string {
+	return func(input string) string {
+		var b bytes.Buffer
+		markdown.Parse(input, ext).WriteHtml(&b)
+		return b.String()
+	}
+}
+
+// RunFunc is like Run, but converts the Markdown files by convert,
+// e.g. to test a Parser with plugins, or another output format.
+// Line endings \r\n of the files are read as \n.
+func RunFunc(t Reporter, dir string, convert func(input string) string) {
+	cases, err := Cases(dir)
+	if err != nil {
+		t.Errorf("%s", err)
+		return
+	}
+	if len(cases) == 0 {
+		t.Errorf("%s: no Markdown files", dir)
+	}
+	for _, c := range cases {
+		input, err := readFile(c.Input)
+		if err != nil {
+			t.Errorf("%s", err)
+			continue
+		}
+		output := convert(input)
+		if Update {
+			if err := ioutil.WriteFile(c.Expected, []byte(output), 0666); err != nil {
+				t.Errorf("%s", err)
+			}
+			continue
+		}
+		expected, err := readFile(c.Expected)
+		if err != nil {
+			t.Errorf("%s", err)
+			continue
+		}
+		if d := Diff(expected, output); d != "" {
+			t.Errorf("%s: output differs from %s (-expected +got):\n%s", c.Input, c.Expected, d)
+		}
+	}
+}
+
+func readFile(name string) (string, os.Error) {
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	return strings.Replace(string(b), "\r\n", "\n", -1), nil
+}
+
+// Diff returns the differences between the lines of the texts
+// expected and got: lines found in expected only, prefixed by -,
+// lines found in got only, prefixed by +, and up to two unchanged
+// lines around them, prefixed by a space; for each group of changes,
+// a line @@ n @@ tells the number of its first line in expected.
+// If the texts are equal, Diff returns "".
+func Diff(expected, got string) string {
+	if expected == got {
+		return ""
+	}
+	a := strings.Split(expected, "\n", -1)
+	b := strings.Split(got, "\n", -1)
+
+	/* lcs[i][j] is the length of the longest common subsequence
+	 * of a[i:] and b[j:]
+	 */
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var lines []diffLine
+	for i, j := 0, 0; i < len(a) || j < len(b); {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i], i})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{'-', a[i], i})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j], i})
+			j++
+		}
+	}
+
+	const context = 2
+	var buf bytes.Buffer
+	last := -1	/* index of the line printed last */
+	for k, l := range lines {
+		if l.op == ' ' && !changed(lines, k-context, k+context) {
+			continue
+		}
+		if k != last+1 || last == -1 {
+			fmt.Fprintf(&buf, "@@ %d @@\n", l.line+1)
+		}
+		fmt.Fprintf(&buf, "%c%s\n", l.op, l.text)
+		last = k
+	}
+	return buf.String()
+}
+
+type diffLine struct {
+	op		int		/* ' ', '-', or '+' */
+	text	string
+	line	int		/* Index of the line in expected, or of the next one. */
+}
+
+/* changed - report whether one of lines[from] ... lines[to] is not
+ * an unchanged one
+ */
+func changed(lines []diffLine, from, to int) bool {
+	for k := from; k <= to; k++ {
+		if k >= 0 && k < len(lines) && lines[k].op != ' ' {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package markdown
+
+// Front matter
+
+import (
+	"strings"
+)
+
+// Meta holds the metadata of a document, as found in a front
+// matter block at its beginning: either YAML, enclosed in lines
+// consisting of "---" (the closing line may also be "..."), or
+// TOML, enclosed in lines of "+++".
+type Meta struct {
+	Raw		string				/* Contents of the block, without delimiter lines. */
+	Values	map[string]string	/* Simple key/value pairs found in the block. */
+}
+
+// Meta returns the metadata of the document, or nil if the
+// FrontMatter extension is not enabled, or the document does
+// not start with a front matter block.
+func (d *Doc) Meta() *Meta {
+	return d.meta
+}
+
+/* splitFrontMatter - if text starts with a front matter block, return
+ * the parsed block, and the remaining text.
+ */
+func splitFrontMatter(text string) (*Meta, string) {
+	var toml bool
+	var end []string
+
+	switch {
+	case strings.HasPrefix(text, "---\n"):
+		end = []string{"---", "..."}
+	case strings.HasPrefix(text, "+++\n"):
+		end = []string{"+++"}
+		toml = true
+	default:
+		return nil, text
+	}
+	for i := 4; i < len(text); {
+		n := strings.Index(text[i:], "\n")
+		if n == -1 {
+			break
+		}
+		line := strings.TrimRight(text[i:i+n], " \r")
+		for _, e := range end {
+			if line == e {
+				m := &Meta{Raw: text[4:i]}
+				m.Values = parseMetaValues(m.Raw, toml)
+				return m, text[i+n+1:]
+			}
+		}
+		i += n + 1
+	}
+	return nil, text	/* no closing line */
+}
+
+/* parseMetaValues - extract "key: value" (YAML), or "key = value" (TOML)
+ * pairs. This is not a complete YAML or TOML parser: nested YAML
+ * structures are ignored; TOML keys below a [table] line get the
+ * table name as prefix, separated by a dot.
+ */
+func parseMetaValues(raw string, toml bool) map[string]string {
+	values := make(map[string]string)
+	sep := ":"
+	if toml {
+		sep = "="
+	}
+	prefix := ""
+	for _, line := range strings.Split(raw, "\n", -1) {
+		line = strings.TrimRight(line, " \r")
+		if line == "" || line[0] == '#' || line[0] == ' ' || line[0] == '-' {
+			continue
+		}
+		if toml && line[0] == '[' {
+			prefix = strings.Trim(line, "[] ") + "."
+			continue
+		}
+		i := strings.Index(line, sep)
+		if i <= 0 {
+			continue
+		}
+		key := strings.TrimSpace(line[:i])
+		values[prefix+unquote(key)] = unquote(strings.TrimSpace(line[i+1:]))
+	}
+	return values
+}
+
+func unquote(s string) string {
+	if n := len(s); n >= 2 && (s[0] == '"' || s[0] == '\'') && s[n-1] == s[0] {
+		return s[1 : n-1]
+	}
+	return s
+}
@@ -0,0 +1,82 @@
+package markdown
+
+// Numbering and placement of footnotes
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Values of Doc.NotePlacement, selecting where HTML output prints
+// the footnotes.
+const (
+	NotesAtEnd		= iota	// after the document
+	NotesPerSection			// before each heading of level 1 or 2, and after the document
+	NotesAtMarker			// in place of a line <!-- footnotes -->, or after the document, if there is none
+)
+
+// NotesMarker is the HTML block replaced by the footnotes, if
+// Doc.NotePlacement is NotesAtMarker.
+const NotesMarker = "<!-- footnotes -->"
+
+// FootnoteSymbols are the markers of footnotes, if Doc.NoteSymbols
+// is set. After the last one, they are doubled, then tripled, etc.
+var FootnoteSymbols = []string{"*", "†", "‡", "§", "‖", "¶"}
+
+/* noteMarker - return the marker of the n-th note: its number, or,
+ * if symbols is set, its symbol
+ */
+func noteMarker(n int, symbols bool) string {
+	if !symbols || n < 1 {
+		return strconv.Itoa(n)
+	}
+	k := len(FootnoteSymbols)
+	return strings.Repeat(FootnoteSymbols[(n-1)%k], (n-1)/k+1)
+}
+
+/* firstNote - return the number of the first note */
+func (d *Doc) firstNote() int {
+	if d.NoteStart > 0 {
+		return d.NoteStart
+	}
+	return 1
+}
+
+// NoteCount returns the number of footnotes of the document, e.g.
+// to set NoteStart of the next chapter of a book.
+func (d *Doc) NoteCount() int {
+	return countNotes(d.tree)
+}
+
+func countNotes(list *Element) int {
+	n := 0
+	for e := list; e != nil; e = e.next {
+		if e.key == NOTE && e.contents.str == "" {
+			n++
+		}
+		n += countNotes(e.children)
+	}
+	return n
+}
+
+/* flushNotes - print the notes collected so far, if any
+ */
+func (w *htmlOut) flushNotes() {
+	if len(w.endNotes) == 0 {
+		return
+	}
+	w.pad(2)
+	w.printEndnotes()
+	w.endNotes = nil
+	w.pset(0)
+}
+
+/* markNote - put the marker of a note at the start of its text, as
+ * the numbers of an ordered list are omitted for symbols
+ */
+func markNote(note, marker string) string {
+	if strings.HasPrefix(note, "<p>") {
+		return "<p>" + marker + " " + note[3:]
+	}
+	return "<p>" + marker + "</p>\n" + note
+}
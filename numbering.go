@@ -0,0 +1,69 @@
+package markdown
+
+// Section numbers of headings
+
+import (
+	"strconv"
+	"strings"
+)
+
+// A HeadingNumbering selects how headings are numbered, if it is set
+// in Doc.Numbering: like 1, 1.1, 1.1.1, beginning with the headings
+// of level Start, or 1, if it is 0; headings of lower levels are not
+// numbered, and start the numbering anew.  Separator is printed
+// between the numbers of the levels, "." if it is "", and Suffix after
+// the number, like "." for 1. and 1.1.
+type HeadingNumbering struct {
+	Start		int
+	Separator	string
+	Suffix		string
+}
+
+func (n *HeadingNumbering) withDefaults() *HeadingNumbering {
+	m := *n
+	if m.Start < 1 {
+		m.Start = 1
+	}
+	if m.Separator == "" {
+		m.Separator = "."
+	}
+	return &m
+}
+
+/* a numberer is a Renderer of a format that numbers headings itself,
+ * like LaTeX, so that Doc.Numbering is not printed
+ */
+type numberer interface {
+	numbersHeadings() bool
+}
+
+// HeadingNumbers returns the section numbers of the headings of the
+// document selected by Numbering, including the Suffix, like "1.2",
+// by their elements; it is nil if Numbering is nil.  A level skipped
+// is counted as 0, like 1.0.1 for a heading of level 3 following one
+// of level 1.
+func (d *Doc) HeadingNumbers() map[*Element]string {
+	if d.Numbering == nil {
+		return nil
+	}
+	n := d.Numbering.withDefaults()
+	numbers := make(map[*Element]string)
+	var count [6]int
+	for _, h := range d.headings() {
+		level := h.key - H1 + 1
+		if level < n.Start {
+			count = [6]int{}
+			continue
+		}
+		count[level-1]++
+		for i := level; i < len(count); i++ {
+			count[i] = 0
+		}
+		parts := make([]string, 0, level)
+		for _, c := range count[n.Start-1 : level] {
+			parts = append(parts, strconv.Itoa(c))
+		}
+		numbers[h] = strings.Join(parts, n.Separator) + n.Suffix
+	}
+	return numbers
+}
@@ -21,8 +21,9 @@ package markdown
 
 import (
 	"os"
+	"io"
+	"bytes"
 	"fmt"
-	"log"
 	"rand"
 	"strings"
 )
@@ -33,38 +34,267 @@ type Writer interface {
 	WriteByte(byte) os.Error
 }
 
+/* an errWriter counts the bytes written to w, and stops writing once
+ * w has returned an error
+ */
+type errWriter struct {
+	w	Writer
+	n	int
+	err	os.Error
+}
+
+func (e *errWriter) WriteString(s string) (int, os.Error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	n, err := e.w.WriteString(s)
+	e.n += n
+	e.err = err
+	return n, err
+}
+
+func (e *errWriter) WriteRune(r int) (int, os.Error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	n, err := e.w.WriteRune(r)
+	e.n += n
+	e.err = err
+	return n, err
+}
+
+func (e *errWriter) WriteByte(c byte) os.Error {
+	if e.err != nil {
+		return e.err
+	}
+	if e.err = e.w.WriteByte(c); e.err == nil {
+		e.n++
+	}
+	return e.err
+}
+
+// A Highlighter writes the HTML representation of the contents of
+// a code block to w; lang is the language of a fenced code block,
+// or "". The result is placed within <pre><code>...</code></pre>. If
+// it returns an error, the code block is printed as it would be
+// without a Highlighter.
+type Highlighter func(lang string, code []byte, w io.Writer) os.Error
+
+// A NoteStyle selects footnote output in the style of PHP Markdown
+// Extra and Pandoc: reference markers are superscripts, the notes
+// are collected in a section of their own, and each note ends with
+// a return link. Empty fields select the defaults shown.
+type NoteStyle struct {
+	Class			string	// class of the notes section: "footnotes"
+	RefClass		string	// class of reference markers: "footnote-ref"
+	BackRefClass	string	// class of return links: "footnote-backref"
+	Marker			string	// format of reference markers, given the note number: "%d"
+	BackRef			string	// HTML text of return links: "&#8617;"
+}
+
+// A Permalink selects the anchor links printed in the headings of
+// HTML output that have an id, linking to the heading itself, like
+// <a class="anchor" href="#intro" aria-hidden="true">&#182;</a>.
+// Empty fields select the defaults shown.
+type Permalink struct {
+	Class	string	// class of the links: "anchor"
+	Symbol	string	// HTML text of the links: "&#182;", a pilcrow
+	Before	bool	// if set, the link precedes the text of the heading, otherwise it follows it
+}
+
+// PresentationalTags, as Doc.SpanTags, selects the elements <i>, <b>,
+// and <s> for emphasis, strong emphasis, and strikethrough.
+var PresentationalTags = map[int]string{EMPH: "i", STRONG: "b", STRIKE: "s"}
+
+// Values of Doc.MailtoObfuscation, selecting how the addresses of
+// mailto: links in HTML output, and their text, are hidden from
+// harvesters.
+const (
+	MailtoEntities	= iota	// each character as a decimal character reference, like peg-markdown
+	MailtoPlain				// printed as is
+	MailtoMixed				// a random mix of decimal and hexadecimal references and plain characters, like Markdown.pl
+)
+
 type htmlOut struct {
 	Writer
-	padded	int
+	dest		*errWriter	/* The Writer passed to WriteHtml. */
+	cancel		<-chan struct{}	/* See WriteHtmlCancel. */
+	padded		int
+	obfuscate	bool
+	mailto		int			/* See MailtoEntities. */
+	rand		*rand.Rand	/* Source of the choices of MailtoMixed. */
+	highlight	Highlighter
+	noFollow	func(url string) bool
+	blank		bool		/* Set target="_blank" on external links. */
+	extRel		string	/* Values of rel of external links, or "". */
+	external	func(url string) bool
+	lazy		bool
+	srcset		func(url string) (srcset, sizes string)
+	noteStyle	*NoteStyle
+	permalink	*Permalink
+	popovers	bool
+	sourcePos	bool
+	dir			string	/* See Doc.Dir. */
+	isolate		bool	/* See Doc.BidiIsolate. */
+	pos			string	/* Position of the top level block started next, see SourcePos. */
+	popover		string	/* HTML text of the note referred to next, see Popovers. */
+	anchor		string	/* Id of the heading being printed, for its permalink. */
+	spanTags	map[int]string
+	html5		bool
+	noObsolete	bool
+	xhtml		bool
+	unwrap		bool	/* True until the paragraph printed without <p> tags has ended. */
+	outer		Writer		/* Writer replaced while printing the alternate text of an image. */
+	attr		*Attributes	/* Attributes of the element started next. */
+	emojiImages	string
+	compact		bool	/* Print no newlines between block elements. */
+	indent		string	/* Indentation of one level of nested elements. */
+	depth		int		/* Nesting level of block elements. */
+	bol			bool	/* Set at the start of a line to be indented. */
+	final		bool	/* Print a newline after the document. */
+	urlEscaping	int
+	numeric		bool	/* Print numeric character references in attribute values. */
+	entities	int		/* See Doc.SourceEntities. */
+	punct		int		/* See Doc.PunctuationEntities. */
+	inAttr		bool	/* Set while printing an attribute value. */
 
-	notenum		int
-	endNotes	[]*element	/* List of endnotes to print after main content. */
+	endNotes	[]func()	/* List of endnotes to print after main content. */
+	noteBase	int			/* Number of the first of endNotes. */
+	symbols		bool		/* Mark notes by symbols, instead of numbers. */
+	placement	int			/* Where notes are printed, see NotesAtEnd. */
 }
 
-// WriteHtml prints a document tree in HTML format using the specified Writer.
+// WriteHtml prints a document tree in HTML format using the specified
+// Writer.  It returns the number of bytes written, and the first error
+// returned by w, if any, after which it stops printing the document.
 //
-func (d *Doc) WriteHtml(w Writer) int {
+func (d *Doc) WriteHtml(w Writer) (n int, err os.Error) {
+	return d.WriteHtmlCancel(w, nil)
+}
+
+// WriteHtmlCancel is like WriteHtml, but stops printing the document
+// once cancel has been closed, and returns ErrCanceled then.  A nil
+// cancel is never closed.
+func (d *Doc) WriteHtmlCancel(w Writer, cancel <-chan struct{}) (n int, err os.Error) {
+	out := d.newHtmlOut(w)
+	out.cancel = cancel
+	d.Render(out)
+	out.finish()
+	return out.dest.n, out.dest.err
+}
+
+func (d *Doc) newHtmlOut(w Writer) *htmlOut {
 	out := new(htmlOut)
-	out.Writer = w
+	out.dest = &errWriter{w: w}
+	out.Writer = out.dest
 	out.padded = 2
-	out.elist(d.tree, false)
-	if len(out.endNotes) != 0 {
-		out.pad(2)
-		out.printEndnotes()
+	out.highlight = d.Highlight
+	out.noFollow = d.NoFollow
+	out.blank = d.TargetBlankExternal
+	if d.RelNofollowExternal {
+		out.extRel = d.ExternalRel
+		if out.extRel == "" {
+			out.extRel = "nofollow ugc"
+		}
+	}
+	out.external = d.External
+	if out.external == nil {
+		out.external = isExternal
+	}
+	out.lazy = d.LazyImages
+	out.srcset = d.ImageSrcset
+	out.html5 = d.Html5
+	out.noObsolete = d.NoObsolete
+	out.xhtml = d.XHTML
+	out.unwrap = d.UnwrapPara && d.singlePara()
+	out.compact = d.CompactHTML
+	out.indent = d.IndentHTML
+	out.final = !d.NoFinalNewline
+	out.urlEscaping = d.URLEscaping
+	out.numeric = d.NumericEntities
+	out.entities = d.SourceEntities
+	out.punct = d.PunctuationEntities
+	out.emojiImages = d.emoji.Images
+	if d.NoteStyle != nil {
+		out.noteStyle = d.NoteStyle.withDefaults()
+	}
+	if d.Permalink != nil {
+		out.permalink = d.Permalink.withDefaults()
+	}
+	out.popovers = d.Popovers
+	out.sourcePos = d.SourcePos
+	out.dir = d.Dir
+	out.isolate = d.BidiIsolate
+	out.spanTags = d.SpanTags
+	out.mailto = d.MailtoObfuscation
+	if out.mailto == MailtoMixed {
+		/* the same choices on each run, so that output can be compared */
+		out.rand = rand.New(rand.NewSource(1))
+	}
+	out.symbols = d.NoteSymbols
+	out.placement = d.NotePlacement
+	return out
+}
+
+/* singlePara - report whether the only printed element at the top
+ * level of the document is a paragraph
+ */
+func (d *Doc) singlePara() bool {
+	n := 0
+	for e := d.tree; e != nil; e = e.next {
+		switch {
+		case e.key == REFERENCE, e.key == ABBREVIATION:
+		case e.key == NOTE && e.contents.str != "":
+		case e.key != PARA:
+			return false
+		default:
+			n++
+		}
+	}
+	return n == 1
+}
+
+// finish - print the endnotes, if any, and a final newline
+func (w *htmlOut) finish() {
+	w.flushNotes()
+	if w.final {
+		w.WriteByte('\n')
 	}
-	out.WriteByte('\n')
-	return 0
 }
 
 // pad - add newlines if needed
 func (h *htmlOut) pad(n int) *htmlOut {
 	for ; n > h.padded; n-- {
-		h.WriteByte('\n')
+		h.nl()
 	}
 	h.padded = n
 	return h
 }
 
+// nl - start a new line between block elements, unless the output
+// is compact; its indentation is printed along with the text following
+func (h *htmlOut) nl() *htmlOut {
+	if !h.compact {
+		h.WriteByte('\n')
+		h.bol = h.indent != ""
+	}
+	return h
+}
+
+// nest - enter (d = 1) or leave (d = -1) a level of nested elements
+func (h *htmlOut) nest(d int) *htmlOut {
+	h.depth += d
+	return h
+}
+
+// indentLine - print the indentation of a new line
+func (h *htmlOut) indentLine() {
+	h.bol = false
+	for i := 0; i < h.depth; i++ {
+		h.WriteString(h.indent)
+	}
+}
+
 func (h *htmlOut) pset(n int) *htmlOut {
 	h.padded = n
 	return h
@@ -72,18 +302,37 @@ func (h *htmlOut) pset(n int) *htmlOut {
 
 // print a string
 func (w *htmlOut) s(s string) *htmlOut {
+	if w.bol {
+		w.indentLine()
+	}
 	w.WriteString(s)
 	return w
 }
 
+// print a named character reference, or, for XHTML, a numeric one
+func (w *htmlOut) entity(s string) *htmlOut {
+	switch {
+	case w.punct == EntitiesUTF8:
+		s = entityText(s)
+	case w.punct == EntitiesNumeric, w.inAttr && w.numeric:
+		s = numericEntity(s)
+	case w.xhtml:
+		s = xmlEntity(s)
+	}
+	return w.s(s)
+}
+
 
 /* print string, escaping for HTML  
- * If obfuscate selected, convert characters to hex or decimal entities at random
+ * If obfuscate selected, convert characters to hex or decimal entities, see obfuscated
  */
-func (w *htmlOut) str(s string, obfuscate bool) *htmlOut {
+func (w *htmlOut) str(s string) *htmlOut {
 	var ws string
 	var i0 = 0
 
+	if w.bol {
+		w.indentLine()
+	}
 	for i, r := range s {
 		switch r {
 		case '&':
@@ -95,13 +344,10 @@ func (w *htmlOut) str(s string, obfuscate bool) *htmlOut {
 		case '"':
 			ws = "&quot;"
 		default:
-			if obfuscate {
-				if rand.Intn(1) == 0 {
-					ws = fmt.Sprintf("&#%d;", r)
-				} else {
-					ws = fmt.Sprintf("&#%x;", r)
-				}
-			} else {
+			if ws = ""; w.obfuscate {
+				ws = w.obfuscated(r)
+			}
+			if ws == "" {
 				if i0 == -1 {
 					i0 = i
 				}
@@ -112,6 +358,9 @@ func (w *htmlOut) str(s string, obfuscate bool) *htmlOut {
 			w.WriteString(s[i0:i])
 			i0 = -1
 		}
+		if w.inAttr && w.numeric {
+			ws = numericEntity(ws)
+		}
 		w.WriteString(ws)
 	}
 	if i0 != -1 {
@@ -120,128 +369,764 @@ func (w *htmlOut) str(s string, obfuscate bool) *htmlOut {
 	return w
 }
 
-/* print a list of elements
+
+/* Renderer methods
  */
-func (w *htmlOut) elist(list *element, obfuscate bool) *htmlOut {
-	for list != nil {
-		w.elem(list, obfuscate)
-		list = list.next
+
+func (w *htmlOut) Str(s string) {
+	w.str(s)
+}
+
+func (w *htmlOut) Space(s string) {
+	w.s(s)
+}
+
+func (w *htmlOut) LineBreak() {
+	w.s("<br").endVoid("/>").s("\n")
+}
+
+func (w *htmlOut) Ellipsis() {
+	w.entity("&hellip;")
+}
+
+func (w *htmlOut) EmDash() {
+	w.entity("&mdash;")
+}
+
+func (w *htmlOut) EnDash() {
+	w.entity("&ndash;")
+}
+
+func (w *htmlOut) Apostrophe() {
+	w.entity("&rsquo;")
+}
+
+func (w *htmlOut) SingleQuoted(entering bool) {
+	if entering {
+		w.entity("&lsquo;")
+	} else {
+		w.entity("&rsquo;")
+	}
+}
+
+func (w *htmlOut) DoubleQuoted(entering bool) {
+	if entering {
+		w.entity("&ldquo;")
+	} else {
+		w.entity("&rdquo;")
+	}
+}
+
+func (w *htmlOut) Code(s string) {
+	name := w.spanName(CODE, "code")
+	if w.isolate {
+		w.s("<").s(name).s(` dir="ltr">`)
+	} else {
+		w.tag(name, true)
+	}
+	w.str(s).tag(name, false)
+}
+
+func (w *htmlOut) Math(s string, display bool) {
+	if display {
+		w.s(`<span class="math display">\[`).str(s).s(`\]</span>`)
+	} else {
+		w.s(`<span class="math inline">\(`).str(s).s(`\)</span>`)
+	}
+	w.pset(0)
+}
+
+func (w *htmlOut) Html(s string) {
+	if w.entities != EntitiesNamed && strings.HasPrefix(s, "&") {
+		/* a character reference */
+		s = sourceEntity(s, w.entities)
+		if w.inAttr && w.numeric {
+			s = numericEntity(s)
+		}
+		w.s(s)
+		return
+	}
+	if w.xhtml {
+		s = xhtml(s)
+	}
+	w.s(s)
+}
+
+func (w *htmlOut) Format() string {
+	return "html"
+}
+
+func (w *htmlOut) Raw(s string) {
+	w.s(s)
+}
+
+func (w *htmlOut) SetAttributes(a *Attributes) {
+	w.attr = a
+}
+
+/* position - print the data-sourcepos attribute of the top level
+ * block being started, if any
+ */
+func (w *htmlOut) position() *htmlOut {
+	if w.pos != "" {
+		w.s(` data-sourcepos="`).s(w.pos).s(`"`)
+		w.pos = ""
+	}
+	return w
+}
+
+/* direction - print the attribute dir of a block element, see Doc.Dir
+ */
+func (w *htmlOut) direction() *htmlOut {
+	if w.dir != "" {
+		w.defaultAttr("dir", w.dir)
 	}
 	return w
 }
 
-// print an element
-func (w *htmlOut) elem(elt *element, obfuscate bool) *htmlOut {
-	var s string
-
-	switch elt.key {
-	case SPACE:
-		s = elt.contents.str
-	case LINEBREAK:
-		s = "<br/>\n"
-	case STR:
-		w.str(elt.contents.str, obfuscate)
-	case ELLIPSIS:
-		s = "&hellip;"
-	case EMDASH:
-		s = "&mdash;"
-	case ENDASH:
-		s = "&ndash;"
-	case APOSTROPHE:
-		s = "&rsquo;"
-	case SINGLEQUOTED:
-		w.s("&lsquo;").elist(elt.children, obfuscate).s("&rsquo;")
-	case DOUBLEQUOTED:
-		w.s("&ldquo;").elist(elt.children, obfuscate).s("&rdquo;")
-	case CODE:
-		w.s("<code>").str(elt.contents.str, obfuscate).s("</code>")
-	case HTML:
-		s = elt.contents.str
-	case LINK:
-		if strings.Index(elt.contents.link.url, "mailto:") == 0 {
-			obfuscate = true	/* obfuscate mailto: links */
-		}
-		w.s(`<a href="`).str(elt.contents.link.url, obfuscate).s(`"`)
-		if len(elt.contents.link.title) > 0 {
-			w.s(` title="`).str(elt.contents.link.title, obfuscate).s(`"`)
-		}
-		w.s(">").elist(elt.contents.link.label, obfuscate).s("</a>")
-	case IMAGE:
-		w.s(`<img src="`).str(elt.contents.link.url, obfuscate).s(`" alt="`)
-		w.elist(elt.contents.link.label, obfuscate).s(`"`)
-		if len(elt.contents.link.title) > 0 {
-			w.s(` title="`).str(elt.contents.link.title, obfuscate).s(`"`)
-		}
-		w.s(" />")
-	case EMPH:
-		w.s("<em>").elist(elt.children, obfuscate).s("</em>")
-	case STRONG:
-		w.s("<strong>").elist(elt.children, obfuscate).s("</strong>")
-	case LIST:
-		w.elist(elt.children, obfuscate)
-	case RAW:
-		/* Shouldn't occur - these are handled by process_raw_blocks() */
-		log.Fatalf("RAW")
-	case H1, H2, H3, H4, H5, H6:
-		h := "h" + string('1'+elt.key-H1) + ">"	/* assumes H1 ... H6 are in order */
-		w.pad(2).s("<").s(h).elist(elt.children, obfuscate).s("</").s(h).pset(0)
-	case PLAIN:
-		w.pad(1).elist(elt.children, obfuscate).pset(0)
-	case PARA:
-		w.pad(2).s("<p>").elist(elt.children, obfuscate).s("</p>").pset(0)
-	case HRULE:
-		w.pad(2).s("<hr />").pset(0)
-	case HTMLBLOCK:
-		w.pad(2).s(elt.contents.str).pset(0)
-	case VERBATIM:
-		w.pad(2).s("<pre><code>").str(elt.contents.str, obfuscate).s("</code></pre>").pset(0)
-	case BULLETLIST:
-		w.pad(2).s("<ul>").pset(0).elist(elt.children, obfuscate).pad(1).s("</ul>").pset(0)
-	case ORDEREDLIST:
-		w.pad(2).s("<ol>").pset(0).elist(elt.children, obfuscate).pad(1).s("</ol>").pset(0)
-	case DEFINITIONLIST:
-		w.pad(2).s("<dl>").pset(0).elist(elt.children, obfuscate).pad(1).s("</dl>").pset(0)
-	case DEFTITLE:
-		w.pad(1).s("<dt>").pset(2).elist(elt.children, obfuscate).s("</dt>").pset(0)
-	case DEFDATA:
-		w.pad(1).s("<dd>").pset(2).elist(elt.children, obfuscate).s("</dd>").pset(0)
-	case LISTITEM:
-		w.pad(1).s("<li>").pset(2).elist(elt.children, obfuscate).s("</li>").pset(0)
-	case BLOCKQUOTE:
-		w.pad(2).s("<blockquote>\n").pset(2).elist(elt.children, obfuscate).pad(1).s("</blockquote>").pset(0)
-	case REFERENCE:
-		/* Nonprinting */
-	case NOTE:
-		/* if contents.str == 0, then print note; else ignore, since this
-		 * is a note block that has been incorporated into the notes list
-		 */
-		if elt.contents.str == "" {
-			w.endNotes = append(w.endNotes, elt)	/* add an endnote to global endnotes list */
-			w.notenum++
-			nn := w.notenum
-			s = fmt.Sprintf(`<a class="noteref" id="fnref%d" href="#fn%d" title="Jump to note %d">[%d]</a>`,
-				nn, nn, nn, nn)
+/* attributes - print the attributes passed to SetAttributes, if any.
+ * The id is left out if the tag has one already.
+ */
+func (w *htmlOut) attributes(withID bool) *htmlOut {
+	a := w.attr
+	if a == nil {
+		return w
+	}
+	w.attr = nil
+	if withID && a.ID != "" {
+		w.s(` id="`).value(a.ID).s(`"`)
+	}
+	if len(a.Classes) > 0 {
+		w.s(` class="`).value(strings.Join(a.Classes, " ")).s(`"`)
+	}
+	for _, kv := range a.Attrs {
+		w.s(" ").s(kv.Key).s(`="`).value(kv.Value).s(`"`)
+	}
+	return w
+}
+
+/* obfuscated - return the character reference printed for r within a
+ * mailto: link, or "" if it is printed as is: with MailtoMixed, '@' is
+ * always replaced, ':' never, other characters in nine of ten cases,
+ * half of them by hexadecimal references
+ */
+func (w *htmlOut) obfuscated(r int) string {
+	if w.mailto != MailtoMixed {
+		return fmt.Sprintf("&#%d;", r)
+	}
+	switch x := w.rand.Float64(); {
+	case r == '@':
+	case r == ':', x >= 0.9:
+		return ""
+	case x < 0.45:
+		return fmt.Sprintf("&#x%X;", r)
+	}
+	return fmt.Sprintf("&#%d;", r)
+}
+
+func (w *htmlOut) Link(url, title string, entering bool) {
+	if !entering {
+		w.s("</a>")
+		w.obfuscate = false
+		return
+	}
+	if strings.Index(url, "mailto:") == 0 && w.mailto != MailtoPlain {
+		w.obfuscate = true	/* obfuscate mailto: links */
+	}
+	w.s(`<a href="`).url(url).s(`"`)
+	if len(title) > 0 {
+		if w.popovers {
+			w.s(` data-title="`).value(title).s(`"`)
+		} else {
+			w.s(` title="`).value(title).s(`"`)
 		}
-	default:
-		log.Fatalf("htmlOut.elem encountered unknown element key = %d\n", elt.key)
 	}
-	if s != "" {
-		w.s(s)
+	var rel []string
+	external := (w.blank || w.extRel != "") && w.external(url)
+	if external && w.blank {
+		w.defaultAttr("target", "_blank")
+		rel = append(rel, "noopener")
+	}
+	if w.noFollow != nil && w.noFollow(url) {
+		rel = append(rel, "nofollow")
+	}
+	if external {
+		rel = append(rel, strings.Fields(w.extRel)...)
+	}
+	if len(rel) > 0 {
+		/* merge the values given by the attribute block */
+		rel = append(rel, strings.Fields(w.takeAttr("rel"))...)
+		w.s(` rel="`).value(strings.Join(uniq(rel), " ")).s(`"`)
+	}
+	if w.isolate {
+		w.defaultAttr("dir", "auto")
+	}
+	w.attributes(true).s(">")
+}
+
+/* isExternal - report whether url is an absolute one, of http: or
+ * https:, or starts with //
+ */
+func isExternal(url string) bool {
+	s := strings.ToLower(url)
+	return strings.HasPrefix(s, "http:") || strings.HasPrefix(s, "https:") || strings.HasPrefix(s, "//")
+}
+
+/* takeAttr - remove an attribute from those passed to SetAttributes,
+ * returning its value, or ""
+ */
+func (w *htmlOut) takeAttr(key string) string {
+	if w.attr == nil {
+		return ""
+	}
+	for i, kv := range w.attr.Attrs {
+		if kv.Key == key {
+			a := *w.attr
+			a.Attrs = append(append([]Attr(nil), a.Attrs[:i]...), a.Attrs[i+1:]...)
+			w.attr = &a
+			return kv.Value
+		}
+	}
+	return ""
+}
+
+func uniq(list []string) []string {
+	var u []string
+	seen := make(map[string]bool)
+	for _, s := range list {
+		if !seen[s] {
+			seen[s] = true
+			u = append(u, s)
+		}
+	}
+	return u
+}
+
+func (w *htmlOut) Image(url, title string, entering bool) {
+	if entering {
+		w.s(`<img src="`).url(url).s(`" alt="`)
+		w.inAttr = true
+		if w.xhtml {
+			/* collect the alternate text, to remove tags from it */
+			w.outer = w.Writer
+			w.Writer = new(bytes.Buffer)
+		}
+		return
+	}
+	if w.outer != nil {
+		alt := w.Writer.(*bytes.Buffer).String()
+		w.Writer = w.outer
+		w.outer = nil
+		w.s(stripTags(alt))
+	}
+	w.inAttr = false
+	w.s(`"`)
+	if len(title) > 0 {
+		w.s(` title="`).value(title).s(`"`)
+	}
+	w.attr = imageSize(w.attr)
+	if w.lazy {
+		w.defaultAttr("loading", "lazy").defaultAttr("decoding", "async")
+	}
+	if w.srcset != nil {
+		srcset, sizes := w.srcset(url)
+		if srcset != "" {
+			w.defaultAttr("srcset", srcset)
+			if sizes != "" {
+				w.defaultAttr("sizes", sizes)
+			}
+		}
+	}
+	w.attributes(true).endVoid(" />")
+}
+
+/* defaultAttr - print an attribute, unless one of the same name is
+ * given by the attribute block of the element
+ */
+func (w *htmlOut) defaultAttr(key, value string) *htmlOut {
+	if w.attr != nil {
+		for _, kv := range w.attr.Attrs {
+			if kv.Key == key {
+				return w
+			}
+		}
+	}
+	return w.s(" " + key + `="`).value(value).s(`"`)
+}
+
+func (w *htmlOut) Emph(entering bool) {
+	w.span(EMPH, "em", entering)
+}
+
+func (w *htmlOut) Strong(entering bool) {
+	w.span(STRONG, "strong", entering)
+}
+
+func (w *htmlOut) Strike(entering bool) {
+	w.span(STRIKE, "del", entering)
+}
+
+func (w *htmlOut) Superscript(entering bool) {
+	w.span(SUPERSCRIPT, "sup", entering)
+}
+
+func (w *htmlOut) Subscript(entering bool) {
+	w.span(SUBSCRIPT, "sub", entering)
+}
+
+func (w *htmlOut) Abbr(title string, entering bool) {
+	if entering {
+		w.s(`<abbr title="`).value(title).s(`">`)
+	} else {
+		w.s("</abbr>")
+	}
+}
+
+func (w *htmlOut) Note(n int, body func()) {
+	if len(w.endNotes) == 0 {
+		w.noteBase = n
+	}
+	w.endNotes = append(w.endNotes, body)	/* add an endnote to global endnotes list */
+	marker := noteMarker(n, w.symbols)
+	if st := w.noteStyle; st != nil {
+		w.s(fmt.Sprintf(`<sup id="fnref%d"><a href="#fn%d" class="`, n, n)).value(st.RefClass).s(`"`)
+		w.notePopover(n).s(">")
+		if w.symbols {
+			w.str(marker)
+		} else {
+			w.str(fmt.Sprintf(st.Marker, n))
+		}
+		w.s("</a></sup>")
+		return
+	}
+	w.s(fmt.Sprintf(`<a class="noteref" id="fnref%d" href="#fn%d" title="Jump to note `, n, n))
+	w.str(marker).s(`"`).notePopover(n).s(">[").str(marker).s("]</a>")
+}
+
+/* SetPosition - remember the position of the element started next,
+ * printed as data-sourcepos attribute, see Doc.SourcePos
+ */
+func (w *htmlOut) SetPosition(line, col, endLine, endCol int) {
+	if !w.sourcePos {
+		return
+	}
+	w.pos = ""
+	if line > 0 {
+		w.pos = fmt.Sprintf("%d:%d-%d:%d", line, col, endLine, endCol)
+	}
+}
+
+func (w *htmlOut) failed() bool {
+	if w.dest.err == nil && canceled(w.cancel) {
+		w.dest.err = ErrCanceled	/* drop the output following */
+	}
+	return w.dest.err != nil
+}
+
+/* notePopover - print the attributes of the reference to note n
+ * holding its text, see Doc.Popovers
+ */
+func (w *htmlOut) notePopover(n int) *htmlOut {
+	if w.popovers {
+		w.s(fmt.Sprintf(` aria-describedby="fn%d" data-footnote-content="`, n)).value(w.popover).s(`"`)
+		w.popover = ""
 	}
 	return w
 }
 
+/* PreviewNote - render the contents of the note referred to next into
+ * w.popover, compactly, by a copy of w, so that the state of w is
+ * kept.  The bodies of notes referred to by the contents are dropped.
+ */
+func (w *htmlOut) PreviewNote(n int, render func(r Renderer)) {
+	if !w.popovers {
+		return
+	}
+	buf := new(bytes.Buffer)
+	p := *w
+	p.Writer = buf
+	p.popovers = false
+	p.compact = true
+	p.unwrap = false
+	p.padded = 2
+	p.depth = 0
+	p.bol = false
+	p.attr = nil
+	p.outer = nil
+	p.endNotes = nil
+	p.placement = NotesAtEnd
+	render(&p)
+	w.popover = buf.String()
+}
+
+func (w *htmlOut) Heading(level int, id string, entering bool) {
+	h := "h" + string('0'+level)
+	if entering {
+		if w.placement == NotesPerSection && level <= 2 {
+			w.flushNotes()
+		}
+		w.pad(2).s("<").s(h).position().direction()
+		if id != "" {
+			w.s(` id="`).value(id).s(`"`)
+		}
+		w.anchor = id
+		if w.anchor == "" && w.attr != nil {
+			w.anchor = w.attr.ID
+		}
+		w.attributes(id == "").s(">")
+		if w.permalink != nil && w.permalink.Before {
+			w.headingLink()
+		}
+	} else {
+		if w.permalink != nil && !w.permalink.Before {
+			w.headingLink()
+		}
+		w.s("</").s(h).s(">").pset(0)
+	}
+}
+
+/* headingLink - print the permalink of the heading being printed, if
+ * it has an id, separated from its text by a space
+ */
+func (w *htmlOut) headingLink() {
+	if w.anchor == "" {
+		return
+	}
+	if !w.permalink.Before {
+		w.s(" ")
+	}
+	w.s(`<a class="`).value(w.permalink.Class).s(`" href="#`).value(w.anchor).s(`" aria-hidden="true">`)
+	w.s(w.permalink.Symbol).s("</a>")
+	if w.permalink.Before {
+		w.s(" ")
+	}
+}
+
+func (w *htmlOut) Plain(entering bool) {
+	if entering {
+		w.pad(1)
+	} else {
+		w.pset(0)
+	}
+}
+
+func (w *htmlOut) Para(entering bool) {
+	if w.unwrap {
+		w.unwrap = entering
+		w.pset(0)
+		return
+	}
+	if entering {
+		w.pad(2).s("<p").position().direction().s(">")
+	} else {
+		w.s("</p>").pset(0)
+	}
+}
+
+func (w *htmlOut) HRule() {
+	w.pad(2).s("<hr").position().endVoid(" />").pset(0)
+}
+
+func (w *htmlOut) HtmlBlock(s string) {
+	if w.placement == NotesAtMarker && strings.TrimSpace(s) == NotesMarker {
+		w.flushNotes()
+		return
+	}
+	if w.xhtml {
+		s = xhtml(s)
+	}
+	w.pad(2).s(s).pset(0)
+}
+
+func (w *htmlOut) Verbatim(s, lang string) {
+	w.pad(2).s("<pre").position()
+	if w.dir != "" {
+		w.defaultAttr("dir", "ltr")
+	}
+	w.attributes(true).s("><code")
+	if lang != "" {
+		w.s(` class="language-`).str(lang).s(`"`)
+	}
+	w.s(">")
+	if w.highlight != nil {
+		var b bytes.Buffer
+		if err := w.highlight(lang, []byte(s), &b); err == nil {
+			w.s(b.String()).s("</code></pre>").pset(0)
+			return
+		}
+	}
+	w.str(s).s("</code></pre>").pset(0)
+}
+
+func (w *htmlOut) BlockQuote(entering bool) {
+	if entering {
+		w.pad(2).s("<blockquote").position().direction().s(">").nest(1).nl().pset(2)
+	} else {
+		w.nest(-1).pad(1).s("</blockquote>").pset(0)
+	}
+}
+
+func (w *htmlOut) BulletList(entering bool) {
+	w.list("ul", entering)
+}
+
+func (w *htmlOut) OrderedList(start int, delim string, entering bool) {
+	if entering && start != 1 {
+		w.list(fmt.Sprintf(`ol start="%d"`, start), true)
+		return
+	}
+	w.list("ol", entering)
+}
+
+func (w *htmlOut) DefinitionList(entering bool) {
+	w.list("dl", entering)
+}
+
+func (w *htmlOut) ListItem(entering bool) {
+	w.item("li", entering)
+}
+
+func (w *htmlOut) TaskItem(done bool, entering bool) {
+	if !entering {
+		w.item("li", false)
+		return
+	}
+	w.pad(1)
+	if done {
+		w.s(`<li class="task done"`).direction().s(`><input type="checkbox" disabled="disabled" checked="checked"`)
+	} else {
+		w.s(`<li class="task"`).direction().s(`><input type="checkbox" disabled="disabled"`)
+	}
+	w.endVoid(" />").s(" ").nest(1).pset(2)
+}
+
+func (w *htmlOut) DefTitle(entering bool) {
+	w.item("dt", entering)
+}
+
+func (w *htmlOut) DefData(entering bool) {
+	w.item("dd", entering)
+}
+
+func (w *htmlOut) Table(align []string, entering bool) {
+	w.list("table", entering)
+}
+
+func (w *htmlOut) TableHead(entering bool) {
+	w.line("thead", entering)
+}
+
+func (w *htmlOut) TableBody(entering bool) {
+	w.line("tbody", entering)
+}
+
+func (w *htmlOut) TableRow(entering bool) {
+	w.line("tr", entering)
+}
+
+func (w *htmlOut) TableCell(align string, header bool, entering bool) {
+	name := "td"
+	if header {
+		name = "th"
+	}
+	if !entering {
+		w.tag(name, false).pset(0)
+		return
+	}
+	w.pad(1).s("<").s(name).direction()
+	switch {
+	case align == "":
+	case w.noObsolete:
+		w.s(` style="text-align: `).s(align).s(`"`)
+	default:
+		w.s(` align="`).s(align).s(`"`)
+	}
+	w.s(">")
+}
+
+func (w *htmlOut) TOC(toc []*TOCItem) {
+	w.pad(2).tocList(toc).pset(0)
+}
+
+func (w *htmlOut) tocList(list []*TOCItem) *htmlOut {
+	w.s("<ul").position().s(">").nest(1)
+	for _, item := range list {
+		w.nl().s("<li><a href=\"#").value(item.Anchor).s(`">`)
+		if item.Number != "" {
+			w.str(item.Number).s(" ")
+		}
+		w.str(item.Text).s("</a>")
+		if len(item.Sub) != 0 {
+			w.nest(1).nl().tocList(item.Sub).nest(-1)
+		}
+		w.s("</li>")
+	}
+	return w.nest(-1).nl().s("</ul>")
+}
+
+// print an inline start or end tag
+func (w *htmlOut) tag(name string, entering bool) *htmlOut {
+	if entering {
+		return w.s("<").s(name).s(">")
+	}
+	return w.s("</").s(name).s(">")
+}
+
+// print an inline start or end tag of a span of the kind given:
+// name, unless Doc.SpanTags maps the kind to another element
+func (w *htmlOut) span(kind int, name string, entering bool) *htmlOut {
+	return w.tag(w.spanName(kind, name), entering)
+}
+
+// the name of the tags of a span of the kind given, see span
+func (w *htmlOut) spanName(kind int, name string) string {
+	if t := w.spanTags[kind]; t != "" {
+		return t
+	}
+	return name
+}
+
+// print a start or end tag of a list
+func (w *htmlOut) list(name string, entering bool) *htmlOut {
+	if entering {
+		return w.pad(2).s("<").s(name).position().s(">").nest(1).pset(0)
+	}
+	return w.nest(-1).pad(1).tag(name, false).pset(0)
+}
+
+// endVoid - finish the tag of a void element like <br>; xhtml is
+// the ending printed unless HTML5 output is selected
+func (w *htmlOut) endVoid(xhtml string) *htmlOut {
+	if w.html5 && !w.xhtml {
+		return w.s(">")
+	}
+	return w.s(xhtml)
+}
+
+// print a start or end tag on a line of its own
+func (w *htmlOut) line(name string, entering bool) *htmlOut {
+	if entering {
+		return w.pad(1).tag(name, true).nest(1).pset(0)
+	}
+	return w.nest(-1).pad(1).tag(name, false).pset(0)
+}
+
+// print a start or end tag of a list item
+func (w *htmlOut) item(name string, entering bool) *htmlOut {
+	if entering {
+		return w.pad(1).s("<").s(name).direction().s(">").nest(1).pset(2)
+	}
+	return w.nest(-1).tag(name, false).pset(0)
+}
+
 
 func (w *htmlOut) printEndnotes() {
-	counter := 0
+	counter := w.noteBase - 1
+
+	if w.noteStyle != nil {
+		w.printStyledEndnotes()
+		return
+	}
 
-	w.s("<hr/>\n<ol id=\"notes\">")
-	for _, elt := range w.endNotes {
+	w.s("<hr").endVoid("/>").nl().s("<ol").noteList().s(">").nest(1)
+	/* notes referred to by the notes printed are appended to endNotes */
+	for i := 0; i < len(w.endNotes); i++ {
+		body := w.endNotes[i]
 		counter++
-		w.pad(1).s(fmt.Sprintf("<li id=\"fn%d\">\n", counter)).pset(2)
-		w.elist(elt.children, false)
+		w.pad(1).s(fmt.Sprintf("<li id=\"fn%d\">", counter)).nest(1).nl().pset(2)
+		if w.symbols {
+			w.s(markNote(w.noteText(body), noteMarker(counter, true)))
+		} else {
+			body()
+		}
 		w.s(fmt.Sprintf(" <a href=\"#fnref%d\" title=\"Jump back to reference\">[back]</a>", counter))
-		w.pad(1).s("</li>")
+		w.nest(-1).pad(1).s("</li>")
+	}
+	w.nest(-1).pad(1).s("</ol>")
+}
+
+/* noteList - print the attributes of the list of notes: its id, the
+ * number of the first note, unless it is 1, or, for symbols, a style
+ * not numbering the items
+ */
+func (w *htmlOut) noteList() *htmlOut {
+	if w.noteStyle == nil {
+		if w.noteBase == 1 {
+			w.s(` id="notes"`)
+		} else {
+			w.s(fmt.Sprintf(` id="notes%d"`, w.noteBase))
+		}
+	}
+	switch {
+	case w.symbols:
+		w.s(` style="list-style-type:none"`)
+	case w.noteBase != 1:
+		w.s(fmt.Sprintf(` start="%d"`, w.noteBase))
+	}
+	return w
+}
+
+/* noteText - return the HTML text of the body of a note */
+func (w *htmlOut) noteText(body func()) string {
+	if w.bol {
+		w.indentLine()
+	}
+	out := w.Writer
+	buf := new(bytes.Buffer)
+	w.Writer = buf
+	body()
+	w.Writer = out
+	return buf.String()
+}
+
+/* printStyledEndnotes - print the notes according to w.noteStyle.
+ * The return link is placed within the last paragraph of a note,
+ * or in a paragraph of its own, if the note doesn't end with one.
+ */
+func (w *htmlOut) printStyledEndnotes() {
+	st := w.noteStyle
+	w.s(`<div class="`).value(st.Class).s(`">`).nest(1).nl().s("<hr").endVoid(" />").nl().s("<ol").noteList().s(">").nest(1)
+	for i := 0; i < len(w.endNotes); i++ {
+		body := w.endNotes[i]
+		n := w.noteBase + i
+		w.pad(1).s(fmt.Sprintf("<li id=\"fn%d\">", n)).nest(1).nl().pset(2)
+		note := w.noteText(body)
+		if w.symbols {
+			note = markNote(note, noteMarker(n, true))
+		}
+		if strings.HasSuffix(note, "</p>") {
+			w.s(note[:len(note)-4]).s(" ")
+		} else {
+			w.s(note).nl().s("<p>")
+		}
+		w.s(fmt.Sprintf(`<a href="#fnref%d" class="`, n)).value(st.BackRefClass).s(`">`).s(st.BackRef).s("</a></p>")
+		w.nest(-1).pad(1).s("</li>")
+	}
+	w.nest(-1).pad(1).s("</ol>").nest(-1).nl().s("</div>")
+}
+
+func (pl *Permalink) withDefaults() *Permalink {
+	p := *pl
+	if p.Class == "" {
+		p.Class = "anchor"
+	}
+	if p.Symbol == "" {
+		p.Symbol = "&#182;"
+	}
+	return &p
+}
+
+func (st *NoteStyle) withDefaults() *NoteStyle {
+	s := *st
+	def := func(f *string, v string) {
+		if *f == "" {
+			*f = v
+		}
 	}
-	w.pad(1).s("</ol>")
+	def(&s.Class, "footnotes")
+	def(&s.RefClass, "footnote-ref")
+	def(&s.BackRefClass, "footnote-backref")
+	def(&s.Marker, "%d")
+	def(&s.BackRef, "&#8617;")
+	return &s
 }
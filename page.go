@@ -0,0 +1,85 @@
+package markdown
+
+// Complete HTML documents
+
+import (
+	"bytes"
+	"io"
+	"os"
+)
+
+// A Template prints a page, given a *PageData. It is satisfied
+// by the Template types of the template packages.
+type Template interface {
+	Execute(w io.Writer, data interface{}) os.Error
+}
+
+// A Page describes the HTML document written by WriteHtmlDocument.
+type Page struct {
+	Title	string		// if empty, the front matter title, or the text of the first heading
+	Charset	string		// if empty, "utf-8"
+	CSS		[]string	// URLs of style sheets to link to
+
+	// If not nil, Template is executed with a *PageData to print
+	// the document, instead of using the built-in layout.
+	Template	Template
+}
+
+// PageData is the data passed to the Template of a Page. Note that
+// templates of package html/template escape Body, unless it is passed
+// through a function converting it to template.HTML.
+type PageData struct {
+	Title	string
+	Charset	string
+	CSS		[]string
+	Meta	map[string]string	// front matter values, or nil
+	Body	string				// the document in HTML format
+}
+
+// WriteHtmlDocument prints a document tree in HTML format, like
+// WriteHtml, as the body of a complete HTML document described by
+// page, which may be nil.  Like WriteHtml, it returns the number of
+// bytes written, and the first error, of w or of the Template.
+func (d *Doc) WriteHtmlDocument(w Writer, page *Page) (n int, err os.Error) {
+	if page == nil {
+		page = new(Page)
+	}
+	data := &PageData{Title: page.Title, Charset: page.Charset, CSS: page.CSS}
+	if m := d.Meta(); m != nil {
+		data.Meta = m.Values
+		if data.Title == "" {
+			data.Title = m.Values["title"]
+		}
+	}
+	if data.Title == "" {
+		if h := d.headings(); len(h) > 0 {
+			data.Title = plainText(h[0].children)
+		}
+	}
+	if data.Charset == "" {
+		data.Charset = "utf-8"
+	}
+	body := new(bytes.Buffer)
+	if _, err = d.WriteHtml(body); err != nil {
+		return 0, err
+	}
+	data.Body = body.String()
+
+	if page.Template != nil {
+		buf := new(bytes.Buffer)
+		if err = page.Template.Execute(buf, data); err != nil {
+			return 0, err
+		}
+		return w.WriteString(buf.String())
+	}
+
+	out := d.newHtmlOut(w)
+	out.s("<!DOCTYPE html>\n<html>\n<head>\n")
+	out.s(`<meta charset="`).str(data.Charset).s(`"`).endVoid(" />").s("\n")
+	out.s("<title>").str(data.Title).s("</title>\n")
+	for _, css := range data.CSS {
+		out.s(`<link rel="stylesheet" href="`).str(css).s(`"`).endVoid(" />").s("\n")
+	}
+	out.s("</head>\n<body>\n").s(data.Body).s("</body>\n</html>\n")
+	return out.dest.n, out.dest.err
+}
@@ -23,23 +23,30 @@ package markdown
 import (
 	"fmt"
 	"strings"
-	"log"
+	"os"
 	"sync"
 )
 
-// Semantic value of a parsing action.
-type element struct {
+// An Element is a node of the document tree; it is also
+// the semantic value of a parsing action.
+type Element struct {
 	key	int
 	contents
-	children	*element
-	next		*element
+	children	*Element
+	next		*Element
+
+	line, endLine	int			/* Source lines, see Lines. */
+	col, endCol		int			/* Columns of the first and the last character of a top level block. */
+	attr			*Attributes	/* Set by the Attributes extension. */
+	loose			bool		/* Set on lists whose items contain paragraphs, see Tight. */
 }
 
 // Information (label, URL and title) for a link.
 type link struct {
-	label	*element
+	label	*Element
 	url		string
 	title	string
+	ref		*link	/* The definition a reference link has been resolved by. */
 }
 
 // Union for contents of an Element (string, list, or link).
@@ -84,9 +91,27 @@ const (
 	HRULE
 	REFERENCE
 	NOTE
-	DEFINITIONLIST
+	DEFINITIONLIST	/* Children are DEFTITLE and DEFDATA elements. */
 	DEFTITLE
 	DEFDATA
+	TABLE
+	TABLEHEAD
+	TABLEBODY
+	TABLEROW
+	TABLECELL	/* contents.str holds the alignment of the column */
+	TOC			/* Placeholder for the table of contents */
+	STRIKE
+	MATH
+	DISPLAYMATH
+	ABBR			/* contents.str holds the title */
+	ABBREVIATION	/* A definition; contents.str holds the title */
+	SUPERSCRIPT
+	SUBSCRIPT
+	EMOJI			/* contents.str holds the shortcode */
+	ADMONITION		/* contents.str holds the kind, the label the title */
+	DIV				/* A fenced div; contents.str holds the classes */
+	CITATION		/* contents.str holds the source, the children the formatted citation */
+	BIBLIOGRAPHY	/* The works cited, a DIV for each */
 	numVAL
 )
 
@@ -94,15 +119,205 @@ type Doc struct {
 	parser		*yyParser
 	extension	Extensions
 
-	tree				*element	/* Results of parse. */
-	references			*element	/* List of link references found. */
-	notes				*element	/* List of footnotes found. */
+	tree				*Element	/* Results of parse. */
+	references			*Element	/* List of link references found. */
+	notes				*Element	/* List of footnotes found. */
+	abbreviations		*Element	/* List of abbreviation definitions found. */
+	meta				*Meta		/* Front matter, if any. */
+	spans				[]int		/* Start and end offsets of the top level blocks. */
+	blocks				[]int		/* Lines the top level blocks start at, see blockLines. */
+	warnings			[]warning	/* Problems found while parsing. */
+	listKind			string		/* Marker of the first item of the list being parsed, see sameKind. */
+	labels				int			/* Nesting of the link labels being parsed, see enterLabel. */
+	smart				SmartOptions
+	slugger				func(string) string
+	emoji				EmojiOptions
+	wikiResolver		func(string) (string, bool)
+	bibliography		Bibliography
+	htmlPolicy			int
+	filterHTML			func(html string, block bool) string
+	openTags			map[string][]bool	/* Whether the start tags within text still open have been dropped, see filterTag. */
+	commentPolicy		int
+	filterComment		func(text string, block bool) string
+	filterText			func(text string, parents []*Element) string
+	filtered			map[*Element]bool	/* Contents of notes passed to filterText already. */
+	expanding			map[*Element]bool	/* Contents of the notes being parsed, see processRawBlocks. */
+	shared				map[*Element]bool	/* Notes whose contents are shared by their definitions in the tree. */
+	templates			[]string	/* Delimiters of template spans, see Parser.TemplateDelimiters. */
+	cited				map[string]bool			/* Keys of the works cited. */
+	plugins				*pluginSet				/* Plugins registered, see plugin.go. */
+	matches				map[string]pluginMatch	/* Texts matched by plugins. */
+	limits				Limits
+	deadline			int64	/* End of the parse time allowed, or 0. */
+	cancel				<-chan struct{}	/* Closed to stop parsing, see Parser.ParseCancel. */
+	depth				int		/* Current nesting of elements. */
+	steps				int		/* Calls of enter, the clock is read every 1024th. */
+	err					os.Error	/* Set if a limit has been exceeded, or parsing has been canceled. */
+
+	OutputOptions
+}
+
+// OutputOptions are the settings of a Doc for its output, set by the
+// caller after parsing.  They are kept by Parser.Reparse, which copies
+// them as a whole.
+type OutputOptions struct {
+	Highlight	Highlighter	/* If not nil, formats code blocks in HTML output. */
+	ResolveURL	URLResolver	/* If not nil, rewrites link and image URLs in all output formats. */
+
+	// RenderOverride maps element kinds, like IMAGE, to functions
+	// consulted by Render before the Renderer, see RenderFunc.
+	RenderOverride	map[int]RenderFunc
+
+	// If not nil, HTML links to URLs for which NoFollow
+	// returns true get the attribute rel="nofollow".
+	NoFollow	func(url string) bool
+
+	// If LazyImages is set, HTML images get the attributes
+	// loading="lazy" and decoding="async", so that browsers load
+	// them when they are scrolled into view.
+	LazyImages	bool
+
+	// If TargetBlankExternal is set, HTML links to external URLs
+	// get the attributes target="_blank" and rel="noopener"; if
+	// RelNofollowExternal is set, they get rel="nofollow ugc", as
+	// links in user generated content should, or the values of
+	// ExternalRel instead, if not empty. External reports whether
+	// a URL is external; if it is nil, those starting with http:,
+	// https: or // are.
+	TargetBlankExternal	bool
+	RelNofollowExternal	bool
+	ExternalRel			string
+	External			func(url string) bool
+
+	// If not nil, ImageSrcset is called for the URL of each image
+	// of HTML output, to supply responsive variants of it: a
+	// non-empty srcset, like "a-480.png 480w, a-800.png 800w", is
+	// printed as the srcset attribute, and sizes, if not empty, as
+	// the sizes attribute.
+	ImageSrcset	func(url string) (srcset, sizes string)
+
+	MailtoObfuscation	int	/* How mailto: links are printed in HTML output, see MailtoEntities. */
+
+	// SpanTags maps the kinds EMPH, STRONG, CODE, STRIKE,
+	// SUPERSCRIPT and SUBSCRIPT to the names of the HTML elements
+	// printed for them, instead of em, strong, code, del, sup and
+	// sub, e.g. to PresentationalTags, for themes that style <i>
+	// and <b> only, or CODE to "kbd".
+	SpanTags	map[int]string
+
+	NoteStyle	*NoteStyle	/* If not nil, selects the style of footnotes in HTML output. */
+	Permalink	*Permalink	/* If not nil, HTML headings with an id get links to themselves. */
+
+	// If Numbering is not nil, headings are prefixed by section
+	// numbers, also in the table of contents, except in LaTeX and
+	// groff mm output, which number sections themselves; see
+	// HeadingNumbers.
+	Numbering	*HeadingNumbering
+
+	// If SourcePos is set, the start tags of the top level blocks
+	// in HTML output get an attribute data-sourcepos, like
+	// "3:1-5:12", holding the line and the column, counting bytes
+	// after tabs have been expanded, of their first and their last
+	// character, e.g. to synchronize the scrolling of an editor and
+	// its preview.  Raw HTML blocks get none.
+	SourcePos	bool
+
+	// If Dir is not "", it is the direction, "auto", "rtl", or "ltr",
+	// printed as attribute dir of HTML paragraphs, headings, block
+	// quotes, list items, and table cells, so that right-to-left
+	// text, like Arabic or Hebrew, is laid out correctly; code blocks
+	// get dir="ltr" then.  If BidiIsolate is set, code spans get
+	// dir="ltr", and links dir="auto", which isolates them from the
+	// text around them, so that e.g. a Latin URL within a Hebrew
+	// sentence does not move the punctuation next to it.
+	Dir			string
+	BidiIsolate	bool
+
+	// If Popovers is set, HTML footnote references carry the HTML
+	// text of their notes in an attribute data-footnote-content, and
+	// refer to them by aria-describedby, and links carry their titles
+	// in data-title instead of title, so that scripts can show both
+	// in popovers, without fetching the notes, or tooltips in the way.
+	Popovers	bool
+
+	// Footnotes are numbered from NoteStart, or from 1, if it is 0,
+	// so that the chapters of a book, parsed one by one, can number
+	// them on (see NoteCount); the ids of HTML notes contain their
+	// numbers. If NoteSymbols is set, notes are marked by symbols
+	// instead, see FootnoteSymbols. NotePlacement selects where HTML
+	// output prints them, see NotesAtEnd.
+	NoteStart		int
+	NoteSymbols		bool
+	NotePlacement	int
+
+	// All output formats shift the levels of headings by
+	// HeadingOffset, e.g. printing <h3> instead of <h1> if it is 2,
+	// so that a document can be embedded in a page that has headings
+	// of its own.  Levels beyond MaxHeadingLevel, if it is not 0, or
+	// beyond 6, are printed as that level, levels below 1 as 1.
+	HeadingOffset	int
+	MaxHeadingLevel	int
+
+	// HTML dialect: if Html5 is set, void elements are printed
+	// like <br>, instead of <br />; if NoObsolete is set, attributes
+	// obsolete in HTML5, like align, are replaced by styles.
+	Html5		bool
+	NoObsolete	bool
+
+	// If XHTML is set, HTML output is well-formed XML, as needed
+	// e.g. for EPUB: named character references other than those
+	// of XML are replaced by numeric ones, void elements of raw
+	// HTML are closed, like <br />, and the alternate text of
+	// images is printed without tags. It overrides Html5.
+	XHTML	bool
+
+	// If UnwrapPara is set, and the document consists of a single
+	// paragraph, HTML output is its contents only, without <p> tags,
+	// e.g. for an image caption.
+	UnwrapPara	bool
+
+	// Layout of HTML output: if CompactHTML is set, block elements
+	// are printed without newlines between them; otherwise, if
+	// IndentHTML is not empty, the lines of nested block elements,
+	// like list items, are indented by it once per level. The text
+	// of paragraphs, code blocks, and raw HTML is printed as is. If
+	// NoFinalNewline is set, no newline follows the last element.
+	CompactHTML		bool
+	IndentHTML		string
+	NoFinalNewline	bool
+
+	// Escaping in HTML output: URLEscaping selects how the URLs of
+	// links and images are printed, see URLAsIs; if NumericEntities
+	// is set, character references in attribute values are numeric
+	// ones, like &#38;, instead of named ones, like &amp;.
+	URLEscaping		int
+	NumericEntities	bool
+
+	// Character references in HTML output: SourceEntities selects
+	// how those written in the source, like &nbsp; or &copy;, are
+	// printed, and PunctuationEntities how the dashes, ellipses and
+	// quotation marks of smart punctuation are, which are named
+	// references by default, see EntitiesNamed.  E.g., XML tools not
+	// knowing the entities of HTML get EntitiesNumeric or EntitiesUTF8.
+	SourceEntities		int
+	PunctuationEntities	int
+
+	// If Figures is set, a paragraph consisting of an image only is
+	// rendered as a figure, with the title of the image as caption:
+	// in HTML, as <figure> with <figcaption>.
+	Figures	bool
 }
 
 
 const (
-	ruleDoc	= iota
+	ruleDocBlock	= iota
+	ruleInlineDoc
 	ruleBlock
+	rulePluginBlock
+	ruleLatePluginBlock
+	rulePluginInline
+	ruleLatePluginInline
+	ruleTemplate
 	rulePara
 	rulePlain
 	ruleAtxInline
@@ -114,21 +329,60 @@ const (
 	ruleSetextHeading1
 	ruleSetextHeading2
 	ruleHeading
+	ruleHeadingAttributes
+	ruleAttributeBlock
+	ruleNothing
 	ruleBlockQuote
+	ruleAdmonition
+	ruleAdmonitionBlock
+	ruleAdmonitionKind
+	ruleAdmonitionTitle
+	ruleAdmonitionChunk
+	ruleAlert
+	ruleAlertKind
+	ruleFencedDiv
+	ruleDivFence
+	ruleDivStart
+	ruleDivBody
+	ruleDivOpen
+	ruleDivEnd
+	ruleDivLine
 	ruleBlockQuoteRaw
 	ruleNonblankIndentedLine
 	ruleVerbatimChunk
 	ruleVerbatim
+	ruleTocMarker
+	ruleFenceStart
+	ruleFencedCode
+	ruleTicksInfo
+	ruleTildesInfo
+	ruleFenceEof
+	ruleTicksClose3
+	ruleTicksClose4
+	ruleTicksClose5
+	ruleTildesClose3
+	ruleTildesClose4
+	ruleTildesClose5
+	ruleFencedCodeTicks3
+	ruleFencedCodeTicks4
+	ruleFencedCodeTicks5
+	ruleFencedCodeTildes3
+	ruleFencedCodeTildes4
+	ruleFencedCodeTildes5
 	ruleHorizontalRule
 	ruleBullet
 	ruleBulletList
+	ruleListKind
+	ruleSameKind
 	ruleListTight
 	ruleListLoose
 	ruleListItem
 	ruleListItemTight
+	ruleTaskMarker
 	ruleListBlock
 	ruleListContinuationBlock
 	ruleEnumerator
+	ruleListStart
 	ruleOrderedList
 	ruleListBlockLine
 	ruleHtmlBlockOpenAddress
@@ -248,10 +502,12 @@ const (
 	ruleNormalEndline
 	ruleTerminalEndline
 	ruleLineBreak
+	ruleEmoji
 	ruleSymbol
 	ruleUlOrStarLine
 	ruleStarLine
 	ruleUlLine
+	ruleIntraword
 	ruleEmph
 	ruleOneStarOpen
 	ruleOneStarClose
@@ -266,8 +522,17 @@ const (
 	ruleTwoUlOpen
 	ruleTwoUlClose
 	ruleStrongUl
+	ruleStrike
+	ruleSuperscript
+	ruleSubscript
 	ruleImage
 	ruleLink
+	ruleWikiLink
+	ruleWikiTarget
+	ruleCitation
+	ruleBracketCitation
+	ruleTextCitation
+	ruleCitationKey
 	ruleReferenceLink
 	ruleReferenceLinkDouble
 	ruleReferenceLinkSingle
@@ -279,6 +544,13 @@ const (
 	ruleTitleDouble
 	ruleAutoLink
 	ruleAutoLinkUrl
+	ruleBareLink
+	ruleBareUrl
+	ruleBareWww
+	ruleBareEmail
+	ruleUrlChar
+	ruleUrlEnd
+	ruleUrlParens
 	ruleAutoLinkEmail
 	ruleReference
 	ruleLabel
@@ -289,12 +561,18 @@ const (
 	ruleRefTitleDouble
 	ruleRefTitleParens
 	ruleReferences
+	ruleAbbreviation
+	ruleAbbreviationName
+	ruleAbbreviations
 	ruleTicks1
 	ruleTicks2
 	ruleTicks3
 	ruleTicks4
 	ruleTicks5
 	ruleCode
+	ruleMath
+	ruleDisplayMath
+	ruleInlineMath
 	ruleRawHtml
 	ruleBlankLine
 	ruleQuoted
@@ -312,6 +590,9 @@ const (
 	ruleNonAlphanumeric
 	ruleAlphanumeric
 	ruleAlphanumericAscii
+	ruleWideSpace
+	ruleAfterPunct
+	ruleClosingPunct
 	ruleDigit
 	ruleHexEntity
 	ruleDecEntity
@@ -326,6 +607,7 @@ const (
 	ruleSkipBlock
 	ruleExtendedSpecialChar
 	ruleSmart
+	ruleArrow
 	ruleApostrophe
 	ruleEllipsis
 	ruleDash
@@ -341,6 +623,7 @@ const (
 	ruleRawNoteReference
 	ruleNote
 	ruleInlineNote
+	ruleNoteInline
 	ruleNotes
 	ruleRawNoteBlock
 	ruleDefinitionList
@@ -350,12 +633,21 @@ const (
 	ruleDefLoose
 	ruleDefmark
 	ruleDefMarker
+	ruleTable
+	ruleTableLine
+	ruleTableRow
+	ruleTableCell
+	ruleTableAlignRow
+	ruleTableAlignCell
+	ruleExtBlock
+	ruleExtInline
+	ruleExtSpecialChar
 )
 
 type yyParser struct {*Doc
 	Buffer string
 	Min, Max int
-	rules [249]func() bool
+	rules [332]func() bool
 	ResetBuffer	func(string) string
 }
 
@@ -395,106 +687,229 @@ func (p *yyParser) PrintError() {
 func (p *yyParser) Init() {
 	var position int
 	var yyp int
-	var yy *element
-	var yyval = make([]*element, 200)
+	var yy *Element
+	var yyval = make([]*Element, 200)
 
 	actions := [...]func(string, int){
-		/* 0 Doc */
+		/* 0 DocBlock */
+		func(yytext string, _ int) {
+			 p.tree = yy 
+		},
+		/* 1 InlineDoc */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 1 Doc */
+		/* 2 InlineDoc */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 p.tree = reverse(a) 
 			yyval[yyp-1] = a
 		},
-		/* 2 Para */
+		/* 3 PluginBlock */
+		func(yytext string, _ int) {
+			 yy = p.pluginElement(yytext) 
+		},
+		/* 4 LatePluginBlock */
+		func(yytext string, _ int) {
+			 yy = p.pluginElement(yytext) 
+		},
+		/* 5 PluginInline */
+		func(yytext string, _ int) {
+			 yy = p.pluginElement(yytext) 
+		},
+		/* 6 LatePluginInline */
+		func(yytext string, _ int) {
+			 yy = p.pluginElement(yytext) 
+		},
+		/* 7 Template */
+		func(yytext string, _ int) {
+			 yy = p.templateSpan(yytext) 
+		},
+		/* 8 Para */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 yy = a; yy.key = PARA 
 			yyval[yyp-1] = a
 		},
-		/* 3 Plain */
+		/* 9 Plain */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 yy = a; yy.key = PLAIN 
 			yyval[yyp-1] = a
 		},
-		/* 4 AtxStart */
+		/* 10 AtxStart */
 		func(yytext string, _ int) {
 			 yy = mk_element(H1 + (len(yytext) - 1)) 
 		},
-		/* 5 AtxHeading */
+		/* 11 AtxHeading */
 		func(yytext string, _ int) {
 			s := yyval[yyp-1]
 			a := yyval[yyp-2]
+			t := yyval[yyp-3]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = s
 			yyval[yyp-2] = a
+			yyval[yyp-3] = t
 		},
-		/* 6 AtxHeading */
+		/* 12 AtxHeading */
 		func(yytext string, _ int) {
 			s := yyval[yyp-1]
 			a := yyval[yyp-2]
+			t := yyval[yyp-3]
 			 yy = mk_list(s.key, a)
-              s = nil 
+              p.setAttributes(yy, t)
+              s = nil
+              t = nil 
 			yyval[yyp-1] = s
 			yyval[yyp-2] = a
+			yyval[yyp-3] = t
 		},
-		/* 7 SetextHeading1 */
+		/* 13 SetextHeading1 */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
+			t := yyval[yyp-2]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
+			yyval[yyp-2] = t
 		},
-		/* 8 SetextHeading1 */
+		/* 14 SetextHeading1 */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
-			 yy = mk_list(H1, a) 
+			t := yyval[yyp-2]
+			 yy = mk_list(H1, a); p.setAttributes(yy, t); t = nil 
 			yyval[yyp-1] = a
+			yyval[yyp-2] = t
 		},
-		/* 9 SetextHeading2 */
+		/* 15 SetextHeading2 */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
+			t := yyval[yyp-2]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
+			yyval[yyp-2] = t
+		},
+		/* 16 SetextHeading2 */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			t := yyval[yyp-2]
+			 yy = mk_list(H2, a); p.setAttributes(yy, t); t = nil 
+			yyval[yyp-1] = a
+			yyval[yyp-2] = t
 		},
-		/* 10 SetextHeading2 */
+		/* 17 HeadingAttributes */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
-			 yy = mk_list(H2, a) 
+			 yy = a 
 			yyval[yyp-1] = a
 		},
-		/* 11 BlockQuote */
+		/* 18 AttributeBlock */
+		func(yytext string, _ int) {
+			 yy = mk_str(yytext) 
+		},
+		/* 19 Nothing */
+		func(yytext string, _ int) {
+			 yy = nil 
+		},
+		/* 20 BlockQuote */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			  yy = mk_element(BLOCKQUOTE)
                 yy.children = a
+                if p.extension.StrictLists {
+                    a.contents.str = strictIndent(a.contents.str, p.extension.ListStart)
+                }
              
 			yyval[yyp-1] = a
 		},
-		/* 12 BlockQuoteRaw */
+		/* 21 AdmonitionBlock */
+		func(yytext string, _ int) {
+			k := yyval[yyp-1]
+			t := yyval[yyp-2]
+			a := yyval[yyp-3]
+			 a = cons(yy, a) 
+			yyval[yyp-1] = k
+			yyval[yyp-2] = t
+			yyval[yyp-3] = a
+		},
+		/* 22 AdmonitionBlock */
+		func(yytext string, _ int) {
+			k := yyval[yyp-1]
+			t := yyval[yyp-2]
+			a := yyval[yyp-3]
+			  yy = p.admonition(k, t, mk_str_from_list(a, true)) 
+			yyval[yyp-1] = k
+			yyval[yyp-2] = t
+			yyval[yyp-3] = a
+		},
+		/* 23 AdmonitionKind */
+		func(yytext string, _ int) {
+			 yy = mk_str(yytext) 
+		},
+		/* 24 AdmonitionTitle */
+		func(yytext string, _ int) {
+			 yy = mk_str(yytext) 
+		},
+		/* 25 AdmonitionChunk */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			 a = cons(mk_str("\n"), a) 
+			yyval[yyp-1] = a
+		},
+		/* 26 AdmonitionChunk */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			 a = cons(yy, a) 
+			yyval[yyp-1] = a
+		},
+		/* 27 AdmonitionChunk */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			 yy = mk_str_from_list(a, false) 
+			yyval[yyp-1] = a
+		},
+		/* 28 Alert */
+		func(yytext string, _ int) {
+			k := yyval[yyp-1]
+			a := yyval[yyp-2]
+			  yy = p.admonition(k, nil, a) 
+			yyval[yyp-1] = k
+			yyval[yyp-2] = a
+		},
+		/* 29 AlertKind */
+		func(yytext string, _ int) {
+			 yy = mk_str(yytext) 
+		},
+		/* 30 FencedDiv */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			 yy = p.fencedDiv(a, yytext) 
+			yyval[yyp-1] = a
+		},
+		/* 31 DivStart */
+		func(yytext string, _ int) {
+			 yy = mk_str(yytext) 
+		},
+		/* 32 BlockQuoteRaw */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 13 BlockQuoteRaw */
+		/* 33 BlockQuoteRaw */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 14 BlockQuoteRaw */
+		/* 34 BlockQuoteRaw */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(mk_str("\n"), a) 
 			yyval[yyp-1] = a
 		},
-		/* 15 BlockQuoteRaw */
+		/* 35 BlockQuoteRaw */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			   yy = mk_str_from_list(a, true)
@@ -502,142 +917,330 @@ func (p *yyParser) Init() {
                  
 			yyval[yyp-1] = a
 		},
-		/* 16 VerbatimChunk */
+		/* 36 VerbatimChunk */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(mk_str("\n"), a) 
 			yyval[yyp-1] = a
 		},
-		/* 17 VerbatimChunk */
+		/* 37 VerbatimChunk */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 18 VerbatimChunk */
+		/* 38 VerbatimChunk */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 yy = mk_str_from_list(a, false) 
 			yyval[yyp-1] = a
 		},
-		/* 19 Verbatim */
+		/* 39 Verbatim */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 20 Verbatim */
+		/* 40 Verbatim */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 yy = mk_str_from_list(a, false)
                  yy.key = VERBATIM 
 			yyval[yyp-1] = a
 		},
-		/* 21 HorizontalRule */
+		/* 41 TocMarker */
+		func(yytext string, _ int) {
+			 yy = mk_element(TOC) 
+		},
+		/* 42 TicksInfo */
+		func(yytext string, _ int) {
+			 yy = mk_str(yytext) 
+		},
+		/* 43 TildesInfo */
+		func(yytext string, _ int) {
+			 yy = mk_str(yytext) 
+		},
+		/* 44 FencedCodeTicks3 */
+		func(yytext string, _ int) {
+			i := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 a = cons(yy, a) 
+			yyval[yyp-1] = i
+			yyval[yyp-2] = a
+		},
+		/* 45 FencedCodeTicks3 */
+		func(yytext string, _ int) {
+			i := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 yy = p.fenced(i, a) 
+			yyval[yyp-1] = i
+			yyval[yyp-2] = a
+		},
+		/* 46 FencedCodeTicks3 */
+		func(yytext string, _ int) {
+			i := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 yy = p.fenced(i, a); p.warn(yy, "unterminated fenced code block") 
+			yyval[yyp-1] = i
+			yyval[yyp-2] = a
+		},
+		/* 47 FencedCodeTicks4 */
+		func(yytext string, _ int) {
+			i := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 a = cons(yy, a) 
+			yyval[yyp-1] = i
+			yyval[yyp-2] = a
+		},
+		/* 48 FencedCodeTicks4 */
+		func(yytext string, _ int) {
+			i := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 yy = p.fenced(i, a) 
+			yyval[yyp-1] = i
+			yyval[yyp-2] = a
+		},
+		/* 49 FencedCodeTicks4 */
+		func(yytext string, _ int) {
+			i := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 yy = p.fenced(i, a); p.warn(yy, "unterminated fenced code block") 
+			yyval[yyp-1] = i
+			yyval[yyp-2] = a
+		},
+		/* 50 FencedCodeTicks5 */
+		func(yytext string, _ int) {
+			i := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 a = cons(yy, a) 
+			yyval[yyp-1] = i
+			yyval[yyp-2] = a
+		},
+		/* 51 FencedCodeTicks5 */
+		func(yytext string, _ int) {
+			i := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 yy = p.fenced(i, a) 
+			yyval[yyp-1] = i
+			yyval[yyp-2] = a
+		},
+		/* 52 FencedCodeTicks5 */
+		func(yytext string, _ int) {
+			i := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 yy = p.fenced(i, a); p.warn(yy, "unterminated fenced code block") 
+			yyval[yyp-1] = i
+			yyval[yyp-2] = a
+		},
+		/* 53 FencedCodeTildes3 */
+		func(yytext string, _ int) {
+			i := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 a = cons(yy, a) 
+			yyval[yyp-1] = i
+			yyval[yyp-2] = a
+		},
+		/* 54 FencedCodeTildes3 */
+		func(yytext string, _ int) {
+			i := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 yy = p.fenced(i, a) 
+			yyval[yyp-1] = i
+			yyval[yyp-2] = a
+		},
+		/* 55 FencedCodeTildes3 */
+		func(yytext string, _ int) {
+			i := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 yy = p.fenced(i, a); p.warn(yy, "unterminated fenced code block") 
+			yyval[yyp-1] = i
+			yyval[yyp-2] = a
+		},
+		/* 56 FencedCodeTildes4 */
+		func(yytext string, _ int) {
+			i := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 a = cons(yy, a) 
+			yyval[yyp-1] = i
+			yyval[yyp-2] = a
+		},
+		/* 57 FencedCodeTildes4 */
+		func(yytext string, _ int) {
+			i := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 yy = p.fenced(i, a) 
+			yyval[yyp-1] = i
+			yyval[yyp-2] = a
+		},
+		/* 58 FencedCodeTildes4 */
+		func(yytext string, _ int) {
+			i := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 yy = p.fenced(i, a); p.warn(yy, "unterminated fenced code block") 
+			yyval[yyp-1] = i
+			yyval[yyp-2] = a
+		},
+		/* 59 FencedCodeTildes5 */
+		func(yytext string, _ int) {
+			i := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 a = cons(yy, a) 
+			yyval[yyp-1] = i
+			yyval[yyp-2] = a
+		},
+		/* 60 FencedCodeTildes5 */
+		func(yytext string, _ int) {
+			i := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 yy = p.fenced(i, a) 
+			yyval[yyp-1] = i
+			yyval[yyp-2] = a
+		},
+		/* 61 FencedCodeTildes5 */
+		func(yytext string, _ int) {
+			i := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 yy = p.fenced(i, a); p.warn(yy, "unterminated fenced code block") 
+			yyval[yyp-1] = i
+			yyval[yyp-2] = a
+		},
+		/* 62 HorizontalRule */
 		func(yytext string, _ int) {
 			 yy = mk_element(HRULE) 
 		},
-		/* 22 BulletList */
+		/* 63 BulletList */
 		func(yytext string, _ int) {
-			 yy.key = BULLETLIST 
+			 yy.key = BULLETLIST
+               if p.extension.StrictLists {
+                   looseList(yy)
+               } 
 		},
-		/* 23 ListTight */
+		/* 64 ListTight */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 24 ListTight */
+		/* 65 ListTight */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 yy = mk_list(LIST, a) 
 			yyval[yyp-1] = a
 		},
-		/* 25 ListLoose */
+		/* 66 ListLoose */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			b := yyval[yyp-2]
 			
                   li := b.children
-                  li.contents.str += "\n\n"
+                  if p.extension.StrictLists {
+                      li.contents.str += yytext
+                  } else {
+                      li.contents.str += "\n\n"
+                  }
                   a = cons(b, a)
               
 			yyval[yyp-1] = a
 			yyval[yyp-2] = b
 		},
-		/* 26 ListLoose */
+		/* 67 ListLoose */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			b := yyval[yyp-2]
-			 yy = mk_list(LIST, a) 
+			 yy = mk_list(LIST, a)
+              yy.loose = true 
 			yyval[yyp-1] = a
 			yyval[yyp-2] = b
 		},
-		/* 27 ListItem */
+		/* 68 ListItem */
 		func(yytext string, _ int) {
-			a := yyval[yyp-1]
+			t := yyval[yyp-1]
+			a := yyval[yyp-2]
 			 a = cons(yy, a) 
-			yyval[yyp-1] = a
+			yyval[yyp-1] = t
+			yyval[yyp-2] = a
 		},
-		/* 28 ListItem */
+		/* 69 ListItem */
 		func(yytext string, _ int) {
-			a := yyval[yyp-1]
+			t := yyval[yyp-1]
+			a := yyval[yyp-2]
 			 a = cons(yy, a) 
-			yyval[yyp-1] = a
+			yyval[yyp-1] = t
+			yyval[yyp-2] = a
 		},
-		/* 29 ListItem */
+		/* 70 ListItem */
 		func(yytext string, _ int) {
-			a := yyval[yyp-1]
+			t := yyval[yyp-1]
+			a := yyval[yyp-2]
 			
                raw := mk_str_from_list(a, false)
                raw.key = RAW
                yy = mk_element(LISTITEM)
                yy.children = raw
+               if t != nil {
+                   yy.contents.str = strings.ToLower(t.contents.str)
+               }
+               t = nil
             
-			yyval[yyp-1] = a
+			yyval[yyp-1] = t
+			yyval[yyp-2] = a
 		},
-		/* 30 ListItemTight */
+		/* 71 ListItemTight */
 		func(yytext string, _ int) {
-			a := yyval[yyp-1]
+			t := yyval[yyp-1]
+			a := yyval[yyp-2]
 			 a = cons(yy, a) 
-			yyval[yyp-1] = a
+			yyval[yyp-1] = t
+			yyval[yyp-2] = a
 		},
-		/* 31 ListItemTight */
+		/* 72 ListItemTight */
 		func(yytext string, _ int) {
-			a := yyval[yyp-1]
+			t := yyval[yyp-1]
+			a := yyval[yyp-2]
 			 a = cons(yy, a) 
-			yyval[yyp-1] = a
+			yyval[yyp-1] = t
+			yyval[yyp-2] = a
 		},
-		/* 32 ListItemTight */
+		/* 73 ListItemTight */
 		func(yytext string, _ int) {
-			a := yyval[yyp-1]
+			t := yyval[yyp-1]
+			a := yyval[yyp-2]
 			
                raw := mk_str_from_list(a, false)
                raw.key = RAW
                yy = mk_element(LISTITEM)
                yy.children = raw
+               if t != nil {
+                   yy.contents.str = strings.ToLower(t.contents.str)
+               }
+               t = nil
             
-			yyval[yyp-1] = a
+			yyval[yyp-1] = t
+			yyval[yyp-2] = a
 		},
-		/* 33 ListBlock */
+		/* 74 TaskMarker */
+		func(yytext string, _ int) {
+			 yy = mk_str(yytext) 
+		},
+		/* 75 ListBlock */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 34 ListBlock */
+		/* 76 ListBlock */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 35 ListBlock */
+		/* 77 ListBlock */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 yy = mk_str_from_list(a, false) 
 			yyval[yyp-1] = a
 		},
-		/* 36 ListContinuationBlock */
+		/* 78 ListContinuationBlock */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			   if len(yytext) == 0 {
@@ -648,43 +1251,44 @@ func (p *yyParser) Init() {
                           
 			yyval[yyp-1] = a
 		},
-		/* 37 ListContinuationBlock */
+		/* 79 ListContinuationBlock */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 38 ListContinuationBlock */
+		/* 80 ListContinuationBlock */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			  yy = mk_str_from_list(a, false) 
 			yyval[yyp-1] = a
 		},
-		/* 39 OrderedList */
+		/* 81 ListStart */
 		func(yytext string, _ int) {
-			 yy.key = ORDEREDLIST 
+			 yy = mk_str(yytext) 
 		},
-		/* 40 HtmlBlock */
+		/* 82 OrderedList */
 		func(yytext string, _ int) {
-			   if p.extension.FilterHTML {
-                    yy = mk_list(LIST, nil)
-                } else {
-                    yy = mk_str(yytext)
-                    yy.key = HTMLBLOCK
+			s := yyval[yyp-1]
+			 yy.key = ORDEREDLIST
+                if s != nil {
+                    yy.contents.str = s.contents.str
                 }
-            
+                s = nil
+                if p.extension.StrictLists {
+                    looseList(yy)
+                } 
+			yyval[yyp-1] = s
 		},
-		/* 41 StyleBlock */
+		/* 83 HtmlBlock */
 		func(yytext string, _ int) {
-			   if p.extension.FilterStyles {
-                        yy = mk_list(LIST, nil)
-                    } else {
-                        yy = mk_str(yytext)
-                        yy.key = HTMLBLOCK
-                    }
-                
+			 yy = p.rawHTML(yytext, true) 
+		},
+		/* 84 StyleBlock */
+		func(yytext string, _ int) {
+			 yy = p.styleBlock(yytext) 
 		},
-		/* 42 Inlines */
+		/* 85 Inlines */
 		func(yytext string, _ int) {
 			c := yyval[yyp-1]
 			a := yyval[yyp-2]
@@ -692,7 +1296,7 @@ func (p *yyParser) Init() {
 			yyval[yyp-1] = c
 			yyval[yyp-2] = a
 		},
-		/* 43 Inlines */
+		/* 86 Inlines */
 		func(yytext string, _ int) {
 			c := yyval[yyp-1]
 			a := yyval[yyp-2]
@@ -700,7 +1304,7 @@ func (p *yyParser) Init() {
 			yyval[yyp-1] = c
 			yyval[yyp-2] = a
 		},
-		/* 44 Inlines */
+		/* 87 Inlines */
 		func(yytext string, _ int) {
 			c := yyval[yyp-1]
 			a := yyval[yyp-2]
@@ -708,221 +1312,323 @@ func (p *yyParser) Init() {
 			yyval[yyp-1] = c
 			yyval[yyp-2] = a
 		},
-		/* 45 Space */
+		/* 88 Space */
 		func(yytext string, _ int) {
 			 yy = mk_str(" ")
           yy.key = SPACE 
 		},
-		/* 46 Str */
+		/* 89 Str */
 		func(yytext string, _ int) {
 			 yy = mk_str(yytext) 
 		},
-		/* 47 EscapedChar */
+		/* 90 EscapedChar */
 		func(yytext string, _ int) {
 			 yy = mk_str(yytext) 
 		},
-		/* 48 Entity */
+		/* 91 Entity */
 		func(yytext string, _ int) {
 			 yy = mk_str(yytext); yy.key = HTML 
 		},
-		/* 49 NormalEndline */
+		/* 92 NormalEndline */
 		func(yytext string, _ int) {
 			 yy = mk_str("\n")
                     yy.key = SPACE 
 		},
-		/* 50 TerminalEndline */
+		/* 93 TerminalEndline */
 		func(yytext string, _ int) {
 			 yy = nil 
 		},
-		/* 51 LineBreak */
+		/* 94 LineBreak */
 		func(yytext string, _ int) {
 			 yy = mk_element(LINEBREAK) 
 		},
-		/* 52 Symbol */
+		/* 95 Emoji */
+		func(yytext string, _ int) {
+			 yy = mk_str(yytext); yy.key = EMOJI 
+		},
+		/* 96 Symbol */
 		func(yytext string, _ int) {
 			 yy = mk_str(yytext) 
 		},
-		/* 53 UlOrStarLine */
+		/* 97 UlOrStarLine */
 		func(yytext string, _ int) {
 			 yy = mk_str(yytext) 
 		},
-		/* 54 OneStarClose */
+		/* 98 OneStarClose */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 yy = a 
 			yyval[yyp-1] = a
 		},
-		/* 55 EmphStar */
+		/* 99 EmphStar */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 56 EmphStar */
+		/* 100 EmphStar */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 57 EmphStar */
+		/* 101 EmphStar */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 yy = mk_list(EMPH, a) 
 			yyval[yyp-1] = a
 		},
-		/* 58 OneUlClose */
+		/* 102 OneUlClose */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 yy = a 
 			yyval[yyp-1] = a
 		},
-		/* 59 EmphUl */
+		/* 103 EmphUl */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 60 EmphUl */
+		/* 104 EmphUl */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 61 EmphUl */
+		/* 105 EmphUl */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 yy = mk_list(EMPH, a) 
 			yyval[yyp-1] = a
 		},
-		/* 62 TwoStarClose */
+		/* 106 TwoStarClose */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 yy = a 
 			yyval[yyp-1] = a
 		},
-		/* 63 StrongStar */
+		/* 107 StrongStar */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 64 StrongStar */
+		/* 108 StrongStar */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 65 StrongStar */
+		/* 109 StrongStar */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 yy = mk_list(STRONG, a) 
 			yyval[yyp-1] = a
 		},
-		/* 66 TwoUlClose */
+		/* 110 TwoUlClose */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 yy = a 
 			yyval[yyp-1] = a
 		},
-		/* 67 StrongUl */
+		/* 111 StrongUl */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 68 StrongUl */
+		/* 112 StrongUl */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 69 StrongUl */
+		/* 113 StrongUl */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 yy = mk_list(STRONG, a) 
 			yyval[yyp-1] = a
 		},
-		/* 70 Image */
+		/* 114 Strike */
 		func(yytext string, _ int) {
-				if yy.key == LINK {
-			yy.key = IMAGE
-		} else {
-			result := yy
-			yy.children = cons(mk_str("!"), result.children)
-		}
-	
+			a := yyval[yyp-1]
+			 a = cons(yy, a) 
+			yyval[yyp-1] = a
 		},
-		/* 71 ReferenceLinkDouble */
+		/* 115 Strike */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
-			b := yyval[yyp-2]
-			
-                           if match, found := p.findReference(b.children); found {
-                               yy = mk_link(a.children, match.url, match.title);
-                               a = nil
-                               b = nil
-                           } else {
-                               result := mk_element(LIST)
-                               result.children = cons(mk_str("["), cons(a, cons(mk_str("]"), cons(mk_str(yytext),
-                                                   cons(mk_str("["), cons(b, mk_str("]")))))))
-                               yy = result
-                           }
-                       
+			 yy = mk_list(STRIKE, a) 
 			yyval[yyp-1] = a
-			yyval[yyp-2] = b
 		},
-		/* 72 ReferenceLinkSingle */
+		/* 116 Superscript */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
-			
-                           if match, found := p.findReference(a.children); found {
-                               yy = mk_link(a.children, match.url, match.title)
-                               a = nil
-                           } else {
-                               result := mk_element(LIST)
-                               result.children = cons(mk_str("["), cons(a, cons(mk_str("]"), mk_str(yytext))));
-                               yy = result
-                           }
-                       
+			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 73 ExplicitLink */
+		/* 117 Superscript */
 		func(yytext string, _ int) {
-			s := yyval[yyp-1]
-			l := yyval[yyp-2]
-			t := yyval[yyp-3]
-			 yy = mk_link(l.children, s.contents.str, t.contents.str)
+			a := yyval[yyp-1]
+			 yy = mk_list(SUPERSCRIPT, a) 
+			yyval[yyp-1] = a
+		},
+		/* 118 Subscript */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			 a = cons(yy, a) 
+			yyval[yyp-1] = a
+		},
+		/* 119 Subscript */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			 yy = mk_list(SUBSCRIPT, a) 
+			yyval[yyp-1] = a
+		},
+		/* 120 Image */
+		func(yytext string, _ int) {
+				if yy.key == LINK {
+			yy.key = IMAGE
+		} else {
+			result := yy
+			yy.children = cons(mk_str("!"), result.children)
+		}
+	
+		},
+		/* 121 WikiLink */
+		func(yytext string, _ int) {
+			t := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 a = cons(yy, a) 
+			yyval[yyp-1] = t
+			yyval[yyp-2] = a
+		},
+		/* 122 WikiLink */
+		func(yytext string, _ int) {
+			t := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 a = mk_list(LIST, a).children 
+			yyval[yyp-1] = t
+			yyval[yyp-2] = a
+		},
+		/* 123 WikiLink */
+		func(yytext string, _ int) {
+			t := yyval[yyp-1]
+			a := yyval[yyp-2]
+			 yy = p.wikiLink(t.contents.str, a)
+              t = nil
+              a = nil 
+			yyval[yyp-1] = t
+			yyval[yyp-2] = a
+		},
+		/* 124 WikiTarget */
+		func(yytext string, _ int) {
+			 yy = mk_str(strings.TrimSpace(yytext)) 
+		},
+		/* 125 BracketCitation */
+		func(yytext string, _ int) {
+			 yy = p.citation(yytext, false) 
+		},
+		/* 126 TextCitation */
+		func(yytext string, _ int) {
+			 yy = p.citation(yytext, true) 
+		},
+		/* 127 ReferenceLinkDouble */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			b := yyval[yyp-2]
+			
+                           if match, found := p.findReference(b.children); found {
+                               yy = mk_link(a.children, match.url, match.title);
+                               yy.contents.link.ref = match
+                               a = nil
+                               b = nil
+                           } else {
+                               result := mk_element(LIST)
+                               p.problem(result, UndefinedReference, "undefined reference [" + plainText(b.children) + "]")
+                               result.children = cons(mk_str("["), cons(a, cons(mk_str("]"), cons(mk_str(yytext),
+                                                   cons(mk_str("["), cons(b, mk_str("]")))))))
+                               yy = result
+                           }
+                       
+			yyval[yyp-1] = a
+			yyval[yyp-2] = b
+		},
+		/* 128 ReferenceLinkSingle */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			
+                           if match, found := p.findReference(a.children); found {
+                               yy = mk_link(a.children, match.url, match.title)
+                               yy.contents.link.ref = match
+                               a = nil
+                           } else {
+                               result := mk_element(LIST)
+                               if strings.HasSuffix(yytext, "[]") {
+                                   p.problem(result, UndefinedReference, "undefined reference [" + plainText(a.children) + "]")
+                               }
+                               result.children = cons(mk_str("["), cons(a, cons(mk_str("]"), mk_str(yytext))));
+                               yy = result
+                           }
+                       
+			yyval[yyp-1] = a
+		},
+		/* 129 ExplicitLink */
+		func(yytext string, _ int) {
+			l := yyval[yyp-1]
+			s := yyval[yyp-2]
+			t := yyval[yyp-3]
+			a := yyval[yyp-4]
+			 yy = mk_link(l.children, p.safeURL(s.contents.str), t.contents.str)
+                  p.checkURL(yy, s.contents.str)
+                  p.setAttributes(yy, a)
                   s = nil
                   t = nil
-                  l = nil 
-			yyval[yyp-1] = s
-			yyval[yyp-2] = l
+                  l = nil
+                  a = nil 
+			yyval[yyp-1] = l
+			yyval[yyp-2] = s
 			yyval[yyp-3] = t
+			yyval[yyp-4] = a
 		},
-		/* 74 Source */
+		/* 130 Source */
 		func(yytext string, _ int) {
 			 yy = mk_str(yytext) 
 		},
-		/* 75 Title */
+		/* 131 Title */
 		func(yytext string, _ int) {
 			 yy = mk_str(yytext) 
 		},
-		/* 76 AutoLinkUrl */
+		/* 132 AutoLinkUrl */
+		func(yytext string, _ int) {
+			   yy = mk_link(mk_str(yytext), p.safeURL(yytext), "") 
+		},
+		/* 133 BareUrl */
+		func(yytext string, _ int) {
+			   yy = mk_link(mk_str(yytext), p.safeURL(yytext), "") 
+		},
+		/* 134 BareWww */
+		func(yytext string, _ int) {
+			   yy = mk_link(mk_str(yytext), "http://"+yytext, "") 
+		},
+		/* 135 BareEmail */
 		func(yytext string, _ int) {
-			   yy = mk_link(mk_str(yytext), yytext, "") 
+			   yy = mk_link(mk_str(yytext), "mailto:"+yytext, "") 
 		},
-		/* 77 AutoLinkEmail */
+		/* 136 AutoLinkEmail */
 		func(yytext string, _ int) {
 			
                     yy = mk_link(mk_str(yytext), "mailto:"+yytext, "")
                 
 		},
-		/* 78 Reference */
+		/* 137 Reference */
 		func(yytext string, _ int) {
 			s := yyval[yyp-1]
 			l := yyval[yyp-2]
 			t := yyval[yyp-3]
-			 yy = mk_link(l.children, s.contents.str, t.contents.str)
+			 yy = mk_link(l.children, p.safeURL(s.contents.str), t.contents.str)
+              p.checkURL(yy, s.contents.str)
               s = nil
               t = nil
               l = nil
@@ -931,28 +1637,28 @@ func (p *yyParser) Init() {
 			yyval[yyp-2] = l
 			yyval[yyp-3] = t
 		},
-		/* 79 Label */
+		/* 138 Label */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 80 Label */
+		/* 139 Label */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 yy = mk_list(LIST, a) 
 			yyval[yyp-1] = a
 		},
-		/* 81 RefSrc */
+		/* 140 RefSrc */
 		func(yytext string, _ int) {
 			 yy = mk_str(yytext)
            yy.key = HTML 
 		},
-		/* 82 RefTitle */
+		/* 141 RefTitle */
 		func(yytext string, _ int) {
 			 yy = mk_str(yytext) 
 		},
-		/* 83 References */
+		/* 142 References */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			b := yyval[yyp-2]
@@ -960,7 +1666,7 @@ func (p *yyParser) Init() {
 			yyval[yyp-1] = a
 			yyval[yyp-2] = b
 		},
-		/* 84 References */
+		/* 143 References */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			b := yyval[yyp-2]
@@ -968,45 +1674,80 @@ func (p *yyParser) Init() {
 			yyval[yyp-1] = a
 			yyval[yyp-2] = b
 		},
-		/* 85 Code */
+		/* 144 Abbreviation */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			 yy = mk_element(ABBREVIATION)
+                 yy.contents.str = strings.TrimSpace(yytext)
+                 yy.children = a
+                 a = nil 
+			yyval[yyp-1] = a
+		},
+		/* 145 AbbreviationName */
+		func(yytext string, _ int) {
+			 yy = mk_str(yytext) 
+		},
+		/* 146 Abbreviations */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			b := yyval[yyp-2]
+			 a = cons(b, a) 
+			yyval[yyp-1] = a
+			yyval[yyp-2] = b
+		},
+		/* 147 Abbreviations */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			b := yyval[yyp-2]
+			 p.abbreviations = reverse(a) 
+			yyval[yyp-1] = a
+			yyval[yyp-2] = b
+		},
+		/* 148 Code */
 		func(yytext string, _ int) {
 			 yy = mk_str(yytext); yy.key = CODE 
 		},
-		/* 86 RawHtml */
+		/* 149 DisplayMath */
 		func(yytext string, _ int) {
-			   if p.extension.FilterHTML {
-                    yy = mk_list(LIST, nil)
-                } else {
-                    yy = mk_str(yytext)
-                    yy.key = HTML
-                }
-            
+			 yy = mk_str(yytext); yy.key = DISPLAYMATH 
+		},
+		/* 150 InlineMath */
+		func(yytext string, _ int) {
+			 yy = mk_str(yytext); yy.key = MATH 
+		},
+		/* 151 RawHtml */
+		func(yytext string, _ int) {
+			 yy = p.rawHTML(yytext, false) 
 		},
-		/* 87 StartList */
+		/* 152 StartList */
 		func(yytext string, _ int) {
 			 yy = nil 
 		},
-		/* 88 Line */
+		/* 153 Line */
 		func(yytext string, _ int) {
 			 yy = mk_str(yytext) 
 		},
-		/* 89 Apostrophe */
+		/* 154 Arrow */
+		func(yytext string, _ int) {
+			 yy = mk_str(arrows[yytext]) 
+		},
+		/* 155 Apostrophe */
 		func(yytext string, _ int) {
 			 yy = mk_element(APOSTROPHE) 
 		},
-		/* 90 Ellipsis */
+		/* 156 Ellipsis */
 		func(yytext string, _ int) {
 			 yy = mk_element(ELLIPSIS) 
 		},
-		/* 91 EnDash */
+		/* 157 EnDash */
 		func(yytext string, _ int) {
 			 yy = mk_element(ENDASH) 
 		},
-		/* 92 EmDash */
+		/* 158 EmDash */
 		func(yytext string, _ int) {
 			 yy = mk_element(EMDASH) 
 		},
-		/* 93 SingleQuoted */
+		/* 159 SingleQuoted */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			b := yyval[yyp-2]
@@ -1014,7 +1755,7 @@ func (p *yyParser) Init() {
 			yyval[yyp-1] = a
 			yyval[yyp-2] = b
 		},
-		/* 94 SingleQuoted */
+		/* 160 SingleQuoted */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			b := yyval[yyp-2]
@@ -1022,7 +1763,7 @@ func (p *yyParser) Init() {
 			yyval[yyp-1] = a
 			yyval[yyp-2] = b
 		},
-		/* 95 DoubleQuoted */
+		/* 161 DoubleQuoted */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			b := yyval[yyp-2]
@@ -1030,7 +1771,7 @@ func (p *yyParser) Init() {
 			yyval[yyp-1] = a
 			yyval[yyp-2] = b
 		},
-		/* 96 DoubleQuoted */
+		/* 162 DoubleQuoted */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			b := yyval[yyp-2]
@@ -1038,25 +1779,29 @@ func (p *yyParser) Init() {
 			yyval[yyp-1] = a
 			yyval[yyp-2] = b
 		},
-		/* 97 NoteReference */
+		/* 163 NoteReference */
 		func(yytext string, _ int) {
 			ref := yyval[yyp-1]
 			
-                    if match, ok := p.find_note(ref.contents.str); ok {
+                    if match, ok := p.find_note(ref.contents.str); ok && p.expanding[match.children] {
+                        yy = mk_str("[^"+ref.contents.str+"]")
+                        p.warn(yy, "note [^"+ref.contents.str+"] refers to itself")
+                    } else if ok {
                         yy = mk_element(NOTE)
                         yy.children = match.children
                         yy.contents.str = ""
                     } else {
                         yy = mk_str("[^"+ref.contents.str+"]")
+                        p.problem(yy, UndefinedNote, "undefined note [^"+ref.contents.str+"]")
                     }
                 
 			yyval[yyp-1] = ref
 		},
-		/* 98 RawNoteReference */
+		/* 164 RawNoteReference */
 		func(yytext string, _ int) {
 			 yy = mk_str(yytext) 
 		},
-		/* 99 Note */
+		/* 165 Note */
 		func(yytext string, _ int) {
 			ref := yyval[yyp-1]
 			a := yyval[yyp-2]
@@ -1064,7 +1809,7 @@ func (p *yyParser) Init() {
 			yyval[yyp-1] = ref
 			yyval[yyp-2] = a
 		},
-		/* 100 Note */
+		/* 166 Note */
 		func(yytext string, _ int) {
 			ref := yyval[yyp-1]
 			a := yyval[yyp-2]
@@ -1072,7 +1817,7 @@ func (p *yyParser) Init() {
 			yyval[yyp-1] = ref
 			yyval[yyp-2] = a
 		},
-		/* 101 Note */
+		/* 167 Note */
 		func(yytext string, _ int) {
 			ref := yyval[yyp-1]
 			a := yyval[yyp-2]
@@ -1082,20 +1827,39 @@ func (p *yyParser) Init() {
 			yyval[yyp-1] = ref
 			yyval[yyp-2] = a
 		},
-		/* 102 InlineNote */
+		/* 168 InlineNote */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 103 InlineNote */
+		/* 169 InlineNote */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
-			 yy = mk_list(NOTE, a)
+			 yy = mk_element(NOTE)
+                  yy.children = mk_list(PARA, a)
                   yy.contents.str = "" 
 			yyval[yyp-1] = a
 		},
-		/* 104 Notes */
+		/* 170 NoteInline */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			 a = cons(mk_str("["), a) 
+			yyval[yyp-1] = a
+		},
+		/* 171 NoteInline */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			 a = cons(yy, a) 
+			yyval[yyp-1] = a
+		},
+		/* 172 NoteInline */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			 yy = mk_list(LIST, cons(mk_str("]"), a)) 
+			yyval[yyp-1] = a
+		},
+		/* 173 Notes */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			b := yyval[yyp-2]
@@ -1103,7 +1867,7 @@ func (p *yyParser) Init() {
 			yyval[yyp-1] = a
 			yyval[yyp-2] = b
 		},
-		/* 105 Notes */
+		/* 174 Notes */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			b := yyval[yyp-2]
@@ -1111,19 +1875,19 @@ func (p *yyParser) Init() {
 			yyval[yyp-1] = a
 			yyval[yyp-2] = b
 		},
-		/* 106 RawNoteBlock */
+		/* 175 RawNoteBlock */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 107 RawNoteBlock */
+		/* 176 RawNoteBlock */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(mk_str(yytext), a) 
 			yyval[yyp-1] = a
 		},
-		/* 108 RawNoteBlock */
+		/* 177 RawNoteBlock */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			   yy = mk_str_from_list(a, true)
@@ -1131,48 +1895,56 @@ func (p *yyParser) Init() {
                 
 			yyval[yyp-1] = a
 		},
-		/* 109 DefinitionList */
+		/* 178 DefinitionList */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
-			 a = cons(yy, a) 
+			
+				for e := yy.children; e != nil; {
+					next := e.next
+					a = cons(e, a)
+					e = next
+				}
+			
 			yyval[yyp-1] = a
 		},
-		/* 110 DefinitionList */
+		/* 179 DefinitionList */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 yy = mk_list(DEFINITIONLIST, a) 
 			yyval[yyp-1] = a
 		},
-		/* 111 Definition */
+		/* 180 Definition */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 112 Definition */
+		/* 181 Definition */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			
-				for e := yy.children; e != nil; e = e.next {
+				for e := yy.children; e != nil; {
+					next := e.next
 					e.key = DEFDATA
+					a = cons(e, a)
+					e = next
 				}
-				a = cons(yy, a)
 			
 			yyval[yyp-1] = a
 		},
-		/* 113 Definition */
+		/* 182 Definition */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 yy = mk_list(LIST, a) 
 			yyval[yyp-1] = a
 		},
-		/* 114 DListTitle */
+		/* 183 DListTitle */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 			 a = cons(yy, a) 
 			yyval[yyp-1] = a
 		},
-		/* 115 DListTitle */
+		/* 184 DListTitle */
 		func(yytext string, _ int) {
 			a := yyval[yyp-1]
 				yy = mk_list(LIST, a)
@@ -1180,26 +1952,104 @@ func (p *yyParser) Init() {
 			
 			yyval[yyp-1] = a
 		},
-		/* 116 yyPush */
+		/* 185 DefLoose */
+		func(yytext string, _ int) {
+			 if p.extension.StrictLists {
+				  endItems(yy, true)
+			  } 
+		},
+		/* 186 Table */
+		func(yytext string, _ int) {
+			h := yyval[yyp-1]
+			l := yyval[yyp-2]
+			a := yyval[yyp-3]
+			 a = cons(yy, a) 
+			yyval[yyp-1] = h
+			yyval[yyp-2] = l
+			yyval[yyp-3] = a
+		},
+		/* 187 Table */
+		func(yytext string, _ int) {
+			h := yyval[yyp-1]
+			l := yyval[yyp-2]
+			a := yyval[yyp-3]
+			 yy = mk_table(h, l, a) 
+			yyval[yyp-1] = h
+			yyval[yyp-2] = l
+			yyval[yyp-3] = a
+		},
+		/* 188 TableRow */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			 a = cons(yy, a) 
+			yyval[yyp-1] = a
+		},
+		/* 189 TableRow */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			 a = cons(yy, a) 
+			yyval[yyp-1] = a
+		},
+		/* 190 TableRow */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			 yy = mk_list(TABLEROW, a) 
+			yyval[yyp-1] = a
+		},
+		/* 191 TableCell */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			 a = cons(yy, a) 
+			yyval[yyp-1] = a
+		},
+		/* 192 TableCell */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			 yy = mk_list(TABLECELL, a) 
+			yyval[yyp-1] = a
+		},
+		/* 193 TableAlignRow */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			 a = cons(yy, a) 
+			yyval[yyp-1] = a
+		},
+		/* 194 TableAlignRow */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			 a = cons(yy, a) 
+			yyval[yyp-1] = a
+		},
+		/* 195 TableAlignRow */
+		func(yytext string, _ int) {
+			a := yyval[yyp-1]
+			 yy = mk_list(LIST, a) 
+			yyval[yyp-1] = a
+		},
+		/* 196 TableAlignCell */
+		func(yytext string, _ int) {
+			 yy = mk_str(yytext) 
+		},
+		/* 197 yyPush */
 		func(_ string, count int) {
 			yyp += count
 			if yyp >= len(yyval) {
-				s := make([]*element, cap(yyval)+200)
+				s := make([]*Element, cap(yyval)+200)
 				copy(s, yyval)
 				yyval = s
 			}
 		},
-		/* 117 yyPop */
+		/* 198 yyPop */
 		func(_ string, count int) {
 			yyp -= count
 		},
-		/* 118 yySet */
+		/* 199 yySet */
 		func(_ string, count int) {
 			yyval[yyp+count] = yy
 		},
 	}
 	const (
-		yyPush = 116+iota
+		yyPush = 197+iota
 		yyPop
 		yySet
 	)
@@ -1300,6 +2150,15 @@ func (p *yyParser) Init() {
 		{0, 0, 0, 0, 0, 0, 255, 3, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
 		{0, 0, 0, 0, 0, 0, 255, 3, 254, 255, 255, 7, 254, 255, 255, 7, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
 		{0, 0, 0, 0, 0, 40, 255, 3, 254, 255, 255, 135, 254, 255, 255, 7, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 32, 255, 3, 254, 255, 255, 135, 254, 255, 255, 7, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 40, 255, 3, 0, 0, 0, 128, 254, 255, 255, 7, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 255, 3, 254, 255, 255, 135, 254, 255, 255, 7, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 120, 200, 0, 212, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 104, 255, 3, 254, 255, 255, 135, 254, 255, 255, 7, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 32, 255, 3, 254, 255, 255, 7, 254, 255, 255, 7, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 134, 82, 0, 140, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
 	}
 	matchClass := func(class uint) bool {
 		if (position < len(p.Buffer)) &&
@@ -1312,12245 +2171,15910 @@ func (p *yyParser) Init() {
 		return false
 	}
 	p.rules = [...]func() bool{
-		/* 0 Doc <- (StartList (Block { a = cons(yy, a) })* { p.tree = reverse(a) } commit) */
+		/* 0 DocBlock <- (&{ p.mark(position) } Block &{ p.mark(position) } { p.tree = yy } commit) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !( p.mark(position) ) {
+				goto l0
+			}
+			if !p.rules[ruleBlock]() {
+				goto l0
+			}
+			if !( p.mark(position) ) {
+				goto l0
+			}
+			do(0)
+			if !(commit(thunkPosition0)) {
+				goto l0
+			}
+			return true
+		l0:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 1 InlineDoc <- (StartList (Inline { a = cons(yy, a) })* { p.tree = reverse(a) } commit) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			doarg(yyPush, 1)
 			if !p.rules[ruleStartList]() {
-				goto l0
+				goto l1
 			}
 			doarg(yySet, -1)
-		l1:
+		l2:
 			{
-				position2, thunkPosition2 := position, thunkPosition
-				if !p.rules[ruleBlock]() {
-					goto l2
+				position3, thunkPosition3 := position, thunkPosition
+				if !p.rules[ruleInline]() {
+					goto l3
 				}
-				do(0)
-				goto l1
-			l2:
-				position, thunkPosition = position2, thunkPosition2
+				do(1)
+				goto l2
+			l3:
+				position, thunkPosition = position3, thunkPosition3
 			}
-			do(1)
+			do(2)
 			if !(commit(thunkPosition0)) {
-				goto l0
+				goto l1
 			}
 			doarg(yyPop, 1)
 			return true
-		l0:
+		l1:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 1 Block <- (BlankLine* (BlockQuote / Verbatim / Note / Reference / HorizontalRule / Heading / DefinitionList / OrderedList / BulletList / HtmlBlock / StyleBlock / Para / Plain)) */
+		/* 2 Block <- (BlankLine* (PluginBlock / ExtBlock / Admonition / FencedDiv / BlockQuote / Verbatim / FencedCode / Note / Abbreviation / Reference / HorizontalRule / Table / Heading / DefinitionList / OrderedList / BulletList / HtmlBlock / StyleBlock / TocMarker / LatePluginBlock / Para / Plain)) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-		l4:
+		l5:
 			{
-				position5, thunkPosition5 := position, thunkPosition
+				position6, thunkPosition6 := position, thunkPosition
 				if !p.rules[ruleBlankLine]() {
-					goto l5
+					goto l6
 				}
-				goto l4
-			l5:
-				position, thunkPosition = position5, thunkPosition5
+				goto l5
+			l6:
+				position, thunkPosition = position6, thunkPosition6
 			}
 			{
-				position6, thunkPosition6 := position, thunkPosition
-				if !p.rules[ruleBlockQuote]() {
-					goto l7
-				}
-				goto l6
-			l7:
-				position, thunkPosition = position6, thunkPosition6
-				if !p.rules[ruleVerbatim]() {
+				position7, thunkPosition7 := position, thunkPosition
+				if !p.rules[rulePluginBlock]() {
 					goto l8
 				}
-				goto l6
+				goto l7
 			l8:
-				position, thunkPosition = position6, thunkPosition6
-				if !p.rules[ruleNote]() {
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[ruleExtBlock]() {
 					goto l9
 				}
-				goto l6
+				goto l7
 			l9:
-				position, thunkPosition = position6, thunkPosition6
-				if !p.rules[ruleReference]() {
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[ruleAdmonition]() {
 					goto l10
 				}
-				goto l6
+				goto l7
 			l10:
-				position, thunkPosition = position6, thunkPosition6
-				if !p.rules[ruleHorizontalRule]() {
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[ruleFencedDiv]() {
 					goto l11
 				}
-				goto l6
+				goto l7
 			l11:
-				position, thunkPosition = position6, thunkPosition6
-				if !p.rules[ruleHeading]() {
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[ruleBlockQuote]() {
 					goto l12
 				}
-				goto l6
+				goto l7
 			l12:
-				position, thunkPosition = position6, thunkPosition6
-				if !p.rules[ruleDefinitionList]() {
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[ruleVerbatim]() {
 					goto l13
 				}
-				goto l6
+				goto l7
 			l13:
-				position, thunkPosition = position6, thunkPosition6
-				if !p.rules[ruleOrderedList]() {
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[ruleFencedCode]() {
 					goto l14
 				}
-				goto l6
+				goto l7
 			l14:
-				position, thunkPosition = position6, thunkPosition6
-				if !p.rules[ruleBulletList]() {
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[ruleNote]() {
 					goto l15
 				}
-				goto l6
+				goto l7
 			l15:
-				position, thunkPosition = position6, thunkPosition6
-				if !p.rules[ruleHtmlBlock]() {
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[ruleAbbreviation]() {
 					goto l16
 				}
-				goto l6
+				goto l7
 			l16:
-				position, thunkPosition = position6, thunkPosition6
-				if !p.rules[ruleStyleBlock]() {
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[ruleReference]() {
 					goto l17
 				}
-				goto l6
+				goto l7
 			l17:
-				position, thunkPosition = position6, thunkPosition6
-				if !p.rules[rulePara]() {
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[ruleHorizontalRule]() {
 					goto l18
 				}
-				goto l6
+				goto l7
 			l18:
-				position, thunkPosition = position6, thunkPosition6
-				if !p.rules[rulePlain]() {
-					goto l3
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[ruleTable]() {
+					goto l19
 				}
+				goto l7
+			l19:
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[ruleHeading]() {
+					goto l20
+				}
+				goto l7
+			l20:
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[ruleDefinitionList]() {
+					goto l21
+				}
+				goto l7
+			l21:
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[ruleOrderedList]() {
+					goto l22
+				}
+				goto l7
+			l22:
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[ruleBulletList]() {
+					goto l23
+				}
+				goto l7
+			l23:
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[ruleHtmlBlock]() {
+					goto l24
+				}
+				goto l7
+			l24:
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[ruleStyleBlock]() {
+					goto l25
+				}
+				goto l7
+			l25:
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[ruleTocMarker]() {
+					goto l26
+				}
+				goto l7
+			l26:
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[ruleLatePluginBlock]() {
+					goto l27
+				}
+				goto l7
+			l27:
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[rulePara]() {
+					goto l28
+				}
+				goto l7
+			l28:
+				position, thunkPosition = position7, thunkPosition7
+				if !p.rules[rulePlain]() {
+					goto l4
+				}
+			}
+		l7:
+			return true
+		l4:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 3 PluginBlock <- (< &{ p.plugin(&position, pluginBlock) } > { yy = p.pluginElement(yytext) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			begin = position
+			if !( p.plugin(&position, pluginBlock) ) {
+				goto l29
+			}
+			end = position
+			do(3)
+			return true
+		l29:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 4 LatePluginBlock <- (< &{ p.plugin(&position, pluginLateBlock) } > { yy = p.pluginElement(yytext) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			begin = position
+			if !( p.plugin(&position, pluginLateBlock) ) {
+				goto l30
+			}
+			end = position
+			do(4)
+			return true
+		l30:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 5 PluginInline <- (< &{ p.plugin(&position, pluginInline) } > { yy = p.pluginElement(yytext) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			begin = position
+			if !( p.plugin(&position, pluginInline) ) {
+				goto l31
+			}
+			end = position
+			do(5)
+			return true
+		l31:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 6 LatePluginInline <- (< &{ p.plugin(&position, pluginLateInline) } > { yy = p.pluginElement(yytext) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			begin = position
+			if !( p.plugin(&position, pluginLateInline) ) {
+				goto l32
+			}
+			end = position
+			do(6)
+			return true
+		l32:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 7 Template <- (&{ p.extension.Templates } < &{ p.template(&position) } > { yy = p.templateSpan(yytext) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !( p.extension.Templates ) {
+				goto l33
+			}
+			begin = position
+			if !( p.template(&position) ) {
+				goto l33
+			}
+			end = position
+			do(7)
+			return true
+		l33:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 8 Para <- (NonindentSpace Inlines BlankLine+ { yy = a; yy.key = PARA }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 1)
+			if !p.rules[ruleNonindentSpace]() {
+				goto l34
+			}
+			if !p.rules[ruleInlines]() {
+				goto l34
 			}
-		l6:
-			return true
-		l3:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 2 Para <- (NonindentSpace Inlines BlankLine+ { yy = a; yy.key = PARA }) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 1)
-			if !p.rules[ruleNonindentSpace]() {
-				goto l19
-			}
-			if !p.rules[ruleInlines]() {
-				goto l19
-			}
 			doarg(yySet, -1)
 			if !p.rules[ruleBlankLine]() {
-				goto l19
+				goto l34
 			}
-		l20:
+		l35:
 			{
-				position21, thunkPosition21 := position, thunkPosition
+				position36, thunkPosition36 := position, thunkPosition
 				if !p.rules[ruleBlankLine]() {
-					goto l21
+					goto l36
 				}
-				goto l20
-			l21:
-				position, thunkPosition = position21, thunkPosition21
+				goto l35
+			l36:
+				position, thunkPosition = position36, thunkPosition36
 			}
-			do(2)
+			do(8)
 			doarg(yyPop, 1)
 			return true
-		l19:
+		l34:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 3 Plain <- (Inlines { yy = a; yy.key = PLAIN }) */
+		/* 9 Plain <- (Inlines { yy = a; yy.key = PLAIN }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			doarg(yyPush, 1)
 			if !p.rules[ruleInlines]() {
-				goto l22
+				goto l37
 			}
 			doarg(yySet, -1)
-			do(3)
+			do(9)
 			doarg(yyPop, 1)
 			return true
-		l22:
+		l37:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 4 AtxInline <- (!Newline !(Sp? '#'* Sp Newline) Inline) */
+		/* 10 AtxInline <- (!Newline !(Sp? '#'* Sp Newline) !HeadingAttributes Inline) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			{
-				position24, thunkPosition24 := position, thunkPosition
+				position39, thunkPosition39 := position, thunkPosition
 				if !p.rules[ruleNewline]() {
-					goto l24
+					goto l39
 				}
-				goto l23
-			l24:
-				position, thunkPosition = position24, thunkPosition24
+				goto l38
+			l39:
+				position, thunkPosition = position39, thunkPosition39
 			}
 			{
-				position25, thunkPosition25 := position, thunkPosition
+				position40, thunkPosition40 := position, thunkPosition
 				{
-					position26, thunkPosition26 := position, thunkPosition
+					position41, thunkPosition41 := position, thunkPosition
 					if !p.rules[ruleSp]() {
-						goto l26
+						goto l41
 					}
-					goto l27
-				l26:
-					position, thunkPosition = position26, thunkPosition26
+					goto l42
+				l41:
+					position, thunkPosition = position41, thunkPosition41
 				}
-			l27:
-			l28:
+			l42:
+			l43:
 				{
-					position29, thunkPosition29 := position, thunkPosition
+					position44, thunkPosition44 := position, thunkPosition
 					if !matchChar('#') {
-						goto l29
+						goto l44
 					}
-					goto l28
-				l29:
-					position, thunkPosition = position29, thunkPosition29
+					goto l43
+				l44:
+					position, thunkPosition = position44, thunkPosition44
 				}
 				if !p.rules[ruleSp]() {
-					goto l25
+					goto l40
 				}
 				if !p.rules[ruleNewline]() {
-					goto l25
+					goto l40
 				}
-				goto l23
-			l25:
-				position, thunkPosition = position25, thunkPosition25
+				goto l38
+			l40:
+				position, thunkPosition = position40, thunkPosition40
+			}
+			{
+				position45, thunkPosition45 := position, thunkPosition
+				if !p.rules[ruleHeadingAttributes]() {
+					goto l45
+				}
+				goto l38
+			l45:
+				position, thunkPosition = position45, thunkPosition45
 			}
 			if !p.rules[ruleInline]() {
-				goto l23
+				goto l38
 			}
 			return true
-		l23:
+		l38:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 5 AtxStart <- (&'#' < ('######' / '#####' / '####' / '###' / '##' / '#') > { yy = mk_element(H1 + (len(yytext) - 1)) }) */
+		/* 11 AtxStart <- (&'#' < ('######' / '#####' / '####' / '###' / '##' / '#') > { yy = mk_element(H1 + (len(yytext) - 1)) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !peekChar('#') {
-				goto l30
+				goto l46
 			}
 			begin = position
 			{
-				position31, thunkPosition31 := position, thunkPosition
+				position47, thunkPosition47 := position, thunkPosition
 				if !matchString("######") {
-					goto l32
+					goto l48
 				}
-				goto l31
-			l32:
-				position, thunkPosition = position31, thunkPosition31
+				goto l47
+			l48:
+				position, thunkPosition = position47, thunkPosition47
 				if !matchString("#####") {
-					goto l33
+					goto l49
 				}
-				goto l31
-			l33:
-				position, thunkPosition = position31, thunkPosition31
+				goto l47
+			l49:
+				position, thunkPosition = position47, thunkPosition47
 				if !matchString("####") {
-					goto l34
+					goto l50
 				}
-				goto l31
-			l34:
-				position, thunkPosition = position31, thunkPosition31
+				goto l47
+			l50:
+				position, thunkPosition = position47, thunkPosition47
 				if !matchString("###") {
-					goto l35
+					goto l51
 				}
-				goto l31
-			l35:
-				position, thunkPosition = position31, thunkPosition31
+				goto l47
+			l51:
+				position, thunkPosition = position47, thunkPosition47
 				if !matchString("##") {
-					goto l36
+					goto l52
 				}
-				goto l31
-			l36:
-				position, thunkPosition = position31, thunkPosition31
+				goto l47
+			l52:
+				position, thunkPosition = position47, thunkPosition47
 				if !matchChar('#') {
-					goto l30
+					goto l46
 				}
 			}
-		l31:
+		l47:
 			end = position
-			do(4)
+			do(10)
 			return true
-		l30:
+		l46:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 6 AtxHeading <- (AtxStart Sp? StartList (AtxInline { a = cons(yy, a) })+ (Sp? '#'* Sp)? Newline { yy = mk_list(s.key, a)
-              s = nil }) */
+		/* 12 AtxHeading <- (AtxStart (&{ !p.extension.CommonMark } / &(Spacechar / Newline)) Sp? StartList (AtxInline { a = cons(yy, a) })+ (Sp? '#'* Sp)? (HeadingAttributes / Nothing) Newline { yy = mk_list(s.key, a)
+              p.setAttributes(yy, t)
+              s = nil
+              t = nil }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 2)
+			doarg(yyPush, 3)
 			if !p.rules[ruleAtxStart]() {
-				goto l37
+				goto l53
 			}
 			doarg(yySet, -1)
 			{
-				position38, thunkPosition38 := position, thunkPosition
+				position54, thunkPosition54 := position, thunkPosition
+				if !( !p.extension.CommonMark ) {
+					goto l55
+				}
+				goto l54
+			l55:
+				position, thunkPosition = position54, thunkPosition54
+				{
+					position56, thunkPosition56 := position, thunkPosition
+					{
+						position57, thunkPosition57 := position, thunkPosition
+						if !p.rules[ruleSpacechar]() {
+							goto l58
+						}
+						goto l57
+					l58:
+						position, thunkPosition = position57, thunkPosition57
+						if !p.rules[ruleNewline]() {
+							goto l53
+						}
+					}
+				l57:
+					position, thunkPosition = position56, thunkPosition56
+				}
+			}
+		l54:
+			{
+				position59, thunkPosition59 := position, thunkPosition
 				if !p.rules[ruleSp]() {
-					goto l38
+					goto l59
 				}
-				goto l39
-			l38:
-				position, thunkPosition = position38, thunkPosition38
+				goto l60
+			l59:
+				position, thunkPosition = position59, thunkPosition59
 			}
-		l39:
+		l60:
 			if !p.rules[ruleStartList]() {
-				goto l37
+				goto l53
 			}
 			doarg(yySet, -2)
 			if !p.rules[ruleAtxInline]() {
-				goto l37
+				goto l53
 			}
-			do(5)
-		l40:
+			do(11)
+		l61:
 			{
-				position41, thunkPosition41 := position, thunkPosition
+				position62, thunkPosition62 := position, thunkPosition
 				if !p.rules[ruleAtxInline]() {
-					goto l41
+					goto l62
 				}
-				do(5)
-				goto l40
-			l41:
-				position, thunkPosition = position41, thunkPosition41
+				do(11)
+				goto l61
+			l62:
+				position, thunkPosition = position62, thunkPosition62
 			}
 			{
-				position42, thunkPosition42 := position, thunkPosition
+				position63, thunkPosition63 := position, thunkPosition
 				{
-					position44, thunkPosition44 := position, thunkPosition
+					position65, thunkPosition65 := position, thunkPosition
 					if !p.rules[ruleSp]() {
-						goto l44
+						goto l65
 					}
-					goto l45
-				l44:
-					position, thunkPosition = position44, thunkPosition44
+					goto l66
+				l65:
+					position, thunkPosition = position65, thunkPosition65
 				}
-			l45:
-			l46:
+			l66:
+			l67:
 				{
-					position47, thunkPosition47 := position, thunkPosition
+					position68, thunkPosition68 := position, thunkPosition
 					if !matchChar('#') {
-						goto l47
+						goto l68
 					}
-					goto l46
-				l47:
-					position, thunkPosition = position47, thunkPosition47
+					goto l67
+				l68:
+					position, thunkPosition = position68, thunkPosition68
 				}
 				if !p.rules[ruleSp]() {
-					goto l42
+					goto l63
 				}
-				goto l43
-			l42:
-				position, thunkPosition = position42, thunkPosition42
+				goto l64
+			l63:
+				position, thunkPosition = position63, thunkPosition63
+			}
+		l64:
+			{
+				position69, thunkPosition69 := position, thunkPosition
+				if !p.rules[ruleHeadingAttributes]() {
+					goto l70
+				}
+				doarg(yySet, -3)
+				goto l69
+			l70:
+				position, thunkPosition = position69, thunkPosition69
+				if !p.rules[ruleNothing]() {
+					goto l53
+				}
+				doarg(yySet, -3)
 			}
-		l43:
+		l69:
 			if !p.rules[ruleNewline]() {
-				goto l37
+				goto l53
 			}
-			do(6)
-			doarg(yyPop, 2)
+			do(12)
+			doarg(yyPop, 3)
 			return true
-		l37:
+		l53:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 7 SetextHeading <- (SetextHeading1 / SetextHeading2) */
+		/* 13 SetextHeading <- (SetextHeading1 / SetextHeading2) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			{
-				position49, thunkPosition49 := position, thunkPosition
+				position72, thunkPosition72 := position, thunkPosition
 				if !p.rules[ruleSetextHeading1]() {
-					goto l50
+					goto l73
 				}
-				goto l49
-			l50:
-				position, thunkPosition = position49, thunkPosition49
+				goto l72
+			l73:
+				position, thunkPosition = position72, thunkPosition72
 				if !p.rules[ruleSetextHeading2]() {
-					goto l48
+					goto l71
 				}
 			}
-		l49:
+		l72:
 			return true
-		l48:
+		l71:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 8 SetextBottom1 <- ('===' '='* Newline) */
+		/* 14 SetextBottom1 <- ('===' '='* Newline) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchString("===") {
-				goto l51
+				goto l74
 			}
-		l52:
+		l75:
 			{
-				position53, thunkPosition53 := position, thunkPosition
+				position76, thunkPosition76 := position, thunkPosition
 				if !matchChar('=') {
-					goto l53
+					goto l76
 				}
-				goto l52
-			l53:
-				position, thunkPosition = position53, thunkPosition53
+				goto l75
+			l76:
+				position, thunkPosition = position76, thunkPosition76
 			}
 			if !p.rules[ruleNewline]() {
-				goto l51
+				goto l74
 			}
 			return true
-		l51:
+		l74:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 9 SetextBottom2 <- ('---' '-'* Newline) */
+		/* 15 SetextBottom2 <- ('---' '-'* Newline) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchString("---") {
-				goto l54
+				goto l77
 			}
-		l55:
+		l78:
 			{
-				position56, thunkPosition56 := position, thunkPosition
+				position79, thunkPosition79 := position, thunkPosition
 				if !matchChar('-') {
-					goto l56
+					goto l79
 				}
-				goto l55
-			l56:
-				position, thunkPosition = position56, thunkPosition56
+				goto l78
+			l79:
+				position, thunkPosition = position79, thunkPosition79
 			}
 			if !p.rules[ruleNewline]() {
-				goto l54
+				goto l77
 			}
 			return true
-		l54:
+		l77:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 10 SetextHeading1 <- (&(RawLine SetextBottom1) StartList (!Endline Inline { a = cons(yy, a) })+ Newline SetextBottom1 { yy = mk_list(H1, a) }) */
+		/* 16 SetextHeading1 <- (&(RawLine SetextBottom1) StartList (!Endline !HeadingAttributes Inline { a = cons(yy, a) })+ (HeadingAttributes / Nothing) Newline SetextBottom1 { yy = mk_list(H1, a); p.setAttributes(yy, t); t = nil }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 1)
+			doarg(yyPush, 2)
 			{
-				position58, thunkPosition58 := position, thunkPosition
+				position81, thunkPosition81 := position, thunkPosition
 				if !p.rules[ruleRawLine]() {
-					goto l57
+					goto l80
 				}
 				if !p.rules[ruleSetextBottom1]() {
-					goto l57
+					goto l80
 				}
-				position, thunkPosition = position58, thunkPosition58
+				position, thunkPosition = position81, thunkPosition81
 			}
 			if !p.rules[ruleStartList]() {
-				goto l57
+				goto l80
 			}
 			doarg(yySet, -1)
 			{
-				position61, thunkPosition61 := position, thunkPosition
+				position84, thunkPosition84 := position, thunkPosition
 				if !p.rules[ruleEndline]() {
-					goto l61
+					goto l84
+				}
+				goto l80
+			l84:
+				position, thunkPosition = position84, thunkPosition84
+			}
+			{
+				position85, thunkPosition85 := position, thunkPosition
+				if !p.rules[ruleHeadingAttributes]() {
+					goto l85
 				}
-				goto l57
-			l61:
-				position, thunkPosition = position61, thunkPosition61
+				goto l80
+			l85:
+				position, thunkPosition = position85, thunkPosition85
 			}
 			if !p.rules[ruleInline]() {
-				goto l57
+				goto l80
 			}
-			do(7)
-		l59:
+			do(13)
+		l82:
 			{
-				position60, thunkPosition60 := position, thunkPosition
+				position83, thunkPosition83 := position, thunkPosition
 				{
-					position62, thunkPosition62 := position, thunkPosition
+					position86, thunkPosition86 := position, thunkPosition
 					if !p.rules[ruleEndline]() {
-						goto l62
+						goto l86
+					}
+					goto l83
+				l86:
+					position, thunkPosition = position86, thunkPosition86
+				}
+				{
+					position87, thunkPosition87 := position, thunkPosition
+					if !p.rules[ruleHeadingAttributes]() {
+						goto l87
 					}
-					goto l60
-				l62:
-					position, thunkPosition = position62, thunkPosition62
+					goto l83
+				l87:
+					position, thunkPosition = position87, thunkPosition87
 				}
 				if !p.rules[ruleInline]() {
-					goto l60
+					goto l83
+				}
+				do(13)
+				goto l82
+			l83:
+				position, thunkPosition = position83, thunkPosition83
+			}
+			{
+				position88, thunkPosition88 := position, thunkPosition
+				if !p.rules[ruleHeadingAttributes]() {
+					goto l89
+				}
+				doarg(yySet, -2)
+				goto l88
+			l89:
+				position, thunkPosition = position88, thunkPosition88
+				if !p.rules[ruleNothing]() {
+					goto l80
 				}
-				do(7)
-				goto l59
-			l60:
-				position, thunkPosition = position60, thunkPosition60
+				doarg(yySet, -2)
 			}
+		l88:
 			if !p.rules[ruleNewline]() {
-				goto l57
+				goto l80
 			}
 			if !p.rules[ruleSetextBottom1]() {
-				goto l57
+				goto l80
 			}
-			do(8)
-			doarg(yyPop, 1)
+			do(14)
+			doarg(yyPop, 2)
 			return true
-		l57:
+		l80:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 11 SetextHeading2 <- (&(RawLine SetextBottom2) StartList (!Endline Inline { a = cons(yy, a) })+ Newline SetextBottom2 { yy = mk_list(H2, a) }) */
+		/* 17 SetextHeading2 <- (&(RawLine SetextBottom2) StartList (!Endline !HeadingAttributes Inline { a = cons(yy, a) })+ (HeadingAttributes / Nothing) Newline SetextBottom2 { yy = mk_list(H2, a); p.setAttributes(yy, t); t = nil }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 1)
+			doarg(yyPush, 2)
 			{
-				position64, thunkPosition64 := position, thunkPosition
+				position91, thunkPosition91 := position, thunkPosition
 				if !p.rules[ruleRawLine]() {
-					goto l63
+					goto l90
 				}
 				if !p.rules[ruleSetextBottom2]() {
-					goto l63
+					goto l90
 				}
-				position, thunkPosition = position64, thunkPosition64
+				position, thunkPosition = position91, thunkPosition91
 			}
 			if !p.rules[ruleStartList]() {
-				goto l63
+				goto l90
 			}
 			doarg(yySet, -1)
 			{
-				position67, thunkPosition67 := position, thunkPosition
+				position94, thunkPosition94 := position, thunkPosition
 				if !p.rules[ruleEndline]() {
-					goto l67
+					goto l94
 				}
-				goto l63
-			l67:
-				position, thunkPosition = position67, thunkPosition67
-			}
+				goto l90
+			l94:
+				position, thunkPosition = position94, thunkPosition94
+			}
+			{
+				position95, thunkPosition95 := position, thunkPosition
+				if !p.rules[ruleHeadingAttributes]() {
+					goto l95
+				}
+				goto l90
+			l95:
+				position, thunkPosition = position95, thunkPosition95
+			}
 			if !p.rules[ruleInline]() {
-				goto l63
+				goto l90
 			}
-			do(9)
-		l65:
+			do(15)
+		l92:
 			{
-				position66, thunkPosition66 := position, thunkPosition
+				position93, thunkPosition93 := position, thunkPosition
 				{
-					position68, thunkPosition68 := position, thunkPosition
+					position96, thunkPosition96 := position, thunkPosition
 					if !p.rules[ruleEndline]() {
-						goto l68
+						goto l96
 					}
-					goto l66
-				l68:
-					position, thunkPosition = position68, thunkPosition68
+					goto l93
+				l96:
+					position, thunkPosition = position96, thunkPosition96
+				}
+				{
+					position97, thunkPosition97 := position, thunkPosition
+					if !p.rules[ruleHeadingAttributes]() {
+						goto l97
+					}
+					goto l93
+				l97:
+					position, thunkPosition = position97, thunkPosition97
 				}
 				if !p.rules[ruleInline]() {
-					goto l66
+					goto l93
 				}
-				do(9)
-				goto l65
-			l66:
-				position, thunkPosition = position66, thunkPosition66
+				do(15)
+				goto l92
+			l93:
+				position, thunkPosition = position93, thunkPosition93
+			}
+			{
+				position98, thunkPosition98 := position, thunkPosition
+				if !p.rules[ruleHeadingAttributes]() {
+					goto l99
+				}
+				doarg(yySet, -2)
+				goto l98
+			l99:
+				position, thunkPosition = position98, thunkPosition98
+				if !p.rules[ruleNothing]() {
+					goto l90
+				}
+				doarg(yySet, -2)
 			}
+		l98:
 			if !p.rules[ruleNewline]() {
-				goto l63
+				goto l90
 			}
 			if !p.rules[ruleSetextBottom2]() {
-				goto l63
+				goto l90
 			}
-			do(10)
-			doarg(yyPop, 1)
+			do(16)
+			doarg(yyPop, 2)
 			return true
-		l63:
+		l90:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 12 Heading <- (AtxHeading / SetextHeading) */
+		/* 18 Heading <- (AtxHeading / SetextHeading) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			{
-				position70, thunkPosition70 := position, thunkPosition
+				position101, thunkPosition101 := position, thunkPosition
 				if !p.rules[ruleAtxHeading]() {
-					goto l71
+					goto l102
 				}
-				goto l70
-			l71:
-				position, thunkPosition = position70, thunkPosition70
+				goto l101
+			l102:
+				position, thunkPosition = position101, thunkPosition101
 				if !p.rules[ruleSetextHeading]() {
-					goto l69
+					goto l100
 				}
 			}
-		l70:
+		l101:
 			return true
-		l69:
+		l100:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 13 BlockQuote <- (BlockQuoteRaw {  yy = mk_element(BLOCKQUOTE)
-                yy.children = a
-             }) */
+		/* 19 HeadingAttributes <- (Sp AttributeBlock Sp &Newline { yy = a }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			doarg(yyPush, 1)
-			if !p.rules[ruleBlockQuoteRaw]() {
-				goto l72
+			if !p.rules[ruleSp]() {
+				goto l103
+			}
+			if !p.rules[ruleAttributeBlock]() {
+				goto l103
 			}
 			doarg(yySet, -1)
-			do(11)
+			if !p.rules[ruleSp]() {
+				goto l103
+			}
+			{
+				position104, thunkPosition104 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l103
+				}
+				position, thunkPosition = position104, thunkPosition104
+			}
+			do(17)
 			doarg(yyPop, 1)
 			return true
-		l72:
+		l103:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 14 BlockQuoteRaw <- (StartList ('>' ' '? Line { a = cons(yy, a) } (!'>' !BlankLine Line { a = cons(yy, a) })* (BlankLine { a = cons(mk_str("\n"), a) })*)+ {   yy = mk_str_from_list(a, true)
-                     yy.key = RAW
-                 }) */
+		/* 20 AttributeBlock <- (&{ p.extension.Attributes } '{' < (!'}' !Newline .)+ > '}' &{ parseAttributes(p.Buffer[begin:end]) != nil } { yy = mk_str(yytext) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 1)
-			if !p.rules[ruleStartList]() {
-				goto l73
+			if !( p.extension.Attributes ) {
+				goto l105
 			}
-			doarg(yySet, -1)
-			if !matchChar('>') {
-				goto l73
+			if !matchChar('{') {
+				goto l105
+			}
+			begin = position
+			if peekChar('}') {
+				goto l105
 			}
 			{
-				position76, thunkPosition76 := position, thunkPosition
-				if !matchChar(' ') {
-					goto l76
+				position108, thunkPosition108 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l108
 				}
-				goto l77
-			l76:
-				position, thunkPosition = position76, thunkPosition76
+				goto l105
+			l108:
+				position, thunkPosition = position108, thunkPosition108
 			}
-		l77:
-			if !p.rules[ruleLine]() {
-				goto l73
+			if !matchDot() {
+				goto l105
 			}
-			do(12)
-		l78:
+		l106:
 			{
-				position79, thunkPosition79 := position, thunkPosition
-				if peekChar('>') {
-					goto l79
+				position107, thunkPosition107 := position, thunkPosition
+				if peekChar('}') {
+					goto l107
 				}
 				{
-					position80, thunkPosition80 := position, thunkPosition
-					if !p.rules[ruleBlankLine]() {
-						goto l80
+					position109, thunkPosition109 := position, thunkPosition
+					if !p.rules[ruleNewline]() {
+						goto l109
 					}
-					goto l79
-				l80:
-					position, thunkPosition = position80, thunkPosition80
+					goto l107
+				l109:
+					position, thunkPosition = position109, thunkPosition109
 				}
-				if !p.rules[ruleLine]() {
-					goto l79
+				if !matchDot() {
+					goto l107
 				}
-				do(13)
-				goto l78
-			l79:
-				position, thunkPosition = position79, thunkPosition79
+				goto l106
+			l107:
+				position, thunkPosition = position107, thunkPosition107
 			}
-		l81:
-			{
-				position82, thunkPosition82 := position, thunkPosition
-				if !p.rules[ruleBlankLine]() {
-					goto l82
-				}
-				do(14)
-				goto l81
-			l82:
-				position, thunkPosition = position82, thunkPosition82
+			end = position
+			if !matchChar('}') {
+				goto l105
 			}
-		l74:
-			{
-				position75, thunkPosition75 := position, thunkPosition
-				if !matchChar('>') {
-					goto l75
-				}
-				{
-					position83, thunkPosition83 := position, thunkPosition
-					if !matchChar(' ') {
-						goto l83
-					}
-					goto l84
-				l83:
-					position, thunkPosition = position83, thunkPosition83
-				}
-			l84:
-				if !p.rules[ruleLine]() {
-					goto l75
-				}
-				do(12)
-			l85:
-				{
-					position86, thunkPosition86 := position, thunkPosition
-					if peekChar('>') {
-						goto l86
-					}
-					{
-						position87, thunkPosition87 := position, thunkPosition
-						if !p.rules[ruleBlankLine]() {
-							goto l87
-						}
-						goto l86
-					l87:
-						position, thunkPosition = position87, thunkPosition87
-					}
-					if !p.rules[ruleLine]() {
-						goto l86
-					}
-					do(13)
-					goto l85
-				l86:
-					position, thunkPosition = position86, thunkPosition86
-				}
-			l88:
-				{
-					position89, thunkPosition89 := position, thunkPosition
-					if !p.rules[ruleBlankLine]() {
-						goto l89
-					}
-					do(14)
-					goto l88
-				l89:
-					position, thunkPosition = position89, thunkPosition89
-				}
-				goto l74
-			l75:
-				position, thunkPosition = position75, thunkPosition75
+			if !( parseAttributes(p.Buffer[begin:end]) != nil ) {
+				goto l105
 			}
-			do(15)
-			doarg(yyPop, 1)
+			do(18)
 			return true
-		l73:
+		l105:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 15 NonblankIndentedLine <- (!BlankLine IndentedLine) */
+		/* 21 Nothing <- ('' { yy = nil }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			{
-				position91, thunkPosition91 := position, thunkPosition
-				if !p.rules[ruleBlankLine]() {
-					goto l91
-				}
-				goto l90
-			l91:
-				position, thunkPosition = position91, thunkPosition91
-			}
-			if !p.rules[ruleIndentedLine]() {
-				goto l90
+			if !matchString("") {
+				goto l110
 			}
+			do(19)
 			return true
-		l90:
+		l110:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 16 VerbatimChunk <- (StartList (BlankLine { a = cons(mk_str("\n"), a) })* (NonblankIndentedLine { a = cons(yy, a) })+ { yy = mk_str_from_list(a, false) }) */
+		/* 22 BlockQuote <- (BlockQuoteRaw {  yy = mk_element(BLOCKQUOTE)
+                yy.children = a
+                if p.extension.StrictLists {
+                    a.contents.str = strictIndent(a.contents.str, p.extension.ListStart)
+                }
+             }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			doarg(yyPush, 1)
-			if !p.rules[ruleStartList]() {
-				goto l92
+			if !p.rules[ruleBlockQuoteRaw]() {
+				goto l111
 			}
 			doarg(yySet, -1)
-		l93:
-			{
-				position94, thunkPosition94 := position, thunkPosition
-				if !p.rules[ruleBlankLine]() {
-					goto l94
-				}
-				do(16)
-				goto l93
-			l94:
-				position, thunkPosition = position94, thunkPosition94
-			}
-			if !p.rules[ruleNonblankIndentedLine]() {
-				goto l92
+			do(20)
+			doarg(yyPop, 1)
+			return true
+		l111:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 23 Admonition <- (&{ p.extension.Admonitions } (AdmonitionBlock / Alert)) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !( p.extension.Admonitions ) {
+				goto l112
 			}
-			do(17)
-		l95:
 			{
-				position96, thunkPosition96 := position, thunkPosition
-				if !p.rules[ruleNonblankIndentedLine]() {
-					goto l96
+				position113, thunkPosition113 := position, thunkPosition
+				if !p.rules[ruleAdmonitionBlock]() {
+					goto l114
+				}
+				goto l113
+			l114:
+				position, thunkPosition = position113, thunkPosition113
+				if !p.rules[ruleAlert]() {
+					goto l112
 				}
-				do(17)
-				goto l95
-			l96:
-				position, thunkPosition = position96, thunkPosition96
 			}
-			do(18)
-			doarg(yyPop, 1)
+		l113:
 			return true
-		l92:
+		l112:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 17 Verbatim <- (StartList (VerbatimChunk { a = cons(yy, a) })+ { yy = mk_str_from_list(a, false)
-                 yy.key = VERBATIM }) */
+		/* 24 AdmonitionBlock <- (NonindentSpace '!!!' Spacechar+ AdmonitionKind (AdmonitionTitle / Nothing) Sp Newline StartList (AdmonitionChunk { a = cons(yy, a) })* {  yy = p.admonition(k, t, mk_str_from_list(a, true)) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 1)
-			if !p.rules[ruleStartList]() {
-				goto l97
+			doarg(yyPush, 3)
+			if !p.rules[ruleNonindentSpace]() {
+				goto l115
+			}
+			if !matchString("!!!") {
+				goto l115
+			}
+			if !p.rules[ruleSpacechar]() {
+				goto l115
+			}
+		l116:
+			{
+				position117, thunkPosition117 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l117
+				}
+				goto l116
+			l117:
+				position, thunkPosition = position117, thunkPosition117
+			}
+			if !p.rules[ruleAdmonitionKind]() {
+				goto l115
 			}
 			doarg(yySet, -1)
-			if !p.rules[ruleVerbatimChunk]() {
-				goto l97
+			{
+				position118, thunkPosition118 := position, thunkPosition
+				if !p.rules[ruleAdmonitionTitle]() {
+					goto l119
+				}
+				doarg(yySet, -2)
+				goto l118
+			l119:
+				position, thunkPosition = position118, thunkPosition118
+				if !p.rules[ruleNothing]() {
+					goto l115
+				}
+				doarg(yySet, -2)
 			}
-			do(19)
-		l98:
+		l118:
+			if !p.rules[ruleSp]() {
+				goto l115
+			}
+			if !p.rules[ruleNewline]() {
+				goto l115
+			}
+			if !p.rules[ruleStartList]() {
+				goto l115
+			}
+			doarg(yySet, -3)
+		l120:
 			{
-				position99, thunkPosition99 := position, thunkPosition
-				if !p.rules[ruleVerbatimChunk]() {
-					goto l99
+				position121, thunkPosition121 := position, thunkPosition
+				if !p.rules[ruleAdmonitionChunk]() {
+					goto l121
 				}
-				do(19)
-				goto l98
-			l99:
-				position, thunkPosition = position99, thunkPosition99
+				do(21)
+				goto l120
+			l121:
+				position, thunkPosition = position121, thunkPosition121
 			}
-			do(20)
-			doarg(yyPop, 1)
+			do(22)
+			doarg(yyPop, 3)
 			return true
-		l97:
+		l115:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 18 HorizontalRule <- (NonindentSpace (('*' Sp '*' Sp '*' (Sp '*')*) / ('-' Sp '-' Sp '-' (Sp '-')*) / ('_' Sp '_' Sp '_' (Sp '_')*)) Sp Newline BlankLine+ { yy = mk_element(HRULE) }) */
+		/* 25 AdmonitionKind <- (< [A-Za-z0-9_-]+ (Spacechar+ [A-Za-z0-9_-]+)* > { yy = mk_str(yytext) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleNonindentSpace]() {
-				goto l100
+			begin = position
+			if !matchClass(10) {
+				goto l122
 			}
+		l123:
 			{
-				position101, thunkPosition101 := position, thunkPosition
-				if !matchChar('*') {
-					goto l102
-				}
-				if !p.rules[ruleSp]() {
-					goto l102
-				}
-				if !matchChar('*') {
-					goto l102
+				position124, thunkPosition124 := position, thunkPosition
+				if !matchClass(10) {
+					goto l124
 				}
-				if !p.rules[ruleSp]() {
-					goto l102
+				goto l123
+			l124:
+				position, thunkPosition = position124, thunkPosition124
+			}
+		l125:
+			{
+				position126, thunkPosition126 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l126
 				}
-				if !matchChar('*') {
-					goto l102
-				}
-			l103:
+			l127:
 				{
-					position104, thunkPosition104 := position, thunkPosition
-					if !p.rules[ruleSp]() {
-						goto l104
-					}
-					if !matchChar('*') {
-						goto l104
+					position128, thunkPosition128 := position, thunkPosition
+					if !p.rules[ruleSpacechar]() {
+						goto l128
 					}
-					goto l103
-				l104:
-					position, thunkPosition = position104, thunkPosition104
-				}
-				goto l101
-			l102:
-				position, thunkPosition = position101, thunkPosition101
-				if !matchChar('-') {
-					goto l105
-				}
-				if !p.rules[ruleSp]() {
-					goto l105
-				}
-				if !matchChar('-') {
-					goto l105
-				}
-				if !p.rules[ruleSp]() {
-					goto l105
+					goto l127
+				l128:
+					position, thunkPosition = position128, thunkPosition128
 				}
-				if !matchChar('-') {
-					goto l105
+				if !matchClass(10) {
+					goto l126
 				}
-			l106:
+			l129:
 				{
-					position107, thunkPosition107 := position, thunkPosition
-					if !p.rules[ruleSp]() {
-						goto l107
-					}
-					if !matchChar('-') {
-						goto l107
+					position130, thunkPosition130 := position, thunkPosition
+					if !matchClass(10) {
+						goto l130
 					}
-					goto l106
-				l107:
-					position, thunkPosition = position107, thunkPosition107
-				}
-				goto l101
-			l105:
-				position, thunkPosition = position101, thunkPosition101
-				if !matchChar('_') {
-					goto l100
-				}
-				if !p.rules[ruleSp]() {
-					goto l100
-				}
-				if !matchChar('_') {
-					goto l100
-				}
-				if !p.rules[ruleSp]() {
-					goto l100
+					goto l129
+				l130:
+					position, thunkPosition = position130, thunkPosition130
 				}
-				if !matchChar('_') {
-					goto l100
+				goto l125
+			l126:
+				position, thunkPosition = position126, thunkPosition126
+			}
+			end = position
+			do(23)
+			return true
+		l122:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 26 AdmonitionTitle <- (Sp '"' < (!'"' !Newline .)* > '"' { yy = mk_str(yytext) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !p.rules[ruleSp]() {
+				goto l131
+			}
+			if !matchChar('"') {
+				goto l131
+			}
+			begin = position
+		l132:
+			{
+				position133, thunkPosition133 := position, thunkPosition
+				if peekChar('"') {
+					goto l133
 				}
-			l108:
 				{
-					position109, thunkPosition109 := position, thunkPosition
-					if !p.rules[ruleSp]() {
-						goto l109
-					}
-					if !matchChar('_') {
-						goto l109
+					position134, thunkPosition134 := position, thunkPosition
+					if !p.rules[ruleNewline]() {
+						goto l134
 					}
-					goto l108
-				l109:
-					position, thunkPosition = position109, thunkPosition109
+					goto l133
+				l134:
+					position, thunkPosition = position134, thunkPosition134
 				}
+				if !matchDot() {
+					goto l133
+				}
+				goto l132
+			l133:
+				position, thunkPosition = position133, thunkPosition133
 			}
-		l101:
-			if !p.rules[ruleSp]() {
-				goto l100
-			}
-			if !p.rules[ruleNewline]() {
-				goto l100
+			end = position
+			if !matchChar('"') {
+				goto l131
 			}
-			if !p.rules[ruleBlankLine]() {
-				goto l100
+			do(24)
+			return true
+		l131:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 27 AdmonitionChunk <- (StartList (BlankLine { a = cons(mk_str("\n"), a) })* (IndentedLine { a = cons(yy, a) })+ { yy = mk_str_from_list(a, false) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 1)
+			if !p.rules[ruleStartList]() {
+				goto l135
 			}
-		l110:
+			doarg(yySet, -1)
+		l136:
 			{
-				position111, thunkPosition111 := position, thunkPosition
+				position137, thunkPosition137 := position, thunkPosition
 				if !p.rules[ruleBlankLine]() {
-					goto l111
+					goto l137
 				}
-				goto l110
-			l111:
-				position, thunkPosition = position111, thunkPosition111
+				do(25)
+				goto l136
+			l137:
+				position, thunkPosition = position137, thunkPosition137
+			}
+			if !p.rules[ruleIndentedLine]() {
+				goto l135
+			}
+			do(26)
+		l138:
+			{
+				position139, thunkPosition139 := position, thunkPosition
+				if !p.rules[ruleIndentedLine]() {
+					goto l139
+				}
+				do(26)
+				goto l138
+			l139:
+				position, thunkPosition = position139, thunkPosition139
 			}
-			do(21)
+			do(27)
+			doarg(yyPop, 1)
 			return true
-		l100:
+		l135:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 19 Bullet <- (!HorizontalRule NonindentSpace ('+' / '*' / '-') Spacechar+) */
+		/* 28 Alert <- ('>' ' '? '[!' AlertKind ']' Sp Newline (BlockQuoteRaw / Nothing) {  yy = p.admonition(k, nil, a) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 2)
+			if !matchChar('>') {
+				goto l140
+			}
 			{
-				position113, thunkPosition113 := position, thunkPosition
-				if !p.rules[ruleHorizontalRule]() {
-					goto l113
+				position141, thunkPosition141 := position, thunkPosition
+				if !matchChar(' ') {
+					goto l141
 				}
-				goto l112
-			l113:
-				position, thunkPosition = position113, thunkPosition113
+				goto l142
+			l141:
+				position, thunkPosition = position141, thunkPosition141
 			}
-			if !p.rules[ruleNonindentSpace]() {
-				goto l112
+		l142:
+			if !matchString("[!") {
+				goto l140
 			}
-			{
-				position114, thunkPosition114 := position, thunkPosition
-				if !matchChar('+') {
-					goto l115
-				}
-				goto l114
-			l115:
-				position, thunkPosition = position114, thunkPosition114
-				if !matchChar('*') {
-					goto l116
-				}
-				goto l114
-			l116:
-				position, thunkPosition = position114, thunkPosition114
-				if !matchChar('-') {
-					goto l112
-				}
+			if !p.rules[ruleAlertKind]() {
+				goto l140
 			}
-		l114:
-			if !p.rules[ruleSpacechar]() {
-				goto l112
+			doarg(yySet, -1)
+			if !matchChar(']') {
+				goto l140
+			}
+			if !p.rules[ruleSp]() {
+				goto l140
+			}
+			if !p.rules[ruleNewline]() {
+				goto l140
 			}
-		l117:
 			{
-				position118, thunkPosition118 := position, thunkPosition
-				if !p.rules[ruleSpacechar]() {
-					goto l118
+				position143, thunkPosition143 := position, thunkPosition
+				if !p.rules[ruleBlockQuoteRaw]() {
+					goto l144
 				}
-				goto l117
-			l118:
-				position, thunkPosition = position118, thunkPosition118
+				doarg(yySet, -2)
+				goto l143
+			l144:
+				position, thunkPosition = position143, thunkPosition143
+				if !p.rules[ruleNothing]() {
+					goto l140
+				}
+				doarg(yySet, -2)
 			}
+		l143:
+			do(28)
+			doarg(yyPop, 2)
 			return true
-		l112:
+		l140:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 20 BulletList <- (&Bullet (ListTight / ListLoose) { yy.key = BULLETLIST }) */
+		/* 29 AlertKind <- (< [A-Za-z]+ > &{ alertKinds[strings.ToLower(p.Buffer[begin:end])] } { yy = mk_str(yytext) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			{
-				position120, thunkPosition120 := position, thunkPosition
-				if !p.rules[ruleBullet]() {
-					goto l119
-				}
-				position, thunkPosition = position120, thunkPosition120
+			begin = position
+			if !matchClass(4) {
+				goto l145
 			}
+		l146:
 			{
-				position121, thunkPosition121 := position, thunkPosition
-				if !p.rules[ruleListTight]() {
-					goto l122
-				}
-				goto l121
-			l122:
-				position, thunkPosition = position121, thunkPosition121
-				if !p.rules[ruleListLoose]() {
-					goto l119
+				position147, thunkPosition147 := position, thunkPosition
+				if !matchClass(4) {
+					goto l147
 				}
+				goto l146
+			l147:
+				position, thunkPosition = position147, thunkPosition147
 			}
-		l121:
-			do(22)
+			end = position
+			if !( alertKinds[strings.ToLower(p.Buffer[begin:end])] ) {
+				goto l145
+			}
+			do(29)
 			return true
-		l119:
+		l145:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 21 ListTight <- (StartList (ListItemTight { a = cons(yy, a) })+ BlankLine* !(Bullet / Enumerator / DefMarker) { yy = mk_list(LIST, a) }) */
+		/* 30 FencedDiv <- (&{ p.extension.FencedDivs } DivStart < DivBody > DivEnd { yy = p.fencedDiv(a, yytext) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			doarg(yyPush, 1)
-			if !p.rules[ruleStartList]() {
-				goto l123
+			if !( p.extension.FencedDivs ) {
+				goto l148
 			}
-			doarg(yySet, -1)
-			if !p.rules[ruleListItemTight]() {
-				goto l123
-			}
-			do(23)
-		l124:
-			{
-				position125, thunkPosition125 := position, thunkPosition
-				if !p.rules[ruleListItemTight]() {
-					goto l125
-				}
-				do(23)
-				goto l124
-			l125:
-				position, thunkPosition = position125, thunkPosition125
+			if !p.rules[ruleDivStart]() {
+				goto l148
 			}
-		l126:
-			{
-				position127, thunkPosition127 := position, thunkPosition
-				if !p.rules[ruleBlankLine]() {
-					goto l127
-				}
-				goto l126
-			l127:
-				position, thunkPosition = position127, thunkPosition127
+			doarg(yySet, -1)
+			begin = position
+			if !p.rules[ruleDivBody]() {
+				goto l148
 			}
-			{
-				position128, thunkPosition128 := position, thunkPosition
-				{
-					position129, thunkPosition129 := position, thunkPosition
-					if !p.rules[ruleBullet]() {
-						goto l130
-					}
-					goto l129
-				l130:
-					position, thunkPosition = position129, thunkPosition129
-					if !p.rules[ruleEnumerator]() {
-						goto l131
-					}
-					goto l129
-				l131:
-					position, thunkPosition = position129, thunkPosition129
-					if !p.rules[ruleDefMarker]() {
-						goto l128
-					}
-				}
-			l129:
-				goto l123
-			l128:
-				position, thunkPosition = position128, thunkPosition128
+			end = position
+			if !p.rules[ruleDivEnd]() {
+				goto l148
 			}
-			do(24)
+			do(30)
 			doarg(yyPop, 1)
 			return true
-		l123:
+		l148:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 22 ListLoose <- (StartList (ListItem BlankLine* {
-                  li := b.children
-                  li.contents.str += "\n\n"
-                  a = cons(b, a)
-              })+ { yy = mk_list(LIST, a) }) */
+		/* 31 DivFence <- (&{ p.extension.FencedDivs } NonindentSpace ':::' ':'* Sp) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 2)
-			if !p.rules[ruleStartList]() {
-				goto l132
+			if !( p.extension.FencedDivs ) {
+				goto l149
 			}
-			doarg(yySet, -1)
-			if !p.rules[ruleListItem]() {
-				goto l132
+			if !p.rules[ruleNonindentSpace]() {
+				goto l149
 			}
-			doarg(yySet, -2)
-		l135:
-			{
-				position136, thunkPosition136 := position, thunkPosition
-				if !p.rules[ruleBlankLine]() {
-					goto l136
-				}
-				goto l135
-			l136:
-				position, thunkPosition = position136, thunkPosition136
+			if !matchString(":::") {
+				goto l149
 			}
-			do(25)
-		l133:
+		l150:
 			{
-				position134, thunkPosition134 := position, thunkPosition
-				if !p.rules[ruleListItem]() {
-					goto l134
-				}
-				doarg(yySet, -2)
-			l137:
-				{
-					position138, thunkPosition138 := position, thunkPosition
-					if !p.rules[ruleBlankLine]() {
-						goto l138
-					}
-					goto l137
-				l138:
-					position, thunkPosition = position138, thunkPosition138
+				position151, thunkPosition151 := position, thunkPosition
+				if !matchChar(':') {
+					goto l151
 				}
-				do(25)
-				goto l133
-			l134:
-				position, thunkPosition = position134, thunkPosition134
+				goto l150
+			l151:
+				position, thunkPosition = position151, thunkPosition151
+			}
+			if !p.rules[ruleSp]() {
+				goto l149
 			}
-			do(26)
-			doarg(yyPop, 2)
 			return true
-		l132:
+		l149:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 23 ListItem <- ((Bullet / Enumerator / DefMarker) StartList ListBlock { a = cons(yy, a) } (ListContinuationBlock { a = cons(yy, a) })* {
-               raw := mk_str_from_list(a, false)
-               raw.key = RAW
-               yy = mk_element(LISTITEM)
-               yy.children = raw
-            }) */
+		/* 32 DivStart <- (DivFence &{ isDivStart(p.Buffer[position:]) } < (!'\r' !'\n' .)* > Newline { yy = mk_str(yytext) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 1)
+			if !p.rules[ruleDivFence]() {
+				goto l152
+			}
+			if !( isDivStart(p.Buffer[position:]) ) {
+				goto l152
+			}
+			begin = position
+		l153:
 			{
-				position140, thunkPosition140 := position, thunkPosition
-				if !p.rules[ruleBullet]() {
-					goto l141
+				position154, thunkPosition154 := position, thunkPosition
+				if peekChar('\r') {
+					goto l154
 				}
-				goto l140
-			l141:
-				position, thunkPosition = position140, thunkPosition140
-				if !p.rules[ruleEnumerator]() {
-					goto l142
+				if peekChar('\n') {
+					goto l154
 				}
-				goto l140
-			l142:
-				position, thunkPosition = position140, thunkPosition140
-				if !p.rules[ruleDefMarker]() {
-					goto l139
+				if !matchDot() {
+					goto l154
 				}
+				goto l153
+			l154:
+				position, thunkPosition = position154, thunkPosition154
 			}
-		l140:
-			if !p.rules[ruleStartList]() {
-				goto l139
-			}
-			doarg(yySet, -1)
-			if !p.rules[ruleListBlock]() {
-				goto l139
+			end = position
+			if !p.rules[ruleNewline]() {
+				goto l152
 			}
-			do(27)
-		l143:
+			do(31)
+			return true
+		l152:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 33 DivBody <- ((DivOpen DivBody DivEnd) / (!DivEnd DivLine))* */
+		func() bool {
+		l156:
 			{
-				position144, thunkPosition144 := position, thunkPosition
-				if !p.rules[ruleListContinuationBlock]() {
-					goto l144
+				position157, thunkPosition157 := position, thunkPosition
+				{
+					position158, thunkPosition158 := position, thunkPosition
+					if !p.rules[ruleDivOpen]() {
+						goto l159
+					}
+					if !p.rules[ruleDivBody]() {
+						goto l159
+					}
+					if !p.rules[ruleDivEnd]() {
+						goto l159
+					}
+					goto l158
+				l159:
+					position, thunkPosition = position158, thunkPosition158
+					{
+						position160, thunkPosition160 := position, thunkPosition
+						if !p.rules[ruleDivEnd]() {
+							goto l160
+						}
+						goto l157
+					l160:
+						position, thunkPosition = position160, thunkPosition160
+					}
+					if !p.rules[ruleDivLine]() {
+						goto l157
+					}
 				}
-				do(28)
-				goto l143
-			l144:
-				position, thunkPosition = position144, thunkPosition144
+			l158:
+				goto l156
+			l157:
+				position, thunkPosition = position157, thunkPosition157
+			}
+			return true
+		},
+		/* 34 DivOpen <- (DivFence &{ isDivStart(p.Buffer[position:]) } DivLine) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !p.rules[ruleDivFence]() {
+				goto l161
+			}
+			if !( isDivStart(p.Buffer[position:]) ) {
+				goto l161
+			}
+			if !p.rules[ruleDivLine]() {
+				goto l161
 			}
-			do(29)
-			doarg(yyPop, 1)
 			return true
-		l139:
+		l161:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 24 ListItemTight <- ((Bullet / Enumerator / DefMarker) StartList ListBlock { a = cons(yy, a) } (!BlankLine ListContinuationBlock { a = cons(yy, a) })* !ListContinuationBlock {
-               raw := mk_str_from_list(a, false)
-               raw.key = RAW
-               yy = mk_element(LISTITEM)
-               yy.children = raw
-            }) */
+		/* 35 DivEnd <- (DivFence (Newline / Eof)) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 1)
+			if !p.rules[ruleDivFence]() {
+				goto l162
+			}
 			{
-				position146, thunkPosition146 := position, thunkPosition
-				if !p.rules[ruleBullet]() {
-					goto l147
-				}
-				goto l146
-			l147:
-				position, thunkPosition = position146, thunkPosition146
-				if !p.rules[ruleEnumerator]() {
-					goto l148
+				position163, thunkPosition163 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l164
 				}
-				goto l146
-			l148:
-				position, thunkPosition = position146, thunkPosition146
-				if !p.rules[ruleDefMarker]() {
-					goto l145
+				goto l163
+			l164:
+				position, thunkPosition = position163, thunkPosition163
+				if !p.rules[ruleEof]() {
+					goto l162
 				}
 			}
-		l146:
-			if !p.rules[ruleStartList]() {
-				goto l145
-			}
-			doarg(yySet, -1)
-			if !p.rules[ruleListBlock]() {
-				goto l145
-			}
-			do(30)
-		l149:
+		l163:
+			return true
+		l162:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 36 DivLine <- (((!'\r' !'\n' .)* Newline) / (.+ Eof)) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
 			{
-				position150, thunkPosition150 := position, thunkPosition
+				position166, thunkPosition166 := position, thunkPosition
+			l168:
 				{
-					position151, thunkPosition151 := position, thunkPosition
-					if !p.rules[ruleBlankLine]() {
-						goto l151
+					position169, thunkPosition169 := position, thunkPosition
+					if peekChar('\r') {
+						goto l169
+					}
+					if peekChar('\n') {
+						goto l169
+					}
+					if !matchDot() {
+						goto l169
 					}
-					goto l150
-				l151:
-					position, thunkPosition = position151, thunkPosition151
+					goto l168
+				l169:
+					position, thunkPosition = position169, thunkPosition169
 				}
-				if !p.rules[ruleListContinuationBlock]() {
-					goto l150
+				if !p.rules[ruleNewline]() {
+					goto l167
 				}
-				do(31)
-				goto l149
-			l150:
-				position, thunkPosition = position150, thunkPosition150
-			}
-			{
-				position152, thunkPosition152 := position, thunkPosition
-				if !p.rules[ruleListContinuationBlock]() {
-					goto l152
+				goto l166
+			l167:
+				position, thunkPosition = position166, thunkPosition166
+				if !matchDot() {
+					goto l165
+				}
+			l170:
+				{
+					position171, thunkPosition171 := position, thunkPosition
+					if !matchDot() {
+						goto l171
+					}
+					goto l170
+				l171:
+					position, thunkPosition = position171, thunkPosition171
+				}
+				if !p.rules[ruleEof]() {
+					goto l165
 				}
-				goto l145
-			l152:
-				position, thunkPosition = position152, thunkPosition152
 			}
-			do(32)
-			doarg(yyPop, 1)
+		l166:
 			return true
-		l145:
+		l165:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 25 ListBlock <- (StartList !BlankLine Line { a = cons(yy, a) } (ListBlockLine { a = cons(yy, a) })* { yy = mk_str_from_list(a, false) }) */
+		/* 37 BlockQuoteRaw <- (StartList ('>' ' '? Line { a = cons(yy, a) } (!'>' !BlankLine Line { a = cons(yy, a) })* (BlankLine { a = cons(mk_str("\n"), a) })*)+ {   yy = mk_str_from_list(a, true)
+                     yy.key = RAW
+                 }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			doarg(yyPush, 1)
 			if !p.rules[ruleStartList]() {
-				goto l153
+				goto l172
 			}
 			doarg(yySet, -1)
+			if !matchChar('>') {
+				goto l172
+			}
 			{
-				position154, thunkPosition154 := position, thunkPosition
-				if !p.rules[ruleBlankLine]() {
-					goto l154
+				position175, thunkPosition175 := position, thunkPosition
+				if !matchChar(' ') {
+					goto l175
 				}
-				goto l153
-			l154:
-				position, thunkPosition = position154, thunkPosition154
+				goto l176
+			l175:
+				position, thunkPosition = position175, thunkPosition175
 			}
+		l176:
 			if !p.rules[ruleLine]() {
-				goto l153
+				goto l172
 			}
-			do(33)
-		l155:
+			do(32)
+		l177:
 			{
-				position156, thunkPosition156 := position, thunkPosition
-				if !p.rules[ruleListBlockLine]() {
-					goto l156
+				position178, thunkPosition178 := position, thunkPosition
+				if peekChar('>') {
+					goto l178
+				}
+				{
+					position179, thunkPosition179 := position, thunkPosition
+					if !p.rules[ruleBlankLine]() {
+						goto l179
+					}
+					goto l178
+				l179:
+					position, thunkPosition = position179, thunkPosition179
+				}
+				if !p.rules[ruleLine]() {
+					goto l178
+				}
+				do(33)
+				goto l177
+			l178:
+				position, thunkPosition = position178, thunkPosition178
+			}
+		l180:
+			{
+				position181, thunkPosition181 := position, thunkPosition
+				if !p.rules[ruleBlankLine]() {
+					goto l181
 				}
 				do(34)
-				goto l155
-			l156:
-				position, thunkPosition = position156, thunkPosition156
+				goto l180
+			l181:
+				position, thunkPosition = position181, thunkPosition181
+			}
+		l173:
+			{
+				position174, thunkPosition174 := position, thunkPosition
+				if !matchChar('>') {
+					goto l174
+				}
+				{
+					position182, thunkPosition182 := position, thunkPosition
+					if !matchChar(' ') {
+						goto l182
+					}
+					goto l183
+				l182:
+					position, thunkPosition = position182, thunkPosition182
+				}
+			l183:
+				if !p.rules[ruleLine]() {
+					goto l174
+				}
+				do(32)
+			l184:
+				{
+					position185, thunkPosition185 := position, thunkPosition
+					if peekChar('>') {
+						goto l185
+					}
+					{
+						position186, thunkPosition186 := position, thunkPosition
+						if !p.rules[ruleBlankLine]() {
+							goto l186
+						}
+						goto l185
+					l186:
+						position, thunkPosition = position186, thunkPosition186
+					}
+					if !p.rules[ruleLine]() {
+						goto l185
+					}
+					do(33)
+					goto l184
+				l185:
+					position, thunkPosition = position185, thunkPosition185
+				}
+			l187:
+				{
+					position188, thunkPosition188 := position, thunkPosition
+					if !p.rules[ruleBlankLine]() {
+						goto l188
+					}
+					do(34)
+					goto l187
+				l188:
+					position, thunkPosition = position188, thunkPosition188
+				}
+				goto l173
+			l174:
+				position, thunkPosition = position174, thunkPosition174
 			}
 			do(35)
 			doarg(yyPop, 1)
 			return true
-		l153:
+		l172:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 26 ListContinuationBlock <- (StartList (< BlankLine* > {   if len(yytext) == 0 {
-                                   a = cons(mk_str("\001"), a) // block separator
-                              } else {
-                                   a = cons(mk_str(yytext), a)
-                              }
-                          }) (Indent ListBlock { a = cons(yy, a) })+ {  yy = mk_str_from_list(a, false) }) */
+		/* 38 NonblankIndentedLine <- (!BlankLine IndentedLine) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position190, thunkPosition190 := position, thunkPosition
+				if !p.rules[ruleBlankLine]() {
+					goto l190
+				}
+				goto l189
+			l190:
+				position, thunkPosition = position190, thunkPosition190
+			}
+			if !p.rules[ruleIndentedLine]() {
+				goto l189
+			}
+			return true
+		l189:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 39 VerbatimChunk <- (StartList (BlankLine { a = cons(mk_str("\n"), a) })* (NonblankIndentedLine { a = cons(yy, a) })+ { yy = mk_str_from_list(a, false) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			doarg(yyPush, 1)
 			if !p.rules[ruleStartList]() {
-				goto l157
+				goto l191
 			}
 			doarg(yySet, -1)
-			begin = position
-		l158:
+		l192:
 			{
-				position159, thunkPosition159 := position, thunkPosition
+				position193, thunkPosition193 := position, thunkPosition
 				if !p.rules[ruleBlankLine]() {
-					goto l159
+					goto l193
 				}
-				goto l158
-			l159:
-				position, thunkPosition = position159, thunkPosition159
-			}
-			end = position
-			do(36)
-			if !p.rules[ruleIndent]() {
-				goto l157
+				do(36)
+				goto l192
+			l193:
+				position, thunkPosition = position193, thunkPosition193
 			}
-			if !p.rules[ruleListBlock]() {
-				goto l157
+			if !p.rules[ruleNonblankIndentedLine]() {
+				goto l191
 			}
 			do(37)
-		l160:
+		l194:
 			{
-				position161, thunkPosition161 := position, thunkPosition
-				if !p.rules[ruleIndent]() {
-					goto l161
-				}
-				if !p.rules[ruleListBlock]() {
-					goto l161
+				position195, thunkPosition195 := position, thunkPosition
+				if !p.rules[ruleNonblankIndentedLine]() {
+					goto l195
 				}
 				do(37)
-				goto l160
-			l161:
-				position, thunkPosition = position161, thunkPosition161
+				goto l194
+			l195:
+				position, thunkPosition = position195, thunkPosition195
 			}
 			do(38)
 			doarg(yyPop, 1)
 			return true
-		l157:
+		l191:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 27 Enumerator <- (NonindentSpace [0-9]+ '.' Spacechar+) */
+		/* 40 Verbatim <- (StartList (VerbatimChunk { a = cons(yy, a) })+ { yy = mk_str_from_list(a, false)
+                 yy.key = VERBATIM }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleNonindentSpace]() {
-				goto l162
-			}
-			if !matchClass(7) {
-				goto l162
-			}
-		l163:
-			{
-				position164, thunkPosition164 := position, thunkPosition
-				if !matchClass(7) {
-					goto l164
-				}
-				goto l163
-			l164:
-				position, thunkPosition = position164, thunkPosition164
-			}
-			if !matchChar('.') {
-				goto l162
+			doarg(yyPush, 1)
+			if !p.rules[ruleStartList]() {
+				goto l196
 			}
-			if !p.rules[ruleSpacechar]() {
-				goto l162
+			doarg(yySet, -1)
+			if !p.rules[ruleVerbatimChunk]() {
+				goto l196
 			}
-		l165:
+			do(39)
+		l197:
 			{
-				position166, thunkPosition166 := position, thunkPosition
-				if !p.rules[ruleSpacechar]() {
-					goto l166
+				position198, thunkPosition198 := position, thunkPosition
+				if !p.rules[ruleVerbatimChunk]() {
+					goto l198
 				}
-				goto l165
-			l166:
-				position, thunkPosition = position166, thunkPosition166
+				do(39)
+				goto l197
+			l198:
+				position, thunkPosition = position198, thunkPosition198
 			}
+			do(40)
+			doarg(yyPop, 1)
 			return true
-		l162:
+		l196:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 28 OrderedList <- (&Enumerator (ListTight / ListLoose) { yy.key = ORDEREDLIST }) */
+		/* 41 TocMarker <- (&{ p.extension.TOC } NonindentSpace '[TOC]' Sp Newline BlankLine* { yy = mk_element(TOC) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			{
-				position168, thunkPosition168 := position, thunkPosition
-				if !p.rules[ruleEnumerator]() {
-					goto l167
-				}
-				position, thunkPosition = position168, thunkPosition168
+			if !( p.extension.TOC ) {
+				goto l199
 			}
-			{
-				position169, thunkPosition169 := position, thunkPosition
-				if !p.rules[ruleListTight]() {
-					goto l170
-				}
-				goto l169
-			l170:
-				position, thunkPosition = position169, thunkPosition169
-				if !p.rules[ruleListLoose]() {
-					goto l167
+			if !p.rules[ruleNonindentSpace]() {
+				goto l199
+			}
+			if !matchString("[TOC]") {
+				goto l199
+			}
+			if !p.rules[ruleSp]() {
+				goto l199
+			}
+			if !p.rules[ruleNewline]() {
+				goto l199
+			}
+		l200:
+			{
+				position201, thunkPosition201 := position, thunkPosition
+				if !p.rules[ruleBlankLine]() {
+					goto l201
 				}
+				goto l200
+			l201:
+				position, thunkPosition = position201, thunkPosition201
 			}
-		l169:
-			do(39)
+			do(41)
 			return true
-		l167:
+		l199:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 29 ListBlockLine <- (!BlankLine !((Indent? (Bullet / Enumerator)) / DefMarker) !HorizontalRule OptionallyIndentedLine) */
+		/* 42 FenceStart <- (&{ p.extension.FencedCode } NonindentSpace ('```' / '~~~')) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			{
-				position172, thunkPosition172 := position, thunkPosition
-				if !p.rules[ruleBlankLine]() {
-					goto l172
-				}
-				goto l171
-			l172:
-				position, thunkPosition = position172, thunkPosition172
+			if !( p.extension.FencedCode ) {
+				goto l202
 			}
-			{
-				position173, thunkPosition173 := position, thunkPosition
-				{
-					position174, thunkPosition174 := position, thunkPosition
-					{
-						position176, thunkPosition176 := position, thunkPosition
-						if !p.rules[ruleIndent]() {
-							goto l176
-						}
-						goto l177
-					l176:
-						position, thunkPosition = position176, thunkPosition176
-					}
-				l177:
-					{
-						position178, thunkPosition178 := position, thunkPosition
-						if !p.rules[ruleBullet]() {
-							goto l179
-						}
-						goto l178
-					l179:
-						position, thunkPosition = position178, thunkPosition178
-						if !p.rules[ruleEnumerator]() {
-							goto l175
-						}
-					}
-				l178:
-					goto l174
-				l175:
-					position, thunkPosition = position174, thunkPosition174
-					if !p.rules[ruleDefMarker]() {
-						goto l173
-					}
-				}
-			l174:
-				goto l171
-			l173:
-				position, thunkPosition = position173, thunkPosition173
+			if !p.rules[ruleNonindentSpace]() {
+				goto l202
 			}
 			{
-				position180, thunkPosition180 := position, thunkPosition
-				if !p.rules[ruleHorizontalRule]() {
-					goto l180
+				position203, thunkPosition203 := position, thunkPosition
+				if !matchString("```") {
+					goto l204
+				}
+				goto l203
+			l204:
+				position, thunkPosition = position203, thunkPosition203
+				if !matchString("~~~") {
+					goto l202
 				}
-				goto l171
-			l180:
-				position, thunkPosition = position180, thunkPosition180
-			}
-			if !p.rules[ruleOptionallyIndentedLine]() {
-				goto l171
 			}
+		l203:
 			return true
-		l171:
+		l202:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 30 HtmlBlockOpenAddress <- ('<' Spnl ('address' / 'ADDRESS') Spnl HtmlAttribute* '>') */
+		/* 43 FencedCode <- (&{ p.extension.FencedCode } (FencedCodeTicks5 / FencedCodeTicks4 / FencedCodeTicks3 / FencedCodeTildes5 / FencedCodeTildes4 / FencedCodeTildes3)) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l181
-			}
-			if !p.rules[ruleSpnl]() {
-				goto l181
+			if !( p.extension.FencedCode ) {
+				goto l205
 			}
 			{
-				position182, thunkPosition182 := position, thunkPosition
-				if !matchString("address") {
-					goto l183
+				position206, thunkPosition206 := position, thunkPosition
+				if !p.rules[ruleFencedCodeTicks5]() {
+					goto l207
 				}
-				goto l182
-			l183:
-				position, thunkPosition = position182, thunkPosition182
-				if !matchString("ADDRESS") {
-					goto l181
+				goto l206
+			l207:
+				position, thunkPosition = position206, thunkPosition206
+				if !p.rules[ruleFencedCodeTicks4]() {
+					goto l208
 				}
-			}
-		l182:
-			if !p.rules[ruleSpnl]() {
-				goto l181
-			}
-		l184:
-			{
-				position185, thunkPosition185 := position, thunkPosition
-				if !p.rules[ruleHtmlAttribute]() {
-					goto l185
+				goto l206
+			l208:
+				position, thunkPosition = position206, thunkPosition206
+				if !p.rules[ruleFencedCodeTicks3]() {
+					goto l209
+				}
+				goto l206
+			l209:
+				position, thunkPosition = position206, thunkPosition206
+				if !p.rules[ruleFencedCodeTildes5]() {
+					goto l210
+				}
+				goto l206
+			l210:
+				position, thunkPosition = position206, thunkPosition206
+				if !p.rules[ruleFencedCodeTildes4]() {
+					goto l211
+				}
+				goto l206
+			l211:
+				position, thunkPosition = position206, thunkPosition206
+				if !p.rules[ruleFencedCodeTildes3]() {
+					goto l205
 				}
-				goto l184
-			l185:
-				position, thunkPosition = position185, thunkPosition185
-			}
-			if !matchChar('>') {
-				goto l181
 			}
+		l206:
 			return true
-		l181:
+		l205:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 31 HtmlBlockCloseAddress <- ('<' Spnl '/' ('address' / 'ADDRESS') Spnl '>') */
+		/* 44 TicksInfo <- (Sp < (!'`' !Newline .)* > Newline { yy = mk_str(yytext) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l186
-			}
-			if !p.rules[ruleSpnl]() {
-				goto l186
-			}
-			if !matchChar('/') {
-				goto l186
+			if !p.rules[ruleSp]() {
+				goto l212
 			}
+			begin = position
+		l213:
 			{
-				position187, thunkPosition187 := position, thunkPosition
-				if !matchString("address") {
-					goto l188
+				position214, thunkPosition214 := position, thunkPosition
+				if peekChar('`') {
+					goto l214
 				}
-				goto l187
-			l188:
-				position, thunkPosition = position187, thunkPosition187
-				if !matchString("ADDRESS") {
-					goto l186
+				{
+					position215, thunkPosition215 := position, thunkPosition
+					if !p.rules[ruleNewline]() {
+						goto l215
+					}
+					goto l214
+				l215:
+					position, thunkPosition = position215, thunkPosition215
 				}
+				if !matchDot() {
+					goto l214
+				}
+				goto l213
+			l214:
+				position, thunkPosition = position214, thunkPosition214
 			}
-		l187:
-			if !p.rules[ruleSpnl]() {
-				goto l186
-			}
-			if !matchChar('>') {
-				goto l186
+			end = position
+			if !p.rules[ruleNewline]() {
+				goto l212
 			}
+			do(42)
 			return true
-		l186:
+		l212:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 32 HtmlBlockAddress <- (HtmlBlockOpenAddress (HtmlBlockAddress / (!HtmlBlockCloseAddress .))* HtmlBlockCloseAddress) */
+		/* 45 TildesInfo <- (Sp < (!Newline .)* > Newline { yy = mk_str(yytext) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenAddress]() {
-				goto l189
+			if !p.rules[ruleSp]() {
+				goto l216
 			}
-		l190:
+			begin = position
+		l217:
 			{
-				position191, thunkPosition191 := position, thunkPosition
+				position218, thunkPosition218 := position, thunkPosition
 				{
-					position192, thunkPosition192 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockAddress]() {
-						goto l193
-					}
-					goto l192
-				l193:
-					position, thunkPosition = position192, thunkPosition192
-					{
-						position194, thunkPosition194 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseAddress]() {
-							goto l194
-						}
-						goto l191
-					l194:
-						position, thunkPosition = position194, thunkPosition194
-					}
-					if !matchDot() {
-						goto l191
+					position219, thunkPosition219 := position, thunkPosition
+					if !p.rules[ruleNewline]() {
+						goto l219
 					}
+					goto l218
+				l219:
+					position, thunkPosition = position219, thunkPosition219
 				}
-			l192:
-				goto l190
-			l191:
-				position, thunkPosition = position191, thunkPosition191
+				if !matchDot() {
+					goto l218
+				}
+				goto l217
+			l218:
+				position, thunkPosition = position218, thunkPosition218
 			}
-			if !p.rules[ruleHtmlBlockCloseAddress]() {
-				goto l189
+			end = position
+			if !p.rules[ruleNewline]() {
+				goto l216
 			}
+			do(43)
 			return true
-		l189:
+		l216:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 33 HtmlBlockOpenBlockquote <- ('<' Spnl ('blockquote' / 'BLOCKQUOTE') Spnl HtmlAttribute* '>') */
+		/* 46 FenceEof <- (BlankLine* Eof) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l195
-			}
-			if !p.rules[ruleSpnl]() {
-				goto l195
-			}
+		l221:
 			{
-				position196, thunkPosition196 := position, thunkPosition
-				if !matchString("blockquote") {
-					goto l197
-				}
-				goto l196
-			l197:
-				position, thunkPosition = position196, thunkPosition196
-				if !matchString("BLOCKQUOTE") {
-					goto l195
+				position222, thunkPosition222 := position, thunkPosition
+				if !p.rules[ruleBlankLine]() {
+					goto l222
 				}
+				goto l221
+			l222:
+				position, thunkPosition = position222, thunkPosition222
 			}
-		l196:
-			if !p.rules[ruleSpnl]() {
-				goto l195
+			if !p.rules[ruleEof]() {
+				goto l220
+			}
+			return true
+		l220:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 47 TicksClose3 <- (NonindentSpace '```' '`'* Sp Newline) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !p.rules[ruleNonindentSpace]() {
+				goto l223
+			}
+			if !matchString("```") {
+				goto l223
 			}
-		l198:
+		l224:
 			{
-				position199, thunkPosition199 := position, thunkPosition
-				if !p.rules[ruleHtmlAttribute]() {
-					goto l199
+				position225, thunkPosition225 := position, thunkPosition
+				if !matchChar('`') {
+					goto l225
 				}
-				goto l198
-			l199:
-				position, thunkPosition = position199, thunkPosition199
+				goto l224
+			l225:
+				position, thunkPosition = position225, thunkPosition225
 			}
-			if !matchChar('>') {
-				goto l195
+			if !p.rules[ruleSp]() {
+				goto l223
+			}
+			if !p.rules[ruleNewline]() {
+				goto l223
 			}
 			return true
-		l195:
+		l223:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 34 HtmlBlockCloseBlockquote <- ('<' Spnl '/' ('blockquote' / 'BLOCKQUOTE') Spnl '>') */
+		/* 48 TicksClose4 <- (NonindentSpace '````' '`'* Sp Newline) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l200
-			}
-			if !p.rules[ruleSpnl]() {
-				goto l200
+			if !p.rules[ruleNonindentSpace]() {
+				goto l226
 			}
-			if !matchChar('/') {
-				goto l200
+			if !matchString("````") {
+				goto l226
 			}
+		l227:
 			{
-				position201, thunkPosition201 := position, thunkPosition
-				if !matchString("blockquote") {
-					goto l202
-				}
-				goto l201
-			l202:
-				position, thunkPosition = position201, thunkPosition201
-				if !matchString("BLOCKQUOTE") {
-					goto l200
+				position228, thunkPosition228 := position, thunkPosition
+				if !matchChar('`') {
+					goto l228
 				}
+				goto l227
+			l228:
+				position, thunkPosition = position228, thunkPosition228
 			}
-		l201:
-			if !p.rules[ruleSpnl]() {
-				goto l200
+			if !p.rules[ruleSp]() {
+				goto l226
 			}
-			if !matchChar('>') {
-				goto l200
+			if !p.rules[ruleNewline]() {
+				goto l226
 			}
 			return true
-		l200:
+		l226:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 35 HtmlBlockBlockquote <- (HtmlBlockOpenBlockquote (HtmlBlockBlockquote / (!HtmlBlockCloseBlockquote .))* HtmlBlockCloseBlockquote) */
+		/* 49 TicksClose5 <- (NonindentSpace '`````' '`'* Sp Newline) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenBlockquote]() {
-				goto l203
+			if !p.rules[ruleNonindentSpace]() {
+				goto l229
+			}
+			if !matchString("`````") {
+				goto l229
 			}
-		l204:
+		l230:
 			{
-				position205, thunkPosition205 := position, thunkPosition
-				{
-					position206, thunkPosition206 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockBlockquote]() {
-						goto l207
-					}
-					goto l206
-				l207:
-					position, thunkPosition = position206, thunkPosition206
-					{
-						position208, thunkPosition208 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseBlockquote]() {
-							goto l208
-						}
-						goto l205
-					l208:
-						position, thunkPosition = position208, thunkPosition208
-					}
-					if !matchDot() {
-						goto l205
-					}
+				position231, thunkPosition231 := position, thunkPosition
+				if !matchChar('`') {
+					goto l231
 				}
-			l206:
-				goto l204
-			l205:
-				position, thunkPosition = position205, thunkPosition205
+				goto l230
+			l231:
+				position, thunkPosition = position231, thunkPosition231
 			}
-			if !p.rules[ruleHtmlBlockCloseBlockquote]() {
-				goto l203
+			if !p.rules[ruleSp]() {
+				goto l229
+			}
+			if !p.rules[ruleNewline]() {
+				goto l229
 			}
 			return true
-		l203:
+		l229:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 36 HtmlBlockOpenCenter <- ('<' Spnl ('center' / 'CENTER') Spnl HtmlAttribute* '>') */
+		/* 50 TildesClose3 <- (NonindentSpace '~~~' '~'* Sp Newline) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l209
+			if !p.rules[ruleNonindentSpace]() {
+				goto l232
 			}
-			if !p.rules[ruleSpnl]() {
-				goto l209
+			if !matchString("~~~") {
+				goto l232
 			}
+		l233:
 			{
-				position210, thunkPosition210 := position, thunkPosition
-				if !matchString("center") {
-					goto l211
-				}
-				goto l210
-			l211:
-				position, thunkPosition = position210, thunkPosition210
-				if !matchString("CENTER") {
-					goto l209
+				position234, thunkPosition234 := position, thunkPosition
+				if !matchChar('~') {
+					goto l234
 				}
+				goto l233
+			l234:
+				position, thunkPosition = position234, thunkPosition234
 			}
-		l210:
-			if !p.rules[ruleSpnl]() {
-				goto l209
-			}
-		l212:
-			{
-				position213, thunkPosition213 := position, thunkPosition
-				if !p.rules[ruleHtmlAttribute]() {
-					goto l213
-				}
-				goto l212
-			l213:
-				position, thunkPosition = position213, thunkPosition213
+			if !p.rules[ruleSp]() {
+				goto l232
 			}
-			if !matchChar('>') {
-				goto l209
+			if !p.rules[ruleNewline]() {
+				goto l232
 			}
 			return true
-		l209:
+		l232:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 37 HtmlBlockCloseCenter <- ('<' Spnl '/' ('center' / 'CENTER') Spnl '>') */
+		/* 51 TildesClose4 <- (NonindentSpace '~~~~' '~'* Sp Newline) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l214
-			}
-			if !p.rules[ruleSpnl]() {
-				goto l214
+			if !p.rules[ruleNonindentSpace]() {
+				goto l235
 			}
-			if !matchChar('/') {
-				goto l214
+			if !matchString("~~~~") {
+				goto l235
 			}
+		l236:
 			{
-				position215, thunkPosition215 := position, thunkPosition
-				if !matchString("center") {
-					goto l216
-				}
-				goto l215
-			l216:
-				position, thunkPosition = position215, thunkPosition215
-				if !matchString("CENTER") {
-					goto l214
+				position237, thunkPosition237 := position, thunkPosition
+				if !matchChar('~') {
+					goto l237
 				}
+				goto l236
+			l237:
+				position, thunkPosition = position237, thunkPosition237
 			}
-		l215:
-			if !p.rules[ruleSpnl]() {
-				goto l214
+			if !p.rules[ruleSp]() {
+				goto l235
 			}
-			if !matchChar('>') {
-				goto l214
+			if !p.rules[ruleNewline]() {
+				goto l235
 			}
 			return true
-		l214:
+		l235:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 38 HtmlBlockCenter <- (HtmlBlockOpenCenter (HtmlBlockCenter / (!HtmlBlockCloseCenter .))* HtmlBlockCloseCenter) */
+		/* 52 TildesClose5 <- (NonindentSpace '~~~~~' '~'* Sp Newline) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenCenter]() {
-				goto l217
+			if !p.rules[ruleNonindentSpace]() {
+				goto l238
 			}
-		l218:
-			{
-				position219, thunkPosition219 := position, thunkPosition
-				{
-					position220, thunkPosition220 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockCenter]() {
-						goto l221
-					}
-					goto l220
-				l221:
-					position, thunkPosition = position220, thunkPosition220
-					{
-						position222, thunkPosition222 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseCenter]() {
-							goto l222
-						}
-						goto l219
-					l222:
-						position, thunkPosition = position222, thunkPosition222
-					}
-					if !matchDot() {
-						goto l219
-					}
+			if !matchString("~~~~~") {
+				goto l238
+			}
+		l239:
+			{
+				position240, thunkPosition240 := position, thunkPosition
+				if !matchChar('~') {
+					goto l240
 				}
-			l220:
-				goto l218
-			l219:
-				position, thunkPosition = position219, thunkPosition219
+				goto l239
+			l240:
+				position, thunkPosition = position240, thunkPosition240
 			}
-			if !p.rules[ruleHtmlBlockCloseCenter]() {
-				goto l217
+			if !p.rules[ruleSp]() {
+				goto l238
+			}
+			if !p.rules[ruleNewline]() {
+				goto l238
 			}
 			return true
-		l217:
+		l238:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 39 HtmlBlockOpenDir <- ('<' Spnl ('dir' / 'DIR') Spnl HtmlAttribute* '>') */
+		/* 53 FencedCodeTicks3 <- (NonindentSpace '```' !'`' TicksInfo StartList (!TicksClose3 !FenceEof Line { a = cons(yy, a) })* ((TicksClose3 { yy = p.fenced(i, a) }) / (FenceEof { yy = p.fenced(i, a); p.warn(yy, "unterminated fenced code block") }))) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l223
+			doarg(yyPush, 2)
+			if !p.rules[ruleNonindentSpace]() {
+				goto l241
 			}
-			if !p.rules[ruleSpnl]() {
-				goto l223
+			if !matchString("```") {
+				goto l241
+			}
+			if peekChar('`') {
+				goto l241
+			}
+			if !p.rules[ruleTicksInfo]() {
+				goto l241
+			}
+			doarg(yySet, -1)
+			if !p.rules[ruleStartList]() {
+				goto l241
 			}
+			doarg(yySet, -2)
+		l242:
 			{
-				position224, thunkPosition224 := position, thunkPosition
-				if !matchString("dir") {
-					goto l225
+				position243, thunkPosition243 := position, thunkPosition
+				{
+					position244, thunkPosition244 := position, thunkPosition
+					if !p.rules[ruleTicksClose3]() {
+						goto l244
+					}
+					goto l243
+				l244:
+					position, thunkPosition = position244, thunkPosition244
 				}
-				goto l224
-			l225:
-				position, thunkPosition = position224, thunkPosition224
-				if !matchString("DIR") {
-					goto l223
+				{
+					position245, thunkPosition245 := position, thunkPosition
+					if !p.rules[ruleFenceEof]() {
+						goto l245
+					}
+					goto l243
+				l245:
+					position, thunkPosition = position245, thunkPosition245
 				}
+				if !p.rules[ruleLine]() {
+					goto l243
+				}
+				do(44)
+				goto l242
+			l243:
+				position, thunkPosition = position243, thunkPosition243
 			}
-		l224:
-			if !p.rules[ruleSpnl]() {
-				goto l223
-			}
-		l226:
 			{
-				position227, thunkPosition227 := position, thunkPosition
-				if !p.rules[ruleHtmlAttribute]() {
-					goto l227
+				position246, thunkPosition246 := position, thunkPosition
+				if !p.rules[ruleTicksClose3]() {
+					goto l247
 				}
-				goto l226
-			l227:
-				position, thunkPosition = position227, thunkPosition227
-			}
-			if !matchChar('>') {
-				goto l223
+				do(45)
+				goto l246
+			l247:
+				position, thunkPosition = position246, thunkPosition246
+				if !p.rules[ruleFenceEof]() {
+					goto l241
+				}
+				do(46)
 			}
+		l246:
+			doarg(yyPop, 2)
 			return true
-		l223:
+		l241:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 40 HtmlBlockCloseDir <- ('<' Spnl '/' ('dir' / 'DIR') Spnl '>') */
+		/* 54 FencedCodeTicks4 <- (NonindentSpace '````' !'`' TicksInfo StartList (!TicksClose4 !FenceEof Line { a = cons(yy, a) })* ((TicksClose4 { yy = p.fenced(i, a) }) / (FenceEof { yy = p.fenced(i, a); p.warn(yy, "unterminated fenced code block") }))) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l228
-			}
-			if !p.rules[ruleSpnl]() {
-				goto l228
-			}
-			if !matchChar('/') {
-				goto l228
+			doarg(yyPush, 2)
+			if !p.rules[ruleNonindentSpace]() {
+				goto l248
 			}
-			{
-				position229, thunkPosition229 := position, thunkPosition
-				if !matchString("dir") {
-					goto l230
-				}
-				goto l229
-			l230:
-				position, thunkPosition = position229, thunkPosition229
-				if !matchString("DIR") {
-					goto l228
-				}
+			if !matchString("````") {
+				goto l248
 			}
-		l229:
-			if !p.rules[ruleSpnl]() {
-				goto l228
+			if peekChar('`') {
+				goto l248
 			}
-			if !matchChar('>') {
-				goto l228
+			if !p.rules[ruleTicksInfo]() {
+				goto l248
 			}
-			return true
-		l228:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 41 HtmlBlockDir <- (HtmlBlockOpenDir (HtmlBlockDir / (!HtmlBlockCloseDir .))* HtmlBlockCloseDir) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenDir]() {
-				goto l231
+			doarg(yySet, -1)
+			if !p.rules[ruleStartList]() {
+				goto l248
 			}
-		l232:
+			doarg(yySet, -2)
+		l249:
 			{
-				position233, thunkPosition233 := position, thunkPosition
+				position250, thunkPosition250 := position, thunkPosition
 				{
-					position234, thunkPosition234 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockDir]() {
-						goto l235
-					}
-					goto l234
-				l235:
-					position, thunkPosition = position234, thunkPosition234
-					{
-						position236, thunkPosition236 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseDir]() {
-							goto l236
-						}
-						goto l233
-					l236:
-						position, thunkPosition = position236, thunkPosition236
+					position251, thunkPosition251 := position, thunkPosition
+					if !p.rules[ruleTicksClose4]() {
+						goto l251
 					}
-					if !matchDot() {
-						goto l233
+					goto l250
+				l251:
+					position, thunkPosition = position251, thunkPosition251
+				}
+				{
+					position252, thunkPosition252 := position, thunkPosition
+					if !p.rules[ruleFenceEof]() {
+						goto l252
 					}
+					goto l250
+				l252:
+					position, thunkPosition = position252, thunkPosition252
 				}
-			l234:
-				goto l232
-			l233:
-				position, thunkPosition = position233, thunkPosition233
+				if !p.rules[ruleLine]() {
+					goto l250
+				}
+				do(47)
+				goto l249
+			l250:
+				position, thunkPosition = position250, thunkPosition250
 			}
-			if !p.rules[ruleHtmlBlockCloseDir]() {
-				goto l231
+			{
+				position253, thunkPosition253 := position, thunkPosition
+				if !p.rules[ruleTicksClose4]() {
+					goto l254
+				}
+				do(48)
+				goto l253
+			l254:
+				position, thunkPosition = position253, thunkPosition253
+				if !p.rules[ruleFenceEof]() {
+					goto l248
+				}
+				do(49)
 			}
+		l253:
+			doarg(yyPop, 2)
 			return true
-		l231:
+		l248:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 42 HtmlBlockOpenDiv <- ('<' Spnl ('div' / 'DIV') Spnl HtmlAttribute* '>') */
+		/* 55 FencedCodeTicks5 <- (NonindentSpace '`````' '`'* TicksInfo StartList (!TicksClose5 !FenceEof Line { a = cons(yy, a) })* ((TicksClose5 { yy = p.fenced(i, a) }) / (FenceEof { yy = p.fenced(i, a); p.warn(yy, "unterminated fenced code block") }))) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l237
+			doarg(yyPush, 2)
+			if !p.rules[ruleNonindentSpace]() {
+				goto l255
 			}
-			if !p.rules[ruleSpnl]() {
-				goto l237
+			if !matchString("`````") {
+				goto l255
 			}
+		l256:
 			{
-				position238, thunkPosition238 := position, thunkPosition
-				if !matchString("div") {
-					goto l239
-				}
-				goto l238
-			l239:
-				position, thunkPosition = position238, thunkPosition238
-				if !matchString("DIV") {
-					goto l237
+				position257, thunkPosition257 := position, thunkPosition
+				if !matchChar('`') {
+					goto l257
 				}
+				goto l256
+			l257:
+				position, thunkPosition = position257, thunkPosition257
 			}
-		l238:
-			if !p.rules[ruleSpnl]() {
-				goto l237
+			if !p.rules[ruleTicksInfo]() {
+				goto l255
 			}
-		l240:
+			doarg(yySet, -1)
+			if !p.rules[ruleStartList]() {
+				goto l255
+			}
+			doarg(yySet, -2)
+		l258:
 			{
-				position241, thunkPosition241 := position, thunkPosition
-				if !p.rules[ruleHtmlAttribute]() {
-					goto l241
+				position259, thunkPosition259 := position, thunkPosition
+				{
+					position260, thunkPosition260 := position, thunkPosition
+					if !p.rules[ruleTicksClose5]() {
+						goto l260
+					}
+					goto l259
+				l260:
+					position, thunkPosition = position260, thunkPosition260
 				}
-				goto l240
-			l241:
-				position, thunkPosition = position241, thunkPosition241
+				{
+					position261, thunkPosition261 := position, thunkPosition
+					if !p.rules[ruleFenceEof]() {
+						goto l261
+					}
+					goto l259
+				l261:
+					position, thunkPosition = position261, thunkPosition261
+				}
+				if !p.rules[ruleLine]() {
+					goto l259
+				}
+				do(50)
+				goto l258
+			l259:
+				position, thunkPosition = position259, thunkPosition259
 			}
-			if !matchChar('>') {
-				goto l237
+			{
+				position262, thunkPosition262 := position, thunkPosition
+				if !p.rules[ruleTicksClose5]() {
+					goto l263
+				}
+				do(51)
+				goto l262
+			l263:
+				position, thunkPosition = position262, thunkPosition262
+				if !p.rules[ruleFenceEof]() {
+					goto l255
+				}
+				do(52)
 			}
+		l262:
+			doarg(yyPop, 2)
 			return true
-		l237:
+		l255:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 43 HtmlBlockCloseDiv <- ('<' Spnl '/' ('div' / 'DIV') Spnl '>') */
+		/* 56 FencedCodeTildes3 <- (NonindentSpace '~~~' !'~' TildesInfo StartList (!TildesClose3 !FenceEof Line { a = cons(yy, a) })* ((TildesClose3 { yy = p.fenced(i, a) }) / (FenceEof { yy = p.fenced(i, a); p.warn(yy, "unterminated fenced code block") }))) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l242
+			doarg(yyPush, 2)
+			if !p.rules[ruleNonindentSpace]() {
+				goto l264
 			}
-			if !p.rules[ruleSpnl]() {
-				goto l242
+			if !matchString("~~~") {
+				goto l264
 			}
-			if !matchChar('/') {
-				goto l242
+			if peekChar('~') {
+				goto l264
+			}
+			if !p.rules[ruleTildesInfo]() {
+				goto l264
+			}
+			doarg(yySet, -1)
+			if !p.rules[ruleStartList]() {
+				goto l264
 			}
+			doarg(yySet, -2)
+		l265:
 			{
-				position243, thunkPosition243 := position, thunkPosition
-				if !matchString("div") {
-					goto l244
+				position266, thunkPosition266 := position, thunkPosition
+				{
+					position267, thunkPosition267 := position, thunkPosition
+					if !p.rules[ruleTildesClose3]() {
+						goto l267
+					}
+					goto l266
+				l267:
+					position, thunkPosition = position267, thunkPosition267
 				}
-				goto l243
-			l244:
-				position, thunkPosition = position243, thunkPosition243
-				if !matchString("DIV") {
-					goto l242
+				{
+					position268, thunkPosition268 := position, thunkPosition
+					if !p.rules[ruleFenceEof]() {
+						goto l268
+					}
+					goto l266
+				l268:
+					position, thunkPosition = position268, thunkPosition268
 				}
+				if !p.rules[ruleLine]() {
+					goto l266
+				}
+				do(53)
+				goto l265
+			l266:
+				position, thunkPosition = position266, thunkPosition266
 			}
-		l243:
-			if !p.rules[ruleSpnl]() {
-				goto l242
-			}
-			if !matchChar('>') {
-				goto l242
+			{
+				position269, thunkPosition269 := position, thunkPosition
+				if !p.rules[ruleTildesClose3]() {
+					goto l270
+				}
+				do(54)
+				goto l269
+			l270:
+				position, thunkPosition = position269, thunkPosition269
+				if !p.rules[ruleFenceEof]() {
+					goto l264
+				}
+				do(55)
 			}
+		l269:
+			doarg(yyPop, 2)
 			return true
-		l242:
+		l264:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 44 HtmlBlockDiv <- (HtmlBlockOpenDiv (HtmlBlockDiv / (!HtmlBlockCloseDiv .))* HtmlBlockCloseDiv) */
+		/* 57 FencedCodeTildes4 <- (NonindentSpace '~~~~' !'~' TildesInfo StartList (!TildesClose4 !FenceEof Line { a = cons(yy, a) })* ((TildesClose4 { yy = p.fenced(i, a) }) / (FenceEof { yy = p.fenced(i, a); p.warn(yy, "unterminated fenced code block") }))) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenDiv]() {
-				goto l245
+			doarg(yyPush, 2)
+			if !p.rules[ruleNonindentSpace]() {
+				goto l271
 			}
-		l246:
+			if !matchString("~~~~") {
+				goto l271
+			}
+			if peekChar('~') {
+				goto l271
+			}
+			if !p.rules[ruleTildesInfo]() {
+				goto l271
+			}
+			doarg(yySet, -1)
+			if !p.rules[ruleStartList]() {
+				goto l271
+			}
+			doarg(yySet, -2)
+		l272:
 			{
-				position247, thunkPosition247 := position, thunkPosition
+				position273, thunkPosition273 := position, thunkPosition
 				{
-					position248, thunkPosition248 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockDiv]() {
-						goto l249
-					}
-					goto l248
-				l249:
-					position, thunkPosition = position248, thunkPosition248
-					{
-						position250, thunkPosition250 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseDiv]() {
-							goto l250
-						}
-						goto l247
-					l250:
-						position, thunkPosition = position250, thunkPosition250
+					position274, thunkPosition274 := position, thunkPosition
+					if !p.rules[ruleTildesClose4]() {
+						goto l274
 					}
-					if !matchDot() {
-						goto l247
+					goto l273
+				l274:
+					position, thunkPosition = position274, thunkPosition274
+				}
+				{
+					position275, thunkPosition275 := position, thunkPosition
+					if !p.rules[ruleFenceEof]() {
+						goto l275
 					}
+					goto l273
+				l275:
+					position, thunkPosition = position275, thunkPosition275
 				}
-			l248:
-				goto l246
-			l247:
-				position, thunkPosition = position247, thunkPosition247
+				if !p.rules[ruleLine]() {
+					goto l273
+				}
+				do(56)
+				goto l272
+			l273:
+				position, thunkPosition = position273, thunkPosition273
 			}
-			if !p.rules[ruleHtmlBlockCloseDiv]() {
-				goto l245
+			{
+				position276, thunkPosition276 := position, thunkPosition
+				if !p.rules[ruleTildesClose4]() {
+					goto l277
+				}
+				do(57)
+				goto l276
+			l277:
+				position, thunkPosition = position276, thunkPosition276
+				if !p.rules[ruleFenceEof]() {
+					goto l271
+				}
+				do(58)
 			}
+		l276:
+			doarg(yyPop, 2)
 			return true
-		l245:
+		l271:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 45 HtmlBlockOpenDl <- ('<' Spnl ('dl' / 'DL') Spnl HtmlAttribute* '>') */
+		/* 58 FencedCodeTildes5 <- (NonindentSpace '~~~~~' '~'* TildesInfo StartList (!TildesClose5 !FenceEof Line { a = cons(yy, a) })* ((TildesClose5 { yy = p.fenced(i, a) }) / (FenceEof { yy = p.fenced(i, a); p.warn(yy, "unterminated fenced code block") }))) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l251
+			doarg(yyPush, 2)
+			if !p.rules[ruleNonindentSpace]() {
+				goto l278
 			}
-			if !p.rules[ruleSpnl]() {
-				goto l251
+			if !matchString("~~~~~") {
+				goto l278
 			}
+		l279:
 			{
-				position252, thunkPosition252 := position, thunkPosition
-				if !matchString("dl") {
-					goto l253
-				}
-				goto l252
-			l253:
-				position, thunkPosition = position252, thunkPosition252
-				if !matchString("DL") {
-					goto l251
+				position280, thunkPosition280 := position, thunkPosition
+				if !matchChar('~') {
+					goto l280
 				}
+				goto l279
+			l280:
+				position, thunkPosition = position280, thunkPosition280
 			}
-		l252:
-			if !p.rules[ruleSpnl]() {
-				goto l251
+			if !p.rules[ruleTildesInfo]() {
+				goto l278
+			}
+			doarg(yySet, -1)
+			if !p.rules[ruleStartList]() {
+				goto l278
 			}
-		l254:
+			doarg(yySet, -2)
+		l281:
 			{
-				position255, thunkPosition255 := position, thunkPosition
-				if !p.rules[ruleHtmlAttribute]() {
-					goto l255
+				position282, thunkPosition282 := position, thunkPosition
+				{
+					position283, thunkPosition283 := position, thunkPosition
+					if !p.rules[ruleTildesClose5]() {
+						goto l283
+					}
+					goto l282
+				l283:
+					position, thunkPosition = position283, thunkPosition283
 				}
-				goto l254
-			l255:
-				position, thunkPosition = position255, thunkPosition255
-			}
-			if !matchChar('>') {
-				goto l251
-			}
-			return true
-		l251:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 46 HtmlBlockCloseDl <- ('<' Spnl '/' ('dl' / 'DL') Spnl '>') */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l256
-			}
-			if !p.rules[ruleSpnl]() {
-				goto l256
-			}
-			if !matchChar('/') {
-				goto l256
+				{
+					position284, thunkPosition284 := position, thunkPosition
+					if !p.rules[ruleFenceEof]() {
+						goto l284
+					}
+					goto l282
+				l284:
+					position, thunkPosition = position284, thunkPosition284
+				}
+				if !p.rules[ruleLine]() {
+					goto l282
+				}
+				do(59)
+				goto l281
+			l282:
+				position, thunkPosition = position282, thunkPosition282
 			}
 			{
-				position257, thunkPosition257 := position, thunkPosition
-				if !matchString("dl") {
-					goto l258
+				position285, thunkPosition285 := position, thunkPosition
+				if !p.rules[ruleTildesClose5]() {
+					goto l286
 				}
-				goto l257
-			l258:
-				position, thunkPosition = position257, thunkPosition257
-				if !matchString("DL") {
-					goto l256
+				do(60)
+				goto l285
+			l286:
+				position, thunkPosition = position285, thunkPosition285
+				if !p.rules[ruleFenceEof]() {
+					goto l278
 				}
+				do(61)
 			}
-		l257:
-			if !p.rules[ruleSpnl]() {
-				goto l256
-			}
-			if !matchChar('>') {
-				goto l256
-			}
+		l285:
+			doarg(yyPop, 2)
 			return true
-		l256:
+		l278:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 47 HtmlBlockDl <- (HtmlBlockOpenDl (HtmlBlockDl / (!HtmlBlockCloseDl .))* HtmlBlockCloseDl) */
+		/* 59 HorizontalRule <- (NonindentSpace (('*' Sp '*' Sp '*' (Sp '*')*) / ('-' Sp '-' Sp '-' (Sp '-')*) / ('_' Sp '_' Sp '_' (Sp '_')*)) Sp Newline BlankLine+ { yy = mk_element(HRULE) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenDl]() {
-				goto l259
+			if !p.rules[ruleNonindentSpace]() {
+				goto l287
 			}
-		l260:
 			{
-				position261, thunkPosition261 := position, thunkPosition
+				position288, thunkPosition288 := position, thunkPosition
+				if !matchChar('*') {
+					goto l289
+				}
+				if !p.rules[ruleSp]() {
+					goto l289
+				}
+				if !matchChar('*') {
+					goto l289
+				}
+				if !p.rules[ruleSp]() {
+					goto l289
+				}
+				if !matchChar('*') {
+					goto l289
+				}
+			l290:
 				{
-					position262, thunkPosition262 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockDl]() {
-						goto l263
+					position291, thunkPosition291 := position, thunkPosition
+					if !p.rules[ruleSp]() {
+						goto l291
 					}
-					goto l262
-				l263:
-					position, thunkPosition = position262, thunkPosition262
-					{
-						position264, thunkPosition264 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseDl]() {
-							goto l264
-						}
-						goto l261
-					l264:
-						position, thunkPosition = position264, thunkPosition264
+					if !matchChar('*') {
+						goto l291
 					}
-					if !matchDot() {
-						goto l261
+					goto l290
+				l291:
+					position, thunkPosition = position291, thunkPosition291
+				}
+				goto l288
+			l289:
+				position, thunkPosition = position288, thunkPosition288
+				if !matchChar('-') {
+					goto l292
+				}
+				if !p.rules[ruleSp]() {
+					goto l292
+				}
+				if !matchChar('-') {
+					goto l292
+				}
+				if !p.rules[ruleSp]() {
+					goto l292
+				}
+				if !matchChar('-') {
+					goto l292
+				}
+			l293:
+				{
+					position294, thunkPosition294 := position, thunkPosition
+					if !p.rules[ruleSp]() {
+						goto l294
+					}
+					if !matchChar('-') {
+						goto l294
+					}
+					goto l293
+				l294:
+					position, thunkPosition = position294, thunkPosition294
+				}
+				goto l288
+			l292:
+				position, thunkPosition = position288, thunkPosition288
+				if !matchChar('_') {
+					goto l287
+				}
+				if !p.rules[ruleSp]() {
+					goto l287
+				}
+				if !matchChar('_') {
+					goto l287
+				}
+				if !p.rules[ruleSp]() {
+					goto l287
+				}
+				if !matchChar('_') {
+					goto l287
+				}
+			l295:
+				{
+					position296, thunkPosition296 := position, thunkPosition
+					if !p.rules[ruleSp]() {
+						goto l296
 					}
+					if !matchChar('_') {
+						goto l296
+					}
+					goto l295
+				l296:
+					position, thunkPosition = position296, thunkPosition296
 				}
-			l262:
-				goto l260
-			l261:
-				position, thunkPosition = position261, thunkPosition261
 			}
-			if !p.rules[ruleHtmlBlockCloseDl]() {
-				goto l259
+		l288:
+			if !p.rules[ruleSp]() {
+				goto l287
+			}
+			if !p.rules[ruleNewline]() {
+				goto l287
+			}
+			if !p.rules[ruleBlankLine]() {
+				goto l287
+			}
+		l297:
+			{
+				position298, thunkPosition298 := position, thunkPosition
+				if !p.rules[ruleBlankLine]() {
+					goto l298
+				}
+				goto l297
+			l298:
+				position, thunkPosition = position298, thunkPosition298
 			}
+			do(62)
 			return true
-		l259:
+		l287:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 48 HtmlBlockOpenFieldset <- ('<' Spnl ('fieldset' / 'FIELDSET') Spnl HtmlAttribute* '>') */
+		/* 60 Bullet <- (!HorizontalRule NonindentSpace ('+' / '*' / '-') Spacechar+) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l265
+			{
+				position300, thunkPosition300 := position, thunkPosition
+				if !p.rules[ruleHorizontalRule]() {
+					goto l300
+				}
+				goto l299
+			l300:
+				position, thunkPosition = position300, thunkPosition300
 			}
-			if !p.rules[ruleSpnl]() {
-				goto l265
+			if !p.rules[ruleNonindentSpace]() {
+				goto l299
 			}
 			{
-				position266, thunkPosition266 := position, thunkPosition
-				if !matchString("fieldset") {
-					goto l267
+				position301, thunkPosition301 := position, thunkPosition
+				if !matchChar('+') {
+					goto l302
 				}
-				goto l266
-			l267:
-				position, thunkPosition = position266, thunkPosition266
-				if !matchString("FIELDSET") {
-					goto l265
+				goto l301
+			l302:
+				position, thunkPosition = position301, thunkPosition301
+				if !matchChar('*') {
+					goto l303
+				}
+				goto l301
+			l303:
+				position, thunkPosition = position301, thunkPosition301
+				if !matchChar('-') {
+					goto l299
 				}
 			}
-		l266:
-			if !p.rules[ruleSpnl]() {
-				goto l265
+		l301:
+			if !p.rules[ruleSpacechar]() {
+				goto l299
 			}
-		l268:
+		l304:
 			{
-				position269, thunkPosition269 := position, thunkPosition
-				if !p.rules[ruleHtmlAttribute]() {
-					goto l269
+				position305, thunkPosition305 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l305
 				}
-				goto l268
-			l269:
-				position, thunkPosition = position269, thunkPosition269
-			}
-			if !matchChar('>') {
-				goto l265
+				goto l304
+			l305:
+				position, thunkPosition = position305, thunkPosition305
 			}
 			return true
-		l265:
+		l299:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 49 HtmlBlockCloseFieldset <- ('<' Spnl '/' ('fieldset' / 'FIELDSET') Spnl '>') */
+		/* 61 BulletList <- (&Bullet ListKind (ListTight / ListLoose) { yy.key = BULLETLIST
+               if p.extension.StrictLists {
+                   looseList(yy)
+               } }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l270
-			}
-			if !p.rules[ruleSpnl]() {
-				goto l270
+			{
+				position307, thunkPosition307 := position, thunkPosition
+				if !p.rules[ruleBullet]() {
+					goto l306
+				}
+				position, thunkPosition = position307, thunkPosition307
 			}
-			if !matchChar('/') {
-				goto l270
+			if !p.rules[ruleListKind]() {
+				goto l306
 			}
 			{
-				position271, thunkPosition271 := position, thunkPosition
-				if !matchString("fieldset") {
-					goto l272
+				position308, thunkPosition308 := position, thunkPosition
+				if !p.rules[ruleListTight]() {
+					goto l309
 				}
-				goto l271
-			l272:
-				position, thunkPosition = position271, thunkPosition271
-				if !matchString("FIELDSET") {
-					goto l270
+				goto l308
+			l309:
+				position, thunkPosition = position308, thunkPosition308
+				if !p.rules[ruleListLoose]() {
+					goto l306
 				}
 			}
-		l271:
-			if !p.rules[ruleSpnl]() {
-				goto l270
-			}
-			if !matchChar('>') {
-				goto l270
-			}
+		l308:
+			do(63)
 			return true
-		l270:
+		l306:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 50 HtmlBlockFieldset <- (HtmlBlockOpenFieldset (HtmlBlockFieldset / (!HtmlBlockCloseFieldset .))* HtmlBlockCloseFieldset) */
+		/* 62 ListKind <- &{ p.startList(position) } */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenFieldset]() {
-				goto l273
-			}
-		l274:
-			{
-				position275, thunkPosition275 := position, thunkPosition
-				{
-					position276, thunkPosition276 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockFieldset]() {
-						goto l277
-					}
-					goto l276
-				l277:
-					position, thunkPosition = position276, thunkPosition276
-					{
-						position278, thunkPosition278 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseFieldset]() {
-							goto l278
-						}
-						goto l275
-					l278:
-						position, thunkPosition = position278, thunkPosition278
-					}
-					if !matchDot() {
-						goto l275
-					}
-				}
-			l276:
-				goto l274
-			l275:
-				position, thunkPosition = position275, thunkPosition275
-			}
-			if !p.rules[ruleHtmlBlockCloseFieldset]() {
-				goto l273
+			if !( p.startList(position) ) {
+				goto l310
 			}
 			return true
-		l273:
+		l310:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 51 HtmlBlockOpenForm <- ('<' Spnl ('form' / 'FORM') Spnl HtmlAttribute* '>') */
+		/* 63 SameKind <- &{ p.sameKind(position) } */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l279
-			}
-			if !p.rules[ruleSpnl]() {
-				goto l279
-			}
-			{
-				position280, thunkPosition280 := position, thunkPosition
-				if !matchString("form") {
-					goto l281
-				}
-				goto l280
-			l281:
-				position, thunkPosition = position280, thunkPosition280
-				if !matchString("FORM") {
-					goto l279
-				}
-			}
-		l280:
-			if !p.rules[ruleSpnl]() {
-				goto l279
-			}
-		l282:
-			{
-				position283, thunkPosition283 := position, thunkPosition
-				if !p.rules[ruleHtmlAttribute]() {
-					goto l283
-				}
-				goto l282
-			l283:
-				position, thunkPosition = position283, thunkPosition283
-			}
-			if !matchChar('>') {
-				goto l279
+			if !( p.sameKind(position) ) {
+				goto l311
 			}
 			return true
-		l279:
+		l311:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 52 HtmlBlockCloseForm <- ('<' Spnl '/' ('form' / 'FORM') Spnl '>') */
+		/* 64 ListTight <- (StartList (ListItemTight { a = cons(yy, a) })+ BlankLine* !(SameKind (Bullet / Enumerator / DefMarker)) { yy = mk_list(LIST, a) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l284
-			}
-			if !p.rules[ruleSpnl]() {
-				goto l284
+			doarg(yyPush, 1)
+			if !p.rules[ruleStartList]() {
+				goto l312
 			}
-			if !matchChar('/') {
-				goto l284
+			doarg(yySet, -1)
+			if !p.rules[ruleListItemTight]() {
+				goto l312
 			}
+			do(64)
+		l313:
 			{
-				position285, thunkPosition285 := position, thunkPosition
-				if !matchString("form") {
-					goto l286
-				}
-				goto l285
-			l286:
-				position, thunkPosition = position285, thunkPosition285
-				if !matchString("FORM") {
-					goto l284
+				position314, thunkPosition314 := position, thunkPosition
+				if !p.rules[ruleListItemTight]() {
+					goto l314
 				}
+				do(64)
+				goto l313
+			l314:
+				position, thunkPosition = position314, thunkPosition314
 			}
-		l285:
-			if !p.rules[ruleSpnl]() {
-				goto l284
+		l315:
+			{
+				position316, thunkPosition316 := position, thunkPosition
+				if !p.rules[ruleBlankLine]() {
+					goto l316
+				}
+				goto l315
+			l316:
+				position, thunkPosition = position316, thunkPosition316
 			}
-			if !matchChar('>') {
-				goto l284
+			{
+				position317, thunkPosition317 := position, thunkPosition
+				if !p.rules[ruleSameKind]() {
+					goto l317
+				}
+				{
+					position318, thunkPosition318 := position, thunkPosition
+					if !p.rules[ruleBullet]() {
+						goto l319
+					}
+					goto l318
+				l319:
+					position, thunkPosition = position318, thunkPosition318
+					if !p.rules[ruleEnumerator]() {
+						goto l320
+					}
+					goto l318
+				l320:
+					position, thunkPosition = position318, thunkPosition318
+					if !p.rules[ruleDefMarker]() {
+						goto l317
+					}
+				}
+			l318:
+				goto l312
+			l317:
+				position, thunkPosition = position317, thunkPosition317
 			}
+			do(65)
+			doarg(yyPop, 1)
 			return true
-		l284:
+		l312:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 53 HtmlBlockForm <- (HtmlBlockOpenForm (HtmlBlockForm / (!HtmlBlockCloseForm .))* HtmlBlockCloseForm) */
+		/* 65 ListLoose <- (StartList (ListItem < BlankLine* > {
+                  li := b.children
+                  if p.extension.StrictLists {
+                      li.contents.str += yytext
+                  } else {
+                      li.contents.str += "\n\n"
+                  }
+                  a = cons(b, a)
+              })+ { yy = mk_list(LIST, a)
+              yy.loose = true }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenForm]() {
-				goto l287
+			doarg(yyPush, 2)
+			if !p.rules[ruleStartList]() {
+				goto l321
 			}
-		l288:
+			doarg(yySet, -1)
+			if !p.rules[ruleListItem]() {
+				goto l321
+			}
+			doarg(yySet, -2)
+			begin = position
+		l324:
+			{
+				position325, thunkPosition325 := position, thunkPosition
+				if !p.rules[ruleBlankLine]() {
+					goto l325
+				}
+				goto l324
+			l325:
+				position, thunkPosition = position325, thunkPosition325
+			}
+			end = position
+			do(66)
+		l322:
 			{
-				position289, thunkPosition289 := position, thunkPosition
+				position323, thunkPosition323 := position, thunkPosition
+				if !p.rules[ruleListItem]() {
+					goto l323
+				}
+				doarg(yySet, -2)
+				begin = position
+			l326:
 				{
-					position290, thunkPosition290 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockForm]() {
-						goto l291
-					}
-					goto l290
-				l291:
-					position, thunkPosition = position290, thunkPosition290
-					{
-						position292, thunkPosition292 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseForm]() {
-							goto l292
-						}
-						goto l289
-					l292:
-						position, thunkPosition = position292, thunkPosition292
-					}
-					if !matchDot() {
-						goto l289
+					position327, thunkPosition327 := position, thunkPosition
+					if !p.rules[ruleBlankLine]() {
+						goto l327
 					}
+					goto l326
+				l327:
+					position, thunkPosition = position327, thunkPosition327
 				}
-			l290:
-				goto l288
-			l289:
-				position, thunkPosition = position289, thunkPosition289
-			}
-			if !p.rules[ruleHtmlBlockCloseForm]() {
-				goto l287
+				end = position
+				do(66)
+				goto l322
+			l323:
+				position, thunkPosition = position323, thunkPosition323
 			}
+			do(67)
+			doarg(yyPop, 2)
 			return true
-		l287:
+		l321:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 54 HtmlBlockOpenH1 <- ('<' Spnl ('h1' / 'H1') Spnl HtmlAttribute* '>') */
+		/* 66 ListItem <- (SameKind (Bullet / Enumerator / DefMarker) (TaskMarker / Nothing) StartList ListBlock { a = cons(yy, a) } (ListContinuationBlock { a = cons(yy, a) })* {
+               raw := mk_str_from_list(a, false)
+               raw.key = RAW
+               yy = mk_element(LISTITEM)
+               yy.children = raw
+               if t != nil {
+                   yy.contents.str = strings.ToLower(t.contents.str)
+               }
+               t = nil
+            }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l293
+			doarg(yyPush, 2)
+			if !p.rules[ruleSameKind]() {
+				goto l328
 			}
-			if !p.rules[ruleSpnl]() {
-				goto l293
+			{
+				position329, thunkPosition329 := position, thunkPosition
+				if !p.rules[ruleBullet]() {
+					goto l330
+				}
+				goto l329
+			l330:
+				position, thunkPosition = position329, thunkPosition329
+				if !p.rules[ruleEnumerator]() {
+					goto l331
+				}
+				goto l329
+			l331:
+				position, thunkPosition = position329, thunkPosition329
+				if !p.rules[ruleDefMarker]() {
+					goto l328
+				}
 			}
+		l329:
 			{
-				position294, thunkPosition294 := position, thunkPosition
-				if !matchString("h1") {
-					goto l295
+				position332, thunkPosition332 := position, thunkPosition
+				if !p.rules[ruleTaskMarker]() {
+					goto l333
 				}
-				goto l294
-			l295:
-				position, thunkPosition = position294, thunkPosition294
-				if !matchString("H1") {
-					goto l293
+				doarg(yySet, -1)
+				goto l332
+			l333:
+				position, thunkPosition = position332, thunkPosition332
+				if !p.rules[ruleNothing]() {
+					goto l328
 				}
+				doarg(yySet, -1)
 			}
-		l294:
-			if !p.rules[ruleSpnl]() {
-				goto l293
+		l332:
+			if !p.rules[ruleStartList]() {
+				goto l328
 			}
-		l296:
+			doarg(yySet, -2)
+			if !p.rules[ruleListBlock]() {
+				goto l328
+			}
+			do(68)
+		l334:
 			{
-				position297, thunkPosition297 := position, thunkPosition
-				if !p.rules[ruleHtmlAttribute]() {
-					goto l297
+				position335, thunkPosition335 := position, thunkPosition
+				if !p.rules[ruleListContinuationBlock]() {
+					goto l335
 				}
-				goto l296
-			l297:
-				position, thunkPosition = position297, thunkPosition297
-			}
-			if !matchChar('>') {
-				goto l293
+				do(69)
+				goto l334
+			l335:
+				position, thunkPosition = position335, thunkPosition335
 			}
+			do(70)
+			doarg(yyPop, 2)
 			return true
-		l293:
+		l328:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 55 HtmlBlockCloseH1 <- ('<' Spnl '/' ('h1' / 'H1') Spnl '>') */
+		/* 67 ListItemTight <- (SameKind (Bullet / Enumerator / DefMarker) (TaskMarker / Nothing) StartList ListBlock { a = cons(yy, a) } (!BlankLine ListContinuationBlock { a = cons(yy, a) })* !ListContinuationBlock {
+               raw := mk_str_from_list(a, false)
+               raw.key = RAW
+               yy = mk_element(LISTITEM)
+               yy.children = raw
+               if t != nil {
+                   yy.contents.str = strings.ToLower(t.contents.str)
+               }
+               t = nil
+            }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l298
-			}
-			if !p.rules[ruleSpnl]() {
-				goto l298
-			}
-			if !matchChar('/') {
-				goto l298
+			doarg(yyPush, 2)
+			if !p.rules[ruleSameKind]() {
+				goto l336
 			}
 			{
-				position299, thunkPosition299 := position, thunkPosition
-				if !matchString("h1") {
-					goto l300
+				position337, thunkPosition337 := position, thunkPosition
+				if !p.rules[ruleBullet]() {
+					goto l338
 				}
-				goto l299
-			l300:
-				position, thunkPosition = position299, thunkPosition299
-				if !matchString("H1") {
-					goto l298
+				goto l337
+			l338:
+				position, thunkPosition = position337, thunkPosition337
+				if !p.rules[ruleEnumerator]() {
+					goto l339
+				}
+				goto l337
+			l339:
+				position, thunkPosition = position337, thunkPosition337
+				if !p.rules[ruleDefMarker]() {
+					goto l336
 				}
 			}
-		l299:
-			if !p.rules[ruleSpnl]() {
-				goto l298
+		l337:
+			{
+				position340, thunkPosition340 := position, thunkPosition
+				if !p.rules[ruleTaskMarker]() {
+					goto l341
+				}
+				doarg(yySet, -1)
+				goto l340
+			l341:
+				position, thunkPosition = position340, thunkPosition340
+				if !p.rules[ruleNothing]() {
+					goto l336
+				}
+				doarg(yySet, -1)
 			}
-			if !matchChar('>') {
-				goto l298
+		l340:
+			if !p.rules[ruleStartList]() {
+				goto l336
 			}
-			return true
-		l298:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 56 HtmlBlockH1 <- (HtmlBlockOpenH1 (HtmlBlockH1 / (!HtmlBlockCloseH1 .))* HtmlBlockCloseH1) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenH1]() {
-				goto l301
+			doarg(yySet, -2)
+			if !p.rules[ruleListBlock]() {
+				goto l336
 			}
-		l302:
+			do(71)
+		l342:
 			{
-				position303, thunkPosition303 := position, thunkPosition
+				position343, thunkPosition343 := position, thunkPosition
 				{
-					position304, thunkPosition304 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockH1]() {
-						goto l305
-					}
-					goto l304
-				l305:
-					position, thunkPosition = position304, thunkPosition304
-					{
-						position306, thunkPosition306 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseH1]() {
-							goto l306
-						}
-						goto l303
-					l306:
-						position, thunkPosition = position306, thunkPosition306
-					}
-					if !matchDot() {
-						goto l303
+					position344, thunkPosition344 := position, thunkPosition
+					if !p.rules[ruleBlankLine]() {
+						goto l344
 					}
+					goto l343
+				l344:
+					position, thunkPosition = position344, thunkPosition344
 				}
-			l304:
-				goto l302
-			l303:
-				position, thunkPosition = position303, thunkPosition303
+				if !p.rules[ruleListContinuationBlock]() {
+					goto l343
+				}
+				do(72)
+				goto l342
+			l343:
+				position, thunkPosition = position343, thunkPosition343
 			}
-			if !p.rules[ruleHtmlBlockCloseH1]() {
-				goto l301
+			{
+				position345, thunkPosition345 := position, thunkPosition
+				if !p.rules[ruleListContinuationBlock]() {
+					goto l345
+				}
+				goto l336
+			l345:
+				position, thunkPosition = position345, thunkPosition345
 			}
+			do(73)
+			doarg(yyPop, 2)
 			return true
-		l301:
+		l336:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 57 HtmlBlockOpenH2 <- ('<' Spnl ('h2' / 'H2') Spnl HtmlAttribute* '>') */
+		/* 68 TaskMarker <- (&{ p.extension.TaskLists } '[' < (' ' / [xX]) > ']' Spacechar+ !Newline { yy = mk_str(yytext) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l307
+			if !( p.extension.TaskLists ) {
+				goto l346
 			}
-			if !p.rules[ruleSpnl]() {
-				goto l307
+			if !matchChar('[') {
+				goto l346
 			}
+			begin = position
 			{
-				position308, thunkPosition308 := position, thunkPosition
-				if !matchString("h2") {
-					goto l309
+				position347, thunkPosition347 := position, thunkPosition
+				if !matchChar(' ') {
+					goto l348
 				}
-				goto l308
-			l309:
-				position, thunkPosition = position308, thunkPosition308
-				if !matchString("H2") {
-					goto l307
+				goto l347
+			l348:
+				position, thunkPosition = position347, thunkPosition347
+				if !matchClass(11) {
+					goto l346
 				}
 			}
-		l308:
-			if !p.rules[ruleSpnl]() {
-				goto l307
+		l347:
+			end = position
+			if !matchChar(']') {
+				goto l346
 			}
-		l310:
+			if !p.rules[ruleSpacechar]() {
+				goto l346
+			}
+		l349:
 			{
-				position311, thunkPosition311 := position, thunkPosition
-				if !p.rules[ruleHtmlAttribute]() {
-					goto l311
+				position350, thunkPosition350 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l350
 				}
-				goto l310
-			l311:
-				position, thunkPosition = position311, thunkPosition311
+				goto l349
+			l350:
+				position, thunkPosition = position350, thunkPosition350
 			}
-			if !matchChar('>') {
-				goto l307
+			{
+				position351, thunkPosition351 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l351
+				}
+				goto l346
+			l351:
+				position, thunkPosition = position351, thunkPosition351
 			}
+			do(74)
 			return true
-		l307:
+		l346:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 58 HtmlBlockCloseH2 <- ('<' Spnl '/' ('h2' / 'H2') Spnl '>') */
+		/* 69 ListBlock <- (StartList !BlankLine Line { a = cons(yy, a) } (ListBlockLine { a = cons(yy, a) })* { yy = mk_str_from_list(a, false) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l312
+			doarg(yyPush, 1)
+			if !p.rules[ruleStartList]() {
+				goto l352
 			}
-			if !p.rules[ruleSpnl]() {
-				goto l312
+			doarg(yySet, -1)
+			{
+				position353, thunkPosition353 := position, thunkPosition
+				if !p.rules[ruleBlankLine]() {
+					goto l353
+				}
+				goto l352
+			l353:
+				position, thunkPosition = position353, thunkPosition353
 			}
-			if !matchChar('/') {
-				goto l312
+			if !p.rules[ruleLine]() {
+				goto l352
 			}
+			do(75)
+		l354:
 			{
-				position313, thunkPosition313 := position, thunkPosition
-				if !matchString("h2") {
-					goto l314
+				position355, thunkPosition355 := position, thunkPosition
+				if !p.rules[ruleListBlockLine]() {
+					goto l355
 				}
-				goto l313
-			l314:
-				position, thunkPosition = position313, thunkPosition313
-				if !matchString("H2") {
-					goto l312
-				}
-			}
-		l313:
-			if !p.rules[ruleSpnl]() {
-				goto l312
-			}
-			if !matchChar('>') {
-				goto l312
+				do(76)
+				goto l354
+			l355:
+				position, thunkPosition = position355, thunkPosition355
 			}
+			do(77)
+			doarg(yyPop, 1)
 			return true
-		l312:
+		l352:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 59 HtmlBlockH2 <- (HtmlBlockOpenH2 (HtmlBlockH2 / (!HtmlBlockCloseH2 .))* HtmlBlockCloseH2) */
+		/* 70 ListContinuationBlock <- (StartList (< BlankLine* > {   if len(yytext) == 0 {
+                                   a = cons(mk_str("\001"), a) // block separator
+                              } else {
+                                   a = cons(mk_str(yytext), a)
+                              }
+                          }) (Indent ListBlock { a = cons(yy, a) })+ {  yy = mk_str_from_list(a, false) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenH2]() {
-				goto l315
+			doarg(yyPush, 1)
+			if !p.rules[ruleStartList]() {
+				goto l356
 			}
-		l316:
+			doarg(yySet, -1)
+			begin = position
+		l357:
 			{
-				position317, thunkPosition317 := position, thunkPosition
-				{
-					position318, thunkPosition318 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockH2]() {
-						goto l319
-					}
-					goto l318
-				l319:
-					position, thunkPosition = position318, thunkPosition318
-					{
-						position320, thunkPosition320 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseH2]() {
-							goto l320
-						}
-						goto l317
-					l320:
-						position, thunkPosition = position320, thunkPosition320
-					}
-					if !matchDot() {
-						goto l317
-					}
+				position358, thunkPosition358 := position, thunkPosition
+				if !p.rules[ruleBlankLine]() {
+					goto l358
 				}
-			l318:
-				goto l316
-			l317:
-				position, thunkPosition = position317, thunkPosition317
+				goto l357
+			l358:
+				position, thunkPosition = position358, thunkPosition358
 			}
-			if !p.rules[ruleHtmlBlockCloseH2]() {
-				goto l315
+			end = position
+			do(78)
+			if !p.rules[ruleIndent]() {
+				goto l356
+			}
+			if !p.rules[ruleListBlock]() {
+				goto l356
+			}
+			do(79)
+		l359:
+			{
+				position360, thunkPosition360 := position, thunkPosition
+				if !p.rules[ruleIndent]() {
+					goto l360
+				}
+				if !p.rules[ruleListBlock]() {
+					goto l360
+				}
+				do(79)
+				goto l359
+			l360:
+				position, thunkPosition = position360, thunkPosition360
 			}
+			do(80)
+			doarg(yyPop, 1)
 			return true
-		l315:
+		l356:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 60 HtmlBlockOpenH3 <- ('<' Spnl ('h3' / 'H3') Spnl HtmlAttribute* '>') */
+		/* 71 Enumerator <- (NonindentSpace [0-9]+ ('.' / (&{ p.extension.ListStart } ')')) Spacechar+) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l321
+			if !p.rules[ruleNonindentSpace]() {
+				goto l361
 			}
-			if !p.rules[ruleSpnl]() {
-				goto l321
+			if !matchClass(7) {
+				goto l361
 			}
+		l362:
 			{
-				position322, thunkPosition322 := position, thunkPosition
-				if !matchString("h3") {
-					goto l323
+				position363, thunkPosition363 := position, thunkPosition
+				if !matchClass(7) {
+					goto l363
 				}
-				goto l322
-			l323:
-				position, thunkPosition = position322, thunkPosition322
-				if !matchString("H3") {
-					goto l321
+				goto l362
+			l363:
+				position, thunkPosition = position363, thunkPosition363
+			}
+			{
+				position364, thunkPosition364 := position, thunkPosition
+				if !matchChar('.') {
+					goto l365
+				}
+				goto l364
+			l365:
+				position, thunkPosition = position364, thunkPosition364
+				if !( p.extension.ListStart ) {
+					goto l361
+				}
+				if !matchChar(')') {
+					goto l361
 				}
 			}
-		l322:
-			if !p.rules[ruleSpnl]() {
-				goto l321
+		l364:
+			if !p.rules[ruleSpacechar]() {
+				goto l361
 			}
-		l324:
+		l366:
 			{
-				position325, thunkPosition325 := position, thunkPosition
-				if !p.rules[ruleHtmlAttribute]() {
-					goto l325
+				position367, thunkPosition367 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l367
 				}
-				goto l324
-			l325:
-				position, thunkPosition = position325, thunkPosition325
-			}
-			if !matchChar('>') {
-				goto l321
+				goto l366
+			l367:
+				position, thunkPosition = position367, thunkPosition367
 			}
 			return true
-		l321:
+		l361:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 61 HtmlBlockCloseH3 <- ('<' Spnl '/' ('h3' / 'H3') Spnl '>') */
+		/* 72 ListStart <- (&(NonindentSpace < [0-9]+ ('.' / ')') >) { yy = mk_str(yytext) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l326
+			{
+				position369, thunkPosition369 := position, thunkPosition
+				if !p.rules[ruleNonindentSpace]() {
+					goto l368
+				}
+				begin = position
+				if !matchClass(7) {
+					goto l368
+				}
+			l370:
+				{
+					position371, thunkPosition371 := position, thunkPosition
+					if !matchClass(7) {
+						goto l371
+					}
+					goto l370
+				l371:
+					position, thunkPosition = position371, thunkPosition371
+				}
+				{
+					position372, thunkPosition372 := position, thunkPosition
+					if !matchChar('.') {
+						goto l373
+					}
+					goto l372
+				l373:
+					position, thunkPosition = position372, thunkPosition372
+					if !matchChar(')') {
+						goto l368
+					}
+				}
+			l372:
+				end = position
+				position, thunkPosition = position369, thunkPosition369
 			}
-			if !p.rules[ruleSpnl]() {
-				goto l326
+			do(81)
+			return true
+		l368:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 73 OrderedList <- (&Enumerator ListKind ((&{ p.extension.ListStart } ListStart) / Nothing) (ListTight / ListLoose) { yy.key = ORDEREDLIST
+                if s != nil {
+                    yy.contents.str = s.contents.str
+                }
+                s = nil
+                if p.extension.StrictLists {
+                    looseList(yy)
+                } }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 1)
+			{
+				position375, thunkPosition375 := position, thunkPosition
+				if !p.rules[ruleEnumerator]() {
+					goto l374
+				}
+				position, thunkPosition = position375, thunkPosition375
 			}
-			if !matchChar('/') {
-				goto l326
+			if !p.rules[ruleListKind]() {
+				goto l374
 			}
 			{
-				position327, thunkPosition327 := position, thunkPosition
-				if !matchString("h3") {
-					goto l328
+				position376, thunkPosition376 := position, thunkPosition
+				if !( p.extension.ListStart ) {
+					goto l377
 				}
-				goto l327
-			l328:
-				position, thunkPosition = position327, thunkPosition327
-				if !matchString("H3") {
-					goto l326
+				if !p.rules[ruleListStart]() {
+					goto l377
 				}
+				doarg(yySet, -1)
+				goto l376
+			l377:
+				position, thunkPosition = position376, thunkPosition376
+				if !p.rules[ruleNothing]() {
+					goto l374
+				}
+				doarg(yySet, -1)
 			}
-		l327:
-			if !p.rules[ruleSpnl]() {
-				goto l326
-			}
-			if !matchChar('>') {
-				goto l326
+		l376:
+			{
+				position378, thunkPosition378 := position, thunkPosition
+				if !p.rules[ruleListTight]() {
+					goto l379
+				}
+				goto l378
+			l379:
+				position, thunkPosition = position378, thunkPosition378
+				if !p.rules[ruleListLoose]() {
+					goto l374
+				}
 			}
+		l378:
+			do(82)
+			doarg(yyPop, 1)
 			return true
-		l326:
+		l374:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 62 HtmlBlockH3 <- (HtmlBlockOpenH3 (HtmlBlockH3 / (!HtmlBlockCloseH3 .))* HtmlBlockCloseH3) */
+		/* 74 ListBlockLine <- (!BlankLine !((Indent? (Bullet / Enumerator)) / DefMarker) !HorizontalRule OptionallyIndentedLine) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenH3]() {
-				goto l329
+			{
+				position381, thunkPosition381 := position, thunkPosition
+				if !p.rules[ruleBlankLine]() {
+					goto l381
+				}
+				goto l380
+			l381:
+				position, thunkPosition = position381, thunkPosition381
 			}
-		l330:
 			{
-				position331, thunkPosition331 := position, thunkPosition
+				position382, thunkPosition382 := position, thunkPosition
 				{
-					position332, thunkPosition332 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockH3]() {
-						goto l333
+					position383, thunkPosition383 := position, thunkPosition
+					{
+						position385, thunkPosition385 := position, thunkPosition
+						if !p.rules[ruleIndent]() {
+							goto l385
+						}
+						goto l386
+					l385:
+						position, thunkPosition = position385, thunkPosition385
 					}
-					goto l332
-				l333:
-					position, thunkPosition = position332, thunkPosition332
+				l386:
 					{
-						position334, thunkPosition334 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseH3]() {
-							goto l334
+						position387, thunkPosition387 := position, thunkPosition
+						if !p.rules[ruleBullet]() {
+							goto l388
+						}
+						goto l387
+					l388:
+						position, thunkPosition = position387, thunkPosition387
+						if !p.rules[ruleEnumerator]() {
+							goto l384
 						}
-						goto l331
-					l334:
-						position, thunkPosition = position334, thunkPosition334
 					}
-					if !matchDot() {
-						goto l331
+				l387:
+					goto l383
+				l384:
+					position, thunkPosition = position383, thunkPosition383
+					if !p.rules[ruleDefMarker]() {
+						goto l382
 					}
 				}
-			l332:
-				goto l330
-			l331:
-				position, thunkPosition = position331, thunkPosition331
+			l383:
+				goto l380
+			l382:
+				position, thunkPosition = position382, thunkPosition382
 			}
-			if !p.rules[ruleHtmlBlockCloseH3]() {
-				goto l329
+			{
+				position389, thunkPosition389 := position, thunkPosition
+				if !p.rules[ruleHorizontalRule]() {
+					goto l389
+				}
+				goto l380
+			l389:
+				position, thunkPosition = position389, thunkPosition389
+			}
+			if !p.rules[ruleOptionallyIndentedLine]() {
+				goto l380
 			}
 			return true
-		l329:
+		l380:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 63 HtmlBlockOpenH4 <- ('<' Spnl ('h4' / 'H4') Spnl HtmlAttribute* '>') */
+		/* 75 HtmlBlockOpenAddress <- ('<' Spnl ('address' / 'ADDRESS') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l335
+				goto l390
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l335
+				goto l390
 			}
 			{
-				position336, thunkPosition336 := position, thunkPosition
-				if !matchString("h4") {
-					goto l337
+				position391, thunkPosition391 := position, thunkPosition
+				if !matchString("address") {
+					goto l392
 				}
-				goto l336
-			l337:
-				position, thunkPosition = position336, thunkPosition336
-				if !matchString("H4") {
-					goto l335
+				goto l391
+			l392:
+				position, thunkPosition = position391, thunkPosition391
+				if !matchString("ADDRESS") {
+					goto l390
 				}
 			}
-		l336:
+		l391:
 			if !p.rules[ruleSpnl]() {
-				goto l335
+				goto l390
 			}
-		l338:
+		l393:
 			{
-				position339, thunkPosition339 := position, thunkPosition
+				position394, thunkPosition394 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l339
+					goto l394
 				}
-				goto l338
-			l339:
-				position, thunkPosition = position339, thunkPosition339
+				goto l393
+			l394:
+				position, thunkPosition = position394, thunkPosition394
 			}
 			if !matchChar('>') {
-				goto l335
+				goto l390
 			}
 			return true
-		l335:
+		l390:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 64 HtmlBlockCloseH4 <- ('<' Spnl '/' ('h4' / 'H4') Spnl '>') */
+		/* 76 HtmlBlockCloseAddress <- ('<' Spnl '/' ('address' / 'ADDRESS') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l340
+				goto l395
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l340
+				goto l395
 			}
 			if !matchChar('/') {
-				goto l340
+				goto l395
 			}
 			{
-				position341, thunkPosition341 := position, thunkPosition
-				if !matchString("h4") {
-					goto l342
+				position396, thunkPosition396 := position, thunkPosition
+				if !matchString("address") {
+					goto l397
 				}
-				goto l341
-			l342:
-				position, thunkPosition = position341, thunkPosition341
-				if !matchString("H4") {
-					goto l340
+				goto l396
+			l397:
+				position, thunkPosition = position396, thunkPosition396
+				if !matchString("ADDRESS") {
+					goto l395
 				}
 			}
-		l341:
+		l396:
 			if !p.rules[ruleSpnl]() {
-				goto l340
+				goto l395
 			}
 			if !matchChar('>') {
-				goto l340
+				goto l395
 			}
 			return true
-		l340:
+		l395:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 65 HtmlBlockH4 <- (HtmlBlockOpenH4 (HtmlBlockH4 / (!HtmlBlockCloseH4 .))* HtmlBlockCloseH4) */
+		/* 77 HtmlBlockAddress <- (HtmlBlockOpenAddress (HtmlBlockAddress / (!HtmlBlockCloseAddress .))* HtmlBlockCloseAddress) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenH4]() {
-				goto l343
+			if !p.rules[ruleHtmlBlockOpenAddress]() {
+				goto l398
 			}
-		l344:
+		l399:
 			{
-				position345, thunkPosition345 := position, thunkPosition
+				position400, thunkPosition400 := position, thunkPosition
 				{
-					position346, thunkPosition346 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockH4]() {
-						goto l347
+					position401, thunkPosition401 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockAddress]() {
+						goto l402
 					}
-					goto l346
-				l347:
-					position, thunkPosition = position346, thunkPosition346
+					goto l401
+				l402:
+					position, thunkPosition = position401, thunkPosition401
 					{
-						position348, thunkPosition348 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseH4]() {
-							goto l348
+						position403, thunkPosition403 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseAddress]() {
+							goto l403
 						}
-						goto l345
-					l348:
-						position, thunkPosition = position348, thunkPosition348
+						goto l400
+					l403:
+						position, thunkPosition = position403, thunkPosition403
 					}
 					if !matchDot() {
-						goto l345
+						goto l400
 					}
 				}
-			l346:
-				goto l344
-			l345:
-				position, thunkPosition = position345, thunkPosition345
+			l401:
+				goto l399
+			l400:
+				position, thunkPosition = position400, thunkPosition400
 			}
-			if !p.rules[ruleHtmlBlockCloseH4]() {
-				goto l343
+			if !p.rules[ruleHtmlBlockCloseAddress]() {
+				goto l398
 			}
 			return true
-		l343:
+		l398:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 66 HtmlBlockOpenH5 <- ('<' Spnl ('h5' / 'H5') Spnl HtmlAttribute* '>') */
+		/* 78 HtmlBlockOpenBlockquote <- ('<' Spnl ('blockquote' / 'BLOCKQUOTE') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l349
+				goto l404
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l349
+				goto l404
 			}
 			{
-				position350, thunkPosition350 := position, thunkPosition
-				if !matchString("h5") {
-					goto l351
+				position405, thunkPosition405 := position, thunkPosition
+				if !matchString("blockquote") {
+					goto l406
 				}
-				goto l350
-			l351:
-				position, thunkPosition = position350, thunkPosition350
-				if !matchString("H5") {
-					goto l349
+				goto l405
+			l406:
+				position, thunkPosition = position405, thunkPosition405
+				if !matchString("BLOCKQUOTE") {
+					goto l404
 				}
 			}
-		l350:
+		l405:
 			if !p.rules[ruleSpnl]() {
-				goto l349
+				goto l404
 			}
-		l352:
+		l407:
 			{
-				position353, thunkPosition353 := position, thunkPosition
+				position408, thunkPosition408 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l353
+					goto l408
 				}
-				goto l352
-			l353:
-				position, thunkPosition = position353, thunkPosition353
+				goto l407
+			l408:
+				position, thunkPosition = position408, thunkPosition408
 			}
 			if !matchChar('>') {
-				goto l349
+				goto l404
 			}
 			return true
-		l349:
+		l404:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 67 HtmlBlockCloseH5 <- ('<' Spnl '/' ('h5' / 'H5') Spnl '>') */
+		/* 79 HtmlBlockCloseBlockquote <- ('<' Spnl '/' ('blockquote' / 'BLOCKQUOTE') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l354
+				goto l409
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l354
+				goto l409
 			}
 			if !matchChar('/') {
-				goto l354
+				goto l409
 			}
 			{
-				position355, thunkPosition355 := position, thunkPosition
-				if !matchString("h5") {
-					goto l356
+				position410, thunkPosition410 := position, thunkPosition
+				if !matchString("blockquote") {
+					goto l411
 				}
-				goto l355
-			l356:
-				position, thunkPosition = position355, thunkPosition355
-				if !matchString("H5") {
-					goto l354
+				goto l410
+			l411:
+				position, thunkPosition = position410, thunkPosition410
+				if !matchString("BLOCKQUOTE") {
+					goto l409
 				}
 			}
-		l355:
+		l410:
 			if !p.rules[ruleSpnl]() {
-				goto l354
+				goto l409
 			}
 			if !matchChar('>') {
-				goto l354
+				goto l409
 			}
 			return true
-		l354:
+		l409:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 68 HtmlBlockH5 <- (HtmlBlockOpenH5 (HtmlBlockH5 / (!HtmlBlockCloseH5 .))* HtmlBlockCloseH5) */
+		/* 80 HtmlBlockBlockquote <- (HtmlBlockOpenBlockquote (HtmlBlockBlockquote / (!HtmlBlockCloseBlockquote .))* HtmlBlockCloseBlockquote) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenH5]() {
-				goto l357
+			if !p.rules[ruleHtmlBlockOpenBlockquote]() {
+				goto l412
 			}
-		l358:
+		l413:
 			{
-				position359, thunkPosition359 := position, thunkPosition
+				position414, thunkPosition414 := position, thunkPosition
 				{
-					position360, thunkPosition360 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockH5]() {
-						goto l361
+					position415, thunkPosition415 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockBlockquote]() {
+						goto l416
 					}
-					goto l360
-				l361:
-					position, thunkPosition = position360, thunkPosition360
+					goto l415
+				l416:
+					position, thunkPosition = position415, thunkPosition415
 					{
-						position362, thunkPosition362 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseH5]() {
-							goto l362
+						position417, thunkPosition417 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseBlockquote]() {
+							goto l417
 						}
-						goto l359
-					l362:
-						position, thunkPosition = position362, thunkPosition362
+						goto l414
+					l417:
+						position, thunkPosition = position417, thunkPosition417
 					}
 					if !matchDot() {
-						goto l359
+						goto l414
 					}
 				}
-			l360:
-				goto l358
-			l359:
-				position, thunkPosition = position359, thunkPosition359
+			l415:
+				goto l413
+			l414:
+				position, thunkPosition = position414, thunkPosition414
 			}
-			if !p.rules[ruleHtmlBlockCloseH5]() {
-				goto l357
+			if !p.rules[ruleHtmlBlockCloseBlockquote]() {
+				goto l412
 			}
 			return true
-		l357:
+		l412:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 69 HtmlBlockOpenH6 <- ('<' Spnl ('h6' / 'H6') Spnl HtmlAttribute* '>') */
+		/* 81 HtmlBlockOpenCenter <- ('<' Spnl ('center' / 'CENTER') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l363
+				goto l418
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l363
+				goto l418
 			}
 			{
-				position364, thunkPosition364 := position, thunkPosition
-				if !matchString("h6") {
-					goto l365
+				position419, thunkPosition419 := position, thunkPosition
+				if !matchString("center") {
+					goto l420
 				}
-				goto l364
-			l365:
-				position, thunkPosition = position364, thunkPosition364
-				if !matchString("H6") {
-					goto l363
+				goto l419
+			l420:
+				position, thunkPosition = position419, thunkPosition419
+				if !matchString("CENTER") {
+					goto l418
 				}
 			}
-		l364:
+		l419:
 			if !p.rules[ruleSpnl]() {
-				goto l363
+				goto l418
 			}
-		l366:
+		l421:
 			{
-				position367, thunkPosition367 := position, thunkPosition
+				position422, thunkPosition422 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l367
+					goto l422
 				}
-				goto l366
-			l367:
-				position, thunkPosition = position367, thunkPosition367
+				goto l421
+			l422:
+				position, thunkPosition = position422, thunkPosition422
 			}
 			if !matchChar('>') {
-				goto l363
+				goto l418
 			}
 			return true
-		l363:
+		l418:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 70 HtmlBlockCloseH6 <- ('<' Spnl '/' ('h6' / 'H6') Spnl '>') */
+		/* 82 HtmlBlockCloseCenter <- ('<' Spnl '/' ('center' / 'CENTER') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l368
+				goto l423
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l368
+				goto l423
 			}
 			if !matchChar('/') {
-				goto l368
+				goto l423
 			}
 			{
-				position369, thunkPosition369 := position, thunkPosition
-				if !matchString("h6") {
-					goto l370
+				position424, thunkPosition424 := position, thunkPosition
+				if !matchString("center") {
+					goto l425
 				}
-				goto l369
-			l370:
-				position, thunkPosition = position369, thunkPosition369
-				if !matchString("H6") {
-					goto l368
+				goto l424
+			l425:
+				position, thunkPosition = position424, thunkPosition424
+				if !matchString("CENTER") {
+					goto l423
 				}
 			}
-		l369:
+		l424:
 			if !p.rules[ruleSpnl]() {
-				goto l368
+				goto l423
 			}
 			if !matchChar('>') {
-				goto l368
+				goto l423
 			}
 			return true
-		l368:
+		l423:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 71 HtmlBlockH6 <- (HtmlBlockOpenH6 (HtmlBlockH6 / (!HtmlBlockCloseH6 .))* HtmlBlockCloseH6) */
+		/* 83 HtmlBlockCenter <- (HtmlBlockOpenCenter (HtmlBlockCenter / (!HtmlBlockCloseCenter .))* HtmlBlockCloseCenter) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenH6]() {
-				goto l371
+			if !p.rules[ruleHtmlBlockOpenCenter]() {
+				goto l426
 			}
-		l372:
+		l427:
 			{
-				position373, thunkPosition373 := position, thunkPosition
+				position428, thunkPosition428 := position, thunkPosition
 				{
-					position374, thunkPosition374 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockH6]() {
-						goto l375
+					position429, thunkPosition429 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockCenter]() {
+						goto l430
 					}
-					goto l374
-				l375:
-					position, thunkPosition = position374, thunkPosition374
+					goto l429
+				l430:
+					position, thunkPosition = position429, thunkPosition429
 					{
-						position376, thunkPosition376 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseH6]() {
-							goto l376
+						position431, thunkPosition431 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseCenter]() {
+							goto l431
 						}
-						goto l373
-					l376:
-						position, thunkPosition = position376, thunkPosition376
+						goto l428
+					l431:
+						position, thunkPosition = position431, thunkPosition431
 					}
 					if !matchDot() {
-						goto l373
+						goto l428
 					}
 				}
-			l374:
-				goto l372
-			l373:
-				position, thunkPosition = position373, thunkPosition373
+			l429:
+				goto l427
+			l428:
+				position, thunkPosition = position428, thunkPosition428
 			}
-			if !p.rules[ruleHtmlBlockCloseH6]() {
-				goto l371
+			if !p.rules[ruleHtmlBlockCloseCenter]() {
+				goto l426
 			}
 			return true
-		l371:
+		l426:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 72 HtmlBlockOpenMenu <- ('<' Spnl ('menu' / 'MENU') Spnl HtmlAttribute* '>') */
+		/* 84 HtmlBlockOpenDir <- ('<' Spnl ('dir' / 'DIR') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l377
+				goto l432
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l377
+				goto l432
 			}
 			{
-				position378, thunkPosition378 := position, thunkPosition
-				if !matchString("menu") {
-					goto l379
+				position433, thunkPosition433 := position, thunkPosition
+				if !matchString("dir") {
+					goto l434
 				}
-				goto l378
-			l379:
-				position, thunkPosition = position378, thunkPosition378
-				if !matchString("MENU") {
-					goto l377
+				goto l433
+			l434:
+				position, thunkPosition = position433, thunkPosition433
+				if !matchString("DIR") {
+					goto l432
 				}
 			}
-		l378:
+		l433:
 			if !p.rules[ruleSpnl]() {
-				goto l377
+				goto l432
 			}
-		l380:
+		l435:
 			{
-				position381, thunkPosition381 := position, thunkPosition
+				position436, thunkPosition436 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l381
+					goto l436
 				}
-				goto l380
-			l381:
-				position, thunkPosition = position381, thunkPosition381
+				goto l435
+			l436:
+				position, thunkPosition = position436, thunkPosition436
 			}
 			if !matchChar('>') {
-				goto l377
+				goto l432
 			}
 			return true
-		l377:
+		l432:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 73 HtmlBlockCloseMenu <- ('<' Spnl '/' ('menu' / 'MENU') Spnl '>') */
+		/* 85 HtmlBlockCloseDir <- ('<' Spnl '/' ('dir' / 'DIR') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l382
+				goto l437
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l382
+				goto l437
 			}
 			if !matchChar('/') {
-				goto l382
+				goto l437
 			}
 			{
-				position383, thunkPosition383 := position, thunkPosition
-				if !matchString("menu") {
-					goto l384
+				position438, thunkPosition438 := position, thunkPosition
+				if !matchString("dir") {
+					goto l439
 				}
-				goto l383
-			l384:
-				position, thunkPosition = position383, thunkPosition383
-				if !matchString("MENU") {
-					goto l382
+				goto l438
+			l439:
+				position, thunkPosition = position438, thunkPosition438
+				if !matchString("DIR") {
+					goto l437
 				}
 			}
-		l383:
+		l438:
 			if !p.rules[ruleSpnl]() {
-				goto l382
+				goto l437
 			}
 			if !matchChar('>') {
-				goto l382
+				goto l437
 			}
 			return true
-		l382:
+		l437:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 74 HtmlBlockMenu <- (HtmlBlockOpenMenu (HtmlBlockMenu / (!HtmlBlockCloseMenu .))* HtmlBlockCloseMenu) */
+		/* 86 HtmlBlockDir <- (HtmlBlockOpenDir (HtmlBlockDir / (!HtmlBlockCloseDir .))* HtmlBlockCloseDir) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenMenu]() {
-				goto l385
+			if !p.rules[ruleHtmlBlockOpenDir]() {
+				goto l440
 			}
-		l386:
+		l441:
 			{
-				position387, thunkPosition387 := position, thunkPosition
+				position442, thunkPosition442 := position, thunkPosition
 				{
-					position388, thunkPosition388 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockMenu]() {
-						goto l389
+					position443, thunkPosition443 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockDir]() {
+						goto l444
 					}
-					goto l388
-				l389:
-					position, thunkPosition = position388, thunkPosition388
+					goto l443
+				l444:
+					position, thunkPosition = position443, thunkPosition443
 					{
-						position390, thunkPosition390 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseMenu]() {
-							goto l390
+						position445, thunkPosition445 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseDir]() {
+							goto l445
 						}
-						goto l387
-					l390:
-						position, thunkPosition = position390, thunkPosition390
+						goto l442
+					l445:
+						position, thunkPosition = position445, thunkPosition445
 					}
 					if !matchDot() {
-						goto l387
+						goto l442
 					}
 				}
-			l388:
-				goto l386
-			l387:
-				position, thunkPosition = position387, thunkPosition387
+			l443:
+				goto l441
+			l442:
+				position, thunkPosition = position442, thunkPosition442
 			}
-			if !p.rules[ruleHtmlBlockCloseMenu]() {
-				goto l385
+			if !p.rules[ruleHtmlBlockCloseDir]() {
+				goto l440
 			}
 			return true
-		l385:
+		l440:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 75 HtmlBlockOpenNoframes <- ('<' Spnl ('noframes' / 'NOFRAMES') Spnl HtmlAttribute* '>') */
+		/* 87 HtmlBlockOpenDiv <- ('<' Spnl ('div' / 'DIV') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l391
+				goto l446
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l391
+				goto l446
 			}
 			{
-				position392, thunkPosition392 := position, thunkPosition
-				if !matchString("noframes") {
-					goto l393
+				position447, thunkPosition447 := position, thunkPosition
+				if !matchString("div") {
+					goto l448
 				}
-				goto l392
-			l393:
-				position, thunkPosition = position392, thunkPosition392
-				if !matchString("NOFRAMES") {
-					goto l391
+				goto l447
+			l448:
+				position, thunkPosition = position447, thunkPosition447
+				if !matchString("DIV") {
+					goto l446
 				}
 			}
-		l392:
+		l447:
 			if !p.rules[ruleSpnl]() {
-				goto l391
+				goto l446
 			}
-		l394:
+		l449:
 			{
-				position395, thunkPosition395 := position, thunkPosition
+				position450, thunkPosition450 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l395
+					goto l450
 				}
-				goto l394
-			l395:
-				position, thunkPosition = position395, thunkPosition395
+				goto l449
+			l450:
+				position, thunkPosition = position450, thunkPosition450
 			}
 			if !matchChar('>') {
-				goto l391
+				goto l446
 			}
 			return true
-		l391:
+		l446:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 76 HtmlBlockCloseNoframes <- ('<' Spnl '/' ('noframes' / 'NOFRAMES') Spnl '>') */
+		/* 88 HtmlBlockCloseDiv <- ('<' Spnl '/' ('div' / 'DIV') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l396
+				goto l451
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l396
+				goto l451
 			}
 			if !matchChar('/') {
-				goto l396
+				goto l451
 			}
 			{
-				position397, thunkPosition397 := position, thunkPosition
-				if !matchString("noframes") {
-					goto l398
+				position452, thunkPosition452 := position, thunkPosition
+				if !matchString("div") {
+					goto l453
 				}
-				goto l397
-			l398:
-				position, thunkPosition = position397, thunkPosition397
-				if !matchString("NOFRAMES") {
-					goto l396
+				goto l452
+			l453:
+				position, thunkPosition = position452, thunkPosition452
+				if !matchString("DIV") {
+					goto l451
 				}
 			}
-		l397:
+		l452:
 			if !p.rules[ruleSpnl]() {
-				goto l396
+				goto l451
 			}
 			if !matchChar('>') {
-				goto l396
+				goto l451
 			}
 			return true
-		l396:
+		l451:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 77 HtmlBlockNoframes <- (HtmlBlockOpenNoframes (HtmlBlockNoframes / (!HtmlBlockCloseNoframes .))* HtmlBlockCloseNoframes) */
+		/* 89 HtmlBlockDiv <- (HtmlBlockOpenDiv (HtmlBlockDiv / (!HtmlBlockCloseDiv .))* HtmlBlockCloseDiv) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenNoframes]() {
-				goto l399
+			if !p.rules[ruleHtmlBlockOpenDiv]() {
+				goto l454
 			}
-		l400:
+		l455:
 			{
-				position401, thunkPosition401 := position, thunkPosition
+				position456, thunkPosition456 := position, thunkPosition
 				{
-					position402, thunkPosition402 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockNoframes]() {
-						goto l403
+					position457, thunkPosition457 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockDiv]() {
+						goto l458
 					}
-					goto l402
-				l403:
-					position, thunkPosition = position402, thunkPosition402
+					goto l457
+				l458:
+					position, thunkPosition = position457, thunkPosition457
 					{
-						position404, thunkPosition404 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseNoframes]() {
-							goto l404
+						position459, thunkPosition459 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseDiv]() {
+							goto l459
 						}
-						goto l401
-					l404:
-						position, thunkPosition = position404, thunkPosition404
+						goto l456
+					l459:
+						position, thunkPosition = position459, thunkPosition459
 					}
 					if !matchDot() {
-						goto l401
+						goto l456
 					}
 				}
-			l402:
-				goto l400
-			l401:
-				position, thunkPosition = position401, thunkPosition401
+			l457:
+				goto l455
+			l456:
+				position, thunkPosition = position456, thunkPosition456
 			}
-			if !p.rules[ruleHtmlBlockCloseNoframes]() {
-				goto l399
+			if !p.rules[ruleHtmlBlockCloseDiv]() {
+				goto l454
 			}
 			return true
-		l399:
+		l454:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 78 HtmlBlockOpenNoscript <- ('<' Spnl ('noscript' / 'NOSCRIPT') Spnl HtmlAttribute* '>') */
+		/* 90 HtmlBlockOpenDl <- ('<' Spnl ('dl' / 'DL') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l405
+				goto l460
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l405
+				goto l460
 			}
 			{
-				position406, thunkPosition406 := position, thunkPosition
-				if !matchString("noscript") {
-					goto l407
+				position461, thunkPosition461 := position, thunkPosition
+				if !matchString("dl") {
+					goto l462
 				}
-				goto l406
-			l407:
-				position, thunkPosition = position406, thunkPosition406
-				if !matchString("NOSCRIPT") {
-					goto l405
+				goto l461
+			l462:
+				position, thunkPosition = position461, thunkPosition461
+				if !matchString("DL") {
+					goto l460
 				}
 			}
-		l406:
+		l461:
 			if !p.rules[ruleSpnl]() {
-				goto l405
+				goto l460
 			}
-		l408:
+		l463:
 			{
-				position409, thunkPosition409 := position, thunkPosition
+				position464, thunkPosition464 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l409
+					goto l464
 				}
-				goto l408
-			l409:
-				position, thunkPosition = position409, thunkPosition409
+				goto l463
+			l464:
+				position, thunkPosition = position464, thunkPosition464
 			}
 			if !matchChar('>') {
-				goto l405
+				goto l460
 			}
 			return true
-		l405:
+		l460:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 79 HtmlBlockCloseNoscript <- ('<' Spnl '/' ('noscript' / 'NOSCRIPT') Spnl '>') */
+		/* 91 HtmlBlockCloseDl <- ('<' Spnl '/' ('dl' / 'DL') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l410
+				goto l465
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l410
+				goto l465
 			}
 			if !matchChar('/') {
-				goto l410
+				goto l465
 			}
 			{
-				position411, thunkPosition411 := position, thunkPosition
-				if !matchString("noscript") {
-					goto l412
+				position466, thunkPosition466 := position, thunkPosition
+				if !matchString("dl") {
+					goto l467
 				}
-				goto l411
-			l412:
-				position, thunkPosition = position411, thunkPosition411
-				if !matchString("NOSCRIPT") {
-					goto l410
+				goto l466
+			l467:
+				position, thunkPosition = position466, thunkPosition466
+				if !matchString("DL") {
+					goto l465
 				}
 			}
-		l411:
+		l466:
 			if !p.rules[ruleSpnl]() {
-				goto l410
+				goto l465
 			}
 			if !matchChar('>') {
-				goto l410
+				goto l465
 			}
 			return true
-		l410:
+		l465:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 80 HtmlBlockNoscript <- (HtmlBlockOpenNoscript (HtmlBlockNoscript / (!HtmlBlockCloseNoscript .))* HtmlBlockCloseNoscript) */
+		/* 92 HtmlBlockDl <- (HtmlBlockOpenDl (HtmlBlockDl / (!HtmlBlockCloseDl .))* HtmlBlockCloseDl) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenNoscript]() {
-				goto l413
+			if !p.rules[ruleHtmlBlockOpenDl]() {
+				goto l468
 			}
-		l414:
+		l469:
 			{
-				position415, thunkPosition415 := position, thunkPosition
+				position470, thunkPosition470 := position, thunkPosition
 				{
-					position416, thunkPosition416 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockNoscript]() {
-						goto l417
+					position471, thunkPosition471 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockDl]() {
+						goto l472
 					}
-					goto l416
-				l417:
-					position, thunkPosition = position416, thunkPosition416
+					goto l471
+				l472:
+					position, thunkPosition = position471, thunkPosition471
 					{
-						position418, thunkPosition418 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseNoscript]() {
-							goto l418
+						position473, thunkPosition473 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseDl]() {
+							goto l473
 						}
-						goto l415
-					l418:
-						position, thunkPosition = position418, thunkPosition418
+						goto l470
+					l473:
+						position, thunkPosition = position473, thunkPosition473
 					}
 					if !matchDot() {
-						goto l415
+						goto l470
 					}
 				}
-			l416:
-				goto l414
-			l415:
-				position, thunkPosition = position415, thunkPosition415
+			l471:
+				goto l469
+			l470:
+				position, thunkPosition = position470, thunkPosition470
 			}
-			if !p.rules[ruleHtmlBlockCloseNoscript]() {
-				goto l413
+			if !p.rules[ruleHtmlBlockCloseDl]() {
+				goto l468
 			}
 			return true
-		l413:
+		l468:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 81 HtmlBlockOpenOl <- ('<' Spnl ('ol' / 'OL') Spnl HtmlAttribute* '>') */
+		/* 93 HtmlBlockOpenFieldset <- ('<' Spnl ('fieldset' / 'FIELDSET') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l419
+				goto l474
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l419
+				goto l474
 			}
 			{
-				position420, thunkPosition420 := position, thunkPosition
-				if !matchString("ol") {
-					goto l421
+				position475, thunkPosition475 := position, thunkPosition
+				if !matchString("fieldset") {
+					goto l476
 				}
-				goto l420
-			l421:
-				position, thunkPosition = position420, thunkPosition420
-				if !matchString("OL") {
-					goto l419
+				goto l475
+			l476:
+				position, thunkPosition = position475, thunkPosition475
+				if !matchString("FIELDSET") {
+					goto l474
 				}
 			}
-		l420:
+		l475:
 			if !p.rules[ruleSpnl]() {
-				goto l419
+				goto l474
 			}
-		l422:
+		l477:
 			{
-				position423, thunkPosition423 := position, thunkPosition
+				position478, thunkPosition478 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l423
+					goto l478
 				}
-				goto l422
-			l423:
-				position, thunkPosition = position423, thunkPosition423
+				goto l477
+			l478:
+				position, thunkPosition = position478, thunkPosition478
 			}
 			if !matchChar('>') {
-				goto l419
+				goto l474
 			}
 			return true
-		l419:
+		l474:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 82 HtmlBlockCloseOl <- ('<' Spnl '/' ('ol' / 'OL') Spnl '>') */
+		/* 94 HtmlBlockCloseFieldset <- ('<' Spnl '/' ('fieldset' / 'FIELDSET') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l424
+				goto l479
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l424
+				goto l479
 			}
 			if !matchChar('/') {
-				goto l424
+				goto l479
 			}
 			{
-				position425, thunkPosition425 := position, thunkPosition
-				if !matchString("ol") {
-					goto l426
+				position480, thunkPosition480 := position, thunkPosition
+				if !matchString("fieldset") {
+					goto l481
 				}
-				goto l425
-			l426:
-				position, thunkPosition = position425, thunkPosition425
-				if !matchString("OL") {
-					goto l424
+				goto l480
+			l481:
+				position, thunkPosition = position480, thunkPosition480
+				if !matchString("FIELDSET") {
+					goto l479
 				}
 			}
-		l425:
+		l480:
 			if !p.rules[ruleSpnl]() {
-				goto l424
+				goto l479
 			}
 			if !matchChar('>') {
-				goto l424
+				goto l479
 			}
 			return true
-		l424:
+		l479:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 83 HtmlBlockOl <- (HtmlBlockOpenOl (HtmlBlockOl / (!HtmlBlockCloseOl .))* HtmlBlockCloseOl) */
+		/* 95 HtmlBlockFieldset <- (HtmlBlockOpenFieldset (HtmlBlockFieldset / (!HtmlBlockCloseFieldset .))* HtmlBlockCloseFieldset) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenOl]() {
-				goto l427
+			if !p.rules[ruleHtmlBlockOpenFieldset]() {
+				goto l482
 			}
-		l428:
+		l483:
 			{
-				position429, thunkPosition429 := position, thunkPosition
+				position484, thunkPosition484 := position, thunkPosition
 				{
-					position430, thunkPosition430 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockOl]() {
-						goto l431
+					position485, thunkPosition485 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockFieldset]() {
+						goto l486
 					}
-					goto l430
-				l431:
-					position, thunkPosition = position430, thunkPosition430
+					goto l485
+				l486:
+					position, thunkPosition = position485, thunkPosition485
 					{
-						position432, thunkPosition432 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseOl]() {
-							goto l432
+						position487, thunkPosition487 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseFieldset]() {
+							goto l487
 						}
-						goto l429
-					l432:
-						position, thunkPosition = position432, thunkPosition432
+						goto l484
+					l487:
+						position, thunkPosition = position487, thunkPosition487
 					}
 					if !matchDot() {
-						goto l429
+						goto l484
 					}
 				}
-			l430:
-				goto l428
-			l429:
-				position, thunkPosition = position429, thunkPosition429
+			l485:
+				goto l483
+			l484:
+				position, thunkPosition = position484, thunkPosition484
 			}
-			if !p.rules[ruleHtmlBlockCloseOl]() {
-				goto l427
+			if !p.rules[ruleHtmlBlockCloseFieldset]() {
+				goto l482
 			}
 			return true
-		l427:
+		l482:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 84 HtmlBlockOpenP <- ('<' Spnl ('p' / 'P') Spnl HtmlAttribute* '>') */
+		/* 96 HtmlBlockOpenForm <- ('<' Spnl ('form' / 'FORM') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l433
+				goto l488
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l433
+				goto l488
 			}
 			{
-				position434, thunkPosition434 := position, thunkPosition
-				if !matchChar('p') {
-					goto l435
+				position489, thunkPosition489 := position, thunkPosition
+				if !matchString("form") {
+					goto l490
 				}
-				goto l434
-			l435:
-				position, thunkPosition = position434, thunkPosition434
-				if !matchChar('P') {
-					goto l433
+				goto l489
+			l490:
+				position, thunkPosition = position489, thunkPosition489
+				if !matchString("FORM") {
+					goto l488
 				}
 			}
-		l434:
+		l489:
 			if !p.rules[ruleSpnl]() {
-				goto l433
+				goto l488
 			}
-		l436:
+		l491:
 			{
-				position437, thunkPosition437 := position, thunkPosition
+				position492, thunkPosition492 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l437
+					goto l492
 				}
-				goto l436
-			l437:
-				position, thunkPosition = position437, thunkPosition437
+				goto l491
+			l492:
+				position, thunkPosition = position492, thunkPosition492
 			}
 			if !matchChar('>') {
-				goto l433
+				goto l488
 			}
 			return true
-		l433:
+		l488:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 85 HtmlBlockCloseP <- ('<' Spnl '/' ('p' / 'P') Spnl '>') */
+		/* 97 HtmlBlockCloseForm <- ('<' Spnl '/' ('form' / 'FORM') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l438
+				goto l493
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l438
+				goto l493
 			}
 			if !matchChar('/') {
-				goto l438
+				goto l493
 			}
 			{
-				position439, thunkPosition439 := position, thunkPosition
-				if !matchChar('p') {
-					goto l440
+				position494, thunkPosition494 := position, thunkPosition
+				if !matchString("form") {
+					goto l495
 				}
-				goto l439
-			l440:
-				position, thunkPosition = position439, thunkPosition439
-				if !matchChar('P') {
-					goto l438
+				goto l494
+			l495:
+				position, thunkPosition = position494, thunkPosition494
+				if !matchString("FORM") {
+					goto l493
 				}
 			}
-		l439:
+		l494:
 			if !p.rules[ruleSpnl]() {
-				goto l438
+				goto l493
 			}
 			if !matchChar('>') {
-				goto l438
+				goto l493
 			}
 			return true
-		l438:
+		l493:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 86 HtmlBlockP <- (HtmlBlockOpenP (HtmlBlockP / (!HtmlBlockCloseP .))* HtmlBlockCloseP) */
+		/* 98 HtmlBlockForm <- (HtmlBlockOpenForm (HtmlBlockForm / (!HtmlBlockCloseForm .))* HtmlBlockCloseForm) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenP]() {
-				goto l441
+			if !p.rules[ruleHtmlBlockOpenForm]() {
+				goto l496
 			}
-		l442:
+		l497:
 			{
-				position443, thunkPosition443 := position, thunkPosition
+				position498, thunkPosition498 := position, thunkPosition
 				{
-					position444, thunkPosition444 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockP]() {
-						goto l445
+					position499, thunkPosition499 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockForm]() {
+						goto l500
 					}
-					goto l444
-				l445:
-					position, thunkPosition = position444, thunkPosition444
+					goto l499
+				l500:
+					position, thunkPosition = position499, thunkPosition499
 					{
-						position446, thunkPosition446 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseP]() {
-							goto l446
+						position501, thunkPosition501 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseForm]() {
+							goto l501
 						}
-						goto l443
-					l446:
-						position, thunkPosition = position446, thunkPosition446
+						goto l498
+					l501:
+						position, thunkPosition = position501, thunkPosition501
 					}
 					if !matchDot() {
-						goto l443
+						goto l498
 					}
 				}
-			l444:
-				goto l442
-			l443:
-				position, thunkPosition = position443, thunkPosition443
+			l499:
+				goto l497
+			l498:
+				position, thunkPosition = position498, thunkPosition498
 			}
-			if !p.rules[ruleHtmlBlockCloseP]() {
-				goto l441
+			if !p.rules[ruleHtmlBlockCloseForm]() {
+				goto l496
 			}
 			return true
-		l441:
+		l496:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 87 HtmlBlockOpenPre <- ('<' Spnl ('pre' / 'PRE') Spnl HtmlAttribute* '>') */
+		/* 99 HtmlBlockOpenH1 <- ('<' Spnl ('h1' / 'H1') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l447
+				goto l502
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l447
+				goto l502
 			}
 			{
-				position448, thunkPosition448 := position, thunkPosition
-				if !matchString("pre") {
-					goto l449
+				position503, thunkPosition503 := position, thunkPosition
+				if !matchString("h1") {
+					goto l504
 				}
-				goto l448
-			l449:
-				position, thunkPosition = position448, thunkPosition448
-				if !matchString("PRE") {
-					goto l447
+				goto l503
+			l504:
+				position, thunkPosition = position503, thunkPosition503
+				if !matchString("H1") {
+					goto l502
 				}
 			}
-		l448:
+		l503:
 			if !p.rules[ruleSpnl]() {
-				goto l447
+				goto l502
 			}
-		l450:
+		l505:
 			{
-				position451, thunkPosition451 := position, thunkPosition
+				position506, thunkPosition506 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l451
+					goto l506
 				}
-				goto l450
-			l451:
-				position, thunkPosition = position451, thunkPosition451
+				goto l505
+			l506:
+				position, thunkPosition = position506, thunkPosition506
 			}
 			if !matchChar('>') {
-				goto l447
+				goto l502
 			}
 			return true
-		l447:
+		l502:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 88 HtmlBlockClosePre <- ('<' Spnl '/' ('pre' / 'PRE') Spnl '>') */
+		/* 100 HtmlBlockCloseH1 <- ('<' Spnl '/' ('h1' / 'H1') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l452
+				goto l507
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l452
+				goto l507
 			}
 			if !matchChar('/') {
-				goto l452
+				goto l507
 			}
 			{
-				position453, thunkPosition453 := position, thunkPosition
-				if !matchString("pre") {
-					goto l454
+				position508, thunkPosition508 := position, thunkPosition
+				if !matchString("h1") {
+					goto l509
 				}
-				goto l453
-			l454:
-				position, thunkPosition = position453, thunkPosition453
-				if !matchString("PRE") {
-					goto l452
+				goto l508
+			l509:
+				position, thunkPosition = position508, thunkPosition508
+				if !matchString("H1") {
+					goto l507
 				}
 			}
-		l453:
+		l508:
 			if !p.rules[ruleSpnl]() {
-				goto l452
+				goto l507
 			}
 			if !matchChar('>') {
-				goto l452
+				goto l507
 			}
 			return true
-		l452:
+		l507:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 89 HtmlBlockPre <- (HtmlBlockOpenPre (HtmlBlockPre / (!HtmlBlockClosePre .))* HtmlBlockClosePre) */
+		/* 101 HtmlBlockH1 <- (HtmlBlockOpenH1 (HtmlBlockH1 / (!HtmlBlockCloseH1 .))* HtmlBlockCloseH1) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenPre]() {
-				goto l455
+			if !p.rules[ruleHtmlBlockOpenH1]() {
+				goto l510
 			}
-		l456:
+		l511:
 			{
-				position457, thunkPosition457 := position, thunkPosition
+				position512, thunkPosition512 := position, thunkPosition
 				{
-					position458, thunkPosition458 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockPre]() {
-						goto l459
+					position513, thunkPosition513 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockH1]() {
+						goto l514
 					}
-					goto l458
-				l459:
-					position, thunkPosition = position458, thunkPosition458
+					goto l513
+				l514:
+					position, thunkPosition = position513, thunkPosition513
 					{
-						position460, thunkPosition460 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockClosePre]() {
-							goto l460
+						position515, thunkPosition515 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseH1]() {
+							goto l515
 						}
-						goto l457
-					l460:
-						position, thunkPosition = position460, thunkPosition460
+						goto l512
+					l515:
+						position, thunkPosition = position515, thunkPosition515
 					}
 					if !matchDot() {
-						goto l457
+						goto l512
 					}
 				}
-			l458:
-				goto l456
-			l457:
-				position, thunkPosition = position457, thunkPosition457
+			l513:
+				goto l511
+			l512:
+				position, thunkPosition = position512, thunkPosition512
 			}
-			if !p.rules[ruleHtmlBlockClosePre]() {
-				goto l455
+			if !p.rules[ruleHtmlBlockCloseH1]() {
+				goto l510
 			}
 			return true
-		l455:
+		l510:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 90 HtmlBlockOpenTable <- ('<' Spnl ('table' / 'TABLE') Spnl HtmlAttribute* '>') */
+		/* 102 HtmlBlockOpenH2 <- ('<' Spnl ('h2' / 'H2') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l461
+				goto l516
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l461
+				goto l516
 			}
 			{
-				position462, thunkPosition462 := position, thunkPosition
-				if !matchString("table") {
-					goto l463
+				position517, thunkPosition517 := position, thunkPosition
+				if !matchString("h2") {
+					goto l518
 				}
-				goto l462
-			l463:
-				position, thunkPosition = position462, thunkPosition462
-				if !matchString("TABLE") {
-					goto l461
+				goto l517
+			l518:
+				position, thunkPosition = position517, thunkPosition517
+				if !matchString("H2") {
+					goto l516
 				}
 			}
-		l462:
+		l517:
 			if !p.rules[ruleSpnl]() {
-				goto l461
+				goto l516
 			}
-		l464:
+		l519:
 			{
-				position465, thunkPosition465 := position, thunkPosition
+				position520, thunkPosition520 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l465
+					goto l520
 				}
-				goto l464
-			l465:
-				position, thunkPosition = position465, thunkPosition465
+				goto l519
+			l520:
+				position, thunkPosition = position520, thunkPosition520
 			}
 			if !matchChar('>') {
-				goto l461
+				goto l516
 			}
 			return true
-		l461:
+		l516:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 91 HtmlBlockCloseTable <- ('<' Spnl '/' ('table' / 'TABLE') Spnl '>') */
+		/* 103 HtmlBlockCloseH2 <- ('<' Spnl '/' ('h2' / 'H2') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l466
+				goto l521
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l466
+				goto l521
 			}
 			if !matchChar('/') {
-				goto l466
+				goto l521
 			}
 			{
-				position467, thunkPosition467 := position, thunkPosition
-				if !matchString("table") {
-					goto l468
+				position522, thunkPosition522 := position, thunkPosition
+				if !matchString("h2") {
+					goto l523
 				}
-				goto l467
-			l468:
-				position, thunkPosition = position467, thunkPosition467
-				if !matchString("TABLE") {
-					goto l466
+				goto l522
+			l523:
+				position, thunkPosition = position522, thunkPosition522
+				if !matchString("H2") {
+					goto l521
 				}
 			}
-		l467:
+		l522:
 			if !p.rules[ruleSpnl]() {
-				goto l466
+				goto l521
 			}
 			if !matchChar('>') {
-				goto l466
+				goto l521
 			}
 			return true
-		l466:
+		l521:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 92 HtmlBlockTable <- (HtmlBlockOpenTable (HtmlBlockTable / (!HtmlBlockCloseTable .))* HtmlBlockCloseTable) */
+		/* 104 HtmlBlockH2 <- (HtmlBlockOpenH2 (HtmlBlockH2 / (!HtmlBlockCloseH2 .))* HtmlBlockCloseH2) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenTable]() {
-				goto l469
+			if !p.rules[ruleHtmlBlockOpenH2]() {
+				goto l524
 			}
-		l470:
+		l525:
 			{
-				position471, thunkPosition471 := position, thunkPosition
+				position526, thunkPosition526 := position, thunkPosition
 				{
-					position472, thunkPosition472 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockTable]() {
-						goto l473
+					position527, thunkPosition527 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockH2]() {
+						goto l528
 					}
-					goto l472
-				l473:
-					position, thunkPosition = position472, thunkPosition472
+					goto l527
+				l528:
+					position, thunkPosition = position527, thunkPosition527
 					{
-						position474, thunkPosition474 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseTable]() {
-							goto l474
+						position529, thunkPosition529 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseH2]() {
+							goto l529
 						}
-						goto l471
-					l474:
-						position, thunkPosition = position474, thunkPosition474
+						goto l526
+					l529:
+						position, thunkPosition = position529, thunkPosition529
 					}
 					if !matchDot() {
-						goto l471
+						goto l526
 					}
 				}
-			l472:
-				goto l470
-			l471:
-				position, thunkPosition = position471, thunkPosition471
+			l527:
+				goto l525
+			l526:
+				position, thunkPosition = position526, thunkPosition526
 			}
-			if !p.rules[ruleHtmlBlockCloseTable]() {
-				goto l469
+			if !p.rules[ruleHtmlBlockCloseH2]() {
+				goto l524
 			}
 			return true
-		l469:
+		l524:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 93 HtmlBlockOpenUl <- ('<' Spnl ('ul' / 'UL') Spnl HtmlAttribute* '>') */
+		/* 105 HtmlBlockOpenH3 <- ('<' Spnl ('h3' / 'H3') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l475
+				goto l530
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l475
+				goto l530
 			}
 			{
-				position476, thunkPosition476 := position, thunkPosition
-				if !matchString("ul") {
-					goto l477
+				position531, thunkPosition531 := position, thunkPosition
+				if !matchString("h3") {
+					goto l532
 				}
-				goto l476
-			l477:
-				position, thunkPosition = position476, thunkPosition476
-				if !matchString("UL") {
-					goto l475
+				goto l531
+			l532:
+				position, thunkPosition = position531, thunkPosition531
+				if !matchString("H3") {
+					goto l530
 				}
 			}
-		l476:
+		l531:
 			if !p.rules[ruleSpnl]() {
-				goto l475
+				goto l530
 			}
-		l478:
+		l533:
 			{
-				position479, thunkPosition479 := position, thunkPosition
+				position534, thunkPosition534 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l479
+					goto l534
 				}
-				goto l478
-			l479:
-				position, thunkPosition = position479, thunkPosition479
+				goto l533
+			l534:
+				position, thunkPosition = position534, thunkPosition534
 			}
 			if !matchChar('>') {
-				goto l475
+				goto l530
 			}
 			return true
-		l475:
+		l530:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 94 HtmlBlockCloseUl <- ('<' Spnl '/' ('ul' / 'UL') Spnl '>') */
+		/* 106 HtmlBlockCloseH3 <- ('<' Spnl '/' ('h3' / 'H3') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l480
+				goto l535
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l480
+				goto l535
 			}
 			if !matchChar('/') {
-				goto l480
+				goto l535
 			}
 			{
-				position481, thunkPosition481 := position, thunkPosition
-				if !matchString("ul") {
-					goto l482
+				position536, thunkPosition536 := position, thunkPosition
+				if !matchString("h3") {
+					goto l537
 				}
-				goto l481
-			l482:
-				position, thunkPosition = position481, thunkPosition481
-				if !matchString("UL") {
-					goto l480
+				goto l536
+			l537:
+				position, thunkPosition = position536, thunkPosition536
+				if !matchString("H3") {
+					goto l535
 				}
 			}
-		l481:
+		l536:
 			if !p.rules[ruleSpnl]() {
-				goto l480
+				goto l535
 			}
 			if !matchChar('>') {
-				goto l480
+				goto l535
 			}
 			return true
-		l480:
+		l535:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 95 HtmlBlockUl <- (HtmlBlockOpenUl (HtmlBlockUl / (!HtmlBlockCloseUl .))* HtmlBlockCloseUl) */
+		/* 107 HtmlBlockH3 <- (HtmlBlockOpenH3 (HtmlBlockH3 / (!HtmlBlockCloseH3 .))* HtmlBlockCloseH3) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenUl]() {
-				goto l483
+			if !p.rules[ruleHtmlBlockOpenH3]() {
+				goto l538
 			}
-		l484:
+		l539:
 			{
-				position485, thunkPosition485 := position, thunkPosition
+				position540, thunkPosition540 := position, thunkPosition
 				{
-					position486, thunkPosition486 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockUl]() {
-						goto l487
+					position541, thunkPosition541 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockH3]() {
+						goto l542
 					}
-					goto l486
-				l487:
-					position, thunkPosition = position486, thunkPosition486
+					goto l541
+				l542:
+					position, thunkPosition = position541, thunkPosition541
 					{
-						position488, thunkPosition488 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseUl]() {
-							goto l488
+						position543, thunkPosition543 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseH3]() {
+							goto l543
 						}
-						goto l485
-					l488:
-						position, thunkPosition = position488, thunkPosition488
+						goto l540
+					l543:
+						position, thunkPosition = position543, thunkPosition543
 					}
 					if !matchDot() {
-						goto l485
+						goto l540
 					}
 				}
-			l486:
-				goto l484
-			l485:
-				position, thunkPosition = position485, thunkPosition485
+			l541:
+				goto l539
+			l540:
+				position, thunkPosition = position540, thunkPosition540
 			}
-			if !p.rules[ruleHtmlBlockCloseUl]() {
-				goto l483
+			if !p.rules[ruleHtmlBlockCloseH3]() {
+				goto l538
 			}
 			return true
-		l483:
+		l538:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 96 HtmlBlockOpenDd <- ('<' Spnl ('dd' / 'DD') Spnl HtmlAttribute* '>') */
+		/* 108 HtmlBlockOpenH4 <- ('<' Spnl ('h4' / 'H4') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l489
+				goto l544
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l489
+				goto l544
 			}
 			{
-				position490, thunkPosition490 := position, thunkPosition
-				if !matchString("dd") {
-					goto l491
+				position545, thunkPosition545 := position, thunkPosition
+				if !matchString("h4") {
+					goto l546
 				}
-				goto l490
-			l491:
-				position, thunkPosition = position490, thunkPosition490
-				if !matchString("DD") {
-					goto l489
+				goto l545
+			l546:
+				position, thunkPosition = position545, thunkPosition545
+				if !matchString("H4") {
+					goto l544
 				}
 			}
-		l490:
+		l545:
 			if !p.rules[ruleSpnl]() {
-				goto l489
+				goto l544
 			}
-		l492:
+		l547:
 			{
-				position493, thunkPosition493 := position, thunkPosition
+				position548, thunkPosition548 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l493
+					goto l548
 				}
-				goto l492
-			l493:
-				position, thunkPosition = position493, thunkPosition493
+				goto l547
+			l548:
+				position, thunkPosition = position548, thunkPosition548
 			}
 			if !matchChar('>') {
-				goto l489
+				goto l544
 			}
 			return true
-		l489:
+		l544:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 97 HtmlBlockCloseDd <- ('<' Spnl '/' ('dd' / 'DD') Spnl '>') */
+		/* 109 HtmlBlockCloseH4 <- ('<' Spnl '/' ('h4' / 'H4') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l494
+				goto l549
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l494
+				goto l549
 			}
 			if !matchChar('/') {
-				goto l494
+				goto l549
 			}
 			{
-				position495, thunkPosition495 := position, thunkPosition
-				if !matchString("dd") {
-					goto l496
+				position550, thunkPosition550 := position, thunkPosition
+				if !matchString("h4") {
+					goto l551
 				}
-				goto l495
-			l496:
-				position, thunkPosition = position495, thunkPosition495
-				if !matchString("DD") {
-					goto l494
+				goto l550
+			l551:
+				position, thunkPosition = position550, thunkPosition550
+				if !matchString("H4") {
+					goto l549
 				}
 			}
-		l495:
+		l550:
 			if !p.rules[ruleSpnl]() {
-				goto l494
+				goto l549
 			}
 			if !matchChar('>') {
-				goto l494
+				goto l549
 			}
 			return true
-		l494:
+		l549:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 98 HtmlBlockDd <- (HtmlBlockOpenDd (HtmlBlockDd / (!HtmlBlockCloseDd .))* HtmlBlockCloseDd) */
+		/* 110 HtmlBlockH4 <- (HtmlBlockOpenH4 (HtmlBlockH4 / (!HtmlBlockCloseH4 .))* HtmlBlockCloseH4) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenDd]() {
-				goto l497
+			if !p.rules[ruleHtmlBlockOpenH4]() {
+				goto l552
 			}
-		l498:
+		l553:
 			{
-				position499, thunkPosition499 := position, thunkPosition
+				position554, thunkPosition554 := position, thunkPosition
 				{
-					position500, thunkPosition500 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockDd]() {
-						goto l501
+					position555, thunkPosition555 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockH4]() {
+						goto l556
 					}
-					goto l500
-				l501:
-					position, thunkPosition = position500, thunkPosition500
+					goto l555
+				l556:
+					position, thunkPosition = position555, thunkPosition555
 					{
-						position502, thunkPosition502 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseDd]() {
-							goto l502
+						position557, thunkPosition557 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseH4]() {
+							goto l557
 						}
-						goto l499
-					l502:
-						position, thunkPosition = position502, thunkPosition502
+						goto l554
+					l557:
+						position, thunkPosition = position557, thunkPosition557
 					}
 					if !matchDot() {
-						goto l499
+						goto l554
 					}
 				}
-			l500:
-				goto l498
-			l499:
-				position, thunkPosition = position499, thunkPosition499
+			l555:
+				goto l553
+			l554:
+				position, thunkPosition = position554, thunkPosition554
 			}
-			if !p.rules[ruleHtmlBlockCloseDd]() {
-				goto l497
+			if !p.rules[ruleHtmlBlockCloseH4]() {
+				goto l552
 			}
 			return true
-		l497:
+		l552:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 99 HtmlBlockOpenDt <- ('<' Spnl ('dt' / 'DT') Spnl HtmlAttribute* '>') */
+		/* 111 HtmlBlockOpenH5 <- ('<' Spnl ('h5' / 'H5') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l503
+				goto l558
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l503
+				goto l558
 			}
 			{
-				position504, thunkPosition504 := position, thunkPosition
-				if !matchString("dt") {
-					goto l505
+				position559, thunkPosition559 := position, thunkPosition
+				if !matchString("h5") {
+					goto l560
 				}
-				goto l504
-			l505:
-				position, thunkPosition = position504, thunkPosition504
-				if !matchString("DT") {
-					goto l503
+				goto l559
+			l560:
+				position, thunkPosition = position559, thunkPosition559
+				if !matchString("H5") {
+					goto l558
 				}
 			}
-		l504:
+		l559:
 			if !p.rules[ruleSpnl]() {
-				goto l503
+				goto l558
 			}
-		l506:
+		l561:
 			{
-				position507, thunkPosition507 := position, thunkPosition
+				position562, thunkPosition562 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l507
+					goto l562
 				}
-				goto l506
-			l507:
-				position, thunkPosition = position507, thunkPosition507
+				goto l561
+			l562:
+				position, thunkPosition = position562, thunkPosition562
 			}
 			if !matchChar('>') {
-				goto l503
+				goto l558
 			}
 			return true
-		l503:
+		l558:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 100 HtmlBlockCloseDt <- ('<' Spnl '/' ('dt' / 'DT') Spnl '>') */
+		/* 112 HtmlBlockCloseH5 <- ('<' Spnl '/' ('h5' / 'H5') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l508
+				goto l563
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l508
+				goto l563
 			}
 			if !matchChar('/') {
-				goto l508
+				goto l563
 			}
 			{
-				position509, thunkPosition509 := position, thunkPosition
-				if !matchString("dt") {
-					goto l510
+				position564, thunkPosition564 := position, thunkPosition
+				if !matchString("h5") {
+					goto l565
 				}
-				goto l509
-			l510:
-				position, thunkPosition = position509, thunkPosition509
-				if !matchString("DT") {
-					goto l508
+				goto l564
+			l565:
+				position, thunkPosition = position564, thunkPosition564
+				if !matchString("H5") {
+					goto l563
 				}
 			}
-		l509:
+		l564:
 			if !p.rules[ruleSpnl]() {
-				goto l508
+				goto l563
 			}
 			if !matchChar('>') {
-				goto l508
+				goto l563
 			}
 			return true
-		l508:
+		l563:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 101 HtmlBlockDt <- (HtmlBlockOpenDt (HtmlBlockDt / (!HtmlBlockCloseDt .))* HtmlBlockCloseDt) */
+		/* 113 HtmlBlockH5 <- (HtmlBlockOpenH5 (HtmlBlockH5 / (!HtmlBlockCloseH5 .))* HtmlBlockCloseH5) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenDt]() {
-				goto l511
+			if !p.rules[ruleHtmlBlockOpenH5]() {
+				goto l566
 			}
-		l512:
+		l567:
 			{
-				position513, thunkPosition513 := position, thunkPosition
+				position568, thunkPosition568 := position, thunkPosition
 				{
-					position514, thunkPosition514 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockDt]() {
-						goto l515
+					position569, thunkPosition569 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockH5]() {
+						goto l570
 					}
-					goto l514
-				l515:
-					position, thunkPosition = position514, thunkPosition514
+					goto l569
+				l570:
+					position, thunkPosition = position569, thunkPosition569
 					{
-						position516, thunkPosition516 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseDt]() {
-							goto l516
+						position571, thunkPosition571 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseH5]() {
+							goto l571
 						}
-						goto l513
-					l516:
-						position, thunkPosition = position516, thunkPosition516
+						goto l568
+					l571:
+						position, thunkPosition = position571, thunkPosition571
 					}
 					if !matchDot() {
-						goto l513
+						goto l568
 					}
 				}
-			l514:
-				goto l512
-			l513:
-				position, thunkPosition = position513, thunkPosition513
+			l569:
+				goto l567
+			l568:
+				position, thunkPosition = position568, thunkPosition568
 			}
-			if !p.rules[ruleHtmlBlockCloseDt]() {
-				goto l511
+			if !p.rules[ruleHtmlBlockCloseH5]() {
+				goto l566
 			}
 			return true
-		l511:
+		l566:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 102 HtmlBlockOpenFrameset <- ('<' Spnl ('frameset' / 'FRAMESET') Spnl HtmlAttribute* '>') */
+		/* 114 HtmlBlockOpenH6 <- ('<' Spnl ('h6' / 'H6') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l517
+				goto l572
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l517
+				goto l572
 			}
 			{
-				position518, thunkPosition518 := position, thunkPosition
-				if !matchString("frameset") {
-					goto l519
+				position573, thunkPosition573 := position, thunkPosition
+				if !matchString("h6") {
+					goto l574
 				}
-				goto l518
-			l519:
-				position, thunkPosition = position518, thunkPosition518
-				if !matchString("FRAMESET") {
-					goto l517
+				goto l573
+			l574:
+				position, thunkPosition = position573, thunkPosition573
+				if !matchString("H6") {
+					goto l572
 				}
 			}
-		l518:
+		l573:
 			if !p.rules[ruleSpnl]() {
-				goto l517
+				goto l572
 			}
-		l520:
+		l575:
 			{
-				position521, thunkPosition521 := position, thunkPosition
+				position576, thunkPosition576 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l521
+					goto l576
 				}
-				goto l520
-			l521:
-				position, thunkPosition = position521, thunkPosition521
+				goto l575
+			l576:
+				position, thunkPosition = position576, thunkPosition576
 			}
 			if !matchChar('>') {
-				goto l517
+				goto l572
 			}
 			return true
-		l517:
+		l572:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 103 HtmlBlockCloseFrameset <- ('<' Spnl '/' ('frameset' / 'FRAMESET') Spnl '>') */
+		/* 115 HtmlBlockCloseH6 <- ('<' Spnl '/' ('h6' / 'H6') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l522
+				goto l577
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l522
+				goto l577
 			}
 			if !matchChar('/') {
-				goto l522
+				goto l577
 			}
 			{
-				position523, thunkPosition523 := position, thunkPosition
-				if !matchString("frameset") {
-					goto l524
+				position578, thunkPosition578 := position, thunkPosition
+				if !matchString("h6") {
+					goto l579
 				}
-				goto l523
-			l524:
-				position, thunkPosition = position523, thunkPosition523
-				if !matchString("FRAMESET") {
-					goto l522
+				goto l578
+			l579:
+				position, thunkPosition = position578, thunkPosition578
+				if !matchString("H6") {
+					goto l577
 				}
 			}
-		l523:
+		l578:
 			if !p.rules[ruleSpnl]() {
-				goto l522
+				goto l577
 			}
 			if !matchChar('>') {
-				goto l522
+				goto l577
 			}
 			return true
-		l522:
+		l577:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 104 HtmlBlockFrameset <- (HtmlBlockOpenFrameset (HtmlBlockFrameset / (!HtmlBlockCloseFrameset .))* HtmlBlockCloseFrameset) */
+		/* 116 HtmlBlockH6 <- (HtmlBlockOpenH6 (HtmlBlockH6 / (!HtmlBlockCloseH6 .))* HtmlBlockCloseH6) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenFrameset]() {
-				goto l525
+			if !p.rules[ruleHtmlBlockOpenH6]() {
+				goto l580
 			}
-		l526:
+		l581:
 			{
-				position527, thunkPosition527 := position, thunkPosition
+				position582, thunkPosition582 := position, thunkPosition
 				{
-					position528, thunkPosition528 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockFrameset]() {
-						goto l529
+					position583, thunkPosition583 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockH6]() {
+						goto l584
 					}
-					goto l528
-				l529:
-					position, thunkPosition = position528, thunkPosition528
+					goto l583
+				l584:
+					position, thunkPosition = position583, thunkPosition583
 					{
-						position530, thunkPosition530 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseFrameset]() {
-							goto l530
+						position585, thunkPosition585 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseH6]() {
+							goto l585
 						}
-						goto l527
-					l530:
-						position, thunkPosition = position530, thunkPosition530
+						goto l582
+					l585:
+						position, thunkPosition = position585, thunkPosition585
 					}
 					if !matchDot() {
-						goto l527
+						goto l582
 					}
 				}
-			l528:
-				goto l526
-			l527:
-				position, thunkPosition = position527, thunkPosition527
+			l583:
+				goto l581
+			l582:
+				position, thunkPosition = position582, thunkPosition582
 			}
-			if !p.rules[ruleHtmlBlockCloseFrameset]() {
-				goto l525
+			if !p.rules[ruleHtmlBlockCloseH6]() {
+				goto l580
 			}
 			return true
-		l525:
+		l580:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 105 HtmlBlockOpenLi <- ('<' Spnl ('li' / 'LI') Spnl HtmlAttribute* '>') */
+		/* 117 HtmlBlockOpenMenu <- ('<' Spnl ('menu' / 'MENU') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l531
+				goto l586
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l531
+				goto l586
 			}
 			{
-				position532, thunkPosition532 := position, thunkPosition
-				if !matchString("li") {
-					goto l533
+				position587, thunkPosition587 := position, thunkPosition
+				if !matchString("menu") {
+					goto l588
 				}
-				goto l532
-			l533:
-				position, thunkPosition = position532, thunkPosition532
-				if !matchString("LI") {
-					goto l531
+				goto l587
+			l588:
+				position, thunkPosition = position587, thunkPosition587
+				if !matchString("MENU") {
+					goto l586
 				}
 			}
-		l532:
+		l587:
 			if !p.rules[ruleSpnl]() {
-				goto l531
+				goto l586
 			}
-		l534:
+		l589:
 			{
-				position535, thunkPosition535 := position, thunkPosition
+				position590, thunkPosition590 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l535
+					goto l590
 				}
-				goto l534
-			l535:
-				position, thunkPosition = position535, thunkPosition535
+				goto l589
+			l590:
+				position, thunkPosition = position590, thunkPosition590
 			}
 			if !matchChar('>') {
-				goto l531
+				goto l586
 			}
 			return true
-		l531:
+		l586:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 106 HtmlBlockCloseLi <- ('<' Spnl '/' ('li' / 'LI') Spnl '>') */
+		/* 118 HtmlBlockCloseMenu <- ('<' Spnl '/' ('menu' / 'MENU') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l536
+				goto l591
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l536
+				goto l591
 			}
 			if !matchChar('/') {
-				goto l536
+				goto l591
 			}
 			{
-				position537, thunkPosition537 := position, thunkPosition
-				if !matchString("li") {
-					goto l538
+				position592, thunkPosition592 := position, thunkPosition
+				if !matchString("menu") {
+					goto l593
 				}
-				goto l537
-			l538:
-				position, thunkPosition = position537, thunkPosition537
-				if !matchString("LI") {
-					goto l536
+				goto l592
+			l593:
+				position, thunkPosition = position592, thunkPosition592
+				if !matchString("MENU") {
+					goto l591
 				}
 			}
-		l537:
+		l592:
 			if !p.rules[ruleSpnl]() {
-				goto l536
+				goto l591
 			}
 			if !matchChar('>') {
-				goto l536
+				goto l591
 			}
 			return true
-		l536:
+		l591:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 107 HtmlBlockLi <- (HtmlBlockOpenLi (HtmlBlockLi / (!HtmlBlockCloseLi .))* HtmlBlockCloseLi) */
+		/* 119 HtmlBlockMenu <- (HtmlBlockOpenMenu (HtmlBlockMenu / (!HtmlBlockCloseMenu .))* HtmlBlockCloseMenu) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenLi]() {
-				goto l539
+			if !p.rules[ruleHtmlBlockOpenMenu]() {
+				goto l594
 			}
-		l540:
+		l595:
 			{
-				position541, thunkPosition541 := position, thunkPosition
+				position596, thunkPosition596 := position, thunkPosition
 				{
-					position542, thunkPosition542 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockLi]() {
-						goto l543
+					position597, thunkPosition597 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockMenu]() {
+						goto l598
 					}
-					goto l542
-				l543:
-					position, thunkPosition = position542, thunkPosition542
+					goto l597
+				l598:
+					position, thunkPosition = position597, thunkPosition597
 					{
-						position544, thunkPosition544 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseLi]() {
-							goto l544
+						position599, thunkPosition599 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseMenu]() {
+							goto l599
 						}
-						goto l541
-					l544:
-						position, thunkPosition = position544, thunkPosition544
+						goto l596
+					l599:
+						position, thunkPosition = position599, thunkPosition599
 					}
 					if !matchDot() {
-						goto l541
+						goto l596
 					}
 				}
-			l542:
-				goto l540
-			l541:
-				position, thunkPosition = position541, thunkPosition541
+			l597:
+				goto l595
+			l596:
+				position, thunkPosition = position596, thunkPosition596
 			}
-			if !p.rules[ruleHtmlBlockCloseLi]() {
-				goto l539
+			if !p.rules[ruleHtmlBlockCloseMenu]() {
+				goto l594
 			}
 			return true
-		l539:
+		l594:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 108 HtmlBlockOpenTbody <- ('<' Spnl ('tbody' / 'TBODY') Spnl HtmlAttribute* '>') */
+		/* 120 HtmlBlockOpenNoframes <- ('<' Spnl ('noframes' / 'NOFRAMES') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l545
+				goto l600
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l545
+				goto l600
 			}
 			{
-				position546, thunkPosition546 := position, thunkPosition
-				if !matchString("tbody") {
-					goto l547
+				position601, thunkPosition601 := position, thunkPosition
+				if !matchString("noframes") {
+					goto l602
 				}
-				goto l546
-			l547:
-				position, thunkPosition = position546, thunkPosition546
-				if !matchString("TBODY") {
-					goto l545
+				goto l601
+			l602:
+				position, thunkPosition = position601, thunkPosition601
+				if !matchString("NOFRAMES") {
+					goto l600
 				}
 			}
-		l546:
+		l601:
 			if !p.rules[ruleSpnl]() {
-				goto l545
+				goto l600
 			}
-		l548:
+		l603:
 			{
-				position549, thunkPosition549 := position, thunkPosition
+				position604, thunkPosition604 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l549
+					goto l604
 				}
-				goto l548
-			l549:
-				position, thunkPosition = position549, thunkPosition549
+				goto l603
+			l604:
+				position, thunkPosition = position604, thunkPosition604
 			}
 			if !matchChar('>') {
-				goto l545
+				goto l600
 			}
 			return true
-		l545:
+		l600:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 109 HtmlBlockCloseTbody <- ('<' Spnl '/' ('tbody' / 'TBODY') Spnl '>') */
+		/* 121 HtmlBlockCloseNoframes <- ('<' Spnl '/' ('noframes' / 'NOFRAMES') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l550
+				goto l605
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l550
+				goto l605
 			}
 			if !matchChar('/') {
-				goto l550
+				goto l605
 			}
 			{
-				position551, thunkPosition551 := position, thunkPosition
-				if !matchString("tbody") {
-					goto l552
+				position606, thunkPosition606 := position, thunkPosition
+				if !matchString("noframes") {
+					goto l607
 				}
-				goto l551
-			l552:
-				position, thunkPosition = position551, thunkPosition551
-				if !matchString("TBODY") {
-					goto l550
+				goto l606
+			l607:
+				position, thunkPosition = position606, thunkPosition606
+				if !matchString("NOFRAMES") {
+					goto l605
 				}
 			}
-		l551:
+		l606:
 			if !p.rules[ruleSpnl]() {
-				goto l550
+				goto l605
 			}
 			if !matchChar('>') {
-				goto l550
+				goto l605
 			}
 			return true
-		l550:
+		l605:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 110 HtmlBlockTbody <- (HtmlBlockOpenTbody (HtmlBlockTbody / (!HtmlBlockCloseTbody .))* HtmlBlockCloseTbody) */
+		/* 122 HtmlBlockNoframes <- (HtmlBlockOpenNoframes (HtmlBlockNoframes / (!HtmlBlockCloseNoframes .))* HtmlBlockCloseNoframes) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenTbody]() {
-				goto l553
+			if !p.rules[ruleHtmlBlockOpenNoframes]() {
+				goto l608
 			}
-		l554:
+		l609:
 			{
-				position555, thunkPosition555 := position, thunkPosition
+				position610, thunkPosition610 := position, thunkPosition
 				{
-					position556, thunkPosition556 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockTbody]() {
-						goto l557
+					position611, thunkPosition611 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockNoframes]() {
+						goto l612
 					}
-					goto l556
-				l557:
-					position, thunkPosition = position556, thunkPosition556
+					goto l611
+				l612:
+					position, thunkPosition = position611, thunkPosition611
 					{
-						position558, thunkPosition558 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseTbody]() {
-							goto l558
+						position613, thunkPosition613 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseNoframes]() {
+							goto l613
 						}
-						goto l555
-					l558:
-						position, thunkPosition = position558, thunkPosition558
+						goto l610
+					l613:
+						position, thunkPosition = position613, thunkPosition613
 					}
 					if !matchDot() {
-						goto l555
+						goto l610
 					}
 				}
-			l556:
-				goto l554
-			l555:
-				position, thunkPosition = position555, thunkPosition555
+			l611:
+				goto l609
+			l610:
+				position, thunkPosition = position610, thunkPosition610
 			}
-			if !p.rules[ruleHtmlBlockCloseTbody]() {
-				goto l553
+			if !p.rules[ruleHtmlBlockCloseNoframes]() {
+				goto l608
 			}
 			return true
-		l553:
+		l608:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 111 HtmlBlockOpenTd <- ('<' Spnl ('td' / 'TD') Spnl HtmlAttribute* '>') */
+		/* 123 HtmlBlockOpenNoscript <- ('<' Spnl ('noscript' / 'NOSCRIPT') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l559
+				goto l614
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l559
+				goto l614
 			}
 			{
-				position560, thunkPosition560 := position, thunkPosition
-				if !matchString("td") {
-					goto l561
+				position615, thunkPosition615 := position, thunkPosition
+				if !matchString("noscript") {
+					goto l616
 				}
-				goto l560
-			l561:
-				position, thunkPosition = position560, thunkPosition560
-				if !matchString("TD") {
-					goto l559
+				goto l615
+			l616:
+				position, thunkPosition = position615, thunkPosition615
+				if !matchString("NOSCRIPT") {
+					goto l614
 				}
 			}
-		l560:
+		l615:
 			if !p.rules[ruleSpnl]() {
-				goto l559
+				goto l614
 			}
-		l562:
+		l617:
 			{
-				position563, thunkPosition563 := position, thunkPosition
+				position618, thunkPosition618 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l563
+					goto l618
 				}
-				goto l562
-			l563:
-				position, thunkPosition = position563, thunkPosition563
+				goto l617
+			l618:
+				position, thunkPosition = position618, thunkPosition618
 			}
 			if !matchChar('>') {
-				goto l559
+				goto l614
 			}
 			return true
-		l559:
+		l614:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 112 HtmlBlockCloseTd <- ('<' Spnl '/' ('td' / 'TD') Spnl '>') */
+		/* 124 HtmlBlockCloseNoscript <- ('<' Spnl '/' ('noscript' / 'NOSCRIPT') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l564
+				goto l619
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l564
+				goto l619
 			}
 			if !matchChar('/') {
-				goto l564
+				goto l619
 			}
 			{
-				position565, thunkPosition565 := position, thunkPosition
-				if !matchString("td") {
-					goto l566
+				position620, thunkPosition620 := position, thunkPosition
+				if !matchString("noscript") {
+					goto l621
 				}
-				goto l565
-			l566:
-				position, thunkPosition = position565, thunkPosition565
-				if !matchString("TD") {
-					goto l564
+				goto l620
+			l621:
+				position, thunkPosition = position620, thunkPosition620
+				if !matchString("NOSCRIPT") {
+					goto l619
 				}
 			}
-		l565:
+		l620:
 			if !p.rules[ruleSpnl]() {
-				goto l564
+				goto l619
 			}
 			if !matchChar('>') {
-				goto l564
+				goto l619
 			}
 			return true
-		l564:
+		l619:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 113 HtmlBlockTd <- (HtmlBlockOpenTd (HtmlBlockTd / (!HtmlBlockCloseTd .))* HtmlBlockCloseTd) */
+		/* 125 HtmlBlockNoscript <- (HtmlBlockOpenNoscript (HtmlBlockNoscript / (!HtmlBlockCloseNoscript .))* HtmlBlockCloseNoscript) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenTd]() {
-				goto l567
+			if !p.rules[ruleHtmlBlockOpenNoscript]() {
+				goto l622
 			}
-		l568:
+		l623:
 			{
-				position569, thunkPosition569 := position, thunkPosition
+				position624, thunkPosition624 := position, thunkPosition
 				{
-					position570, thunkPosition570 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockTd]() {
-						goto l571
+					position625, thunkPosition625 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockNoscript]() {
+						goto l626
 					}
-					goto l570
-				l571:
-					position, thunkPosition = position570, thunkPosition570
+					goto l625
+				l626:
+					position, thunkPosition = position625, thunkPosition625
 					{
-						position572, thunkPosition572 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseTd]() {
-							goto l572
+						position627, thunkPosition627 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseNoscript]() {
+							goto l627
 						}
-						goto l569
-					l572:
-						position, thunkPosition = position572, thunkPosition572
+						goto l624
+					l627:
+						position, thunkPosition = position627, thunkPosition627
 					}
 					if !matchDot() {
-						goto l569
+						goto l624
 					}
 				}
-			l570:
-				goto l568
-			l569:
-				position, thunkPosition = position569, thunkPosition569
+			l625:
+				goto l623
+			l624:
+				position, thunkPosition = position624, thunkPosition624
 			}
-			if !p.rules[ruleHtmlBlockCloseTd]() {
-				goto l567
+			if !p.rules[ruleHtmlBlockCloseNoscript]() {
+				goto l622
 			}
 			return true
-		l567:
+		l622:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 114 HtmlBlockOpenTfoot <- ('<' Spnl ('tfoot' / 'TFOOT') Spnl HtmlAttribute* '>') */
+		/* 126 HtmlBlockOpenOl <- ('<' Spnl ('ol' / 'OL') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l573
+				goto l628
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l573
+				goto l628
 			}
 			{
-				position574, thunkPosition574 := position, thunkPosition
-				if !matchString("tfoot") {
-					goto l575
+				position629, thunkPosition629 := position, thunkPosition
+				if !matchString("ol") {
+					goto l630
 				}
-				goto l574
-			l575:
-				position, thunkPosition = position574, thunkPosition574
-				if !matchString("TFOOT") {
-					goto l573
+				goto l629
+			l630:
+				position, thunkPosition = position629, thunkPosition629
+				if !matchString("OL") {
+					goto l628
 				}
 			}
-		l574:
+		l629:
 			if !p.rules[ruleSpnl]() {
-				goto l573
+				goto l628
 			}
-		l576:
+		l631:
 			{
-				position577, thunkPosition577 := position, thunkPosition
+				position632, thunkPosition632 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l577
+					goto l632
 				}
-				goto l576
-			l577:
-				position, thunkPosition = position577, thunkPosition577
+				goto l631
+			l632:
+				position, thunkPosition = position632, thunkPosition632
 			}
 			if !matchChar('>') {
-				goto l573
+				goto l628
 			}
 			return true
-		l573:
+		l628:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 115 HtmlBlockCloseTfoot <- ('<' Spnl '/' ('tfoot' / 'TFOOT') Spnl '>') */
+		/* 127 HtmlBlockCloseOl <- ('<' Spnl '/' ('ol' / 'OL') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l578
+				goto l633
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l578
+				goto l633
 			}
 			if !matchChar('/') {
-				goto l578
+				goto l633
 			}
 			{
-				position579, thunkPosition579 := position, thunkPosition
-				if !matchString("tfoot") {
-					goto l580
+				position634, thunkPosition634 := position, thunkPosition
+				if !matchString("ol") {
+					goto l635
 				}
-				goto l579
-			l580:
-				position, thunkPosition = position579, thunkPosition579
-				if !matchString("TFOOT") {
-					goto l578
+				goto l634
+			l635:
+				position, thunkPosition = position634, thunkPosition634
+				if !matchString("OL") {
+					goto l633
 				}
 			}
-		l579:
+		l634:
 			if !p.rules[ruleSpnl]() {
-				goto l578
+				goto l633
 			}
 			if !matchChar('>') {
-				goto l578
+				goto l633
 			}
 			return true
-		l578:
+		l633:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 116 HtmlBlockTfoot <- (HtmlBlockOpenTfoot (HtmlBlockTfoot / (!HtmlBlockCloseTfoot .))* HtmlBlockCloseTfoot) */
+		/* 128 HtmlBlockOl <- (HtmlBlockOpenOl (HtmlBlockOl / (!HtmlBlockCloseOl .))* HtmlBlockCloseOl) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenTfoot]() {
-				goto l581
+			if !p.rules[ruleHtmlBlockOpenOl]() {
+				goto l636
 			}
-		l582:
+		l637:
 			{
-				position583, thunkPosition583 := position, thunkPosition
+				position638, thunkPosition638 := position, thunkPosition
 				{
-					position584, thunkPosition584 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockTfoot]() {
-						goto l585
+					position639, thunkPosition639 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockOl]() {
+						goto l640
 					}
-					goto l584
-				l585:
-					position, thunkPosition = position584, thunkPosition584
+					goto l639
+				l640:
+					position, thunkPosition = position639, thunkPosition639
 					{
-						position586, thunkPosition586 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseTfoot]() {
-							goto l586
+						position641, thunkPosition641 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseOl]() {
+							goto l641
 						}
-						goto l583
-					l586:
-						position, thunkPosition = position586, thunkPosition586
+						goto l638
+					l641:
+						position, thunkPosition = position641, thunkPosition641
 					}
 					if !matchDot() {
-						goto l583
+						goto l638
 					}
 				}
-			l584:
-				goto l582
-			l583:
-				position, thunkPosition = position583, thunkPosition583
+			l639:
+				goto l637
+			l638:
+				position, thunkPosition = position638, thunkPosition638
 			}
-			if !p.rules[ruleHtmlBlockCloseTfoot]() {
-				goto l581
+			if !p.rules[ruleHtmlBlockCloseOl]() {
+				goto l636
 			}
 			return true
-		l581:
+		l636:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 117 HtmlBlockOpenTh <- ('<' Spnl ('th' / 'TH') Spnl HtmlAttribute* '>') */
+		/* 129 HtmlBlockOpenP <- ('<' Spnl ('p' / 'P') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l587
+				goto l642
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l587
+				goto l642
 			}
 			{
-				position588, thunkPosition588 := position, thunkPosition
-				if !matchString("th") {
-					goto l589
+				position643, thunkPosition643 := position, thunkPosition
+				if !matchChar('p') {
+					goto l644
 				}
-				goto l588
-			l589:
-				position, thunkPosition = position588, thunkPosition588
-				if !matchString("TH") {
-					goto l587
+				goto l643
+			l644:
+				position, thunkPosition = position643, thunkPosition643
+				if !matchChar('P') {
+					goto l642
 				}
 			}
-		l588:
+		l643:
 			if !p.rules[ruleSpnl]() {
-				goto l587
+				goto l642
 			}
-		l590:
+		l645:
 			{
-				position591, thunkPosition591 := position, thunkPosition
+				position646, thunkPosition646 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l591
+					goto l646
 				}
-				goto l590
-			l591:
-				position, thunkPosition = position591, thunkPosition591
+				goto l645
+			l646:
+				position, thunkPosition = position646, thunkPosition646
 			}
 			if !matchChar('>') {
-				goto l587
+				goto l642
 			}
 			return true
-		l587:
+		l642:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 118 HtmlBlockCloseTh <- ('<' Spnl '/' ('th' / 'TH') Spnl '>') */
+		/* 130 HtmlBlockCloseP <- ('<' Spnl '/' ('p' / 'P') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l592
+				goto l647
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l592
+				goto l647
 			}
 			if !matchChar('/') {
-				goto l592
+				goto l647
 			}
 			{
-				position593, thunkPosition593 := position, thunkPosition
-				if !matchString("th") {
-					goto l594
+				position648, thunkPosition648 := position, thunkPosition
+				if !matchChar('p') {
+					goto l649
 				}
-				goto l593
-			l594:
-				position, thunkPosition = position593, thunkPosition593
-				if !matchString("TH") {
-					goto l592
+				goto l648
+			l649:
+				position, thunkPosition = position648, thunkPosition648
+				if !matchChar('P') {
+					goto l647
 				}
 			}
-		l593:
+		l648:
 			if !p.rules[ruleSpnl]() {
-				goto l592
+				goto l647
 			}
 			if !matchChar('>') {
-				goto l592
+				goto l647
 			}
 			return true
-		l592:
+		l647:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 119 HtmlBlockTh <- (HtmlBlockOpenTh (HtmlBlockTh / (!HtmlBlockCloseTh .))* HtmlBlockCloseTh) */
+		/* 131 HtmlBlockP <- (HtmlBlockOpenP (HtmlBlockP / (!HtmlBlockCloseP .))* HtmlBlockCloseP) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenTh]() {
-				goto l595
+			if !p.rules[ruleHtmlBlockOpenP]() {
+				goto l650
 			}
-		l596:
+		l651:
 			{
-				position597, thunkPosition597 := position, thunkPosition
+				position652, thunkPosition652 := position, thunkPosition
 				{
-					position598, thunkPosition598 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockTh]() {
-						goto l599
+					position653, thunkPosition653 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockP]() {
+						goto l654
 					}
-					goto l598
-				l599:
-					position, thunkPosition = position598, thunkPosition598
+					goto l653
+				l654:
+					position, thunkPosition = position653, thunkPosition653
 					{
-						position600, thunkPosition600 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseTh]() {
-							goto l600
+						position655, thunkPosition655 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseP]() {
+							goto l655
 						}
-						goto l597
-					l600:
-						position, thunkPosition = position600, thunkPosition600
+						goto l652
+					l655:
+						position, thunkPosition = position655, thunkPosition655
 					}
 					if !matchDot() {
-						goto l597
+						goto l652
 					}
 				}
-			l598:
-				goto l596
-			l597:
-				position, thunkPosition = position597, thunkPosition597
+			l653:
+				goto l651
+			l652:
+				position, thunkPosition = position652, thunkPosition652
 			}
-			if !p.rules[ruleHtmlBlockCloseTh]() {
-				goto l595
+			if !p.rules[ruleHtmlBlockCloseP]() {
+				goto l650
 			}
 			return true
-		l595:
+		l650:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 120 HtmlBlockOpenThead <- ('<' Spnl ('thead' / 'THEAD') Spnl HtmlAttribute* '>') */
+		/* 132 HtmlBlockOpenPre <- ('<' Spnl ('pre' / 'PRE') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l601
+				goto l656
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l601
+				goto l656
 			}
 			{
-				position602, thunkPosition602 := position, thunkPosition
-				if !matchString("thead") {
-					goto l603
+				position657, thunkPosition657 := position, thunkPosition
+				if !matchString("pre") {
+					goto l658
 				}
-				goto l602
-			l603:
-				position, thunkPosition = position602, thunkPosition602
-				if !matchString("THEAD") {
-					goto l601
+				goto l657
+			l658:
+				position, thunkPosition = position657, thunkPosition657
+				if !matchString("PRE") {
+					goto l656
 				}
 			}
-		l602:
+		l657:
 			if !p.rules[ruleSpnl]() {
-				goto l601
+				goto l656
 			}
-		l604:
+		l659:
 			{
-				position605, thunkPosition605 := position, thunkPosition
+				position660, thunkPosition660 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l605
+					goto l660
 				}
-				goto l604
-			l605:
-				position, thunkPosition = position605, thunkPosition605
+				goto l659
+			l660:
+				position, thunkPosition = position660, thunkPosition660
 			}
 			if !matchChar('>') {
-				goto l601
+				goto l656
 			}
 			return true
-		l601:
+		l656:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 121 HtmlBlockCloseThead <- ('<' Spnl '/' ('thead' / 'THEAD') Spnl '>') */
+		/* 133 HtmlBlockClosePre <- ('<' Spnl '/' ('pre' / 'PRE') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l606
+				goto l661
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l606
+				goto l661
 			}
 			if !matchChar('/') {
-				goto l606
+				goto l661
 			}
 			{
-				position607, thunkPosition607 := position, thunkPosition
-				if !matchString("thead") {
-					goto l608
+				position662, thunkPosition662 := position, thunkPosition
+				if !matchString("pre") {
+					goto l663
 				}
-				goto l607
-			l608:
-				position, thunkPosition = position607, thunkPosition607
-				if !matchString("THEAD") {
-					goto l606
+				goto l662
+			l663:
+				position, thunkPosition = position662, thunkPosition662
+				if !matchString("PRE") {
+					goto l661
 				}
 			}
-		l607:
+		l662:
 			if !p.rules[ruleSpnl]() {
-				goto l606
+				goto l661
 			}
 			if !matchChar('>') {
-				goto l606
+				goto l661
 			}
 			return true
-		l606:
+		l661:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 122 HtmlBlockThead <- (HtmlBlockOpenThead (HtmlBlockThead / (!HtmlBlockCloseThead .))* HtmlBlockCloseThead) */
+		/* 134 HtmlBlockPre <- (HtmlBlockOpenPre (HtmlBlockPre / (!HtmlBlockClosePre .))* HtmlBlockClosePre) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenThead]() {
-				goto l609
+			if !p.rules[ruleHtmlBlockOpenPre]() {
+				goto l664
 			}
-		l610:
+		l665:
 			{
-				position611, thunkPosition611 := position, thunkPosition
+				position666, thunkPosition666 := position, thunkPosition
 				{
-					position612, thunkPosition612 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockThead]() {
-						goto l613
+					position667, thunkPosition667 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockPre]() {
+						goto l668
 					}
-					goto l612
-				l613:
-					position, thunkPosition = position612, thunkPosition612
+					goto l667
+				l668:
+					position, thunkPosition = position667, thunkPosition667
 					{
-						position614, thunkPosition614 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseThead]() {
-							goto l614
+						position669, thunkPosition669 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockClosePre]() {
+							goto l669
 						}
-						goto l611
-					l614:
-						position, thunkPosition = position614, thunkPosition614
+						goto l666
+					l669:
+						position, thunkPosition = position669, thunkPosition669
 					}
 					if !matchDot() {
-						goto l611
+						goto l666
 					}
 				}
-			l612:
-				goto l610
-			l611:
-				position, thunkPosition = position611, thunkPosition611
+			l667:
+				goto l665
+			l666:
+				position, thunkPosition = position666, thunkPosition666
 			}
-			if !p.rules[ruleHtmlBlockCloseThead]() {
-				goto l609
+			if !p.rules[ruleHtmlBlockClosePre]() {
+				goto l664
 			}
 			return true
-		l609:
+		l664:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 123 HtmlBlockOpenTr <- ('<' Spnl ('tr' / 'TR') Spnl HtmlAttribute* '>') */
+		/* 135 HtmlBlockOpenTable <- ('<' Spnl ('table' / 'TABLE') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l615
+				goto l670
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l615
+				goto l670
 			}
 			{
-				position616, thunkPosition616 := position, thunkPosition
-				if !matchString("tr") {
-					goto l617
+				position671, thunkPosition671 := position, thunkPosition
+				if !matchString("table") {
+					goto l672
 				}
-				goto l616
-			l617:
-				position, thunkPosition = position616, thunkPosition616
-				if !matchString("TR") {
-					goto l615
+				goto l671
+			l672:
+				position, thunkPosition = position671, thunkPosition671
+				if !matchString("TABLE") {
+					goto l670
 				}
 			}
-		l616:
+		l671:
 			if !p.rules[ruleSpnl]() {
-				goto l615
+				goto l670
 			}
-		l618:
+		l673:
 			{
-				position619, thunkPosition619 := position, thunkPosition
+				position674, thunkPosition674 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l619
+					goto l674
 				}
-				goto l618
-			l619:
-				position, thunkPosition = position619, thunkPosition619
+				goto l673
+			l674:
+				position, thunkPosition = position674, thunkPosition674
 			}
 			if !matchChar('>') {
-				goto l615
+				goto l670
 			}
 			return true
-		l615:
+		l670:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 124 HtmlBlockCloseTr <- ('<' Spnl '/' ('tr' / 'TR') Spnl '>') */
+		/* 136 HtmlBlockCloseTable <- ('<' Spnl '/' ('table' / 'TABLE') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l620
+				goto l675
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l620
+				goto l675
 			}
 			if !matchChar('/') {
-				goto l620
+				goto l675
 			}
 			{
-				position621, thunkPosition621 := position, thunkPosition
-				if !matchString("tr") {
-					goto l622
+				position676, thunkPosition676 := position, thunkPosition
+				if !matchString("table") {
+					goto l677
 				}
-				goto l621
-			l622:
-				position, thunkPosition = position621, thunkPosition621
-				if !matchString("TR") {
-					goto l620
+				goto l676
+			l677:
+				position, thunkPosition = position676, thunkPosition676
+				if !matchString("TABLE") {
+					goto l675
 				}
 			}
-		l621:
+		l676:
 			if !p.rules[ruleSpnl]() {
-				goto l620
+				goto l675
 			}
 			if !matchChar('>') {
-				goto l620
+				goto l675
 			}
 			return true
-		l620:
+		l675:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 125 HtmlBlockTr <- (HtmlBlockOpenTr (HtmlBlockTr / (!HtmlBlockCloseTr .))* HtmlBlockCloseTr) */
+		/* 137 HtmlBlockTable <- (HtmlBlockOpenTable (HtmlBlockTable / (!HtmlBlockCloseTable .))* HtmlBlockCloseTable) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenTr]() {
-				goto l623
+			if !p.rules[ruleHtmlBlockOpenTable]() {
+				goto l678
 			}
-		l624:
+		l679:
 			{
-				position625, thunkPosition625 := position, thunkPosition
+				position680, thunkPosition680 := position, thunkPosition
 				{
-					position626, thunkPosition626 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockTr]() {
-						goto l627
+					position681, thunkPosition681 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockTable]() {
+						goto l682
 					}
-					goto l626
-				l627:
-					position, thunkPosition = position626, thunkPosition626
+					goto l681
+				l682:
+					position, thunkPosition = position681, thunkPosition681
 					{
-						position628, thunkPosition628 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseTr]() {
-							goto l628
+						position683, thunkPosition683 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseTable]() {
+							goto l683
 						}
-						goto l625
-					l628:
-						position, thunkPosition = position628, thunkPosition628
+						goto l680
+					l683:
+						position, thunkPosition = position683, thunkPosition683
 					}
 					if !matchDot() {
-						goto l625
+						goto l680
 					}
 				}
-			l626:
-				goto l624
-			l625:
-				position, thunkPosition = position625, thunkPosition625
+			l681:
+				goto l679
+			l680:
+				position, thunkPosition = position680, thunkPosition680
 			}
-			if !p.rules[ruleHtmlBlockCloseTr]() {
-				goto l623
+			if !p.rules[ruleHtmlBlockCloseTable]() {
+				goto l678
 			}
 			return true
-		l623:
+		l678:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 126 HtmlBlockOpenScript <- ('<' Spnl ('script' / 'SCRIPT') Spnl HtmlAttribute* '>') */
+		/* 138 HtmlBlockOpenUl <- ('<' Spnl ('ul' / 'UL') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l629
+				goto l684
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l629
+				goto l684
 			}
 			{
-				position630, thunkPosition630 := position, thunkPosition
-				if !matchString("script") {
-					goto l631
+				position685, thunkPosition685 := position, thunkPosition
+				if !matchString("ul") {
+					goto l686
 				}
-				goto l630
-			l631:
-				position, thunkPosition = position630, thunkPosition630
-				if !matchString("SCRIPT") {
-					goto l629
+				goto l685
+			l686:
+				position, thunkPosition = position685, thunkPosition685
+				if !matchString("UL") {
+					goto l684
 				}
 			}
-		l630:
+		l685:
 			if !p.rules[ruleSpnl]() {
-				goto l629
+				goto l684
 			}
-		l632:
+		l687:
 			{
-				position633, thunkPosition633 := position, thunkPosition
+				position688, thunkPosition688 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l633
+					goto l688
 				}
-				goto l632
-			l633:
-				position, thunkPosition = position633, thunkPosition633
+				goto l687
+			l688:
+				position, thunkPosition = position688, thunkPosition688
 			}
 			if !matchChar('>') {
-				goto l629
+				goto l684
 			}
 			return true
-		l629:
+		l684:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 127 HtmlBlockCloseScript <- ('<' Spnl '/' ('script' / 'SCRIPT') Spnl '>') */
+		/* 139 HtmlBlockCloseUl <- ('<' Spnl '/' ('ul' / 'UL') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l634
+				goto l689
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l634
+				goto l689
 			}
 			if !matchChar('/') {
-				goto l634
+				goto l689
 			}
 			{
-				position635, thunkPosition635 := position, thunkPosition
-				if !matchString("script") {
-					goto l636
+				position690, thunkPosition690 := position, thunkPosition
+				if !matchString("ul") {
+					goto l691
 				}
-				goto l635
-			l636:
-				position, thunkPosition = position635, thunkPosition635
-				if !matchString("SCRIPT") {
-					goto l634
+				goto l690
+			l691:
+				position, thunkPosition = position690, thunkPosition690
+				if !matchString("UL") {
+					goto l689
 				}
 			}
-		l635:
+		l690:
 			if !p.rules[ruleSpnl]() {
-				goto l634
+				goto l689
 			}
 			if !matchChar('>') {
-				goto l634
+				goto l689
 			}
 			return true
-		l634:
+		l689:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 128 HtmlBlockScript <- (HtmlBlockOpenScript (HtmlBlockScript / (!HtmlBlockCloseScript .))* HtmlBlockCloseScript) */
+		/* 140 HtmlBlockUl <- (HtmlBlockOpenUl (HtmlBlockUl / (!HtmlBlockCloseUl .))* HtmlBlockCloseUl) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleHtmlBlockOpenScript]() {
-				goto l637
+			if !p.rules[ruleHtmlBlockOpenUl]() {
+				goto l692
 			}
-		l638:
+		l693:
 			{
-				position639, thunkPosition639 := position, thunkPosition
+				position694, thunkPosition694 := position, thunkPosition
 				{
-					position640, thunkPosition640 := position, thunkPosition
-					if !p.rules[ruleHtmlBlockScript]() {
-						goto l641
+					position695, thunkPosition695 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockUl]() {
+						goto l696
 					}
-					goto l640
-				l641:
-					position, thunkPosition = position640, thunkPosition640
+					goto l695
+				l696:
+					position, thunkPosition = position695, thunkPosition695
 					{
-						position642, thunkPosition642 := position, thunkPosition
-						if !p.rules[ruleHtmlBlockCloseScript]() {
-							goto l642
+						position697, thunkPosition697 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseUl]() {
+							goto l697
 						}
-						goto l639
-					l642:
-						position, thunkPosition = position642, thunkPosition642
+						goto l694
+					l697:
+						position, thunkPosition = position697, thunkPosition697
 					}
 					if !matchDot() {
-						goto l639
+						goto l694
 					}
 				}
-			l640:
-				goto l638
-			l639:
-				position, thunkPosition = position639, thunkPosition639
+			l695:
+				goto l693
+			l694:
+				position, thunkPosition = position694, thunkPosition694
 			}
-			if !p.rules[ruleHtmlBlockCloseScript]() {
-				goto l637
+			if !p.rules[ruleHtmlBlockCloseUl]() {
+				goto l692
 			}
 			return true
-		l637:
+		l692:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 129 HtmlBlockInTags <- (HtmlBlockAddress / HtmlBlockBlockquote / HtmlBlockCenter / HtmlBlockDir / HtmlBlockDiv / HtmlBlockDl / HtmlBlockFieldset / HtmlBlockForm / HtmlBlockH1 / HtmlBlockH2 / HtmlBlockH3 / HtmlBlockH4 / HtmlBlockH5 / HtmlBlockH6 / HtmlBlockMenu / HtmlBlockNoframes / HtmlBlockNoscript / HtmlBlockOl / HtmlBlockP / HtmlBlockPre / HtmlBlockTable / HtmlBlockUl / HtmlBlockDd / HtmlBlockDt / HtmlBlockFrameset / HtmlBlockLi / HtmlBlockTbody / HtmlBlockTd / HtmlBlockTfoot / HtmlBlockTh / HtmlBlockThead / HtmlBlockTr / HtmlBlockScript) */
+		/* 141 HtmlBlockOpenDd <- ('<' Spnl ('dd' / 'DD') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			{
-				position644, thunkPosition644 := position, thunkPosition
-				if !p.rules[ruleHtmlBlockAddress]() {
-					goto l645
-				}
-				goto l644
-			l645:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockBlockquote]() {
-					goto l646
-				}
-				goto l644
-			l646:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockCenter]() {
-					goto l647
-				}
-				goto l644
-			l647:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockDir]() {
-					goto l648
-				}
-				goto l644
-			l648:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockDiv]() {
-					goto l649
-				}
-				goto l644
-			l649:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockDl]() {
-					goto l650
-				}
-				goto l644
-			l650:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockFieldset]() {
-					goto l651
-				}
-				goto l644
-			l651:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockForm]() {
-					goto l652
-				}
-				goto l644
-			l652:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockH1]() {
-					goto l653
-				}
-				goto l644
-			l653:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockH2]() {
-					goto l654
-				}
-				goto l644
-			l654:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockH3]() {
-					goto l655
-				}
-				goto l644
-			l655:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockH4]() {
-					goto l656
-				}
-				goto l644
-			l656:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockH5]() {
-					goto l657
-				}
-				goto l644
-			l657:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockH6]() {
-					goto l658
-				}
-				goto l644
-			l658:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockMenu]() {
-					goto l659
-				}
-				goto l644
-			l659:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockNoframes]() {
-					goto l660
-				}
-				goto l644
-			l660:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockNoscript]() {
-					goto l661
-				}
-				goto l644
-			l661:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockOl]() {
-					goto l662
-				}
-				goto l644
-			l662:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockP]() {
-					goto l663
-				}
-				goto l644
-			l663:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockPre]() {
-					goto l664
-				}
-				goto l644
-			l664:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockTable]() {
-					goto l665
-				}
-				goto l644
-			l665:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockUl]() {
-					goto l666
-				}
-				goto l644
-			l666:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockDd]() {
-					goto l667
-				}
-				goto l644
-			l667:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockDt]() {
-					goto l668
-				}
-				goto l644
-			l668:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockFrameset]() {
-					goto l669
-				}
-				goto l644
-			l669:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockLi]() {
-					goto l670
-				}
-				goto l644
-			l670:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockTbody]() {
-					goto l671
-				}
-				goto l644
-			l671:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockTd]() {
-					goto l672
-				}
-				goto l644
-			l672:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockTfoot]() {
-					goto l673
-				}
-				goto l644
-			l673:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockTh]() {
-					goto l674
-				}
-				goto l644
-			l674:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockThead]() {
-					goto l675
-				}
-				goto l644
-			l675:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockTr]() {
-					goto l676
-				}
-				goto l644
-			l676:
-				position, thunkPosition = position644, thunkPosition644
-				if !p.rules[ruleHtmlBlockScript]() {
-					goto l643
-				}
+			if !matchChar('<') {
+				goto l698
 			}
-		l644:
-			return true
-		l643:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 130 HtmlBlock <- (&'<' < (HtmlBlockInTags / HtmlComment / HtmlBlockSelfClosing) > BlankLine+ {   if p.extension.FilterHTML {
-                    yy = mk_list(LIST, nil)
-                } else {
-                    yy = mk_str(yytext)
-                    yy.key = HTMLBLOCK
-                }
-            }) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			if !peekChar('<') {
-				goto l677
+			if !p.rules[ruleSpnl]() {
+				goto l698
 			}
-			begin = position
 			{
-				position678, thunkPosition678 := position, thunkPosition
-				if !p.rules[ruleHtmlBlockInTags]() {
-					goto l679
-				}
-				goto l678
-			l679:
-				position, thunkPosition = position678, thunkPosition678
-				if !p.rules[ruleHtmlComment]() {
-					goto l680
+				position699, thunkPosition699 := position, thunkPosition
+				if !matchString("dd") {
+					goto l700
 				}
-				goto l678
-			l680:
-				position, thunkPosition = position678, thunkPosition678
-				if !p.rules[ruleHtmlBlockSelfClosing]() {
-					goto l677
+				goto l699
+			l700:
+				position, thunkPosition = position699, thunkPosition699
+				if !matchString("DD") {
+					goto l698
 				}
 			}
-		l678:
-			end = position
-			if !p.rules[ruleBlankLine]() {
-				goto l677
+		l699:
+			if !p.rules[ruleSpnl]() {
+				goto l698
 			}
-		l681:
+		l701:
 			{
-				position682, thunkPosition682 := position, thunkPosition
-				if !p.rules[ruleBlankLine]() {
-					goto l682
+				position702, thunkPosition702 := position, thunkPosition
+				if !p.rules[ruleHtmlAttribute]() {
+					goto l702
 				}
-				goto l681
-			l682:
-				position, thunkPosition = position682, thunkPosition682
+				goto l701
+			l702:
+				position, thunkPosition = position702, thunkPosition702
+			}
+			if !matchChar('>') {
+				goto l698
 			}
-			do(40)
 			return true
-		l677:
+		l698:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 131 HtmlBlockSelfClosing <- ('<' Spnl HtmlBlockType Spnl HtmlAttribute* '/' Spnl '>') */
+		/* 142 HtmlBlockCloseDd <- ('<' Spnl '/' ('dd' / 'DD') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l683
+				goto l703
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l683
+				goto l703
 			}
-			if !p.rules[ruleHtmlBlockType]() {
-				goto l683
-			}
-			if !p.rules[ruleSpnl]() {
-				goto l683
+			if !matchChar('/') {
+				goto l703
 			}
-		l684:
 			{
-				position685, thunkPosition685 := position, thunkPosition
-				if !p.rules[ruleHtmlAttribute]() {
-					goto l685
+				position704, thunkPosition704 := position, thunkPosition
+				if !matchString("dd") {
+					goto l705
+				}
+				goto l704
+			l705:
+				position, thunkPosition = position704, thunkPosition704
+				if !matchString("DD") {
+					goto l703
 				}
-				goto l684
-			l685:
-				position, thunkPosition = position685, thunkPosition685
-			}
-			if !matchChar('/') {
-				goto l683
 			}
+		l704:
 			if !p.rules[ruleSpnl]() {
-				goto l683
+				goto l703
 			}
 			if !matchChar('>') {
-				goto l683
+				goto l703
 			}
 			return true
-		l683:
+		l703:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 132 HtmlBlockType <- ('address' / 'blockquote' / 'center' / 'dir' / 'div' / 'dl' / 'fieldset' / 'form' / 'h1' / 'h2' / 'h3' / 'h4' / 'h5' / 'h6' / 'hr' / 'isindex' / 'menu' / 'noframes' / 'noscript' / 'ol' / 'p' / 'pre' / 'table' / 'ul' / 'dd' / 'dt' / 'frameset' / 'li' / 'tbody' / 'td' / 'tfoot' / 'th' / 'thead' / 'tr' / 'script' / 'ADDRESS' / 'BLOCKQUOTE' / 'CENTER' / 'DIR' / 'DIV' / 'DL' / 'FIELDSET' / 'FORM' / 'H1' / 'H2' / 'H3' / 'H4' / 'H5' / 'H6' / 'HR' / 'ISINDEX' / 'MENU' / 'NOFRAMES' / 'NOSCRIPT' / 'OL' / 'P' / 'PRE' / 'TABLE' / 'UL' / 'DD' / 'DT' / 'FRAMESET' / 'LI' / 'TBODY' / 'TD' / 'TFOOT' / 'TH' / 'THEAD' / 'TR' / 'SCRIPT') */
+		/* 143 HtmlBlockDd <- (HtmlBlockOpenDd (HtmlBlockDd / (!HtmlBlockCloseDd .))* HtmlBlockCloseDd) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
+			if !p.rules[ruleHtmlBlockOpenDd]() {
+				goto l706
+			}
+		l707:
 			{
-				position687, thunkPosition687 := position, thunkPosition
-				if !matchString("address") {
-					goto l688
-				}
-				goto l687
-			l688:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("blockquote") {
-					goto l689
-				}
-				goto l687
-			l689:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("center") {
-					goto l690
-				}
-				goto l687
-			l690:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("dir") {
-					goto l691
-				}
-				goto l687
-			l691:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("div") {
-					goto l692
-				}
-				goto l687
-			l692:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("dl") {
-					goto l693
-				}
-				goto l687
-			l693:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("fieldset") {
-					goto l694
-				}
-				goto l687
-			l694:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("form") {
-					goto l695
-				}
-				goto l687
-			l695:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("h1") {
-					goto l696
-				}
-				goto l687
-			l696:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("h2") {
-					goto l697
-				}
-				goto l687
-			l697:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("h3") {
-					goto l698
-				}
-				goto l687
-			l698:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("h4") {
-					goto l699
-				}
-				goto l687
-			l699:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("h5") {
-					goto l700
-				}
-				goto l687
-			l700:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("h6") {
-					goto l701
-				}
-				goto l687
-			l701:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("hr") {
-					goto l702
-				}
-				goto l687
-			l702:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("isindex") {
-					goto l703
-				}
-				goto l687
-			l703:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("menu") {
-					goto l704
-				}
-				goto l687
-			l704:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("noframes") {
-					goto l705
-				}
-				goto l687
-			l705:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("noscript") {
-					goto l706
-				}
-				goto l687
-			l706:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("ol") {
-					goto l707
-				}
-				goto l687
-			l707:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchChar('p') {
-					goto l708
-				}
-				goto l687
-			l708:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("pre") {
+				position708, thunkPosition708 := position, thunkPosition
+				{
+					position709, thunkPosition709 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockDd]() {
+						goto l710
+					}
 					goto l709
+				l710:
+					position, thunkPosition = position709, thunkPosition709
+					{
+						position711, thunkPosition711 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseDd]() {
+							goto l711
+						}
+						goto l708
+					l711:
+						position, thunkPosition = position711, thunkPosition711
+					}
+					if !matchDot() {
+						goto l708
+					}
 				}
-				goto l687
 			l709:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("table") {
-					goto l710
-				}
-				goto l687
-			l710:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("ul") {
-					goto l711
-				}
-				goto l687
-			l711:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("dd") {
-					goto l712
-				}
-				goto l687
-			l712:
-				position, thunkPosition = position687, thunkPosition687
+				goto l707
+			l708:
+				position, thunkPosition = position708, thunkPosition708
+			}
+			if !p.rules[ruleHtmlBlockCloseDd]() {
+				goto l706
+			}
+			return true
+		l706:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 144 HtmlBlockOpenDt <- ('<' Spnl ('dt' / 'DT') Spnl HtmlAttribute* '>') */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('<') {
+				goto l712
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l712
+			}
+			{
+				position713, thunkPosition713 := position, thunkPosition
 				if !matchString("dt") {
-					goto l713
-				}
-				goto l687
-			l713:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("frameset") {
 					goto l714
 				}
-				goto l687
+				goto l713
 			l714:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("li") {
-					goto l715
+				position, thunkPosition = position713, thunkPosition713
+				if !matchString("DT") {
+					goto l712
 				}
-				goto l687
-			l715:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("tbody") {
+			}
+		l713:
+			if !p.rules[ruleSpnl]() {
+				goto l712
+			}
+		l715:
+			{
+				position716, thunkPosition716 := position, thunkPosition
+				if !p.rules[ruleHtmlAttribute]() {
 					goto l716
 				}
-				goto l687
+				goto l715
 			l716:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("td") {
-					goto l717
-				}
-				goto l687
-			l717:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("tfoot") {
-					goto l718
-				}
-				goto l687
-			l718:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("th") {
+				position, thunkPosition = position716, thunkPosition716
+			}
+			if !matchChar('>') {
+				goto l712
+			}
+			return true
+		l712:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 145 HtmlBlockCloseDt <- ('<' Spnl '/' ('dt' / 'DT') Spnl '>') */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('<') {
+				goto l717
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l717
+			}
+			if !matchChar('/') {
+				goto l717
+			}
+			{
+				position718, thunkPosition718 := position, thunkPosition
+				if !matchString("dt") {
 					goto l719
 				}
-				goto l687
+				goto l718
 			l719:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("thead") {
-					goto l720
-				}
-				goto l687
-			l720:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("tr") {
-					goto l721
-				}
-				goto l687
-			l721:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("script") {
-					goto l722
+				position, thunkPosition = position718, thunkPosition718
+				if !matchString("DT") {
+					goto l717
 				}
-				goto l687
-			l722:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("ADDRESS") {
+			}
+		l718:
+			if !p.rules[ruleSpnl]() {
+				goto l717
+			}
+			if !matchChar('>') {
+				goto l717
+			}
+			return true
+		l717:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 146 HtmlBlockDt <- (HtmlBlockOpenDt (HtmlBlockDt / (!HtmlBlockCloseDt .))* HtmlBlockCloseDt) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !p.rules[ruleHtmlBlockOpenDt]() {
+				goto l720
+			}
+		l721:
+			{
+				position722, thunkPosition722 := position, thunkPosition
+				{
+					position723, thunkPosition723 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockDt]() {
+						goto l724
+					}
 					goto l723
+				l724:
+					position, thunkPosition = position723, thunkPosition723
+					{
+						position725, thunkPosition725 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseDt]() {
+							goto l725
+						}
+						goto l722
+					l725:
+						position, thunkPosition = position725, thunkPosition725
+					}
+					if !matchDot() {
+						goto l722
+					}
 				}
-				goto l687
 			l723:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("BLOCKQUOTE") {
-					goto l724
-				}
-				goto l687
-			l724:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("CENTER") {
-					goto l725
-				}
-				goto l687
-			l725:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("DIR") {
-					goto l726
-				}
-				goto l687
-			l726:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("DIV") {
-					goto l727
-				}
-				goto l687
-			l727:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("DL") {
+				goto l721
+			l722:
+				position, thunkPosition = position722, thunkPosition722
+			}
+			if !p.rules[ruleHtmlBlockCloseDt]() {
+				goto l720
+			}
+			return true
+		l720:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 147 HtmlBlockOpenFrameset <- ('<' Spnl ('frameset' / 'FRAMESET') Spnl HtmlAttribute* '>') */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('<') {
+				goto l726
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l726
+			}
+			{
+				position727, thunkPosition727 := position, thunkPosition
+				if !matchString("frameset") {
 					goto l728
 				}
-				goto l687
+				goto l727
 			l728:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("FIELDSET") {
-					goto l729
+				position, thunkPosition = position727, thunkPosition727
+				if !matchString("FRAMESET") {
+					goto l726
 				}
-				goto l687
-			l729:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("FORM") {
+			}
+		l727:
+			if !p.rules[ruleSpnl]() {
+				goto l726
+			}
+		l729:
+			{
+				position730, thunkPosition730 := position, thunkPosition
+				if !p.rules[ruleHtmlAttribute]() {
 					goto l730
 				}
-				goto l687
+				goto l729
 			l730:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("H1") {
-					goto l731
-				}
-				goto l687
-			l731:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("H2") {
-					goto l732
-				}
-				goto l687
-			l732:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("H3") {
+				position, thunkPosition = position730, thunkPosition730
+			}
+			if !matchChar('>') {
+				goto l726
+			}
+			return true
+		l726:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 148 HtmlBlockCloseFrameset <- ('<' Spnl '/' ('frameset' / 'FRAMESET') Spnl '>') */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('<') {
+				goto l731
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l731
+			}
+			if !matchChar('/') {
+				goto l731
+			}
+			{
+				position732, thunkPosition732 := position, thunkPosition
+				if !matchString("frameset") {
 					goto l733
 				}
-				goto l687
+				goto l732
 			l733:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("H4") {
-					goto l734
-				}
-				goto l687
-			l734:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("H5") {
-					goto l735
-				}
-				goto l687
-			l735:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("H6") {
-					goto l736
+				position, thunkPosition = position732, thunkPosition732
+				if !matchString("FRAMESET") {
+					goto l731
 				}
-				goto l687
-			l736:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("HR") {
+			}
+		l732:
+			if !p.rules[ruleSpnl]() {
+				goto l731
+			}
+			if !matchChar('>') {
+				goto l731
+			}
+			return true
+		l731:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 149 HtmlBlockFrameset <- (HtmlBlockOpenFrameset (HtmlBlockFrameset / (!HtmlBlockCloseFrameset .))* HtmlBlockCloseFrameset) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !p.rules[ruleHtmlBlockOpenFrameset]() {
+				goto l734
+			}
+		l735:
+			{
+				position736, thunkPosition736 := position, thunkPosition
+				{
+					position737, thunkPosition737 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockFrameset]() {
+						goto l738
+					}
 					goto l737
+				l738:
+					position, thunkPosition = position737, thunkPosition737
+					{
+						position739, thunkPosition739 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseFrameset]() {
+							goto l739
+						}
+						goto l736
+					l739:
+						position, thunkPosition = position739, thunkPosition739
+					}
+					if !matchDot() {
+						goto l736
+					}
 				}
-				goto l687
 			l737:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("ISINDEX") {
-					goto l738
-				}
-				goto l687
-			l738:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("MENU") {
-					goto l739
-				}
-				goto l687
-			l739:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("NOFRAMES") {
-					goto l740
-				}
-				goto l687
-			l740:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("NOSCRIPT") {
-					goto l741
-				}
-				goto l687
-			l741:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("OL") {
+				goto l735
+			l736:
+				position, thunkPosition = position736, thunkPosition736
+			}
+			if !p.rules[ruleHtmlBlockCloseFrameset]() {
+				goto l734
+			}
+			return true
+		l734:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 150 HtmlBlockOpenLi <- ('<' Spnl ('li' / 'LI') Spnl HtmlAttribute* '>') */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('<') {
+				goto l740
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l740
+			}
+			{
+				position741, thunkPosition741 := position, thunkPosition
+				if !matchString("li") {
 					goto l742
 				}
-				goto l687
+				goto l741
 			l742:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchChar('P') {
-					goto l743
+				position, thunkPosition = position741, thunkPosition741
+				if !matchString("LI") {
+					goto l740
 				}
-				goto l687
-			l743:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("PRE") {
+			}
+		l741:
+			if !p.rules[ruleSpnl]() {
+				goto l740
+			}
+		l743:
+			{
+				position744, thunkPosition744 := position, thunkPosition
+				if !p.rules[ruleHtmlAttribute]() {
 					goto l744
 				}
-				goto l687
+				goto l743
 			l744:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("TABLE") {
-					goto l745
-				}
-				goto l687
-			l745:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("UL") {
-					goto l746
-				}
-				goto l687
-			l746:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("DD") {
+				position, thunkPosition = position744, thunkPosition744
+			}
+			if !matchChar('>') {
+				goto l740
+			}
+			return true
+		l740:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 151 HtmlBlockCloseLi <- ('<' Spnl '/' ('li' / 'LI') Spnl '>') */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('<') {
+				goto l745
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l745
+			}
+			if !matchChar('/') {
+				goto l745
+			}
+			{
+				position746, thunkPosition746 := position, thunkPosition
+				if !matchString("li") {
 					goto l747
 				}
-				goto l687
+				goto l746
 			l747:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("DT") {
-					goto l748
-				}
-				goto l687
-			l748:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("FRAMESET") {
-					goto l749
-				}
-				goto l687
-			l749:
-				position, thunkPosition = position687, thunkPosition687
+				position, thunkPosition = position746, thunkPosition746
 				if !matchString("LI") {
-					goto l750
+					goto l745
 				}
-				goto l687
-			l750:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("TBODY") {
+			}
+		l746:
+			if !p.rules[ruleSpnl]() {
+				goto l745
+			}
+			if !matchChar('>') {
+				goto l745
+			}
+			return true
+		l745:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 152 HtmlBlockLi <- (HtmlBlockOpenLi (HtmlBlockLi / (!HtmlBlockCloseLi .))* HtmlBlockCloseLi) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !p.rules[ruleHtmlBlockOpenLi]() {
+				goto l748
+			}
+		l749:
+			{
+				position750, thunkPosition750 := position, thunkPosition
+				{
+					position751, thunkPosition751 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockLi]() {
+						goto l752
+					}
 					goto l751
+				l752:
+					position, thunkPosition = position751, thunkPosition751
+					{
+						position753, thunkPosition753 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseLi]() {
+							goto l753
+						}
+						goto l750
+					l753:
+						position, thunkPosition = position753, thunkPosition753
+					}
+					if !matchDot() {
+						goto l750
+					}
 				}
-				goto l687
 			l751:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("TD") {
-					goto l752
-				}
-				goto l687
-			l752:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("TFOOT") {
-					goto l753
-				}
-				goto l687
-			l753:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("TH") {
-					goto l754
-				}
-				goto l687
-			l754:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("THEAD") {
-					goto l755
-				}
-				goto l687
-			l755:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("TR") {
-					goto l756
-				}
-				goto l687
-			l756:
-				position, thunkPosition = position687, thunkPosition687
-				if !matchString("SCRIPT") {
-					goto l686
-				}
+				goto l749
+			l750:
+				position, thunkPosition = position750, thunkPosition750
+			}
+			if !p.rules[ruleHtmlBlockCloseLi]() {
+				goto l748
 			}
-		l687:
 			return true
-		l686:
+		l748:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 133 StyleOpen <- ('<' Spnl ('style' / 'STYLE') Spnl HtmlAttribute* '>') */
+		/* 153 HtmlBlockOpenTbody <- ('<' Spnl ('tbody' / 'TBODY') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l757
+				goto l754
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l757
+				goto l754
 			}
 			{
-				position758, thunkPosition758 := position, thunkPosition
-				if !matchString("style") {
-					goto l759
+				position755, thunkPosition755 := position, thunkPosition
+				if !matchString("tbody") {
+					goto l756
 				}
-				goto l758
-			l759:
-				position, thunkPosition = position758, thunkPosition758
-				if !matchString("STYLE") {
-					goto l757
+				goto l755
+			l756:
+				position, thunkPosition = position755, thunkPosition755
+				if !matchString("TBODY") {
+					goto l754
 				}
 			}
-		l758:
+		l755:
 			if !p.rules[ruleSpnl]() {
-				goto l757
+				goto l754
 			}
-		l760:
+		l757:
 			{
-				position761, thunkPosition761 := position, thunkPosition
+				position758, thunkPosition758 := position, thunkPosition
 				if !p.rules[ruleHtmlAttribute]() {
-					goto l761
+					goto l758
 				}
-				goto l760
-			l761:
-				position, thunkPosition = position761, thunkPosition761
+				goto l757
+			l758:
+				position, thunkPosition = position758, thunkPosition758
 			}
 			if !matchChar('>') {
-				goto l757
+				goto l754
 			}
 			return true
-		l757:
+		l754:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 134 StyleClose <- ('<' Spnl '/' ('style' / 'STYLE') Spnl '>') */
+		/* 154 HtmlBlockCloseTbody <- ('<' Spnl '/' ('tbody' / 'TBODY') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('<') {
-				goto l762
+				goto l759
 			}
 			if !p.rules[ruleSpnl]() {
-				goto l762
+				goto l759
 			}
 			if !matchChar('/') {
-				goto l762
+				goto l759
 			}
 			{
-				position763, thunkPosition763 := position, thunkPosition
-				if !matchString("style") {
-					goto l764
+				position760, thunkPosition760 := position, thunkPosition
+				if !matchString("tbody") {
+					goto l761
 				}
-				goto l763
-			l764:
-				position, thunkPosition = position763, thunkPosition763
-				if !matchString("STYLE") {
-					goto l762
+				goto l760
+			l761:
+				position, thunkPosition = position760, thunkPosition760
+				if !matchString("TBODY") {
+					goto l759
 				}
 			}
-		l763:
+		l760:
 			if !p.rules[ruleSpnl]() {
-				goto l762
+				goto l759
 			}
 			if !matchChar('>') {
-				goto l762
+				goto l759
 			}
 			return true
-		l762:
+		l759:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 135 InStyleTags <- (StyleOpen (!StyleClose .)* StyleClose) */
+		/* 155 HtmlBlockTbody <- (HtmlBlockOpenTbody (HtmlBlockTbody / (!HtmlBlockCloseTbody .))* HtmlBlockCloseTbody) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleStyleOpen]() {
-				goto l765
+			if !p.rules[ruleHtmlBlockOpenTbody]() {
+				goto l762
 			}
-		l766:
+		l763:
 			{
-				position767, thunkPosition767 := position, thunkPosition
+				position764, thunkPosition764 := position, thunkPosition
 				{
-					position768, thunkPosition768 := position, thunkPosition
-					if !p.rules[ruleStyleClose]() {
-						goto l768
+					position765, thunkPosition765 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockTbody]() {
+						goto l766
+					}
+					goto l765
+				l766:
+					position, thunkPosition = position765, thunkPosition765
+					{
+						position767, thunkPosition767 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseTbody]() {
+							goto l767
+						}
+						goto l764
+					l767:
+						position, thunkPosition = position767, thunkPosition767
+					}
+					if !matchDot() {
+						goto l764
 					}
-					goto l767
-				l768:
-					position, thunkPosition = position768, thunkPosition768
-				}
-				if !matchDot() {
-					goto l767
 				}
-				goto l766
-			l767:
-				position, thunkPosition = position767, thunkPosition767
+			l765:
+				goto l763
+			l764:
+				position, thunkPosition = position764, thunkPosition764
 			}
-			if !p.rules[ruleStyleClose]() {
-				goto l765
+			if !p.rules[ruleHtmlBlockCloseTbody]() {
+				goto l762
 			}
 			return true
-		l765:
+		l762:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 136 StyleBlock <- (< InStyleTags > BlankLine* {   if p.extension.FilterStyles {
-                        yy = mk_list(LIST, nil)
-                    } else {
-                        yy = mk_str(yytext)
-                        yy.key = HTMLBLOCK
-                    }
-                }) */
+		/* 156 HtmlBlockOpenTd <- ('<' Spnl ('td' / 'TD') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			begin = position
-			if !p.rules[ruleInStyleTags]() {
+			if !matchChar('<') {
+				goto l768
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l768
+			}
+			{
+				position769, thunkPosition769 := position, thunkPosition
+				if !matchString("td") {
+					goto l770
+				}
 				goto l769
+			l770:
+				position, thunkPosition = position769, thunkPosition769
+				if !matchString("TD") {
+					goto l768
+				}
 			}
-			end = position
-		l770:
+		l769:
+			if !p.rules[ruleSpnl]() {
+				goto l768
+			}
+		l771:
 			{
-				position771, thunkPosition771 := position, thunkPosition
-				if !p.rules[ruleBlankLine]() {
-					goto l771
+				position772, thunkPosition772 := position, thunkPosition
+				if !p.rules[ruleHtmlAttribute]() {
+					goto l772
 				}
-				goto l770
-			l771:
-				position, thunkPosition = position771, thunkPosition771
+				goto l771
+			l772:
+				position, thunkPosition = position772, thunkPosition772
+			}
+			if !matchChar('>') {
+				goto l768
 			}
-			do(41)
 			return true
-		l769:
+		l768:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 137 Inlines <- (StartList ((!Endline Inline { a = cons(yy, a) }) / (Endline &Inline { a = cons(c, a) }))+ Endline? { yy = mk_list(LIST, a) }) */
+		/* 157 HtmlBlockCloseTd <- ('<' Spnl '/' ('td' / 'TD') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 2)
-			if !p.rules[ruleStartList]() {
-				goto l772
+			if !matchChar('<') {
+				goto l773
 			}
-			doarg(yySet, -2)
-			{
-				position775, thunkPosition775 := position, thunkPosition
-				{
-					position777, thunkPosition777 := position, thunkPosition
-					if !p.rules[ruleEndline]() {
-						goto l777
-					}
-					goto l776
-				l777:
-					position, thunkPosition = position777, thunkPosition777
-				}
-				if !p.rules[ruleInline]() {
-					goto l776
-				}
-				do(42)
-				goto l775
-			l776:
-				position, thunkPosition = position775, thunkPosition775
-				if !p.rules[ruleEndline]() {
-					goto l772
+			if !p.rules[ruleSpnl]() {
+				goto l773
+			}
+			if !matchChar('/') {
+				goto l773
+			}
+			{
+				position774, thunkPosition774 := position, thunkPosition
+				if !matchString("td") {
+					goto l775
 				}
-				doarg(yySet, -1)
-				{
-					position778, thunkPosition778 := position, thunkPosition
-					if !p.rules[ruleInline]() {
-						goto l772
-					}
-					position, thunkPosition = position778, thunkPosition778
+				goto l774
+			l775:
+				position, thunkPosition = position774, thunkPosition774
+				if !matchString("TD") {
+					goto l773
 				}
-				do(43)
 			}
-		l775:
+		l774:
+			if !p.rules[ruleSpnl]() {
+				goto l773
+			}
+			if !matchChar('>') {
+				goto l773
+			}
+			return true
 		l773:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 158 HtmlBlockTd <- (HtmlBlockOpenTd (HtmlBlockTd / (!HtmlBlockCloseTd .))* HtmlBlockCloseTd) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !p.rules[ruleHtmlBlockOpenTd]() {
+				goto l776
+			}
+		l777:
 			{
-				position774, thunkPosition774 := position, thunkPosition
+				position778, thunkPosition778 := position, thunkPosition
 				{
 					position779, thunkPosition779 := position, thunkPosition
-					{
-						position781, thunkPosition781 := position, thunkPosition
-						if !p.rules[ruleEndline]() {
-							goto l781
-						}
-						goto l780
-					l781:
-						position, thunkPosition = position781, thunkPosition781
-					}
-					if !p.rules[ruleInline]() {
+					if !p.rules[ruleHtmlBlockTd]() {
 						goto l780
 					}
-					do(42)
 					goto l779
 				l780:
 					position, thunkPosition = position779, thunkPosition779
-					if !p.rules[ruleEndline]() {
-						goto l774
-					}
-					doarg(yySet, -1)
 					{
-						position782, thunkPosition782 := position, thunkPosition
-						if !p.rules[ruleInline]() {
-							goto l774
+						position781, thunkPosition781 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseTd]() {
+							goto l781
 						}
-						position, thunkPosition = position782, thunkPosition782
+						goto l778
+					l781:
+						position, thunkPosition = position781, thunkPosition781
+					}
+					if !matchDot() {
+						goto l778
 					}
-					do(43)
 				}
 			l779:
-				goto l773
-			l774:
-				position, thunkPosition = position774, thunkPosition774
+				goto l777
+			l778:
+				position, thunkPosition = position778, thunkPosition778
+			}
+			if !p.rules[ruleHtmlBlockCloseTd]() {
+				goto l776
+			}
+			return true
+		l776:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 159 HtmlBlockOpenTfoot <- ('<' Spnl ('tfoot' / 'TFOOT') Spnl HtmlAttribute* '>') */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('<') {
+				goto l782
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l782
 			}
 			{
 				position783, thunkPosition783 := position, thunkPosition
-				if !p.rules[ruleEndline]() {
-					goto l783
+				if !matchString("tfoot") {
+					goto l784
 				}
-				goto l784
-			l783:
+				goto l783
+			l784:
 				position, thunkPosition = position783, thunkPosition783
+				if !matchString("TFOOT") {
+					goto l782
+				}
+			}
+		l783:
+			if !p.rules[ruleSpnl]() {
+				goto l782
+			}
+		l785:
+			{
+				position786, thunkPosition786 := position, thunkPosition
+				if !p.rules[ruleHtmlAttribute]() {
+					goto l786
+				}
+				goto l785
+			l786:
+				position, thunkPosition = position786, thunkPosition786
+			}
+			if !matchChar('>') {
+				goto l782
 			}
-		l784:
-			do(44)
-			doarg(yyPop, 2)
 			return true
-		l772:
+		l782:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 138 Inline <- (Str / Endline / UlOrStarLine / Space / Strong / Emph / Image / Link / NoteReference / InlineNote / Code / RawHtml / Entity / EscapedChar / Smart / Symbol) */
+		/* 160 HtmlBlockCloseTfoot <- ('<' Spnl '/' ('tfoot' / 'TFOOT') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('<') {
+				goto l787
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l787
+			}
+			if !matchChar('/') {
+				goto l787
+			}
 			{
-				position786, thunkPosition786 := position, thunkPosition
-				if !p.rules[ruleStr]() {
-					goto l787
-				}
-				goto l786
-			l787:
-				position, thunkPosition = position786, thunkPosition786
-				if !p.rules[ruleEndline]() {
-					goto l788
-				}
-				goto l786
-			l788:
-				position, thunkPosition = position786, thunkPosition786
-				if !p.rules[ruleUlOrStarLine]() {
+				position788, thunkPosition788 := position, thunkPosition
+				if !matchString("tfoot") {
 					goto l789
 				}
-				goto l786
+				goto l788
 			l789:
-				position, thunkPosition = position786, thunkPosition786
-				if !p.rules[ruleSpace]() {
-					goto l790
-				}
-				goto l786
-			l790:
-				position, thunkPosition = position786, thunkPosition786
-				if !p.rules[ruleStrong]() {
-					goto l791
-				}
-				goto l786
-			l791:
-				position, thunkPosition = position786, thunkPosition786
-				if !p.rules[ruleEmph]() {
-					goto l792
+				position, thunkPosition = position788, thunkPosition788
+				if !matchString("TFOOT") {
+					goto l787
 				}
-				goto l786
-			l792:
-				position, thunkPosition = position786, thunkPosition786
-				if !p.rules[ruleImage]() {
+			}
+		l788:
+			if !p.rules[ruleSpnl]() {
+				goto l787
+			}
+			if !matchChar('>') {
+				goto l787
+			}
+			return true
+		l787:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 161 HtmlBlockTfoot <- (HtmlBlockOpenTfoot (HtmlBlockTfoot / (!HtmlBlockCloseTfoot .))* HtmlBlockCloseTfoot) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !p.rules[ruleHtmlBlockOpenTfoot]() {
+				goto l790
+			}
+		l791:
+			{
+				position792, thunkPosition792 := position, thunkPosition
+				{
+					position793, thunkPosition793 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockTfoot]() {
+						goto l794
+					}
 					goto l793
+				l794:
+					position, thunkPosition = position793, thunkPosition793
+					{
+						position795, thunkPosition795 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseTfoot]() {
+							goto l795
+						}
+						goto l792
+					l795:
+						position, thunkPosition = position795, thunkPosition795
+					}
+					if !matchDot() {
+						goto l792
+					}
 				}
-				goto l786
 			l793:
-				position, thunkPosition = position786, thunkPosition786
-				if !p.rules[ruleLink]() {
-					goto l794
-				}
-				goto l786
-			l794:
-				position, thunkPosition = position786, thunkPosition786
-				if !p.rules[ruleNoteReference]() {
-					goto l795
-				}
-				goto l786
-			l795:
-				position, thunkPosition = position786, thunkPosition786
-				if !p.rules[ruleInlineNote]() {
-					goto l796
-				}
-				goto l786
-			l796:
-				position, thunkPosition = position786, thunkPosition786
-				if !p.rules[ruleCode]() {
-					goto l797
-				}
-				goto l786
-			l797:
-				position, thunkPosition = position786, thunkPosition786
-				if !p.rules[ruleRawHtml]() {
+				goto l791
+			l792:
+				position, thunkPosition = position792, thunkPosition792
+			}
+			if !p.rules[ruleHtmlBlockCloseTfoot]() {
+				goto l790
+			}
+			return true
+		l790:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 162 HtmlBlockOpenTh <- ('<' Spnl ('th' / 'TH') Spnl HtmlAttribute* '>') */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('<') {
+				goto l796
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l796
+			}
+			{
+				position797, thunkPosition797 := position, thunkPosition
+				if !matchString("th") {
 					goto l798
 				}
-				goto l786
+				goto l797
 			l798:
-				position, thunkPosition = position786, thunkPosition786
-				if !p.rules[ruleEntity]() {
-					goto l799
+				position, thunkPosition = position797, thunkPosition797
+				if !matchString("TH") {
+					goto l796
 				}
-				goto l786
-			l799:
-				position, thunkPosition = position786, thunkPosition786
-				if !p.rules[ruleEscapedChar]() {
+			}
+		l797:
+			if !p.rules[ruleSpnl]() {
+				goto l796
+			}
+		l799:
+			{
+				position800, thunkPosition800 := position, thunkPosition
+				if !p.rules[ruleHtmlAttribute]() {
 					goto l800
 				}
-				goto l786
+				goto l799
 			l800:
-				position, thunkPosition = position786, thunkPosition786
-				if !p.rules[ruleSmart]() {
-					goto l801
-				}
-				goto l786
-			l801:
-				position, thunkPosition = position786, thunkPosition786
-				if !p.rules[ruleSymbol]() {
-					goto l785
-				}
+				position, thunkPosition = position800, thunkPosition800
+			}
+			if !matchChar('>') {
+				goto l796
 			}
-		l786:
 			return true
-		l785:
+		l796:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 139 Space <- (Spacechar+ { yy = mk_str(" ")
-          yy.key = SPACE }) */
+		/* 163 HtmlBlockCloseTh <- ('<' Spnl '/' ('th' / 'TH') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleSpacechar]() {
-				goto l802
+			if !matchChar('<') {
+				goto l801
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l801
+			}
+			if !matchChar('/') {
+				goto l801
 			}
-		l803:
 			{
-				position804, thunkPosition804 := position, thunkPosition
-				if !p.rules[ruleSpacechar]() {
-					goto l804
+				position802, thunkPosition802 := position, thunkPosition
+				if !matchString("th") {
+					goto l803
+				}
+				goto l802
+			l803:
+				position, thunkPosition = position802, thunkPosition802
+				if !matchString("TH") {
+					goto l801
 				}
-				goto l803
-			l804:
-				position, thunkPosition = position804, thunkPosition804
 			}
-			do(45)
-			return true
 		l802:
+			if !p.rules[ruleSpnl]() {
+				goto l801
+			}
+			if !matchChar('>') {
+				goto l801
+			}
+			return true
+		l801:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 140 Str <- (< NormalChar (NormalChar / ('_'+ &Alphanumeric))* > { yy = mk_str(yytext) }) */
+		/* 164 HtmlBlockTh <- (HtmlBlockOpenTh (HtmlBlockTh / (!HtmlBlockCloseTh .))* HtmlBlockCloseTh) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			begin = position
-			if !p.rules[ruleNormalChar]() {
-				goto l805
+			if !p.rules[ruleHtmlBlockOpenTh]() {
+				goto l804
 			}
-		l806:
+		l805:
 			{
-				position807, thunkPosition807 := position, thunkPosition
+				position806, thunkPosition806 := position, thunkPosition
 				{
-					position808, thunkPosition808 := position, thunkPosition
-					if !p.rules[ruleNormalChar]() {
-						goto l809
-					}
-					goto l808
-				l809:
-					position, thunkPosition = position808, thunkPosition808
-					if !matchChar('_') {
-						goto l807
+					position807, thunkPosition807 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockTh]() {
+						goto l808
 					}
-				l810:
+					goto l807
+				l808:
+					position, thunkPosition = position807, thunkPosition807
 					{
-						position811, thunkPosition811 := position, thunkPosition
-						if !matchChar('_') {
-							goto l811
+						position809, thunkPosition809 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseTh]() {
+							goto l809
 						}
-						goto l810
-					l811:
-						position, thunkPosition = position811, thunkPosition811
+						goto l806
+					l809:
+						position, thunkPosition = position809, thunkPosition809
 					}
-					{
-						position812, thunkPosition812 := position, thunkPosition
-						if !p.rules[ruleAlphanumeric]() {
-							goto l807
-						}
-						position, thunkPosition = position812, thunkPosition812
+					if !matchDot() {
+						goto l806
 					}
 				}
-			l808:
-				goto l806
 			l807:
-				position, thunkPosition = position807, thunkPosition807
+				goto l805
+			l806:
+				position, thunkPosition = position806, thunkPosition806
+			}
+			if !p.rules[ruleHtmlBlockCloseTh]() {
+				goto l804
 			}
-			end = position
-			do(46)
 			return true
-		l805:
+		l804:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 141 EscapedChar <- ('\\' !Newline < [-\\`|*_{}[\]()#+.!><] > { yy = mk_str(yytext) }) */
+		/* 165 HtmlBlockOpenThead <- ('<' Spnl ('thead' / 'THEAD') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('\\') {
-				goto l813
+			if !matchChar('<') {
+				goto l810
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l810
+			}
+			{
+				position811, thunkPosition811 := position, thunkPosition
+				if !matchString("thead") {
+					goto l812
+				}
+				goto l811
+			l812:
+				position, thunkPosition = position811, thunkPosition811
+				if !matchString("THEAD") {
+					goto l810
+				}
+			}
+		l811:
+			if !p.rules[ruleSpnl]() {
+				goto l810
 			}
+		l813:
 			{
 				position814, thunkPosition814 := position, thunkPosition
-				if !p.rules[ruleNewline]() {
+				if !p.rules[ruleHtmlAttribute]() {
 					goto l814
 				}
 				goto l813
 			l814:
 				position, thunkPosition = position814, thunkPosition814
 			}
-			begin = position
-			if !matchClass(2) {
-				goto l813
+			if !matchChar('>') {
+				goto l810
 			}
-			end = position
-			do(47)
 			return true
-		l813:
+		l810:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 142 Entity <- ((HexEntity / DecEntity / CharEntity) { yy = mk_str(yytext); yy.key = HTML }) */
+		/* 166 HtmlBlockCloseThead <- ('<' Spnl '/' ('thead' / 'THEAD') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('<') {
+				goto l815
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l815
+			}
+			if !matchChar('/') {
+				goto l815
+			}
 			{
 				position816, thunkPosition816 := position, thunkPosition
-				if !p.rules[ruleHexEntity]() {
+				if !matchString("thead") {
 					goto l817
 				}
 				goto l816
 			l817:
 				position, thunkPosition = position816, thunkPosition816
-				if !p.rules[ruleDecEntity]() {
-					goto l818
-				}
-				goto l816
-			l818:
-				position, thunkPosition = position816, thunkPosition816
-				if !p.rules[ruleCharEntity]() {
+				if !matchString("THEAD") {
 					goto l815
 				}
 			}
 		l816:
-			do(48)
-			return true
-		l815:
-			position, thunkPosition = position0, thunkPosition0
+			if !p.rules[ruleSpnl]() {
+				goto l815
+			}
+			if !matchChar('>') {
+				goto l815
+			}
+			return true
+		l815:
+			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 143 Endline <- (LineBreak / TerminalEndline / NormalEndline) */
+		/* 167 HtmlBlockThead <- (HtmlBlockOpenThead (HtmlBlockThead / (!HtmlBlockCloseThead .))* HtmlBlockCloseThead) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
+			if !p.rules[ruleHtmlBlockOpenThead]() {
+				goto l818
+			}
+		l819:
 			{
 				position820, thunkPosition820 := position, thunkPosition
-				if !p.rules[ruleLineBreak]() {
+				{
+					position821, thunkPosition821 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockThead]() {
+						goto l822
+					}
 					goto l821
+				l822:
+					position, thunkPosition = position821, thunkPosition821
+					{
+						position823, thunkPosition823 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseThead]() {
+							goto l823
+						}
+						goto l820
+					l823:
+						position, thunkPosition = position823, thunkPosition823
+					}
+					if !matchDot() {
+						goto l820
+					}
 				}
-				goto l820
 			l821:
+				goto l819
+			l820:
 				position, thunkPosition = position820, thunkPosition820
-				if !p.rules[ruleTerminalEndline]() {
-					goto l822
-				}
-				goto l820
-			l822:
-				position, thunkPosition = position820, thunkPosition820
-				if !p.rules[ruleNormalEndline]() {
-					goto l819
-				}
 			}
-		l820:
+			if !p.rules[ruleHtmlBlockCloseThead]() {
+				goto l818
+			}
 			return true
-		l819:
+		l818:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 144 NormalEndline <- (Sp Newline !BlankLine !'>' !AtxStart !(Line (('===' '='*) / ('---' '-'*)) Newline) { yy = mk_str("\n")
-                    yy.key = SPACE }) */
+		/* 168 HtmlBlockOpenTr <- ('<' Spnl ('tr' / 'TR') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleSp]() {
-				goto l823
+			if !matchChar('<') {
+				goto l824
 			}
-			if !p.rules[ruleNewline]() {
-				goto l823
+			if !p.rules[ruleSpnl]() {
+				goto l824
 			}
 			{
-				position824, thunkPosition824 := position, thunkPosition
-				if !p.rules[ruleBlankLine]() {
+				position825, thunkPosition825 := position, thunkPosition
+				if !matchString("tr") {
+					goto l826
+				}
+				goto l825
+			l826:
+				position, thunkPosition = position825, thunkPosition825
+				if !matchString("TR") {
 					goto l824
 				}
-				goto l823
-			l824:
-				position, thunkPosition = position824, thunkPosition824
 			}
-			if peekChar('>') {
-				goto l823
+		l825:
+			if !p.rules[ruleSpnl]() {
+				goto l824
 			}
+		l827:
 			{
-				position825, thunkPosition825 := position, thunkPosition
-				if !p.rules[ruleAtxStart]() {
-					goto l825
+				position828, thunkPosition828 := position, thunkPosition
+				if !p.rules[ruleHtmlAttribute]() {
+					goto l828
 				}
-				goto l823
-			l825:
-				position, thunkPosition = position825, thunkPosition825
+				goto l827
+			l828:
+				position, thunkPosition = position828, thunkPosition828
 			}
-			{
-				position826, thunkPosition826 := position, thunkPosition
-				if !p.rules[ruleLine]() {
-					goto l826
-				}
-				{
-					position827, thunkPosition827 := position, thunkPosition
-					if !matchString("===") {
-						goto l828
-					}
-				l829:
-					{
-						position830, thunkPosition830 := position, thunkPosition
-						if !matchChar('=') {
-							goto l830
-						}
-						goto l829
-					l830:
-						position, thunkPosition = position830, thunkPosition830
-					}
-					goto l827
-				l828:
-					position, thunkPosition = position827, thunkPosition827
-					if !matchString("---") {
-						goto l826
-					}
-				l831:
-					{
-						position832, thunkPosition832 := position, thunkPosition
-						if !matchChar('-') {
-							goto l832
-						}
-						goto l831
-					l832:
-						position, thunkPosition = position832, thunkPosition832
-					}
-				}
-			l827:
-				if !p.rules[ruleNewline]() {
-					goto l826
-				}
-				goto l823
-			l826:
-				position, thunkPosition = position826, thunkPosition826
+			if !matchChar('>') {
+				goto l824
 			}
-			do(49)
 			return true
-		l823:
+		l824:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 145 TerminalEndline <- (Sp Newline Eof { yy = nil }) */
+		/* 169 HtmlBlockCloseTr <- ('<' Spnl '/' ('tr' / 'TR') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleSp]() {
-				goto l833
+			if !matchChar('<') {
+				goto l829
 			}
-			if !p.rules[ruleNewline]() {
-				goto l833
+			if !p.rules[ruleSpnl]() {
+				goto l829
 			}
-			if !p.rules[ruleEof]() {
-				goto l833
+			if !matchChar('/') {
+				goto l829
 			}
-			do(50)
-			return true
-		l833:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 146 LineBreak <- ('  ' NormalEndline { yy = mk_element(LINEBREAK) }) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			if !matchString("  ") {
-				goto l834
+			{
+				position830, thunkPosition830 := position, thunkPosition
+				if !matchString("tr") {
+					goto l831
+				}
+				goto l830
+			l831:
+				position, thunkPosition = position830, thunkPosition830
+				if !matchString("TR") {
+					goto l829
+				}
 			}
-			if !p.rules[ruleNormalEndline]() {
-				goto l834
+		l830:
+			if !p.rules[ruleSpnl]() {
+				goto l829
+			}
+			if !matchChar('>') {
+				goto l829
 			}
-			do(51)
 			return true
-		l834:
+		l829:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 147 Symbol <- (< SpecialChar > { yy = mk_str(yytext) }) */
+		/* 170 HtmlBlockTr <- (HtmlBlockOpenTr (HtmlBlockTr / (!HtmlBlockCloseTr .))* HtmlBlockCloseTr) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			begin = position
-			if !p.rules[ruleSpecialChar]() {
-				goto l835
+			if !p.rules[ruleHtmlBlockOpenTr]() {
+				goto l832
+			}
+		l833:
+			{
+				position834, thunkPosition834 := position, thunkPosition
+				{
+					position835, thunkPosition835 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockTr]() {
+						goto l836
+					}
+					goto l835
+				l836:
+					position, thunkPosition = position835, thunkPosition835
+					{
+						position837, thunkPosition837 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseTr]() {
+							goto l837
+						}
+						goto l834
+					l837:
+						position, thunkPosition = position837, thunkPosition837
+					}
+					if !matchDot() {
+						goto l834
+					}
+				}
+			l835:
+				goto l833
+			l834:
+				position, thunkPosition = position834, thunkPosition834
+			}
+			if !p.rules[ruleHtmlBlockCloseTr]() {
+				goto l832
 			}
-			end = position
-			do(52)
 			return true
-		l835:
+		l832:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 148 UlOrStarLine <- ((UlLine / StarLine) { yy = mk_str(yytext) }) */
+		/* 171 HtmlBlockOpenScript <- ('<' Spnl ('script' / 'SCRIPT') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('<') {
+				goto l838
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l838
+			}
 			{
-				position837, thunkPosition837 := position, thunkPosition
-				if !p.rules[ruleUlLine]() {
+				position839, thunkPosition839 := position, thunkPosition
+				if !matchString("script") {
+					goto l840
+				}
+				goto l839
+			l840:
+				position, thunkPosition = position839, thunkPosition839
+				if !matchString("SCRIPT") {
 					goto l838
 				}
-				goto l837
-			l838:
-				position, thunkPosition = position837, thunkPosition837
-				if !p.rules[ruleStarLine]() {
-					goto l836
+			}
+		l839:
+			if !p.rules[ruleSpnl]() {
+				goto l838
+			}
+		l841:
+			{
+				position842, thunkPosition842 := position, thunkPosition
+				if !p.rules[ruleHtmlAttribute]() {
+					goto l842
 				}
+				goto l841
+			l842:
+				position, thunkPosition = position842, thunkPosition842
+			}
+			if !matchChar('>') {
+				goto l838
 			}
-		l837:
-			do(53)
 			return true
-		l836:
+		l838:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 149 StarLine <- ((< '****' '*'* >) / (< Spacechar '*'+ &Spacechar >)) */
+		/* 172 HtmlBlockCloseScript <- ('<' Spnl '/' ('script' / 'SCRIPT') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('<') {
+				goto l843
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l843
+			}
+			if !matchChar('/') {
+				goto l843
+			}
 			{
-				position840, thunkPosition840 := position, thunkPosition
-				begin = position
-				if !matchString("****") {
-					goto l841
-				}
-			l842:
-				{
-					position843, thunkPosition843 := position, thunkPosition
-					if !matchChar('*') {
-						goto l843
-					}
-					goto l842
-				l843:
-					position, thunkPosition = position843, thunkPosition843
-				}
-				end = position
-				goto l840
-			l841:
-				position, thunkPosition = position840, thunkPosition840
-				begin = position
-				if !p.rules[ruleSpacechar]() {
-					goto l839
-				}
-				if !matchChar('*') {
-					goto l839
-				}
-			l844:
-				{
-					position845, thunkPosition845 := position, thunkPosition
-					if !matchChar('*') {
-						goto l845
-					}
-					goto l844
-				l845:
-					position, thunkPosition = position845, thunkPosition845
+				position844, thunkPosition844 := position, thunkPosition
+				if !matchString("script") {
+					goto l845
 				}
-				{
-					position846, thunkPosition846 := position, thunkPosition
-					if !p.rules[ruleSpacechar]() {
-						goto l839
-					}
-					position, thunkPosition = position846, thunkPosition846
+				goto l844
+			l845:
+				position, thunkPosition = position844, thunkPosition844
+				if !matchString("SCRIPT") {
+					goto l843
 				}
-				end = position
 			}
-		l840:
+		l844:
+			if !p.rules[ruleSpnl]() {
+				goto l843
+			}
+			if !matchChar('>') {
+				goto l843
+			}
 			return true
-		l839:
+		l843:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 150 UlLine <- ((< '____' '_'* >) / (< Spacechar '_'+ &Spacechar >)) */
+		/* 173 HtmlBlockScript <- (HtmlBlockOpenScript (HtmlBlockScript / (!HtmlBlockCloseScript .))* HtmlBlockCloseScript) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
+			if !p.rules[ruleHtmlBlockOpenScript]() {
+				goto l846
+			}
+		l847:
 			{
 				position848, thunkPosition848 := position, thunkPosition
-				begin = position
-				if !matchString("____") {
-					goto l849
-				}
-			l850:
 				{
-					position851, thunkPosition851 := position, thunkPosition
-					if !matchChar('_') {
-						goto l851
+					position849, thunkPosition849 := position, thunkPosition
+					if !p.rules[ruleHtmlBlockScript]() {
+						goto l850
 					}
-					goto l850
-				l851:
-					position, thunkPosition = position851, thunkPosition851
-				}
-				end = position
-				goto l848
-			l849:
-				position, thunkPosition = position848, thunkPosition848
-				begin = position
-				if !p.rules[ruleSpacechar]() {
-					goto l847
-				}
-				if !matchChar('_') {
-					goto l847
-				}
-			l852:
-				{
-					position853, thunkPosition853 := position, thunkPosition
-					if !matchChar('_') {
-						goto l853
+					goto l849
+				l850:
+					position, thunkPosition = position849, thunkPosition849
+					{
+						position851, thunkPosition851 := position, thunkPosition
+						if !p.rules[ruleHtmlBlockCloseScript]() {
+							goto l851
+						}
+						goto l848
+					l851:
+						position, thunkPosition = position851, thunkPosition851
 					}
-					goto l852
-				l853:
-					position, thunkPosition = position853, thunkPosition853
-				}
-				{
-					position854, thunkPosition854 := position, thunkPosition
-					if !p.rules[ruleSpacechar]() {
-						goto l847
+					if !matchDot() {
+						goto l848
 					}
-					position, thunkPosition = position854, thunkPosition854
 				}
-				end = position
+			l849:
+				goto l847
+			l848:
+				position, thunkPosition = position848, thunkPosition848
+			}
+			if !p.rules[ruleHtmlBlockCloseScript]() {
+				goto l846
 			}
-		l848:
 			return true
-		l847:
+		l846:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 151 Emph <- (EmphStar / EmphUl) */
+		/* 174 HtmlBlockInTags <- (HtmlBlockAddress / HtmlBlockBlockquote / HtmlBlockCenter / HtmlBlockDir / HtmlBlockDiv / HtmlBlockDl / HtmlBlockFieldset / HtmlBlockForm / HtmlBlockH1 / HtmlBlockH2 / HtmlBlockH3 / HtmlBlockH4 / HtmlBlockH5 / HtmlBlockH6 / HtmlBlockMenu / HtmlBlockNoframes / HtmlBlockNoscript / HtmlBlockOl / HtmlBlockP / HtmlBlockPre / HtmlBlockTable / HtmlBlockUl / HtmlBlockDd / HtmlBlockDt / HtmlBlockFrameset / HtmlBlockLi / HtmlBlockTbody / HtmlBlockTd / HtmlBlockTfoot / HtmlBlockTh / HtmlBlockThead / HtmlBlockTr / HtmlBlockScript) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			{
-				position856, thunkPosition856 := position, thunkPosition
-				if !p.rules[ruleEmphStar]() {
+				position853, thunkPosition853 := position, thunkPosition
+				if !p.rules[ruleHtmlBlockAddress]() {
+					goto l854
+				}
+				goto l853
+			l854:
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockBlockquote]() {
+					goto l855
+				}
+				goto l853
+			l855:
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockCenter]() {
+					goto l856
+				}
+				goto l853
+			l856:
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockDir]() {
 					goto l857
 				}
-				goto l856
+				goto l853
 			l857:
-				position, thunkPosition = position856, thunkPosition856
-				if !p.rules[ruleEmphUl]() {
-					goto l855
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockDiv]() {
+					goto l858
 				}
-			}
-		l856:
-			return true
-		l855:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 152 OneStarOpen <- (!StarLine '*' !Spacechar !Newline) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			{
-				position859, thunkPosition859 := position, thunkPosition
-				if !p.rules[ruleStarLine]() {
+				goto l853
+			l858:
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockDl]() {
 					goto l859
 				}
-				goto l858
+				goto l853
 			l859:
-				position, thunkPosition = position859, thunkPosition859
-			}
-			if !matchChar('*') {
-				goto l858
-			}
-			{
-				position860, thunkPosition860 := position, thunkPosition
-				if !p.rules[ruleSpacechar]() {
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockFieldset]() {
 					goto l860
 				}
-				goto l858
+				goto l853
 			l860:
-				position, thunkPosition = position860, thunkPosition860
-			}
-			{
-				position861, thunkPosition861 := position, thunkPosition
-				if !p.rules[ruleNewline]() {
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockForm]() {
 					goto l861
 				}
-				goto l858
+				goto l853
 			l861:
-				position, thunkPosition = position861, thunkPosition861
-			}
-			return true
-		l858:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 153 OneStarClose <- (!Spacechar !Newline Inline !StrongStar '*' { yy = a }) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 1)
-			{
-				position863, thunkPosition863 := position, thunkPosition
-				if !p.rules[ruleSpacechar]() {
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockH1]() {
+					goto l862
+				}
+				goto l853
+			l862:
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockH2]() {
 					goto l863
 				}
-				goto l862
+				goto l853
 			l863:
-				position, thunkPosition = position863, thunkPosition863
-			}
-			{
-				position864, thunkPosition864 := position, thunkPosition
-				if !p.rules[ruleNewline]() {
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockH3]() {
 					goto l864
 				}
-				goto l862
+				goto l853
 			l864:
-				position, thunkPosition = position864, thunkPosition864
-			}
-			if !p.rules[ruleInline]() {
-				goto l862
-			}
-			doarg(yySet, -1)
-			{
-				position865, thunkPosition865 := position, thunkPosition
-				if !p.rules[ruleStrongStar]() {
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockH4]() {
 					goto l865
 				}
-				goto l862
+				goto l853
 			l865:
-				position, thunkPosition = position865, thunkPosition865
-			}
-			if !matchChar('*') {
-				goto l862
-			}
-			do(54)
-			doarg(yyPop, 1)
-			return true
-		l862:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 154 EmphStar <- (OneStarOpen StartList (!OneStarClose Inline { a = cons(yy, a) })* OneStarClose { a = cons(yy, a) } { yy = mk_list(EMPH, a) }) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 1)
-			if !p.rules[ruleOneStarOpen]() {
-				goto l866
-			}
-			if !p.rules[ruleStartList]() {
-				goto l866
-			}
-			doarg(yySet, -1)
-		l867:
-			{
-				position868, thunkPosition868 := position, thunkPosition
-				{
-					position869, thunkPosition869 := position, thunkPosition
-					if !p.rules[ruleOneStarClose]() {
-						goto l869
-					}
-					goto l868
-				l869:
-					position, thunkPosition = position869, thunkPosition869
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockH5]() {
+					goto l866
 				}
-				if !p.rules[ruleInline]() {
+				goto l853
+			l866:
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockH6]() {
+					goto l867
+				}
+				goto l853
+			l867:
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockMenu]() {
 					goto l868
 				}
-				do(55)
-				goto l867
+				goto l853
 			l868:
-				position, thunkPosition = position868, thunkPosition868
-			}
-			if !p.rules[ruleOneStarClose]() {
-				goto l866
-			}
-			do(56)
-			do(57)
-			doarg(yyPop, 1)
-			return true
-		l866:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 155 OneUlOpen <- (!UlLine '_' !Spacechar !Newline) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			{
-				position871, thunkPosition871 := position, thunkPosition
-				if !p.rules[ruleUlLine]() {
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockNoframes]() {
+					goto l869
+				}
+				goto l853
+			l869:
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockNoscript]() {
+					goto l870
+				}
+				goto l853
+			l870:
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockOl]() {
 					goto l871
 				}
-				goto l870
+				goto l853
 			l871:
-				position, thunkPosition = position871, thunkPosition871
-			}
-			if !matchChar('_') {
-				goto l870
-			}
-			{
-				position872, thunkPosition872 := position, thunkPosition
-				if !p.rules[ruleSpacechar]() {
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockP]() {
 					goto l872
 				}
-				goto l870
+				goto l853
 			l872:
-				position, thunkPosition = position872, thunkPosition872
-			}
-			{
-				position873, thunkPosition873 := position, thunkPosition
-				if !p.rules[ruleNewline]() {
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockPre]() {
 					goto l873
 				}
-				goto l870
+				goto l853
 			l873:
-				position, thunkPosition = position873, thunkPosition873
-			}
-			return true
-		l870:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 156 OneUlClose <- (!Spacechar !Newline Inline !StrongUl '_' !Alphanumeric { yy = a }) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 1)
-			{
-				position875, thunkPosition875 := position, thunkPosition
-				if !p.rules[ruleSpacechar]() {
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockTable]() {
+					goto l874
+				}
+				goto l853
+			l874:
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockUl]() {
 					goto l875
 				}
-				goto l874
+				goto l853
 			l875:
-				position, thunkPosition = position875, thunkPosition875
-			}
-			{
-				position876, thunkPosition876 := position, thunkPosition
-				if !p.rules[ruleNewline]() {
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockDd]() {
 					goto l876
 				}
-				goto l874
+				goto l853
 			l876:
-				position, thunkPosition = position876, thunkPosition876
-			}
-			if !p.rules[ruleInline]() {
-				goto l874
-			}
-			doarg(yySet, -1)
-			{
-				position877, thunkPosition877 := position, thunkPosition
-				if !p.rules[ruleStrongUl]() {
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockDt]() {
 					goto l877
 				}
-				goto l874
+				goto l853
 			l877:
-				position, thunkPosition = position877, thunkPosition877
-			}
-			if !matchChar('_') {
-				goto l874
-			}
-			{
-				position878, thunkPosition878 := position, thunkPosition
-				if !p.rules[ruleAlphanumeric]() {
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockFrameset]() {
 					goto l878
 				}
-				goto l874
+				goto l853
 			l878:
-				position, thunkPosition = position878, thunkPosition878
-			}
-			do(58)
-			doarg(yyPop, 1)
-			return true
-		l874:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 157 EmphUl <- (OneUlOpen StartList (!OneUlClose Inline { a = cons(yy, a) })* OneUlClose { a = cons(yy, a) } { yy = mk_list(EMPH, a) }) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 1)
-			if !p.rules[ruleOneUlOpen]() {
-				goto l879
-			}
-			if !p.rules[ruleStartList]() {
-				goto l879
-			}
-			doarg(yySet, -1)
-		l880:
-			{
-				position881, thunkPosition881 := position, thunkPosition
-				{
-					position882, thunkPosition882 := position, thunkPosition
-					if !p.rules[ruleOneUlClose]() {
-						goto l882
-					}
-					goto l881
-				l882:
-					position, thunkPosition = position882, thunkPosition882
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockLi]() {
+					goto l879
 				}
-				if !p.rules[ruleInline]() {
+				goto l853
+			l879:
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockTbody]() {
+					goto l880
+				}
+				goto l853
+			l880:
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockTd]() {
 					goto l881
 				}
-				do(59)
-				goto l880
+				goto l853
 			l881:
-				position, thunkPosition = position881, thunkPosition881
-			}
-			if !p.rules[ruleOneUlClose]() {
-				goto l879
-			}
-			do(60)
-			do(61)
-			doarg(yyPop, 1)
-			return true
-		l879:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 158 Strong <- (StrongStar / StrongUl) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			{
-				position884, thunkPosition884 := position, thunkPosition
-				if !p.rules[ruleStrongStar]() {
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockTfoot]() {
+					goto l882
+				}
+				goto l853
+			l882:
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockTh]() {
+					goto l883
+				}
+				goto l853
+			l883:
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockThead]() {
+					goto l884
+				}
+				goto l853
+			l884:
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockTr]() {
 					goto l885
 				}
-				goto l884
+				goto l853
 			l885:
-				position, thunkPosition = position884, thunkPosition884
-				if !p.rules[ruleStrongUl]() {
-					goto l883
+				position, thunkPosition = position853, thunkPosition853
+				if !p.rules[ruleHtmlBlockScript]() {
+					goto l852
 				}
 			}
-		l884:
+		l853:
 			return true
-		l883:
+		l852:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 159 TwoStarOpen <- (!StarLine '**' !Spacechar !Newline) */
+		/* 175 HtmlBlock <- (&'<' < (HtmlBlockInTags / HtmlComment / HtmlBlockSelfClosing) > BlankLine+ { yy = p.rawHTML(yytext, true) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			{
-				position887, thunkPosition887 := position, thunkPosition
-				if !p.rules[ruleStarLine]() {
-					goto l887
-				}
-				goto l886
-			l887:
-				position, thunkPosition = position887, thunkPosition887
-			}
-			if !matchString("**") {
+			if !peekChar('<') {
 				goto l886
 			}
+			begin = position
 			{
-				position888, thunkPosition888 := position, thunkPosition
-				if !p.rules[ruleSpacechar]() {
+				position887, thunkPosition887 := position, thunkPosition
+				if !p.rules[ruleHtmlBlockInTags]() {
 					goto l888
 				}
-				goto l886
+				goto l887
 			l888:
-				position, thunkPosition = position888, thunkPosition888
-			}
-			{
-				position889, thunkPosition889 := position, thunkPosition
-				if !p.rules[ruleNewline]() {
+				position, thunkPosition = position887, thunkPosition887
+				if !p.rules[ruleHtmlComment]() {
 					goto l889
 				}
-				goto l886
+				goto l887
 			l889:
-				position, thunkPosition = position889, thunkPosition889
+				position, thunkPosition = position887, thunkPosition887
+				if !p.rules[ruleHtmlBlockSelfClosing]() {
+					goto l886
+				}
 			}
-			return true
-		l886:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 160 TwoStarClose <- (!Spacechar !Newline Inline '**' { yy = a }) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 1)
+		l887:
+			end = position
+			if !p.rules[ruleBlankLine]() {
+				goto l886
+			}
+		l890:
 			{
 				position891, thunkPosition891 := position, thunkPosition
-				if !p.rules[ruleSpacechar]() {
+				if !p.rules[ruleBlankLine]() {
 					goto l891
 				}
 				goto l890
 			l891:
 				position, thunkPosition = position891, thunkPosition891
 			}
-			{
-				position892, thunkPosition892 := position, thunkPosition
-				if !p.rules[ruleNewline]() {
-					goto l892
-				}
-				goto l890
-			l892:
-				position, thunkPosition = position892, thunkPosition892
-			}
-			if !p.rules[ruleInline]() {
-				goto l890
-			}
-			doarg(yySet, -1)
-			if !matchString("**") {
-				goto l890
-			}
-			do(62)
-			doarg(yyPop, 1)
+			do(83)
 			return true
-		l890:
+		l886:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 161 StrongStar <- (TwoStarOpen StartList (!TwoStarClose Inline { a = cons(yy, a) })* TwoStarClose { a = cons(yy, a) } { yy = mk_list(STRONG, a) }) */
+		/* 176 HtmlBlockSelfClosing <- ('<' Spnl HtmlBlockType Spnl HtmlAttribute* '/' Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 1)
-			if !p.rules[ruleTwoStarOpen]() {
-				goto l893
+			if !matchChar('<') {
+				goto l892
 			}
-			if !p.rules[ruleStartList]() {
-				goto l893
+			if !p.rules[ruleSpnl]() {
+				goto l892
 			}
-			doarg(yySet, -1)
-		l894:
+			if !p.rules[ruleHtmlBlockType]() {
+				goto l892
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l892
+			}
+		l893:
 			{
-				position895, thunkPosition895 := position, thunkPosition
-				{
-					position896, thunkPosition896 := position, thunkPosition
-					if !p.rules[ruleTwoStarClose]() {
-						goto l896
-					}
-					goto l895
-				l896:
-					position, thunkPosition = position896, thunkPosition896
-				}
-				if !p.rules[ruleInline]() {
-					goto l895
+				position894, thunkPosition894 := position, thunkPosition
+				if !p.rules[ruleHtmlAttribute]() {
+					goto l894
 				}
-				do(63)
-				goto l894
-			l895:
-				position, thunkPosition = position895, thunkPosition895
-			}
-			if !p.rules[ruleTwoStarClose]() {
 				goto l893
+			l894:
+				position, thunkPosition = position894, thunkPosition894
+			}
+			if !matchChar('/') {
+				goto l892
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l892
+			}
+			if !matchChar('>') {
+				goto l892
 			}
-			do(64)
-			do(65)
-			doarg(yyPop, 1)
 			return true
-		l893:
+		l892:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 162 TwoUlOpen <- (!UlLine '__' !Spacechar !Newline) */
+		/* 177 HtmlBlockType <- ('address' / 'blockquote' / 'center' / 'dir' / 'div' / 'dl' / 'fieldset' / 'form' / 'h1' / 'h2' / 'h3' / 'h4' / 'h5' / 'h6' / 'hr' / 'isindex' / 'menu' / 'noframes' / 'noscript' / 'ol' / 'p' / 'pre' / 'table' / 'ul' / 'dd' / 'dt' / 'frameset' / 'li' / 'tbody' / 'td' / 'tfoot' / 'th' / 'thead' / 'tr' / 'script' / 'ADDRESS' / 'BLOCKQUOTE' / 'CENTER' / 'DIR' / 'DIV' / 'DL' / 'FIELDSET' / 'FORM' / 'H1' / 'H2' / 'H3' / 'H4' / 'H5' / 'H6' / 'HR' / 'ISINDEX' / 'MENU' / 'NOFRAMES' / 'NOSCRIPT' / 'OL' / 'P' / 'PRE' / 'TABLE' / 'UL' / 'DD' / 'DT' / 'FRAMESET' / 'LI' / 'TBODY' / 'TD' / 'TFOOT' / 'TH' / 'THEAD' / 'TR' / 'SCRIPT') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			{
-				position898, thunkPosition898 := position, thunkPosition
-				if !p.rules[ruleUlLine]() {
+				position896, thunkPosition896 := position, thunkPosition
+				if !matchString("address") {
+					goto l897
+				}
+				goto l896
+			l897:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("blockquote") {
 					goto l898
 				}
-				goto l897
+				goto l896
 			l898:
-				position, thunkPosition = position898, thunkPosition898
-			}
-			if !matchString("__") {
-				goto l897
-			}
-			{
-				position899, thunkPosition899 := position, thunkPosition
-				if !p.rules[ruleSpacechar]() {
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("center") {
 					goto l899
 				}
-				goto l897
+				goto l896
 			l899:
-				position, thunkPosition = position899, thunkPosition899
-			}
-			{
-				position900, thunkPosition900 := position, thunkPosition
-				if !p.rules[ruleNewline]() {
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("dir") {
 					goto l900
 				}
-				goto l897
+				goto l896
 			l900:
-				position, thunkPosition = position900, thunkPosition900
-			}
-			return true
-		l897:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 163 TwoUlClose <- (!Spacechar !Newline Inline '__' !Alphanumeric { yy = a }) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 1)
-			{
-				position902, thunkPosition902 := position, thunkPosition
-				if !p.rules[ruleSpacechar]() {
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("div") {
+					goto l901
+				}
+				goto l896
+			l901:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("dl") {
 					goto l902
 				}
-				goto l901
+				goto l896
 			l902:
-				position, thunkPosition = position902, thunkPosition902
-			}
-			{
-				position903, thunkPosition903 := position, thunkPosition
-				if !p.rules[ruleNewline]() {
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("fieldset") {
 					goto l903
 				}
-				goto l901
+				goto l896
 			l903:
-				position, thunkPosition = position903, thunkPosition903
-			}
-			if !p.rules[ruleInline]() {
-				goto l901
-			}
-			doarg(yySet, -1)
-			if !matchString("__") {
-				goto l901
-			}
-			{
-				position904, thunkPosition904 := position, thunkPosition
-				if !p.rules[ruleAlphanumeric]() {
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("form") {
 					goto l904
 				}
-				goto l901
+				goto l896
 			l904:
-				position, thunkPosition = position904, thunkPosition904
-			}
-			do(66)
-			doarg(yyPop, 1)
-			return true
-		l901:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 164 StrongUl <- (TwoUlOpen StartList (!TwoUlClose Inline { a = cons(yy, a) })* TwoUlClose { a = cons(yy, a) } { yy = mk_list(STRONG, a) }) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 1)
-			if !p.rules[ruleTwoUlOpen]() {
-				goto l905
-			}
-			if !p.rules[ruleStartList]() {
-				goto l905
-			}
-			doarg(yySet, -1)
-		l906:
-			{
-				position907, thunkPosition907 := position, thunkPosition
-				{
-					position908, thunkPosition908 := position, thunkPosition
-					if !p.rules[ruleTwoUlClose]() {
-						goto l908
-					}
-					goto l907
-				l908:
-					position, thunkPosition = position908, thunkPosition908
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("h1") {
+					goto l905
 				}
-				if !p.rules[ruleInline]() {
+				goto l896
+			l905:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("h2") {
+					goto l906
+				}
+				goto l896
+			l906:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("h3") {
 					goto l907
 				}
-				do(67)
-				goto l906
+				goto l896
 			l907:
-				position, thunkPosition = position907, thunkPosition907
-			}
-			if !p.rules[ruleTwoUlClose]() {
-				goto l905
-			}
-			do(68)
-			do(69)
-			doarg(yyPop, 1)
-			return true
-		l905:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 165 Image <- ('!' (ExplicitLink / ReferenceLink) {	if yy.key == LINK {
-			yy.key = IMAGE
-		} else {
-			result := yy
-			yy.children = cons(mk_str("!"), result.children)
-		}
-	}) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('!') {
-				goto l909
-			}
-			{
-				position910, thunkPosition910 := position, thunkPosition
-				if !p.rules[ruleExplicitLink]() {
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("h4") {
+					goto l908
+				}
+				goto l896
+			l908:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("h5") {
+					goto l909
+				}
+				goto l896
+			l909:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("h6") {
+					goto l910
+				}
+				goto l896
+			l910:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("hr") {
 					goto l911
 				}
-				goto l910
+				goto l896
 			l911:
-				position, thunkPosition = position910, thunkPosition910
-				if !p.rules[ruleReferenceLink]() {
-					goto l909
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("isindex") {
+					goto l912
 				}
-			}
-		l910:
-			do(70)
-			return true
-		l909:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 166 Link <- (ExplicitLink / ReferenceLink / AutoLink) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			{
-				position913, thunkPosition913 := position, thunkPosition
-				if !p.rules[ruleExplicitLink]() {
+				goto l896
+			l912:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("menu") {
+					goto l913
+				}
+				goto l896
+			l913:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("noframes") {
 					goto l914
 				}
-				goto l913
+				goto l896
 			l914:
-				position, thunkPosition = position913, thunkPosition913
-				if !p.rules[ruleReferenceLink]() {
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("noscript") {
 					goto l915
 				}
-				goto l913
+				goto l896
 			l915:
-				position, thunkPosition = position913, thunkPosition913
-				if !p.rules[ruleAutoLink]() {
-					goto l912
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("ol") {
+					goto l916
 				}
-			}
-		l913:
-			return true
-		l912:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 167 ReferenceLink <- (ReferenceLinkDouble / ReferenceLinkSingle) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			{
-				position917, thunkPosition917 := position, thunkPosition
-				if !p.rules[ruleReferenceLinkDouble]() {
+				goto l896
+			l916:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchChar('p') {
+					goto l917
+				}
+				goto l896
+			l917:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("pre") {
 					goto l918
 				}
-				goto l917
+				goto l896
 			l918:
-				position, thunkPosition = position917, thunkPosition917
-				if !p.rules[ruleReferenceLinkSingle]() {
-					goto l916
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("table") {
+					goto l919
 				}
-			}
-		l917:
-			return true
-		l916:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 168 ReferenceLinkDouble <- (Label < Spnl > !'[]' Label {
-                           if match, found := p.findReference(b.children); found {
-                               yy = mk_link(a.children, match.url, match.title);
-                               a = nil
-                               b = nil
-                           } else {
-                               result := mk_element(LIST)
-                               result.children = cons(mk_str("["), cons(a, cons(mk_str("]"), cons(mk_str(yytext),
-                                                   cons(mk_str("["), cons(b, mk_str("]")))))))
-                               yy = result
-                           }
-                       }) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 2)
-			if !p.rules[ruleLabel]() {
-				goto l919
-			}
-			doarg(yySet, -1)
-			begin = position
-			if !p.rules[ruleSpnl]() {
-				goto l919
-			}
-			end = position
-			{
-				position920, thunkPosition920 := position, thunkPosition
-				if !matchString("[]") {
+				goto l896
+			l919:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("ul") {
 					goto l920
 				}
-				goto l919
+				goto l896
 			l920:
-				position, thunkPosition = position920, thunkPosition920
-			}
-			if !p.rules[ruleLabel]() {
-				goto l919
-			}
-			doarg(yySet, -2)
-			do(71)
-			doarg(yyPop, 2)
-			return true
-		l919:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 169 ReferenceLinkSingle <- (Label < (Spnl '[]')? > {
-                           if match, found := p.findReference(a.children); found {
-                               yy = mk_link(a.children, match.url, match.title)
-                               a = nil
-                           } else {
-                               result := mk_element(LIST)
-                               result.children = cons(mk_str("["), cons(a, cons(mk_str("]"), mk_str(yytext))));
-                               yy = result
-                           }
-                       }) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 1)
-			if !p.rules[ruleLabel]() {
-				goto l921
-			}
-			doarg(yySet, -1)
-			begin = position
-			{
-				position922, thunkPosition922 := position, thunkPosition
-				if !p.rules[ruleSpnl]() {
-					goto l922
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("dd") {
+					goto l921
 				}
-				if !matchString("[]") {
+				goto l896
+			l921:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("dt") {
 					goto l922
 				}
-				goto l923
+				goto l896
 			l922:
-				position, thunkPosition = position922, thunkPosition922
-			}
-		l923:
-			end = position
-			do(72)
-			doarg(yyPop, 1)
-			return true
-		l921:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 170 ExplicitLink <- (Label Spnl '(' Sp Source Spnl Title Sp ')' { yy = mk_link(l.children, s.contents.str, t.contents.str)
-                  s = nil
-                  t = nil
-                  l = nil }) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 3)
-			if !p.rules[ruleLabel]() {
-				goto l924
-			}
-			doarg(yySet, -2)
-			if !p.rules[ruleSpnl]() {
-				goto l924
-			}
-			if !matchChar('(') {
-				goto l924
-			}
-			if !p.rules[ruleSp]() {
-				goto l924
-			}
-			if !p.rules[ruleSource]() {
-				goto l924
-			}
-			doarg(yySet, -1)
-			if !p.rules[ruleSpnl]() {
-				goto l924
-			}
-			if !p.rules[ruleTitle]() {
-				goto l924
-			}
-			doarg(yySet, -3)
-			if !p.rules[ruleSp]() {
-				goto l924
-			}
-			if !matchChar(')') {
-				goto l924
-			}
-			do(73)
-			doarg(yyPop, 3)
-			return true
-		l924:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 171 Source <- ((('<' < SourceContents > '>') / (< SourceContents >)) { yy = mk_str(yytext) }) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			{
-				position926, thunkPosition926 := position, thunkPosition
-				if !matchChar('<') {
-					goto l927
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("frameset") {
+					goto l923
 				}
-				begin = position
-				if !p.rules[ruleSourceContents]() {
-					goto l927
+				goto l896
+			l923:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("li") {
+					goto l924
 				}
-				end = position
-				if !matchChar('>') {
+				goto l896
+			l924:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("tbody") {
+					goto l925
+				}
+				goto l896
+			l925:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("td") {
+					goto l926
+				}
+				goto l896
+			l926:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("tfoot") {
 					goto l927
 				}
-				goto l926
+				goto l896
 			l927:
-				position, thunkPosition = position926, thunkPosition926
-				begin = position
-				if !p.rules[ruleSourceContents]() {
-					goto l925
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("th") {
+					goto l928
 				}
-				end = position
-			}
-		l926:
-			do(74)
-			return true
-		l925:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 172 SourceContents <- (((!'(' !')' !'>' Nonspacechar)+ / ('(' SourceContents ')'))* / '') */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			{
-				position929, thunkPosition929 := position, thunkPosition
+				goto l896
+			l928:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("thead") {
+					goto l929
+				}
+				goto l896
+			l929:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("tr") {
+					goto l930
+				}
+				goto l896
+			l930:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("script") {
+					goto l931
+				}
+				goto l896
 			l931:
-				{
-					position932, thunkPosition932 := position, thunkPosition
-					{
-						position933, thunkPosition933 := position, thunkPosition
-						if peekChar('(') {
-							goto l934
-						}
-						if peekChar(')') {
-							goto l934
-						}
-						if peekChar('>') {
-							goto l934
-						}
-						if !p.rules[ruleNonspacechar]() {
-							goto l934
-						}
-					l935:
-						{
-							position936, thunkPosition936 := position, thunkPosition
-							if peekChar('(') {
-								goto l936
-							}
-							if peekChar(')') {
-								goto l936
-							}
-							if peekChar('>') {
-								goto l936
-							}
-							if !p.rules[ruleNonspacechar]() {
-								goto l936
-							}
-							goto l935
-						l936:
-							position, thunkPosition = position936, thunkPosition936
-						}
-						goto l933
-					l934:
-						position, thunkPosition = position933, thunkPosition933
-						if !matchChar('(') {
-							goto l932
-						}
-						if !p.rules[ruleSourceContents]() {
-							goto l932
-						}
-						if !matchChar(')') {
-							goto l932
-						}
-					}
-				l933:
-					goto l931
-				l932:
-					position, thunkPosition = position932, thunkPosition932
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("ADDRESS") {
+					goto l932
 				}
-				goto l929
-			l930:
-				position, thunkPosition = position929, thunkPosition929
-				if !matchString("") {
-					goto l928
+				goto l896
+			l932:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("BLOCKQUOTE") {
+					goto l933
+				}
+				goto l896
+			l933:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("CENTER") {
+					goto l934
+				}
+				goto l896
+			l934:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("DIR") {
+					goto l935
+				}
+				goto l896
+			l935:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("DIV") {
+					goto l936
+				}
+				goto l896
+			l936:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("DL") {
+					goto l937
+				}
+				goto l896
+			l937:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("FIELDSET") {
+					goto l938
+				}
+				goto l896
+			l938:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("FORM") {
+					goto l939
+				}
+				goto l896
+			l939:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("H1") {
+					goto l940
+				}
+				goto l896
+			l940:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("H2") {
+					goto l941
+				}
+				goto l896
+			l941:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("H3") {
+					goto l942
+				}
+				goto l896
+			l942:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("H4") {
+					goto l943
+				}
+				goto l896
+			l943:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("H5") {
+					goto l944
+				}
+				goto l896
+			l944:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("H6") {
+					goto l945
+				}
+				goto l896
+			l945:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("HR") {
+					goto l946
+				}
+				goto l896
+			l946:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("ISINDEX") {
+					goto l947
+				}
+				goto l896
+			l947:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("MENU") {
+					goto l948
+				}
+				goto l896
+			l948:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("NOFRAMES") {
+					goto l949
+				}
+				goto l896
+			l949:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("NOSCRIPT") {
+					goto l950
+				}
+				goto l896
+			l950:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("OL") {
+					goto l951
+				}
+				goto l896
+			l951:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchChar('P') {
+					goto l952
+				}
+				goto l896
+			l952:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("PRE") {
+					goto l953
+				}
+				goto l896
+			l953:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("TABLE") {
+					goto l954
+				}
+				goto l896
+			l954:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("UL") {
+					goto l955
+				}
+				goto l896
+			l955:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("DD") {
+					goto l956
+				}
+				goto l896
+			l956:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("DT") {
+					goto l957
+				}
+				goto l896
+			l957:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("FRAMESET") {
+					goto l958
+				}
+				goto l896
+			l958:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("LI") {
+					goto l959
+				}
+				goto l896
+			l959:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("TBODY") {
+					goto l960
+				}
+				goto l896
+			l960:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("TD") {
+					goto l961
+				}
+				goto l896
+			l961:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("TFOOT") {
+					goto l962
+				}
+				goto l896
+			l962:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("TH") {
+					goto l963
+				}
+				goto l896
+			l963:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("THEAD") {
+					goto l964
+				}
+				goto l896
+			l964:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("TR") {
+					goto l965
+				}
+				goto l896
+			l965:
+				position, thunkPosition = position896, thunkPosition896
+				if !matchString("SCRIPT") {
+					goto l895
 				}
 			}
-		l929:
+		l896:
 			return true
-		l928:
+		l895:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 173 Title <- ((TitleSingle / TitleDouble / (< '' >)) { yy = mk_str(yytext) }) */
+		/* 178 StyleOpen <- ('<' Spnl ('style' / 'STYLE') Spnl HtmlAttribute* '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('<') {
+				goto l966
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l966
+			}
 			{
-				position938, thunkPosition938 := position, thunkPosition
-				if !p.rules[ruleTitleSingle]() {
-					goto l939
+				position967, thunkPosition967 := position, thunkPosition
+				if !matchString("style") {
+					goto l968
 				}
-				goto l938
-			l939:
-				position, thunkPosition = position938, thunkPosition938
-				if !p.rules[ruleTitleDouble]() {
-					goto l940
+				goto l967
+			l968:
+				position, thunkPosition = position967, thunkPosition967
+				if !matchString("STYLE") {
+					goto l966
 				}
-				goto l938
-			l940:
-				position, thunkPosition = position938, thunkPosition938
-				begin = position
-				if !matchString("") {
-					goto l937
+			}
+		l967:
+			if !p.rules[ruleSpnl]() {
+				goto l966
+			}
+		l969:
+			{
+				position970, thunkPosition970 := position, thunkPosition
+				if !p.rules[ruleHtmlAttribute]() {
+					goto l970
 				}
-				end = position
+				goto l969
+			l970:
+				position, thunkPosition = position970, thunkPosition970
+			}
+			if !matchChar('>') {
+				goto l966
 			}
-		l938:
-			do(75)
 			return true
-		l937:
+		l966:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 174 TitleSingle <- ('\'' < (!('\'' Sp (')' / Newline)) .)* > '\'') */
+		/* 179 StyleClose <- ('<' Spnl '/' ('style' / 'STYLE') Spnl '>') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('\'') {
-				goto l941
+			if !matchChar('<') {
+				goto l971
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l971
+			}
+			if !matchChar('/') {
+				goto l971
 			}
-			begin = position
-		l942:
 			{
-				position943, thunkPosition943 := position, thunkPosition
-				{
-					position944, thunkPosition944 := position, thunkPosition
-					if !matchChar('\'') {
-						goto l944
-					}
-					if !p.rules[ruleSp]() {
-						goto l944
-					}
-					{
-						position945, thunkPosition945 := position, thunkPosition
-						if !matchChar(')') {
-							goto l946
-						}
-						goto l945
-					l946:
-						position, thunkPosition = position945, thunkPosition945
-						if !p.rules[ruleNewline]() {
-							goto l944
-						}
-					}
-				l945:
-					goto l943
-				l944:
-					position, thunkPosition = position944, thunkPosition944
+				position972, thunkPosition972 := position, thunkPosition
+				if !matchString("style") {
+					goto l973
 				}
-				if !matchDot() {
-					goto l943
+				goto l972
+			l973:
+				position, thunkPosition = position972, thunkPosition972
+				if !matchString("STYLE") {
+					goto l971
 				}
-				goto l942
-			l943:
-				position, thunkPosition = position943, thunkPosition943
 			}
-			end = position
-			if !matchChar('\'') {
-				goto l941
+		l972:
+			if !p.rules[ruleSpnl]() {
+				goto l971
+			}
+			if !matchChar('>') {
+				goto l971
 			}
 			return true
-		l941:
+		l971:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 175 TitleDouble <- ('"' < (!('"' Sp (')' / Newline)) .)* > '"') */
+		/* 180 InStyleTags <- (StyleOpen (!StyleClose .)* StyleClose) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('"') {
-				goto l947
+			if !p.rules[ruleStyleOpen]() {
+				goto l974
 			}
-			begin = position
-		l948:
+		l975:
 			{
-				position949, thunkPosition949 := position, thunkPosition
+				position976, thunkPosition976 := position, thunkPosition
 				{
-					position950, thunkPosition950 := position, thunkPosition
-					if !matchChar('"') {
-						goto l950
-					}
-					if !p.rules[ruleSp]() {
-						goto l950
-					}
-					{
-						position951, thunkPosition951 := position, thunkPosition
-						if !matchChar(')') {
-							goto l952
-						}
-						goto l951
-					l952:
-						position, thunkPosition = position951, thunkPosition951
-						if !p.rules[ruleNewline]() {
-							goto l950
-						}
+					position977, thunkPosition977 := position, thunkPosition
+					if !p.rules[ruleStyleClose]() {
+						goto l977
 					}
-				l951:
-					goto l949
-				l950:
-					position, thunkPosition = position950, thunkPosition950
+					goto l976
+				l977:
+					position, thunkPosition = position977, thunkPosition977
 				}
 				if !matchDot() {
-					goto l949
+					goto l976
 				}
-				goto l948
-			l949:
-				position, thunkPosition = position949, thunkPosition949
+				goto l975
+			l976:
+				position, thunkPosition = position976, thunkPosition976
 			}
-			end = position
-			if !matchChar('"') {
-				goto l947
+			if !p.rules[ruleStyleClose]() {
+				goto l974
 			}
 			return true
-		l947:
+		l974:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 176 AutoLink <- (AutoLinkUrl / AutoLinkEmail) */
+		/* 181 StyleBlock <- (< InStyleTags > BlankLine* { yy = p.styleBlock(yytext) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
+			begin = position
+			if !p.rules[ruleInStyleTags]() {
+				goto l978
+			}
+			end = position
+		l979:
 			{
-				position954, thunkPosition954 := position, thunkPosition
-				if !p.rules[ruleAutoLinkUrl]() {
-					goto l955
-				}
-				goto l954
-			l955:
-				position, thunkPosition = position954, thunkPosition954
-				if !p.rules[ruleAutoLinkEmail]() {
-					goto l953
+				position980, thunkPosition980 := position, thunkPosition
+				if !p.rules[ruleBlankLine]() {
+					goto l980
 				}
+				goto l979
+			l980:
+				position, thunkPosition = position980, thunkPosition980
 			}
-		l954:
+			do(84)
 			return true
-		l953:
+		l978:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 177 AutoLinkUrl <- ('<' < [A-Za-z]+ '://' (!Newline !'>' .)+ > '>' {   yy = mk_link(mk_str(yytext), yytext, "") }) */
+		/* 182 Inlines <- (StartList ((!Endline Inline { a = cons(yy, a) }) / (Endline &Inline { a = cons(c, a) }))+ Endline? { yy = mk_list(LIST, a) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l956
-			}
-			begin = position
-			if !matchClass(4) {
-				goto l956
+			doarg(yyPush, 2)
+			if !p.rules[ruleStartList]() {
+				goto l981
 			}
-		l957:
+			doarg(yySet, -2)
 			{
-				position958, thunkPosition958 := position, thunkPosition
-				if !matchClass(4) {
-					goto l958
+				position984, thunkPosition984 := position, thunkPosition
+				{
+					position986, thunkPosition986 := position, thunkPosition
+					if !p.rules[ruleEndline]() {
+						goto l986
+					}
+					goto l985
+				l986:
+					position, thunkPosition = position986, thunkPosition986
 				}
-				goto l957
-			l958:
-				position, thunkPosition = position958, thunkPosition958
-			}
-			if !matchString("://") {
-				goto l956
-			}
-			{
-				position961, thunkPosition961 := position, thunkPosition
-				if !p.rules[ruleNewline]() {
-					goto l961
+				if !p.rules[ruleInline]() {
+					goto l985
 				}
-				goto l956
-			l961:
-				position, thunkPosition = position961, thunkPosition961
-			}
-			if peekChar('>') {
-				goto l956
-			}
-			if !matchDot() {
-				goto l956
+				do(85)
+				goto l984
+			l985:
+				position, thunkPosition = position984, thunkPosition984
+				if !p.rules[ruleEndline]() {
+					goto l981
+				}
+				doarg(yySet, -1)
+				{
+					position987, thunkPosition987 := position, thunkPosition
+					if !p.rules[ruleInline]() {
+						goto l981
+					}
+					position, thunkPosition = position987, thunkPosition987
+				}
+				do(86)
 			}
-		l959:
+		l984:
+		l982:
 			{
-				position960, thunkPosition960 := position, thunkPosition
+				position983, thunkPosition983 := position, thunkPosition
 				{
-					position962, thunkPosition962 := position, thunkPosition
-					if !p.rules[ruleNewline]() {
-						goto l962
+					position988, thunkPosition988 := position, thunkPosition
+					{
+						position990, thunkPosition990 := position, thunkPosition
+						if !p.rules[ruleEndline]() {
+							goto l990
+						}
+						goto l989
+					l990:
+						position, thunkPosition = position990, thunkPosition990
 					}
-					goto l960
-				l962:
-					position, thunkPosition = position962, thunkPosition962
-				}
-				if peekChar('>') {
-					goto l960
-				}
-				if !matchDot() {
-					goto l960
+					if !p.rules[ruleInline]() {
+						goto l989
+					}
+					do(85)
+					goto l988
+				l989:
+					position, thunkPosition = position988, thunkPosition988
+					if !p.rules[ruleEndline]() {
+						goto l983
+					}
+					doarg(yySet, -1)
+					{
+						position991, thunkPosition991 := position, thunkPosition
+						if !p.rules[ruleInline]() {
+							goto l983
+						}
+						position, thunkPosition = position991, thunkPosition991
+					}
+					do(86)
 				}
-				goto l959
-			l960:
-				position, thunkPosition = position960, thunkPosition960
+			l988:
+				goto l982
+			l983:
+				position, thunkPosition = position983, thunkPosition983
 			}
-			end = position
-			if !matchChar('>') {
-				goto l956
+			{
+				position992, thunkPosition992 := position, thunkPosition
+				if !p.rules[ruleEndline]() {
+					goto l992
+				}
+				goto l993
+			l992:
+				position, thunkPosition = position992, thunkPosition992
 			}
-			do(76)
+		l993:
+			do(87)
+			doarg(yyPop, 2)
 			return true
-		l956:
+		l981:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 178 AutoLinkEmail <- ('<' < [-A-Za-z0-9+_]+ '@' (!Newline !'>' .)+ > '>' {
-                    yy = mk_link(mk_str(yytext), "mailto:"+yytext, "")
-                }) */
+		/* 183 Inline <- ((&{ p.enter() } (PluginInline / ExtInline / Template / BareLink / Str / Endline / UlOrStarLine / Space / Strong / Emph / Strike / Superscript / Subscript / WikiLink / Citation / Image / Link / NoteReference / InlineNote / Code / Math / RawHtml / Entity / EscapedChar / Smart / Emoji / LatePluginInline / Symbol) &{ p.leave(true) }) / &{ p.leave(false) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l963
-			}
-			begin = position
-			if !matchClass(9) {
-				goto l963
-			}
-		l964:
-			{
-				position965, thunkPosition965 := position, thunkPosition
-				if !matchClass(9) {
-					goto l965
-				}
-				goto l964
-			l965:
-				position, thunkPosition = position965, thunkPosition965
-			}
-			if !matchChar('@') {
-				goto l963
-			}
 			{
-				position968, thunkPosition968 := position, thunkPosition
-				if !p.rules[ruleNewline]() {
-					goto l968
+				position995, thunkPosition995 := position, thunkPosition
+				if !( p.enter() ) {
+					goto l996
 				}
-				goto l963
-			l968:
-				position, thunkPosition = position968, thunkPosition968
-			}
-			if peekChar('>') {
-				goto l963
-			}
-			if !matchDot() {
-				goto l963
-			}
-		l966:
-			{
-				position967, thunkPosition967 := position, thunkPosition
 				{
-					position969, thunkPosition969 := position, thunkPosition
-					if !p.rules[ruleNewline]() {
-						goto l969
+					position997, thunkPosition997 := position, thunkPosition
+					if !p.rules[rulePluginInline]() {
+						goto l998
+					}
+					goto l997
+				l998:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleExtInline]() {
+						goto l999
+					}
+					goto l997
+				l999:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleTemplate]() {
+						goto l1000
+					}
+					goto l997
+				l1000:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleBareLink]() {
+						goto l1001
+					}
+					goto l997
+				l1001:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleStr]() {
+						goto l1002
+					}
+					goto l997
+				l1002:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleEndline]() {
+						goto l1003
+					}
+					goto l997
+				l1003:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleUlOrStarLine]() {
+						goto l1004
+					}
+					goto l997
+				l1004:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleSpace]() {
+						goto l1005
+					}
+					goto l997
+				l1005:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleStrong]() {
+						goto l1006
+					}
+					goto l997
+				l1006:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleEmph]() {
+						goto l1007
+					}
+					goto l997
+				l1007:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleStrike]() {
+						goto l1008
+					}
+					goto l997
+				l1008:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleSuperscript]() {
+						goto l1009
+					}
+					goto l997
+				l1009:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleSubscript]() {
+						goto l1010
+					}
+					goto l997
+				l1010:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleWikiLink]() {
+						goto l1011
+					}
+					goto l997
+				l1011:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleCitation]() {
+						goto l1012
+					}
+					goto l997
+				l1012:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleImage]() {
+						goto l1013
+					}
+					goto l997
+				l1013:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleLink]() {
+						goto l1014
+					}
+					goto l997
+				l1014:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleNoteReference]() {
+						goto l1015
+					}
+					goto l997
+				l1015:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleInlineNote]() {
+						goto l1016
+					}
+					goto l997
+				l1016:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleCode]() {
+						goto l1017
+					}
+					goto l997
+				l1017:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleMath]() {
+						goto l1018
+					}
+					goto l997
+				l1018:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleRawHtml]() {
+						goto l1019
+					}
+					goto l997
+				l1019:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleEntity]() {
+						goto l1020
+					}
+					goto l997
+				l1020:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleEscapedChar]() {
+						goto l1021
+					}
+					goto l997
+				l1021:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleSmart]() {
+						goto l1022
+					}
+					goto l997
+				l1022:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleEmoji]() {
+						goto l1023
+					}
+					goto l997
+				l1023:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleLatePluginInline]() {
+						goto l1024
+					}
+					goto l997
+				l1024:
+					position, thunkPosition = position997, thunkPosition997
+					if !p.rules[ruleSymbol]() {
+						goto l996
 					}
-					goto l967
-				l969:
-					position, thunkPosition = position969, thunkPosition969
 				}
-				if peekChar('>') {
-					goto l967
+			l997:
+				if !( p.leave(true) ) {
+					goto l996
 				}
-				if !matchDot() {
-					goto l967
+				goto l995
+			l996:
+				position, thunkPosition = position995, thunkPosition995
+				if !( p.leave(false) ) {
+					goto l994
 				}
-				goto l966
-			l967:
-				position, thunkPosition = position967, thunkPosition967
-			}
-			end = position
-			if !matchChar('>') {
-				goto l963
 			}
-			do(77)
+		l995:
 			return true
-		l963:
+		l994:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 179 Reference <- (NonindentSpace !'[]' Label ':' Spnl RefSrc Spnl RefTitle BlankLine* { yy = mk_link(l.children, s.contents.str, t.contents.str)
-              s = nil
-              t = nil
-              l = nil
-              yy.key = REFERENCE }) */
+		/* 184 Space <- (Spacechar+ { yy = mk_str(" ")
+          yy.key = SPACE }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 3)
-			if !p.rules[ruleNonindentSpace]() {
-				goto l970
+			if !p.rules[ruleSpacechar]() {
+				goto l1025
 			}
+		l1026:
 			{
-				position971, thunkPosition971 := position, thunkPosition
-				if !matchString("[]") {
-					goto l971
+				position1027, thunkPosition1027 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l1027
 				}
-				goto l970
-			l971:
-				position, thunkPosition = position971, thunkPosition971
-			}
-			if !p.rules[ruleLabel]() {
-				goto l970
-			}
-			doarg(yySet, -2)
-			if !matchChar(':') {
-				goto l970
+				goto l1026
+			l1027:
+				position, thunkPosition = position1027, thunkPosition1027
 			}
-			if !p.rules[ruleSpnl]() {
-				goto l970
-			}
-			if !p.rules[ruleRefSrc]() {
-				goto l970
-			}
-			doarg(yySet, -1)
-			if !p.rules[ruleSpnl]() {
-				goto l970
-			}
-			if !p.rules[ruleRefTitle]() {
-				goto l970
+			do(88)
+			return true
+		l1025:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 185 Str <- (< NormalChar (NormalChar / (!AfterPunct '_'+ &Alphanumeric))* > { yy = mk_str(yytext) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			begin = position
+			if !p.rules[ruleNormalChar]() {
+				goto l1028
 			}
-			doarg(yySet, -3)
-		l972:
+		l1029:
 			{
-				position973, thunkPosition973 := position, thunkPosition
-				if !p.rules[ruleBlankLine]() {
-					goto l973
+				position1030, thunkPosition1030 := position, thunkPosition
+				{
+					position1031, thunkPosition1031 := position, thunkPosition
+					if !p.rules[ruleNormalChar]() {
+						goto l1032
+					}
+					goto l1031
+				l1032:
+					position, thunkPosition = position1031, thunkPosition1031
+					{
+						position1033, thunkPosition1033 := position, thunkPosition
+						if !p.rules[ruleAfterPunct]() {
+							goto l1033
+						}
+						goto l1030
+					l1033:
+						position, thunkPosition = position1033, thunkPosition1033
+					}
+					if !matchChar('_') {
+						goto l1030
+					}
+				l1034:
+					{
+						position1035, thunkPosition1035 := position, thunkPosition
+						if !matchChar('_') {
+							goto l1035
+						}
+						goto l1034
+					l1035:
+						position, thunkPosition = position1035, thunkPosition1035
+					}
+					{
+						position1036, thunkPosition1036 := position, thunkPosition
+						if !p.rules[ruleAlphanumeric]() {
+							goto l1030
+						}
+						position, thunkPosition = position1036, thunkPosition1036
+					}
 				}
-				goto l972
-			l973:
-				position, thunkPosition = position973, thunkPosition973
+			l1031:
+				goto l1029
+			l1030:
+				position, thunkPosition = position1030, thunkPosition1030
 			}
-			do(78)
-			doarg(yyPop, 3)
+			end = position
+			do(89)
 			return true
-		l970:
+		l1028:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 180 Label <- ('[' ((!'^' &{ p.extension.Notes }) / (&. &{ !p.extension.Notes })) StartList (!']' Inline { a = cons(yy, a) })* ']' { yy = mk_list(LIST, a) }) */
+		/* 186 EscapedChar <- ('\\' !Newline < ([-\\`|*_{}[\]()#+.!><] / (&{ p.extension.Math } '$') / (&{ p.extension.SupSub } [~^])) > { yy = mk_str(yytext) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 1)
-			if !matchChar('[') {
-				goto l974
+			if !matchChar('\\') {
+				goto l1037
 			}
 			{
-				position975, thunkPosition975 := position, thunkPosition
-				if peekChar('^') {
-					goto l976
+				position1038, thunkPosition1038 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l1038
 				}
-				if !( p.extension.Notes ) {
-					goto l976
+				goto l1037
+			l1038:
+				position, thunkPosition = position1038, thunkPosition1038
+			}
+			begin = position
+			{
+				position1039, thunkPosition1039 := position, thunkPosition
+				if !matchClass(2) {
+					goto l1040
 				}
-				goto l975
-			l976:
-				position, thunkPosition = position975, thunkPosition975
-				if !peekDot() {
-					goto l974
+				goto l1039
+			l1040:
+				position, thunkPosition = position1039, thunkPosition1039
+				if !( p.extension.Math ) {
+					goto l1041
 				}
-				if !( !p.extension.Notes ) {
-					goto l974
+				if !matchChar('$') {
+					goto l1041
 				}
-			}
-		l975:
-			if !p.rules[ruleStartList]() {
-				goto l974
-			}
-			doarg(yySet, -1)
-		l977:
-			{
-				position978, thunkPosition978 := position, thunkPosition
-				if peekChar(']') {
-					goto l978
+				goto l1039
+			l1041:
+				position, thunkPosition = position1039, thunkPosition1039
+				if !( p.extension.SupSub ) {
+					goto l1037
 				}
-				if !p.rules[ruleInline]() {
-					goto l978
+				if !matchClass(12) {
+					goto l1037
 				}
-				do(79)
-				goto l977
-			l978:
-				position, thunkPosition = position978, thunkPosition978
-			}
-			if !matchChar(']') {
-				goto l974
 			}
-			do(80)
-			doarg(yyPop, 1)
+		l1039:
+			end = position
+			do(90)
 			return true
-		l974:
+		l1037:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 181 RefSrc <- (< Nonspacechar+ > { yy = mk_str(yytext)
-           yy.key = HTML }) */
+		/* 187 Entity <- ((HexEntity / DecEntity / CharEntity) { yy = mk_str(yytext); yy.key = HTML }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			begin = position
-			if !p.rules[ruleNonspacechar]() {
-				goto l979
-			}
-		l980:
 			{
-				position981, thunkPosition981 := position, thunkPosition
-				if !p.rules[ruleNonspacechar]() {
-					goto l981
+				position1043, thunkPosition1043 := position, thunkPosition
+				if !p.rules[ruleHexEntity]() {
+					goto l1044
+				}
+				goto l1043
+			l1044:
+				position, thunkPosition = position1043, thunkPosition1043
+				if !p.rules[ruleDecEntity]() {
+					goto l1045
+				}
+				goto l1043
+			l1045:
+				position, thunkPosition = position1043, thunkPosition1043
+				if !p.rules[ruleCharEntity]() {
+					goto l1042
 				}
-				goto l980
-			l981:
-				position, thunkPosition = position981, thunkPosition981
 			}
-			end = position
-			do(81)
+		l1043:
+			do(91)
 			return true
-		l979:
+		l1042:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 182 RefTitle <- ((RefTitleSingle / RefTitleDouble / RefTitleParens / EmptyTitle) { yy = mk_str(yytext) }) */
+		/* 188 Endline <- (LineBreak / TerminalEndline / NormalEndline) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			{
-				position983, thunkPosition983 := position, thunkPosition
-				if !p.rules[ruleRefTitleSingle]() {
-					goto l984
-				}
-				goto l983
-			l984:
-				position, thunkPosition = position983, thunkPosition983
-				if !p.rules[ruleRefTitleDouble]() {
-					goto l985
+				position1047, thunkPosition1047 := position, thunkPosition
+				if !p.rules[ruleLineBreak]() {
+					goto l1048
 				}
-				goto l983
-			l985:
-				position, thunkPosition = position983, thunkPosition983
-				if !p.rules[ruleRefTitleParens]() {
-					goto l986
+				goto l1047
+			l1048:
+				position, thunkPosition = position1047, thunkPosition1047
+				if !p.rules[ruleTerminalEndline]() {
+					goto l1049
 				}
-				goto l983
-			l986:
-				position, thunkPosition = position983, thunkPosition983
-				if !p.rules[ruleEmptyTitle]() {
-					goto l982
+				goto l1047
+			l1049:
+				position, thunkPosition = position1047, thunkPosition1047
+				if !p.rules[ruleNormalEndline]() {
+					goto l1046
 				}
 			}
-		l983:
-			do(82)
+		l1047:
 			return true
-		l982:
+		l1046:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 183 EmptyTitle <- (< '' >) */
+		/* 189 NormalEndline <- (Sp Newline !BlankLine !'>' !AtxStart !FenceStart !DivFence !(Line (('===' '='*) / ('---' '-'*)) Newline) { yy = mk_str("\n")
+                    yy.key = SPACE }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			begin = position
-			if !matchString("") {
-				goto l987
+			if !p.rules[ruleSp]() {
+				goto l1050
 			}
-			end = position
-			return true
-		l987:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 184 RefTitleSingle <- ('\'' < (!(('\'' Sp Newline) / Newline) .)* > '\'') */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('\'') {
-				goto l988
+			if !p.rules[ruleNewline]() {
+				goto l1050
+			}
+			{
+				position1051, thunkPosition1051 := position, thunkPosition
+				if !p.rules[ruleBlankLine]() {
+					goto l1051
+				}
+				goto l1050
+			l1051:
+				position, thunkPosition = position1051, thunkPosition1051
+			}
+			if peekChar('>') {
+				goto l1050
+			}
+			{
+				position1052, thunkPosition1052 := position, thunkPosition
+				if !p.rules[ruleAtxStart]() {
+					goto l1052
+				}
+				goto l1050
+			l1052:
+				position, thunkPosition = position1052, thunkPosition1052
 			}
-			begin = position
-		l989:
 			{
-				position990, thunkPosition990 := position, thunkPosition
+				position1053, thunkPosition1053 := position, thunkPosition
+				if !p.rules[ruleFenceStart]() {
+					goto l1053
+				}
+				goto l1050
+			l1053:
+				position, thunkPosition = position1053, thunkPosition1053
+			}
+			{
+				position1054, thunkPosition1054 := position, thunkPosition
+				if !p.rules[ruleDivFence]() {
+					goto l1054
+				}
+				goto l1050
+			l1054:
+				position, thunkPosition = position1054, thunkPosition1054
+			}
+			{
+				position1055, thunkPosition1055 := position, thunkPosition
+				if !p.rules[ruleLine]() {
+					goto l1055
+				}
 				{
-					position991, thunkPosition991 := position, thunkPosition
+					position1056, thunkPosition1056 := position, thunkPosition
+					if !matchString("===") {
+						goto l1057
+					}
+				l1058:
 					{
-						position992, thunkPosition992 := position, thunkPosition
-						if !matchChar('\'') {
-							goto l993
-						}
-						if !p.rules[ruleSp]() {
-							goto l993
-						}
-						if !p.rules[ruleNewline]() {
-							goto l993
+						position1059, thunkPosition1059 := position, thunkPosition
+						if !matchChar('=') {
+							goto l1059
 						}
-						goto l992
-					l993:
-						position, thunkPosition = position992, thunkPosition992
-						if !p.rules[ruleNewline]() {
-							goto l991
+						goto l1058
+					l1059:
+						position, thunkPosition = position1059, thunkPosition1059
+					}
+					goto l1056
+				l1057:
+					position, thunkPosition = position1056, thunkPosition1056
+					if !matchString("---") {
+						goto l1055
+					}
+				l1060:
+					{
+						position1061, thunkPosition1061 := position, thunkPosition
+						if !matchChar('-') {
+							goto l1061
 						}
+						goto l1060
+					l1061:
+						position, thunkPosition = position1061, thunkPosition1061
 					}
-				l992:
-					goto l990
-				l991:
-					position, thunkPosition = position991, thunkPosition991
 				}
-				if !matchDot() {
-					goto l990
+			l1056:
+				if !p.rules[ruleNewline]() {
+					goto l1055
 				}
-				goto l989
-			l990:
-				position, thunkPosition = position990, thunkPosition990
-			}
-			end = position
-			if !matchChar('\'') {
-				goto l988
+				goto l1050
+			l1055:
+				position, thunkPosition = position1055, thunkPosition1055
 			}
+			do(92)
 			return true
-		l988:
+		l1050:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 185 RefTitleDouble <- ('"' < (!(('"' Sp Newline) / Newline) .)* > '"') */
+		/* 190 TerminalEndline <- (Sp Newline Eof { yy = nil }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('"') {
-				goto l994
+			if !p.rules[ruleSp]() {
+				goto l1062
 			}
-			begin = position
-		l995:
+			if !p.rules[ruleNewline]() {
+				goto l1062
+			}
+			if !p.rules[ruleEof]() {
+				goto l1062
+			}
+			do(93)
+			return true
+		l1062:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 191 LineBreak <- (('  ' / (&{ p.extension.GFM || p.extension.CommonMark } '\\') / &{ p.extension.HardWraps }) NormalEndline { yy = mk_element(LINEBREAK) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
 			{
-				position996, thunkPosition996 := position, thunkPosition
-				{
-					position997, thunkPosition997 := position, thunkPosition
-					{
-						position998, thunkPosition998 := position, thunkPosition
-						if !matchChar('"') {
-							goto l999
-						}
-						if !p.rules[ruleSp]() {
-							goto l999
-						}
-						if !p.rules[ruleNewline]() {
-							goto l999
-						}
-						goto l998
-					l999:
-						position, thunkPosition = position998, thunkPosition998
-						if !p.rules[ruleNewline]() {
-							goto l997
-						}
-					}
-				l998:
-					goto l996
-				l997:
-					position, thunkPosition = position997, thunkPosition997
+				position1064, thunkPosition1064 := position, thunkPosition
+				if !matchString("  ") {
+					goto l1065
 				}
-				if !matchDot() {
-					goto l996
+				goto l1064
+			l1065:
+				position, thunkPosition = position1064, thunkPosition1064
+				if !( p.extension.GFM || p.extension.CommonMark ) {
+					goto l1066
+				}
+				if !matchChar('\\') {
+					goto l1066
+				}
+				goto l1064
+			l1066:
+				position, thunkPosition = position1064, thunkPosition1064
+				if !( p.extension.HardWraps ) {
+					goto l1063
 				}
-				goto l995
-			l996:
-				position, thunkPosition = position996, thunkPosition996
 			}
-			end = position
-			if !matchChar('"') {
-				goto l994
+		l1064:
+			if !p.rules[ruleNormalEndline]() {
+				goto l1063
 			}
+			do(94)
 			return true
-		l994:
+		l1063:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 186 RefTitleParens <- ('(' < (!((')' Sp Newline) / Newline) .)* > ')') */
+		/* 192 Emoji <- (&{ p.extension.Emoji } ':' < [-+_a-z0-9]+ > ':' &{ p.emojiText(p.Buffer[begin:end]) != "" } { yy = mk_str(yytext); yy.key = EMOJI }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('(') {
-				goto l1000
+			if !( p.extension.Emoji ) {
+				goto l1067
+			}
+			if !matchChar(':') {
+				goto l1067
 			}
 			begin = position
-		l1001:
+			if !matchClass(13) {
+				goto l1067
+			}
+		l1068:
 			{
-				position1002, thunkPosition1002 := position, thunkPosition
-				{
-					position1003, thunkPosition1003 := position, thunkPosition
-					{
-						position1004, thunkPosition1004 := position, thunkPosition
-						if !matchChar(')') {
-							goto l1005
-						}
-						if !p.rules[ruleSp]() {
-							goto l1005
-						}
-						if !p.rules[ruleNewline]() {
-							goto l1005
-						}
-						goto l1004
-					l1005:
-						position, thunkPosition = position1004, thunkPosition1004
-						if !p.rules[ruleNewline]() {
-							goto l1003
-						}
-					}
-				l1004:
-					goto l1002
-				l1003:
-					position, thunkPosition = position1003, thunkPosition1003
+				position1069, thunkPosition1069 := position, thunkPosition
+				if !matchClass(13) {
+					goto l1069
 				}
-				if !matchDot() {
-					goto l1002
-				}
-				goto l1001
-			l1002:
-				position, thunkPosition = position1002, thunkPosition1002
+				goto l1068
+			l1069:
+				position, thunkPosition = position1069, thunkPosition1069
 			}
 			end = position
-			if !matchChar(')') {
-				goto l1000
+			if !matchChar(':') {
+				goto l1067
 			}
+			if !( p.emojiText(p.Buffer[begin:end]) != "" ) {
+				goto l1067
+			}
+			do(95)
 			return true
-		l1000:
+		l1067:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 187 References <- (StartList ((Reference { a = cons(b, a) }) / SkipBlock)* { p.references = reverse(a) } commit) */
+		/* 193 Symbol <- (< SpecialChar > { yy = mk_str(yytext) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			doarg(yyPush, 2)
-			if !p.rules[ruleStartList]() {
-				goto l1006
+			begin = position
+			if !p.rules[ruleSpecialChar]() {
+				goto l1070
 			}
-			doarg(yySet, -1)
-		l1007:
+			end = position
+			do(96)
+			return true
+		l1070:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 194 UlOrStarLine <- ((UlLine / StarLine) { yy = mk_str(yytext) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
 			{
-				position1008, thunkPosition1008 := position, thunkPosition
-				{
-					position1009, thunkPosition1009 := position, thunkPosition
-					if !p.rules[ruleReference]() {
-						goto l1010
-					}
-					doarg(yySet, -2)
-					do(83)
-					goto l1009
-				l1010:
-					position, thunkPosition = position1009, thunkPosition1009
-					if !p.rules[ruleSkipBlock]() {
-						goto l1008
-					}
+				position1072, thunkPosition1072 := position, thunkPosition
+				if !p.rules[ruleUlLine]() {
+					goto l1073
+				}
+				goto l1072
+			l1073:
+				position, thunkPosition = position1072, thunkPosition1072
+				if !p.rules[ruleStarLine]() {
+					goto l1071
 				}
-			l1009:
-				goto l1007
-			l1008:
-				position, thunkPosition = position1008, thunkPosition1008
-			}
-			do(84)
-			if !(commit(thunkPosition0)) {
-				goto l1006
 			}
-			doarg(yyPop, 2)
+		l1072:
+			do(97)
 			return true
-		l1006:
+		l1071:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 188 Ticks1 <- ('`' !'`') */
+		/* 195 StarLine <- ((< '****' '*'* >) / (< Spacechar '*'+ &Spacechar >)) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('`') {
-				goto l1011
-			}
-			if peekChar('`') {
-				goto l1011
+			{
+				position1075, thunkPosition1075 := position, thunkPosition
+				begin = position
+				if !matchString("****") {
+					goto l1076
+				}
+			l1077:
+				{
+					position1078, thunkPosition1078 := position, thunkPosition
+					if !matchChar('*') {
+						goto l1078
+					}
+					goto l1077
+				l1078:
+					position, thunkPosition = position1078, thunkPosition1078
+				}
+				end = position
+				goto l1075
+			l1076:
+				position, thunkPosition = position1075, thunkPosition1075
+				begin = position
+				if !p.rules[ruleSpacechar]() {
+					goto l1074
+				}
+				if !matchChar('*') {
+					goto l1074
+				}
+			l1079:
+				{
+					position1080, thunkPosition1080 := position, thunkPosition
+					if !matchChar('*') {
+						goto l1080
+					}
+					goto l1079
+				l1080:
+					position, thunkPosition = position1080, thunkPosition1080
+				}
+				{
+					position1081, thunkPosition1081 := position, thunkPosition
+					if !p.rules[ruleSpacechar]() {
+						goto l1074
+					}
+					position, thunkPosition = position1081, thunkPosition1081
+				}
+				end = position
 			}
+		l1075:
 			return true
-		l1011:
+		l1074:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 189 Ticks2 <- ('``' !'`') */
+		/* 196 UlLine <- ((< '____' '_'* >) / (< Spacechar '_'+ &Spacechar >)) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchString("``") {
-				goto l1012
-			}
-			if peekChar('`') {
-				goto l1012
+			{
+				position1083, thunkPosition1083 := position, thunkPosition
+				begin = position
+				if !matchString("____") {
+					goto l1084
+				}
+			l1085:
+				{
+					position1086, thunkPosition1086 := position, thunkPosition
+					if !matchChar('_') {
+						goto l1086
+					}
+					goto l1085
+				l1086:
+					position, thunkPosition = position1086, thunkPosition1086
+				}
+				end = position
+				goto l1083
+			l1084:
+				position, thunkPosition = position1083, thunkPosition1083
+				begin = position
+				if !p.rules[ruleSpacechar]() {
+					goto l1082
+				}
+				if !matchChar('_') {
+					goto l1082
+				}
+			l1087:
+				{
+					position1088, thunkPosition1088 := position, thunkPosition
+					if !matchChar('_') {
+						goto l1088
+					}
+					goto l1087
+				l1088:
+					position, thunkPosition = position1088, thunkPosition1088
+				}
+				{
+					position1089, thunkPosition1089 := position, thunkPosition
+					if !p.rules[ruleSpacechar]() {
+						goto l1082
+					}
+					position, thunkPosition = position1089, thunkPosition1089
+				}
+				end = position
 			}
+		l1083:
 			return true
-		l1012:
+		l1082:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 190 Ticks3 <- ('```' !'`') */
+		/* 197 Intraword <- &{ p.extension.CommonMark && p.intraword(position) } */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchString("```") {
-				goto l1013
-			}
-			if peekChar('`') {
-				goto l1013
+			if !( p.extension.CommonMark && p.intraword(position) ) {
+				goto l1090
 			}
 			return true
-		l1013:
+		l1090:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 191 Ticks4 <- ('````' !'`') */
+		/* 198 Emph <- (EmphStar / EmphUl) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchString("````") {
-				goto l1014
-			}
-			if peekChar('`') {
-				goto l1014
+			{
+				position1092, thunkPosition1092 := position, thunkPosition
+				if !p.rules[ruleEmphStar]() {
+					goto l1093
+				}
+				goto l1092
+			l1093:
+				position, thunkPosition = position1092, thunkPosition1092
+				if !p.rules[ruleEmphUl]() {
+					goto l1091
+				}
 			}
+		l1092:
 			return true
-		l1014:
+		l1091:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 192 Ticks5 <- ('`````' !'`') */
+		/* 199 OneStarOpen <- (!StarLine '*' !Spacechar !WideSpace !Newline) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchString("`````") {
-				goto l1015
+			{
+				position1095, thunkPosition1095 := position, thunkPosition
+				if !p.rules[ruleStarLine]() {
+					goto l1095
+				}
+				goto l1094
+			l1095:
+				position, thunkPosition = position1095, thunkPosition1095
 			}
-			if peekChar('`') {
-				goto l1015
+			if !matchChar('*') {
+				goto l1094
+			}
+			{
+				position1096, thunkPosition1096 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l1096
+				}
+				goto l1094
+			l1096:
+				position, thunkPosition = position1096, thunkPosition1096
+			}
+			{
+				position1097, thunkPosition1097 := position, thunkPosition
+				if !p.rules[ruleWideSpace]() {
+					goto l1097
+				}
+				goto l1094
+			l1097:
+				position, thunkPosition = position1097, thunkPosition1097
+			}
+			{
+				position1098, thunkPosition1098 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l1098
+				}
+				goto l1094
+			l1098:
+				position, thunkPosition = position1098, thunkPosition1098
 			}
 			return true
-		l1015:
+		l1094:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 193 Code <- (((Ticks1 Sp < ((!'`' Nonspacechar)+ / (!Ticks1 '`'+) / (!(Sp Ticks1) (Spacechar / (Newline !BlankLine))))+ > Sp Ticks1) / (Ticks2 Sp < ((!'`' Nonspacechar)+ / (!Ticks2 '`'+) / (!(Sp Ticks2) (Spacechar / (Newline !BlankLine))))+ > Sp Ticks2) / (Ticks3 Sp < ((!'`' Nonspacechar)+ / (!Ticks3 '`'+) / (!(Sp Ticks3) (Spacechar / (Newline !BlankLine))))+ > Sp Ticks3) / (Ticks4 Sp < ((!'`' Nonspacechar)+ / (!Ticks4 '`'+) / (!(Sp Ticks4) (Spacechar / (Newline !BlankLine))))+ > Sp Ticks4) / (Ticks5 Sp < ((!'`' Nonspacechar)+ / (!Ticks5 '`'+) / (!(Sp Ticks5) (Spacechar / (Newline !BlankLine))))+ > Sp Ticks5)) { yy = mk_str(yytext); yy.key = CODE }) */
+		/* 200 OneStarClose <- (!Spacechar !WideSpace !Newline Inline !StrongStar '*' { yy = a }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 1)
 			{
-				position1017, thunkPosition1017 := position, thunkPosition
-				if !p.rules[ruleTicks1]() {
-					goto l1018
+				position1100, thunkPosition1100 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l1100
 				}
-				if !p.rules[ruleSp]() {
-					goto l1018
+				goto l1099
+			l1100:
+				position, thunkPosition = position1100, thunkPosition1100
+			}
+			{
+				position1101, thunkPosition1101 := position, thunkPosition
+				if !p.rules[ruleWideSpace]() {
+					goto l1101
 				}
-				begin = position
-				{
-					position1021, thunkPosition1021 := position, thunkPosition
-					if peekChar('`') {
-						goto l1022
-					}
-					if !p.rules[ruleNonspacechar]() {
-						goto l1022
-					}
-				l1023:
-					{
-						position1024, thunkPosition1024 := position, thunkPosition
-						if peekChar('`') {
-							goto l1024
-						}
-						if !p.rules[ruleNonspacechar]() {
-							goto l1024
-						}
-						goto l1023
-					l1024:
-						position, thunkPosition = position1024, thunkPosition1024
-					}
-					goto l1021
-				l1022:
-					position, thunkPosition = position1021, thunkPosition1021
-					{
-						position1026, thunkPosition1026 := position, thunkPosition
-						if !p.rules[ruleTicks1]() {
-							goto l1026
-						}
-						goto l1025
-					l1026:
-						position, thunkPosition = position1026, thunkPosition1026
-					}
-					if !matchChar('`') {
-						goto l1025
-					}
-				l1027:
-					{
-						position1028, thunkPosition1028 := position, thunkPosition
-						if !matchChar('`') {
-							goto l1028
-						}
-						goto l1027
-					l1028:
-						position, thunkPosition = position1028, thunkPosition1028
-					}
-					goto l1021
-				l1025:
-					position, thunkPosition = position1021, thunkPosition1021
-					{
-						position1029, thunkPosition1029 := position, thunkPosition
-						if !p.rules[ruleSp]() {
-							goto l1029
-						}
-						if !p.rules[ruleTicks1]() {
-							goto l1029
-						}
-						goto l1018
-					l1029:
-						position, thunkPosition = position1029, thunkPosition1029
-					}
-					{
-						position1030, thunkPosition1030 := position, thunkPosition
-						if !p.rules[ruleSpacechar]() {
-							goto l1031
-						}
-						goto l1030
-					l1031:
-						position, thunkPosition = position1030, thunkPosition1030
-						if !p.rules[ruleNewline]() {
-							goto l1018
-						}
-						{
-							position1032, thunkPosition1032 := position, thunkPosition
-							if !p.rules[ruleBlankLine]() {
-								goto l1032
-							}
-							goto l1018
-						l1032:
-							position, thunkPosition = position1032, thunkPosition1032
-						}
-					}
-				l1030:
+				goto l1099
+			l1101:
+				position, thunkPosition = position1101, thunkPosition1101
+			}
+			{
+				position1102, thunkPosition1102 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l1102
+				}
+				goto l1099
+			l1102:
+				position, thunkPosition = position1102, thunkPosition1102
+			}
+			if !p.rules[ruleInline]() {
+				goto l1099
+			}
+			doarg(yySet, -1)
+			{
+				position1103, thunkPosition1103 := position, thunkPosition
+				if !p.rules[ruleStrongStar]() {
+					goto l1103
 				}
-			l1021:
-			l1019:
+				goto l1099
+			l1103:
+				position, thunkPosition = position1103, thunkPosition1103
+			}
+			if !matchChar('*') {
+				goto l1099
+			}
+			do(98)
+			doarg(yyPop, 1)
+			return true
+		l1099:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 201 EmphStar <- (OneStarOpen StartList (!OneStarClose Inline { a = cons(yy, a) })* OneStarClose { a = cons(yy, a) } { yy = mk_list(EMPH, a) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 1)
+			if !p.rules[ruleOneStarOpen]() {
+				goto l1104
+			}
+			if !p.rules[ruleStartList]() {
+				goto l1104
+			}
+			doarg(yySet, -1)
+		l1105:
+			{
+				position1106, thunkPosition1106 := position, thunkPosition
 				{
-					position1020, thunkPosition1020 := position, thunkPosition
-					{
-						position1033, thunkPosition1033 := position, thunkPosition
-						if peekChar('`') {
-							goto l1034
-						}
-						if !p.rules[ruleNonspacechar]() {
-							goto l1034
-						}
-					l1035:
-						{
-							position1036, thunkPosition1036 := position, thunkPosition
-							if peekChar('`') {
-								goto l1036
-							}
-							if !p.rules[ruleNonspacechar]() {
-								goto l1036
-							}
-							goto l1035
-						l1036:
-							position, thunkPosition = position1036, thunkPosition1036
-						}
-						goto l1033
-					l1034:
-						position, thunkPosition = position1033, thunkPosition1033
-						{
-							position1038, thunkPosition1038 := position, thunkPosition
-							if !p.rules[ruleTicks1]() {
-								goto l1038
-							}
-							goto l1037
-						l1038:
-							position, thunkPosition = position1038, thunkPosition1038
-						}
-						if !matchChar('`') {
-							goto l1037
-						}
-					l1039:
-						{
-							position1040, thunkPosition1040 := position, thunkPosition
-							if !matchChar('`') {
-								goto l1040
-							}
-							goto l1039
-						l1040:
-							position, thunkPosition = position1040, thunkPosition1040
-						}
-						goto l1033
-					l1037:
-						position, thunkPosition = position1033, thunkPosition1033
-						{
-							position1041, thunkPosition1041 := position, thunkPosition
-							if !p.rules[ruleSp]() {
-								goto l1041
-							}
-							if !p.rules[ruleTicks1]() {
-								goto l1041
-							}
-							goto l1020
-						l1041:
-							position, thunkPosition = position1041, thunkPosition1041
-						}
-						{
-							position1042, thunkPosition1042 := position, thunkPosition
-							if !p.rules[ruleSpacechar]() {
-								goto l1043
-							}
-							goto l1042
-						l1043:
-							position, thunkPosition = position1042, thunkPosition1042
-							if !p.rules[ruleNewline]() {
-								goto l1020
-							}
-							{
-								position1044, thunkPosition1044 := position, thunkPosition
-								if !p.rules[ruleBlankLine]() {
-									goto l1044
-								}
-								goto l1020
-							l1044:
-								position, thunkPosition = position1044, thunkPosition1044
-							}
-						}
-					l1042:
+					position1107, thunkPosition1107 := position, thunkPosition
+					if !p.rules[ruleOneStarClose]() {
+						goto l1107
 					}
-				l1033:
-					goto l1019
-				l1020:
-					position, thunkPosition = position1020, thunkPosition1020
+					goto l1106
+				l1107:
+					position, thunkPosition = position1107, thunkPosition1107
 				}
-				end = position
-				if !p.rules[ruleSp]() {
-					goto l1018
+				if !p.rules[ruleInline]() {
+					goto l1106
 				}
-				if !p.rules[ruleTicks1]() {
-					goto l1018
+				do(99)
+				goto l1105
+			l1106:
+				position, thunkPosition = position1106, thunkPosition1106
+			}
+			if !p.rules[ruleOneStarClose]() {
+				goto l1104
+			}
+			do(100)
+			do(101)
+			doarg(yyPop, 1)
+			return true
+		l1104:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 202 OneUlOpen <- (!UlLine !Intraword '_' !Spacechar !WideSpace !Newline) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1109, thunkPosition1109 := position, thunkPosition
+				if !p.rules[ruleUlLine]() {
+					goto l1109
 				}
-				goto l1017
-			l1018:
-				position, thunkPosition = position1017, thunkPosition1017
-				if !p.rules[ruleTicks2]() {
-					goto l1045
+				goto l1108
+			l1109:
+				position, thunkPosition = position1109, thunkPosition1109
+			}
+			{
+				position1110, thunkPosition1110 := position, thunkPosition
+				if !p.rules[ruleIntraword]() {
+					goto l1110
 				}
-				if !p.rules[ruleSp]() {
-					goto l1045
+				goto l1108
+			l1110:
+				position, thunkPosition = position1110, thunkPosition1110
+			}
+			if !matchChar('_') {
+				goto l1108
+			}
+			{
+				position1111, thunkPosition1111 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l1111
 				}
-				begin = position
+				goto l1108
+			l1111:
+				position, thunkPosition = position1111, thunkPosition1111
+			}
+			{
+				position1112, thunkPosition1112 := position, thunkPosition
+				if !p.rules[ruleWideSpace]() {
+					goto l1112
+				}
+				goto l1108
+			l1112:
+				position, thunkPosition = position1112, thunkPosition1112
+			}
+			{
+				position1113, thunkPosition1113 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l1113
+				}
+				goto l1108
+			l1113:
+				position, thunkPosition = position1113, thunkPosition1113
+			}
+			return true
+		l1108:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 203 OneUlClose <- (!Spacechar !WideSpace !Newline Inline !StrongUl '_' !Alphanumeric { yy = a }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 1)
+			{
+				position1115, thunkPosition1115 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l1115
+				}
+				goto l1114
+			l1115:
+				position, thunkPosition = position1115, thunkPosition1115
+			}
+			{
+				position1116, thunkPosition1116 := position, thunkPosition
+				if !p.rules[ruleWideSpace]() {
+					goto l1116
+				}
+				goto l1114
+			l1116:
+				position, thunkPosition = position1116, thunkPosition1116
+			}
+			{
+				position1117, thunkPosition1117 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l1117
+				}
+				goto l1114
+			l1117:
+				position, thunkPosition = position1117, thunkPosition1117
+			}
+			if !p.rules[ruleInline]() {
+				goto l1114
+			}
+			doarg(yySet, -1)
+			{
+				position1118, thunkPosition1118 := position, thunkPosition
+				if !p.rules[ruleStrongUl]() {
+					goto l1118
+				}
+				goto l1114
+			l1118:
+				position, thunkPosition = position1118, thunkPosition1118
+			}
+			if !matchChar('_') {
+				goto l1114
+			}
+			{
+				position1119, thunkPosition1119 := position, thunkPosition
+				if !p.rules[ruleAlphanumeric]() {
+					goto l1119
+				}
+				goto l1114
+			l1119:
+				position, thunkPosition = position1119, thunkPosition1119
+			}
+			do(102)
+			doarg(yyPop, 1)
+			return true
+		l1114:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 204 EmphUl <- (OneUlOpen StartList (!OneUlClose Inline { a = cons(yy, a) })* OneUlClose { a = cons(yy, a) } { yy = mk_list(EMPH, a) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 1)
+			if !p.rules[ruleOneUlOpen]() {
+				goto l1120
+			}
+			if !p.rules[ruleStartList]() {
+				goto l1120
+			}
+			doarg(yySet, -1)
+		l1121:
+			{
+				position1122, thunkPosition1122 := position, thunkPosition
 				{
-					position1048, thunkPosition1048 := position, thunkPosition
-					if peekChar('`') {
-						goto l1049
-					}
-					if !p.rules[ruleNonspacechar]() {
-						goto l1049
-					}
-				l1050:
-					{
-						position1051, thunkPosition1051 := position, thunkPosition
-						if peekChar('`') {
-							goto l1051
-						}
-						if !p.rules[ruleNonspacechar]() {
-							goto l1051
-						}
-						goto l1050
-					l1051:
-						position, thunkPosition = position1051, thunkPosition1051
-					}
-					goto l1048
-				l1049:
-					position, thunkPosition = position1048, thunkPosition1048
-					{
-						position1053, thunkPosition1053 := position, thunkPosition
-						if !p.rules[ruleTicks2]() {
-							goto l1053
-						}
-						goto l1052
-					l1053:
-						position, thunkPosition = position1053, thunkPosition1053
-					}
-					if !matchChar('`') {
-						goto l1052
-					}
-				l1054:
-					{
-						position1055, thunkPosition1055 := position, thunkPosition
-						if !matchChar('`') {
-							goto l1055
-						}
-						goto l1054
-					l1055:
-						position, thunkPosition = position1055, thunkPosition1055
-					}
-					goto l1048
-				l1052:
-					position, thunkPosition = position1048, thunkPosition1048
-					{
-						position1056, thunkPosition1056 := position, thunkPosition
-						if !p.rules[ruleSp]() {
-							goto l1056
-						}
-						if !p.rules[ruleTicks2]() {
-							goto l1056
-						}
-						goto l1045
-					l1056:
-						position, thunkPosition = position1056, thunkPosition1056
-					}
-					{
-						position1057, thunkPosition1057 := position, thunkPosition
-						if !p.rules[ruleSpacechar]() {
-							goto l1058
-						}
-						goto l1057
-					l1058:
-						position, thunkPosition = position1057, thunkPosition1057
-						if !p.rules[ruleNewline]() {
-							goto l1045
-						}
-						{
-							position1059, thunkPosition1059 := position, thunkPosition
-							if !p.rules[ruleBlankLine]() {
-								goto l1059
-							}
-							goto l1045
-						l1059:
-							position, thunkPosition = position1059, thunkPosition1059
-						}
+					position1123, thunkPosition1123 := position, thunkPosition
+					if !p.rules[ruleOneUlClose]() {
+						goto l1123
 					}
-				l1057:
+					goto l1122
+				l1123:
+					position, thunkPosition = position1123, thunkPosition1123
 				}
-			l1048:
-			l1046:
-				{
-					position1047, thunkPosition1047 := position, thunkPosition
-					{
-						position1060, thunkPosition1060 := position, thunkPosition
-						if peekChar('`') {
-							goto l1061
-						}
-						if !p.rules[ruleNonspacechar]() {
-							goto l1061
-						}
-					l1062:
-						{
-							position1063, thunkPosition1063 := position, thunkPosition
-							if peekChar('`') {
-								goto l1063
-							}
-							if !p.rules[ruleNonspacechar]() {
-								goto l1063
-							}
-							goto l1062
-						l1063:
-							position, thunkPosition = position1063, thunkPosition1063
-						}
-						goto l1060
-					l1061:
-						position, thunkPosition = position1060, thunkPosition1060
-						{
-							position1065, thunkPosition1065 := position, thunkPosition
-							if !p.rules[ruleTicks2]() {
-								goto l1065
-							}
-							goto l1064
-						l1065:
-							position, thunkPosition = position1065, thunkPosition1065
-						}
-						if !matchChar('`') {
-							goto l1064
-						}
-					l1066:
-						{
-							position1067, thunkPosition1067 := position, thunkPosition
-							if !matchChar('`') {
-								goto l1067
-							}
-							goto l1066
-						l1067:
-							position, thunkPosition = position1067, thunkPosition1067
-						}
-						goto l1060
-					l1064:
-						position, thunkPosition = position1060, thunkPosition1060
-						{
-							position1068, thunkPosition1068 := position, thunkPosition
-							if !p.rules[ruleSp]() {
-								goto l1068
-							}
-							if !p.rules[ruleTicks2]() {
-								goto l1068
-							}
-							goto l1047
-						l1068:
-							position, thunkPosition = position1068, thunkPosition1068
-						}
-						{
-							position1069, thunkPosition1069 := position, thunkPosition
-							if !p.rules[ruleSpacechar]() {
-								goto l1070
-							}
-							goto l1069
-						l1070:
-							position, thunkPosition = position1069, thunkPosition1069
-							if !p.rules[ruleNewline]() {
-								goto l1047
-							}
-							{
-								position1071, thunkPosition1071 := position, thunkPosition
-								if !p.rules[ruleBlankLine]() {
-									goto l1071
-								}
-								goto l1047
-							l1071:
-								position, thunkPosition = position1071, thunkPosition1071
-							}
-						}
-					l1069:
-					}
-				l1060:
-					goto l1046
-				l1047:
-					position, thunkPosition = position1047, thunkPosition1047
-				}
-				end = position
-				if !p.rules[ruleSp]() {
-					goto l1045
+				if !p.rules[ruleInline]() {
+					goto l1122
 				}
-				if !p.rules[ruleTicks2]() {
-					goto l1045
+				do(103)
+				goto l1121
+			l1122:
+				position, thunkPosition = position1122, thunkPosition1122
+			}
+			if !p.rules[ruleOneUlClose]() {
+				goto l1120
+			}
+			do(104)
+			do(105)
+			doarg(yyPop, 1)
+			return true
+		l1120:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 205 Strong <- (StrongStar / StrongUl) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1125, thunkPosition1125 := position, thunkPosition
+				if !p.rules[ruleStrongStar]() {
+					goto l1126
 				}
-				goto l1017
-			l1045:
-				position, thunkPosition = position1017, thunkPosition1017
-				if !p.rules[ruleTicks3]() {
-					goto l1072
+				goto l1125
+			l1126:
+				position, thunkPosition = position1125, thunkPosition1125
+				if !p.rules[ruleStrongUl]() {
+					goto l1124
 				}
-				if !p.rules[ruleSp]() {
-					goto l1072
+			}
+		l1125:
+			return true
+		l1124:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 206 TwoStarOpen <- (!StarLine '**' !Spacechar !WideSpace !Newline) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1128, thunkPosition1128 := position, thunkPosition
+				if !p.rules[ruleStarLine]() {
+					goto l1128
 				}
-				begin = position
-				{
-					position1075, thunkPosition1075 := position, thunkPosition
-					if peekChar('`') {
-						goto l1076
-					}
-					if !p.rules[ruleNonspacechar]() {
-						goto l1076
-					}
-				l1077:
-					{
-						position1078, thunkPosition1078 := position, thunkPosition
-						if peekChar('`') {
-							goto l1078
-						}
-						if !p.rules[ruleNonspacechar]() {
-							goto l1078
-						}
-						goto l1077
-					l1078:
-						position, thunkPosition = position1078, thunkPosition1078
-					}
-					goto l1075
-				l1076:
-					position, thunkPosition = position1075, thunkPosition1075
-					{
-						position1080, thunkPosition1080 := position, thunkPosition
-						if !p.rules[ruleTicks3]() {
-							goto l1080
-						}
-						goto l1079
-					l1080:
-						position, thunkPosition = position1080, thunkPosition1080
-					}
-					if !matchChar('`') {
-						goto l1079
-					}
-				l1081:
-					{
-						position1082, thunkPosition1082 := position, thunkPosition
-						if !matchChar('`') {
-							goto l1082
-						}
-						goto l1081
-					l1082:
-						position, thunkPosition = position1082, thunkPosition1082
-					}
-					goto l1075
-				l1079:
-					position, thunkPosition = position1075, thunkPosition1075
-					{
-						position1083, thunkPosition1083 := position, thunkPosition
-						if !p.rules[ruleSp]() {
-							goto l1083
-						}
-						if !p.rules[ruleTicks3]() {
-							goto l1083
-						}
-						goto l1072
-					l1083:
-						position, thunkPosition = position1083, thunkPosition1083
-					}
-					{
-						position1084, thunkPosition1084 := position, thunkPosition
-						if !p.rules[ruleSpacechar]() {
-							goto l1085
-						}
-						goto l1084
-					l1085:
-						position, thunkPosition = position1084, thunkPosition1084
-						if !p.rules[ruleNewline]() {
-							goto l1072
-						}
-						{
-							position1086, thunkPosition1086 := position, thunkPosition
-							if !p.rules[ruleBlankLine]() {
-								goto l1086
-							}
-							goto l1072
-						l1086:
-							position, thunkPosition = position1086, thunkPosition1086
-						}
-					}
-				l1084:
+				goto l1127
+			l1128:
+				position, thunkPosition = position1128, thunkPosition1128
+			}
+			if !matchString("**") {
+				goto l1127
+			}
+			{
+				position1129, thunkPosition1129 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l1129
 				}
-			l1075:
-			l1073:
-				{
-					position1074, thunkPosition1074 := position, thunkPosition
-					{
-						position1087, thunkPosition1087 := position, thunkPosition
-						if peekChar('`') {
-							goto l1088
-						}
-						if !p.rules[ruleNonspacechar]() {
-							goto l1088
-						}
-					l1089:
-						{
-							position1090, thunkPosition1090 := position, thunkPosition
-							if peekChar('`') {
-								goto l1090
-							}
-							if !p.rules[ruleNonspacechar]() {
-								goto l1090
-							}
-							goto l1089
-						l1090:
-							position, thunkPosition = position1090, thunkPosition1090
-						}
-						goto l1087
-					l1088:
-						position, thunkPosition = position1087, thunkPosition1087
-						{
-							position1092, thunkPosition1092 := position, thunkPosition
-							if !p.rules[ruleTicks3]() {
-								goto l1092
-							}
-							goto l1091
-						l1092:
-							position, thunkPosition = position1092, thunkPosition1092
-						}
-						if !matchChar('`') {
-							goto l1091
-						}
-					l1093:
-						{
-							position1094, thunkPosition1094 := position, thunkPosition
-							if !matchChar('`') {
-								goto l1094
-							}
-							goto l1093
-						l1094:
-							position, thunkPosition = position1094, thunkPosition1094
-						}
-						goto l1087
-					l1091:
-						position, thunkPosition = position1087, thunkPosition1087
-						{
-							position1095, thunkPosition1095 := position, thunkPosition
-							if !p.rules[ruleSp]() {
-								goto l1095
-							}
-							if !p.rules[ruleTicks3]() {
-								goto l1095
-							}
-							goto l1074
-						l1095:
-							position, thunkPosition = position1095, thunkPosition1095
-						}
-						{
-							position1096, thunkPosition1096 := position, thunkPosition
-							if !p.rules[ruleSpacechar]() {
-								goto l1097
-							}
-							goto l1096
-						l1097:
-							position, thunkPosition = position1096, thunkPosition1096
-							if !p.rules[ruleNewline]() {
-								goto l1074
-							}
-							{
-								position1098, thunkPosition1098 := position, thunkPosition
-								if !p.rules[ruleBlankLine]() {
-									goto l1098
-								}
-								goto l1074
-							l1098:
-								position, thunkPosition = position1098, thunkPosition1098
-							}
-						}
-					l1096:
-					}
-				l1087:
-					goto l1073
-				l1074:
-					position, thunkPosition = position1074, thunkPosition1074
+				goto l1127
+			l1129:
+				position, thunkPosition = position1129, thunkPosition1129
+			}
+			{
+				position1130, thunkPosition1130 := position, thunkPosition
+				if !p.rules[ruleWideSpace]() {
+					goto l1130
 				}
-				end = position
-				if !p.rules[ruleSp]() {
-					goto l1072
+				goto l1127
+			l1130:
+				position, thunkPosition = position1130, thunkPosition1130
+			}
+			{
+				position1131, thunkPosition1131 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l1131
 				}
-				if !p.rules[ruleTicks3]() {
-					goto l1072
+				goto l1127
+			l1131:
+				position, thunkPosition = position1131, thunkPosition1131
+			}
+			return true
+		l1127:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 207 TwoStarClose <- (!Spacechar !WideSpace !Newline Inline '**' { yy = a }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 1)
+			{
+				position1133, thunkPosition1133 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l1133
 				}
-				goto l1017
-			l1072:
-				position, thunkPosition = position1017, thunkPosition1017
-				if !p.rules[ruleTicks4]() {
-					goto l1099
+				goto l1132
+			l1133:
+				position, thunkPosition = position1133, thunkPosition1133
+			}
+			{
+				position1134, thunkPosition1134 := position, thunkPosition
+				if !p.rules[ruleWideSpace]() {
+					goto l1134
 				}
-				if !p.rules[ruleSp]() {
-					goto l1099
+				goto l1132
+			l1134:
+				position, thunkPosition = position1134, thunkPosition1134
+			}
+			{
+				position1135, thunkPosition1135 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l1135
 				}
-				begin = position
+				goto l1132
+			l1135:
+				position, thunkPosition = position1135, thunkPosition1135
+			}
+			if !p.rules[ruleInline]() {
+				goto l1132
+			}
+			doarg(yySet, -1)
+			if !matchString("**") {
+				goto l1132
+			}
+			do(106)
+			doarg(yyPop, 1)
+			return true
+		l1132:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 208 StrongStar <- (TwoStarOpen StartList (!TwoStarClose Inline { a = cons(yy, a) })* TwoStarClose { a = cons(yy, a) } { yy = mk_list(STRONG, a) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 1)
+			if !p.rules[ruleTwoStarOpen]() {
+				goto l1136
+			}
+			if !p.rules[ruleStartList]() {
+				goto l1136
+			}
+			doarg(yySet, -1)
+		l1137:
+			{
+				position1138, thunkPosition1138 := position, thunkPosition
 				{
-					position1102, thunkPosition1102 := position, thunkPosition
-					if peekChar('`') {
-						goto l1103
-					}
-					if !p.rules[ruleNonspacechar]() {
-						goto l1103
-					}
-				l1104:
-					{
-						position1105, thunkPosition1105 := position, thunkPosition
-						if peekChar('`') {
-							goto l1105
-						}
-						if !p.rules[ruleNonspacechar]() {
-							goto l1105
-						}
-						goto l1104
-					l1105:
-						position, thunkPosition = position1105, thunkPosition1105
-					}
-					goto l1102
-				l1103:
-					position, thunkPosition = position1102, thunkPosition1102
-					{
-						position1107, thunkPosition1107 := position, thunkPosition
-						if !p.rules[ruleTicks4]() {
-							goto l1107
-						}
-						goto l1106
-					l1107:
-						position, thunkPosition = position1107, thunkPosition1107
-					}
-					if !matchChar('`') {
-						goto l1106
-					}
-				l1108:
-					{
-						position1109, thunkPosition1109 := position, thunkPosition
-						if !matchChar('`') {
-							goto l1109
-						}
-						goto l1108
-					l1109:
-						position, thunkPosition = position1109, thunkPosition1109
-					}
-					goto l1102
-				l1106:
-					position, thunkPosition = position1102, thunkPosition1102
-					{
-						position1110, thunkPosition1110 := position, thunkPosition
-						if !p.rules[ruleSp]() {
-							goto l1110
-						}
-						if !p.rules[ruleTicks4]() {
-							goto l1110
-						}
-						goto l1099
-					l1110:
-						position, thunkPosition = position1110, thunkPosition1110
-					}
-					{
-						position1111, thunkPosition1111 := position, thunkPosition
-						if !p.rules[ruleSpacechar]() {
-							goto l1112
-						}
-						goto l1111
-					l1112:
-						position, thunkPosition = position1111, thunkPosition1111
-						if !p.rules[ruleNewline]() {
-							goto l1099
-						}
-						{
-							position1113, thunkPosition1113 := position, thunkPosition
-							if !p.rules[ruleBlankLine]() {
-								goto l1113
-							}
-							goto l1099
-						l1113:
-							position, thunkPosition = position1113, thunkPosition1113
-						}
+					position1139, thunkPosition1139 := position, thunkPosition
+					if !p.rules[ruleTwoStarClose]() {
+						goto l1139
 					}
-				l1111:
+					goto l1138
+				l1139:
+					position, thunkPosition = position1139, thunkPosition1139
 				}
-			l1102:
-			l1100:
-				{
-					position1101, thunkPosition1101 := position, thunkPosition
-					{
-						position1114, thunkPosition1114 := position, thunkPosition
-						if peekChar('`') {
-							goto l1115
-						}
-						if !p.rules[ruleNonspacechar]() {
-							goto l1115
-						}
-					l1116:
-						{
-							position1117, thunkPosition1117 := position, thunkPosition
-							if peekChar('`') {
-								goto l1117
-							}
-							if !p.rules[ruleNonspacechar]() {
-								goto l1117
-							}
-							goto l1116
-						l1117:
-							position, thunkPosition = position1117, thunkPosition1117
+				if !p.rules[ruleInline]() {
+					goto l1138
+				}
+				do(107)
+				goto l1137
+			l1138:
+				position, thunkPosition = position1138, thunkPosition1138
+			}
+			if !p.rules[ruleTwoStarClose]() {
+				goto l1136
+			}
+			do(108)
+			do(109)
+			doarg(yyPop, 1)
+			return true
+		l1136:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 209 TwoUlOpen <- (!UlLine !Intraword '__' !Spacechar !WideSpace !Newline) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1141, thunkPosition1141 := position, thunkPosition
+				if !p.rules[ruleUlLine]() {
+					goto l1141
+				}
+				goto l1140
+			l1141:
+				position, thunkPosition = position1141, thunkPosition1141
+			}
+			{
+				position1142, thunkPosition1142 := position, thunkPosition
+				if !p.rules[ruleIntraword]() {
+					goto l1142
+				}
+				goto l1140
+			l1142:
+				position, thunkPosition = position1142, thunkPosition1142
+			}
+			if !matchString("__") {
+				goto l1140
+			}
+			{
+				position1143, thunkPosition1143 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l1143
+				}
+				goto l1140
+			l1143:
+				position, thunkPosition = position1143, thunkPosition1143
+			}
+			{
+				position1144, thunkPosition1144 := position, thunkPosition
+				if !p.rules[ruleWideSpace]() {
+					goto l1144
+				}
+				goto l1140
+			l1144:
+				position, thunkPosition = position1144, thunkPosition1144
+			}
+			{
+				position1145, thunkPosition1145 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l1145
+				}
+				goto l1140
+			l1145:
+				position, thunkPosition = position1145, thunkPosition1145
+			}
+			return true
+		l1140:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 210 TwoUlClose <- (!Spacechar !WideSpace !Newline Inline '__' !Alphanumeric { yy = a }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 1)
+			{
+				position1147, thunkPosition1147 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l1147
+				}
+				goto l1146
+			l1147:
+				position, thunkPosition = position1147, thunkPosition1147
+			}
+			{
+				position1148, thunkPosition1148 := position, thunkPosition
+				if !p.rules[ruleWideSpace]() {
+					goto l1148
+				}
+				goto l1146
+			l1148:
+				position, thunkPosition = position1148, thunkPosition1148
+			}
+			{
+				position1149, thunkPosition1149 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l1149
+				}
+				goto l1146
+			l1149:
+				position, thunkPosition = position1149, thunkPosition1149
+			}
+			if !p.rules[ruleInline]() {
+				goto l1146
+			}
+			doarg(yySet, -1)
+			if !matchString("__") {
+				goto l1146
+			}
+			{
+				position1150, thunkPosition1150 := position, thunkPosition
+				if !p.rules[ruleAlphanumeric]() {
+					goto l1150
+				}
+				goto l1146
+			l1150:
+				position, thunkPosition = position1150, thunkPosition1150
+			}
+			do(110)
+			doarg(yyPop, 1)
+			return true
+		l1146:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 211 StrongUl <- (TwoUlOpen StartList (!TwoUlClose Inline { a = cons(yy, a) })* TwoUlClose { a = cons(yy, a) } { yy = mk_list(STRONG, a) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 1)
+			if !p.rules[ruleTwoUlOpen]() {
+				goto l1151
+			}
+			if !p.rules[ruleStartList]() {
+				goto l1151
+			}
+			doarg(yySet, -1)
+		l1152:
+			{
+				position1153, thunkPosition1153 := position, thunkPosition
+				{
+					position1154, thunkPosition1154 := position, thunkPosition
+					if !p.rules[ruleTwoUlClose]() {
+						goto l1154
+					}
+					goto l1153
+				l1154:
+					position, thunkPosition = position1154, thunkPosition1154
+				}
+				if !p.rules[ruleInline]() {
+					goto l1153
+				}
+				do(111)
+				goto l1152
+			l1153:
+				position, thunkPosition = position1153, thunkPosition1153
+			}
+			if !p.rules[ruleTwoUlClose]() {
+				goto l1151
+			}
+			do(112)
+			do(113)
+			doarg(yyPop, 1)
+			return true
+		l1151:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 212 Strike <- (&{ p.extension.Strike } '~~' !Spacechar !Newline StartList (!'~~' Inline { a = cons(yy, a) })+ '~~' { yy = mk_list(STRIKE, a) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 1)
+			if !( p.extension.Strike ) {
+				goto l1155
+			}
+			if !matchString("~~") {
+				goto l1155
+			}
+			{
+				position1156, thunkPosition1156 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l1156
+				}
+				goto l1155
+			l1156:
+				position, thunkPosition = position1156, thunkPosition1156
+			}
+			{
+				position1157, thunkPosition1157 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l1157
+				}
+				goto l1155
+			l1157:
+				position, thunkPosition = position1157, thunkPosition1157
+			}
+			if !p.rules[ruleStartList]() {
+				goto l1155
+			}
+			doarg(yySet, -1)
+			{
+				position1160, thunkPosition1160 := position, thunkPosition
+				if !matchString("~~") {
+					goto l1160
+				}
+				goto l1155
+			l1160:
+				position, thunkPosition = position1160, thunkPosition1160
+			}
+			if !p.rules[ruleInline]() {
+				goto l1155
+			}
+			do(114)
+		l1158:
+			{
+				position1159, thunkPosition1159 := position, thunkPosition
+				{
+					position1161, thunkPosition1161 := position, thunkPosition
+					if !matchString("~~") {
+						goto l1161
+					}
+					goto l1159
+				l1161:
+					position, thunkPosition = position1161, thunkPosition1161
+				}
+				if !p.rules[ruleInline]() {
+					goto l1159
+				}
+				do(114)
+				goto l1158
+			l1159:
+				position, thunkPosition = position1159, thunkPosition1159
+			}
+			if !matchString("~~") {
+				goto l1155
+			}
+			do(115)
+			doarg(yyPop, 1)
+			return true
+		l1155:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 213 Superscript <- (&{ p.extension.SupSub } '^' !'[' StartList (!'^' !Spacechar !Newline Inline { a = cons(yy, a) })+ '^' !'^' { yy = mk_list(SUPERSCRIPT, a) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 1)
+			if !( p.extension.SupSub ) {
+				goto l1162
+			}
+			if !matchChar('^') {
+				goto l1162
+			}
+			if peekChar('[') {
+				goto l1162
+			}
+			if !p.rules[ruleStartList]() {
+				goto l1162
+			}
+			doarg(yySet, -1)
+			if peekChar('^') {
+				goto l1162
+			}
+			{
+				position1165, thunkPosition1165 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l1165
+				}
+				goto l1162
+			l1165:
+				position, thunkPosition = position1165, thunkPosition1165
+			}
+			{
+				position1166, thunkPosition1166 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l1166
+				}
+				goto l1162
+			l1166:
+				position, thunkPosition = position1166, thunkPosition1166
+			}
+			if !p.rules[ruleInline]() {
+				goto l1162
+			}
+			do(116)
+		l1163:
+			{
+				position1164, thunkPosition1164 := position, thunkPosition
+				if peekChar('^') {
+					goto l1164
+				}
+				{
+					position1167, thunkPosition1167 := position, thunkPosition
+					if !p.rules[ruleSpacechar]() {
+						goto l1167
+					}
+					goto l1164
+				l1167:
+					position, thunkPosition = position1167, thunkPosition1167
+				}
+				{
+					position1168, thunkPosition1168 := position, thunkPosition
+					if !p.rules[ruleNewline]() {
+						goto l1168
+					}
+					goto l1164
+				l1168:
+					position, thunkPosition = position1168, thunkPosition1168
+				}
+				if !p.rules[ruleInline]() {
+					goto l1164
+				}
+				do(116)
+				goto l1163
+			l1164:
+				position, thunkPosition = position1164, thunkPosition1164
+			}
+			if !matchChar('^') {
+				goto l1162
+			}
+			if peekChar('^') {
+				goto l1162
+			}
+			do(117)
+			doarg(yyPop, 1)
+			return true
+		l1162:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 214 Subscript <- (&{ p.extension.SupSub } '~' !'~' StartList (!'~' !Spacechar !Newline Inline { a = cons(yy, a) })+ '~' !'~' { yy = mk_list(SUBSCRIPT, a) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 1)
+			if !( p.extension.SupSub ) {
+				goto l1169
+			}
+			if !matchChar('~') {
+				goto l1169
+			}
+			if peekChar('~') {
+				goto l1169
+			}
+			if !p.rules[ruleStartList]() {
+				goto l1169
+			}
+			doarg(yySet, -1)
+			if peekChar('~') {
+				goto l1169
+			}
+			{
+				position1172, thunkPosition1172 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l1172
+				}
+				goto l1169
+			l1172:
+				position, thunkPosition = position1172, thunkPosition1172
+			}
+			{
+				position1173, thunkPosition1173 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l1173
+				}
+				goto l1169
+			l1173:
+				position, thunkPosition = position1173, thunkPosition1173
+			}
+			if !p.rules[ruleInline]() {
+				goto l1169
+			}
+			do(118)
+		l1170:
+			{
+				position1171, thunkPosition1171 := position, thunkPosition
+				if peekChar('~') {
+					goto l1171
+				}
+				{
+					position1174, thunkPosition1174 := position, thunkPosition
+					if !p.rules[ruleSpacechar]() {
+						goto l1174
+					}
+					goto l1171
+				l1174:
+					position, thunkPosition = position1174, thunkPosition1174
+				}
+				{
+					position1175, thunkPosition1175 := position, thunkPosition
+					if !p.rules[ruleNewline]() {
+						goto l1175
+					}
+					goto l1171
+				l1175:
+					position, thunkPosition = position1175, thunkPosition1175
+				}
+				if !p.rules[ruleInline]() {
+					goto l1171
+				}
+				do(118)
+				goto l1170
+			l1171:
+				position, thunkPosition = position1171, thunkPosition1171
+			}
+			if !matchChar('~') {
+				goto l1169
+			}
+			if peekChar('~') {
+				goto l1169
+			}
+			do(119)
+			doarg(yyPop, 1)
+			return true
+		l1169:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 215 Image <- ('!' (ExplicitLink / ReferenceLink) {	if yy.key == LINK {
+			yy.key = IMAGE
+		} else {
+			result := yy
+			yy.children = cons(mk_str("!"), result.children)
+		}
+	}) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('!') {
+				goto l1176
+			}
+			{
+				position1177, thunkPosition1177 := position, thunkPosition
+				if !p.rules[ruleExplicitLink]() {
+					goto l1178
+				}
+				goto l1177
+			l1178:
+				position, thunkPosition = position1177, thunkPosition1177
+				if !p.rules[ruleReferenceLink]() {
+					goto l1176
+				}
+			}
+		l1177:
+			do(120)
+			return true
+		l1176:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 216 Link <- (ExplicitLink / ReferenceLink / AutoLink) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1180, thunkPosition1180 := position, thunkPosition
+				if !p.rules[ruleExplicitLink]() {
+					goto l1181
+				}
+				goto l1180
+			l1181:
+				position, thunkPosition = position1180, thunkPosition1180
+				if !p.rules[ruleReferenceLink]() {
+					goto l1182
+				}
+				goto l1180
+			l1182:
+				position, thunkPosition = position1180, thunkPosition1180
+				if !p.rules[ruleAutoLink]() {
+					goto l1179
+				}
+			}
+		l1180:
+			return true
+		l1179:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 217 WikiLink <- (&{ p.extension.WikiLinks } '[[' WikiTarget (('|' StartList (!']]' Inline { a = cons(yy, a) })+ { a = mk_list(LIST, a).children }) / Nothing) ']]' { yy = p.wikiLink(t.contents.str, a)
+              t = nil
+              a = nil }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 2)
+			if !( p.extension.WikiLinks ) {
+				goto l1183
+			}
+			if !matchString("[[") {
+				goto l1183
+			}
+			if !p.rules[ruleWikiTarget]() {
+				goto l1183
+			}
+			doarg(yySet, -1)
+			{
+				position1184, thunkPosition1184 := position, thunkPosition
+				if !matchChar('|') {
+					goto l1185
+				}
+				if !p.rules[ruleStartList]() {
+					goto l1185
+				}
+				doarg(yySet, -2)
+				{
+					position1188, thunkPosition1188 := position, thunkPosition
+					if !matchString("]]") {
+						goto l1188
+					}
+					goto l1185
+				l1188:
+					position, thunkPosition = position1188, thunkPosition1188
+				}
+				if !p.rules[ruleInline]() {
+					goto l1185
+				}
+				do(121)
+			l1186:
+				{
+					position1187, thunkPosition1187 := position, thunkPosition
+					{
+						position1189, thunkPosition1189 := position, thunkPosition
+						if !matchString("]]") {
+							goto l1189
+						}
+						goto l1187
+					l1189:
+						position, thunkPosition = position1189, thunkPosition1189
+					}
+					if !p.rules[ruleInline]() {
+						goto l1187
+					}
+					do(121)
+					goto l1186
+				l1187:
+					position, thunkPosition = position1187, thunkPosition1187
+				}
+				do(122)
+				goto l1184
+			l1185:
+				position, thunkPosition = position1184, thunkPosition1184
+				if !p.rules[ruleNothing]() {
+					goto l1183
+				}
+				doarg(yySet, -2)
+			}
+		l1184:
+			if !matchString("]]") {
+				goto l1183
+			}
+			do(123)
+			doarg(yyPop, 2)
+			return true
+		l1183:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 218 WikiTarget <- (< (!']' !'|' !Newline .)+ > &{ strings.TrimSpace(p.Buffer[begin:end]) != "" } { yy = mk_str(strings.TrimSpace(yytext)) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			begin = position
+			if peekChar(']') {
+				goto l1190
+			}
+			if peekChar('|') {
+				goto l1190
+			}
+			{
+				position1193, thunkPosition1193 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l1193
+				}
+				goto l1190
+			l1193:
+				position, thunkPosition = position1193, thunkPosition1193
+			}
+			if !matchDot() {
+				goto l1190
+			}
+		l1191:
+			{
+				position1192, thunkPosition1192 := position, thunkPosition
+				if peekChar(']') {
+					goto l1192
+				}
+				if peekChar('|') {
+					goto l1192
+				}
+				{
+					position1194, thunkPosition1194 := position, thunkPosition
+					if !p.rules[ruleNewline]() {
+						goto l1194
+					}
+					goto l1192
+				l1194:
+					position, thunkPosition = position1194, thunkPosition1194
+				}
+				if !matchDot() {
+					goto l1192
+				}
+				goto l1191
+			l1192:
+				position, thunkPosition = position1192, thunkPosition1192
+			}
+			end = position
+			if !( strings.TrimSpace(p.Buffer[begin:end]) != "" ) {
+				goto l1190
+			}
+			do(124)
+			return true
+		l1190:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 219 Citation <- (&{ p.extension.Citations } (BracketCitation / TextCitation)) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !( p.extension.Citations ) {
+				goto l1195
+			}
+			{
+				position1196, thunkPosition1196 := position, thunkPosition
+				if !p.rules[ruleBracketCitation]() {
+					goto l1197
+				}
+				goto l1196
+			l1197:
+				position, thunkPosition = position1196, thunkPosition1196
+				if !p.rules[ruleTextCitation]() {
+					goto l1195
+				}
+			}
+		l1196:
+			return true
+		l1195:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 220 BracketCitation <- (< '[' (!'[' !']' !(Newline BlankLine) .)+ ']' > !'(' &{ isCitation(p.Buffer[begin:end]) } { yy = p.citation(yytext, false) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			begin = position
+			if !matchChar('[') {
+				goto l1198
+			}
+			if peekChar('[') {
+				goto l1198
+			}
+			if peekChar(']') {
+				goto l1198
+			}
+			{
+				position1201, thunkPosition1201 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l1201
+				}
+				if !p.rules[ruleBlankLine]() {
+					goto l1201
+				}
+				goto l1198
+			l1201:
+				position, thunkPosition = position1201, thunkPosition1201
+			}
+			if !matchDot() {
+				goto l1198
+			}
+		l1199:
+			{
+				position1200, thunkPosition1200 := position, thunkPosition
+				if peekChar('[') {
+					goto l1200
+				}
+				if peekChar(']') {
+					goto l1200
+				}
+				{
+					position1202, thunkPosition1202 := position, thunkPosition
+					if !p.rules[ruleNewline]() {
+						goto l1202
+					}
+					if !p.rules[ruleBlankLine]() {
+						goto l1202
+					}
+					goto l1200
+				l1202:
+					position, thunkPosition = position1202, thunkPosition1202
+				}
+				if !matchDot() {
+					goto l1200
+				}
+				goto l1199
+			l1200:
+				position, thunkPosition = position1200, thunkPosition1200
+			}
+			if !matchChar(']') {
+				goto l1198
+			}
+			end = position
+			if peekChar('(') {
+				goto l1198
+			}
+			if !( isCitation(p.Buffer[begin:end]) ) {
+				goto l1198
+			}
+			do(125)
+			return true
+		l1198:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 221 TextCitation <- (&{ p.textCitation(position) } < '@' CitationKey (Sp '[' (!'[' !']' !Newline .)* ']' !'(')? > { yy = p.citation(yytext, true) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !( p.textCitation(position) ) {
+				goto l1203
+			}
+			begin = position
+			if !matchChar('@') {
+				goto l1203
+			}
+			if !p.rules[ruleCitationKey]() {
+				goto l1203
+			}
+			{
+				position1204, thunkPosition1204 := position, thunkPosition
+				if !p.rules[ruleSp]() {
+					goto l1204
+				}
+				if !matchChar('[') {
+					goto l1204
+				}
+			l1206:
+				{
+					position1207, thunkPosition1207 := position, thunkPosition
+					if peekChar('[') {
+						goto l1207
+					}
+					if peekChar(']') {
+						goto l1207
+					}
+					{
+						position1208, thunkPosition1208 := position, thunkPosition
+						if !p.rules[ruleNewline]() {
+							goto l1208
+						}
+						goto l1207
+					l1208:
+						position, thunkPosition = position1208, thunkPosition1208
+					}
+					if !matchDot() {
+						goto l1207
+					}
+					goto l1206
+				l1207:
+					position, thunkPosition = position1207, thunkPosition1207
+				}
+				if !matchChar(']') {
+					goto l1204
+				}
+				if peekChar('(') {
+					goto l1204
+				}
+				goto l1205
+			l1204:
+				position, thunkPosition = position1204, thunkPosition1204
+			}
+		l1205:
+			end = position
+			do(126)
+			return true
+		l1203:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 222 CitationKey <- ([A-Za-z0-9_] ([A-Za-z0-9_] / (([:.#$%&+?<>~/] / '-') &[A-Za-z0-9_]))*) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !matchClass(14) {
+				goto l1209
+			}
+		l1210:
+			{
+				position1211, thunkPosition1211 := position, thunkPosition
+				{
+					position1212, thunkPosition1212 := position, thunkPosition
+					if !matchClass(14) {
+						goto l1213
+					}
+					goto l1212
+				l1213:
+					position, thunkPosition = position1212, thunkPosition1212
+					{
+						position1214, thunkPosition1214 := position, thunkPosition
+						if !matchClass(15) {
+							goto l1215
+						}
+						goto l1214
+					l1215:
+						position, thunkPosition = position1214, thunkPosition1214
+						if !matchChar('-') {
+							goto l1211
+						}
+					}
+				l1214:
+					{
+						position1216, thunkPosition1216 := position, thunkPosition
+						if !matchClass(14) {
+							goto l1211
+						}
+						position, thunkPosition = position1216, thunkPosition1216
+					}
+				}
+			l1212:
+				goto l1210
+			l1211:
+				position, thunkPosition = position1211, thunkPosition1211
+			}
+			return true
+		l1209:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 223 ReferenceLink <- (ReferenceLinkDouble / ReferenceLinkSingle) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1218, thunkPosition1218 := position, thunkPosition
+				if !p.rules[ruleReferenceLinkDouble]() {
+					goto l1219
+				}
+				goto l1218
+			l1219:
+				position, thunkPosition = position1218, thunkPosition1218
+				if !p.rules[ruleReferenceLinkSingle]() {
+					goto l1217
+				}
+			}
+		l1218:
+			return true
+		l1217:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 224 ReferenceLinkDouble <- (Label < Spnl > !'[]' Label {
+                           if match, found := p.findReference(b.children); found {
+                               yy = mk_link(a.children, match.url, match.title);
+                               yy.contents.link.ref = match
+                               a = nil
+                               b = nil
+                           } else {
+                               result := mk_element(LIST)
+                               p.problem(result, UndefinedReference, "undefined reference [" + plainText(b.children) + "]")
+                               result.children = cons(mk_str("["), cons(a, cons(mk_str("]"), cons(mk_str(yytext),
+                                                   cons(mk_str("["), cons(b, mk_str("]")))))))
+                               yy = result
+                           }
+                       }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 2)
+			if !p.rules[ruleLabel]() {
+				goto l1220
+			}
+			doarg(yySet, -1)
+			begin = position
+			if !p.rules[ruleSpnl]() {
+				goto l1220
+			}
+			end = position
+			{
+				position1221, thunkPosition1221 := position, thunkPosition
+				if !matchString("[]") {
+					goto l1221
+				}
+				goto l1220
+			l1221:
+				position, thunkPosition = position1221, thunkPosition1221
+			}
+			if !p.rules[ruleLabel]() {
+				goto l1220
+			}
+			doarg(yySet, -2)
+			do(127)
+			doarg(yyPop, 2)
+			return true
+		l1220:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 225 ReferenceLinkSingle <- (Label < (Spnl '[]')? > {
+                           if match, found := p.findReference(a.children); found {
+                               yy = mk_link(a.children, match.url, match.title)
+                               yy.contents.link.ref = match
+                               a = nil
+                           } else {
+                               result := mk_element(LIST)
+                               if strings.HasSuffix(yytext, "[]") {
+                                   p.problem(result, UndefinedReference, "undefined reference [" + plainText(a.children) + "]")
+                               }
+                               result.children = cons(mk_str("["), cons(a, cons(mk_str("]"), mk_str(yytext))));
+                               yy = result
+                           }
+                       }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 1)
+			if !p.rules[ruleLabel]() {
+				goto l1222
+			}
+			doarg(yySet, -1)
+			begin = position
+			{
+				position1223, thunkPosition1223 := position, thunkPosition
+				if !p.rules[ruleSpnl]() {
+					goto l1223
+				}
+				if !matchString("[]") {
+					goto l1223
+				}
+				goto l1224
+			l1223:
+				position, thunkPosition = position1223, thunkPosition1223
+			}
+		l1224:
+			end = position
+			do(128)
+			doarg(yyPop, 1)
+			return true
+		l1222:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 226 ExplicitLink <- (Label Spnl '(' Sp Source Spnl Title Sp ')' (AttributeBlock / Nothing) { yy = mk_link(l.children, p.safeURL(s.contents.str), t.contents.str)
+                  p.checkURL(yy, s.contents.str)
+                  p.setAttributes(yy, a)
+                  s = nil
+                  t = nil
+                  l = nil
+                  a = nil }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 4)
+			if !p.rules[ruleLabel]() {
+				goto l1225
+			}
+			doarg(yySet, -1)
+			if !p.rules[ruleSpnl]() {
+				goto l1225
+			}
+			if !matchChar('(') {
+				goto l1225
+			}
+			if !p.rules[ruleSp]() {
+				goto l1225
+			}
+			if !p.rules[ruleSource]() {
+				goto l1225
+			}
+			doarg(yySet, -2)
+			if !p.rules[ruleSpnl]() {
+				goto l1225
+			}
+			if !p.rules[ruleTitle]() {
+				goto l1225
+			}
+			doarg(yySet, -3)
+			if !p.rules[ruleSp]() {
+				goto l1225
+			}
+			if !matchChar(')') {
+				goto l1225
+			}
+			{
+				position1226, thunkPosition1226 := position, thunkPosition
+				if !p.rules[ruleAttributeBlock]() {
+					goto l1227
+				}
+				doarg(yySet, -4)
+				goto l1226
+			l1227:
+				position, thunkPosition = position1226, thunkPosition1226
+				if !p.rules[ruleNothing]() {
+					goto l1225
+				}
+				doarg(yySet, -4)
+			}
+		l1226:
+			do(129)
+			doarg(yyPop, 4)
+			return true
+		l1225:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 227 Source <- ((('<' < SourceContents > '>') / (< SourceContents >)) { yy = mk_str(yytext) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1229, thunkPosition1229 := position, thunkPosition
+				if !matchChar('<') {
+					goto l1230
+				}
+				begin = position
+				if !p.rules[ruleSourceContents]() {
+					goto l1230
+				}
+				end = position
+				if !matchChar('>') {
+					goto l1230
+				}
+				goto l1229
+			l1230:
+				position, thunkPosition = position1229, thunkPosition1229
+				begin = position
+				if !p.rules[ruleSourceContents]() {
+					goto l1228
+				}
+				end = position
+			}
+		l1229:
+			do(130)
+			return true
+		l1228:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 228 SourceContents <- (((!'(' !')' !'>' Nonspacechar)+ / ('(' SourceContents ')'))* / '') */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1232, thunkPosition1232 := position, thunkPosition
+			l1234:
+				{
+					position1235, thunkPosition1235 := position, thunkPosition
+					{
+						position1236, thunkPosition1236 := position, thunkPosition
+						if peekChar('(') {
+							goto l1237
 						}
-						goto l1114
-					l1115:
-						position, thunkPosition = position1114, thunkPosition1114
-						{
-							position1119, thunkPosition1119 := position, thunkPosition
-							if !p.rules[ruleTicks4]() {
-								goto l1119
-							}
-							goto l1118
-						l1119:
-							position, thunkPosition = position1119, thunkPosition1119
+						if peekChar(')') {
+							goto l1237
 						}
-						if !matchChar('`') {
-							goto l1118
+						if peekChar('>') {
+							goto l1237
+						}
+						if !p.rules[ruleNonspacechar]() {
+							goto l1237
 						}
-					l1120:
+					l1238:
 						{
-							position1121, thunkPosition1121 := position, thunkPosition
-							if !matchChar('`') {
-								goto l1121
+							position1239, thunkPosition1239 := position, thunkPosition
+							if peekChar('(') {
+								goto l1239
+							}
+							if peekChar(')') {
+								goto l1239
+							}
+							if peekChar('>') {
+								goto l1239
+							}
+							if !p.rules[ruleNonspacechar]() {
+								goto l1239
 							}
-							goto l1120
-						l1121:
-							position, thunkPosition = position1121, thunkPosition1121
+							goto l1238
+						l1239:
+							position, thunkPosition = position1239, thunkPosition1239
+						}
+						goto l1236
+					l1237:
+						position, thunkPosition = position1236, thunkPosition1236
+						if !matchChar('(') {
+							goto l1235
+						}
+						if !p.rules[ruleSourceContents]() {
+							goto l1235
+						}
+						if !matchChar(')') {
+							goto l1235
+						}
+					}
+				l1236:
+					goto l1234
+				l1235:
+					position, thunkPosition = position1235, thunkPosition1235
+				}
+				goto l1232
+			l1233:
+				position, thunkPosition = position1232, thunkPosition1232
+				if !matchString("") {
+					goto l1231
+				}
+			}
+		l1232:
+			return true
+		l1231:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 229 Title <- ((TitleSingle / TitleDouble / (< '' >)) { yy = mk_str(yytext) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1241, thunkPosition1241 := position, thunkPosition
+				if !p.rules[ruleTitleSingle]() {
+					goto l1242
+				}
+				goto l1241
+			l1242:
+				position, thunkPosition = position1241, thunkPosition1241
+				if !p.rules[ruleTitleDouble]() {
+					goto l1243
+				}
+				goto l1241
+			l1243:
+				position, thunkPosition = position1241, thunkPosition1241
+				begin = position
+				if !matchString("") {
+					goto l1240
+				}
+				end = position
+			}
+		l1241:
+			do(131)
+			return true
+		l1240:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 230 TitleSingle <- ('\'' < (!('\'' Sp (')' / Newline)) .)* > '\'') */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('\'') {
+				goto l1244
+			}
+			begin = position
+		l1245:
+			{
+				position1246, thunkPosition1246 := position, thunkPosition
+				{
+					position1247, thunkPosition1247 := position, thunkPosition
+					if !matchChar('\'') {
+						goto l1247
+					}
+					if !p.rules[ruleSp]() {
+						goto l1247
+					}
+					{
+						position1248, thunkPosition1248 := position, thunkPosition
+						if !matchChar(')') {
+							goto l1249
+						}
+						goto l1248
+					l1249:
+						position, thunkPosition = position1248, thunkPosition1248
+						if !p.rules[ruleNewline]() {
+							goto l1247
+						}
+					}
+				l1248:
+					goto l1246
+				l1247:
+					position, thunkPosition = position1247, thunkPosition1247
+				}
+				if !matchDot() {
+					goto l1246
+				}
+				goto l1245
+			l1246:
+				position, thunkPosition = position1246, thunkPosition1246
+			}
+			end = position
+			if !matchChar('\'') {
+				goto l1244
+			}
+			return true
+		l1244:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 231 TitleDouble <- ('"' < (!('"' Sp (')' / Newline)) .)* > '"') */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('"') {
+				goto l1250
+			}
+			begin = position
+		l1251:
+			{
+				position1252, thunkPosition1252 := position, thunkPosition
+				{
+					position1253, thunkPosition1253 := position, thunkPosition
+					if !matchChar('"') {
+						goto l1253
+					}
+					if !p.rules[ruleSp]() {
+						goto l1253
+					}
+					{
+						position1254, thunkPosition1254 := position, thunkPosition
+						if !matchChar(')') {
+							goto l1255
+						}
+						goto l1254
+					l1255:
+						position, thunkPosition = position1254, thunkPosition1254
+						if !p.rules[ruleNewline]() {
+							goto l1253
 						}
-						goto l1114
-					l1118:
-						position, thunkPosition = position1114, thunkPosition1114
-						{
-							position1122, thunkPosition1122 := position, thunkPosition
-							if !p.rules[ruleSp]() {
-								goto l1122
-							}
-							if !p.rules[ruleTicks4]() {
-								goto l1122
-							}
-							goto l1101
-						l1122:
-							position, thunkPosition = position1122, thunkPosition1122
+					}
+				l1254:
+					goto l1252
+				l1253:
+					position, thunkPosition = position1253, thunkPosition1253
+				}
+				if !matchDot() {
+					goto l1252
+				}
+				goto l1251
+			l1252:
+				position, thunkPosition = position1252, thunkPosition1252
+			}
+			end = position
+			if !matchChar('"') {
+				goto l1250
+			}
+			return true
+		l1250:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 232 AutoLink <- (AutoLinkUrl / AutoLinkEmail) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1257, thunkPosition1257 := position, thunkPosition
+				if !p.rules[ruleAutoLinkUrl]() {
+					goto l1258
+				}
+				goto l1257
+			l1258:
+				position, thunkPosition = position1257, thunkPosition1257
+				if !p.rules[ruleAutoLinkEmail]() {
+					goto l1256
+				}
+			}
+		l1257:
+			return true
+		l1256:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 233 AutoLinkUrl <- ('<' < [A-Za-z]+ '://' (!Newline !'>' .)+ > '>' {   yy = mk_link(mk_str(yytext), p.safeURL(yytext), "") }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('<') {
+				goto l1259
+			}
+			begin = position
+			if !matchClass(4) {
+				goto l1259
+			}
+		l1260:
+			{
+				position1261, thunkPosition1261 := position, thunkPosition
+				if !matchClass(4) {
+					goto l1261
+				}
+				goto l1260
+			l1261:
+				position, thunkPosition = position1261, thunkPosition1261
+			}
+			if !matchString("://") {
+				goto l1259
+			}
+			{
+				position1264, thunkPosition1264 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l1264
+				}
+				goto l1259
+			l1264:
+				position, thunkPosition = position1264, thunkPosition1264
+			}
+			if peekChar('>') {
+				goto l1259
+			}
+			if !matchDot() {
+				goto l1259
+			}
+		l1262:
+			{
+				position1263, thunkPosition1263 := position, thunkPosition
+				{
+					position1265, thunkPosition1265 := position, thunkPosition
+					if !p.rules[ruleNewline]() {
+						goto l1265
+					}
+					goto l1263
+				l1265:
+					position, thunkPosition = position1265, thunkPosition1265
+				}
+				if peekChar('>') {
+					goto l1263
+				}
+				if !matchDot() {
+					goto l1263
+				}
+				goto l1262
+			l1263:
+				position, thunkPosition = position1263, thunkPosition1263
+			}
+			end = position
+			if !matchChar('>') {
+				goto l1259
+			}
+			do(132)
+			return true
+		l1259:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 234 BareLink <- (&{ p.extension.Autolink && p.labels == 0 } (BareUrl / BareWww / BareEmail)) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !( p.extension.Autolink && p.labels == 0 ) {
+				goto l1266
+			}
+			{
+				position1267, thunkPosition1267 := position, thunkPosition
+				if !p.rules[ruleBareUrl]() {
+					goto l1268
+				}
+				goto l1267
+			l1268:
+				position, thunkPosition = position1267, thunkPosition1267
+				if !p.rules[ruleBareWww]() {
+					goto l1269
+				}
+				goto l1267
+			l1269:
+				position, thunkPosition = position1267, thunkPosition1267
+				if !p.rules[ruleBareEmail]() {
+					goto l1266
+				}
+			}
+		l1267:
+			return true
+		l1266:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 235 BareUrl <- (< ('http://' / 'https://' / 'ftp://') (UrlParens / (!UrlEnd UrlChar))+ > {   yy = mk_link(mk_str(yytext), p.safeURL(yytext), "") }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			begin = position
+			{
+				position1271, thunkPosition1271 := position, thunkPosition
+				if !matchString("http://") {
+					goto l1272
+				}
+				goto l1271
+			l1272:
+				position, thunkPosition = position1271, thunkPosition1271
+				if !matchString("https://") {
+					goto l1273
+				}
+				goto l1271
+			l1273:
+				position, thunkPosition = position1271, thunkPosition1271
+				if !matchString("ftp://") {
+					goto l1270
+				}
+			}
+		l1271:
+			{
+				position1276, thunkPosition1276 := position, thunkPosition
+				if !p.rules[ruleUrlParens]() {
+					goto l1277
+				}
+				goto l1276
+			l1277:
+				position, thunkPosition = position1276, thunkPosition1276
+				{
+					position1278, thunkPosition1278 := position, thunkPosition
+					if !p.rules[ruleUrlEnd]() {
+						goto l1278
+					}
+					goto l1270
+				l1278:
+					position, thunkPosition = position1278, thunkPosition1278
+				}
+				if !p.rules[ruleUrlChar]() {
+					goto l1270
+				}
+			}
+		l1276:
+		l1274:
+			{
+				position1275, thunkPosition1275 := position, thunkPosition
+				{
+					position1279, thunkPosition1279 := position, thunkPosition
+					if !p.rules[ruleUrlParens]() {
+						goto l1280
+					}
+					goto l1279
+				l1280:
+					position, thunkPosition = position1279, thunkPosition1279
+					{
+						position1281, thunkPosition1281 := position, thunkPosition
+						if !p.rules[ruleUrlEnd]() {
+							goto l1281
 						}
-						{
-							position1123, thunkPosition1123 := position, thunkPosition
-							if !p.rules[ruleSpacechar]() {
-								goto l1124
-							}
-							goto l1123
-						l1124:
-							position, thunkPosition = position1123, thunkPosition1123
-							if !p.rules[ruleNewline]() {
-								goto l1101
-							}
-							{
-								position1125, thunkPosition1125 := position, thunkPosition
-								if !p.rules[ruleBlankLine]() {
-									goto l1125
-								}
-								goto l1101
-							l1125:
-								position, thunkPosition = position1125, thunkPosition1125
-							}
+						goto l1275
+					l1281:
+						position, thunkPosition = position1281, thunkPosition1281
+					}
+					if !p.rules[ruleUrlChar]() {
+						goto l1275
+					}
+				}
+			l1279:
+				goto l1274
+			l1275:
+				position, thunkPosition = position1275, thunkPosition1275
+			}
+			end = position
+			do(133)
+			return true
+		l1270:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 236 BareWww <- (< 'www.' (UrlParens / (!UrlEnd UrlChar))+ > {   yy = mk_link(mk_str(yytext), "http://"+yytext, "") }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			begin = position
+			if !matchString("www.") {
+				goto l1282
+			}
+			{
+				position1285, thunkPosition1285 := position, thunkPosition
+				if !p.rules[ruleUrlParens]() {
+					goto l1286
+				}
+				goto l1285
+			l1286:
+				position, thunkPosition = position1285, thunkPosition1285
+				{
+					position1287, thunkPosition1287 := position, thunkPosition
+					if !p.rules[ruleUrlEnd]() {
+						goto l1287
+					}
+					goto l1282
+				l1287:
+					position, thunkPosition = position1287, thunkPosition1287
+				}
+				if !p.rules[ruleUrlChar]() {
+					goto l1282
+				}
+			}
+		l1285:
+		l1283:
+			{
+				position1284, thunkPosition1284 := position, thunkPosition
+				{
+					position1288, thunkPosition1288 := position, thunkPosition
+					if !p.rules[ruleUrlParens]() {
+						goto l1289
+					}
+					goto l1288
+				l1289:
+					position, thunkPosition = position1288, thunkPosition1288
+					{
+						position1290, thunkPosition1290 := position, thunkPosition
+						if !p.rules[ruleUrlEnd]() {
+							goto l1290
 						}
-					l1123:
+						goto l1284
+					l1290:
+						position, thunkPosition = position1290, thunkPosition1290
 					}
-				l1114:
-					goto l1100
-				l1101:
-					position, thunkPosition = position1101, thunkPosition1101
+					if !p.rules[ruleUrlChar]() {
+						goto l1284
+					}
+				}
+			l1288:
+				goto l1283
+			l1284:
+				position, thunkPosition = position1284, thunkPosition1284
+			}
+			end = position
+			do(134)
+			return true
+		l1282:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 237 BareEmail <- (< [-A-Za-z0-9+_.]+ '@' [-A-Za-z0-9]+ ('.' [-A-Za-z0-9]+)+ > {   yy = mk_link(mk_str(yytext), "mailto:"+yytext, "") }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			begin = position
+			if !matchClass(16) {
+				goto l1291
+			}
+		l1292:
+			{
+				position1293, thunkPosition1293 := position, thunkPosition
+				if !matchClass(16) {
+					goto l1293
+				}
+				goto l1292
+			l1293:
+				position, thunkPosition = position1293, thunkPosition1293
+			}
+			if !matchChar('@') {
+				goto l1291
+			}
+			if !matchClass(17) {
+				goto l1291
+			}
+		l1294:
+			{
+				position1295, thunkPosition1295 := position, thunkPosition
+				if !matchClass(17) {
+					goto l1295
+				}
+				goto l1294
+			l1295:
+				position, thunkPosition = position1295, thunkPosition1295
+			}
+			if !matchChar('.') {
+				goto l1291
+			}
+			if !matchClass(17) {
+				goto l1291
+			}
+		l1298:
+			{
+				position1299, thunkPosition1299 := position, thunkPosition
+				if !matchClass(17) {
+					goto l1299
+				}
+				goto l1298
+			l1299:
+				position, thunkPosition = position1299, thunkPosition1299
+			}
+		l1296:
+			{
+				position1297, thunkPosition1297 := position, thunkPosition
+				if !matchChar('.') {
+					goto l1297
+				}
+				if !matchClass(17) {
+					goto l1297
+				}
+			l1300:
+				{
+					position1301, thunkPosition1301 := position, thunkPosition
+					if !matchClass(17) {
+						goto l1301
+					}
+					goto l1300
+				l1301:
+					position, thunkPosition = position1301, thunkPosition1301
+				}
+				goto l1296
+			l1297:
+				position, thunkPosition = position1297, thunkPosition1297
+			}
+			end = position
+			do(135)
+			return true
+		l1291:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 238 UrlChar <- (!Spacechar !Newline !'<' !'>' !'[' !']' .) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1303, thunkPosition1303 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l1303
+				}
+				goto l1302
+			l1303:
+				position, thunkPosition = position1303, thunkPosition1303
+			}
+			{
+				position1304, thunkPosition1304 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l1304
+				}
+				goto l1302
+			l1304:
+				position, thunkPosition = position1304, thunkPosition1304
+			}
+			if peekChar('<') {
+				goto l1302
+			}
+			if peekChar('>') {
+				goto l1302
+			}
+			if peekChar('[') {
+				goto l1302
+			}
+			if peekChar(']') {
+				goto l1302
+			}
+			if !matchDot() {
+				goto l1302
+			}
+			return true
+		l1302:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 239 UrlEnd <- ([.,:;!?)"']* (Spacechar / Newline / '<' / '[' / ']' / Eof)) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+		l1306:
+			{
+				position1307, thunkPosition1307 := position, thunkPosition
+				if !matchClass(18) {
+					goto l1307
+				}
+				goto l1306
+			l1307:
+				position, thunkPosition = position1307, thunkPosition1307
+			}
+			{
+				position1308, thunkPosition1308 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l1309
 				}
-				end = position
-				if !p.rules[ruleSp]() {
-					goto l1099
+				goto l1308
+			l1309:
+				position, thunkPosition = position1308, thunkPosition1308
+				if !p.rules[ruleNewline]() {
+					goto l1310
 				}
-				if !p.rules[ruleTicks4]() {
-					goto l1099
+				goto l1308
+			l1310:
+				position, thunkPosition = position1308, thunkPosition1308
+				if !matchChar('<') {
+					goto l1311
 				}
-				goto l1017
-			l1099:
-				position, thunkPosition = position1017, thunkPosition1017
-				if !p.rules[ruleTicks5]() {
-					goto l1016
+				goto l1308
+			l1311:
+				position, thunkPosition = position1308, thunkPosition1308
+				if !matchChar('[') {
+					goto l1312
 				}
-				if !p.rules[ruleSp]() {
-					goto l1016
+				goto l1308
+			l1312:
+				position, thunkPosition = position1308, thunkPosition1308
+				if !matchChar(']') {
+					goto l1313
+				}
+				goto l1308
+			l1313:
+				position, thunkPosition = position1308, thunkPosition1308
+				if !p.rules[ruleEof]() {
+					goto l1305
+				}
+			}
+		l1308:
+			return true
+		l1305:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 240 UrlParens <- ('(' (!'(' !')' !UrlEnd UrlChar)* ')') */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('(') {
+				goto l1314
+			}
+		l1315:
+			{
+				position1316, thunkPosition1316 := position, thunkPosition
+				if peekChar('(') {
+					goto l1316
+				}
+				if peekChar(')') {
+					goto l1316
 				}
-				begin = position
 				{
-					position1128, thunkPosition1128 := position, thunkPosition
-					if peekChar('`') {
-						goto l1129
-					}
-					if !p.rules[ruleNonspacechar]() {
-						goto l1129
-					}
-				l1130:
-					{
-						position1131, thunkPosition1131 := position, thunkPosition
-						if peekChar('`') {
-							goto l1131
-						}
-						if !p.rules[ruleNonspacechar]() {
-							goto l1131
-						}
-						goto l1130
-					l1131:
-						position, thunkPosition = position1131, thunkPosition1131
-					}
-					goto l1128
-				l1129:
-					position, thunkPosition = position1128, thunkPosition1128
-					{
-						position1133, thunkPosition1133 := position, thunkPosition
-						if !p.rules[ruleTicks5]() {
-							goto l1133
-						}
-						goto l1132
-					l1133:
-						position, thunkPosition = position1133, thunkPosition1133
-					}
-					if !matchChar('`') {
-						goto l1132
-					}
-				l1134:
-					{
-						position1135, thunkPosition1135 := position, thunkPosition
-						if !matchChar('`') {
-							goto l1135
-						}
-						goto l1134
-					l1135:
-						position, thunkPosition = position1135, thunkPosition1135
-					}
-					goto l1128
-				l1132:
-					position, thunkPosition = position1128, thunkPosition1128
-					{
-						position1136, thunkPosition1136 := position, thunkPosition
-						if !p.rules[ruleSp]() {
-							goto l1136
-						}
-						if !p.rules[ruleTicks5]() {
-							goto l1136
-						}
-						goto l1016
-					l1136:
-						position, thunkPosition = position1136, thunkPosition1136
-					}
-					{
-						position1137, thunkPosition1137 := position, thunkPosition
-						if !p.rules[ruleSpacechar]() {
-							goto l1138
-						}
-						goto l1137
-					l1138:
-						position, thunkPosition = position1137, thunkPosition1137
-						if !p.rules[ruleNewline]() {
-							goto l1016
-						}
-						{
-							position1139, thunkPosition1139 := position, thunkPosition
-							if !p.rules[ruleBlankLine]() {
-								goto l1139
-							}
-							goto l1016
-						l1139:
-							position, thunkPosition = position1139, thunkPosition1139
-						}
+					position1317, thunkPosition1317 := position, thunkPosition
+					if !p.rules[ruleUrlEnd]() {
+						goto l1317
 					}
-				l1137:
+					goto l1316
+				l1317:
+					position, thunkPosition = position1317, thunkPosition1317
 				}
-			l1128:
-			l1126:
+				if !p.rules[ruleUrlChar]() {
+					goto l1316
+				}
+				goto l1315
+			l1316:
+				position, thunkPosition = position1316, thunkPosition1316
+			}
+			if !matchChar(')') {
+				goto l1314
+			}
+			return true
+		l1314:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 241 AutoLinkEmail <- ('<' < [-A-Za-z0-9+_]+ '@' (!Newline !'>' .)+ > '>' {
+                    yy = mk_link(mk_str(yytext), "mailto:"+yytext, "")
+                }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('<') {
+				goto l1318
+			}
+			begin = position
+			if !matchClass(9) {
+				goto l1318
+			}
+		l1319:
+			{
+				position1320, thunkPosition1320 := position, thunkPosition
+				if !matchClass(9) {
+					goto l1320
+				}
+				goto l1319
+			l1320:
+				position, thunkPosition = position1320, thunkPosition1320
+			}
+			if !matchChar('@') {
+				goto l1318
+			}
+			{
+				position1323, thunkPosition1323 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l1323
+				}
+				goto l1318
+			l1323:
+				position, thunkPosition = position1323, thunkPosition1323
+			}
+			if peekChar('>') {
+				goto l1318
+			}
+			if !matchDot() {
+				goto l1318
+			}
+		l1321:
+			{
+				position1322, thunkPosition1322 := position, thunkPosition
 				{
-					position1127, thunkPosition1127 := position, thunkPosition
-					{
-						position1140, thunkPosition1140 := position, thunkPosition
-						if peekChar('`') {
-							goto l1141
-						}
-						if !p.rules[ruleNonspacechar]() {
-							goto l1141
-						}
-					l1142:
-						{
-							position1143, thunkPosition1143 := position, thunkPosition
-							if peekChar('`') {
-								goto l1143
-							}
-							if !p.rules[ruleNonspacechar]() {
-								goto l1143
-							}
-							goto l1142
-						l1143:
-							position, thunkPosition = position1143, thunkPosition1143
-						}
-						goto l1140
-					l1141:
-						position, thunkPosition = position1140, thunkPosition1140
-						{
-							position1145, thunkPosition1145 := position, thunkPosition
-							if !p.rules[ruleTicks5]() {
-								goto l1145
-							}
-							goto l1144
-						l1145:
-							position, thunkPosition = position1145, thunkPosition1145
-						}
-						if !matchChar('`') {
-							goto l1144
-						}
-					l1146:
-						{
-							position1147, thunkPosition1147 := position, thunkPosition
-							if !matchChar('`') {
-								goto l1147
-							}
-							goto l1146
-						l1147:
-							position, thunkPosition = position1147, thunkPosition1147
-						}
-						goto l1140
-					l1144:
-						position, thunkPosition = position1140, thunkPosition1140
-						{
-							position1148, thunkPosition1148 := position, thunkPosition
-							if !p.rules[ruleSp]() {
-								goto l1148
-							}
-							if !p.rules[ruleTicks5]() {
-								goto l1148
-							}
-							goto l1127
-						l1148:
-							position, thunkPosition = position1148, thunkPosition1148
-						}
-						{
-							position1149, thunkPosition1149 := position, thunkPosition
-							if !p.rules[ruleSpacechar]() {
-								goto l1150
-							}
-							goto l1149
-						l1150:
-							position, thunkPosition = position1149, thunkPosition1149
-							if !p.rules[ruleNewline]() {
-								goto l1127
-							}
-							{
-								position1151, thunkPosition1151 := position, thunkPosition
-								if !p.rules[ruleBlankLine]() {
-									goto l1151
-								}
-								goto l1127
-							l1151:
-								position, thunkPosition = position1151, thunkPosition1151
-							}
-						}
-					l1149:
+					position1324, thunkPosition1324 := position, thunkPosition
+					if !p.rules[ruleNewline]() {
+						goto l1324
 					}
-				l1140:
-					goto l1126
-				l1127:
-					position, thunkPosition = position1127, thunkPosition1127
+					goto l1322
+				l1324:
+					position, thunkPosition = position1324, thunkPosition1324
 				}
-				end = position
-				if !p.rules[ruleSp]() {
-					goto l1016
+				if peekChar('>') {
+					goto l1322
 				}
-				if !p.rules[ruleTicks5]() {
-					goto l1016
+				if !matchDot() {
+					goto l1322
+				}
+				goto l1321
+			l1322:
+				position, thunkPosition = position1322, thunkPosition1322
+			}
+			end = position
+			if !matchChar('>') {
+				goto l1318
+			}
+			do(136)
+			return true
+		l1318:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 242 Reference <- (NonindentSpace !'[]' Label ':' Spnl RefSrc Spnl RefTitle BlankLine* { yy = mk_link(l.children, p.safeURL(s.contents.str), t.contents.str)
+              p.checkURL(yy, s.contents.str)
+              s = nil
+              t = nil
+              l = nil
+              yy.key = REFERENCE }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 3)
+			if !p.rules[ruleNonindentSpace]() {
+				goto l1325
+			}
+			{
+				position1326, thunkPosition1326 := position, thunkPosition
+				if !matchString("[]") {
+					goto l1326
+				}
+				goto l1325
+			l1326:
+				position, thunkPosition = position1326, thunkPosition1326
+			}
+			if !p.rules[ruleLabel]() {
+				goto l1325
+			}
+			doarg(yySet, -2)
+			if !matchChar(':') {
+				goto l1325
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l1325
+			}
+			if !p.rules[ruleRefSrc]() {
+				goto l1325
+			}
+			doarg(yySet, -1)
+			if !p.rules[ruleSpnl]() {
+				goto l1325
+			}
+			if !p.rules[ruleRefTitle]() {
+				goto l1325
+			}
+			doarg(yySet, -3)
+		l1327:
+			{
+				position1328, thunkPosition1328 := position, thunkPosition
+				if !p.rules[ruleBlankLine]() {
+					goto l1328
+				}
+				goto l1327
+			l1328:
+				position, thunkPosition = position1328, thunkPosition1328
+			}
+			do(137)
+			doarg(yyPop, 3)
+			return true
+		l1325:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 243 Label <- ('[' ((!'^' &{ p.extension.Notes }) / (&. &{ !p.extension.Notes })) StartList &{ p.enterLabel() } (!']' Inline { a = cons(yy, a) })* &{ p.leaveLabel() } ']' { yy = mk_list(LIST, a) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 1)
+			if !matchChar('[') {
+				goto l1329
+			}
+			{
+				position1330, thunkPosition1330 := position, thunkPosition
+				if peekChar('^') {
+					goto l1331
+				}
+				if !( p.extension.Notes ) {
+					goto l1331
+				}
+				goto l1330
+			l1331:
+				position, thunkPosition = position1330, thunkPosition1330
+				if !peekDot() {
+					goto l1329
+				}
+				if !( !p.extension.Notes ) {
+					goto l1329
+				}
+			}
+		l1330:
+			if !p.rules[ruleStartList]() {
+				goto l1329
+			}
+			doarg(yySet, -1)
+			if !( p.enterLabel() ) {
+				goto l1329
+			}
+		l1332:
+			{
+				position1333, thunkPosition1333 := position, thunkPosition
+				if peekChar(']') {
+					goto l1333
+				}
+				if !p.rules[ruleInline]() {
+					goto l1333
+				}
+				do(138)
+				goto l1332
+			l1333:
+				position, thunkPosition = position1333, thunkPosition1333
+			}
+			if !( p.leaveLabel() ) {
+				goto l1329
+			}
+			if !matchChar(']') {
+				goto l1329
+			}
+			do(139)
+			doarg(yyPop, 1)
+			return true
+		l1329:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 244 RefSrc <- (< Nonspacechar+ > { yy = mk_str(yytext)
+           yy.key = HTML }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			begin = position
+			if !p.rules[ruleNonspacechar]() {
+				goto l1334
+			}
+		l1335:
+			{
+				position1336, thunkPosition1336 := position, thunkPosition
+				if !p.rules[ruleNonspacechar]() {
+					goto l1336
 				}
+				goto l1335
+			l1336:
+				position, thunkPosition = position1336, thunkPosition1336
 			}
-		l1017:
-			do(85)
+			end = position
+			do(140)
 			return true
-		l1016:
+		l1334:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 194 RawHtml <- (< (HtmlComment / HtmlTag) > {   if p.extension.FilterHTML {
-                    yy = mk_list(LIST, nil)
-                } else {
-                    yy = mk_str(yytext)
-                    yy.key = HTML
-                }
-            }) */
+		/* 245 RefTitle <- ((RefTitleSingle / RefTitleDouble / RefTitleParens / EmptyTitle) { yy = mk_str(yytext) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			begin = position
 			{
-				position1153, thunkPosition1153 := position, thunkPosition
-				if !p.rules[ruleHtmlComment]() {
-					goto l1154
+				position1338, thunkPosition1338 := position, thunkPosition
+				if !p.rules[ruleRefTitleSingle]() {
+					goto l1339
 				}
-				goto l1153
-			l1154:
-				position, thunkPosition = position1153, thunkPosition1153
-				if !p.rules[ruleHtmlTag]() {
-					goto l1152
+				goto l1338
+			l1339:
+				position, thunkPosition = position1338, thunkPosition1338
+				if !p.rules[ruleRefTitleDouble]() {
+					goto l1340
+				}
+				goto l1338
+			l1340:
+				position, thunkPosition = position1338, thunkPosition1338
+				if !p.rules[ruleRefTitleParens]() {
+					goto l1341
+				}
+				goto l1338
+			l1341:
+				position, thunkPosition = position1338, thunkPosition1338
+				if !p.rules[ruleEmptyTitle]() {
+					goto l1337
 				}
 			}
-		l1153:
-			end = position
-			do(86)
+		l1338:
+			do(141)
 			return true
-		l1152:
+		l1337:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 195 BlankLine <- (Sp Newline) */
+		/* 246 EmptyTitle <- (< '' >) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleSp]() {
-				goto l1155
-			}
-			if !p.rules[ruleNewline]() {
-				goto l1155
+			begin = position
+			if !matchString("") {
+				goto l1342
 			}
+			end = position
 			return true
-		l1155:
+		l1342:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 196 Quoted <- (('"' (!'"' .)* '"') / ('\'' (!'\'' .)* '\'')) */
+		/* 247 RefTitleSingle <- ('\'' < (!(('\'' Sp Newline) / Newline) .)* > '\'') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('\'') {
+				goto l1343
+			}
+			begin = position
+		l1344:
 			{
-				position1157, thunkPosition1157 := position, thunkPosition
-				if !matchChar('"') {
-					goto l1158
-				}
-			l1159:
+				position1345, thunkPosition1345 := position, thunkPosition
 				{
-					position1160, thunkPosition1160 := position, thunkPosition
-					if peekChar('"') {
-						goto l1160
-					}
-					if !matchDot() {
-						goto l1160
-					}
-					goto l1159
-				l1160:
-					position, thunkPosition = position1160, thunkPosition1160
-				}
-				if !matchChar('"') {
-					goto l1158
-				}
-				goto l1157
-			l1158:
-				position, thunkPosition = position1157, thunkPosition1157
-				if !matchChar('\'') {
-					goto l1156
-				}
-			l1161:
-				{
-					position1162, thunkPosition1162 := position, thunkPosition
-					if peekChar('\'') {
-						goto l1162
-					}
-					if !matchDot() {
-						goto l1162
+					position1346, thunkPosition1346 := position, thunkPosition
+					{
+						position1347, thunkPosition1347 := position, thunkPosition
+						if !matchChar('\'') {
+							goto l1348
+						}
+						if !p.rules[ruleSp]() {
+							goto l1348
+						}
+						if !p.rules[ruleNewline]() {
+							goto l1348
+						}
+						goto l1347
+					l1348:
+						position, thunkPosition = position1347, thunkPosition1347
+						if !p.rules[ruleNewline]() {
+							goto l1346
+						}
 					}
-					goto l1161
-				l1162:
-					position, thunkPosition = position1162, thunkPosition1162
+				l1347:
+					goto l1345
+				l1346:
+					position, thunkPosition = position1346, thunkPosition1346
 				}
-				if !matchChar('\'') {
-					goto l1156
+				if !matchDot() {
+					goto l1345
 				}
+				goto l1344
+			l1345:
+				position, thunkPosition = position1345, thunkPosition1345
+			}
+			end = position
+			if !matchChar('\'') {
+				goto l1343
 			}
-		l1157:
 			return true
-		l1156:
+		l1343:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 197 HtmlAttribute <- ((AlphanumericAscii / '-')+ Spnl ('=' Spnl (Quoted / (!'>' Nonspacechar)+))? Spnl) */
+		/* 248 RefTitleDouble <- ('"' < (!(('"' Sp Newline) / Newline) .)* > '"') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			{
-				position1166, thunkPosition1166 := position, thunkPosition
-				if !p.rules[ruleAlphanumericAscii]() {
-					goto l1167
-				}
-				goto l1166
-			l1167:
-				position, thunkPosition = position1166, thunkPosition1166
-				if !matchChar('-') {
-					goto l1163
-				}
-			}
-		l1166:
-		l1164:
-			{
-				position1165, thunkPosition1165 := position, thunkPosition
-				{
-					position1168, thunkPosition1168 := position, thunkPosition
-					if !p.rules[ruleAlphanumericAscii]() {
-						goto l1169
-					}
-					goto l1168
-				l1169:
-					position, thunkPosition = position1168, thunkPosition1168
-					if !matchChar('-') {
-						goto l1165
-					}
-				}
-			l1168:
-				goto l1164
-			l1165:
-				position, thunkPosition = position1165, thunkPosition1165
-			}
-			if !p.rules[ruleSpnl]() {
-				goto l1163
+			if !matchChar('"') {
+				goto l1349
 			}
+			begin = position
+		l1350:
 			{
-				position1170, thunkPosition1170 := position, thunkPosition
-				if !matchChar('=') {
-					goto l1170
-				}
-				if !p.rules[ruleSpnl]() {
-					goto l1170
-				}
+				position1351, thunkPosition1351 := position, thunkPosition
 				{
-					position1172, thunkPosition1172 := position, thunkPosition
-					if !p.rules[ruleQuoted]() {
-						goto l1173
-					}
-					goto l1172
-				l1173:
-					position, thunkPosition = position1172, thunkPosition1172
-					if peekChar('>') {
-						goto l1170
-					}
-					if !p.rules[ruleNonspacechar]() {
-						goto l1170
-					}
-				l1174:
+					position1352, thunkPosition1352 := position, thunkPosition
 					{
-						position1175, thunkPosition1175 := position, thunkPosition
-						if peekChar('>') {
-							goto l1175
+						position1353, thunkPosition1353 := position, thunkPosition
+						if !matchChar('"') {
+							goto l1354
 						}
-						if !p.rules[ruleNonspacechar]() {
-							goto l1175
+						if !p.rules[ruleSp]() {
+							goto l1354
+						}
+						if !p.rules[ruleNewline]() {
+							goto l1354
+						}
+						goto l1353
+					l1354:
+						position, thunkPosition = position1353, thunkPosition1353
+						if !p.rules[ruleNewline]() {
+							goto l1352
 						}
-						goto l1174
-					l1175:
-						position, thunkPosition = position1175, thunkPosition1175
 					}
+				l1353:
+					goto l1351
+				l1352:
+					position, thunkPosition = position1352, thunkPosition1352
 				}
-			l1172:
-				goto l1171
-			l1170:
-				position, thunkPosition = position1170, thunkPosition1170
+				if !matchDot() {
+					goto l1351
+				}
+				goto l1350
+			l1351:
+				position, thunkPosition = position1351, thunkPosition1351
 			}
-		l1171:
-			if !p.rules[ruleSpnl]() {
-				goto l1163
+			end = position
+			if !matchChar('"') {
+				goto l1349
 			}
 			return true
-		l1163:
+		l1349:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 198 HtmlComment <- ('<!--' (!'-->' .)* '-->') */
+		/* 249 RefTitleParens <- ('(' < (!((')' Sp Newline) / Newline) .)* > ')') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchString("<!--") {
-				goto l1176
+			if !matchChar('(') {
+				goto l1355
 			}
-		l1177:
+			begin = position
+		l1356:
 			{
-				position1178, thunkPosition1178 := position, thunkPosition
+				position1357, thunkPosition1357 := position, thunkPosition
 				{
-					position1179, thunkPosition1179 := position, thunkPosition
-					if !matchString("-->") {
-						goto l1179
+					position1358, thunkPosition1358 := position, thunkPosition
+					{
+						position1359, thunkPosition1359 := position, thunkPosition
+						if !matchChar(')') {
+							goto l1360
+						}
+						if !p.rules[ruleSp]() {
+							goto l1360
+						}
+						if !p.rules[ruleNewline]() {
+							goto l1360
+						}
+						goto l1359
+					l1360:
+						position, thunkPosition = position1359, thunkPosition1359
+						if !p.rules[ruleNewline]() {
+							goto l1358
+						}
 					}
-					goto l1178
-				l1179:
-					position, thunkPosition = position1179, thunkPosition1179
+				l1359:
+					goto l1357
+				l1358:
+					position, thunkPosition = position1358, thunkPosition1358
 				}
 				if !matchDot() {
-					goto l1178
+					goto l1357
 				}
-				goto l1177
-			l1178:
-				position, thunkPosition = position1178, thunkPosition1178
+				goto l1356
+			l1357:
+				position, thunkPosition = position1357, thunkPosition1357
 			}
-			if !matchString("-->") {
-				goto l1176
+			end = position
+			if !matchChar(')') {
+				goto l1355
 			}
 			return true
-		l1176:
+		l1355:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 199 HtmlTag <- ('<' Spnl '/'? AlphanumericAscii+ Spnl HtmlAttribute* '/'? Spnl '>') */
+		/* 250 References <- (StartList ((Reference { a = cons(b, a) }) / SkipBlock)* { p.references = reverse(a) } commit) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchChar('<') {
-				goto l1180
-			}
-			if !p.rules[ruleSpnl]() {
-				goto l1180
-			}
-			{
-				position1181, thunkPosition1181 := position, thunkPosition
-				if !matchChar('/') {
-					goto l1181
-				}
-				goto l1182
-			l1181:
-				position, thunkPosition = position1181, thunkPosition1181
-			}
-		l1182:
-			if !p.rules[ruleAlphanumericAscii]() {
-				goto l1180
-			}
-		l1183:
-			{
-				position1184, thunkPosition1184 := position, thunkPosition
-				if !p.rules[ruleAlphanumericAscii]() {
-					goto l1184
-				}
-				goto l1183
-			l1184:
-				position, thunkPosition = position1184, thunkPosition1184
-			}
-			if !p.rules[ruleSpnl]() {
-				goto l1180
-			}
-		l1185:
-			{
-				position1186, thunkPosition1186 := position, thunkPosition
-				if !p.rules[ruleHtmlAttribute]() {
-					goto l1186
-				}
-				goto l1185
-			l1186:
-				position, thunkPosition = position1186, thunkPosition1186
+			doarg(yyPush, 2)
+			if !p.rules[ruleStartList]() {
+				goto l1361
 			}
+			doarg(yySet, -1)
+		l1362:
 			{
-				position1187, thunkPosition1187 := position, thunkPosition
-				if !matchChar('/') {
-					goto l1187
+				position1363, thunkPosition1363 := position, thunkPosition
+				{
+					position1364, thunkPosition1364 := position, thunkPosition
+					if !p.rules[ruleReference]() {
+						goto l1365
+					}
+					doarg(yySet, -2)
+					do(142)
+					goto l1364
+				l1365:
+					position, thunkPosition = position1364, thunkPosition1364
+					if !p.rules[ruleSkipBlock]() {
+						goto l1363
+					}
 				}
-				goto l1188
-			l1187:
-				position, thunkPosition = position1187, thunkPosition1187
-			}
-		l1188:
-			if !p.rules[ruleSpnl]() {
-				goto l1180
+			l1364:
+				goto l1362
+			l1363:
+				position, thunkPosition = position1363, thunkPosition1363
 			}
-			if !matchChar('>') {
-				goto l1180
+			do(143)
+			if !(commit(thunkPosition0)) {
+				goto l1361
 			}
+			doarg(yyPop, 2)
 			return true
-		l1180:
+		l1361:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 200 Eof <- !. */
+		/* 251 Abbreviation <- (&{ p.extension.Abbreviations } NonindentSpace '*' AbbreviationName ':' Sp < (!Newline .)* > Newline BlankLine* { yy = mk_element(ABBREVIATION)
+                 yy.contents.str = strings.TrimSpace(yytext)
+                 yy.children = a
+                 a = nil }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if peekDot() {
-				goto l1189
+			doarg(yyPush, 1)
+			if !( p.extension.Abbreviations ) {
+				goto l1366
 			}
-			return true
-		l1189:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 201 Spacechar <- (' ' / '\t') */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
+			if !p.rules[ruleNonindentSpace]() {
+				goto l1366
+			}
+			if !matchChar('*') {
+				goto l1366
+			}
+			if !p.rules[ruleAbbreviationName]() {
+				goto l1366
+			}
+			doarg(yySet, -1)
+			if !matchChar(':') {
+				goto l1366
+			}
+			if !p.rules[ruleSp]() {
+				goto l1366
+			}
+			begin = position
+		l1367:
 			{
-				position1191, thunkPosition1191 := position, thunkPosition
-				if !matchChar(' ') {
-					goto l1192
+				position1368, thunkPosition1368 := position, thunkPosition
+				{
+					position1369, thunkPosition1369 := position, thunkPosition
+					if !p.rules[ruleNewline]() {
+						goto l1369
+					}
+					goto l1368
+				l1369:
+					position, thunkPosition = position1369, thunkPosition1369
 				}
-				goto l1191
-			l1192:
-				position, thunkPosition = position1191, thunkPosition1191
-				if !matchChar('\t') {
-					goto l1190
+				if !matchDot() {
+					goto l1368
+				}
+				goto l1367
+			l1368:
+				position, thunkPosition = position1368, thunkPosition1368
+			}
+			end = position
+			if !p.rules[ruleNewline]() {
+				goto l1366
+			}
+		l1370:
+			{
+				position1371, thunkPosition1371 := position, thunkPosition
+				if !p.rules[ruleBlankLine]() {
+					goto l1371
 				}
+				goto l1370
+			l1371:
+				position, thunkPosition = position1371, thunkPosition1371
 			}
-		l1191:
+			do(144)
+			doarg(yyPop, 1)
 			return true
-		l1190:
+		l1366:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 202 Nonspacechar <- (!Spacechar !Newline .) */
+		/* 252 AbbreviationName <- ('[' < (!']' !Newline .)+ > ']' { yy = mk_str(yytext) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			{
-				position1194, thunkPosition1194 := position, thunkPosition
-				if !p.rules[ruleSpacechar]() {
-					goto l1194
-				}
-				goto l1193
-			l1194:
-				position, thunkPosition = position1194, thunkPosition1194
+			if !matchChar('[') {
+				goto l1372
+			}
+			begin = position
+			if peekChar(']') {
+				goto l1372
 			}
 			{
-				position1195, thunkPosition1195 := position, thunkPosition
+				position1375, thunkPosition1375 := position, thunkPosition
 				if !p.rules[ruleNewline]() {
-					goto l1195
+					goto l1375
 				}
-				goto l1193
-			l1195:
-				position, thunkPosition = position1195, thunkPosition1195
+				goto l1372
+			l1375:
+				position, thunkPosition = position1375, thunkPosition1375
 			}
 			if !matchDot() {
-				goto l1193
+				goto l1372
 			}
-			return true
-		l1193:
-			position, thunkPosition = position0, thunkPosition0
-			return false
-		},
-		/* 203 Newline <- ('\n' / ('\r' '\n'?)) */
-		func() bool {
-			position0, thunkPosition0 := position, thunkPosition
+		l1373:
 			{
-				position1197, thunkPosition1197 := position, thunkPosition
-				if !matchChar('\n') {
-					goto l1198
-				}
-				goto l1197
-			l1198:
-				position, thunkPosition = position1197, thunkPosition1197
-				if !matchChar('\r') {
-					goto l1196
+				position1374, thunkPosition1374 := position, thunkPosition
+				if peekChar(']') {
+					goto l1374
 				}
 				{
-					position1199, thunkPosition1199 := position, thunkPosition
-					if !matchChar('\n') {
-						goto l1199
+					position1376, thunkPosition1376 := position, thunkPosition
+					if !p.rules[ruleNewline]() {
+						goto l1376
 					}
-					goto l1200
-				l1199:
-					position, thunkPosition = position1199, thunkPosition1199
+					goto l1374
+				l1376:
+					position, thunkPosition = position1376, thunkPosition1376
 				}
-			l1200:
+				if !matchDot() {
+					goto l1374
+				}
+				goto l1373
+			l1374:
+				position, thunkPosition = position1374, thunkPosition1374
+			}
+			end = position
+			if !matchChar(']') {
+				goto l1372
 			}
-		l1197:
+			do(145)
 			return true
-		l1196:
+		l1372:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 204 Sp <- Spacechar* */
+		/* 253 Abbreviations <- (StartList ((Abbreviation { a = cons(b, a) }) / SkipBlock)* { p.abbreviations = reverse(a) } commit) */
 		func() bool {
-		l1202:
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 2)
+			if !p.rules[ruleStartList]() {
+				goto l1377
+			}
+			doarg(yySet, -1)
+		l1378:
 			{
-				position1203, thunkPosition1203 := position, thunkPosition
-				if !p.rules[ruleSpacechar]() {
-					goto l1203
+				position1379, thunkPosition1379 := position, thunkPosition
+				{
+					position1380, thunkPosition1380 := position, thunkPosition
+					if !p.rules[ruleAbbreviation]() {
+						goto l1381
+					}
+					doarg(yySet, -2)
+					do(146)
+					goto l1380
+				l1381:
+					position, thunkPosition = position1380, thunkPosition1380
+					if !p.rules[ruleSkipBlock]() {
+						goto l1379
+					}
 				}
-				goto l1202
-			l1203:
-				position, thunkPosition = position1203, thunkPosition1203
+			l1380:
+				goto l1378
+			l1379:
+				position, thunkPosition = position1379, thunkPosition1379
+			}
+			do(147)
+			if !(commit(thunkPosition0)) {
+				goto l1377
 			}
+			doarg(yyPop, 2)
 			return true
+		l1377:
+			position, thunkPosition = position0, thunkPosition0
+			return false
 		},
-		/* 205 Spnl <- (Sp (Newline Sp)?) */
+		/* 254 Ticks1 <- ('`' !'`') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !p.rules[ruleSp]() {
-				goto l1204
+			if !matchChar('`') {
+				goto l1382
 			}
-			{
-				position1205, thunkPosition1205 := position, thunkPosition
-				if !p.rules[ruleNewline]() {
-					goto l1205
-				}
-				if !p.rules[ruleSp]() {
-					goto l1205
-				}
-				goto l1206
-			l1205:
-				position, thunkPosition = position1205, thunkPosition1205
+			if peekChar('`') {
+				goto l1382
 			}
-		l1206:
 			return true
-		l1204:
+		l1382:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 206 SpecialChar <- ('*' / '_' / '`' / '&' / '[' / ']' / '<' / '!' / '#' / '\\' / ExtendedSpecialChar) */
+		/* 255 Ticks2 <- ('``' !'`') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			{
-				position1208, thunkPosition1208 := position, thunkPosition
-				if !matchChar('*') {
-					goto l1209
-				}
-				goto l1208
-			l1209:
-				position, thunkPosition = position1208, thunkPosition1208
-				if !matchChar('_') {
-					goto l1210
-				}
-				goto l1208
-			l1210:
-				position, thunkPosition = position1208, thunkPosition1208
-				if !matchChar('`') {
-					goto l1211
-				}
-				goto l1208
-			l1211:
-				position, thunkPosition = position1208, thunkPosition1208
-				if !matchChar('&') {
-					goto l1212
-				}
-				goto l1208
-			l1212:
-				position, thunkPosition = position1208, thunkPosition1208
-				if !matchChar('[') {
-					goto l1213
-				}
-				goto l1208
-			l1213:
-				position, thunkPosition = position1208, thunkPosition1208
-				if !matchChar(']') {
-					goto l1214
-				}
-				goto l1208
-			l1214:
-				position, thunkPosition = position1208, thunkPosition1208
-				if !matchChar('<') {
-					goto l1215
-				}
-				goto l1208
-			l1215:
-				position, thunkPosition = position1208, thunkPosition1208
-				if !matchChar('!') {
-					goto l1216
-				}
-				goto l1208
-			l1216:
-				position, thunkPosition = position1208, thunkPosition1208
-				if !matchChar('#') {
-					goto l1217
-				}
-				goto l1208
-			l1217:
-				position, thunkPosition = position1208, thunkPosition1208
-				if !matchChar('\\') {
-					goto l1218
-				}
-				goto l1208
-			l1218:
-				position, thunkPosition = position1208, thunkPosition1208
-				if !p.rules[ruleExtendedSpecialChar]() {
-					goto l1207
-				}
+			if !matchString("``") {
+				goto l1383
+			}
+			if peekChar('`') {
+				goto l1383
 			}
-		l1208:
 			return true
-		l1207:
+		l1383:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 207 NormalChar <- (!(SpecialChar / Spacechar / Newline) .) */
+		/* 256 Ticks3 <- ('```' !'`') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			{
-				position1220, thunkPosition1220 := position, thunkPosition
-				{
-					position1221, thunkPosition1221 := position, thunkPosition
-					if !p.rules[ruleSpecialChar]() {
-						goto l1222
-					}
-					goto l1221
-				l1222:
-					position, thunkPosition = position1221, thunkPosition1221
-					if !p.rules[ruleSpacechar]() {
-						goto l1223
-					}
-					goto l1221
-				l1223:
-					position, thunkPosition = position1221, thunkPosition1221
-					if !p.rules[ruleNewline]() {
-						goto l1220
-					}
-				}
-			l1221:
-				goto l1219
-			l1220:
-				position, thunkPosition = position1220, thunkPosition1220
+			if !matchString("```") {
+				goto l1384
 			}
-			if !matchDot() {
-				goto l1219
+			if peekChar('`') {
+				goto l1384
 			}
 			return true
-		l1219:
+		l1384:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 208 NonAlphanumeric <- [\000-\057\072-\100\133-\140\173-\177] */
+		/* 257 Ticks4 <- ('````' !'`') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			if !matchClass(3) {
-				goto l1224
+			if !matchString("````") {
+				goto l1385
+			}
+			if peekChar('`') {
+				goto l1385
 			}
 			return true
-		l1224:
+		l1385:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 209 Alphanumeric <- ([0-9A-Za-z] / '\200' / '\201' / '\202' / '\203' / '\204' / '\205' / '\206' / '\207' / '\210' / '\211' / '\212' / '\213' / '\214' / '\215' / '\216' / '\217' / '\220' / '\221' / '\222' / '\223' / '\224' / '\225' / '\226' / '\227' / '\230' / '\231' / '\232' / '\233' / '\234' / '\235' / '\236' / '\237' / '\240' / '\241' / '\242' / '\243' / '\244' / '\245' / '\246' / '\247' / '\250' / '\251' / '\252' / '\253' / '\254' / '\255' / '\256' / '\257' / '\260' / '\261' / '\262' / '\263' / '\264' / '\265' / '\266' / '\267' / '\270' / '\271' / '\272' / '\273' / '\274' / '\275' / '\276' / '\277' / '\300' / '\301' / '\302' / '\303' / '\304' / '\305' / '\306' / '\307' / '\310' / '\311' / '\312' / '\313' / '\314' / '\315' / '\316' / '\317' / '\320' / '\321' / '\322' / '\323' / '\324' / '\325' / '\326' / '\327' / '\330' / '\331' / '\332' / '\333' / '\334' / '\335' / '\336' / '\337' / '\340' / '\341' / '\342' / '\343' / '\344' / '\345' / '\346' / '\347' / '\350' / '\351' / '\352' / '\353' / '\354' / '\355' / '\356' / '\357' / '\360' / '\361' / '\362' / '\363' / '\364' / '\365' / '\366' / '\367' / '\370' / '\371' / '\372' / '\373' / '\374' / '\375' / '\376' / '\377') */
+		/* 258 Ticks5 <- ('`````' !'`') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
-			{
-				position1226, thunkPosition1226 := position, thunkPosition
-				if !matchClass(1) {
-					goto l1227
-				}
-				goto l1226
-			l1227:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\200") {
-					goto l1228
-				}
-				goto l1226
-			l1228:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\201") {
-					goto l1229
-				}
-				goto l1226
-			l1229:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\202") {
-					goto l1230
-				}
-				goto l1226
-			l1230:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\203") {
-					goto l1231
-				}
-				goto l1226
-			l1231:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\204") {
-					goto l1232
-				}
-				goto l1226
-			l1232:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\205") {
-					goto l1233
-				}
-				goto l1226
-			l1233:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\206") {
-					goto l1234
-				}
-				goto l1226
-			l1234:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\207") {
-					goto l1235
-				}
-				goto l1226
-			l1235:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\210") {
-					goto l1236
-				}
-				goto l1226
-			l1236:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\211") {
-					goto l1237
-				}
-				goto l1226
-			l1237:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\212") {
-					goto l1238
-				}
-				goto l1226
-			l1238:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\213") {
-					goto l1239
-				}
-				goto l1226
-			l1239:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\214") {
-					goto l1240
-				}
-				goto l1226
-			l1240:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\215") {
-					goto l1241
-				}
-				goto l1226
-			l1241:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\216") {
-					goto l1242
-				}
-				goto l1226
-			l1242:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\217") {
-					goto l1243
-				}
-				goto l1226
-			l1243:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\220") {
-					goto l1244
-				}
-				goto l1226
-			l1244:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\221") {
-					goto l1245
-				}
-				goto l1226
-			l1245:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\222") {
-					goto l1246
-				}
-				goto l1226
-			l1246:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\223") {
-					goto l1247
-				}
-				goto l1226
-			l1247:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\224") {
-					goto l1248
-				}
-				goto l1226
-			l1248:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\225") {
-					goto l1249
-				}
-				goto l1226
-			l1249:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\226") {
-					goto l1250
-				}
-				goto l1226
-			l1250:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\227") {
-					goto l1251
-				}
-				goto l1226
-			l1251:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\230") {
-					goto l1252
-				}
-				goto l1226
-			l1252:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\231") {
-					goto l1253
-				}
-				goto l1226
-			l1253:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\232") {
-					goto l1254
-				}
-				goto l1226
-			l1254:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\233") {
-					goto l1255
-				}
-				goto l1226
-			l1255:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\234") {
-					goto l1256
-				}
-				goto l1226
-			l1256:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\235") {
-					goto l1257
-				}
-				goto l1226
-			l1257:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\236") {
-					goto l1258
-				}
-				goto l1226
-			l1258:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\237") {
-					goto l1259
-				}
-				goto l1226
-			l1259:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\240") {
-					goto l1260
-				}
-				goto l1226
-			l1260:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\241") {
-					goto l1261
+			if !matchString("`````") {
+				goto l1386
+			}
+			if peekChar('`') {
+				goto l1386
+			}
+			return true
+		l1386:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 259 Code <- (((Ticks1 Sp < ((!'`' Nonspacechar)+ / (!Ticks1 '`'+) / (!(Sp Ticks1) (Spacechar / (Newline !BlankLine))))+ > Sp Ticks1) / (Ticks2 Sp < ((!'`' Nonspacechar)+ / (!Ticks2 '`'+) / (!(Sp Ticks2) (Spacechar / (Newline !BlankLine))))+ > Sp Ticks2) / (Ticks3 Sp < ((!'`' Nonspacechar)+ / (!Ticks3 '`'+) / (!(Sp Ticks3) (Spacechar / (Newline !BlankLine))))+ > Sp Ticks3) / (Ticks4 Sp < ((!'`' Nonspacechar)+ / (!Ticks4 '`'+) / (!(Sp Ticks4) (Spacechar / (Newline !BlankLine))))+ > Sp Ticks4) / (Ticks5 Sp < ((!'`' Nonspacechar)+ / (!Ticks5 '`'+) / (!(Sp Ticks5) (Spacechar / (Newline !BlankLine))))+ > Sp Ticks5)) { yy = mk_str(yytext); yy.key = CODE }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1388, thunkPosition1388 := position, thunkPosition
+				if !p.rules[ruleTicks1]() {
+					goto l1389
 				}
-				goto l1226
-			l1261:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\242") {
-					goto l1262
+				if !p.rules[ruleSp]() {
+					goto l1389
 				}
-				goto l1226
-			l1262:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\243") {
-					goto l1263
+				begin = position
+				{
+					position1392, thunkPosition1392 := position, thunkPosition
+					if peekChar('`') {
+						goto l1393
+					}
+					if !p.rules[ruleNonspacechar]() {
+						goto l1393
+					}
+				l1394:
+					{
+						position1395, thunkPosition1395 := position, thunkPosition
+						if peekChar('`') {
+							goto l1395
+						}
+						if !p.rules[ruleNonspacechar]() {
+							goto l1395
+						}
+						goto l1394
+					l1395:
+						position, thunkPosition = position1395, thunkPosition1395
+					}
+					goto l1392
+				l1393:
+					position, thunkPosition = position1392, thunkPosition1392
+					{
+						position1397, thunkPosition1397 := position, thunkPosition
+						if !p.rules[ruleTicks1]() {
+							goto l1397
+						}
+						goto l1396
+					l1397:
+						position, thunkPosition = position1397, thunkPosition1397
+					}
+					if !matchChar('`') {
+						goto l1396
+					}
+				l1398:
+					{
+						position1399, thunkPosition1399 := position, thunkPosition
+						if !matchChar('`') {
+							goto l1399
+						}
+						goto l1398
+					l1399:
+						position, thunkPosition = position1399, thunkPosition1399
+					}
+					goto l1392
+				l1396:
+					position, thunkPosition = position1392, thunkPosition1392
+					{
+						position1400, thunkPosition1400 := position, thunkPosition
+						if !p.rules[ruleSp]() {
+							goto l1400
+						}
+						if !p.rules[ruleTicks1]() {
+							goto l1400
+						}
+						goto l1389
+					l1400:
+						position, thunkPosition = position1400, thunkPosition1400
+					}
+					{
+						position1401, thunkPosition1401 := position, thunkPosition
+						if !p.rules[ruleSpacechar]() {
+							goto l1402
+						}
+						goto l1401
+					l1402:
+						position, thunkPosition = position1401, thunkPosition1401
+						if !p.rules[ruleNewline]() {
+							goto l1389
+						}
+						{
+							position1403, thunkPosition1403 := position, thunkPosition
+							if !p.rules[ruleBlankLine]() {
+								goto l1403
+							}
+							goto l1389
+						l1403:
+							position, thunkPosition = position1403, thunkPosition1403
+						}
+					}
+				l1401:
 				}
-				goto l1226
-			l1263:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\244") {
-					goto l1264
+			l1392:
+			l1390:
+				{
+					position1391, thunkPosition1391 := position, thunkPosition
+					{
+						position1404, thunkPosition1404 := position, thunkPosition
+						if peekChar('`') {
+							goto l1405
+						}
+						if !p.rules[ruleNonspacechar]() {
+							goto l1405
+						}
+					l1406:
+						{
+							position1407, thunkPosition1407 := position, thunkPosition
+							if peekChar('`') {
+								goto l1407
+							}
+							if !p.rules[ruleNonspacechar]() {
+								goto l1407
+							}
+							goto l1406
+						l1407:
+							position, thunkPosition = position1407, thunkPosition1407
+						}
+						goto l1404
+					l1405:
+						position, thunkPosition = position1404, thunkPosition1404
+						{
+							position1409, thunkPosition1409 := position, thunkPosition
+							if !p.rules[ruleTicks1]() {
+								goto l1409
+							}
+							goto l1408
+						l1409:
+							position, thunkPosition = position1409, thunkPosition1409
+						}
+						if !matchChar('`') {
+							goto l1408
+						}
+					l1410:
+						{
+							position1411, thunkPosition1411 := position, thunkPosition
+							if !matchChar('`') {
+								goto l1411
+							}
+							goto l1410
+						l1411:
+							position, thunkPosition = position1411, thunkPosition1411
+						}
+						goto l1404
+					l1408:
+						position, thunkPosition = position1404, thunkPosition1404
+						{
+							position1412, thunkPosition1412 := position, thunkPosition
+							if !p.rules[ruleSp]() {
+								goto l1412
+							}
+							if !p.rules[ruleTicks1]() {
+								goto l1412
+							}
+							goto l1391
+						l1412:
+							position, thunkPosition = position1412, thunkPosition1412
+						}
+						{
+							position1413, thunkPosition1413 := position, thunkPosition
+							if !p.rules[ruleSpacechar]() {
+								goto l1414
+							}
+							goto l1413
+						l1414:
+							position, thunkPosition = position1413, thunkPosition1413
+							if !p.rules[ruleNewline]() {
+								goto l1391
+							}
+							{
+								position1415, thunkPosition1415 := position, thunkPosition
+								if !p.rules[ruleBlankLine]() {
+									goto l1415
+								}
+								goto l1391
+							l1415:
+								position, thunkPosition = position1415, thunkPosition1415
+							}
+						}
+					l1413:
+					}
+				l1404:
+					goto l1390
+				l1391:
+					position, thunkPosition = position1391, thunkPosition1391
 				}
-				goto l1226
-			l1264:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\245") {
-					goto l1265
+				end = position
+				if !p.rules[ruleSp]() {
+					goto l1389
 				}
-				goto l1226
-			l1265:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\246") {
-					goto l1266
+				if !p.rules[ruleTicks1]() {
+					goto l1389
 				}
-				goto l1226
-			l1266:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\247") {
-					goto l1267
+				goto l1388
+			l1389:
+				position, thunkPosition = position1388, thunkPosition1388
+				if !p.rules[ruleTicks2]() {
+					goto l1416
 				}
-				goto l1226
-			l1267:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\250") {
-					goto l1268
+				if !p.rules[ruleSp]() {
+					goto l1416
 				}
-				goto l1226
-			l1268:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\251") {
-					goto l1269
+				begin = position
+				{
+					position1419, thunkPosition1419 := position, thunkPosition
+					if peekChar('`') {
+						goto l1420
+					}
+					if !p.rules[ruleNonspacechar]() {
+						goto l1420
+					}
+				l1421:
+					{
+						position1422, thunkPosition1422 := position, thunkPosition
+						if peekChar('`') {
+							goto l1422
+						}
+						if !p.rules[ruleNonspacechar]() {
+							goto l1422
+						}
+						goto l1421
+					l1422:
+						position, thunkPosition = position1422, thunkPosition1422
+					}
+					goto l1419
+				l1420:
+					position, thunkPosition = position1419, thunkPosition1419
+					{
+						position1424, thunkPosition1424 := position, thunkPosition
+						if !p.rules[ruleTicks2]() {
+							goto l1424
+						}
+						goto l1423
+					l1424:
+						position, thunkPosition = position1424, thunkPosition1424
+					}
+					if !matchChar('`') {
+						goto l1423
+					}
+				l1425:
+					{
+						position1426, thunkPosition1426 := position, thunkPosition
+						if !matchChar('`') {
+							goto l1426
+						}
+						goto l1425
+					l1426:
+						position, thunkPosition = position1426, thunkPosition1426
+					}
+					goto l1419
+				l1423:
+					position, thunkPosition = position1419, thunkPosition1419
+					{
+						position1427, thunkPosition1427 := position, thunkPosition
+						if !p.rules[ruleSp]() {
+							goto l1427
+						}
+						if !p.rules[ruleTicks2]() {
+							goto l1427
+						}
+						goto l1416
+					l1427:
+						position, thunkPosition = position1427, thunkPosition1427
+					}
+					{
+						position1428, thunkPosition1428 := position, thunkPosition
+						if !p.rules[ruleSpacechar]() {
+							goto l1429
+						}
+						goto l1428
+					l1429:
+						position, thunkPosition = position1428, thunkPosition1428
+						if !p.rules[ruleNewline]() {
+							goto l1416
+						}
+						{
+							position1430, thunkPosition1430 := position, thunkPosition
+							if !p.rules[ruleBlankLine]() {
+								goto l1430
+							}
+							goto l1416
+						l1430:
+							position, thunkPosition = position1430, thunkPosition1430
+						}
+					}
+				l1428:
 				}
-				goto l1226
-			l1269:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\252") {
-					goto l1270
+			l1419:
+			l1417:
+				{
+					position1418, thunkPosition1418 := position, thunkPosition
+					{
+						position1431, thunkPosition1431 := position, thunkPosition
+						if peekChar('`') {
+							goto l1432
+						}
+						if !p.rules[ruleNonspacechar]() {
+							goto l1432
+						}
+					l1433:
+						{
+							position1434, thunkPosition1434 := position, thunkPosition
+							if peekChar('`') {
+								goto l1434
+							}
+							if !p.rules[ruleNonspacechar]() {
+								goto l1434
+							}
+							goto l1433
+						l1434:
+							position, thunkPosition = position1434, thunkPosition1434
+						}
+						goto l1431
+					l1432:
+						position, thunkPosition = position1431, thunkPosition1431
+						{
+							position1436, thunkPosition1436 := position, thunkPosition
+							if !p.rules[ruleTicks2]() {
+								goto l1436
+							}
+							goto l1435
+						l1436:
+							position, thunkPosition = position1436, thunkPosition1436
+						}
+						if !matchChar('`') {
+							goto l1435
+						}
+					l1437:
+						{
+							position1438, thunkPosition1438 := position, thunkPosition
+							if !matchChar('`') {
+								goto l1438
+							}
+							goto l1437
+						l1438:
+							position, thunkPosition = position1438, thunkPosition1438
+						}
+						goto l1431
+					l1435:
+						position, thunkPosition = position1431, thunkPosition1431
+						{
+							position1439, thunkPosition1439 := position, thunkPosition
+							if !p.rules[ruleSp]() {
+								goto l1439
+							}
+							if !p.rules[ruleTicks2]() {
+								goto l1439
+							}
+							goto l1418
+						l1439:
+							position, thunkPosition = position1439, thunkPosition1439
+						}
+						{
+							position1440, thunkPosition1440 := position, thunkPosition
+							if !p.rules[ruleSpacechar]() {
+								goto l1441
+							}
+							goto l1440
+						l1441:
+							position, thunkPosition = position1440, thunkPosition1440
+							if !p.rules[ruleNewline]() {
+								goto l1418
+							}
+							{
+								position1442, thunkPosition1442 := position, thunkPosition
+								if !p.rules[ruleBlankLine]() {
+									goto l1442
+								}
+								goto l1418
+							l1442:
+								position, thunkPosition = position1442, thunkPosition1442
+							}
+						}
+					l1440:
+					}
+				l1431:
+					goto l1417
+				l1418:
+					position, thunkPosition = position1418, thunkPosition1418
 				}
-				goto l1226
-			l1270:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\253") {
-					goto l1271
+				end = position
+				if !p.rules[ruleSp]() {
+					goto l1416
 				}
-				goto l1226
-			l1271:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\254") {
-					goto l1272
+				if !p.rules[ruleTicks2]() {
+					goto l1416
 				}
-				goto l1226
-			l1272:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\255") {
-					goto l1273
+				goto l1388
+			l1416:
+				position, thunkPosition = position1388, thunkPosition1388
+				if !p.rules[ruleTicks3]() {
+					goto l1443
 				}
-				goto l1226
-			l1273:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\256") {
-					goto l1274
+				if !p.rules[ruleSp]() {
+					goto l1443
 				}
-				goto l1226
-			l1274:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\257") {
-					goto l1275
+				begin = position
+				{
+					position1446, thunkPosition1446 := position, thunkPosition
+					if peekChar('`') {
+						goto l1447
+					}
+					if !p.rules[ruleNonspacechar]() {
+						goto l1447
+					}
+				l1448:
+					{
+						position1449, thunkPosition1449 := position, thunkPosition
+						if peekChar('`') {
+							goto l1449
+						}
+						if !p.rules[ruleNonspacechar]() {
+							goto l1449
+						}
+						goto l1448
+					l1449:
+						position, thunkPosition = position1449, thunkPosition1449
+					}
+					goto l1446
+				l1447:
+					position, thunkPosition = position1446, thunkPosition1446
+					{
+						position1451, thunkPosition1451 := position, thunkPosition
+						if !p.rules[ruleTicks3]() {
+							goto l1451
+						}
+						goto l1450
+					l1451:
+						position, thunkPosition = position1451, thunkPosition1451
+					}
+					if !matchChar('`') {
+						goto l1450
+					}
+				l1452:
+					{
+						position1453, thunkPosition1453 := position, thunkPosition
+						if !matchChar('`') {
+							goto l1453
+						}
+						goto l1452
+					l1453:
+						position, thunkPosition = position1453, thunkPosition1453
+					}
+					goto l1446
+				l1450:
+					position, thunkPosition = position1446, thunkPosition1446
+					{
+						position1454, thunkPosition1454 := position, thunkPosition
+						if !p.rules[ruleSp]() {
+							goto l1454
+						}
+						if !p.rules[ruleTicks3]() {
+							goto l1454
+						}
+						goto l1443
+					l1454:
+						position, thunkPosition = position1454, thunkPosition1454
+					}
+					{
+						position1455, thunkPosition1455 := position, thunkPosition
+						if !p.rules[ruleSpacechar]() {
+							goto l1456
+						}
+						goto l1455
+					l1456:
+						position, thunkPosition = position1455, thunkPosition1455
+						if !p.rules[ruleNewline]() {
+							goto l1443
+						}
+						{
+							position1457, thunkPosition1457 := position, thunkPosition
+							if !p.rules[ruleBlankLine]() {
+								goto l1457
+							}
+							goto l1443
+						l1457:
+							position, thunkPosition = position1457, thunkPosition1457
+						}
+					}
+				l1455:
 				}
-				goto l1226
-			l1275:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\260") {
-					goto l1276
+			l1446:
+			l1444:
+				{
+					position1445, thunkPosition1445 := position, thunkPosition
+					{
+						position1458, thunkPosition1458 := position, thunkPosition
+						if peekChar('`') {
+							goto l1459
+						}
+						if !p.rules[ruleNonspacechar]() {
+							goto l1459
+						}
+					l1460:
+						{
+							position1461, thunkPosition1461 := position, thunkPosition
+							if peekChar('`') {
+								goto l1461
+							}
+							if !p.rules[ruleNonspacechar]() {
+								goto l1461
+							}
+							goto l1460
+						l1461:
+							position, thunkPosition = position1461, thunkPosition1461
+						}
+						goto l1458
+					l1459:
+						position, thunkPosition = position1458, thunkPosition1458
+						{
+							position1463, thunkPosition1463 := position, thunkPosition
+							if !p.rules[ruleTicks3]() {
+								goto l1463
+							}
+							goto l1462
+						l1463:
+							position, thunkPosition = position1463, thunkPosition1463
+						}
+						if !matchChar('`') {
+							goto l1462
+						}
+					l1464:
+						{
+							position1465, thunkPosition1465 := position, thunkPosition
+							if !matchChar('`') {
+								goto l1465
+							}
+							goto l1464
+						l1465:
+							position, thunkPosition = position1465, thunkPosition1465
+						}
+						goto l1458
+					l1462:
+						position, thunkPosition = position1458, thunkPosition1458
+						{
+							position1466, thunkPosition1466 := position, thunkPosition
+							if !p.rules[ruleSp]() {
+								goto l1466
+							}
+							if !p.rules[ruleTicks3]() {
+								goto l1466
+							}
+							goto l1445
+						l1466:
+							position, thunkPosition = position1466, thunkPosition1466
+						}
+						{
+							position1467, thunkPosition1467 := position, thunkPosition
+							if !p.rules[ruleSpacechar]() {
+								goto l1468
+							}
+							goto l1467
+						l1468:
+							position, thunkPosition = position1467, thunkPosition1467
+							if !p.rules[ruleNewline]() {
+								goto l1445
+							}
+							{
+								position1469, thunkPosition1469 := position, thunkPosition
+								if !p.rules[ruleBlankLine]() {
+									goto l1469
+								}
+								goto l1445
+							l1469:
+								position, thunkPosition = position1469, thunkPosition1469
+							}
+						}
+					l1467:
+					}
+				l1458:
+					goto l1444
+				l1445:
+					position, thunkPosition = position1445, thunkPosition1445
 				}
-				goto l1226
-			l1276:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\261") {
-					goto l1277
+				end = position
+				if !p.rules[ruleSp]() {
+					goto l1443
 				}
-				goto l1226
-			l1277:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\262") {
-					goto l1278
+				if !p.rules[ruleTicks3]() {
+					goto l1443
 				}
-				goto l1226
-			l1278:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\263") {
-					goto l1279
+				goto l1388
+			l1443:
+				position, thunkPosition = position1388, thunkPosition1388
+				if !p.rules[ruleTicks4]() {
+					goto l1470
 				}
-				goto l1226
-			l1279:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\264") {
-					goto l1280
+				if !p.rules[ruleSp]() {
+					goto l1470
 				}
-				goto l1226
-			l1280:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\265") {
-					goto l1281
+				begin = position
+				{
+					position1473, thunkPosition1473 := position, thunkPosition
+					if peekChar('`') {
+						goto l1474
+					}
+					if !p.rules[ruleNonspacechar]() {
+						goto l1474
+					}
+				l1475:
+					{
+						position1476, thunkPosition1476 := position, thunkPosition
+						if peekChar('`') {
+							goto l1476
+						}
+						if !p.rules[ruleNonspacechar]() {
+							goto l1476
+						}
+						goto l1475
+					l1476:
+						position, thunkPosition = position1476, thunkPosition1476
+					}
+					goto l1473
+				l1474:
+					position, thunkPosition = position1473, thunkPosition1473
+					{
+						position1478, thunkPosition1478 := position, thunkPosition
+						if !p.rules[ruleTicks4]() {
+							goto l1478
+						}
+						goto l1477
+					l1478:
+						position, thunkPosition = position1478, thunkPosition1478
+					}
+					if !matchChar('`') {
+						goto l1477
+					}
+				l1479:
+					{
+						position1480, thunkPosition1480 := position, thunkPosition
+						if !matchChar('`') {
+							goto l1480
+						}
+						goto l1479
+					l1480:
+						position, thunkPosition = position1480, thunkPosition1480
+					}
+					goto l1473
+				l1477:
+					position, thunkPosition = position1473, thunkPosition1473
+					{
+						position1481, thunkPosition1481 := position, thunkPosition
+						if !p.rules[ruleSp]() {
+							goto l1481
+						}
+						if !p.rules[ruleTicks4]() {
+							goto l1481
+						}
+						goto l1470
+					l1481:
+						position, thunkPosition = position1481, thunkPosition1481
+					}
+					{
+						position1482, thunkPosition1482 := position, thunkPosition
+						if !p.rules[ruleSpacechar]() {
+							goto l1483
+						}
+						goto l1482
+					l1483:
+						position, thunkPosition = position1482, thunkPosition1482
+						if !p.rules[ruleNewline]() {
+							goto l1470
+						}
+						{
+							position1484, thunkPosition1484 := position, thunkPosition
+							if !p.rules[ruleBlankLine]() {
+								goto l1484
+							}
+							goto l1470
+						l1484:
+							position, thunkPosition = position1484, thunkPosition1484
+						}
+					}
+				l1482:
 				}
-				goto l1226
-			l1281:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\266") {
-					goto l1282
+			l1473:
+			l1471:
+				{
+					position1472, thunkPosition1472 := position, thunkPosition
+					{
+						position1485, thunkPosition1485 := position, thunkPosition
+						if peekChar('`') {
+							goto l1486
+						}
+						if !p.rules[ruleNonspacechar]() {
+							goto l1486
+						}
+					l1487:
+						{
+							position1488, thunkPosition1488 := position, thunkPosition
+							if peekChar('`') {
+								goto l1488
+							}
+							if !p.rules[ruleNonspacechar]() {
+								goto l1488
+							}
+							goto l1487
+						l1488:
+							position, thunkPosition = position1488, thunkPosition1488
+						}
+						goto l1485
+					l1486:
+						position, thunkPosition = position1485, thunkPosition1485
+						{
+							position1490, thunkPosition1490 := position, thunkPosition
+							if !p.rules[ruleTicks4]() {
+								goto l1490
+							}
+							goto l1489
+						l1490:
+							position, thunkPosition = position1490, thunkPosition1490
+						}
+						if !matchChar('`') {
+							goto l1489
+						}
+					l1491:
+						{
+							position1492, thunkPosition1492 := position, thunkPosition
+							if !matchChar('`') {
+								goto l1492
+							}
+							goto l1491
+						l1492:
+							position, thunkPosition = position1492, thunkPosition1492
+						}
+						goto l1485
+					l1489:
+						position, thunkPosition = position1485, thunkPosition1485
+						{
+							position1493, thunkPosition1493 := position, thunkPosition
+							if !p.rules[ruleSp]() {
+								goto l1493
+							}
+							if !p.rules[ruleTicks4]() {
+								goto l1493
+							}
+							goto l1472
+						l1493:
+							position, thunkPosition = position1493, thunkPosition1493
+						}
+						{
+							position1494, thunkPosition1494 := position, thunkPosition
+							if !p.rules[ruleSpacechar]() {
+								goto l1495
+							}
+							goto l1494
+						l1495:
+							position, thunkPosition = position1494, thunkPosition1494
+							if !p.rules[ruleNewline]() {
+								goto l1472
+							}
+							{
+								position1496, thunkPosition1496 := position, thunkPosition
+								if !p.rules[ruleBlankLine]() {
+									goto l1496
+								}
+								goto l1472
+							l1496:
+								position, thunkPosition = position1496, thunkPosition1496
+							}
+						}
+					l1494:
+					}
+				l1485:
+					goto l1471
+				l1472:
+					position, thunkPosition = position1472, thunkPosition1472
 				}
-				goto l1226
-			l1282:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\267") {
-					goto l1283
+				end = position
+				if !p.rules[ruleSp]() {
+					goto l1470
 				}
-				goto l1226
-			l1283:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\270") {
-					goto l1284
+				if !p.rules[ruleTicks4]() {
+					goto l1470
 				}
-				goto l1226
-			l1284:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\271") {
-					goto l1285
+				goto l1388
+			l1470:
+				position, thunkPosition = position1388, thunkPosition1388
+				if !p.rules[ruleTicks5]() {
+					goto l1387
 				}
-				goto l1226
-			l1285:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\272") {
-					goto l1286
+				if !p.rules[ruleSp]() {
+					goto l1387
 				}
-				goto l1226
-			l1286:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\273") {
-					goto l1287
+				begin = position
+				{
+					position1499, thunkPosition1499 := position, thunkPosition
+					if peekChar('`') {
+						goto l1500
+					}
+					if !p.rules[ruleNonspacechar]() {
+						goto l1500
+					}
+				l1501:
+					{
+						position1502, thunkPosition1502 := position, thunkPosition
+						if peekChar('`') {
+							goto l1502
+						}
+						if !p.rules[ruleNonspacechar]() {
+							goto l1502
+						}
+						goto l1501
+					l1502:
+						position, thunkPosition = position1502, thunkPosition1502
+					}
+					goto l1499
+				l1500:
+					position, thunkPosition = position1499, thunkPosition1499
+					{
+						position1504, thunkPosition1504 := position, thunkPosition
+						if !p.rules[ruleTicks5]() {
+							goto l1504
+						}
+						goto l1503
+					l1504:
+						position, thunkPosition = position1504, thunkPosition1504
+					}
+					if !matchChar('`') {
+						goto l1503
+					}
+				l1505:
+					{
+						position1506, thunkPosition1506 := position, thunkPosition
+						if !matchChar('`') {
+							goto l1506
+						}
+						goto l1505
+					l1506:
+						position, thunkPosition = position1506, thunkPosition1506
+					}
+					goto l1499
+				l1503:
+					position, thunkPosition = position1499, thunkPosition1499
+					{
+						position1507, thunkPosition1507 := position, thunkPosition
+						if !p.rules[ruleSp]() {
+							goto l1507
+						}
+						if !p.rules[ruleTicks5]() {
+							goto l1507
+						}
+						goto l1387
+					l1507:
+						position, thunkPosition = position1507, thunkPosition1507
+					}
+					{
+						position1508, thunkPosition1508 := position, thunkPosition
+						if !p.rules[ruleSpacechar]() {
+							goto l1509
+						}
+						goto l1508
+					l1509:
+						position, thunkPosition = position1508, thunkPosition1508
+						if !p.rules[ruleNewline]() {
+							goto l1387
+						}
+						{
+							position1510, thunkPosition1510 := position, thunkPosition
+							if !p.rules[ruleBlankLine]() {
+								goto l1510
+							}
+							goto l1387
+						l1510:
+							position, thunkPosition = position1510, thunkPosition1510
+						}
+					}
+				l1508:
 				}
-				goto l1226
-			l1287:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\274") {
-					goto l1288
+			l1499:
+			l1497:
+				{
+					position1498, thunkPosition1498 := position, thunkPosition
+					{
+						position1511, thunkPosition1511 := position, thunkPosition
+						if peekChar('`') {
+							goto l1512
+						}
+						if !p.rules[ruleNonspacechar]() {
+							goto l1512
+						}
+					l1513:
+						{
+							position1514, thunkPosition1514 := position, thunkPosition
+							if peekChar('`') {
+								goto l1514
+							}
+							if !p.rules[ruleNonspacechar]() {
+								goto l1514
+							}
+							goto l1513
+						l1514:
+							position, thunkPosition = position1514, thunkPosition1514
+						}
+						goto l1511
+					l1512:
+						position, thunkPosition = position1511, thunkPosition1511
+						{
+							position1516, thunkPosition1516 := position, thunkPosition
+							if !p.rules[ruleTicks5]() {
+								goto l1516
+							}
+							goto l1515
+						l1516:
+							position, thunkPosition = position1516, thunkPosition1516
+						}
+						if !matchChar('`') {
+							goto l1515
+						}
+					l1517:
+						{
+							position1518, thunkPosition1518 := position, thunkPosition
+							if !matchChar('`') {
+								goto l1518
+							}
+							goto l1517
+						l1518:
+							position, thunkPosition = position1518, thunkPosition1518
+						}
+						goto l1511
+					l1515:
+						position, thunkPosition = position1511, thunkPosition1511
+						{
+							position1519, thunkPosition1519 := position, thunkPosition
+							if !p.rules[ruleSp]() {
+								goto l1519
+							}
+							if !p.rules[ruleTicks5]() {
+								goto l1519
+							}
+							goto l1498
+						l1519:
+							position, thunkPosition = position1519, thunkPosition1519
+						}
+						{
+							position1520, thunkPosition1520 := position, thunkPosition
+							if !p.rules[ruleSpacechar]() {
+								goto l1521
+							}
+							goto l1520
+						l1521:
+							position, thunkPosition = position1520, thunkPosition1520
+							if !p.rules[ruleNewline]() {
+								goto l1498
+							}
+							{
+								position1522, thunkPosition1522 := position, thunkPosition
+								if !p.rules[ruleBlankLine]() {
+									goto l1522
+								}
+								goto l1498
+							l1522:
+								position, thunkPosition = position1522, thunkPosition1522
+							}
+						}
+					l1520:
+					}
+				l1511:
+					goto l1497
+				l1498:
+					position, thunkPosition = position1498, thunkPosition1498
 				}
-				goto l1226
-			l1288:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\275") {
-					goto l1289
+				end = position
+				if !p.rules[ruleSp]() {
+					goto l1387
 				}
-				goto l1226
-			l1289:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\276") {
-					goto l1290
+				if !p.rules[ruleTicks5]() {
+					goto l1387
 				}
-				goto l1226
-			l1290:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\277") {
-					goto l1291
+			}
+		l1388:
+			do(148)
+			return true
+		l1387:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 260 Math <- (&{ p.extension.Math } (DisplayMath / InlineMath)) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !( p.extension.Math ) {
+				goto l1523
+			}
+			{
+				position1524, thunkPosition1524 := position, thunkPosition
+				if !p.rules[ruleDisplayMath]() {
+					goto l1525
 				}
-				goto l1226
-			l1291:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\300") {
-					goto l1292
+				goto l1524
+			l1525:
+				position, thunkPosition = position1524, thunkPosition1524
+				if !p.rules[ruleInlineMath]() {
+					goto l1523
 				}
-				goto l1226
-			l1292:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\301") {
-					goto l1293
+			}
+		l1524:
+			return true
+		l1523:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 261 DisplayMath <- ('$$' < (!'$$' !(Newline BlankLine) .)+ > '$$' { yy = mk_str(yytext); yy.key = DISPLAYMATH }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !matchString("$$") {
+				goto l1526
+			}
+			begin = position
+			{
+				position1529, thunkPosition1529 := position, thunkPosition
+				if !matchString("$$") {
+					goto l1529
 				}
-				goto l1226
-			l1293:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\302") {
-					goto l1294
+				goto l1526
+			l1529:
+				position, thunkPosition = position1529, thunkPosition1529
+			}
+			{
+				position1530, thunkPosition1530 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l1530
 				}
-				goto l1226
-			l1294:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\303") {
-					goto l1295
+				if !p.rules[ruleBlankLine]() {
+					goto l1530
 				}
-				goto l1226
-			l1295:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\304") {
-					goto l1296
+				goto l1526
+			l1530:
+				position, thunkPosition = position1530, thunkPosition1530
+			}
+			if !matchDot() {
+				goto l1526
+			}
+		l1527:
+			{
+				position1528, thunkPosition1528 := position, thunkPosition
+				{
+					position1531, thunkPosition1531 := position, thunkPosition
+					if !matchString("$$") {
+						goto l1531
+					}
+					goto l1528
+				l1531:
+					position, thunkPosition = position1531, thunkPosition1531
 				}
-				goto l1226
-			l1296:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\305") {
-					goto l1297
+				{
+					position1532, thunkPosition1532 := position, thunkPosition
+					if !p.rules[ruleNewline]() {
+						goto l1532
+					}
+					if !p.rules[ruleBlankLine]() {
+						goto l1532
+					}
+					goto l1528
+				l1532:
+					position, thunkPosition = position1532, thunkPosition1532
 				}
-				goto l1226
-			l1297:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\306") {
-					goto l1298
+				if !matchDot() {
+					goto l1528
 				}
-				goto l1226
-			l1298:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\307") {
-					goto l1299
+				goto l1527
+			l1528:
+				position, thunkPosition = position1528, thunkPosition1528
+			}
+			end = position
+			if !matchString("$$") {
+				goto l1526
+			}
+			do(149)
+			return true
+		l1526:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 262 InlineMath <- ('$' !Spacechar < (('\\' .) / ((Spacechar / (Newline !BlankLine))+ !'$') / (!'$' !Spacechar !Newline .))+ > '$' !Digit { yy = mk_str(yytext); yy.key = MATH }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('$') {
+				goto l1533
+			}
+			{
+				position1534, thunkPosition1534 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l1534
 				}
-				goto l1226
-			l1299:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\310") {
-					goto l1300
+				goto l1533
+			l1534:
+				position, thunkPosition = position1534, thunkPosition1534
+			}
+			begin = position
+			{
+				position1537, thunkPosition1537 := position, thunkPosition
+				if !matchChar('\\') {
+					goto l1538
 				}
-				goto l1226
-			l1300:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\311") {
-					goto l1301
+				if !matchDot() {
+					goto l1538
 				}
-				goto l1226
-			l1301:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\312") {
-					goto l1302
+				goto l1537
+			l1538:
+				position, thunkPosition = position1537, thunkPosition1537
+				{
+					position1542, thunkPosition1542 := position, thunkPosition
+					if !p.rules[ruleSpacechar]() {
+						goto l1543
+					}
+					goto l1542
+				l1543:
+					position, thunkPosition = position1542, thunkPosition1542
+					if !p.rules[ruleNewline]() {
+						goto l1539
+					}
+					{
+						position1544, thunkPosition1544 := position, thunkPosition
+						if !p.rules[ruleBlankLine]() {
+							goto l1544
+						}
+						goto l1539
+					l1544:
+						position, thunkPosition = position1544, thunkPosition1544
+					}
 				}
-				goto l1226
-			l1302:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\313") {
-					goto l1303
+			l1542:
+			l1540:
+				{
+					position1541, thunkPosition1541 := position, thunkPosition
+					{
+						position1545, thunkPosition1545 := position, thunkPosition
+						if !p.rules[ruleSpacechar]() {
+							goto l1546
+						}
+						goto l1545
+					l1546:
+						position, thunkPosition = position1545, thunkPosition1545
+						if !p.rules[ruleNewline]() {
+							goto l1541
+						}
+						{
+							position1547, thunkPosition1547 := position, thunkPosition
+							if !p.rules[ruleBlankLine]() {
+								goto l1547
+							}
+							goto l1541
+						l1547:
+							position, thunkPosition = position1547, thunkPosition1547
+						}
+					}
+				l1545:
+					goto l1540
+				l1541:
+					position, thunkPosition = position1541, thunkPosition1541
 				}
-				goto l1226
-			l1303:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\314") {
-					goto l1304
+				if peekChar('$') {
+					goto l1539
 				}
-				goto l1226
-			l1304:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\315") {
-					goto l1305
+				goto l1537
+			l1539:
+				position, thunkPosition = position1537, thunkPosition1537
+				if peekChar('$') {
+					goto l1533
 				}
-				goto l1226
-			l1305:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\316") {
-					goto l1306
+				{
+					position1548, thunkPosition1548 := position, thunkPosition
+					if !p.rules[ruleSpacechar]() {
+						goto l1548
+					}
+					goto l1533
+				l1548:
+					position, thunkPosition = position1548, thunkPosition1548
 				}
-				goto l1226
-			l1306:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\317") {
-					goto l1307
+				{
+					position1549, thunkPosition1549 := position, thunkPosition
+					if !p.rules[ruleNewline]() {
+						goto l1549
+					}
+					goto l1533
+				l1549:
+					position, thunkPosition = position1549, thunkPosition1549
 				}
-				goto l1226
-			l1307:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\320") {
-					goto l1308
+				if !matchDot() {
+					goto l1533
 				}
-				goto l1226
-			l1308:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\321") {
-					goto l1309
+			}
+		l1537:
+		l1535:
+			{
+				position1536, thunkPosition1536 := position, thunkPosition
+				{
+					position1550, thunkPosition1550 := position, thunkPosition
+					if !matchChar('\\') {
+						goto l1551
+					}
+					if !matchDot() {
+						goto l1551
+					}
+					goto l1550
+				l1551:
+					position, thunkPosition = position1550, thunkPosition1550
+					{
+						position1555, thunkPosition1555 := position, thunkPosition
+						if !p.rules[ruleSpacechar]() {
+							goto l1556
+						}
+						goto l1555
+					l1556:
+						position, thunkPosition = position1555, thunkPosition1555
+						if !p.rules[ruleNewline]() {
+							goto l1552
+						}
+						{
+							position1557, thunkPosition1557 := position, thunkPosition
+							if !p.rules[ruleBlankLine]() {
+								goto l1557
+							}
+							goto l1552
+						l1557:
+							position, thunkPosition = position1557, thunkPosition1557
+						}
+					}
+				l1555:
+				l1553:
+					{
+						position1554, thunkPosition1554 := position, thunkPosition
+						{
+							position1558, thunkPosition1558 := position, thunkPosition
+							if !p.rules[ruleSpacechar]() {
+								goto l1559
+							}
+							goto l1558
+						l1559:
+							position, thunkPosition = position1558, thunkPosition1558
+							if !p.rules[ruleNewline]() {
+								goto l1554
+							}
+							{
+								position1560, thunkPosition1560 := position, thunkPosition
+								if !p.rules[ruleBlankLine]() {
+									goto l1560
+								}
+								goto l1554
+							l1560:
+								position, thunkPosition = position1560, thunkPosition1560
+							}
+						}
+					l1558:
+						goto l1553
+					l1554:
+						position, thunkPosition = position1554, thunkPosition1554
+					}
+					if peekChar('$') {
+						goto l1552
+					}
+					goto l1550
+				l1552:
+					position, thunkPosition = position1550, thunkPosition1550
+					if peekChar('$') {
+						goto l1536
+					}
+					{
+						position1561, thunkPosition1561 := position, thunkPosition
+						if !p.rules[ruleSpacechar]() {
+							goto l1561
+						}
+						goto l1536
+					l1561:
+						position, thunkPosition = position1561, thunkPosition1561
+					}
+					{
+						position1562, thunkPosition1562 := position, thunkPosition
+						if !p.rules[ruleNewline]() {
+							goto l1562
+						}
+						goto l1536
+					l1562:
+						position, thunkPosition = position1562, thunkPosition1562
+					}
+					if !matchDot() {
+						goto l1536
+					}
 				}
-				goto l1226
-			l1309:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\322") {
-					goto l1310
+			l1550:
+				goto l1535
+			l1536:
+				position, thunkPosition = position1536, thunkPosition1536
+			}
+			end = position
+			if !matchChar('$') {
+				goto l1533
+			}
+			{
+				position1563, thunkPosition1563 := position, thunkPosition
+				if !p.rules[ruleDigit]() {
+					goto l1563
 				}
-				goto l1226
-			l1310:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\323") {
-					goto l1311
+				goto l1533
+			l1563:
+				position, thunkPosition = position1563, thunkPosition1563
+			}
+			do(150)
+			return true
+		l1533:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 263 RawHtml <- (< (HtmlComment / HtmlTag) > { yy = p.rawHTML(yytext, false) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			begin = position
+			{
+				position1565, thunkPosition1565 := position, thunkPosition
+				if !p.rules[ruleHtmlComment]() {
+					goto l1566
 				}
-				goto l1226
-			l1311:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\324") {
-					goto l1312
+				goto l1565
+			l1566:
+				position, thunkPosition = position1565, thunkPosition1565
+				if !p.rules[ruleHtmlTag]() {
+					goto l1564
 				}
-				goto l1226
-			l1312:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\325") {
-					goto l1313
+			}
+		l1565:
+			end = position
+			do(151)
+			return true
+		l1564:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 264 BlankLine <- (Sp Newline) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !p.rules[ruleSp]() {
+				goto l1567
+			}
+			if !p.rules[ruleNewline]() {
+				goto l1567
+			}
+			return true
+		l1567:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 265 Quoted <- (('"' (!'"' .)* '"') / ('\'' (!'\'' .)* '\'')) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1569, thunkPosition1569 := position, thunkPosition
+				if !matchChar('"') {
+					goto l1570
 				}
-				goto l1226
-			l1313:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\326") {
-					goto l1314
+			l1571:
+				{
+					position1572, thunkPosition1572 := position, thunkPosition
+					if peekChar('"') {
+						goto l1572
+					}
+					if !matchDot() {
+						goto l1572
+					}
+					goto l1571
+				l1572:
+					position, thunkPosition = position1572, thunkPosition1572
 				}
-				goto l1226
-			l1314:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\327") {
-					goto l1315
+				if !matchChar('"') {
+					goto l1570
 				}
-				goto l1226
-			l1315:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\330") {
-					goto l1316
+				goto l1569
+			l1570:
+				position, thunkPosition = position1569, thunkPosition1569
+				if !matchChar('\'') {
+					goto l1568
 				}
-				goto l1226
-			l1316:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\331") {
-					goto l1317
+			l1573:
+				{
+					position1574, thunkPosition1574 := position, thunkPosition
+					if peekChar('\'') {
+						goto l1574
+					}
+					if !matchDot() {
+						goto l1574
+					}
+					goto l1573
+				l1574:
+					position, thunkPosition = position1574, thunkPosition1574
 				}
-				goto l1226
-			l1317:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\332") {
-					goto l1318
+				if !matchChar('\'') {
+					goto l1568
 				}
-				goto l1226
-			l1318:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\333") {
-					goto l1319
+			}
+		l1569:
+			return true
+		l1568:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 266 HtmlAttribute <- ((AlphanumericAscii / '-')+ Spnl ('=' Spnl (Quoted / (!'>' Nonspacechar)+))? Spnl) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1578, thunkPosition1578 := position, thunkPosition
+				if !p.rules[ruleAlphanumericAscii]() {
+					goto l1579
 				}
-				goto l1226
-			l1319:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\334") {
-					goto l1320
+				goto l1578
+			l1579:
+				position, thunkPosition = position1578, thunkPosition1578
+				if !matchChar('-') {
+					goto l1575
 				}
-				goto l1226
-			l1320:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\335") {
-					goto l1321
+			}
+		l1578:
+		l1576:
+			{
+				position1577, thunkPosition1577 := position, thunkPosition
+				{
+					position1580, thunkPosition1580 := position, thunkPosition
+					if !p.rules[ruleAlphanumericAscii]() {
+						goto l1581
+					}
+					goto l1580
+				l1581:
+					position, thunkPosition = position1580, thunkPosition1580
+					if !matchChar('-') {
+						goto l1577
+					}
 				}
-				goto l1226
-			l1321:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\336") {
-					goto l1322
+			l1580:
+				goto l1576
+			l1577:
+				position, thunkPosition = position1577, thunkPosition1577
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l1575
+			}
+			{
+				position1582, thunkPosition1582 := position, thunkPosition
+				if !matchChar('=') {
+					goto l1582
 				}
-				goto l1226
-			l1322:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\337") {
-					goto l1323
+				if !p.rules[ruleSpnl]() {
+					goto l1582
 				}
-				goto l1226
-			l1323:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\340") {
-					goto l1324
+				{
+					position1584, thunkPosition1584 := position, thunkPosition
+					if !p.rules[ruleQuoted]() {
+						goto l1585
+					}
+					goto l1584
+				l1585:
+					position, thunkPosition = position1584, thunkPosition1584
+					if peekChar('>') {
+						goto l1582
+					}
+					if !p.rules[ruleNonspacechar]() {
+						goto l1582
+					}
+				l1586:
+					{
+						position1587, thunkPosition1587 := position, thunkPosition
+						if peekChar('>') {
+							goto l1587
+						}
+						if !p.rules[ruleNonspacechar]() {
+							goto l1587
+						}
+						goto l1586
+					l1587:
+						position, thunkPosition = position1587, thunkPosition1587
+					}
 				}
-				goto l1226
-			l1324:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\341") {
-					goto l1325
+			l1584:
+				goto l1583
+			l1582:
+				position, thunkPosition = position1582, thunkPosition1582
+			}
+		l1583:
+			if !p.rules[ruleSpnl]() {
+				goto l1575
+			}
+			return true
+		l1575:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 267 HtmlComment <- ('<!--' (!'-->' .)* '-->') */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !matchString("<!--") {
+				goto l1588
+			}
+		l1589:
+			{
+				position1590, thunkPosition1590 := position, thunkPosition
+				{
+					position1591, thunkPosition1591 := position, thunkPosition
+					if !matchString("-->") {
+						goto l1591
+					}
+					goto l1590
+				l1591:
+					position, thunkPosition = position1591, thunkPosition1591
 				}
-				goto l1226
-			l1325:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\342") {
-					goto l1326
+				if !matchDot() {
+					goto l1590
 				}
-				goto l1226
-			l1326:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\343") {
-					goto l1327
+				goto l1589
+			l1590:
+				position, thunkPosition = position1590, thunkPosition1590
+			}
+			if !matchString("-->") {
+				goto l1588
+			}
+			return true
+		l1588:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 268 HtmlTag <- ('<' Spnl '/'? AlphanumericAscii+ Spnl HtmlAttribute* '/'? Spnl '>') */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !matchChar('<') {
+				goto l1592
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l1592
+			}
+			{
+				position1593, thunkPosition1593 := position, thunkPosition
+				if !matchChar('/') {
+					goto l1593
 				}
-				goto l1226
-			l1327:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\344") {
-					goto l1328
+				goto l1594
+			l1593:
+				position, thunkPosition = position1593, thunkPosition1593
+			}
+		l1594:
+			if !p.rules[ruleAlphanumericAscii]() {
+				goto l1592
+			}
+		l1595:
+			{
+				position1596, thunkPosition1596 := position, thunkPosition
+				if !p.rules[ruleAlphanumericAscii]() {
+					goto l1596
 				}
-				goto l1226
-			l1328:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\345") {
-					goto l1329
+				goto l1595
+			l1596:
+				position, thunkPosition = position1596, thunkPosition1596
+			}
+			if !p.rules[ruleSpnl]() {
+				goto l1592
+			}
+		l1597:
+			{
+				position1598, thunkPosition1598 := position, thunkPosition
+				if !p.rules[ruleHtmlAttribute]() {
+					goto l1598
 				}
-				goto l1226
-			l1329:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\346") {
-					goto l1330
+				goto l1597
+			l1598:
+				position, thunkPosition = position1598, thunkPosition1598
+			}
+			{
+				position1599, thunkPosition1599 := position, thunkPosition
+				if !matchChar('/') {
+					goto l1599
 				}
-				goto l1226
-			l1330:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\347") {
-					goto l1331
+				goto l1600
+			l1599:
+				position, thunkPosition = position1599, thunkPosition1599
+			}
+		l1600:
+			if !p.rules[ruleSpnl]() {
+				goto l1592
+			}
+			if !matchChar('>') {
+				goto l1592
+			}
+			return true
+		l1592:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 269 Eof <- !. */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if peekDot() {
+				goto l1601
+			}
+			return true
+		l1601:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 270 Spacechar <- (' ' / '\t') */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1603, thunkPosition1603 := position, thunkPosition
+				if !matchChar(' ') {
+					goto l1604
 				}
-				goto l1226
-			l1331:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\350") {
-					goto l1332
+				goto l1603
+			l1604:
+				position, thunkPosition = position1603, thunkPosition1603
+				if !matchChar('\t') {
+					goto l1602
 				}
-				goto l1226
-			l1332:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\351") {
-					goto l1333
+			}
+		l1603:
+			return true
+		l1602:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 271 Nonspacechar <- (!Spacechar !Newline .) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1606, thunkPosition1606 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l1606
 				}
-				goto l1226
-			l1333:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\352") {
-					goto l1334
+				goto l1605
+			l1606:
+				position, thunkPosition = position1606, thunkPosition1606
+			}
+			{
+				position1607, thunkPosition1607 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l1607
 				}
-				goto l1226
-			l1334:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\353") {
-					goto l1335
+				goto l1605
+			l1607:
+				position, thunkPosition = position1607, thunkPosition1607
+			}
+			if !matchDot() {
+				goto l1605
+			}
+			return true
+		l1605:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 272 Newline <- ('\n' / ('\r' '\n'?)) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1609, thunkPosition1609 := position, thunkPosition
+				if !matchChar('\n') {
+					goto l1610
 				}
-				goto l1226
-			l1335:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\354") {
-					goto l1336
+				goto l1609
+			l1610:
+				position, thunkPosition = position1609, thunkPosition1609
+				if !matchChar('\r') {
+					goto l1608
 				}
-				goto l1226
-			l1336:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\355") {
-					goto l1337
+				{
+					position1611, thunkPosition1611 := position, thunkPosition
+					if !matchChar('\n') {
+						goto l1611
+					}
+					goto l1612
+				l1611:
+					position, thunkPosition = position1611, thunkPosition1611
 				}
-				goto l1226
-			l1337:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\356") {
-					goto l1338
+			l1612:
+			}
+		l1609:
+			return true
+		l1608:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 273 Sp <- Spacechar* */
+		func() bool {
+		l1614:
+			{
+				position1615, thunkPosition1615 := position, thunkPosition
+				if !p.rules[ruleSpacechar]() {
+					goto l1615
 				}
-				goto l1226
-			l1338:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\357") {
-					goto l1339
+				goto l1614
+			l1615:
+				position, thunkPosition = position1615, thunkPosition1615
+			}
+			return true
+		},
+		/* 274 Spnl <- (Sp (Newline Sp)?) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !p.rules[ruleSp]() {
+				goto l1616
+			}
+			{
+				position1617, thunkPosition1617 := position, thunkPosition
+				if !p.rules[ruleNewline]() {
+					goto l1617
 				}
-				goto l1226
-			l1339:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\360") {
-					goto l1340
+				if !p.rules[ruleSp]() {
+					goto l1617
 				}
-				goto l1226
-			l1340:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\361") {
-					goto l1341
+				goto l1618
+			l1617:
+				position, thunkPosition = position1617, thunkPosition1617
+			}
+		l1618:
+			return true
+		l1616:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 275 SpecialChar <- ('*' / '_' / '`' / '&' / '[' / ']' / '<' / '!' / '#' / '\\' / ExtendedSpecialChar) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1620, thunkPosition1620 := position, thunkPosition
+				if !matchChar('*') {
+					goto l1621
 				}
-				goto l1226
-			l1341:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\362") {
-					goto l1342
+				goto l1620
+			l1621:
+				position, thunkPosition = position1620, thunkPosition1620
+				if !matchChar('_') {
+					goto l1622
 				}
-				goto l1226
-			l1342:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\363") {
-					goto l1343
+				goto l1620
+			l1622:
+				position, thunkPosition = position1620, thunkPosition1620
+				if !matchChar('`') {
+					goto l1623
 				}
-				goto l1226
-			l1343:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\364") {
-					goto l1344
+				goto l1620
+			l1623:
+				position, thunkPosition = position1620, thunkPosition1620
+				if !matchChar('&') {
+					goto l1624
 				}
-				goto l1226
-			l1344:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\365") {
-					goto l1345
+				goto l1620
+			l1624:
+				position, thunkPosition = position1620, thunkPosition1620
+				if !matchChar('[') {
+					goto l1625
 				}
-				goto l1226
-			l1345:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\366") {
-					goto l1346
+				goto l1620
+			l1625:
+				position, thunkPosition = position1620, thunkPosition1620
+				if !matchChar(']') {
+					goto l1626
 				}
-				goto l1226
-			l1346:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\367") {
-					goto l1347
+				goto l1620
+			l1626:
+				position, thunkPosition = position1620, thunkPosition1620
+				if !matchChar('<') {
+					goto l1627
 				}
-				goto l1226
-			l1347:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\370") {
-					goto l1348
+				goto l1620
+			l1627:
+				position, thunkPosition = position1620, thunkPosition1620
+				if !matchChar('!') {
+					goto l1628
 				}
-				goto l1226
-			l1348:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\371") {
-					goto l1349
+				goto l1620
+			l1628:
+				position, thunkPosition = position1620, thunkPosition1620
+				if !matchChar('#') {
+					goto l1629
 				}
-				goto l1226
-			l1349:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\372") {
-					goto l1350
+				goto l1620
+			l1629:
+				position, thunkPosition = position1620, thunkPosition1620
+				if !matchChar('\\') {
+					goto l1630
 				}
-				goto l1226
-			l1350:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\373") {
-					goto l1351
+				goto l1620
+			l1630:
+				position, thunkPosition = position1620, thunkPosition1620
+				if !p.rules[ruleExtendedSpecialChar]() {
+					goto l1619
 				}
-				goto l1226
-			l1351:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\374") {
-					goto l1352
+			}
+		l1620:
+			return true
+		l1619:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 276 NormalChar <- (!(SpecialChar / Spacechar / Newline) .) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1632, thunkPosition1632 := position, thunkPosition
+				{
+					position1633, thunkPosition1633 := position, thunkPosition
+					if !p.rules[ruleSpecialChar]() {
+						goto l1634
+					}
+					goto l1633
+				l1634:
+					position, thunkPosition = position1633, thunkPosition1633
+					if !p.rules[ruleSpacechar]() {
+						goto l1635
+					}
+					goto l1633
+				l1635:
+					position, thunkPosition = position1633, thunkPosition1633
+					if !p.rules[ruleNewline]() {
+						goto l1632
+					}
 				}
-				goto l1226
-			l1352:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\375") {
-					goto l1353
+			l1633:
+				goto l1631
+			l1632:
+				position, thunkPosition = position1632, thunkPosition1632
+			}
+			if !matchDot() {
+				goto l1631
+			}
+			return true
+		l1631:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 277 NonAlphanumeric <- [\000-\057\072-\100\133-\140\173-\177] */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !matchClass(3) {
+				goto l1636
+			}
+			return true
+		l1636:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 278 Alphanumeric <- ([0-9A-Za-z] / (&{ p.alnumAt(position) } .)) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1638, thunkPosition1638 := position, thunkPosition
+				if !matchClass(1) {
+					goto l1639
 				}
-				goto l1226
-			l1353:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\376") {
-					goto l1354
+				goto l1638
+			l1639:
+				position, thunkPosition = position1638, thunkPosition1638
+				if !( p.alnumAt(position) ) {
+					goto l1637
 				}
-				goto l1226
-			l1354:
-				position, thunkPosition = position1226, thunkPosition1226
-				if !matchString("\377") {
-					goto l1225
+				if !matchDot() {
+					goto l1637
 				}
 			}
-		l1226:
+		l1638:
 			return true
-		l1225:
+		l1637:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 210 AlphanumericAscii <- [A-Za-z0-9] */
+		/* 279 AlphanumericAscii <- [A-Za-z0-9] */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchClass(8) {
-				goto l1355
+				goto l1640
 			}
 			return true
-		l1355:
+		l1640:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 280 WideSpace <- (&{ p.spaceAt(position) } .) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !( p.spaceAt(position) ) {
+				goto l1641
+			}
+			if !matchDot() {
+				goto l1641
+			}
+			return true
+		l1641:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 281 AfterPunct <- &{ p.afterPunct(position) } */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !( p.afterPunct(position) ) {
+				goto l1642
+			}
+			return true
+		l1642:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 282 ClosingPunct <- (&{ p.closingAt(position) } .) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !( p.closingAt(position) ) {
+				goto l1643
+			}
+			if !matchDot() {
+				goto l1643
+			}
+			return true
+		l1643:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 211 Digit <- [0-9] */
+		/* 283 Digit <- [0-9] */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchClass(7) {
-				goto l1356
+				goto l1644
 			}
 			return true
-		l1356:
+		l1644:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 212 HexEntity <- (< '&' '#' [Xx] [0-9a-fA-F]+ ';' >) */
+		/* 284 HexEntity <- (< '&' '#' [Xx] [0-9a-fA-F]+ ';' >) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			begin = position
 			if !matchChar('&') {
-				goto l1357
+				goto l1645
 			}
 			if !matchChar('#') {
-				goto l1357
+				goto l1645
 			}
 			if !matchClass(5) {
-				goto l1357
+				goto l1645
 			}
 			if !matchClass(0) {
-				goto l1357
+				goto l1645
 			}
-		l1358:
+		l1646:
 			{
-				position1359, thunkPosition1359 := position, thunkPosition
+				position1647, thunkPosition1647 := position, thunkPosition
 				if !matchClass(0) {
-					goto l1359
+					goto l1647
 				}
-				goto l1358
-			l1359:
-				position, thunkPosition = position1359, thunkPosition1359
+				goto l1646
+			l1647:
+				position, thunkPosition = position1647, thunkPosition1647
 			}
 			if !matchChar(';') {
-				goto l1357
+				goto l1645
 			}
 			end = position
 			return true
-		l1357:
+		l1645:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 213 DecEntity <- (< '&' '#' [0-9]+ > ';' >) */
+		/* 285 DecEntity <- (< '&' '#' [0-9]+ > ';' >) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			begin = position
 			if !matchChar('&') {
-				goto l1360
+				goto l1648
 			}
 			if !matchChar('#') {
-				goto l1360
+				goto l1648
 			}
 			if !matchClass(7) {
-				goto l1360
+				goto l1648
 			}
-		l1361:
+		l1649:
 			{
-				position1362, thunkPosition1362 := position, thunkPosition
+				position1650, thunkPosition1650 := position, thunkPosition
 				if !matchClass(7) {
-					goto l1362
+					goto l1650
 				}
-				goto l1361
-			l1362:
-				position, thunkPosition = position1362, thunkPosition1362
+				goto l1649
+			l1650:
+				position, thunkPosition = position1650, thunkPosition1650
 			}
 			end = position
 			if !matchChar(';') {
-				goto l1360
+				goto l1648
 			}
 			end = position
 			return true
-		l1360:
+		l1648:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 214 CharEntity <- (< '&' [A-Za-z0-9]+ ';' >) */
+		/* 286 CharEntity <- (< '&' [A-Za-z0-9]+ ';' >) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			begin = position
 			if !matchChar('&') {
-				goto l1363
+				goto l1651
 			}
 			if !matchClass(8) {
-				goto l1363
+				goto l1651
 			}
-		l1364:
+		l1652:
 			{
-				position1365, thunkPosition1365 := position, thunkPosition
+				position1653, thunkPosition1653 := position, thunkPosition
 				if !matchClass(8) {
-					goto l1365
+					goto l1653
 				}
-				goto l1364
-			l1365:
-				position, thunkPosition = position1365, thunkPosition1365
+				goto l1652
+			l1653:
+				position, thunkPosition = position1653, thunkPosition1653
 			}
 			if !matchChar(';') {
-				goto l1363
+				goto l1651
 			}
 			end = position
 			return true
-		l1363:
+		l1651:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 215 NonindentSpace <- ('   ' / '  ' / ' ' / '') */
+		/* 287 NonindentSpace <- ('   ' / '  ' / ' ' / '') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			{
-				position1367, thunkPosition1367 := position, thunkPosition
+				position1655, thunkPosition1655 := position, thunkPosition
 				if !matchString("   ") {
-					goto l1368
+					goto l1656
 				}
-				goto l1367
-			l1368:
-				position, thunkPosition = position1367, thunkPosition1367
+				goto l1655
+			l1656:
+				position, thunkPosition = position1655, thunkPosition1655
 				if !matchString("  ") {
-					goto l1369
+					goto l1657
 				}
-				goto l1367
-			l1369:
-				position, thunkPosition = position1367, thunkPosition1367
+				goto l1655
+			l1657:
+				position, thunkPosition = position1655, thunkPosition1655
 				if !matchChar(' ') {
-					goto l1370
+					goto l1658
 				}
-				goto l1367
-			l1370:
-				position, thunkPosition = position1367, thunkPosition1367
+				goto l1655
+			l1658:
+				position, thunkPosition = position1655, thunkPosition1655
 				if !matchString("") {
-					goto l1366
+					goto l1654
 				}
 			}
-		l1367:
+		l1655:
 			return true
-		l1366:
+		l1654:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 216 Indent <- ('\t' / '    ') */
+		/* 288 Indent <- ('\t' / '    ') */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			{
-				position1372, thunkPosition1372 := position, thunkPosition
+				position1660, thunkPosition1660 := position, thunkPosition
 				if !matchChar('\t') {
-					goto l1373
+					goto l1661
 				}
-				goto l1372
-			l1373:
-				position, thunkPosition = position1372, thunkPosition1372
+				goto l1660
+			l1661:
+				position, thunkPosition = position1660, thunkPosition1660
 				if !matchString("    ") {
-					goto l1371
+					goto l1659
 				}
 			}
-		l1372:
+		l1660:
 			return true
-		l1371:
+		l1659:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 217 IndentedLine <- (Indent Line) */
+		/* 289 IndentedLine <- (Indent Line) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !p.rules[ruleIndent]() {
-				goto l1374
+				goto l1662
 			}
 			if !p.rules[ruleLine]() {
-				goto l1374
+				goto l1662
 			}
 			return true
-		l1374:
+		l1662:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 218 OptionallyIndentedLine <- (Indent? Line) */
+		/* 290 OptionallyIndentedLine <- (Indent? Line) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			{
-				position1376, thunkPosition1376 := position, thunkPosition
+				position1664, thunkPosition1664 := position, thunkPosition
 				if !p.rules[ruleIndent]() {
-					goto l1376
+					goto l1664
 				}
-				goto l1377
-			l1376:
-				position, thunkPosition = position1376, thunkPosition1376
+				goto l1665
+			l1664:
+				position, thunkPosition = position1664, thunkPosition1664
 			}
-		l1377:
+		l1665:
 			if !p.rules[ruleLine]() {
-				goto l1375
+				goto l1663
 			}
 			return true
-		l1375:
+		l1663:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 219 StartList <- (&. { yy = nil }) */
+		/* 291 StartList <- (&. { yy = nil }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !peekDot() {
-				goto l1378
+				goto l1666
 			}
-			do(87)
+			do(152)
 			return true
-		l1378:
+		l1666:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 220 Line <- (RawLine { yy = mk_str(yytext) }) */
+		/* 292 Line <- (RawLine { yy = mk_str(yytext) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !p.rules[ruleRawLine]() {
-				goto l1379
+				goto l1667
 			}
-			do(88)
+			do(153)
 			return true
-		l1379:
+		l1667:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 221 RawLine <- ((< (!'\r' !'\n' .)* Newline >) / (< .+ > Eof)) */
+		/* 293 RawLine <- ((< (!'\r' !'\n' .)* Newline >) / (< .+ > Eof)) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			{
-				position1381, thunkPosition1381 := position, thunkPosition
+				position1669, thunkPosition1669 := position, thunkPosition
 				begin = position
-			l1383:
+			l1671:
 				{
-					position1384, thunkPosition1384 := position, thunkPosition
+					position1672, thunkPosition1672 := position, thunkPosition
 					if peekChar('\r') {
-						goto l1384
+						goto l1672
 					}
 					if peekChar('\n') {
-						goto l1384
+						goto l1672
 					}
 					if !matchDot() {
-						goto l1384
+						goto l1672
 					}
-					goto l1383
-				l1384:
-					position, thunkPosition = position1384, thunkPosition1384
+					goto l1671
+				l1672:
+					position, thunkPosition = position1672, thunkPosition1672
 				}
 				if !p.rules[ruleNewline]() {
-					goto l1382
+					goto l1670
 				}
 				end = position
-				goto l1381
-			l1382:
-				position, thunkPosition = position1381, thunkPosition1381
+				goto l1669
+			l1670:
+				position, thunkPosition = position1669, thunkPosition1669
 				begin = position
 				if !matchDot() {
-					goto l1380
+					goto l1668
 				}
-			l1385:
+			l1673:
 				{
-					position1386, thunkPosition1386 := position, thunkPosition
+					position1674, thunkPosition1674 := position, thunkPosition
 					if !matchDot() {
-						goto l1386
+						goto l1674
 					}
-					goto l1385
-				l1386:
-					position, thunkPosition = position1386, thunkPosition1386
+					goto l1673
+				l1674:
+					position, thunkPosition = position1674, thunkPosition1674
 				}
 				end = position
 				if !p.rules[ruleEof]() {
-					goto l1380
+					goto l1668
 				}
 			}
-		l1381:
+		l1669:
 			return true
-		l1380:
+		l1668:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 222 SkipBlock <- (((!BlankLine RawLine)+ BlankLine*) / BlankLine+) */
+		/* 294 SkipBlock <- (((!BlankLine RawLine)+ BlankLine*) / BlankLine+) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			{
-				position1388, thunkPosition1388 := position, thunkPosition
+				position1676, thunkPosition1676 := position, thunkPosition
 				{
-					position1392, thunkPosition1392 := position, thunkPosition
+					position1680, thunkPosition1680 := position, thunkPosition
 					if !p.rules[ruleBlankLine]() {
-						goto l1392
+						goto l1680
 					}
-					goto l1389
-				l1392:
-					position, thunkPosition = position1392, thunkPosition1392
+					goto l1677
+				l1680:
+					position, thunkPosition = position1680, thunkPosition1680
 				}
 				if !p.rules[ruleRawLine]() {
-					goto l1389
+					goto l1677
 				}
-			l1390:
+			l1678:
 				{
-					position1391, thunkPosition1391 := position, thunkPosition
+					position1679, thunkPosition1679 := position, thunkPosition
 					{
-						position1393, thunkPosition1393 := position, thunkPosition
+						position1681, thunkPosition1681 := position, thunkPosition
 						if !p.rules[ruleBlankLine]() {
-							goto l1393
+							goto l1681
 						}
-						goto l1391
-					l1393:
-						position, thunkPosition = position1393, thunkPosition1393
+						goto l1679
+					l1681:
+						position, thunkPosition = position1681, thunkPosition1681
 					}
 					if !p.rules[ruleRawLine]() {
-						goto l1391
+						goto l1679
 					}
-					goto l1390
-				l1391:
-					position, thunkPosition = position1391, thunkPosition1391
+					goto l1678
+				l1679:
+					position, thunkPosition = position1679, thunkPosition1679
 				}
-			l1394:
+			l1682:
 				{
-					position1395, thunkPosition1395 := position, thunkPosition
+					position1683, thunkPosition1683 := position, thunkPosition
 					if !p.rules[ruleBlankLine]() {
-						goto l1395
+						goto l1683
 					}
-					goto l1394
-				l1395:
-					position, thunkPosition = position1395, thunkPosition1395
+					goto l1682
+				l1683:
+					position, thunkPosition = position1683, thunkPosition1683
 				}
-				goto l1388
-			l1389:
-				position, thunkPosition = position1388, thunkPosition1388
+				goto l1676
+			l1677:
+				position, thunkPosition = position1676, thunkPosition1676
 				if !p.rules[ruleBlankLine]() {
-					goto l1387
+					goto l1675
 				}
-			l1396:
+			l1684:
 				{
-					position1397, thunkPosition1397 := position, thunkPosition
+					position1685, thunkPosition1685 := position, thunkPosition
 					if !p.rules[ruleBlankLine]() {
-						goto l1397
+						goto l1685
 					}
-					goto l1396
-				l1397:
-					position, thunkPosition = position1397, thunkPosition1397
+					goto l1684
+				l1685:
+					position, thunkPosition = position1685, thunkPosition1685
 				}
 			}
-		l1388:
+		l1676:
 			return true
-		l1387:
+		l1675:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 223 ExtendedSpecialChar <- ((&{ p.extension.Smart } ('.' / '-' / '\'' / '"')) / (&{ p.extension.Notes } '^')) */
+		/* 295 ExtendedSpecialChar <- ((&{ p.extension.Smart } ('.' / '-' / '\'' / '"')) / (&{ p.extension.Notes } '^') / (&{ p.extension.Tables } '|') / (&{ p.extension.Strike } '~') / (&{ p.extension.Autolink } '(') / (&{ p.extension.Math } '$') / (&{ p.extension.SupSub } ('^' / '~')) / (&{ p.extension.Emoji } ':') / (&{ p.extension.Citations } '@') / (&{ p.pluginChar(position) } .) / (&{ p.templateChar(position) } .) / ExtSpecialChar) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			{
-				position1399, thunkPosition1399 := position, thunkPosition
+				position1687, thunkPosition1687 := position, thunkPosition
 				if !( p.extension.Smart ) {
-					goto l1400
+					goto l1688
 				}
 				{
-					position1401, thunkPosition1401 := position, thunkPosition
+					position1689, thunkPosition1689 := position, thunkPosition
 					if !matchChar('.') {
-						goto l1402
+						goto l1690
 					}
-					goto l1401
-				l1402:
-					position, thunkPosition = position1401, thunkPosition1401
+					goto l1689
+				l1690:
+					position, thunkPosition = position1689, thunkPosition1689
 					if !matchChar('-') {
-						goto l1403
+						goto l1691
 					}
-					goto l1401
-				l1403:
-					position, thunkPosition = position1401, thunkPosition1401
+					goto l1689
+				l1691:
+					position, thunkPosition = position1689, thunkPosition1689
 					if !matchChar('\'') {
-						goto l1404
+						goto l1692
 					}
-					goto l1401
-				l1404:
-					position, thunkPosition = position1401, thunkPosition1401
+					goto l1689
+				l1692:
+					position, thunkPosition = position1689, thunkPosition1689
 					if !matchChar('"') {
-						goto l1400
+						goto l1688
 					}
 				}
-			l1401:
-				goto l1399
-			l1400:
-				position, thunkPosition = position1399, thunkPosition1399
+			l1689:
+				goto l1687
+			l1688:
+				position, thunkPosition = position1687, thunkPosition1687
 				if !( p.extension.Notes ) {
-					goto l1398
+					goto l1693
 				}
 				if !matchChar('^') {
-					goto l1398
+					goto l1693
+				}
+				goto l1687
+			l1693:
+				position, thunkPosition = position1687, thunkPosition1687
+				if !( p.extension.Tables ) {
+					goto l1694
+				}
+				if !matchChar('|') {
+					goto l1694
+				}
+				goto l1687
+			l1694:
+				position, thunkPosition = position1687, thunkPosition1687
+				if !( p.extension.Strike ) {
+					goto l1695
+				}
+				if !matchChar('~') {
+					goto l1695
+				}
+				goto l1687
+			l1695:
+				position, thunkPosition = position1687, thunkPosition1687
+				if !( p.extension.Autolink ) {
+					goto l1696
+				}
+				if !matchChar('(') {
+					goto l1696
+				}
+				goto l1687
+			l1696:
+				position, thunkPosition = position1687, thunkPosition1687
+				if !( p.extension.Math ) {
+					goto l1697
+				}
+				if !matchChar('$') {
+					goto l1697
+				}
+				goto l1687
+			l1697:
+				position, thunkPosition = position1687, thunkPosition1687
+				if !( p.extension.SupSub ) {
+					goto l1698
+				}
+				{
+					position1699, thunkPosition1699 := position, thunkPosition
+					if !matchChar('^') {
+						goto l1700
+					}
+					goto l1699
+				l1700:
+					position, thunkPosition = position1699, thunkPosition1699
+					if !matchChar('~') {
+						goto l1698
+					}
+				}
+			l1699:
+				goto l1687
+			l1698:
+				position, thunkPosition = position1687, thunkPosition1687
+				if !( p.extension.Emoji ) {
+					goto l1701
+				}
+				if !matchChar(':') {
+					goto l1701
+				}
+				goto l1687
+			l1701:
+				position, thunkPosition = position1687, thunkPosition1687
+				if !( p.extension.Citations ) {
+					goto l1702
+				}
+				if !matchChar('@') {
+					goto l1702
+				}
+				goto l1687
+			l1702:
+				position, thunkPosition = position1687, thunkPosition1687
+				if !( p.pluginChar(position) ) {
+					goto l1703
+				}
+				if !matchDot() {
+					goto l1703
+				}
+				goto l1687
+			l1703:
+				position, thunkPosition = position1687, thunkPosition1687
+				if !( p.templateChar(position) ) {
+					goto l1704
+				}
+				if !matchDot() {
+					goto l1704
+				}
+				goto l1687
+			l1704:
+				position, thunkPosition = position1687, thunkPosition1687
+				if !p.rules[ruleExtSpecialChar]() {
+					goto l1686
 				}
 			}
-		l1399:
+		l1687:
 			return true
-		l1398:
+		l1686:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 224 Smart <- (&{ p.extension.Smart } (Ellipsis / Dash / SingleQuoted / DoubleQuoted / Apostrophe)) */
+		/* 296 Smart <- (&{ p.extension.Smart } (Arrow / Ellipsis / Dash / SingleQuoted / DoubleQuoted / Apostrophe)) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !( p.extension.Smart ) {
-				goto l1405
+				goto l1705
 			}
 			{
-				position1406, thunkPosition1406 := position, thunkPosition
+				position1706, thunkPosition1706 := position, thunkPosition
+				if !p.rules[ruleArrow]() {
+					goto l1707
+				}
+				goto l1706
+			l1707:
+				position, thunkPosition = position1706, thunkPosition1706
 				if !p.rules[ruleEllipsis]() {
-					goto l1407
+					goto l1708
 				}
-				goto l1406
-			l1407:
-				position, thunkPosition = position1406, thunkPosition1406
+				goto l1706
+			l1708:
+				position, thunkPosition = position1706, thunkPosition1706
 				if !p.rules[ruleDash]() {
-					goto l1408
+					goto l1709
 				}
-				goto l1406
-			l1408:
-				position, thunkPosition = position1406, thunkPosition1406
+				goto l1706
+			l1709:
+				position, thunkPosition = position1706, thunkPosition1706
 				if !p.rules[ruleSingleQuoted]() {
-					goto l1409
+					goto l1710
 				}
-				goto l1406
-			l1409:
-				position, thunkPosition = position1406, thunkPosition1406
+				goto l1706
+			l1710:
+				position, thunkPosition = position1706, thunkPosition1706
 				if !p.rules[ruleDoubleQuoted]() {
-					goto l1410
+					goto l1711
 				}
-				goto l1406
-			l1410:
-				position, thunkPosition = position1406, thunkPosition1406
+				goto l1706
+			l1711:
+				position, thunkPosition = position1706, thunkPosition1706
 				if !p.rules[ruleApostrophe]() {
-					goto l1405
+					goto l1705
 				}
 			}
-		l1406:
+		l1706:
+			return true
+		l1705:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 297 Arrow <- (&{ p.smart.Arrows } < ('<-->' / '<->' / '<--' / '<-' / '-->' / '->') > { yy = mk_str(arrows[yytext]) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !( p.smart.Arrows ) {
+				goto l1712
+			}
+			begin = position
+			{
+				position1713, thunkPosition1713 := position, thunkPosition
+				if !matchString("<-->") {
+					goto l1714
+				}
+				goto l1713
+			l1714:
+				position, thunkPosition = position1713, thunkPosition1713
+				if !matchString("<->") {
+					goto l1715
+				}
+				goto l1713
+			l1715:
+				position, thunkPosition = position1713, thunkPosition1713
+				if !matchString("<--") {
+					goto l1716
+				}
+				goto l1713
+			l1716:
+				position, thunkPosition = position1713, thunkPosition1713
+				if !matchString("<-") {
+					goto l1717
+				}
+				goto l1713
+			l1717:
+				position, thunkPosition = position1713, thunkPosition1713
+				if !matchString("-->") {
+					goto l1718
+				}
+				goto l1713
+			l1718:
+				position, thunkPosition = position1713, thunkPosition1713
+				if !matchString("->") {
+					goto l1712
+				}
+			}
+		l1713:
+			end = position
+			do(154)
 			return true
-		l1405:
+		l1712:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 225 Apostrophe <- ('\'' { yy = mk_element(APOSTROPHE) }) */
+		/* 298 Apostrophe <- ('\'' { yy = mk_element(APOSTROPHE) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('\'') {
-				goto l1411
+				goto l1719
 			}
-			do(89)
+			do(155)
 			return true
-		l1411:
+		l1719:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 226 Ellipsis <- (('...' / '. . .') { yy = mk_element(ELLIPSIS) }) */
+		/* 299 Ellipsis <- (&{ !p.smart.NoEllipsis } ('...' / '. . .') { yy = mk_element(ELLIPSIS) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
+			if !( !p.smart.NoEllipsis ) {
+				goto l1720
+			}
 			{
-				position1413, thunkPosition1413 := position, thunkPosition
+				position1721, thunkPosition1721 := position, thunkPosition
 				if !matchString("...") {
-					goto l1414
+					goto l1722
 				}
-				goto l1413
-			l1414:
-				position, thunkPosition = position1413, thunkPosition1413
+				goto l1721
+			l1722:
+				position, thunkPosition = position1721, thunkPosition1721
 				if !matchString(". . .") {
-					goto l1412
+					goto l1720
 				}
 			}
-		l1413:
-			do(90)
+		l1721:
+			do(156)
 			return true
-		l1412:
+		l1720:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 227 Dash <- (EmDash / EnDash) */
+		/* 300 Dash <- (&{ !p.smart.NoDashes } (EmDash / EnDash)) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
+			if !( !p.smart.NoDashes ) {
+				goto l1723
+			}
 			{
-				position1416, thunkPosition1416 := position, thunkPosition
+				position1724, thunkPosition1724 := position, thunkPosition
 				if !p.rules[ruleEmDash]() {
-					goto l1417
+					goto l1725
 				}
-				goto l1416
-			l1417:
-				position, thunkPosition = position1416, thunkPosition1416
+				goto l1724
+			l1725:
+				position, thunkPosition = position1724, thunkPosition1724
 				if !p.rules[ruleEnDash]() {
-					goto l1415
+					goto l1723
 				}
 			}
-		l1416:
+		l1724:
 			return true
-		l1415:
+		l1723:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 228 EnDash <- ('-' &Digit { yy = mk_element(ENDASH) }) */
+		/* 301 EnDash <- ('-' &Digit { yy = mk_element(ENDASH) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('-') {
-				goto l1418
+				goto l1726
 			}
 			{
-				position1419, thunkPosition1419 := position, thunkPosition
+				position1727, thunkPosition1727 := position, thunkPosition
 				if !p.rules[ruleDigit]() {
-					goto l1418
+					goto l1726
 				}
-				position, thunkPosition = position1419, thunkPosition1419
+				position, thunkPosition = position1727, thunkPosition1727
 			}
-			do(91)
+			do(157)
 			return true
-		l1418:
+		l1726:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 229 EmDash <- (('---' / '--') { yy = mk_element(EMDASH) }) */
+		/* 302 EmDash <- (('---' / '--') { yy = mk_element(EMDASH) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			{
-				position1421, thunkPosition1421 := position, thunkPosition
+				position1729, thunkPosition1729 := position, thunkPosition
 				if !matchString("---") {
-					goto l1422
+					goto l1730
 				}
-				goto l1421
-			l1422:
-				position, thunkPosition = position1421, thunkPosition1421
+				goto l1729
+			l1730:
+				position, thunkPosition = position1729, thunkPosition1729
 				if !matchString("--") {
-					goto l1420
+					goto l1728
 				}
 			}
-		l1421:
-			do(92)
+		l1729:
+			do(158)
 			return true
-		l1420:
+		l1728:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 230 SingleQuoteStart <- ('\'' ![)!\],.;:-? \t\n] !(('s' / 't' / 'm' / 've' / 'll' / 're') !Alphanumeric)) */
+		/* 303 SingleQuoteStart <- ('\'' ![)!\],.;:-? \t\n] !ClosingPunct !(('s' / 't' / 'm' / 've' / 'll' / 're') !Alphanumeric)) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('\'') {
-				goto l1423
+				goto l1731
 			}
 			{
-				position1424, thunkPosition1424 := position, thunkPosition
+				position1732, thunkPosition1732 := position, thunkPosition
 				if !matchClass(6) {
-					goto l1424
+					goto l1732
+				}
+				goto l1731
+			l1732:
+				position, thunkPosition = position1732, thunkPosition1732
+			}
+			{
+				position1733, thunkPosition1733 := position, thunkPosition
+				if !p.rules[ruleClosingPunct]() {
+					goto l1733
 				}
-				goto l1423
-			l1424:
-				position, thunkPosition = position1424, thunkPosition1424
+				goto l1731
+			l1733:
+				position, thunkPosition = position1733, thunkPosition1733
 			}
 			{
-				position1425, thunkPosition1425 := position, thunkPosition
+				position1734, thunkPosition1734 := position, thunkPosition
 				{
-					position1426, thunkPosition1426 := position, thunkPosition
+					position1735, thunkPosition1735 := position, thunkPosition
 					if !matchChar('s') {
-						goto l1427
+						goto l1736
 					}
-					goto l1426
-				l1427:
-					position, thunkPosition = position1426, thunkPosition1426
+					goto l1735
+				l1736:
+					position, thunkPosition = position1735, thunkPosition1735
 					if !matchChar('t') {
-						goto l1428
+						goto l1737
 					}
-					goto l1426
-				l1428:
-					position, thunkPosition = position1426, thunkPosition1426
+					goto l1735
+				l1737:
+					position, thunkPosition = position1735, thunkPosition1735
 					if !matchChar('m') {
-						goto l1429
+						goto l1738
 					}
-					goto l1426
-				l1429:
-					position, thunkPosition = position1426, thunkPosition1426
+					goto l1735
+				l1738:
+					position, thunkPosition = position1735, thunkPosition1735
 					if !matchString("ve") {
-						goto l1430
+						goto l1739
 					}
-					goto l1426
-				l1430:
-					position, thunkPosition = position1426, thunkPosition1426
+					goto l1735
+				l1739:
+					position, thunkPosition = position1735, thunkPosition1735
 					if !matchString("ll") {
-						goto l1431
+						goto l1740
 					}
-					goto l1426
-				l1431:
-					position, thunkPosition = position1426, thunkPosition1426
+					goto l1735
+				l1740:
+					position, thunkPosition = position1735, thunkPosition1735
 					if !matchString("re") {
-						goto l1425
+						goto l1734
 					}
 				}
-			l1426:
+			l1735:
 				{
-					position1432, thunkPosition1432 := position, thunkPosition
+					position1741, thunkPosition1741 := position, thunkPosition
 					if !p.rules[ruleAlphanumeric]() {
-						goto l1432
+						goto l1741
 					}
-					goto l1425
-				l1432:
-					position, thunkPosition = position1432, thunkPosition1432
+					goto l1734
+				l1741:
+					position, thunkPosition = position1741, thunkPosition1741
 				}
-				goto l1423
-			l1425:
-				position, thunkPosition = position1425, thunkPosition1425
+				goto l1731
+			l1734:
+				position, thunkPosition = position1734, thunkPosition1734
 			}
 			return true
-		l1423:
+		l1731:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 231 SingleQuoteEnd <- ('\'' !Alphanumeric) */
+		/* 304 SingleQuoteEnd <- ('\'' !Alphanumeric) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('\'') {
-				goto l1433
+				goto l1742
 			}
 			{
-				position1434, thunkPosition1434 := position, thunkPosition
+				position1743, thunkPosition1743 := position, thunkPosition
 				if !p.rules[ruleAlphanumeric]() {
-					goto l1434
+					goto l1743
 				}
-				goto l1433
-			l1434:
-				position, thunkPosition = position1434, thunkPosition1434
+				goto l1742
+			l1743:
+				position, thunkPosition = position1743, thunkPosition1743
 			}
 			return true
-		l1433:
+		l1742:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 232 SingleQuoted <- (SingleQuoteStart StartList (!SingleQuoteEnd Inline { a = cons(b, a) })+ SingleQuoteEnd { yy = mk_list(SINGLEQUOTED, a) }) */
+		/* 305 SingleQuoted <- (SingleQuoteStart StartList (!SingleQuoteEnd Inline { a = cons(b, a) })+ SingleQuoteEnd { yy = mk_list(SINGLEQUOTED, a) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			doarg(yyPush, 2)
 			if !p.rules[ruleSingleQuoteStart]() {
-				goto l1435
+				goto l1744
 			}
 			if !p.rules[ruleStartList]() {
-				goto l1435
+				goto l1744
 			}
 			doarg(yySet, -1)
 			{
-				position1438, thunkPosition1438 := position, thunkPosition
+				position1747, thunkPosition1747 := position, thunkPosition
 				if !p.rules[ruleSingleQuoteEnd]() {
-					goto l1438
+					goto l1747
 				}
-				goto l1435
-			l1438:
-				position, thunkPosition = position1438, thunkPosition1438
+				goto l1744
+			l1747:
+				position, thunkPosition = position1747, thunkPosition1747
 			}
 			if !p.rules[ruleInline]() {
-				goto l1435
+				goto l1744
 			}
 			doarg(yySet, -2)
-			do(93)
-		l1436:
+			do(159)
+		l1745:
 			{
-				position1437, thunkPosition1437 := position, thunkPosition
+				position1746, thunkPosition1746 := position, thunkPosition
 				{
-					position1439, thunkPosition1439 := position, thunkPosition
+					position1748, thunkPosition1748 := position, thunkPosition
 					if !p.rules[ruleSingleQuoteEnd]() {
-						goto l1439
+						goto l1748
 					}
-					goto l1437
-				l1439:
-					position, thunkPosition = position1439, thunkPosition1439
+					goto l1746
+				l1748:
+					position, thunkPosition = position1748, thunkPosition1748
 				}
 				if !p.rules[ruleInline]() {
-					goto l1437
+					goto l1746
 				}
 				doarg(yySet, -2)
-				do(93)
-				goto l1436
-			l1437:
-				position, thunkPosition = position1437, thunkPosition1437
+				do(159)
+				goto l1745
+			l1746:
+				position, thunkPosition = position1746, thunkPosition1746
 			}
 			if !p.rules[ruleSingleQuoteEnd]() {
-				goto l1435
+				goto l1744
 			}
-			do(94)
+			do(160)
 			doarg(yyPop, 2)
 			return true
-		l1435:
+		l1744:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 233 DoubleQuoteStart <- '"' */
+		/* 306 DoubleQuoteStart <- '"' */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('"') {
-				goto l1440
+				goto l1749
 			}
 			return true
-		l1440:
+		l1749:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 234 DoubleQuoteEnd <- '"' */
+		/* 307 DoubleQuoteEnd <- '"' */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchChar('"') {
-				goto l1441
+				goto l1750
 			}
 			return true
-		l1441:
+		l1750:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 235 DoubleQuoted <- (DoubleQuoteStart StartList (!DoubleQuoteEnd Inline { a = cons(b, a) })+ DoubleQuoteEnd { yy = mk_list(DOUBLEQUOTED, a) }) */
+		/* 308 DoubleQuoted <- (DoubleQuoteStart StartList (!DoubleQuoteEnd Inline { a = cons(b, a) })+ DoubleQuoteEnd { yy = mk_list(DOUBLEQUOTED, a) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			doarg(yyPush, 2)
 			if !p.rules[ruleDoubleQuoteStart]() {
-				goto l1442
+				goto l1751
 			}
 			if !p.rules[ruleStartList]() {
-				goto l1442
+				goto l1751
 			}
 			doarg(yySet, -1)
 			{
-				position1445, thunkPosition1445 := position, thunkPosition
+				position1754, thunkPosition1754 := position, thunkPosition
 				if !p.rules[ruleDoubleQuoteEnd]() {
-					goto l1445
+					goto l1754
 				}
-				goto l1442
-			l1445:
-				position, thunkPosition = position1445, thunkPosition1445
+				goto l1751
+			l1754:
+				position, thunkPosition = position1754, thunkPosition1754
 			}
 			if !p.rules[ruleInline]() {
-				goto l1442
+				goto l1751
 			}
 			doarg(yySet, -2)
-			do(95)
-		l1443:
+			do(161)
+		l1752:
 			{
-				position1444, thunkPosition1444 := position, thunkPosition
+				position1753, thunkPosition1753 := position, thunkPosition
 				{
-					position1446, thunkPosition1446 := position, thunkPosition
+					position1755, thunkPosition1755 := position, thunkPosition
 					if !p.rules[ruleDoubleQuoteEnd]() {
-						goto l1446
+						goto l1755
 					}
-					goto l1444
-				l1446:
-					position, thunkPosition = position1446, thunkPosition1446
+					goto l1753
+				l1755:
+					position, thunkPosition = position1755, thunkPosition1755
 				}
 				if !p.rules[ruleInline]() {
-					goto l1444
+					goto l1753
 				}
 				doarg(yySet, -2)
-				do(95)
-				goto l1443
-			l1444:
-				position, thunkPosition = position1444, thunkPosition1444
+				do(161)
+				goto l1752
+			l1753:
+				position, thunkPosition = position1753, thunkPosition1753
 			}
 			if !p.rules[ruleDoubleQuoteEnd]() {
-				goto l1442
+				goto l1751
 			}
-			do(96)
+			do(162)
 			doarg(yyPop, 2)
 			return true
-		l1442:
+		l1751:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 236 NoteReference <- (&{ p.extension.Notes } RawNoteReference {
-                    if match, ok := p.find_note(ref.contents.str); ok {
+		/* 309 NoteReference <- (&{ p.extension.Notes } RawNoteReference {
+                    if match, ok := p.find_note(ref.contents.str); ok && p.expanding[match.children] {
+                        yy = mk_str("[^"+ref.contents.str+"]")
+                        p.warn(yy, "note [^"+ref.contents.str+"] refers to itself")
+                    } else if ok {
                         yy = mk_element(NOTE)
                         yy.children = match.children
                         yy.contents.str = ""
                     } else {
                         yy = mk_str("[^"+ref.contents.str+"]")
+                        p.problem(yy, UndefinedNote, "undefined note [^"+ref.contents.str+"]")
                     }
                 }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			doarg(yyPush, 1)
 			if !( p.extension.Notes ) {
-				goto l1447
+				goto l1756
 			}
 			if !p.rules[ruleRawNoteReference]() {
-				goto l1447
+				goto l1756
 			}
 			doarg(yySet, -1)
-			do(97)
+			do(163)
 			doarg(yyPop, 1)
 			return true
-		l1447:
+		l1756:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 237 RawNoteReference <- ('[^' < (!Newline !']' .)+ > ']' { yy = mk_str(yytext) }) */
+		/* 310 RawNoteReference <- ('[^' < (!Newline !']' .)+ > ']' { yy = mk_str(yytext) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !matchString("[^") {
-				goto l1448
+				goto l1757
 			}
 			begin = position
 			{
-				position1451, thunkPosition1451 := position, thunkPosition
+				position1760, thunkPosition1760 := position, thunkPosition
 				if !p.rules[ruleNewline]() {
-					goto l1451
+					goto l1760
 				}
-				goto l1448
-			l1451:
-				position, thunkPosition = position1451, thunkPosition1451
+				goto l1757
+			l1760:
+				position, thunkPosition = position1760, thunkPosition1760
 			}
 			if peekChar(']') {
-				goto l1448
+				goto l1757
 			}
 			if !matchDot() {
-				goto l1448
+				goto l1757
 			}
-		l1449:
+		l1758:
 			{
-				position1450, thunkPosition1450 := position, thunkPosition
+				position1759, thunkPosition1759 := position, thunkPosition
 				{
-					position1452, thunkPosition1452 := position, thunkPosition
+					position1761, thunkPosition1761 := position, thunkPosition
 					if !p.rules[ruleNewline]() {
-						goto l1452
+						goto l1761
 					}
-					goto l1450
-				l1452:
-					position, thunkPosition = position1452, thunkPosition1452
+					goto l1759
+				l1761:
+					position, thunkPosition = position1761, thunkPosition1761
 				}
 				if peekChar(']') {
-					goto l1450
+					goto l1759
 				}
 				if !matchDot() {
-					goto l1450
+					goto l1759
 				}
-				goto l1449
-			l1450:
-				position, thunkPosition = position1450, thunkPosition1450
+				goto l1758
+			l1759:
+				position, thunkPosition = position1759, thunkPosition1759
 			}
 			end = position
 			if !matchChar(']') {
-				goto l1448
+				goto l1757
 			}
-			do(98)
+			do(164)
 			return true
-		l1448:
+		l1757:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 238 Note <- (&{ p.extension.Notes } NonindentSpace RawNoteReference ':' Sp StartList (RawNoteBlock { a = cons(yy, a) }) (&Indent RawNoteBlock { a = cons(yy, a) })* {   yy = mk_list(NOTE, a)
+		/* 311 Note <- (&{ p.extension.Notes } NonindentSpace RawNoteReference ':' Sp StartList (RawNoteBlock { a = cons(yy, a) }) (&Indent RawNoteBlock { a = cons(yy, a) })* {   yy = mk_list(NOTE, a)
                     yy.contents.str = ref.contents.str
                 }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			doarg(yyPush, 2)
 			if !( p.extension.Notes ) {
-				goto l1453
+				goto l1762
 			}
 			if !p.rules[ruleNonindentSpace]() {
-				goto l1453
+				goto l1762
 			}
 			if !p.rules[ruleRawNoteReference]() {
-				goto l1453
+				goto l1762
 			}
 			doarg(yySet, -1)
 			if !matchChar(':') {
-				goto l1453
+				goto l1762
 			}
 			if !p.rules[ruleSp]() {
-				goto l1453
+				goto l1762
 			}
 			if !p.rules[ruleStartList]() {
-				goto l1453
+				goto l1762
 			}
 			doarg(yySet, -2)
 			if !p.rules[ruleRawNoteBlock]() {
-				goto l1453
+				goto l1762
 			}
-			do(99)
-		l1454:
+			do(165)
+		l1763:
 			{
-				position1455, thunkPosition1455 := position, thunkPosition
+				position1764, thunkPosition1764 := position, thunkPosition
 				{
-					position1456, thunkPosition1456 := position, thunkPosition
+					position1765, thunkPosition1765 := position, thunkPosition
 					if !p.rules[ruleIndent]() {
-						goto l1455
+						goto l1764
 					}
-					position, thunkPosition = position1456, thunkPosition1456
+					position, thunkPosition = position1765, thunkPosition1765
 				}
 				if !p.rules[ruleRawNoteBlock]() {
-					goto l1455
+					goto l1764
 				}
-				do(100)
-				goto l1454
-			l1455:
-				position, thunkPosition = position1455, thunkPosition1455
+				do(166)
+				goto l1763
+			l1764:
+				position, thunkPosition = position1764, thunkPosition1764
 			}
-			do(101)
+			do(167)
 			doarg(yyPop, 2)
 			return true
-		l1453:
+		l1762:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 239 InlineNote <- (&{ p.extension.Notes } '^[' StartList (!']' Inline { a = cons(yy, a) })+ ']' { yy = mk_list(NOTE, a)
+		/* 312 InlineNote <- (&{ p.extension.Notes } '^[' StartList (!']' NoteInline { a = cons(yy, a) })+ ']' { yy = mk_element(NOTE)
+                  yy.children = mk_list(PARA, a)
                   yy.contents.str = "" }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			doarg(yyPush, 1)
 			if !( p.extension.Notes ) {
-				goto l1457
+				goto l1766
 			}
 			if !matchString("^[") {
-				goto l1457
+				goto l1766
 			}
 			if !p.rules[ruleStartList]() {
-				goto l1457
+				goto l1766
 			}
 			doarg(yySet, -1)
 			if peekChar(']') {
-				goto l1457
+				goto l1766
 			}
-			if !p.rules[ruleInline]() {
-				goto l1457
+			if !p.rules[ruleNoteInline]() {
+				goto l1766
 			}
-			do(102)
-		l1458:
+			do(168)
+		l1767:
 			{
-				position1459, thunkPosition1459 := position, thunkPosition
+				position1768, thunkPosition1768 := position, thunkPosition
 				if peekChar(']') {
-					goto l1459
+					goto l1768
+				}
+				if !p.rules[ruleNoteInline]() {
+					goto l1768
+				}
+				do(168)
+				goto l1767
+			l1768:
+				position, thunkPosition = position1768, thunkPosition1768
+			}
+			if !matchChar(']') {
+				goto l1766
+			}
+			do(169)
+			doarg(yyPop, 1)
+			return true
+		l1766:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 313 NoteInline <- ((&'[' !WikiLink !Citation !Link !NoteReference '[' StartList { a = cons(mk_str("["), a) } (!']' NoteInline { a = cons(yy, a) })* ']' { yy = mk_list(LIST, cons(mk_str("]"), a)) }) / Inline) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 1)
+			{
+				position1770, thunkPosition1770 := position, thunkPosition
+				if !peekChar('[') {
+					goto l1771
+				}
+				{
+					position1772, thunkPosition1772 := position, thunkPosition
+					if !p.rules[ruleWikiLink]() {
+						goto l1772
+					}
+					goto l1771
+				l1772:
+					position, thunkPosition = position1772, thunkPosition1772
+				}
+				{
+					position1773, thunkPosition1773 := position, thunkPosition
+					if !p.rules[ruleCitation]() {
+						goto l1773
+					}
+					goto l1771
+				l1773:
+					position, thunkPosition = position1773, thunkPosition1773
+				}
+				{
+					position1774, thunkPosition1774 := position, thunkPosition
+					if !p.rules[ruleLink]() {
+						goto l1774
+					}
+					goto l1771
+				l1774:
+					position, thunkPosition = position1774, thunkPosition1774
+				}
+				{
+					position1775, thunkPosition1775 := position, thunkPosition
+					if !p.rules[ruleNoteReference]() {
+						goto l1775
+					}
+					goto l1771
+				l1775:
+					position, thunkPosition = position1775, thunkPosition1775
+				}
+				if !matchChar('[') {
+					goto l1771
 				}
+				if !p.rules[ruleStartList]() {
+					goto l1771
+				}
+				doarg(yySet, -1)
+				do(170)
+			l1776:
+				{
+					position1777, thunkPosition1777 := position, thunkPosition
+					if peekChar(']') {
+						goto l1777
+					}
+					if !p.rules[ruleNoteInline]() {
+						goto l1777
+					}
+					do(171)
+					goto l1776
+				l1777:
+					position, thunkPosition = position1777, thunkPosition1777
+				}
+				if !matchChar(']') {
+					goto l1771
+				}
+				do(172)
+				goto l1770
+			l1771:
+				position, thunkPosition = position1770, thunkPosition1770
 				if !p.rules[ruleInline]() {
-					goto l1459
+					goto l1769
 				}
-				do(102)
-				goto l1458
-			l1459:
-				position, thunkPosition = position1459, thunkPosition1459
 			}
-			if !matchChar(']') {
-				goto l1457
-			}
-			do(103)
+		l1770:
 			doarg(yyPop, 1)
 			return true
-		l1457:
+		l1769:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 240 Notes <- (StartList ((Note { a = cons(b, a) }) / SkipBlock)* { p.notes = reverse(a) } commit) */
+		/* 314 Notes <- (StartList ((Note { a = cons(b, a) }) / SkipBlock)* { p.notes = reverse(a) } commit) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			doarg(yyPush, 2)
 			if !p.rules[ruleStartList]() {
-				goto l1460
+				goto l1778
 			}
 			doarg(yySet, -1)
-		l1461:
+		l1779:
 			{
-				position1462, thunkPosition1462 := position, thunkPosition
+				position1780, thunkPosition1780 := position, thunkPosition
 				{
-					position1463, thunkPosition1463 := position, thunkPosition
+					position1781, thunkPosition1781 := position, thunkPosition
 					if !p.rules[ruleNote]() {
-						goto l1464
+						goto l1782
 					}
 					doarg(yySet, -2)
-					do(104)
-					goto l1463
-				l1464:
-					position, thunkPosition = position1463, thunkPosition1463
+					do(173)
+					goto l1781
+				l1782:
+					position, thunkPosition = position1781, thunkPosition1781
 					if !p.rules[ruleSkipBlock]() {
-						goto l1462
+						goto l1780
 					}
 				}
-			l1463:
-				goto l1461
-			l1462:
-				position, thunkPosition = position1462, thunkPosition1462
+			l1781:
+				goto l1779
+			l1780:
+				position, thunkPosition = position1780, thunkPosition1780
 			}
-			do(105)
+			do(174)
 			if !(commit(thunkPosition0)) {
-				goto l1460
+				goto l1778
 			}
 			doarg(yyPop, 2)
 			return true
-		l1460:
+		l1778:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 241 RawNoteBlock <- (StartList (!BlankLine OptionallyIndentedLine { a = cons(yy, a) })+ (< BlankLine* > { a = cons(mk_str(yytext), a) }) {   yy = mk_str_from_list(a, true)
+		/* 315 RawNoteBlock <- (StartList (!BlankLine OptionallyIndentedLine { a = cons(yy, a) })+ (< BlankLine* > { a = cons(mk_str(yytext), a) }) {   yy = mk_str_from_list(a, true)
                     yy.key = RAW
                 }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			doarg(yyPush, 1)
 			if !p.rules[ruleStartList]() {
-				goto l1465
+				goto l1783
 			}
 			doarg(yySet, -1)
 			{
-				position1468, thunkPosition1468 := position, thunkPosition
+				position1786, thunkPosition1786 := position, thunkPosition
 				if !p.rules[ruleBlankLine]() {
-					goto l1468
+					goto l1786
 				}
-				goto l1465
-			l1468:
-				position, thunkPosition = position1468, thunkPosition1468
+				goto l1783
+			l1786:
+				position, thunkPosition = position1786, thunkPosition1786
 			}
 			if !p.rules[ruleOptionallyIndentedLine]() {
-				goto l1465
+				goto l1783
 			}
-			do(106)
-		l1466:
+			do(175)
+		l1784:
 			{
-				position1467, thunkPosition1467 := position, thunkPosition
+				position1785, thunkPosition1785 := position, thunkPosition
 				{
-					position1469, thunkPosition1469 := position, thunkPosition
+					position1787, thunkPosition1787 := position, thunkPosition
 					if !p.rules[ruleBlankLine]() {
-						goto l1469
+						goto l1787
 					}
-					goto l1467
-				l1469:
-					position, thunkPosition = position1469, thunkPosition1469
+					goto l1785
+				l1787:
+					position, thunkPosition = position1787, thunkPosition1787
 				}
 				if !p.rules[ruleOptionallyIndentedLine]() {
-					goto l1467
+					goto l1785
 				}
-				do(106)
-				goto l1466
-			l1467:
-				position, thunkPosition = position1467, thunkPosition1467
+				do(175)
+				goto l1784
+			l1785:
+				position, thunkPosition = position1785, thunkPosition1785
 			}
 			begin = position
-		l1470:
+		l1788:
 			{
-				position1471, thunkPosition1471 := position, thunkPosition
+				position1789, thunkPosition1789 := position, thunkPosition
 				if !p.rules[ruleBlankLine]() {
-					goto l1471
+					goto l1789
 				}
-				goto l1470
-			l1471:
-				position, thunkPosition = position1471, thunkPosition1471
+				goto l1788
+			l1789:
+				position, thunkPosition = position1789, thunkPosition1789
 			}
 			end = position
-			do(107)
-			do(108)
+			do(176)
+			do(177)
 			doarg(yyPop, 1)
 			return true
-		l1465:
+		l1783:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 242 DefinitionList <- (&{ p.extension.Dlists } StartList (Definition { a = cons(yy, a) })+ { yy = mk_list(DEFINITIONLIST, a) }) */
+		/* 316 DefinitionList <- (&{ p.extension.Dlists } StartList (Definition {
+				for e := yy.children; e != nil; {
+					next := e.next
+					a = cons(e, a)
+					e = next
+				}
+			})+ { yy = mk_list(DEFINITIONLIST, a) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			doarg(yyPush, 1)
 			if !( p.extension.Dlists ) {
-				goto l1472
+				goto l1790
 			}
 			if !p.rules[ruleStartList]() {
-				goto l1472
+				goto l1790
 			}
 			doarg(yySet, -1)
 			if !p.rules[ruleDefinition]() {
-				goto l1472
+				goto l1790
 			}
-			do(109)
-		l1473:
+			do(178)
+		l1791:
 			{
-				position1474, thunkPosition1474 := position, thunkPosition
+				position1792, thunkPosition1792 := position, thunkPosition
 				if !p.rules[ruleDefinition]() {
-					goto l1474
+					goto l1792
 				}
-				do(109)
-				goto l1473
-			l1474:
-				position, thunkPosition = position1474, thunkPosition1474
+				do(178)
+				goto l1791
+			l1792:
+				position, thunkPosition = position1792, thunkPosition1792
 			}
-			do(110)
+			do(179)
 			doarg(yyPop, 1)
 			return true
-		l1472:
+		l1790:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 243 Definition <- (&((!Defmark RawLine)+ BlankLine? Defmark) StartList (DListTitle { a = cons(yy, a) })+ (DefTight / DefLoose) {
-				for e := yy.children; e != nil; e = e.next {
+		/* 317 Definition <- (&((!Defmark RawLine)+ BlankLine? Defmark) StartList (DListTitle { a = cons(yy, a) })+ (DefTight / DefLoose) {
+				for e := yy.children; e != nil; {
+					next := e.next
 					e.key = DEFDATA
+					a = cons(e, a)
+					e = next
 				}
-				a = cons(yy, a)
 			} { yy = mk_list(LIST, a) }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			doarg(yyPush, 1)
 			{
-				position1476, thunkPosition1476 := position, thunkPosition
+				position1794, thunkPosition1794 := position, thunkPosition
 				{
-					position1479, thunkPosition1479 := position, thunkPosition
+					position1797, thunkPosition1797 := position, thunkPosition
 					if !p.rules[ruleDefmark]() {
-						goto l1479
+						goto l1797
 					}
-					goto l1475
-				l1479:
-					position, thunkPosition = position1479, thunkPosition1479
+					goto l1793
+				l1797:
+					position, thunkPosition = position1797, thunkPosition1797
 				}
 				if !p.rules[ruleRawLine]() {
-					goto l1475
+					goto l1793
 				}
-			l1477:
+			l1795:
 				{
-					position1478, thunkPosition1478 := position, thunkPosition
+					position1796, thunkPosition1796 := position, thunkPosition
 					{
-						position1480, thunkPosition1480 := position, thunkPosition
+						position1798, thunkPosition1798 := position, thunkPosition
 						if !p.rules[ruleDefmark]() {
-							goto l1480
+							goto l1798
 						}
-						goto l1478
-					l1480:
-						position, thunkPosition = position1480, thunkPosition1480
+						goto l1796
+					l1798:
+						position, thunkPosition = position1798, thunkPosition1798
 					}
 					if !p.rules[ruleRawLine]() {
-						goto l1478
+						goto l1796
 					}
-					goto l1477
-				l1478:
-					position, thunkPosition = position1478, thunkPosition1478
+					goto l1795
+				l1796:
+					position, thunkPosition = position1796, thunkPosition1796
 				}
 				{
-					position1481, thunkPosition1481 := position, thunkPosition
+					position1799, thunkPosition1799 := position, thunkPosition
 					if !p.rules[ruleBlankLine]() {
-						goto l1481
+						goto l1799
 					}
-					goto l1482
-				l1481:
-					position, thunkPosition = position1481, thunkPosition1481
+					goto l1800
+				l1799:
+					position, thunkPosition = position1799, thunkPosition1799
 				}
-			l1482:
+			l1800:
 				if !p.rules[ruleDefmark]() {
-					goto l1475
+					goto l1793
 				}
-				position, thunkPosition = position1476, thunkPosition1476
+				position, thunkPosition = position1794, thunkPosition1794
 			}
 			if !p.rules[ruleStartList]() {
-				goto l1475
+				goto l1793
 			}
 			doarg(yySet, -1)
 			if !p.rules[ruleDListTitle]() {
-				goto l1475
+				goto l1793
 			}
-			do(111)
-		l1483:
+			do(180)
+		l1801:
 			{
-				position1484, thunkPosition1484 := position, thunkPosition
+				position1802, thunkPosition1802 := position, thunkPosition
 				if !p.rules[ruleDListTitle]() {
-					goto l1484
+					goto l1802
 				}
-				do(111)
-				goto l1483
-			l1484:
-				position, thunkPosition = position1484, thunkPosition1484
+				do(180)
+				goto l1801
+			l1802:
+				position, thunkPosition = position1802, thunkPosition1802
 			}
 			{
-				position1485, thunkPosition1485 := position, thunkPosition
+				position1803, thunkPosition1803 := position, thunkPosition
 				if !p.rules[ruleDefTight]() {
-					goto l1486
+					goto l1804
 				}
-				goto l1485
-			l1486:
-				position, thunkPosition = position1485, thunkPosition1485
+				goto l1803
+			l1804:
+				position, thunkPosition = position1803, thunkPosition1803
 				if !p.rules[ruleDefLoose]() {
-					goto l1475
+					goto l1793
 				}
 			}
-		l1485:
-			do(112)
-			do(113)
+		l1803:
+			do(181)
+			do(182)
 			doarg(yyPop, 1)
 			return true
-		l1475:
+		l1793:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 244 DListTitle <- (NonindentSpace !Defmark &Nonspacechar StartList (!Endline Inline { a = cons(yy, a) })+ Sp Newline {	yy = mk_list(LIST, a)
+		/* 318 DListTitle <- (NonindentSpace !Defmark &Nonspacechar StartList (!Endline Inline { a = cons(yy, a) })+ Sp Newline {	yy = mk_list(LIST, a)
 				yy.key = DEFTITLE
 			}) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			doarg(yyPush, 1)
 			if !p.rules[ruleNonindentSpace]() {
-				goto l1487
+				goto l1805
 			}
 			{
-				position1488, thunkPosition1488 := position, thunkPosition
+				position1806, thunkPosition1806 := position, thunkPosition
 				if !p.rules[ruleDefmark]() {
-					goto l1488
+					goto l1806
 				}
-				goto l1487
-			l1488:
-				position, thunkPosition = position1488, thunkPosition1488
+				goto l1805
+			l1806:
+				position, thunkPosition = position1806, thunkPosition1806
 			}
 			{
-				position1489, thunkPosition1489 := position, thunkPosition
+				position1807, thunkPosition1807 := position, thunkPosition
 				if !p.rules[ruleNonspacechar]() {
-					goto l1487
+					goto l1805
 				}
-				position, thunkPosition = position1489, thunkPosition1489
+				position, thunkPosition = position1807, thunkPosition1807
 			}
 			if !p.rules[ruleStartList]() {
-				goto l1487
+				goto l1805
 			}
 			doarg(yySet, -1)
 			{
-				position1492, thunkPosition1492 := position, thunkPosition
+				position1810, thunkPosition1810 := position, thunkPosition
 				if !p.rules[ruleEndline]() {
-					goto l1492
+					goto l1810
 				}
-				goto l1487
-			l1492:
-				position, thunkPosition = position1492, thunkPosition1492
+				goto l1805
+			l1810:
+				position, thunkPosition = position1810, thunkPosition1810
 			}
 			if !p.rules[ruleInline]() {
-				goto l1487
+				goto l1805
 			}
-			do(114)
-		l1490:
+			do(183)
+		l1808:
 			{
-				position1491, thunkPosition1491 := position, thunkPosition
+				position1809, thunkPosition1809 := position, thunkPosition
 				{
-					position1493, thunkPosition1493 := position, thunkPosition
+					position1811, thunkPosition1811 := position, thunkPosition
 					if !p.rules[ruleEndline]() {
-						goto l1493
+						goto l1811
 					}
-					goto l1491
-				l1493:
-					position, thunkPosition = position1493, thunkPosition1493
+					goto l1809
+				l1811:
+					position, thunkPosition = position1811, thunkPosition1811
 				}
 				if !p.rules[ruleInline]() {
-					goto l1491
+					goto l1809
 				}
-				do(114)
-				goto l1490
-			l1491:
-				position, thunkPosition = position1491, thunkPosition1491
+				do(183)
+				goto l1808
+			l1809:
+				position, thunkPosition = position1809, thunkPosition1809
 			}
 			if !p.rules[ruleSp]() {
-				goto l1487
+				goto l1805
 			}
 			if !p.rules[ruleNewline]() {
-				goto l1487
+				goto l1805
 			}
-			do(115)
+			do(184)
 			doarg(yyPop, 1)
 			return true
-		l1487:
+		l1805:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 245 DefTight <- (&Defmark ListTight) */
+		/* 319 DefTight <- (&Defmark ListKind ListTight) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			{
-				position1495, thunkPosition1495 := position, thunkPosition
+				position1813, thunkPosition1813 := position, thunkPosition
 				if !p.rules[ruleDefmark]() {
-					goto l1494
+					goto l1812
 				}
-				position, thunkPosition = position1495, thunkPosition1495
+				position, thunkPosition = position1813, thunkPosition1813
+			}
+			if !p.rules[ruleListKind]() {
+				goto l1812
 			}
 			if !p.rules[ruleListTight]() {
-				goto l1494
+				goto l1812
 			}
 			return true
-		l1494:
+		l1812:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 246 DefLoose <- (BlankLine &Defmark ListLoose) */
+		/* 320 DefLoose <- (BlankLine &Defmark ListKind ListLoose { if p.extension.StrictLists {
+				  endItems(yy, true)
+			  } }) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !p.rules[ruleBlankLine]() {
-				goto l1496
+				goto l1814
 			}
 			{
-				position1497, thunkPosition1497 := position, thunkPosition
+				position1815, thunkPosition1815 := position, thunkPosition
 				if !p.rules[ruleDefmark]() {
-					goto l1496
+					goto l1814
 				}
-				position, thunkPosition = position1497, thunkPosition1497
+				position, thunkPosition = position1815, thunkPosition1815
+			}
+			if !p.rules[ruleListKind]() {
+				goto l1814
 			}
 			if !p.rules[ruleListLoose]() {
-				goto l1496
+				goto l1814
 			}
+			do(185)
 			return true
-		l1496:
+		l1814:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 247 Defmark <- (NonindentSpace (':' / '~') Spacechar+) */
+		/* 321 Defmark <- (NonindentSpace (':' / '~') Spacechar+) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !p.rules[ruleNonindentSpace]() {
-				goto l1498
+				goto l1816
 			}
 			{
-				position1499, thunkPosition1499 := position, thunkPosition
+				position1817, thunkPosition1817 := position, thunkPosition
 				if !matchChar(':') {
-					goto l1500
+					goto l1818
 				}
-				goto l1499
-			l1500:
-				position, thunkPosition = position1499, thunkPosition1499
+				goto l1817
+			l1818:
+				position, thunkPosition = position1817, thunkPosition1817
 				if !matchChar('~') {
-					goto l1498
+					goto l1816
 				}
 			}
-		l1499:
+		l1817:
 			if !p.rules[ruleSpacechar]() {
-				goto l1498
+				goto l1816
 			}
-		l1501:
+		l1819:
 			{
-				position1502, thunkPosition1502 := position, thunkPosition
+				position1820, thunkPosition1820 := position, thunkPosition
 				if !p.rules[ruleSpacechar]() {
-					goto l1502
+					goto l1820
 				}
-				goto l1501
-			l1502:
-				position, thunkPosition = position1502, thunkPosition1502
+				goto l1819
+			l1820:
+				position, thunkPosition = position1820, thunkPosition1820
 			}
 			return true
-		l1498:
+		l1816:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
-		/* 248 DefMarker <- (&{ p.extension.Dlists } Defmark) */
+		/* 322 DefMarker <- (&{ p.extension.Dlists } Defmark) */
 		func() bool {
 			position0, thunkPosition0 := position, thunkPosition
 			if !( p.extension.Dlists ) {
-				goto l1503
+				goto l1821
 			}
 			if !p.rules[ruleDefmark]() {
-				goto l1503
+				goto l1821
+			}
+			return true
+		l1821:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 323 Table <- (&{ p.extension.Tables } TableRow TableAlignRow StartList (TableRow { a = cons(yy, a) })* BlankLine* { yy = mk_table(h, l, a) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 3)
+			if !( p.extension.Tables ) {
+				goto l1822
+			}
+			if !p.rules[ruleTableRow]() {
+				goto l1822
+			}
+			doarg(yySet, -1)
+			if !p.rules[ruleTableAlignRow]() {
+				goto l1822
+			}
+			doarg(yySet, -2)
+			if !p.rules[ruleStartList]() {
+				goto l1822
+			}
+			doarg(yySet, -3)
+		l1823:
+			{
+				position1824, thunkPosition1824 := position, thunkPosition
+				if !p.rules[ruleTableRow]() {
+					goto l1824
+				}
+				do(186)
+				goto l1823
+			l1824:
+				position, thunkPosition = position1824, thunkPosition1824
+			}
+		l1825:
+			{
+				position1826, thunkPosition1826 := position, thunkPosition
+				if !p.rules[ruleBlankLine]() {
+					goto l1826
+				}
+				goto l1825
+			l1826:
+				position, thunkPosition = position1826, thunkPosition1826
+			}
+			do(187)
+			doarg(yyPop, 3)
+			return true
+		l1822:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 324 TableLine <- &((!Newline !'|' .)* '|') */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			{
+				position1828, thunkPosition1828 := position, thunkPosition
+			l1829:
+				{
+					position1830, thunkPosition1830 := position, thunkPosition
+					{
+						position1831, thunkPosition1831 := position, thunkPosition
+						if !p.rules[ruleNewline]() {
+							goto l1831
+						}
+						goto l1830
+					l1831:
+						position, thunkPosition = position1831, thunkPosition1831
+					}
+					if peekChar('|') {
+						goto l1830
+					}
+					if !matchDot() {
+						goto l1830
+					}
+					goto l1829
+				l1830:
+					position, thunkPosition = position1830, thunkPosition1830
+				}
+				if !matchChar('|') {
+					goto l1827
+				}
+				position, thunkPosition = position1828, thunkPosition1828
+			}
+			return true
+		l1827:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 325 TableRow <- (TableLine NonindentSpace '|'? StartList TableCell { a = cons(yy, a) } ('|' !(Sp Newline) TableCell { a = cons(yy, a) })* '|'? Sp Newline { yy = mk_list(TABLEROW, a) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 1)
+			if !p.rules[ruleTableLine]() {
+				goto l1832
+			}
+			if !p.rules[ruleNonindentSpace]() {
+				goto l1832
+			}
+			{
+				position1833, thunkPosition1833 := position, thunkPosition
+				if !matchChar('|') {
+					goto l1833
+				}
+				goto l1834
+			l1833:
+				position, thunkPosition = position1833, thunkPosition1833
+			}
+		l1834:
+			if !p.rules[ruleStartList]() {
+				goto l1832
+			}
+			doarg(yySet, -1)
+			if !p.rules[ruleTableCell]() {
+				goto l1832
+			}
+			do(188)
+		l1835:
+			{
+				position1836, thunkPosition1836 := position, thunkPosition
+				if !matchChar('|') {
+					goto l1836
+				}
+				{
+					position1837, thunkPosition1837 := position, thunkPosition
+					if !p.rules[ruleSp]() {
+						goto l1837
+					}
+					if !p.rules[ruleNewline]() {
+						goto l1837
+					}
+					goto l1836
+				l1837:
+					position, thunkPosition = position1837, thunkPosition1837
+				}
+				if !p.rules[ruleTableCell]() {
+					goto l1836
+				}
+				do(189)
+				goto l1835
+			l1836:
+				position, thunkPosition = position1836, thunkPosition1836
+			}
+			{
+				position1838, thunkPosition1838 := position, thunkPosition
+				if !matchChar('|') {
+					goto l1838
+				}
+				goto l1839
+			l1838:
+				position, thunkPosition = position1838, thunkPosition1838
+			}
+		l1839:
+			if !p.rules[ruleSp]() {
+				goto l1832
+			}
+			if !p.rules[ruleNewline]() {
+				goto l1832
+			}
+			do(190)
+			doarg(yyPop, 1)
+			return true
+		l1832:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 326 TableCell <- (Sp StartList (!(Sp ('|' / Newline)) Inline { a = cons(yy, a) })* Sp { yy = mk_list(TABLECELL, a) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 1)
+			if !p.rules[ruleSp]() {
+				goto l1840
+			}
+			if !p.rules[ruleStartList]() {
+				goto l1840
+			}
+			doarg(yySet, -1)
+		l1841:
+			{
+				position1842, thunkPosition1842 := position, thunkPosition
+				{
+					position1843, thunkPosition1843 := position, thunkPosition
+					if !p.rules[ruleSp]() {
+						goto l1843
+					}
+					{
+						position1844, thunkPosition1844 := position, thunkPosition
+						if !matchChar('|') {
+							goto l1845
+						}
+						goto l1844
+					l1845:
+						position, thunkPosition = position1844, thunkPosition1844
+						if !p.rules[ruleNewline]() {
+							goto l1843
+						}
+					}
+				l1844:
+					goto l1842
+				l1843:
+					position, thunkPosition = position1843, thunkPosition1843
+				}
+				if !p.rules[ruleInline]() {
+					goto l1842
+				}
+				do(191)
+				goto l1841
+			l1842:
+				position, thunkPosition = position1842, thunkPosition1842
+			}
+			if !p.rules[ruleSp]() {
+				goto l1840
+			}
+			do(192)
+			doarg(yyPop, 1)
+			return true
+		l1840:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 327 TableAlignRow <- (TableLine NonindentSpace '|'? StartList TableAlignCell { a = cons(yy, a) } ('|' !(Sp Newline) TableAlignCell { a = cons(yy, a) })* '|'? Sp Newline { yy = mk_list(LIST, a) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			doarg(yyPush, 1)
+			if !p.rules[ruleTableLine]() {
+				goto l1846
+			}
+			if !p.rules[ruleNonindentSpace]() {
+				goto l1846
+			}
+			{
+				position1847, thunkPosition1847 := position, thunkPosition
+				if !matchChar('|') {
+					goto l1847
+				}
+				goto l1848
+			l1847:
+				position, thunkPosition = position1847, thunkPosition1847
+			}
+		l1848:
+			if !p.rules[ruleStartList]() {
+				goto l1846
+			}
+			doarg(yySet, -1)
+			if !p.rules[ruleTableAlignCell]() {
+				goto l1846
+			}
+			do(193)
+		l1849:
+			{
+				position1850, thunkPosition1850 := position, thunkPosition
+				if !matchChar('|') {
+					goto l1850
+				}
+				{
+					position1851, thunkPosition1851 := position, thunkPosition
+					if !p.rules[ruleSp]() {
+						goto l1851
+					}
+					if !p.rules[ruleNewline]() {
+						goto l1851
+					}
+					goto l1850
+				l1851:
+					position, thunkPosition = position1851, thunkPosition1851
+				}
+				if !p.rules[ruleTableAlignCell]() {
+					goto l1850
+				}
+				do(194)
+				goto l1849
+			l1850:
+				position, thunkPosition = position1850, thunkPosition1850
+			}
+			{
+				position1852, thunkPosition1852 := position, thunkPosition
+				if !matchChar('|') {
+					goto l1852
+				}
+				goto l1853
+			l1852:
+				position, thunkPosition = position1852, thunkPosition1852
+			}
+		l1853:
+			if !p.rules[ruleSp]() {
+				goto l1846
+			}
+			if !p.rules[ruleNewline]() {
+				goto l1846
+			}
+			do(195)
+			doarg(yyPop, 1)
+			return true
+		l1846:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 328 TableAlignCell <- (Sp < ':'? '-'+ ':'? > Sp { yy = mk_str(yytext) }) */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !p.rules[ruleSp]() {
+				goto l1854
+			}
+			begin = position
+			{
+				position1855, thunkPosition1855 := position, thunkPosition
+				if !matchChar(':') {
+					goto l1855
+				}
+				goto l1856
+			l1855:
+				position, thunkPosition = position1855, thunkPosition1855
+			}
+		l1856:
+			if !matchChar('-') {
+				goto l1854
+			}
+		l1857:
+			{
+				position1858, thunkPosition1858 := position, thunkPosition
+				if !matchChar('-') {
+					goto l1858
+				}
+				goto l1857
+			l1858:
+				position, thunkPosition = position1858, thunkPosition1858
+			}
+			{
+				position1859, thunkPosition1859 := position, thunkPosition
+				if !matchChar(':') {
+					goto l1859
+				}
+				goto l1860
+			l1859:
+				position, thunkPosition = position1859, thunkPosition1859
+			}
+		l1860:
+			end = position
+			if !p.rules[ruleSp]() {
+				goto l1854
+			}
+			do(196)
+			return true
+		l1854:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 329 ExtBlock <- &{ false } */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !( false ) {
+				goto l1861
+			}
+			return true
+		l1861:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 330 ExtInline <- &{ false } */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !( false ) {
+				goto l1862
+			}
+			return true
+		l1862:
+			position, thunkPosition = position0, thunkPosition0
+			return false
+		},
+		/* 331 ExtSpecialChar <- &{ false } */
+		func() bool {
+			position0, thunkPosition0 := position, thunkPosition
+			if !( false ) {
+				goto l1863
 			}
 			return true
-		l1503:
+		l1863:
 			position, thunkPosition = position0, thunkPosition0
 			return false
 		},
@@ -13566,14 +18090,14 @@ func (p *yyParser) Init() {
 
 /* cons - cons an element onto a list, returning pointer to new head
  */
-func cons(new, list *element) *element {
+func cons(new, list *Element) *Element {
 	new.next = list
 	return new
 }
 
 /* reverse - reverse a list, returning pointer to new list
  */
-func reverse(list *element) (new *element) {
+func reverse(list *Element) (new *Element) {
 	for list != nil {
 		next := list.next
 		new = cons(list, new)
@@ -13583,14 +18107,23 @@ func reverse(list *element) (new *element) {
 }
 
 /* concat_string_list - concatenates string contents of list of STR elements.
+ * The length is computed first, so that the result is copied only once,
+ * instead of once per element.
  */
-func concat_string_list(list *element) string {
-	s := ""
-	for list != nil {
-		s += list.contents.str
-		list = list.next
+func concat_string_list(list *Element) string {
+	if list != nil && list.next == nil {
+		return list.contents.str
+	}
+	n := 0
+	for e := list; e != nil; e = e.next {
+		n += len(e.contents.str)
 	}
-	return s
+	b := make([]byte, n)
+	n = 0
+	for ; list != nil; list = list.next {
+		n += copy(b[n:], list.contents.str)
+	}
+	return string(b)
 }
 
 
@@ -13603,13 +18136,13 @@ func concat_string_list(list *element) string {
 
 /* mk_element - generic constructor for element
  */
-var elbuf []element
+var elbuf []Element
 var elock sync.Mutex
 
-func mk_element(key int) *element {
+func mk_element(key int) *Element {
 	elock.Lock()
 	if len(elbuf) == 0 {
-		elbuf = make([]element, 1024)
+		elbuf = make([]Element, 1024)
 	}
 	e := &elbuf[0]
 	elbuf = elbuf[1:]
@@ -13620,7 +18153,7 @@ func mk_element(key int) *element {
 
 /* mk_str - constructor for STR element
  */
-func mk_str(s string) (result *element) {
+func mk_str(s string) (result *Element) {
 	result = mk_element(STR)
 	result.contents.str = s
 	return
@@ -13629,7 +18162,7 @@ func mk_str(s string) (result *element) {
 /* mk_str_from_list - makes STR element by concatenating a
  * reversed list of strings, adding optional extra newline
  */
-func mk_str_from_list(list *element, extra_newline bool) (result *element) {
+func mk_str_from_list(list *Element, extra_newline bool) (result *Element) {
 	s := concat_string_list(reverse(list))
 	if extra_newline {
 		s += "\n"
@@ -13643,7 +18176,7 @@ func mk_str_from_list(list *element, extra_newline bool) (result *element) {
  * This is designed to be used with cons to build lists in a parser action.
  * The reversing is necessary because cons adds to the head of a list.
  */
-func mk_list(key int, lst *element) *element {
+func mk_list(key int, lst *Element) *Element {
 	result := mk_element(key)
 	result.children = reverse(lst)
 	return result
@@ -13651,16 +18184,95 @@ func mk_list(key int, lst *element) *element {
 
 /* mk_link - constructor for LINK element
  */
-func mk_link(label *element, url, title string) *element {
+func mk_link(label *Element, url, title string) *Element {
 	result := mk_element(LINK)
 	result.contents.link = &link{label: label, url: url, title: title}
 	return result
 }
 
 
+/* mk_fenced - makes VERBATIM element from the info string and the
+ * reversed list of lines of a fenced code block. The info string is
+ * kept as a STR child of the element.
+ */
+func mk_fenced(info, lines *Element) *Element {
+	result := mk_str_from_list(lines, false)
+	result.key = VERBATIM
+	if s := strings.TrimSpace(info.contents.str); s != "" {
+		result.children = mk_str(s)
+	}
+	return result
+}
+
+/* mk_table - makes TABLE element from the header row, the row of
+ * alignment specifications and the reversed list of body rows.
+ * Each row is cut or padded to the number of columns of the alignment
+ * row, and each cell is marked with the alignment of its column.
+ */
+func mk_table(head, aligns, rows *Element) *Element {
+	var align []string
+
+	for e := aligns.children; e != nil; e = e.next {
+		s := e.contents.str
+		left := s[0] == ':'
+		right := s[len(s)-1] == ':'
+		switch {
+		case left && right:
+			align = append(align, "center")
+		case left:
+			align = append(align, "left")
+		case right:
+			align = append(align, "right")
+		default:
+			align = append(align, "")
+		}
+	}
+
+	table := mk_element(TABLE)
+	thead := mk_element(TABLEHEAD)
+	thead.children = head
+	table.children = thead
+	if rows != nil {
+		tbody := mk_list(TABLEBODY, rows)
+		thead.next = tbody
+	}
+	for part := table.children; part != nil; part = part.next {
+		for row := part.children; row != nil; row = row.next {
+			cellp := &row.children
+			for _, a := range align {
+				if *cellp == nil {
+					*cellp = mk_element(TABLECELL)
+				}
+				(*cellp).contents.str = a
+				cellp = &(*cellp).next
+			}
+			*cellp = nil
+		}
+	}
+	return table
+}
+
+
+/* safeURL - in safe mode, returns "" for URLs using a scheme that
+ * could be used to inject scripts, i.e. javascript:, vbscript:, and data:
+ */
+func (d *Doc) safeURL(url string) string {
+	if !d.extension.Safe {
+		return url
+	}
+	u := strings.ToLower(strings.TrimSpace(url))
+	for _, scheme := range []string{"javascript:", "vbscript:", "data:"} {
+		if strings.HasPrefix(u, scheme) {
+			return ""
+		}
+	}
+	return url
+}
+
+
 /* match_inlines - returns true if inline lists match (case-insensitive...)
  */
-func match_inlines(l1, l2 *element) bool {
+func match_inlines(l1, l2 *Element) bool {
 	for l1 != nil && l2 != nil {
 		if l1.key != l2.key {
 			return false
@@ -13668,18 +18280,25 @@ func match_inlines(l1, l2 *element) bool {
 		switch l1.key {
 		case SPACE, LINEBREAK, ELLIPSIS, EMDASH, ENDASH, APOSTROPHE:
 			break
-		case CODE, STR, HTML:
+		case CODE, STR, HTML, MATH, DISPLAYMATH, RAW, EMOJI:
 			if strings.ToUpper(l1.contents.str) != strings.ToUpper(l2.contents.str) {
 				return false
 			}
-		case EMPH, STRONG, LIST, SINGLEQUOTED, DOUBLEQUOTED:
+		case EMPH, STRONG, STRIKE, LIST, SINGLEQUOTED, DOUBLEQUOTED, SUPERSCRIPT, SUBSCRIPT, NOTE, PARA:
 			if !match_inlines(l1.children, l2.children) {
 				return false
 			}
 		case LINK, IMAGE:
 			return false	/* No links or images within links */
 		default:
-			log.Fatalf("match_inlines encountered unknown key = %d\n", l1.key)
+			if l1.key >= numVAL {
+				/* an element of a plugin */
+				if strings.ToUpper(l1.contents.str) != strings.ToUpper(l2.contents.str) || !match_inlines(l1.children, l2.children) {
+					return false
+				}
+				break
+			}
+			return false
 		}
 		l1 = l1.next
 		l2 = l2.next
@@ -13688,10 +18307,19 @@ func match_inlines(l1, l2 *element) bool {
 }
 
 
+/* intraword - return true if the character before pos is alphanumeric,
+ * like rule Alphanumeric.
+ */
+func (d *Doc) intraword(pos int) bool {
+	c := d.runeBefore(pos)
+	return c != -1 && isWordRune(c)
+}
+
+
 /* find_reference - return true if link found in references matching label.
  * 'link' is modified with the matching url and title.
  */
-func (d *Doc) findReference(label *element) (*link, bool) {
+func (d *Doc) findReference(label *Element) (*link, bool) {
 	for cur := d.references; cur != nil; cur = cur.next {
 		l := cur.contents.link
 		if match_inlines(label, l.label) {
@@ -13705,7 +18333,7 @@ func (d *Doc) findReference(label *element) (*link, bool) {
 /* find_note - return true if note found in notes matching label.
  * if found, 'result' is set to point to matched note.
  */
-func (d *Doc) find_note(label string) (*element, bool) {
+func (d *Doc) find_note(label string) (*Element, bool) {
 	for el := d.notes; el != nil; el = el.next {
 		if label == el.contents.str {
 			return el, true
@@ -13717,17 +18345,14 @@ func (d *Doc) find_note(label string) (*element, bool) {
 
 /* print tree of elements, for debugging only.
  */
-func print_tree(elt *element, indent int) {
+func print_tree(elt *Element, indent int) {
 	var key string
 
 	for elt != nil {
 		for i := 0; i < indent; i++ {
 			fmt.Print("\t")
 		}
-		key = keynames[elt.key]
-		if key == "" {
-			key = "?"
-		}
+		key = elt.KindName()
 		if elt.key == STR {
 			fmt.Printf("%p:\t%s\t'%s'\n", elt, key, elt.contents.str)
 		} else {
@@ -13778,4 +18403,22 @@ var keynames = [numVAL]string{
 	DEFINITIONLIST:	"DEFINITIONLIST",
 	DEFTITLE:		"DEFTITLE",
 	DEFDATA:		"DEFDATA",
+	TABLE:			"TABLE",
+	TABLEHEAD:		"TABLEHEAD",
+	TABLEBODY:		"TABLEBODY",
+	TABLEROW:		"TABLEROW",
+	TABLECELL:		"TABLECELL",
+	TOC:			"TOC",
+	STRIKE:			"STRIKE",
+	MATH:			"MATH",
+	DISPLAYMATH:	"DISPLAYMATH",
+	ABBR:			"ABBR",
+	ABBREVIATION:	"ABBREVIATION",
+	SUPERSCRIPT:	"SUPERSCRIPT",
+	SUBSCRIPT:		"SUBSCRIPT",
+	EMOJI:			"EMOJI",
+	ADMONITION:		"ADMONITION",
+	DIV:			"DIV",
+	CITATION:		"CITATION",
+	BIBLIOGRAPHY:	"BIBLIOGRAPHY",
 }
@@ -0,0 +1,249 @@
+package markdown
+
+// Plugins adding kinds of elements to the syntax
+
+import (
+	"strings"
+	"sync"
+)
+
+// A Plugin adds a kind of block, like an admonition or a diagram,
+// or of inline element to the syntax, without changing the grammar.
+// Plugins are registered by Register, and take part in the parse of
+// all documents parsed afterwards.  They are tried in the order of
+// decreasing Priority, and, for the same priority, in the order they
+// have been registered: plugins of a non-negative priority before
+// the built-in syntax, the others after it, right before paragraphs,
+// or, for inline elements, single special characters.
+type Plugin struct {
+	Name		string	// name of the kind, as returned by Element.KindName
+	Block		bool	// if set, the plugin parses blocks, otherwise inline elements
+	Prefix		string	// non-empty text an element starts with, for blocks after up to three spaces
+	Priority	int
+
+	// Parse is called where the text starts with Prefix, with the
+	// text from there up to the end of the document; for a block,
+	// it starts at the beginning of the line.  Parse returns the
+	// length of the element, or 0, if text does not start one; a
+	// block is extended up to the end of its last line.  data is
+	// the text of the element, and inner, if not empty, is parsed as
+	// its children: as blocks for a block, otherwise as inline
+	// elements.  The results must depend on the first n bytes of
+	// text only.
+	Parse	func(text string) (n int, data, inner string)
+
+	// Render, if not nil, is called by Doc.Render for the elements
+	// of the plugin, before and after their children are rendered,
+	// unless the Renderer is a PluginRenderer.  It may call e.g.
+	// HtmlBlock to print raw HTML, which is ignored by the other
+	// output formats.  If Render is nil, the children are rendered,
+	// or, if there are none, the text of the element is printed as
+	// code: as a code block, using the Name as its language, or as
+	// a code span.
+	Render	func(r Renderer, e *Element, entering bool)
+
+	// Formats maps the names of output formats, as returned by
+	// FormatRenderer.Format, like "latex", to functions called
+	// instead of Render for the Renderers of these formats, which
+	// may print the element e.g. by FormatRenderer.Raw.
+	Formats	map[string]func(r Renderer, e *Element, entering bool)
+}
+
+// A PluginRenderer is a Renderer that prints the elements of plugins
+// itself.  If implemented, Render calls Plugin for them instead of
+// the Render function of the plugin.
+type PluginRenderer interface {
+	Plugin(e *Element, entering bool)	// e.KindName() is the name of the plugin
+}
+
+/* classes of plugins, see Doc.plugin */
+const (
+	pluginBlock	= iota
+	pluginLateBlock
+	pluginInline
+	pluginLateInline
+)
+
+/* pluginSet - the plugins registered when a document is parsed */
+type pluginSet struct {
+	list	[]*Plugin		/* All plugins; the kind of list[i] is numVAL+i. */
+	class	[4][]*Plugin	/* Plugins of each class, in the order they are tried. */
+	kinds	map[*Plugin]int
+	chars	string	/* First bytes of the prefixes of inline plugins. */
+}
+
+var plugins struct {
+	sync.Mutex
+	set	*pluginSet
+}
+
+// Register adds a plugin to the syntax, and returns the kind of its
+// elements, as returned by Element.Kind.  It must not be called while
+// documents are parsed.
+func Register(pl *Plugin) int {
+	plugins.Lock()
+	defer plugins.Unlock()
+	set := new(pluginSet)
+	if old := plugins.set; old != nil {
+		set.list = append(set.list, old.list...)
+	}
+	set.list = append(set.list, pl)
+	set.kinds = make(map[*Plugin]int)
+	for i, pl := range set.list {
+		set.kinds[pl] = numVAL + i
+		c := pluginInline
+		if pl.Block {
+			c = pluginBlock
+		}
+		if pl.Priority < 0 {
+			c++
+		}
+		/* insert after the plugins of the same or a higher priority */
+		list := set.class[c]
+		k := len(list)
+		for k > 0 && list[k-1].Priority < pl.Priority {
+			k--
+		}
+		list = append(list, nil)
+		copy(list[k+1:], list[k:])
+		list[k] = pl
+		set.class[c] = list
+		if !pl.Block && strings.Index(set.chars, pl.Prefix[:1]) == -1 {
+			set.chars += pl.Prefix[:1]
+		}
+	}
+	plugins.set = set
+	return numVAL + len(set.list) - 1
+}
+
+/* registered - return the plugins currently registered, or nil */
+func registered() *pluginSet {
+	plugins.Lock()
+	defer plugins.Unlock()
+	return plugins.set
+}
+
+/* pluginOf - return the plugin creating elements of the specified
+ * kind, or nil
+ */
+func pluginOf(kind int) *Plugin {
+	if set := registered(); set != nil && kind >= numVAL && kind < numVAL+len(set.list) {
+		return set.list[kind-numVAL]
+	}
+	return nil
+}
+
+/* pluginMatch - the result of a plugin's Parse function */
+type pluginMatch struct {
+	kind		int
+	data, inner	string
+}
+
+/* plugin - called by the rules for elements of plugins: if one of the
+ * plugins of the class c matches at *pos, advance *pos to its end,
+ * and record the match for pluginElement.
+ */
+func (d *Doc) plugin(pos *int, c int) bool {
+	if d.plugins == nil || len(d.plugins.class[c]) == 0 {
+		return false
+	}
+	text := d.parser.Buffer[*pos:]
+	start := text
+	if c == pluginBlock || c == pluginLateBlock {
+		for i := 0; i < 3 && strings.HasPrefix(start, " "); i++ {
+			start = start[1:]
+		}
+	}
+	for _, pl := range d.plugins.class[c] {
+		if !strings.HasPrefix(start, pl.Prefix) {
+			continue
+		}
+		n, data, inner := pl.Parse(text)
+		if n <= 0 {
+			continue
+		}
+		if n > len(text) {
+			n = len(text)
+		}
+		if pl.Block {
+			if i := strings.Index(text[n-1:], "\n"); i != -1 {
+				n += i
+			} else {
+				n = len(text)
+			}
+		}
+		if d.matches == nil {
+			d.matches = make(map[string]pluginMatch)
+		}
+		d.matches[text[:n]] = pluginMatch{d.plugins.kinds[pl], data, inner}
+		*pos += n
+		return true
+	}
+	return false
+}
+
+/* pluginElement - create the element for the text matched by plugin
+ */
+func (d *Doc) pluginElement(text string) *Element {
+	m := d.matches[text]
+	e := mk_element(m.kind)
+	e.contents.str = m.data
+	if m.inner != "" {
+		e.children = mk_str(m.inner)
+		if pluginOf(m.kind).Block {
+			e.children.contents.str += "\n"
+			if !strings.HasSuffix(m.inner, "\n") {
+				e.children.contents.str += "\n"
+			}
+		}
+		e.children.key = RAW
+	}
+	return e
+}
+
+/* pluginChar - return true if the character at pos may start an
+ * inline element of a plugin
+ */
+func (d *Doc) pluginChar(pos int) bool {
+	return d.plugins != nil && pos < len(d.parser.Buffer) &&
+		strings.Index(d.plugins.chars, d.parser.Buffer[pos:pos+1]) != -1
+}
+
+/* pluginInlines - parse the text of the RAW child of an inline
+ * element of a plugin, which processRawBlocks would parse as blocks
+ */
+func (d *Doc) pluginInlines(e *Element) {
+	if raw := e.children; raw != nil && raw.key == RAW && !pluginOf(e.key).Block {
+		d.parseInlines(raw)
+	}
+}
+
+/* renderPlugin - render an element of the plugin pl
+ */
+func (w *walker) renderPlugin(pl *Plugin, e *Element) {
+	var render func(bool)
+	switch r := w.r.(type) {
+	case PluginRenderer:
+		render = func(entering bool) { r.Plugin(e, entering) }
+	default:
+		f := pl.Render
+		if fr, ok := r.(FormatRenderer); ok && pl.Formats[fr.Format()] != nil {
+			f = pl.Formats[fr.Format()]
+		}
+		if f != nil {
+			render = func(entering bool) { f(r, e, entering) }
+		}
+	}
+	switch {
+	case render != nil:
+		render(true)
+		w.elist(e.children)
+		render(false)
+	case e.children != nil:
+		w.elist(e.children)
+	case pl.Block:
+		w.r.Verbatim(e.contents.str, pl.Name)
+	default:
+		w.r.Code(e.contents.str)
+	}
+}
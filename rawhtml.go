@@ -0,0 +1,354 @@
+package markdown
+
+// Treatment of raw HTML, see Parser.HTMLPolicy
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// Values of Parser.HTMLPolicy.
+const (
+	HTMLDefault	= iota	// as selected by the extensions FilterHTML and Safe, otherwise like HTMLAllow
+	HTMLAllow			// printed as is
+	HTMLEscape			// printed as text, like with the Safe extension
+	HTMLDrop			// left out, like with the FilterHTML extension
+	HTMLFilter			// replaced by the result of Parser.FilterHTML
+)
+
+// Values of Parser.CommentPolicy.
+const (
+	CommentsDefault	= iota	// treated like other raw HTML, see HTMLPolicy
+	CommentsKeep			// printed as is, regardless of HTMLPolicy
+	CommentsStrip			// left out
+	CommentsFilter			// replaced by the result of Parser.FilterComment
+)
+
+/* policy - return the treatment of raw HTML; style blocks are not
+ * affected by the FilterHTML extension
+ */
+func (d *Doc) policy(style bool) int {
+	switch {
+	case d.htmlPolicy != HTMLDefault:
+		return d.htmlPolicy
+	case d.extension.FilterHTML && !style:
+		return HTMLDrop
+	case d.extension.Safe:
+		return HTMLEscape
+	}
+	return HTMLAllow
+}
+
+/* rawHTML - make the element for raw HTML s, an HTML block, if block
+ * is set, or a tag or comment
+ */
+func (d *Doc) rawHTML(s string, block bool) *Element {
+	if d.commentPolicy != CommentsDefault && strings.HasPrefix(s, "<!--") {
+		return d.comment(s, block)
+	}
+	return d.html(s, block, d.policy(false))
+}
+
+/* comment - make the element for an HTML comment s, as selected by
+ * d.commentPolicy
+ */
+func (d *Doc) comment(s string, block bool) *Element {
+	switch d.commentPolicy {
+	case CommentsStrip:
+		s = ""
+	case CommentsFilter:
+		if d.filterComment == nil {
+			return mk_list(LIST, nil)
+		}
+		s = d.filterComment(commentText(s), block)
+	}
+	return d.html(s, block, HTMLAllow)
+}
+
+/* commentText - return the text of an HTML comment, without <!-- and
+ * -->, and the white space around it
+ */
+func commentText(s string) string {
+	return strings.TrimSpace(s[len("<!--") : len(s)-len("-->")])
+}
+
+/* styleBlock - make the element for a <style> block */
+func (d *Doc) styleBlock(s string) *Element {
+	if d.extension.FilterStyles {
+		return mk_list(LIST, nil)
+	}
+	return d.html(s, true, d.policy(true))
+}
+
+func (d *Doc) html(s string, block bool, policy int) *Element {
+	switch policy {
+	case HTMLEscape:
+		if block {
+			return mk_list(PARA, mk_str(s))
+		}
+		return mk_str(s)
+	case HTMLFilter:
+		if d.filterHTML == nil {
+			return mk_list(LIST, nil)
+		}
+		if block {
+			s = d.filterHTML(s, block)
+		} else {
+			s = d.filterTag(s)
+		}
+	case HTMLDrop:
+		s = ""
+	}
+	if s == "" {
+		return mk_list(LIST, nil)
+	}
+	e := mk_str(s)
+	if block {
+		e.key = HTMLBLOCK
+	} else {
+		e.key = HTML
+	}
+	return e
+}
+
+/* filterTag - pass a tag or comment within text to d.filterHTML; the
+ * end tag of a start tag dropped is dropped, too.  For each element,
+ * d.openTags tracks whether the start tags still open have been
+ * dropped, at most maxOpen, as those never closed pile up
+ */
+func (d *Doc) filterTag(tag string) string {
+	const maxOpen = 64
+	s := d.filterHTML(tag, false)
+	if len(tag) < 2 || strings.Index("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ/", tag[1:2]) == -1 {
+		return s	/* a comment, or a declaration */
+	}
+	name, closing, _, n := parseTag(tag)
+	l := d.openTags[name]
+	switch {
+	case closing:
+		if len(l) > 0 {
+			d.openTags[name] = l[:len(l)-1]
+			if l[len(l)-1] {
+				return ""
+			}
+		}
+	case !voidElements[name] && !strings.HasSuffix(tag[:n], "/>"):
+		if d.openTags == nil {
+			d.openTags = make(map[string][]bool)
+		}
+		if len(l) == maxOpen {
+			l = l[1:]
+		}
+		d.openTags[name] = append(l, s == "")
+	}
+	return s
+}
+
+// AllowTags returns a function for Parser.FilterHTML that keeps the
+// tags of the elements named, like "kbd" and "sup", and drops other
+// tags and comments; an HTML block is kept only if all its tags are
+// allowed.  Tags with event handler attributes, like onclick, style
+// attributes, or javascript:, vbscript: or data: URLs are dropped,
+// too.  Attributes are split, and character references in their
+// values decoded, as browsers do it.  The function keeps no state, so
+// it may be shared by Parsers used at the same time.
+func AllowTags(names ...string) func(html string, block bool) string {
+	allowed := make(map[string]bool)
+	for _, name := range names {
+		allowed[strings.ToLower(name)] = true
+	}
+	return func(html string, block bool) string {
+		s := html
+		for i := strings.Index(s, "<"); i != -1; i = strings.Index(s, "<") {
+			s = s[i:]
+			if len(s) < 2 || strings.Index("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ/!", s[1:2]) == -1 {
+				s = s[1:]
+				continue	/* not a tag */
+			}
+			if s[1] == '!' {
+				return ""	/* a comment, or a declaration */
+			}
+			name, closing, attrs, n := parseTag(s)
+			s = s[n:]
+			if !allowed[name] || !closing && unsafeTag(attrs) {
+				return ""
+			}
+		}
+		return html
+	}
+}
+
+/* tagName - return the name of the element of a start or end tag, in
+ * lower case
+ */
+func tagName(tag string) string {
+	s := strings.TrimLeft(tag[1:], "/ \t\n")
+	n := 0
+	for n < len(s) && (s[n] >= 'a' && s[n] <= 'z' || s[n] >= '0' && s[n] <= '9') {
+		n++
+	}
+	return s[:n]
+}
+
+/* parseTag - split the start or end tag at the beginning of s, like
+ * browsers do, into the name of its element, in lower case, whether
+ * it is an end tag, and its attributes, with their names in lower
+ * case; n is the length of the tag, up to the first > not within a
+ * quoted value
+ */
+func parseTag(s string) (name string, closing bool, attrs []Attr, n int) {
+	i := 1
+	if i < len(s) && s[i] == '/' {
+		closing = true
+		i++
+	}
+	j := i
+	for j < len(s) && !isTagSpace(s[j]) && s[j] != '/' && s[j] != '>' {
+		j++
+	}
+	name = strings.ToLower(s[i:j])
+	for i = j; i < len(s); {
+		switch s[i] {
+		case '>':
+			return name, closing, attrs, i + 1
+		case ' ', '\t', '\n', '\f', '\r', '/':
+			i++
+			continue
+		}
+
+		/* an attribute, which may follow a quoted value without
+		 * white space, like title="a"onclick="..."
+		 */
+		j = i + 1
+		for j < len(s) && !isTagSpace(s[j]) && s[j] != '/' && s[j] != '>' && s[j] != '=' {
+			j++
+		}
+		a := Attr{Key: strings.ToLower(s[i:j])}
+		k := j
+		for k < len(s) && isTagSpace(s[k]) {
+			k++
+		}
+		if k < len(s) && s[k] == '=' {
+			for k++; k < len(s) && isTagSpace(s[k]); k++ {
+			}
+			switch {
+			case k < len(s) && (s[k] == '"' || s[k] == '\''):
+				e := strings.Index(s[k+1:], s[k:k+1])
+				if e == -1 {
+					a.Value = s[k+1:]
+					j = len(s)
+				} else {
+					a.Value = s[k+1 : k+1+e]
+					j = k + 2 + e
+				}
+			default:
+				for j = k; j < len(s) && !isTagSpace(s[j]) && s[j] != '>'; j++ {
+				}
+				a.Value = s[k:j]
+			}
+		}
+		attrs = append(attrs, a)
+		i = j
+	}
+	return name, closing, attrs, len(s)
+}
+
+func isTagSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\f' || c == '\r'
+}
+
+/* unsafeTag - report whether the attributes of a tag include an event
+ * handler, a style, or a javascript:, vbscript: or data: URL, which
+ * safeURL removes from links
+ */
+func unsafeTag(attrs []Attr) bool {
+	for _, a := range attrs {
+		if strings.HasPrefix(a.Key, "on") || a.Key == "style" {
+			return true
+		}
+		v := attrValue(a.Value)
+		if strings.Index(v, "javascript:") != -1 || strings.Index(v, "vbscript:") != -1 || strings.HasPrefix(v, "data:") {
+			return true
+		}
+	}
+	return false
+}
+
+/* attrValue - return the value of an attribute as browsers see it
+ * when they look for the scheme of a URL: with character references
+ * decoded, including numeric ones without a semicolon, and without
+ * white space and control characters, in lower case
+ */
+func attrValue(s string) string {
+	if strings.Index(s, "&") != -1 {
+		var b bytes.Buffer
+		for i := 0; i < len(s); i++ {
+			if s[i] == '&' {
+				if text, n := attrCharRef(s[i:]); n > 0 {
+					b.WriteString(text)
+					i += n - 1
+					continue
+				}
+			}
+			b.WriteByte(s[i])
+		}
+		s = b.String()
+	}
+	b := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c > ' ' && c != 0x7f {
+			b = append(b, c)
+		}
+	}
+	return strings.ToLower(string(b))
+}
+
+/* references of HTML5 browsers decode that may hide a URL scheme,
+ * which are not in htmlEntities
+ */
+var attrEntities = map[string]int{
+	"colon":	':',
+	"Tab":		'\t',
+	"NewLine":	'\n',
+}
+
+/* attrCharRef - return the text of the character reference at the
+ * start of s, and its length, or 0, if there is none
+ */
+func attrCharRef(s string) (text string, n int) {
+	if strings.HasPrefix(s, "&#") {
+		i := 2
+		base := 10
+		if i < len(s) && (s[i] == 'x' || s[i] == 'X') {
+			base = 16
+			i++
+		}
+		j := i
+		for j < len(s) && (base == 16 && isHexDigit(s[j]) || s[j] >= '0' && s[j] <= '9') {
+			j++
+		}
+		if j == i {
+			return "", 0
+		}
+		c, err := strconv.Btoui64(s[i:j], base)
+		if err != nil || c == 0 || c > 0x10FFFF {
+			c = 0xFFFD
+		}
+		if j < len(s) && s[j] == ';' {
+			j++
+		}
+		return string(int(c)), j
+	}
+	j := strings.Index(s, ";")
+	if j < 2 {
+		return "", 0
+	}
+	if c, ok := attrEntities[s[1:j]]; ok {
+		return string(c), j + 1
+	}
+	if text = entityText(s[:j+1]); text != "" {
+		return text, j + 1
+	}
+	return "", 0
+}
@@ -0,0 +1,47 @@
+package markdown
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+/* allowKbd - convert text with a Parser keeping <kbd> tags only */
+func allowKbd(p *Parser, text string) string {
+	var b bytes.Buffer
+	p.Parse(text).WriteHtml(&b)
+	return strings.TrimSpace(b.String())
+}
+
+// TestAllowTags checks the tags kept by AllowTags, and that the end
+// tags of dropped start tags are dropped as well.
+func TestAllowTags(t *testing.T) {
+	p := NewParser(Extensions{})
+	p.HTMLPolicy = HTMLFilter
+	p.FilterHTML = AllowTags("kbd")
+	for _, c := range []struct {
+		text, html	string
+	}{
+		{"<kbd>x</kbd>", "<p><kbd>x</kbd></p>"},
+		{"<b>x</b>", "<p>x</p>"},
+		{`<kbd title="a"onmouseover="alert(1)">x</kbd>`, "<p>x</p>"},
+		{`<kbd title="a>b" onclick="alert(1)">x</kbd>`, "<p>x</p>"},
+		{`<kbd style="position:fixed">x</kbd>`, "<p>x</p>"},
+		{`<kbd data-x="jav&#x61;script:alert(1)">x</kbd>`, "<p>x</p>"},
+		{`<kbd data-x="jav&#x09;ascript:alert(1)">x</kbd>`, "<p>x</p>"},
+		{`<kbd title="data:text/html,x">x</kbd>`, "<p>x</p>"},
+		{`<kbd onclick="x">a <kbd>b</kbd></kbd>`, "<p>a <kbd>b</kbd></p>"},
+	} {
+		if s := allowKbd(p, c.text); s != c.html {
+			t.Errorf("%q: %s, want %s", c.text, s, c.html)
+		}
+	}
+
+	/* a start tag left open in one document doesn't decide the
+	 * treatment of end tags in the next one
+	 */
+	allowKbd(p, `<kbd onclick="x">open`)
+	if s := allowKbd(p, "a</kbd>"); s != "<p>a</kbd></p>" {
+		t.Errorf("end tag after a dropped start tag of another document: %s", s)
+	}
+}
@@ -0,0 +1,75 @@
+package markdown
+
+// Link reference definitions
+
+import (
+	"strings"
+)
+
+// A Reference is the target of a link definition
+//
+//	[label]: url "title"
+//
+type Reference struct {
+	URL		string
+	Title	string
+}
+
+// References returns the link definitions available to the document,
+// indexed by label, as written in the definition. This includes the
+// definitions passed in by Parser.References. Labels are matched
+// case-insensitively; if a label is defined more than once, only the
+// definition used for links is returned.
+func (d *Doc) References() map[string]Reference {
+	m := make(map[string]Reference)
+	seen := make(map[string]bool)
+	for e := d.references; e != nil; e = e.next {
+		l := e.contents.link
+		label := plainText(l.label)
+		if u := strings.ToUpper(label); !seen[u] {
+			seen[u] = true
+			m[label] = Reference{l.url, l.title}
+		}
+	}
+	return m
+}
+
+/* enterLabel - called when the parser descends into a link label,
+ * within which URLs in running text are not turned into links, see
+ * BareLink; each call is matched by a call of leaveLabel
+ */
+func (d *Doc) enterLabel() bool {
+	d.labels++
+	return true
+}
+
+func (d *Doc) leaveLabel() bool {
+	d.labels--
+	return true
+}
+
+/* addReferences - append the definitions in refs to the list of
+ * references of the document, so that definitions found in the
+ * document take precedence.  Each label is run through the
+ * References rule, to get it into the same form as a label
+ * within the document.
+ */
+func (d *Doc) addReferences(refs map[string]Reference) {
+	own := d.references
+	tail := &own
+	for *tail != nil {
+		tail = &(*tail).next
+	}
+	for label, r := range refs {
+		d.parseRule(ruleReferences, "["+label+"]: x\n")
+		e := d.references
+		if e == nil || e.next != nil {
+			continue	/* not a valid label */
+		}
+		e.contents.link.url = d.safeURL(r.URL)
+		e.contents.link.title = r.Title
+		*tail = e
+		tail = &e.next
+	}
+	d.references = own
+}
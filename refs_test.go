@@ -0,0 +1,53 @@
+package markdown
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestLabelKinds checks that the kinds of inline elements within
+// link labels, which exited the program before, are matched against
+// the labels of the definitions.
+func TestLabelKinds(t *testing.T) {
+	for _, c := range []struct {
+		name, url	string
+		ext			Extensions
+	}{
+		{"label-supsub", "/water", Extensions{SupSub: true}},
+		{"label-emoji", "/smile", Extensions{Emoji: true}},
+		{"label-note", "/note", Extensions{Notes: true}},
+	} {
+		data, err := ioutil.ReadFile(filepath.Join("testdata/fuzz/corpus", c.name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		links := NewParser(c.ext).ParseBytes(data).Links()
+		if len(links) != 1 || links[0].URL != c.url {
+			t.Errorf("%s: links %v, want one to %s", c.name, links, c.url)
+		}
+	}
+}
+
+// TestBareURLLabels checks that URLs in running text stop at
+// brackets, and are not turned into links within link labels.
+func TestBareURLLabels(t *testing.T) {
+	for _, c := range []struct {
+		text, html	string
+	}{
+		{"[https://x.org](https://x.org)", `<p><a href="https://x.org">https://x.org</a></p>`},
+		{"[docs at www.x.org](/docs)", `<p><a href="/docs">docs at www.x.org</a></p>`},
+		{"[docs at www.x.org][d]\n\n[d]: /docs", `<p><a href="/docs">docs at www.x.org</a></p>`},
+		{"[https://x.org/a][]\n\n[https://x.org/a]: /a", `<p><a href="/a">https://x.org/a</a></p>`},
+		{"(see https://x.org/a_(b))", `<p>(see <a href="https://x.org/a_(b)">https://x.org/a_(b)</a>)</p>`},
+		{"https://x.org]", `<p><a href="https://x.org">https://x.org</a>]</p>`},
+	} {
+		var b bytes.Buffer
+		NewParser(Extensions{Autolink: true}).Parse(c.text).WriteHtml(&b)
+		if s := strings.TrimSpace(b.String()); s != c.html {
+			t.Errorf("%q: %s, want %s", c.text, s, c.html)
+		}
+	}
+}
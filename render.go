@@ -0,0 +1,612 @@
+package markdown
+
+// Tree walking for output formats
+
+import (
+	"log"
+)
+
+// A Renderer formats the elements of a document tree. Doc.Render
+// walks the tree and calls, for each element, the method matching
+// its kind. Methods of container elements are called twice: with
+// entering set to true before the element's children are rendered,
+// and with entering set to false afterwards.
+//
+// The elements of extensions, like tables or strikethrough, are
+// passed to the methods of optional interfaces, like TableRenderer;
+// if r doesn't implement one, Render falls back to the methods of
+// Renderer, e.g. rendering the text of struck out words only.  A
+// Renderer embedding a BaseRenderer needs to implement the methods
+// it uses only.
+type Renderer interface {
+	// Inline elements
+	Str(s string)	// plain text, not yet escaped
+	Space(s string)
+	LineBreak()
+	Ellipsis()
+	EmDash()
+	EnDash()
+	Apostrophe()
+	SingleQuoted(entering bool)
+	DoubleQuoted(entering bool)
+	Code(s string)
+	Html(s string)	// raw inline HTML
+	Link(url, title string, entering bool)	// children are the link label
+	Image(url, title string, entering bool)	// children are the alternate text
+	Emph(entering bool)
+	Strong(entering bool)
+
+	// Note is called for a reference to the n-th footnote.
+	// Calling body renders the contents of the note; it
+	// may be called at any time, e.g. after Render has
+	// returned, to collect notes at the end of a document.
+	Note(n int, body func())
+
+	// Block elements
+	Heading(level int, id string, entering bool)	// id is set if the HeadingIDs extension is enabled
+	Plain(entering bool)
+	Para(entering bool)
+	HRule()
+	HtmlBlock(s string)
+	Verbatim(s, lang string)	// lang is the language of a fenced code block, or ""
+	BlockQuote(entering bool)
+	BulletList(entering bool)
+	OrderedList(start int, delim string, entering bool)	// start is the number of the first item, delim "." or ")", see Element.ListStart
+	ListItem(entering bool)
+	DefinitionList(entering bool)
+	DefTitle(entering bool)
+	DefData(entering bool)
+}
+
+// A BaseRenderer implements Renderer, printing nothing.  Embedded in
+// the type of a Renderer, it provides the methods that the type
+// doesn't implement, so that it keeps working if methods are added.
+// It implements none of the optional interfaces, like TableRenderer.
+type BaseRenderer struct{}
+
+func (BaseRenderer) Str(s string)										{}
+func (BaseRenderer) Space(s string)										{}
+func (BaseRenderer) LineBreak()											{}
+func (BaseRenderer) Ellipsis()											{}
+func (BaseRenderer) EmDash()											{}
+func (BaseRenderer) EnDash()											{}
+func (BaseRenderer) Apostrophe()										{}
+func (BaseRenderer) SingleQuoted(entering bool)							{}
+func (BaseRenderer) DoubleQuoted(entering bool)							{}
+func (BaseRenderer) Code(s string)										{}
+func (BaseRenderer) Html(s string)										{}
+func (BaseRenderer) Link(url, title string, entering bool)				{}
+func (BaseRenderer) Image(url, title string, entering bool)				{}
+func (BaseRenderer) Emph(entering bool)									{}
+func (BaseRenderer) Strong(entering bool)								{}
+func (BaseRenderer) Note(n int, body func())							{}
+func (BaseRenderer) Heading(level int, id string, entering bool)		{}
+func (BaseRenderer) Plain(entering bool)								{}
+func (BaseRenderer) Para(entering bool)									{}
+func (BaseRenderer) HRule()												{}
+func (BaseRenderer) HtmlBlock(s string)									{}
+func (BaseRenderer) Verbatim(s, lang string)							{}
+func (BaseRenderer) BlockQuote(entering bool)							{}
+func (BaseRenderer) BulletList(entering bool)							{}
+func (BaseRenderer) OrderedList(start int, delim string, entering bool)	{}
+func (BaseRenderer) ListItem(entering bool)								{}
+func (BaseRenderer) DefinitionList(entering bool)						{}
+func (BaseRenderer) DefTitle(entering bool)								{}
+func (BaseRenderer) DefData(entering bool)								{}
+
+// Optional interfaces of a Renderer for the elements of extensions.
+// Render falls back to the methods of Renderer as noted for those not
+// implemented.
+type (
+	// Strikethrough, and superscripts and subscripts; otherwise
+	// their text is rendered.
+	StrikeRenderer interface {
+		Strike(entering bool)
+	}
+	SupSubRenderer interface {
+		Superscript(entering bool)
+		Subscript(entering bool)
+	}
+
+	// TeX formulas; otherwise rendered by Code.
+	MathRenderer interface {
+		Math(s string, display bool)	// TeX source of a formula
+	}
+
+	// Abbreviations, and citations; otherwise their text is
+	// rendered.  Bibliography is called at the end of the
+	// document; its children are a div for each work cited.
+	AbbrRenderer interface {
+		Abbr(title string, entering bool)	// children are the abbreviation
+	}
+	CitationRenderer interface {
+		Citation(source string, entering bool)	// source is the citation as written, like [@key, p. 33]
+		Bibliography(entering bool)
+	}
+
+	// Emoji; otherwise their text is rendered by Str.
+	EmojiRenderer interface {
+		Emoji(name, s string)	// s is the text of the emoji with shortcode name
+	}
+
+	// A para consisting of an image only, if Doc.Figures is set;
+	// otherwise rendered as a para.
+	FigureRenderer interface {
+		Figure(caption string, entering bool)	// caption is the title of the image
+	}
+
+	// Admonitions, otherwise rendered as block quotes, with the
+	// title as a para, and fenced divs, otherwise rendered as
+	// their contents.
+	AdmonitionRenderer interface {
+		Admonition(kind string, entering bool)	// kind is e.g. "note", or several words, like "danger highlight"
+		AdmonitionTitle(entering bool)	// called, if the admonition has a title, right after its start
+	}
+	DivRenderer interface {
+		Div(class string, entering bool)	// class holds the classes of the div, separated by spaces
+	}
+
+	// Items of task lists; otherwise rendered as list items.
+	TaskRenderer interface {
+		TaskItem(done bool, entering bool)	// done if checked
+	}
+
+	// Tables; otherwise each row is rendered as a para, with
+	// the cells separated by spaces.  The alignment of a column
+	// is one of "left", "center", "right", or "" if not specified.
+	TableRenderer interface {
+		Table(align []string, entering bool)
+		TableHead(entering bool)
+		TableBody(entering bool)
+		TableRow(entering bool)
+		TableCell(align string, header bool, entering bool)
+	}
+
+	// TOC is called where a [TOC] marker appears in the document,
+	// if the TOC extension is enabled; otherwise, nothing is
+	// rendered.
+	TOCRenderer interface {
+		TOC(toc []*TOCItem)
+	}
+)
+
+// A PositionRenderer is a Renderer that is passed the positions of
+// elements in the text, like the HTML output if Doc.SourcePos is set.
+// If implemented, SetPosition is called by Render before each element
+// is started, with line 0 if its position is not known, see
+// Element.Lines.
+type PositionRenderer interface {
+	SetPosition(line, col, endLine, endCol int)
+}
+
+// A NotePreviewer is a Renderer that shows the contents of a note where
+// it is referred to, like the HTML output as a popover, if Doc.Popovers
+// is set.  If implemented, PreviewNote is called right before Note
+// with a function rendering the contents to another Renderer, which
+// leaves the numbering of the notes alone.
+type NotePreviewer interface {
+	PreviewNote(n int, render func(r Renderer))
+}
+
+// A URLResolver returns the URL to be printed for the destination
+// of a link (kind is LINK) or the source of an image (kind is IMAGE),
+// e.g. to rebase relative paths or to resolve wiki-style links.
+type URLResolver func(kind int, url string) string
+
+// A RenderFunc renders the elements of a kind differently from the
+// Renderer, as set in Doc.RenderOverride. It is called with entering
+// set to true before an element is rendered, and returns whether it
+// has handled it; it may call the methods of r, like Html, to print
+// raw HTML. If it has, the label and the children of the element are
+// rendered next, like the alternate text of an image, and it is called
+// again with entering set to false. Otherwise, r renders the element
+// as usual.
+type RenderFunc func(r Renderer, e *Element, entering bool) (handled bool)
+
+// A FormatRenderer is a Renderer of one of the output formats of the
+// package that can print text in its own language, so that a
+// RenderFunc, or a Plugin, can render an element in each format,
+// instead of printing raw HTML that the other formats drop.  Format
+// returns the name of the format, as accepted by option -t of the
+// command: "html", "latex", "groff-mm", "man", "docbook", "markdown",
+// "text", "flowed", or "term".  Raw prints s as is, as part of the text of the
+// current block, like \newpage in LaTeX output.
+type FormatRenderer interface {
+	Format() string
+	Raw(s string)
+}
+
+// Render walks the document tree, calling the methods of r for
+// each element in document order.
+func (d *Doc) Render(r Renderer) {
+	w := &walker{r: r, d: d}
+	w.f, _ = r.(failer)
+	if n, ok := r.(numberer); !ok || !n.numbersHeadings() {
+		w.numbers = d.HeadingNumbers()
+	}
+	if d.NoteStart > 0 {
+		w.notenum = d.NoteStart - 1
+	}
+	w.elist(d.tree)
+}
+
+/* a failer is a Renderer that can fail, like the HTML output once its
+ * Writer has returned an error; the walker stops calling it then
+ */
+type failer interface {
+	failed() bool
+}
+
+type walker struct {
+	r		Renderer
+	f		failer	/* Set if r is a failer. */
+	d		*Doc
+	numbers	map[*Element]string	/* Section numbers of the headings, see Doc.Numbering. */
+	notenum	int
+	inHead	bool
+}
+
+func (w *walker) url(kind int, url string) string {
+	if w.d.ResolveURL != nil {
+		url = w.d.ResolveURL(kind, url)
+	}
+	return url
+}
+
+/* headingLevel - return the level printed for a heading of level n,
+ * see Doc.HeadingOffset
+ */
+func (d *Doc) headingLevel(n int) int {
+	max := 6
+	if d.MaxHeadingLevel > 0 && d.MaxHeadingLevel < max {
+		max = d.MaxHeadingLevel
+	}
+	n += d.HeadingOffset
+	switch {
+	case n > max:
+		n = max
+	case n < 1:
+		n = 1
+	}
+	return n
+}
+
+func (w *walker) elist(list *Element) {
+	for ; list != nil; list = list.next {
+		if w.f != nil && w.f.failed() {
+			return
+		}
+		w.elem(list)
+	}
+}
+
+func (w *walker) elem(elt *Element) {
+	r := w.r
+
+	if f := w.d.RenderOverride[elt.key]; f != nil && f(r, elt, true) {
+		w.elist(elt.Label())
+		w.elist(elt.children)
+		f(r, elt, false)
+		return
+	}
+	if pr, ok := r.(PositionRenderer); ok {
+		if elt.col > 0 {
+			pr.SetPosition(elt.line, elt.col, elt.endLine, elt.endCol)
+		} else {
+			pr.SetPosition(0, 0, 0, 0)
+		}
+	}
+	if elt.attr != nil {
+		if ar, ok := r.(AttributeRenderer); ok {
+			ar.SetAttributes(elt.attr)
+		}
+	}
+	switch elt.key {
+	case SPACE:
+		r.Space(elt.contents.str)
+	case LINEBREAK:
+		r.LineBreak()
+	case STR:
+		r.Str(elt.contents.str)
+	case ELLIPSIS:
+		r.Ellipsis()
+	case EMDASH:
+		r.EmDash()
+	case ENDASH:
+		r.EnDash()
+	case APOSTROPHE:
+		r.Apostrophe()
+	case SINGLEQUOTED:
+		if q := w.d.smart.Quotes; q != nil {
+			r.Str(q.SingleOpen)
+			w.elist(elt.children)
+			r.Str(q.SingleClose)
+			break
+		}
+		r.SingleQuoted(true)
+		w.elist(elt.children)
+		r.SingleQuoted(false)
+	case DOUBLEQUOTED:
+		if q := w.d.smart.Quotes; q != nil {
+			r.Str(q.DoubleOpen)
+			w.elist(elt.children)
+			r.Str(q.DoubleClose)
+			break
+		}
+		r.DoubleQuoted(true)
+		w.elist(elt.children)
+		r.DoubleQuoted(false)
+	case CODE:
+		r.Code(elt.contents.str)
+	case MATH, DISPLAYMATH:
+		if mr, ok := r.(MathRenderer); ok {
+			mr.Math(elt.contents.str, elt.key == DISPLAYMATH)
+		} else {
+			r.Code(elt.contents.str)
+		}
+	case HTML:
+		r.Html(elt.contents.str)
+	case LINK:
+		l := elt.contents.link
+		url := w.url(LINK, l.url)
+		r.Link(url, l.title, true)
+		w.elist(l.label)
+		r.Link(url, l.title, false)
+	case IMAGE:
+		l := elt.contents.link
+		url := w.url(IMAGE, l.url)
+		r.Image(url, l.title, true)
+		w.elist(l.label)
+		r.Image(url, l.title, false)
+	case EMPH:
+		r.Emph(true)
+		w.elist(elt.children)
+		r.Emph(false)
+	case STRONG:
+		r.Strong(true)
+		w.elist(elt.children)
+		r.Strong(false)
+	case STRIKE:
+		sr, ok := r.(StrikeRenderer)
+		if ok {
+			sr.Strike(true)
+		}
+		w.elist(elt.children)
+		if ok {
+			sr.Strike(false)
+		}
+	case SUPERSCRIPT, SUBSCRIPT:
+		sr, ok := r.(SupSubRenderer)
+		if ok {
+			w.supSub(sr, elt.key, true)
+		}
+		w.elist(elt.children)
+		if ok {
+			w.supSub(sr, elt.key, false)
+		}
+	case EMOJI:
+		s := w.d.emojiText(elt.contents.str)
+		switch er, ok := r.(EmojiRenderer); {
+		case ok:
+			er.Emoji(elt.contents.str, s)
+		case s != "":
+			r.Str(s)
+		default:
+			r.Str(":" + elt.contents.str + ":")
+		}
+	case ABBR:
+		ar, ok := r.(AbbrRenderer)
+		if ok {
+			ar.Abbr(elt.contents.str, true)
+		}
+		w.elist(elt.children)
+		if ok {
+			ar.Abbr(elt.contents.str, false)
+		}
+	case LIST:
+		w.elist(elt.children)
+	case RAW:
+		/* Shouldn't occur - these are handled by process_raw_blocks() */
+		log.Fatalf("RAW")
+	case H1, H2, H3, H4, H5, H6:
+		level := w.d.headingLevel(elt.key - H1 + 1)	/* assumes H1 ... H6 are in order */
+		r.Heading(level, elt.contents.str, true)
+		if n := w.numbers[elt]; n != "" {
+			r.Str(n + " ")
+		}
+		w.elist(elt.children)
+		r.Heading(level, elt.contents.str, false)
+	case PLAIN:
+		r.Plain(true)
+		w.elist(elt.children)
+		r.Plain(false)
+	case PARA:
+		if fr, ok := r.(FigureRenderer); ok {
+			if img := w.figure(elt); img != nil {
+				fr.Figure(img.contents.link.title, true)
+				w.elem(img)
+				fr.Figure(img.contents.link.title, false)
+				break
+			}
+		}
+		r.Para(true)
+		w.elist(elt.children)
+		r.Para(false)
+	case HRULE:
+		r.HRule()
+	case HTMLBLOCK:
+		r.HtmlBlock(elt.contents.str)
+	case VERBATIM:
+		r.Verbatim(elt.contents.str, elt.Language())
+	case BLOCKQUOTE:
+		r.BlockQuote(true)
+		w.elist(elt.children)
+		r.BlockQuote(false)
+	case ADMONITION:
+		ar, ok := r.(AdmonitionRenderer)
+		if !ok {
+			r.BlockQuote(true)
+			if l := elt.Label(); l != nil {
+				r.Para(true)
+				w.elist(l)
+				r.Para(false)
+			}
+			w.elist(elt.children)
+			r.BlockQuote(false)
+			break
+		}
+		ar.Admonition(elt.contents.str, true)
+		if l := elt.Label(); l != nil {
+			ar.AdmonitionTitle(true)
+			w.elist(l)
+			ar.AdmonitionTitle(false)
+		}
+		w.elist(elt.children)
+		ar.Admonition(elt.contents.str, false)
+	case DIV:
+		dr, ok := r.(DivRenderer)
+		if ok {
+			dr.Div(elt.contents.str, true)
+		}
+		w.elist(elt.children)
+		if ok {
+			dr.Div(elt.contents.str, false)
+		}
+	case CITATION:
+		cr, ok := r.(CitationRenderer)
+		if ok {
+			cr.Citation(elt.contents.str, true)
+		}
+		w.elist(elt.children)
+		if ok {
+			cr.Citation(elt.contents.str, false)
+		}
+	case BIBLIOGRAPHY:
+		cr, ok := r.(CitationRenderer)
+		if ok {
+			cr.Bibliography(true)
+		}
+		w.elist(elt.children)
+		if ok {
+			cr.Bibliography(false)
+		}
+	case BULLETLIST:
+		r.BulletList(true)
+		w.elist(elt.children)
+		r.BulletList(false)
+	case ORDEREDLIST:
+		start, delim := elt.ListStart()
+		r.OrderedList(start, delim, true)
+		w.elist(elt.children)
+		r.OrderedList(start, delim, false)
+	case LISTITEM:
+		if tr, ok := r.(TaskRenderer); ok {
+			if task, done := elt.Task(); task {
+				tr.TaskItem(done, true)
+				w.elist(elt.children)
+				tr.TaskItem(done, false)
+				break
+			}
+		}
+		r.ListItem(true)
+		w.elist(elt.children)
+		r.ListItem(false)
+	case DEFINITIONLIST:
+		r.DefinitionList(true)
+		w.elist(elt.children)
+		r.DefinitionList(false)
+	case DEFTITLE:
+		r.DefTitle(true)
+		w.elist(elt.children)
+		r.DefTitle(false)
+	case DEFDATA:
+		r.DefData(true)
+		w.elist(elt.children)
+		r.DefData(false)
+	case TABLE, TABLEHEAD, TABLEBODY, TABLEROW, TABLECELL:
+		if tr, ok := r.(TableRenderer); ok {
+			w.table(tr, elt)
+			break
+		}
+		switch elt.key {
+		case TABLEROW:
+			r.Para(true)
+			for c := elt.children; c != nil; c = c.next {
+				if c != elt.children {
+					r.Space(" ")
+				}
+				w.elist(c.children)
+			}
+			r.Para(false)
+		default:
+			w.elist(elt.children)
+		}
+	case TOC:
+		if tr, ok := r.(TOCRenderer); ok {
+			tr.TOC(w.d.TOC())
+		}
+	case REFERENCE, ABBREVIATION:
+		/* Nonprinting */
+	case NOTE:
+		/* if contents.str == 0, then print note; else ignore, since this
+		 * is a note block that has been incorporated into the notes list
+		 */
+		if elt.contents.str == "" {
+			w.notenum++
+			n, children := w.notenum, elt.children
+			if np, ok := r.(NotePreviewer); ok {
+				np.PreviewNote(n, func(r Renderer) { (&walker{r: r, d: w.d, notenum: n}).elist(children) })
+			}
+			r.Note(n, func() { w.elist(children) })
+		}
+	default:
+		if pl := pluginOf(elt.key); pl != nil {
+			w.renderPlugin(pl, elt)
+			break
+		}
+		log.Fatalf("Render encountered unknown element key = %d\n", elt.key)
+	}
+}
+
+/* supSub - call the method of a superscript, or subscript, of r */
+func (w *walker) supSub(r SupSubRenderer, key int, entering bool) {
+	if key == SUPERSCRIPT {
+		r.Superscript(entering)
+	} else {
+		r.Subscript(entering)
+	}
+}
+
+/* table - render an element of a table, and its children */
+func (w *walker) table(r TableRenderer, elt *Element) {
+	switch elt.key {
+	case TABLE:
+		var align []string
+		if head := elt.children; head.children != nil {
+			for c := head.children.children; c != nil; c = c.next {
+				align = append(align, c.contents.str)
+			}
+		}
+		r.Table(align, true)
+		w.elist(elt.children)
+		r.Table(align, false)
+	case TABLEHEAD:
+		w.inHead = true
+		r.TableHead(true)
+		w.elist(elt.children)
+		r.TableHead(false)
+		w.inHead = false
+	case TABLEBODY:
+		r.TableBody(true)
+		w.elist(elt.children)
+		r.TableBody(false)
+	case TABLEROW:
+		r.TableRow(true)
+		w.elist(elt.children)
+		r.TableRow(false)
+	case TABLECELL:
+		r.TableCell(elt.contents.str, w.inHead, true)
+		w.elist(elt.children)
+		r.TableCell(elt.contents.str, w.inHead, false)
+	}
+}
@@ -0,0 +1,58 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+/* a textRenderer implements the methods of Renderer for text only */
+type textRenderer struct {
+	BaseRenderer
+	bytes.Buffer
+	lines	[]int
+}
+
+func (r *textRenderer) Str(s string)	{ r.WriteString(s) }
+func (r *textRenderer) Space(s string)	{ r.WriteString(" ") }
+
+func (r *textRenderer) Para(entering bool) {
+	if entering {
+		r.WriteString("[")
+	} else {
+		r.WriteString("]")
+	}
+}
+
+func (r *textRenderer) SetPosition(line, col, endLine, endCol int) {
+	if line > 0 {
+		r.lines = append(r.lines, line)
+	}
+}
+
+// TestBaseRenderer checks that Render falls back to the methods of
+// Renderer for elements of extensions, and passes positions to a
+// PositionRenderer.
+func TestBaseRenderer(t *testing.T) {
+	for _, c := range []struct {
+		text, out	string
+	}{
+		{"a ~~b~~ c", "[a b c]"},
+		{"| x | y |\n|---|---|\n| 1 | 2 |\n", "[x y][1 2]"},
+		{"- [x] done\n", "done"},
+		{"$x^2$", "[]"},
+	} {
+		var r textRenderer
+		p := NewParser(Extensions{Strike: true, Tables: true, TaskLists: true, Math: true})
+		p.Parse(c.text).Render(&r)
+		if s := r.String(); s != c.out {
+			t.Errorf("%q: %q, want %q", c.text, s, c.out)
+		}
+	}
+
+	var r textRenderer
+	NewParser(Extensions{}).Parse("one\n\ntwo\n").Render(&r)
+	if s := fmt.Sprint(r.lines); s != "[1 3]" {
+		t.Errorf("positions %s, want [1 3]", s)
+	}
+}
@@ -0,0 +1,301 @@
+package markdown
+
+// Parsing a document again after an edit
+
+import (
+	"io"
+	"strings"
+)
+
+// Reparse returns the document for the text resulting from an edit
+// of old, the text d has been parsed from using p: the bytes
+// old[start:end] are replaced by text.  Only the top level blocks
+// around the edit are parsed again, from the start of the block
+// preceding it, until the parser reaches the start of a block of d
+// following it; the other blocks are taken from d, with their lines
+// adjusted.  This allows e.g. an editor to update the preview of a
+// large document on each keystroke.  The link references, notes and
+// abbreviations of the whole text are collected again; if they, or
+// the front matter, have been changed by the edit, if the lines of
+// the edit contain HTML tags or code fences, if a note definition is
+// among the blocks parsed again, if the Citations or StrictLists
+// extension is enabled or Include is set, or if d has not been
+// returned by one of the Parse methods of p, or Reparse, the whole
+// text is parsed.  The OutputOptions of d, like Html5, are kept.  d
+// is modified, and must not be used afterwards.
+func (p *Parser) Reparse(d *Doc, old string, start, end int, text string) *Doc {
+	s := old[:start] + text + old[end:]
+	if p.reparse(d, old, s, start, end, text) {
+		return d
+	}
+	nd := p.Parse(s)
+	nd.OutputOptions = d.OutputOptions
+	return nd
+}
+
+/* reparse - update d for the text s resulting from the edit; returns
+ * false, if the whole text must be parsed instead
+ */
+func (p *Parser) reparse(d *Doc, old, s string, start, end int, text string) bool {
+	if d.err != nil || d.parser != nil || len(d.blocks) == 0 || p.checkSize(int64(len(s))) != nil {
+		return false
+	}
+	if p.ext.Citations || p.ext.StrictLists || p.Include != nil {
+		/* the list of works cited, the indentation of lists, and
+		 * included lines depend on the whole text
+		 */
+		return false
+	}
+	var elems []*Element
+	for e := d.tree; e != nil; e = e.next {
+		elems = append(elems, e)
+	}
+	n := len(elems)
+	if n == 0 || n != len(d.blocks)-1 {
+		return false
+	}
+
+	/* HTML blocks, fenced code blocks, fenced divs, and blocks of
+	 * plugins may extend over any number of blocks once a tag or fence
+	 * is added or removed
+	 */
+	chars := "<>`~"
+	if p.ext.FencedDivs {
+		chars += ":"
+	}
+	if d.plugins != nil {
+		for _, c := range []int{pluginBlock, pluginLateBlock} {
+			for _, pl := range d.plugins.class[c] {
+				chars += pl.Prefix[:1]
+			}
+		}
+	}
+	ls := strings.LastIndex(old[:start], "\n") + 1
+	le := len(old)
+	if i := strings.Index(old[end:], "\n"); i != -1 {
+		le = end + i
+	}
+	if strings.IndexAny(old[ls:le], chars) != -1 || strings.IndexAny(s[ls:le+len(s)-len(old)], chars) != -1 {
+		return false
+	}
+
+	/* the front matter and the definitions must not have changed */
+	if p.ext.FrontMatter {
+		if _, body := splitFrontMatter(old); start <= len(old)-len(body) {
+			return false
+		}
+	}
+	pf := p.preformat(len(s))
+	io.Copy(pf, strings.NewReader(s))
+	defs, _, _ := p.start(pf.text())
+	same := defs.err == nil &&
+		sameElements(d.references, defs.references) &&
+		sameElements(d.notes, defs.notes) &&
+		sameElements(d.abbreviations, defs.abbreviations)
+	defs.detach()
+	if !same {
+		return false
+	}
+
+	/* lines of the edit in old, and the number of lines added */
+	first := 1 + strings.Count(old[:start], "\n")
+	last := 1 + strings.Count(old[:end], "\n")
+	delta := strings.Count(text, "\n") - strings.Count(old[start:end], "\n")
+
+	/* start with the block preceding the edit, as its extent may
+	 * depend on the lines following it, and with the one before, as
+	 * e.g. a paragraph or list may take up the lines of the next
+	 * block; go back further until a block that starts with a blank
+	 * line.  Blocks starting within a line are parsed again together
+	 * with the preceding one.
+	 */
+	i0 := 0
+	for i := 1; i < n && d.blocks[i] < first; i++ {
+		if d.blocks[i] > 0 {
+			i0 = i
+		}
+	}
+	for back := true; i0 > 0 && (back || !blankLine(old, lineOffset(old, d.blocks[i0]-1))); back = false {
+		for i0--; d.blocks[i0] < 0; i0-- {
+		}
+	}
+	line0 := d.blocks[i0] - 1
+	off := lineOffset(old, line0)
+	pf = p.preformat(len(s) - off)
+	io.Copy(pf, strings.NewReader(s[off:]))
+	region := pf.text()
+
+	d.parser = p.yy
+	d.parser.Doc = d
+	d.setLimits(p.Limits, p.cancel)
+	warnings := d.warnings
+	d.warnings = nil
+	m := d.parser
+	m.ResetBuffer(region)
+	d.spans = d.spans[:0]
+	var list *Element
+	j := -1	/* index of the first block of d that is kept */
+	line := line0 + 1
+	for pos := 0; ; pos = m.Min {
+		if line > last+delta && region[pos-1] == '\n' {
+			for k := i0 + 1; k <= n && d.blocks[k] <= line-delta; k++ {
+				if d.blocks[k] == line-delta && d.blocks[k] > last {
+					j = k
+				}
+			}
+			if j != -1 {
+				break
+			}
+		}
+		if pos >= len(region) || !m.Parse(ruleDocBlock) || m.Min <= pos {
+			break
+		}
+		list = cons(d.tree, list)
+		line += strings.Count(region[pos:m.Min], "\n")
+	}
+	m.ResetBuffer("")
+	spans := d.spans
+	d.spans = nil
+	blocks := d.processRawBlocks(reverse(list))
+	if d.err != nil {
+		d.detach()
+		return false
+	}
+	for e := blocks; e != nil; e = e.next {
+		if e.key == NOTE && e.contents.str != "" {
+			/* the contents of a note, shared by its definition,
+			 * have been parsed already, see processRawBlocks
+			 */
+			d.detach()
+			return false
+		}
+	}
+	if d.abbreviations != nil {
+		d.markAbbreviations(blocks)
+	}
+	d.typeset(blocks)
+	setLines(blocks, spans, region, line0)
+	starts := blockLines(spans, region, line0)
+
+	/* keep the warnings about the blocks taken from d; those about
+	 * duplicate references are found again by checkReferences
+	 */
+	for _, w := range warnings {
+		switch {
+		case w.kind == DuplicateReference:
+		case w.e.line < d.blocks[i0], j != -1 && w.e.line >= d.blocks[j]:
+			d.warnings = append(d.warnings, w)
+		}
+	}
+
+	/* splice the new blocks into the list */
+	lines := append([]int(nil), d.blocks[:i0]...)
+	var tail *Element
+	if j != -1 {
+		for _, e := range elems[j:] {
+			e.line += delta
+			e.endLine += delta
+			shiftLines(e.Label(), delta)
+			shiftLines(e.children, delta)
+		}
+		if j < n {
+			tail = elems[j]
+		}
+		if len(starts) > 0 {
+			lines = append(lines, starts[:len(starts)-1]...)
+		}
+		for _, l := range d.blocks[j:] {
+			if l < 0 {
+				l -= delta
+			} else {
+				l += delta
+			}
+			lines = append(lines, l)
+		}
+	} else if len(starts) > 0 {
+		lines = append(lines, starts...)
+	} else {
+		lines = append(lines, line)
+	}
+	var tree *Element
+	link := &tree
+	for _, e := range elems[:i0] {
+		*link = e
+		link = &e.next
+	}
+	for e := blocks; e != nil; e = e.next {
+		*link = e
+		link = &e.next
+	}
+	*link = tail
+	d.tree = tree
+	d.blocks = lines
+
+	d.checkReferences()
+	if p.ext.HeadingIDs {
+		d.setAnchors(make(map[string]bool))
+	}
+	d.detach()
+	return true
+}
+
+/* lineOffset - return the offset of the line following the first n
+ * lines of s
+ */
+func lineOffset(s string, n int) int {
+	off := 0
+	for ; n > 0; n-- {
+		off += strings.Index(s[off:], "\n") + 1
+	}
+	return off
+}
+
+/* blankLine - return true if the line of s at offset off contains
+ * white space only
+ */
+func blankLine(s string, off int) bool {
+	line := s[off:]
+	if i := strings.Index(line, "\n"); i != -1 {
+		line = line[:i]
+	}
+	return strings.TrimSpace(line) == ""
+}
+
+/* shiftLines - add delta to the lines of the elements in list, and
+ * their descendants, like inheritLines
+ */
+func shiftLines(list *Element, delta int) {
+	for e := list; e != nil; e = e.next {
+		e.line += delta
+		e.endLine += delta
+		if e.key == NOTE {
+			/* the contents are shared with the list of notes */
+			continue
+		}
+		shiftLines(e.Label(), delta)
+		shiftLines(e.children, delta)
+	}
+}
+
+/* sameElements - return true if the lists a and b have the same
+ * structure and contents, like the definitions found in two versions
+ * of a document
+ */
+func sameElements(a, b *Element) bool {
+	for ; a != nil && b != nil; a, b = a.next, b.next {
+		if a.key != b.key || a.contents.str != b.contents.str {
+			return false
+		}
+		la, lb := a.contents.link, b.contents.link
+		if (la == nil) != (lb == nil) {
+			return false
+		}
+		if la != nil && (la.url != lb.url || la.title != lb.title || !sameElements(la.label, lb.label)) {
+			return false
+		}
+		if !sameElements(a.children, b.children) {
+			return false
+		}
+	}
+	return a == nil && b == nil
+}
@@ -0,0 +1,133 @@
+package markdown
+
+// Classes of characters, as seen by the inline rules of the grammar,
+// decoding UTF-8 sequences
+
+import (
+	"unicode"
+	"utf8"
+)
+
+/* runeAt - return the character starting at pos, or -1 at the end of
+ * the buffer
+ */
+func (d *Doc) runeAt(pos int) int {
+	b := d.parser.Buffer
+	if pos >= len(b) {
+		return -1
+	}
+	c, _ := utf8.DecodeRuneInString(b[pos:])
+	return c
+}
+
+/* runeBefore - return the character ending at pos, or -1 at the start
+ * of the buffer
+ */
+func (d *Doc) runeBefore(pos int) int {
+	if pos <= 0 {
+		return -1
+	}
+	c, _ := utf8.DecodeLastRuneInString(d.parser.Buffer[:pos])
+	return c
+}
+
+/* isWordRune - report whether c is part of a word: a letter, digit, or
+ * combining mark; invalid UTF-8 is taken as a letter
+ */
+func isWordRune(c int) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || unicode.IsMark(c) || c == utf8.RuneError
+}
+
+/* isWideSpace - report whether c is a space other than ' ' and '\t',
+ * like the ideographic space or the no-break space
+ */
+func isWideSpace(c int) bool {
+	return c >= utf8.RuneSelf && unicode.IsSpace(c)
+}
+
+/* isWidePunct - report whether c is a punctuation character beyond
+ * ASCII, like 。 or »; if closing is set, only closing punctuation,
+ * final quotes, and other punctuation, like 、 or ！, count
+ */
+func isWidePunct(c int, closing bool) bool {
+	if c < utf8.RuneSelf || !unicode.IsPunct(c) {
+		return false
+	}
+	return !closing || unicode.Is(unicode.Pe, c) || unicode.Is(unicode.Pf, c) || unicode.Is(unicode.Po, c)
+}
+
+/* alnumAt - report whether the character at pos is part of a word */
+func (d *Doc) alnumAt(pos int) bool {
+	c := d.runeAt(pos)
+	return c != -1 && isWordRune(c)
+}
+
+/* spaceAt - report whether a wide space starts at pos */
+func (d *Doc) spaceAt(pos int) bool {
+	return isWideSpace(d.runeAt(pos))
+}
+
+/* afterPunct - report whether the character before pos is a wide
+ * space or punctuation, which, like a space, ends a word
+ */
+func (d *Doc) afterPunct(pos int) bool {
+	c := d.runeBefore(pos)
+	return isWideSpace(c) || isWidePunct(c, false)
+}
+
+/* closingAt - report whether closing punctuation beyond ASCII, or a wide
+ * space, starts at pos
+ */
+func (d *Doc) closingAt(pos int) bool {
+	c := d.runeAt(pos)
+	return isWideSpace(c) || isWidePunct(c, true)
+}
+
+/* wide - ranges of East Asian wide and fullwidth characters, which
+ * take two columns on terminals and in monospaced fonts
+ */
+var wide = [][2]int{
+	{0x1100, 0x115F},	/* Hangul Jamo */
+	{0x2E80, 0x303E},	/* CJK radicals, punctuation */
+	{0x3041, 0x33FF},	/* Kana, CJK compatibility */
+	{0x3400, 0x4DBF},	/* CJK unified ideographs, extension A */
+	{0x4E00, 0x9FFF},	/* CJK unified ideographs */
+	{0xA000, 0xA4CF},	/* Yi */
+	{0xAC00, 0xD7A3},	/* Hangul syllables */
+	{0xF900, 0xFAFF},	/* CJK compatibility ideographs */
+	{0xFE30, 0xFE4F},	/* CJK compatibility forms */
+	{0xFF00, 0xFF60},	/* fullwidth forms */
+	{0xFFE0, 0xFFE6},
+	{0x1F300, 0x1F64F},	/* emoji */
+	{0x1F900, 0x1F9FF},
+	{0x20000, 0x3FFFD},	/* CJK extensions */
+}
+
+/* runeWidth - return the number of columns c takes: 0 for combining
+ * marks, 2 for wide characters, otherwise 1
+ */
+func runeWidth(c int) int {
+	if c < 0x300 {
+		return 1
+	}
+	if unicode.Is(unicode.Mn, c) || unicode.Is(unicode.Me, c) || c == 0x200B {
+		return 0
+	}
+	for _, r := range wide {
+		if c >= r[0] && c <= r[1] {
+			return 2
+		}
+	}
+	return 1
+}
+
+/* textWidth - return the number of columns s takes, for aligning
+ * tables, and wrapping lines
+ */
+func textWidth(s string) int {
+	n := 0
+	for _, c := range s {
+		n += runeWidth(c)
+	}
+	return n
+}
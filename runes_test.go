@@ -0,0 +1,75 @@
+package markdown
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestTextWidth checks the columns of wide, combining, and other
+// characters, used to align tables, and to wrap lines.
+func TestTextWidth(t *testing.T) {
+	for _, c := range []struct {
+		s	string
+		n	int
+	}{
+		{"", 0},
+		{"word", 4},
+		{"слово", 5},
+		{"中文", 4},
+		{"日本語のテキスト", 16},
+		{"한국어", 6},
+		{"ＡＢ", 4},
+		{"caf\u00e9", 4},
+		{"cafe\u0301", 4},
+		{"й", 1},
+		{"a中b", 4},
+	} {
+		if n := textWidth(c.s); n != c.n {
+			t.Errorf("textWidth(%q) = %d, want %d", c.s, n, c.n)
+		}
+	}
+	if n := termWidth("\x1b[1m中文\x1b[0m"); n != 4 {
+		t.Errorf("termWidth of bold CJK text = %d, want 4", n)
+	}
+}
+
+// TestSlug checks the fragment identifiers of headings in other
+// scripts than Latin.
+func TestSlug(t *testing.T) {
+	for _, c := range []struct {
+		text, slug	string
+	}{
+		{"Hello, World", "hello-world"},
+		{"Привет мир", "привет-мир"},
+		{"中文 标题", "中文-标题"},
+		{"Cafe\u0301 au lait", "cafe\u0301-au-lait"},
+		{"!?", "section"},
+	} {
+		if s := Slug(c.text); s != c.slug {
+			t.Errorf("Slug(%q) = %q, want %q", c.text, s, c.slug)
+		}
+	}
+}
+
+// TestEmphasisRunes checks that '*' and '_' are matched next to
+// characters of other scripts, and that intraword '_' is not.
+func TestEmphasisRunes(t *testing.T) {
+	for _, c := range []struct {
+		text	string
+		em		bool
+	}{
+		{"中文*强调*中文", true},
+		{"слово *выделено* слово", true},
+		{"слово _выделено_ слово", true},
+		{"слово_внутри_слова", false},
+		{"cafe\u0301_x_", false},
+		{"x_e\u0301_", false},
+	} {
+		var b bytes.Buffer
+		NewParser(Extensions{}).Parse(c.text).WriteHtml(&b)
+		if em := strings.Contains(b.String(), "<em>"); em != c.em {
+			t.Errorf("%q: %q, emphasis %v, want %v", c.text, b.String(), em, c.em)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package markdown
+
+// Parts of documents: sections, and ranges of lines
+
+import (
+	"strings"
+)
+
+// Section returns the part of the document consisting of the heading
+// named, at the top level, and the elements following it up to the
+// next heading of the same or a higher level, as a document of its
+// own; or nil, if there is no such heading. The name is the text of
+// the heading, compared regardless of case, or its anchor: the id
+// set by an attribute block or the HeadingIDs extension, or, if the
+// extension is not enabled, the one it would set.
+func (d *Doc) Section(name string) *Doc {
+	for e := d.tree; e != nil; e = e.next {
+		if e.key < H1 || e.key > H6 || !d.isSection(e, name) {
+			continue
+		}
+		list := []*Element{e}
+		for s := e.next; s != nil && (s.key < H1 || s.key > e.key); s = s.next {
+			list = append(list, s)
+		}
+		return d.part(list)
+	}
+	return nil
+}
+
+/* isSection - report whether the heading h is named name */
+func (d *Doc) isSection(h *Element, name string) bool {
+	text := plainText(h.children)
+	if strings.ToLower(strings.TrimSpace(text)) == strings.ToLower(strings.TrimSpace(name)) {
+		return true
+	}
+	id := h.contents.str
+	switch {
+	case h.attr != nil && h.attr.ID != "":
+		id = h.attr.ID
+	case id == "" && d.slugger != nil:
+		id = d.slugger(text)
+	case id == "":
+		id = Slug(text)
+	}
+	return id == name
+}
+
+// LineRange returns the part of the document consisting of the top
+// level blocks parsed from the lines first to last of the source
+// text, counting from 1, as a document of its own. Blocks extending
+// beyond these lines are left out.
+func (d *Doc) LineRange(first, last int) *Doc {
+	var list []*Element
+	for e := d.tree; e != nil; e = e.next {
+		if e.line >= first && e.line > 0 && e.endLine <= last {
+			list = append(list, e)
+		}
+	}
+	return d.part(list)
+}
+
+/* part - return a document like d, consisting of copies of the top
+ * level elements of list, and the warnings about their lines; their
+ * contents are shared with d
+ */
+func (d *Doc) part(list []*Element) *Doc {
+	p := *d
+	p.parser = nil
+	p.tree = nil
+	p.spans = nil
+	p.blocks = nil
+	p.warnings = nil
+	p.tree = chain(list)
+	if len(list) > 0 {
+		first, last := list[0].line, list[len(list)-1].endLine
+		for _, w := range d.warnings {
+			if w.e.line >= first && w.e.line <= last {
+				p.warnings = append(p.warnings, w)
+			}
+		}
+	}
+	return &p
+}
+
+/* chain - return a list of copies of the elements of list */
+func chain(list []*Element) *Element {
+	var first *Element
+	next := &first
+	for _, e := range list {
+		c := *e
+		c.next = nil
+		*next = &c
+		next = &c.next
+	}
+	return first
+}
@@ -0,0 +1,80 @@
+package markdown
+
+// Sessions, parsing the documents of a book as parts of a whole
+
+import (
+	"io"
+	"strings"
+)
+
+// A Session parses several documents, like the chapters of a book,
+// as parts of a whole: the link definitions of each document are
+// available to the others, footnotes are numbered on from one
+// document to the next, and the ids derived from headings are
+// unique across all of them. The documents are rendered as usual,
+// e.g. by WriteHtml, one after the other.
+type Session struct {
+	parser		*Parser
+	references	map[string]Reference	/* Link definitions of the documents. */
+	labels		map[string]bool			/* Labels of references, in upper case. */
+	anchors		map[string]bool			/* Ids of the headings parsed so far. */
+	notes		int						/* Number of footnotes parsed so far. */
+}
+
+// NewSession returns a Session parsing documents with p. The
+// Parser.References of p are available to each of them.
+func NewSession(p *Parser) *Session {
+	s := new(Session)
+	s.parser = p
+	s.references = make(map[string]Reference)
+	s.labels = make(map[string]bool)
+	s.anchors = make(map[string]bool)
+	s.addReferences(p.References)
+	return s
+}
+
+// Define adds the link definitions of text to those available to
+// the documents parsed afterwards, without parsing it in full. To
+// let a chapter link to a definition of a later one, call Define
+// for each chapter first, then Parse.
+func (s *Session) Define(text string) {
+	p := s.parser
+	pf := p.preformat(len(text))
+	io.Copy(pf, strings.NewReader(text))
+	refs := p.References
+	p.References = nil
+	t, _ := p.expandIncludes(pf.text())
+	d, _, _ := p.start(t)
+	p.References = refs
+	s.addReferences(d.References())
+	d.detach()
+}
+
+// Parse parses the next document of the session. Its NoteStart is
+// set to continue the numbering of the footnotes of the documents
+// parsed before.
+func (s *Session) Parse(text string) *Doc {
+	p := s.parser
+	refs := p.References
+	p.References = s.references
+	p.anchors = s.anchors
+	d := p.Parse(text)
+	p.References = refs
+	p.anchors = nil
+	s.addReferences(d.References())
+	d.NoteStart = s.notes + 1
+	s.notes += d.NoteCount()
+	return d
+}
+
+/* addReferences - add link definitions to the session, unless one
+ * with the same label has been added before
+ */
+func (s *Session) addReferences(refs map[string]Reference) {
+	for label, r := range refs {
+		if u := strings.ToUpper(label); !s.labels[u] {
+			s.labels[u] = true
+			s.references[label] = r
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package markdown
+
+// Splitting documents into slides
+
+import (
+	"bytes"
+	"os"
+	"strings"
+)
+
+// Values of the argument of Doc.Slides, selecting where a document
+// is split into slides; they can be combined, like
+// SlidesAtRules|SlidesAtHeadings.
+const (
+	SlidesAtRules		= 1 << iota	// at horizontal rules at the top level, which are dropped
+	SlidesAtHeadings				// before headings of level 1 and 2 at the top level
+)
+
+// Slides splits the document into slides at the top level elements
+// selected by at, and returns them as documents of their own, like
+// those returned by Section.  Slides that would print nothing are
+// left out, and footnotes are numbered on from one slide to the
+// next, so that their ids are unique across all slides.
+func (d *Doc) Slides(at int) []*Doc {
+	var slides []*Doc
+	var list []*Element
+	note := d.firstNote()
+	split := func() {
+		if printed(chain(list)) {
+			s := d.part(list)
+			s.NoteStart = note
+			note += countNotes(s.tree)
+			slides = append(slides, s)
+		}
+		list = nil
+	}
+	for e := d.tree; e != nil; e = e.next {
+		switch {
+		case e.key == HRULE && at&SlidesAtRules != 0:
+			split()
+			continue
+		case (e.key == H1 || e.key == H2) && at&SlidesAtHeadings != 0:
+			split()
+		}
+		list = append(list, e)
+	}
+	split()
+	return slides
+}
+
+// WriteSlides splits a document into slides, like Slides, and prints
+// each in HTML format, like WriteHtml, as a <section> element, with
+// its footnotes at its end.  The sections are what reveal.js expects
+// within <div class="reveal"><div class="slides">.
+// It returns the number of bytes written, and the first error
+// returned by w, if any, like WriteHtml.
+//
+func (d *Doc) WriteSlides(w Writer, at int) (n int, err os.Error) {
+	dest := &errWriter{w: w}
+	for _, s := range d.Slides(at) {
+		buf := new(bytes.Buffer)
+		s.WriteHtml(buf)
+		dest.WriteString("<section>\n")
+		dest.WriteString(strings.TrimRight(buf.String(), "\n"))
+		if _, err := dest.WriteString("\n</section>\n"); err != nil {
+			break
+		}
+	}
+	return dest.n, dest.err
+}
@@ -0,0 +1,30 @@
+package markdown
+
+// Configuration of the Smart extension
+
+// SmartOptions configure the Smart extension, see Parser.Smart.
+type SmartOptions struct {
+	Quotes		*QuoteStyle	// quotation marks to print; if nil, those of the output format
+	NoDashes	bool		// don't turn -- and --- into dashes
+	NoEllipsis	bool		// don't turn ... into an ellipsis
+	Fractions	bool		// turn 1/2, 1/4, 3/4 and similar fractions into ½, ¼, ¾
+	Arrows		bool		// turn -> and -->, <- and <--, <-> into →, ←, ↔
+	Symbols		bool		// turn (c), (r) and (tm) into ©, ® and ™
+	French		bool		// put no-break spaces before ! ? ; : and », and after «
+	Units		bool		// put no-break spaces between numbers and units, like 10 kg
+}
+
+// A QuoteStyle contains the quotation marks printed for quoted text.
+type QuoteStyle struct {
+	DoubleOpen, DoubleClose	string
+	SingleOpen, SingleClose	string
+}
+
+// Quotation marks of some languages. French quotes include
+// no-break spaces.
+var (
+	EnglishQuotes	= &QuoteStyle{"“", "”", "‘", "’"}
+	GermanQuotes	= &QuoteStyle{"„", "“", "‚", "‘"}
+	FrenchQuotes	= &QuoteStyle{"«\u00a0", "\u00a0»", "‹\u00a0", "\u00a0›"}
+	SwedishQuotes	= &QuoteStyle{"”", "”", "’", "’"}
+)
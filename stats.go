@@ -0,0 +1,92 @@
+package markdown
+
+// Document statistics
+
+import (
+	"bytes"
+	"strings"
+	"utf8"
+)
+
+// WordsPerMinute is the reading speed assumed by Doc.Stats.
+var WordsPerMinute = 200
+
+// Stats describes the size and contents of a document, see Doc.Stats.
+type Stats struct {
+	Words		int		// words of the text, including footnotes, but not code blocks or raw HTML
+	Chars		int		// characters of the same text, including spaces within blocks
+	Headings	[6]int	// number of headings of level 1 to 6
+	Links		int
+	Images		int
+	CodeBlocks	int
+	ReadingTime	int	// estimated minutes of reading, see WordsPerMinute
+}
+
+// Stats counts the words, headings, links, etc. of the document.
+func (d *Doc) Stats() *Stats {
+	c := new(statsCounter)
+	d.Walk(c)
+	st := &c.st
+	st.Words = len(strings.Fields(c.text.String()))
+	if st.Words > 0 && WordsPerMinute > 0 {
+		st.ReadingTime = (st.Words + WordsPerMinute - 1) / WordsPerMinute
+	}
+	return st
+}
+
+type statsCounter struct {
+	st		Stats
+	text	bytes.Buffer
+}
+
+func (c *statsCounter) Visit(e *Element) Visitor {
+	if e == nil {
+		return nil
+	}
+	switch e.key {
+	case STR, CODE, MATH, DISPLAYMATH:
+		c.add(e.contents.str)
+	case SPACE, LINEBREAK:
+		c.add(" ")
+	case ELLIPSIS:
+		c.add("…")
+	case EMDASH:
+		c.add("—")
+	case ENDASH:
+		c.add("–")
+	case APOSTROPHE:
+		c.add("’")
+	case SINGLEQUOTED, DOUBLEQUOTED:
+		c.add("''")	/* the quotation marks, at the start of the text quoted */
+	case LINK:
+		c.st.Links++
+	case IMAGE:
+		c.st.Images++
+	case VERBATIM:
+		c.st.CodeBlocks++
+		return nil
+	case EMOJI:
+		c.st.Chars++	/* not a word */
+		return nil
+	case H1, H2, H3, H4, H5, H6:
+		c.st.Headings[e.key-H1]++
+		c.text.WriteByte('\n')
+	case PLAIN, PARA, LISTITEM, BLOCKQUOTE, DEFTITLE, DEFDATA, TABLECELL, ADMONITION, DIV:
+		/* separate the words of blocks */
+		c.text.WriteByte('\n')
+	case HTML, HTMLBLOCK, REFERENCE, ABBREVIATION, BIBLIOGRAPHY:
+		return nil
+	case NOTE:
+		if e.contents.str != "" {
+			/* a note incorporated in the notes list */
+			return nil
+		}
+		c.text.WriteByte('\n')
+	}
+	return c
+}
+
+func (c *statsCounter) add(s string) {
+	c.text.WriteString(s)
+	c.st.Chars += utf8.RuneCountInString(s)
+}
@@ -0,0 +1,68 @@
+package markdown
+
+// Parsing and printing a document block by block
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// StreamHtml reads a document from r until EOF and prints it in
+// HTML format to w, like ParseReader followed by WriteHtml. But it
+// parses and prints one top level block at a time, so the element
+// tree of the whole document is never held in memory; the memory
+// needed is proportional to the largest block.  The text itself is
+// kept, as link references and footnotes may be defined anywhere
+// in the document.  A [TOC] marker lists no headings when streaming,
+// as the headings following it have not been parsed yet, and no
+// warnings are collected.  If one of the Limits of p is exceeded,
+// the blocks printed so far are followed by the notes, and the
+// error is returned.
+func (p *Parser) StreamHtml(r io.Reader, w Writer) os.Error {
+	pf := p.preformat(0)
+	n, err := io.Copy(pf, p.limitReader(r))
+	if err != nil {
+		return err
+	}
+	if p.checkSize(n) != nil {
+		return ErrTooLarge
+	}
+	d, s, line0 := p.start(pf.text())
+	out := d.newHtmlOut(w)
+	walk := &walker{r: out, d: d}
+	used := make(map[string]bool)
+
+	m := d.parser
+	for s != "" {
+		m.ResetBuffer(s)
+		d.spans = d.spans[:0]
+		if !m.Parse(ruleDocBlock) || m.Min == 0 {
+			break
+		}
+		n := m.Min
+		m.ResetBuffer("")	/* the rest is parsed in the next round */
+		spans := d.spans
+		d.spans = nil
+		d.tree = d.processRawBlocks(d.tree)
+		if d.err != nil {
+			break
+		}
+		if d.abbreviations != nil {
+			d.markAbbreviations(d.tree)
+		}
+		d.typeset(d.tree)
+		setLines(d.tree, spans, s, line0)
+		if p.ext.HeadingIDs {
+			d.setAnchors(used)
+		}
+		walk.elist(d.tree)
+		d.warnings = nil
+		line0 += strings.Count(s[:n], "\n")
+		s = s[n:]
+	}
+	d.tree = nil
+	out.finish()
+	d.detach()
+	return d.err
+}
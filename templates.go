@@ -0,0 +1,67 @@
+package markdown
+
+// Spans of template languages passed through unchanged, see
+// Extensions.Templates
+
+import (
+	"strings"
+)
+
+// DefaultTemplateDelimiters are the delimiters of template spans used
+// if Parser.TemplateDelimiters is empty: those of Go templates, which
+// include Hugo shortcodes like {{< figure >}}, and of the statements
+// and comments of Jinja and Liquid.
+var DefaultTemplateDelimiters = []string{
+	"{{", "}}",
+	"{%", "%}",
+	"{#", "#}",
+}
+
+/* template - called by the rule Template: if a template span starts
+ * at *pos, advance *pos to its end.  A span ends at the first closing
+ * delimiter matching its opening one, which must be found before the
+ * end of the paragraph.
+ */
+func (d *Doc) template(pos *int) bool {
+	s := d.parser.Buffer[*pos:]
+	delims := d.templates
+	for i := 0; i+1 < len(delims); i += 2 {
+		open, end := delims[i], delims[i+1]
+		if open == "" || end == "" || !strings.HasPrefix(s, open) {
+			continue
+		}
+		n := strings.Index(s[len(open):], end)
+		if n == -1 || strings.Index(s[:len(open)+n], "\n\n") != -1 {
+			continue
+		}
+		*pos += len(open) + n + len(end)
+		return true
+	}
+	return false
+}
+
+/* templateSpan - make the element for a template span s: raw HTML,
+ * unless raw HTML is escaped, e.g. by the Safe extension
+ */
+func (d *Doc) templateSpan(s string) *Element {
+	e := mk_str(s)
+	if d.policy(false) != HTMLEscape {
+		e.key = HTML
+	}
+	return e
+}
+
+/* templateChar - return true if the character at pos may start a
+ * template span
+ */
+func (d *Doc) templateChar(pos int) bool {
+	if !d.extension.Templates || pos >= len(d.parser.Buffer) {
+		return false
+	}
+	for i := 0; i < len(d.templates); i += 2 {
+		if strings.HasPrefix(d.templates[i], d.parser.Buffer[pos:pos+1]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,487 @@
+package markdown
+
+// Output for terminals, styled using ANSI escape sequences
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"strings"
+	"utf8"
+)
+
+/* ANSI escape sequences */
+const (
+	ansiBold		= "\x1b[1m"
+	ansiDim			= "\x1b[2m"
+	ansiNormal		= "\x1b[22m"	/* neither bold nor dim */
+	ansiItalic		= "\x1b[3m"
+	ansiNoItalic	= "\x1b[23m"
+	ansiUnderline	= "\x1b[4m"
+	ansiNoUnderline	= "\x1b[24m"
+	ansiStrike		= "\x1b[9m"
+	ansiNoStrike	= "\x1b[29m"
+	ansiCyan		= "\x1b[36m"
+	ansiNoColor		= "\x1b[39m"
+)
+
+var termBullets = []string{"• ", "◦ ", "▪ "}
+
+type termOut struct {
+	Writer
+
+	buf		bytes.Buffer	/* Inline text of the current block. */
+	frames	[]*mdFrame
+	lists	[]mdList
+	started	bool	/* True after the first line has been printed. */
+	blank	bool	/* True if an empty line is needed before the next block. */
+	notes	[]func()
+	symbols	bool	/* Mark notes by symbols, instead of numbers. */
+
+	inCell	bool
+	row		[]string
+	rows	[][]string
+	nhead	int
+}
+
+// WriteTerm prints a document tree to the specified Writer, styled
+// for display on a terminal using ANSI escape sequences: emphasis is
+// printed in italics, strong emphasis and headings in bold, code
+// blocks indented and dimmed, and links underlined, followed by their
+// destination.
+// It returns the number of bytes written, and the first error
+// returned by w, if any, like WriteHtml.
+//
+func (d *Doc) WriteTerm(w Writer) (n int, err os.Error) {
+	out := new(termOut)
+	dest := &errWriter{w: w}
+	out.Writer = dest
+	out.symbols = d.NoteSymbols
+	d.Render(out)
+	for i := 0; i < len(out.notes); i++ {
+		body := out.notes[i]
+		out.blank = true
+		out.frames = append(out.frames, &mdFrame{first: "[" + noteMarker(d.firstNote()+i, d.NoteSymbols) + "] ", rest: "    "})
+		body()
+		if s := out.text(); s != "" {
+			/* an inline note */
+			out.block(strings.Split(s, "\n", -1), false)
+		}
+		out.frames = out.frames[:len(out.frames)-1]
+	}
+	return dest.n, dest.err
+}
+
+/* line - print a line, preceded by the prefixes of the containers
+ */
+func (w *termOut) line(s string) {
+	p := ""
+	for _, f := range w.frames {
+		if f.used {
+			p += f.rest
+		} else {
+			p += f.first
+			f.used = true
+		}
+	}
+	if s == "" {
+		p = strings.TrimRight(p, " ")
+	}
+	w.WriteString(p + s + "\n")
+}
+
+/* block - print the lines of a block, separated by an empty line from
+ * the preceding one, unless both are tight
+ */
+func (w *termOut) block(lines []string, tight bool) {
+	if w.started && (w.blank || !tight) {
+		p := ""
+		for _, f := range w.frames {
+			if f.used {
+				p += f.rest
+			}
+		}
+		w.WriteString(strings.TrimRight(p, " ") + "\n")
+	}
+	for _, l := range lines {
+		w.line(l)
+	}
+	w.started = true
+	w.blank = !tight
+}
+
+/* text - return the inline text collected for the current block */
+func (w *termOut) text() string {
+	s := strings.TrimSpace(w.buf.String())
+	w.buf.Reset()
+	return s
+}
+
+func (w *termOut) para(tight bool) {
+	if s := w.text(); s != "" {
+		w.block(strings.Split(s, "\n", -1), tight)
+	}
+}
+
+func (w *termOut) style(on, off string, entering bool) {
+	if entering {
+		w.buf.WriteString(on)
+	} else {
+		w.buf.WriteString(off)
+	}
+}
+
+/* termWidth - return the number of columns s takes on a terminal,
+ * not counting escape sequences, see runeWidth
+ */
+func termWidth(s string) int {
+	n := 0
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' {
+			for i < len(s) && s[i] != 'm' {
+				i++
+			}
+			i++
+			continue
+		}
+		c, size := utf8.DecodeRuneInString(s[i:])
+		n += runeWidth(c)
+		i += size
+	}
+	return n
+}
+
+/* Renderer methods
+ */
+
+func (w *termOut) Str(s string) {
+	w.buf.WriteString(s)
+}
+
+func (w *termOut) Space(s string) {
+	if strings.Index(s, "\n") != -1 && !w.inCell {
+		w.buf.WriteString("\n")
+	} else {
+		w.buf.WriteString(" ")
+	}
+}
+
+func (w *termOut) LineBreak() {
+	w.buf.WriteString("\n")
+}
+
+func (w *termOut) Ellipsis() {
+	w.buf.WriteString("…")
+}
+
+func (w *termOut) EmDash() {
+	w.buf.WriteString("—")
+}
+
+func (w *termOut) EnDash() {
+	w.buf.WriteString("–")
+}
+
+func (w *termOut) Apostrophe() {
+	w.buf.WriteString("’")
+}
+
+func (w *termOut) SingleQuoted(entering bool) {
+	w.style("‘", "’", entering)
+}
+
+func (w *termOut) DoubleQuoted(entering bool) {
+	w.style("“", "”", entering)
+}
+
+func (w *termOut) Code(s string) {
+	w.buf.WriteString(ansiCyan + s + ansiNoColor)
+}
+
+func (w *termOut) Math(s string, display bool) {
+	w.buf.WriteString(ansiCyan + s + ansiNoColor)
+}
+
+func (w *termOut) Html(s string) {
+	/* print entities only */
+	if strings.HasPrefix(s, "&") {
+		if t := entityText(s); t != "" {
+			s = t
+		}
+		w.buf.WriteString(s)
+	}
+}
+
+func (w *termOut) Format() string {
+	return "term"
+}
+
+func (w *termOut) Raw(s string) {
+	w.buf.WriteString(s)
+}
+
+func (w *termOut) Link(url, title string, entering bool) {
+	if entering {
+		w.buf.WriteString(ansiUnderline)
+		return
+	}
+	w.buf.WriteString(ansiNoUnderline)
+	if url != "" && url[0] != '#' {
+		w.buf.WriteString(" " + ansiDim + "(" + url + ")" + ansiNormal)
+	}
+}
+
+func (w *termOut) Image(url, title string, entering bool) {
+	if entering {
+		w.buf.WriteString("[")
+		return
+	}
+	w.buf.WriteString("] " + ansiDim + "(" + url + ")" + ansiNormal)
+}
+
+func (w *termOut) Emph(entering bool) {
+	w.style(ansiItalic, ansiNoItalic, entering)
+}
+
+func (w *termOut) Strong(entering bool) {
+	w.style(ansiBold, ansiNormal, entering)
+}
+
+func (w *termOut) Strike(entering bool) {
+	w.style(ansiStrike, ansiNoStrike, entering)
+}
+
+func (w *termOut) Superscript(entering bool) {
+	w.style("^", "", entering)
+}
+
+func (w *termOut) Subscript(entering bool) {
+	w.style("_", "", entering)
+}
+
+func (w *termOut) Abbr(title string, entering bool) {
+}
+
+func (w *termOut) Emoji(name, s string) {
+	w.buf.WriteString(s)
+}
+
+func (w *termOut) Citation(source string, entering bool) {
+	/* print the formatted citation only */
+}
+
+func (w *termOut) Note(n int, body func()) {
+	w.buf.WriteString(ansiDim + "[" + noteMarker(n, w.symbols) + "]" + ansiNormal)
+	w.notes = append(w.notes, body)
+}
+
+func (w *termOut) Heading(level int, id string, entering bool) {
+	if entering {
+		return
+	}
+	s := ansiBold + w.text() + ansiNormal
+	if level == 1 {
+		s = ansiUnderline + s + ansiNoUnderline
+	}
+	w.block([]string{s}, false)
+}
+
+func (w *termOut) Plain(entering bool) {
+	if !entering {
+		w.para(true)
+	}
+}
+
+func (w *termOut) Para(entering bool) {
+	if !entering {
+		w.para(false)
+	}
+}
+
+func (w *termOut) Figure(caption string, entering bool) {
+	w.Para(entering)
+}
+
+func (w *termOut) HRule() {
+	w.block([]string{ansiDim + strings.Repeat("─", 40) + ansiNormal}, false)
+}
+
+func (w *termOut) HtmlBlock(s string) {
+	/* don't print HTML block */
+}
+
+func (w *termOut) Verbatim(s, lang string) {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n", -1)
+	for i, l := range lines {
+		lines[i] = "    " + ansiDim + l + ansiNormal
+	}
+	w.block(lines, false)
+}
+
+func (w *termOut) BlockQuote(entering bool) {
+	if entering {
+		w.frames = append(w.frames, &mdFrame{first: "│ ", rest: "│ "})
+	} else {
+		w.frames = w.frames[:len(w.frames)-1]
+	}
+	w.blank = true
+}
+
+func (w *termOut) Admonition(kind string, entering bool) {
+	w.BlockQuote(entering)
+}
+
+func (w *termOut) AdmonitionTitle(entering bool) {
+	if !entering {
+		w.block([]string{ansiBold + w.text() + ansiNormal}, true)
+	}
+}
+
+func (w *termOut) Div(class string, entering bool) {
+	/* print the contents only */
+}
+
+func (w *termOut) Bibliography(entering bool) {
+	/* print the entries only */
+}
+
+func (w *termOut) list(l mdList, entering bool) {
+	if entering {
+		w.lists = append(w.lists, l)
+	} else {
+		w.lists = w.lists[:len(w.lists)-1]
+		w.blank = true
+	}
+}
+
+func (w *termOut) BulletList(entering bool) {
+	w.list(mdList{}, entering)
+}
+
+func (w *termOut) OrderedList(start int, delim string, entering bool) {
+	w.list(mdList{ordered: true, n: start - 1, delim: delim}, entering)
+}
+
+func (w *termOut) item(marker string, entering bool) {
+	if !entering {
+		w.frames = w.frames[:len(w.frames)-1]
+		return
+	}
+	l := &w.lists[len(w.lists)-1]
+	l.n++
+	if marker == "" {
+		marker = termBullets[(len(w.lists)-1)%len(termBullets)]
+		if l.ordered {
+			marker = strconv.Itoa(l.n) + l.delim + " "
+		}
+	}
+	if n := termWidth(marker); n < 4 {
+		marker = strings.Repeat(" ", 4-n) + marker
+	}
+	w.frames = append(w.frames, &mdFrame{first: marker, rest: "    "})
+}
+
+func (w *termOut) ListItem(entering bool) {
+	w.item("", entering)
+}
+
+func (w *termOut) TaskItem(done bool, entering bool) {
+	if done {
+		w.item("☑ ", entering)
+	} else {
+		w.item("☐ ", entering)
+	}
+}
+
+func (w *termOut) DefinitionList(entering bool) {
+	w.blank = true
+}
+
+func (w *termOut) DefTitle(entering bool) {
+	if !entering {
+		w.block([]string{ansiBold + w.text() + ansiNormal}, true)
+	}
+}
+
+func (w *termOut) DefData(entering bool) {
+	if entering {
+		w.frames = append(w.frames, &mdFrame{first: "    ", rest: "    "})
+	} else {
+		w.frames = w.frames[:len(w.frames)-1]
+		w.blank = true
+	}
+}
+
+func (w *termOut) Table(align []string, entering bool) {
+	if entering {
+		w.rows = nil
+		w.nhead = 0
+		return
+	}
+	ncol := len(align)
+	width := make([]int, ncol)
+	for _, row := range w.rows {
+		for i, cell := range row {
+			if n := termWidth(cell); i < ncol && n > width[i] {
+				width[i] = n
+			}
+		}
+	}
+	var lines []string
+	for i, row := range w.rows {
+		cells := make([]string, ncol)
+		for j := range cells {
+			if j < len(row) {
+				cells[j] = row[j]
+			}
+			pad := width[j] - termWidth(cells[j])
+			switch align[j] {
+			case "right":
+				cells[j] = strings.Repeat(" ", pad) + cells[j]
+			case "center":
+				cells[j] = strings.Repeat(" ", pad/2) + cells[j] + strings.Repeat(" ", pad-pad/2)
+			default:
+				cells[j] += strings.Repeat(" ", pad)
+			}
+			if i < w.nhead {
+				cells[j] = ansiBold + cells[j] + ansiNormal
+			}
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(cells, " │ "), " "))
+		if i+1 == w.nhead {
+			sep := make([]string, ncol)
+			for j := range sep {
+				sep[j] = strings.Repeat("─", width[j])
+			}
+			lines = append(lines, strings.Join(sep, "─┼─"))
+		}
+	}
+	w.block(lines, false)
+}
+
+func (w *termOut) TableHead(entering bool) {
+	if !entering {
+		w.nhead = len(w.rows)
+	}
+}
+
+func (w *termOut) TableBody(entering bool) {
+}
+
+func (w *termOut) TableRow(entering bool) {
+	if entering {
+		w.row = nil
+	} else {
+		w.rows = append(w.rows, w.row)
+	}
+}
+
+func (w *termOut) TableCell(align string, header bool, entering bool) {
+	w.inCell = entering
+	if !entering {
+		w.row = append(w.row, w.text())
+	}
+}
+
+func (w *termOut) TOC(toc []*TOCItem) {
+}
@@ -0,0 +1,321 @@
+package markdown
+
+// Plain text output functions
+
+import (
+	"bytes"
+	"os"
+	"strings"
+)
+
+type textOut struct {
+	Writer
+	urls	bool
+
+	buf		bytes.Buffer	/* Inline text of the current block. */
+	started	bool	/* True after the first block has been printed. */
+	blank	bool	/* True if an empty line is needed before the next block. */
+	prefix	string	/* Printed in front of the next block, e.g. a note's number. */
+	links	[]int	/* Offsets of the open links in buf. */
+	notes	[]func()
+	symbols	bool	/* Mark notes by symbols, instead of numbers. */
+	row		[]string
+}
+
+// WriteText prints a document tree as plain text, stripped of all
+// markup, to the specified Writer.  The text of links is kept; if urls
+// is true, it is followed by the link's destination in parentheses.
+// Code blocks are printed verbatim, HTML is dropped, with character
+// entities replaced by the characters they stand for, and notes are
+// printed at the end.
+// It returns the number of bytes written, and the first error
+// returned by w, if any, like WriteHtml.
+//
+func (d *Doc) WriteText(w Writer, urls bool) (n int, err os.Error) {
+	out := new(textOut)
+	dest := &errWriter{w: w}
+	out.Writer = dest
+	out.urls = urls
+	out.symbols = d.NoteSymbols
+	d.Render(out)
+	for i := 0; i < len(out.notes); i++ {
+		body := out.notes[i]
+		out.blank = true
+		out.prefix = "[" + noteMarker(d.firstNote()+i, d.NoteSymbols) + "] "
+		body()
+		if s := out.text(); s != "" {
+			/* an inline note */
+			out.block(s, false)
+		}
+	}
+	return dest.n, dest.err
+}
+
+/* block - print the text of a block, separated by an empty line from
+ * the preceding one, unless both are tight, like the items of a tight
+ * list, or the rows of a table
+ */
+func (w *textOut) block(s string, tight bool) {
+	if w.started && (w.blank || !tight) {
+		w.WriteByte('\n')
+	}
+	w.WriteString(w.prefix)
+	w.WriteString(s)
+	if !strings.HasSuffix(s, "\n") {
+		w.WriteByte('\n')
+	}
+	w.prefix = ""
+	w.started = true
+	w.blank = !tight
+}
+
+/* text - return the inline text collected for the current block */
+func (w *textOut) text() string {
+	s := strings.TrimSpace(w.buf.String())
+	w.buf.Reset()
+	return s
+}
+
+/* Renderer methods
+ */
+
+func (w *textOut) Str(s string) {
+	w.buf.WriteString(s)
+}
+
+func (w *textOut) Space(s string) {
+	if strings.Index(s, "\n") != -1 {
+		w.buf.WriteString("\n")
+	} else {
+		w.buf.WriteString(" ")
+	}
+}
+
+func (w *textOut) LineBreak() {
+	w.buf.WriteString("\n")
+}
+
+func (w *textOut) Ellipsis() {
+	w.buf.WriteString("…")
+}
+
+func (w *textOut) EmDash() {
+	w.buf.WriteString("—")
+}
+
+func (w *textOut) EnDash() {
+	w.buf.WriteString("–")
+}
+
+func (w *textOut) Apostrophe() {
+	w.buf.WriteString("’")
+}
+
+func (w *textOut) SingleQuoted(entering bool) {
+	if entering {
+		w.buf.WriteString("‘")
+	} else {
+		w.buf.WriteString("’")
+	}
+}
+
+func (w *textOut) DoubleQuoted(entering bool) {
+	if entering {
+		w.buf.WriteString("“")
+	} else {
+		w.buf.WriteString("”")
+	}
+}
+
+func (w *textOut) Code(s string) {
+	w.buf.WriteString(s)
+}
+
+func (w *textOut) Math(s string, display bool) {
+	w.buf.WriteString(s)
+}
+
+func (w *textOut) Html(s string) {
+	/* print entities only */
+	if strings.HasPrefix(s, "&") {
+		if t := entityText(s); t != "" {
+			s = t
+		}
+		w.buf.WriteString(s)
+	}
+}
+
+func (w *textOut) Format() string {
+	return "text"
+}
+
+func (w *textOut) Raw(s string) {
+	w.buf.WriteString(s)
+}
+
+func (w *textOut) Link(url, title string, entering bool) {
+	if entering {
+		w.links = append(w.links, w.buf.Len())
+		return
+	}
+	start := w.links[len(w.links)-1]
+	w.links = w.links[:len(w.links)-1]
+	text := w.buf.String()[start:]
+	if !w.urls || url == "" || url[0] == '#' || text == url || "mailto:"+text == url {
+		return
+	}
+	w.buf.WriteString(" (" + url + ")")
+}
+
+func (w *textOut) Image(url, title string, entering bool) {
+	/* print the alternate text only */
+}
+
+func (w *textOut) Emph(entering bool) {
+}
+
+func (w *textOut) Strong(entering bool) {
+}
+
+func (w *textOut) Strike(entering bool) {
+}
+
+func (w *textOut) Superscript(entering bool) {
+}
+
+func (w *textOut) Subscript(entering bool) {
+}
+
+func (w *textOut) Abbr(title string, entering bool) {
+}
+
+func (w *textOut) Emoji(name, s string) {
+	w.buf.WriteString(s)
+}
+
+func (w *textOut) Citation(source string, entering bool) {
+	/* print the formatted citation only */
+}
+
+func (w *textOut) Note(n int, body func()) {
+	w.buf.WriteString("[" + noteMarker(n, w.symbols) + "]")
+	w.notes = append(w.notes, body)
+}
+
+func (w *textOut) Heading(level int, id string, entering bool) {
+	if !entering {
+		w.block(w.text(), false)
+	}
+}
+
+func (w *textOut) Plain(entering bool) {
+	if entering {
+		return
+	}
+	if s := w.text(); s != "" {
+		w.block(s, true)
+	}
+}
+
+func (w *textOut) Para(entering bool) {
+	if entering {
+		return
+	}
+	if s := w.text(); s != "" {
+		w.block(s, false)
+	}
+}
+
+func (w *textOut) Figure(caption string, entering bool) {
+	w.Para(entering)
+}
+
+func (w *textOut) HRule() {
+}
+
+func (w *textOut) HtmlBlock(s string) {
+	/* don't print HTML block */
+}
+
+func (w *textOut) Verbatim(s, lang string) {
+	w.block(s, false)
+}
+
+func (w *textOut) BlockQuote(entering bool) {
+	w.blank = true
+}
+
+func (w *textOut) Admonition(kind string, entering bool) {
+	w.blank = true
+}
+
+func (w *textOut) AdmonitionTitle(entering bool) {
+	if !entering {
+		w.block(w.text(), true)
+	}
+}
+
+func (w *textOut) Div(class string, entering bool) {
+	w.blank = true
+}
+
+func (w *textOut) Bibliography(entering bool) {
+	w.blank = true
+}
+
+func (w *textOut) BulletList(entering bool) {
+	w.blank = true
+}
+
+func (w *textOut) OrderedList(start int, delim string, entering bool) {
+	w.blank = true
+}
+
+func (w *textOut) ListItem(entering bool) {
+}
+
+func (w *textOut) TaskItem(done bool, entering bool) {
+}
+
+func (w *textOut) DefinitionList(entering bool) {
+	w.blank = true
+}
+
+func (w *textOut) DefTitle(entering bool) {
+	if !entering {
+		w.block(w.text(), true)
+	}
+}
+
+func (w *textOut) DefData(entering bool) {
+	if !entering {
+		w.blank = true
+	}
+}
+
+func (w *textOut) Table(align []string, entering bool) {
+	w.blank = true
+}
+
+func (w *textOut) TableHead(entering bool) {
+}
+
+func (w *textOut) TableBody(entering bool) {
+}
+
+func (w *textOut) TableRow(entering bool) {
+	if entering {
+		w.row = nil
+	} else {
+		w.block(strings.Join(w.row, "\t"), true)
+	}
+}
+
+func (w *textOut) TableCell(align string, header bool, entering bool) {
+	if !entering {
+		w.row = append(w.row, w.text())
+	}
+}
+
+func (w *textOut) TOC(toc []*TOCItem) {
+}
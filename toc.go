@@ -0,0 +1,150 @@
+package markdown
+
+// Table of contents
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// A TOCItem is an entry of the table of contents of a document,
+// describing a heading and the headings of lower level following it.
+type TOCItem struct {
+	Level	int			/* 1 to 6 */
+	Text	string	/* Plain text of the heading. */
+	Anchor	string	/* Value of the id attribute of the heading. */
+	Number	string	/* Section number, see Doc.Numbering, or "". */
+	Sub		[]*TOCItem
+}
+
+// TOC returns the heading hierarchy of the document. Anchors
+// are only available if the HeadingIDs extension is enabled,
+// which is implied by TOC.
+func (d *Doc) TOC() (toc []*TOCItem) {
+	var stack []*TOCItem
+
+	numbers := d.HeadingNumbers()
+	for _, h := range d.headings() {
+		item := &TOCItem{Level: h.key - H1 + 1, Text: plainText(h.children), Anchor: h.contents.str, Number: numbers[h]}
+		for len(stack) > 0 && stack[len(stack)-1].Level >= item.Level {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			toc = append(toc, item)
+		} else {
+			parent := stack[len(stack)-1]
+			parent.Sub = append(parent.Sub, item)
+		}
+		stack = append(stack, item)
+	}
+	return
+}
+
+/* headings - return all H1 ... H6 elements of the document, in order.
+ */
+func (d *Doc) headings() []*Element {
+	v := &headingCollector{}
+	d.Walk(v)
+	return v.list
+}
+
+type headingCollector struct {
+	list []*Element
+}
+
+func (v *headingCollector) Visit(e *Element) Visitor {
+	if e == nil {
+		return nil
+	}
+	if e.key >= H1 && e.key <= H6 {
+		v.list = append(v.list, e)
+		return nil
+	}
+	return v
+}
+
+/* setAnchors - assign an unique anchor to each heading of the document,
+ * derived from its text.  Anchors already in used are avoided; headings
+ * with an id set by an attribute block keep it.
+ */
+func (d *Doc) setAnchors(used map[string]bool) {
+	slug := Slug
+	if d.slugger != nil {
+		slug = d.slugger
+	}
+	headings := d.headings()
+	for _, h := range headings {
+		if h.attr != nil && h.attr.ID != "" {
+			used[h.attr.ID] = true
+		}
+	}
+	for _, h := range headings {
+		if h.attr != nil && h.attr.ID != "" {
+			continue
+		}
+		base := slug(plainText(h.children))
+		id := base
+		for n := 1; used[id]; n++ {
+			id = base + "-" + strconv.Itoa(n)
+		}
+		used[id] = true
+		h.contents.str = id
+	}
+}
+
+// Slug converts the text of a heading into a string usable as
+// fragment identifier: letters are converted to lower case, spaces
+// to '-'; other characters except digits, combining marks, like the
+// accent of "e\u0301", '-' and '_' are dropped.
+func Slug(text string) string {
+	s := make([]int, 0, len(text))
+	for _, c := range strings.ToLower(strings.TrimSpace(text)) {
+		switch {
+		case unicode.IsLetter(c), unicode.IsDigit(c), unicode.IsMark(c), c == '-', c == '_':
+			s = append(s, c)
+		case c == ' ':
+			s = append(s, '-')
+		}
+	}
+	if len(s) == 0 {
+		return "section"
+	}
+	return string(s)
+}
+
+/* plainText - return the text of a list of inline elements, without markup.
+ */
+func plainText(list *Element) string {
+	s := ""
+	for e := list; e != nil; e = e.next {
+		switch e.key {
+		case STR, CODE, MATH, DISPLAYMATH:
+			s += e.contents.str
+		case SPACE, LINEBREAK:
+			s += " "
+		case ELLIPSIS:
+			s += "..."
+		case EMDASH:
+			s += "--"
+		case ENDASH:
+			s += "-"
+		case APOSTROPHE:
+			s += "'"
+		case SINGLEQUOTED:
+			s += "'" + plainText(e.children) + "'"
+		case DOUBLEQUOTED:
+			s += `"` + plainText(e.children) + `"`
+		case LINK, IMAGE:
+			s += plainText(e.contents.link.label)
+		case HTML, NOTE:
+		default:
+			if e.key >= numVAL && e.children == nil {
+				/* an element of a plugin */
+				s += e.contents.str
+			}
+			s += plainText(e.children)
+		}
+	}
+	return s
+}
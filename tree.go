@@ -0,0 +1,207 @@
+package markdown
+
+// Access to the document tree
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Root returns the first element of the top level list of the
+// document tree.
+func (d *Doc) Root() *Element {
+	return d.tree
+}
+
+// Kind returns the type of the element, one of the constants
+// LIST, RAW, SPACE, ... DEFDATA, or a kind returned by Register.
+func (e *Element) Kind() int {
+	return e.key
+}
+
+// KindName returns the name of the element's type, e.g. "PARA".
+func (e *Element) KindName() string {
+	if e.key >= 0 && e.key < numVAL && keynames[e.key] != "" {
+		return keynames[e.key]
+	}
+	if pl := pluginOf(e.key); pl != nil {
+		return pl.Name
+	}
+	return "?"
+}
+
+// Next returns the element's successor in the list
+// it is part of, or nil.
+func (e *Element) Next() *Element {
+	return e.next
+}
+
+// Children returns the first element of the list of
+// children, or nil. The label of a LINK or IMAGE element
+// is not returned by Children, but by Label.
+func (e *Element) Children() *Element {
+	return e.children
+}
+
+// Text returns the string contents of the element. It
+// is set for elements like STR, SPACE, CODE, HTML, HTMLBLOCK
+// and VERBATIM. For H1 ... H6 it is the anchor assigned, if
+// the HeadingIDs extension is enabled.
+func (e *Element) Text() string {
+	return e.contents.str
+}
+
+// Info returns the info string of a fenced code block, i.e. the
+// text following the opening fence, or "".
+func (e *Element) Info() string {
+	if e.key == VERBATIM && e.children != nil {
+		return e.children.contents.str
+	}
+	return ""
+}
+
+// Language returns the language of a fenced code block, the first
+// word of its info string.  If the info string is an attribute block,
+// like {.python}, which is kept if the Attributes extension is not
+// enabled, it is the first class, or "".
+func (e *Element) Language() string {
+	s := e.Info()
+	if strings.HasPrefix(s, "{") {
+		if i := strings.Index(s, "}"); i != -1 {
+			s = s[:i]
+		}
+		if a := parseAttributes(s[1:]); a != nil && len(a.Classes) > 0 {
+			return a.Classes[0]
+		}
+		return ""
+	}
+	if f := strings.Fields(s); len(f) > 0 {
+		if i := strings.Index(f[0], "{"); i != -1 {
+			return f[0][:i]
+		}
+		return f[0]
+	}
+	return ""
+}
+
+// Lines returns the first and last line of the source text the
+// element has been parsed from, counting from 1; blank lines
+// around a block are not included. Positions are block-granular:
+// they are tracked per top level block, and the elements contained
+// in a block, including inline elements and the nested blocks of
+// lists and block quotes, report the lines of the enclosing top
+// level block; there are no byte offsets, and columns only for the
+// top level blocks, see Doc.SourcePos. For the contents of a
+// footnote, and for elements not created by the parser, 0, 0 is
+// returned.
+func (e *Element) Lines() (first, last int) {
+	return e.line, e.endLine
+}
+
+// Task reports whether a LISTITEM is an item of a task list, starting
+// with [ ] or [x], and whether it has been checked; see
+// Extensions.TaskLists.
+func (e *Element) Task() (task, done bool) {
+	if e.key != LISTITEM {
+		return false, false
+	}
+	return e.contents.str != "", e.contents.str == "x"
+}
+
+// Tight reports whether a BULLETLIST or ORDEREDLIST is tight: its
+// items aren't separated by blank lines, and their paragraphs are
+// printed without <p> tags. See Extensions.StrictLists for how this
+// is decided.
+func (e *Element) Tight() bool {
+	return (e.key == BULLETLIST || e.key == ORDEREDLIST) && !e.loose
+}
+
+// ListStart returns the number of the first item of an ORDEREDLIST,
+// and the delimiter following it, "." or ")", if the ListStart
+// extension is enabled; otherwise 1 and ".".
+func (e *Element) ListStart() (start int, delim string) {
+	s := e.contents.str
+	if e.key != ORDEREDLIST || s == "" {
+		return 1, "."
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil {
+		n = 1
+	}
+	return n, s[len(s)-1:]
+}
+
+// Comment reports whether an HTML or HTMLBLOCK element is an HTML
+// comment, and returns its text without <!-- and -->, and the white
+// space around it, like "toc" for <!-- toc -->.
+func (e *Element) Comment() (text string, ok bool) {
+	s := e.contents.str
+	if e.key != HTML && e.key != HTMLBLOCK || !strings.HasPrefix(s, "<!--") || !strings.HasSuffix(s, "-->") {
+		return "", false
+	}
+	return commentText(s), true
+}
+
+// Attributes returns the attributes set by an attribute block, or
+// nil; see Extensions.Attributes.
+func (e *Element) Attributes() *Attributes {
+	return e.attr
+}
+
+// Label returns the first element of the label of a LINK,
+// of the alternate text of an IMAGE, or of the title of an
+// ADMONITION.
+func (e *Element) Label() *Element {
+	if e.contents.link == nil {
+		return nil
+	}
+	return e.contents.link.label
+}
+
+// URL returns the destination of a LINK, or the source of an IMAGE.
+func (e *Element) URL() string {
+	if e.contents.link == nil {
+		return ""
+	}
+	return e.contents.link.url
+}
+
+// Title returns the title of a LINK or IMAGE.
+func (e *Element) Title() string {
+	if e.contents.link == nil {
+		return ""
+	}
+	return e.contents.link.title
+}
+
+
+// A Visitor's Visit method is invoked for each element encountered
+// by Walk.  If the result visitor w is not nil, Walk visits each of
+// the children of the element with the visitor w, followed by a
+// call of w.Visit(nil).
+type Visitor interface {
+	Visit(e *Element) (w Visitor)
+}
+
+// Walk traverses the document tree in depth-first order.
+func (d *Doc) Walk(v Visitor) {
+	Walk(v, d.tree)
+}
+
+// Walk traverses a list of elements in depth-first order: it
+// starts by calling v.Visit(e) for each element e of the list; if
+// the visitor returned is not nil, Walk is invoked recursively with
+// it for the label (in case of LINK and IMAGE) and the children of e.
+func Walk(v Visitor, list *Element) {
+	for e := list; e != nil; e = e.next {
+		w := v.Visit(e)
+		if w == nil {
+			continue
+		}
+		if l := e.Label(); l != nil {
+			Walk(w, l)
+		}
+		Walk(w, e.children)
+		w.Visit(nil)
+	}
+}
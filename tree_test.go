@@ -0,0 +1,41 @@
+package markdown
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestLanguage checks the language of fenced code blocks with
+// attribute blocks, with and without the Attributes extension.
+func TestLanguage(t *testing.T) {
+	for _, c := range []struct {
+		info, lang	string
+	}{
+		{"python", "python"},
+		{"python {.numbered}", "python"},
+		{"python{.numbered}", "python"},
+		{"{.python}", "python"},
+		{"{.python .numbered}", "python"},
+		{"{#id}", ""},
+		{"{python}", ""},
+		{"{.python} extra", "python"},
+	} {
+		for _, attrs := range []bool{false, true} {
+			text := "~~~ " + c.info + "\ncode\n~~~\n"
+			d := NewParser(Extensions{FencedCode: true, Attributes: attrs}).Parse(text)
+			e := d.Root()
+			if e == nil || e.Kind() != VERBATIM {
+				t.Errorf("%q: no code block", c.info)
+				continue
+			}
+			if l := e.Language(); l != c.lang {
+				t.Errorf("%q, attributes %v: language %q, want %q", c.info, attrs, l, c.lang)
+			}
+			var b bytes.Buffer
+			d.WriteHtml(&b)
+			if bytes.IndexAny(b.Bytes(), "{}") != -1 {
+				t.Errorf("%q, attributes %v: %q", c.info, attrs, b.String())
+			}
+		}
+	}
+}
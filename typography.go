@@ -0,0 +1,215 @@
+package markdown
+
+// Optional conversions of the Smart extension: fractions, arrows,
+// symbols, and no-break spaces
+
+import (
+	"strings"
+)
+
+const nbsp = "\u00a0"
+
+/* arrows printed for the sequences matched by the Arrow rule */
+var arrows = map[string]string{
+	"->":	"→",
+	"-->":	"→",
+	"<-":	"←",
+	"<--":	"←",
+	"<->":	"↔",
+	"<-->":	"↔",
+}
+
+/* fractions replaced, if SmartOptions.Fractions is set */
+var fractions = map[string]string{
+	"1/2":	"½",
+	"1/3":	"⅓",
+	"2/3":	"⅔",
+	"1/4":	"¼",
+	"3/4":	"¾",
+	"1/5":	"⅕",
+	"1/6":	"⅙",
+	"1/8":	"⅛",
+	"3/8":	"⅜",
+	"5/8":	"⅝",
+	"7/8":	"⅞",
+}
+
+/* pairs of the sequences replaced, if SmartOptions.Symbols is set,
+ * followed by their replacements
+ */
+var smartSymbols = []string{
+	"(c)", "©", "(C)", "©",
+	"(r)", "®", "(R)", "®",
+	"(tm)", "™", "(TM)", "™",
+}
+
+/* units separated from a preceding number by a no-break space, if
+ * SmartOptions.Units is set
+ */
+var units = map[string]bool{
+	"%": true, "‰": true, "°C": true, "°F": true, "K": true,
+	"mm": true, "cm": true, "m": true, "km": true, "in": true, "ft": true, "mi": true,
+	"mg": true, "g": true, "kg": true, "t": true, "lb": true, "oz": true,
+	"ml": true, "l": true, "L": true, "m²": true, "m³": true,
+	"ms": true, "s": true, "min": true, "h": true,
+	"Hz": true, "kHz": true, "MHz": true, "GHz": true,
+	"V": true, "A": true, "W": true, "kW": true, "kWh": true,
+	"B": true, "kB": true, "KB": true, "MB": true, "GB": true, "TB": true,
+	"px": true, "pt": true, "em": true,
+	"€": true, "$": true, "£": true, "¥": true,
+}
+
+/* typeset - apply the conversions of d.smart, other than those
+ * done by the grammar, and Parser.FilterText to the text in list
+ */
+func (d *Doc) typeset(list *Element) {
+	o := d.smart
+	if d.extension.Smart && (o.Fractions || o.Symbols || o.French || o.Units) {
+		o.typeset(list)
+	}
+	if d.filterText != nil {
+		d.filterTexts(list, nil)
+	}
+}
+
+func (o *SmartOptions) typeset(list *Element) {
+	var prev *Element
+	for e := list; e != nil; prev, e = e, e.next {
+		switch e.key {
+		case STR:
+			for e.next != nil && e.next.key == STR {
+				/* e.g. ( and c), if ( starts an autolink */
+				e.contents.str += e.next.contents.str
+				e.next = e.next.next
+			}
+			space := prev == nil || prev.key == SPACE || prev.key == LINEBREAK
+			if prev != nil && prev.key == STR && strings.HasSuffix(prev.contents.str, nbsp) {
+				space = true
+			}
+			e.contents.str = o.text(e.contents.str, space)
+		case SPACE:
+			if o.noBreak(prev, e.next) {
+				e.key = STR
+				e.contents.str = nbsp
+			}
+		case CODE, HTML, VERBATIM, HTMLBLOCK, MATH, DISPLAYMATH:
+		default:
+			if l := e.Label(); l != nil {
+				o.typeset(l)
+			}
+			o.typeset(e.children)
+		}
+	}
+}
+
+/* noBreak - report whether the space between the elements prev and
+ * next is to be replaced by a no-break space
+ */
+func (o *SmartOptions) noBreak(prev, next *Element) bool {
+	if prev == nil || next == nil || prev.key != STR || next.key != STR {
+		return false
+	}
+	before, after := prev.contents.str, next.contents.str
+	if after == "" {
+		return false
+	}
+	if o.French && (strings.HasSuffix(before, "«") || strings.IndexAny(after[:1], "!?;:") == 0 || strings.HasPrefix(after, "»")) {
+		return true
+	}
+	if o.Units && before != "" && before[len(before)-1] >= '0' && before[len(before)-1] <= '9' {
+		return units[strings.TrimRight(after, ".,;:!?)")]
+	}
+	return false
+}
+
+/* text - convert the text of a STR element; space tells whether it
+ * follows a space
+ */
+func (o *SmartOptions) text(s string, space bool) string {
+	if o.Symbols && strings.Index(s, "(") != -1 {
+		for i := 0; i < len(smartSymbols); i += 2 {
+			s = strings.Replace(s, smartSymbols[i], smartSymbols[i+1], -1)
+		}
+	}
+	if o.Fractions && strings.Index(s, "/") != -1 {
+		s = replaceFractions(s)
+	}
+	if o.French {
+		s = frenchSpacing(s, space)
+	}
+	return s
+}
+
+/* replaceFractions - replace fractions like 1/2, but not parts of
+ * dates like 1/2/2012, or of larger numbers, like 11/2
+ */
+func replaceFractions(s string) string {
+	isDigit := func(i int) bool {
+		return i >= 0 && i < len(s) && s[i] >= '0' && s[i] <= '9'
+	}
+	t := ""
+	i0 := 0
+	for i := 1; i+1 < len(s); i++ {
+		if s[i] != '/' || !isDigit(i-1) || !isDigit(i+1) {
+			continue
+		}
+		a, b := i-1, i+2 /* the fraction is s[a:b] */
+		for isDigit(a - 1) {
+			a--
+		}
+		for isDigit(b) {
+			b++
+		}
+		if a > 0 && s[a-1] == '/' || b < len(s) && s[b] == '/' {
+			continue
+		}
+		if f, ok := fractions[s[a:b]]; ok {
+			t += s[i0:a] + f
+			i0 = b
+		}
+	}
+	return t + s[i0:]
+}
+
+/* frenchSpacing - insert no-break spaces before the punctuation ! ? ;
+ * : and », and after «, as in French typography; space tells whether
+ * s follows a space. Punctuation within a word, like the colons of
+ * 12:30 or http://, is left alone.
+ */
+func frenchSpacing(s string, space bool) string {
+	t := ""
+	for s != "" {
+		n := len(s) - len(strings.TrimLeft(s, "!?;:"))
+		switch {
+		case strings.HasPrefix(s, "«"):
+			n = len("«")
+			t += s[:n]
+			if n < len(s) && !strings.HasPrefix(s[n:], nbsp) {
+				t += nbsp
+			}
+		case strings.HasPrefix(s, "»"):
+			n = len("»")
+			t = padBefore(t, space) + s[:n]
+		case n > 0:
+			if rest := s[n:]; rest == "" || strings.HasPrefix(rest, "»") || strings.IndexAny(rest[:1], ",.)") == 0 {
+				t = padBefore(t, space)
+			}
+			t += s[:n]
+		default:
+			n = 1
+			t += s[:n]
+		}
+		s = s[n:]
+	}
+	return t
+}
+
+/* padBefore - append a no-break space to t, unless it ends with
+ * a space, or, if empty, the text follows one
+ */
+func padBefore(t string, space bool) string {
+	if t == "" && space || strings.HasSuffix(t, nbsp) {
+		return t
+	}
+	return t + nbsp
+}
@@ -0,0 +1,215 @@
+package markdown
+
+// Checking the links, references and anchors of a document
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A Problem is found by Doc.Validate. Kind tells which one it is,
+// Line and Msg are like those of a Warning.
+type Problem struct {
+	Line	int
+	Kind	int
+	Msg		string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("line %d: %s", p.Line, p.Msg)
+}
+
+// Values of Problem.Kind.
+const (
+	OtherProblem		= iota	// another problem reported by Doc.Warnings, like a code fence not closed
+	UndefinedReference			// a reference link to a label that is not defined
+	DuplicateReference			// a label defined more than once
+	UnusedReference				// a link definition no link refers to
+	UndefinedNote				// a reference to a footnote that is not defined
+	UnusedNote					// a footnote no reference refers to
+	DuplicateAnchor				// an id given to more than one element
+	BrokenFragment				// a link to #id, where id is not the id of any element
+)
+
+// Validate checks the document like a linter: it returns the
+// problems reported by Warnings, and in addition those found by
+// examining the whole document: link definitions and footnotes not
+// used, ids given to more than one element, and links to fragments
+// of the document, like #usage, that no element has as id.  Ids are
+// those of attribute blocks, of headings, if the HeadingIDs
+// extension is enabled, and of elements of raw HTML.  The problems
+// are returned in order of their lines.
+func (d *Doc) Validate() []Problem {
+	v := &validator{
+		d:		d,
+		ids:	make(map[string]*Element),
+		refs:	make(map[*link]bool),
+		notes:	make(map[*Element]bool),
+	}
+	d.Walk(v)
+	list := make(warningList, len(d.warnings))
+	copy(list, d.warnings)
+	add := func(e *Element, kind int, msg string) {
+		list = append(list, warning{e, kind, msg, len(list)})
+	}
+
+	/* the definitions the links have been resolved by, and the
+	 * footnotes referred to, by their labels
+	 */
+	used := make(map[string]bool)
+	for r := d.references; r != nil; r = r.next {
+		if v.refs[r.contents.link] {
+			used[strings.ToUpper(plainText(r.contents.link.label))] = true
+		}
+	}
+	for n := d.notes; n != nil; n = n.next {
+		if v.notes[n.children] {
+			used["^"+n.contents.str] = true
+		}
+	}
+	seen := make(map[string]bool)
+	for _, e := range v.defs {
+		if e.key == REFERENCE {
+			label := plainText(e.contents.link.label)
+			if u := strings.ToUpper(label); !used[u] && !seen[u] {
+				add(e, UnusedReference, "unused reference ["+label+"]")
+				seen[u] = true
+			}
+		} else if u := "^" + e.contents.str; !used[u] && !seen[u] {
+			add(e, UnusedNote, "unused note [^"+e.contents.str+"]")
+			seen[u] = true
+		}
+	}
+
+	for _, e := range v.fragments {
+		if id := e.contents.link.url[1:]; v.ids[id] == nil {
+			add(e, BrokenFragment, "undefined fragment #"+id)
+		}
+	}
+	for _, w := range v.problems {
+		add(w.e, w.kind, w.msg)
+	}
+
+	sort.Sort(list)
+	ps := make([]Problem, len(list))
+	for i, w := range list {
+		ps[i] = Problem{w.e.line, w.kind, w.msg}
+	}
+	return ps
+}
+
+/* a validator collects the ids, links to fragments and definitions
+ * of a document, and which definitions are used.  The contents of
+ * a footnote are visited once, at its first reference.
+ */
+type validator struct {
+	d			*Doc
+	ids			map[string]*Element	/* The element each id has been given to first. */
+	refs		map[*link]bool		/* Definitions found by reference links. */
+	notes		map[*Element]bool	/* Contents of the footnotes referred to. */
+	defs		[]*Element			/* Link definitions and footnotes, in order. */
+	fragments	[]*Element			/* Links to #id. */
+	problems	[]warning			/* Duplicate ids. */
+}
+
+func (v *validator) Visit(e *Element) Visitor {
+	if e == nil {
+		return nil
+	}
+	switch {
+	case e.attr != nil && e.attr.ID != "":
+		v.id(e, e.attr.ID)
+	case e.key >= H1 && e.key <= H6 && e.contents.str != "":
+		v.id(e, e.contents.str)
+	}
+	switch e.key {
+	case REFERENCE:
+		v.defs = append(v.defs, e)
+		return nil
+	case NOTE:
+		if e.contents.str != "" {
+			v.defs = append(v.defs, e)
+			return nil
+		}
+		if v.notes[e.children] {
+			return nil
+		}
+		v.notes[e.children] = true
+	case LINK:
+		if l := e.contents.link; l.ref != nil {
+			v.refs[l.ref] = true
+		} else if len(l.url) > 1 && l.url[0] == '#' {
+			v.fragments = append(v.fragments, e)
+		}
+	case HTML, HTMLBLOCK:
+		for _, id := range htmlIDs(e.contents.str) {
+			v.id(e, id)
+		}
+	}
+	return v
+}
+
+/* id - record that id is given to e, or that it is a duplicate */
+func (v *validator) id(e *Element, id string) {
+	if prev, ok := v.ids[id]; ok {
+		msg := fmt.Sprintf("duplicate id #%s, first used in line %d", id, prev.line)
+		v.problems = append(v.problems, warning{e, DuplicateAnchor, msg, 0})
+		return
+	}
+	v.ids[id] = e
+}
+
+/* htmlIDs - return the values of the id attributes of the tags in
+ * HTML text, and of the name attributes of its <a> tags
+ */
+func htmlIDs(s string) (ids []string) {
+	for {
+		i := strings.Index(s, "<")
+		if i == -1 {
+			break
+		}
+		s = s[i:]
+		n := tagEnd(s)
+		if n == -1 {
+			break
+		}
+		tag := strings.ToLower(s[:n])
+		if id, ok := tagAttr(s[:n], "id"); ok {
+			ids = append(ids, id)
+		} else if id, ok := tagAttr(s[:n], "name"); ok && tagName(tag) == "a" {
+			ids = append(ids, id)
+		}
+		s = s[n:]
+	}
+	return
+}
+
+/* tagAttr - return the value of the attribute name, in lower case,
+ * of a start tag
+ */
+func tagAttr(tag, name string) (value string, ok bool) {
+	lower := strings.ToLower(tag)
+	for i := 1; i < len(tag); i += len(name) {
+		n := strings.Index(lower[i:], name+"=")
+		if n == -1 {
+			break
+		}
+		i += n
+		if c := tag[i-1]; c != ' ' && c != '\t' && c != '\n' {
+			continue
+		}
+		s := tag[i+len(name)+1:]
+		if s != "" && (s[0] == '"' || s[0] == '\'') {
+			if end := strings.Index(s[1:], s[:1]); end != -1 {
+				return s[1 : 1+end], true
+			}
+			break
+		}
+		if end := strings.IndexAny(s, " \t\n/>"); end != -1 {
+			return s[:end], true
+		}
+		break
+	}
+	return "", false
+}
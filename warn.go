@@ -0,0 +1,103 @@
+package markdown
+
+// Warnings about problems found in a document
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A Warning describes a problem found while parsing a document,
+// like an undefined reference, or a fenced code block that is not
+// closed.  Line is the first line of the top level block the problem
+// has been found in, or 0, if it is not known.
+type Warning struct {
+	Line	int
+	Msg		string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("line %d: %s", w.Line, w.Msg)
+}
+
+// Warnings returns the problems found while parsing the document, in
+// order of their lines.
+func (d *Doc) Warnings() []Warning {
+	list := make(warningList, len(d.warnings))
+	copy(list, d.warnings)
+	sort.Sort(list)
+	ws := make([]Warning, len(list))
+	for i, w := range list {
+		ws[i] = Warning{w.e.line, w.msg}
+	}
+	return ws
+}
+
+/* a warning is recorded together with the element it applies to, as
+ * lines are known only after the document has been parsed.
+ */
+type warning struct {
+	e		*Element
+	kind	int	/* See Problem. */
+	msg		string
+	seq		int
+}
+
+type warningList []warning
+
+func (l warningList) Len() int		{ return len(l) }
+func (l warningList) Swap(i, j int)	{ l[i], l[j] = l[j], l[i] }
+func (l warningList) Less(i, j int) bool {
+	if l[i].e.line != l[j].e.line {
+		return l[i].e.line < l[j].e.line
+	}
+	return l[i].seq < l[j].seq
+}
+
+func (d *Doc) warn(e *Element, msg string) {
+	d.problem(e, OtherProblem, msg)
+}
+
+/* problem - record a warning of one of the kinds of Problem */
+func (d *Doc) problem(e *Element, kind int, msg string) {
+	d.warnings = append(d.warnings, warning{e, kind, msg, len(d.warnings)})
+}
+
+/* checkURL - warn about an empty link destination, or one that has
+ * been dropped by safeURL.
+ */
+func (d *Doc) checkURL(e *Element, url string) {
+	switch {
+	case url == "":
+		d.warn(e, "empty link destination")
+	case e.contents.link.url == "":
+		d.warn(e, "unsafe link destination removed: "+url)
+	}
+}
+
+/* checkReferences - warn about labels defined more than once.
+ */
+func (d *Doc) checkReferences() {
+	v := &referenceChecker{d, make(map[string]*Element)}
+	d.Walk(v)
+}
+
+type referenceChecker struct {
+	d		*Doc
+	seen	map[string]*Element
+}
+
+func (v *referenceChecker) Visit(e *Element) Visitor {
+	if e == nil || e.key != REFERENCE {
+		return v
+	}
+	label := plainText(e.contents.link.label)
+	u := strings.ToUpper(label)
+	if prev, ok := v.seen[u]; ok {
+		v.d.problem(e, DuplicateReference, fmt.Sprintf("duplicate reference [%s], first defined in line %d", label, prev.line))
+	} else {
+		v.seen[u] = e
+	}
+	return nil
+}
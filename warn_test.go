@@ -0,0 +1,37 @@
+package markdown
+
+import (
+	"testing"
+)
+
+// TestNoteWarnings checks that problems within the text of a note are
+// reported once, in the lines of its definition.
+func TestNoteWarnings(t *testing.T) {
+	for _, c := range []struct {
+		text	string
+		want	[]Warning
+	}{
+		{"Text.[^a]\n\n[^a]: Note with [^y]\n", []Warning{
+			{3, "undefined note [^y]"},
+		}},
+		{"[^a]: Note with [x][].\n\nText.[^a] and again.[^a]\n", []Warning{
+			{1, "undefined reference [x]"},
+		}},
+		{"Text ^[inline [z][]]\n\n[^b]: unused [^y]\n", []Warning{
+			{1, "undefined reference [z]"},
+			{3, "undefined note [^y]"},
+		}},
+		{"A.[^a]\n\n[^a]: see [^a]\n", []Warning{
+			{3, "note [^a] refers to itself"},
+		}},
+	} {
+		ws := NewParser(Extensions{Notes: true}).Parse(c.text).Warnings()
+		ok := len(ws) == len(c.want)
+		for i := 0; ok && i < len(ws); i++ {
+			ok = ws[i].Line == c.want[i].Line && ws[i].Msg == c.want[i].Msg
+		}
+		if !ok {
+			t.Errorf("%q: warnings %v, want %v", c.text, ws, c.want)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package markdown
+
+// Wiki links like [[Page Name]], or [[Page Name|label]]
+
+import (
+	"strings"
+)
+
+/* wikiLink - make a LINK element for a wiki link to target.  The text of
+ * the link is label, or the target, if label is nil.  The element gets
+ * the class "wikilink", and "missing", if the resolver reports so.
+ */
+func (d *Doc) wikiLink(target string, label *Element) *Element {
+	if label == nil {
+		label = mk_str(target)
+	}
+	url, missing := strings.Replace(target, " ", "%20", -1), false
+	if d.wikiResolver != nil {
+		url, missing = d.wikiResolver(target)
+	}
+	e := mk_link(label, d.safeURL(url), "")
+	e.attr = &Attributes{Classes: []string{"wikilink"}}
+	if missing {
+		e.attr.Classes = append(e.attr.Classes, "missing")
+	}
+	return e
+}
@@ -0,0 +1,101 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"utf8"
+)
+
+var errFull = os.NewError("writer full")
+
+/* a failWriter takes up to max bytes, and fails then */
+type failWriter struct {
+	bytes.Buffer
+	max	int
+}
+
+func (w *failWriter) WriteString(s string) (int, os.Error) {
+	if w.Len()+len(s) > w.max {
+		return 0, errFull
+	}
+	return w.Buffer.WriteString(s)
+}
+
+func (w *failWriter) WriteRune(r int) (int, os.Error) {
+	if w.Len()+utf8.RuneLen(r) > w.max {
+		return 0, errFull
+	}
+	return w.Buffer.WriteRune(r)
+}
+
+func (w *failWriter) WriteByte(c byte) os.Error {
+	if w.Len()+1 > w.max {
+		return errFull
+	}
+	return w.Buffer.WriteByte(c)
+}
+
+/* a pageTemplate prints the body of a page within the format */
+type pageTemplate string
+
+func (t pageTemplate) Execute(w io.Writer, data interface{}) os.Error {
+	_, err := fmt.Fprintf(w, string(t), data.(*PageData).Body)
+	return err
+}
+
+var writers = []struct {
+	name	string
+	write	func(d *Doc, w Writer) (int, os.Error)
+}{
+	{"html", func(d *Doc, w Writer) (int, os.Error) { return d.WriteHtml(w) }},
+	{"document", func(d *Doc, w Writer) (int, os.Error) { return d.WriteHtmlDocument(w, nil) }},
+	{"template", func(d *Doc, w Writer) (int, os.Error) {
+		return d.WriteHtmlDocument(w, &Page{Template: pageTemplate("<body>\n%s</body>\n")})
+	}},
+	{"slides", func(d *Doc, w Writer) (int, os.Error) { return d.WriteSlides(w, SlidesAtRules) }},
+	{"ast", func(d *Doc, w Writer) (int, os.Error) { return d.WriteAST(w) }},
+	{"docbook", func(d *Doc, w Writer) (int, os.Error) { return d.WriteDocBook(w) }},
+	{"flowed", func(d *Doc, w Writer) (int, os.Error) { return d.WriteFlowed(w, 0) }},
+	{"groff", func(d *Doc, w Writer) (int, os.Error) { return d.WriteGroffMm(w) }},
+	{"latex", func(d *Doc, w Writer) (int, os.Error) { return d.WriteLatex(w) }},
+	{"man", func(d *Doc, w Writer) (int, os.Error) { return d.WriteMan(w, nil) }},
+	{"markdown", func(d *Doc, w Writer) (int, os.Error) { return d.WriteMarkdown(w, nil) }},
+	{"term", func(d *Doc, w Writer) (int, os.Error) { return d.WriteTerm(w) }},
+	{"text", func(d *Doc, w Writer) (int, os.Error) { return d.WriteText(w, true) }},
+}
+
+const writersText = `# Heading
+
+A paragraph with *emphasis*, a [link](/url), and a note.[^1]
+
+- an item
+- another one
+
+---
+
+	code
+
+[^1]: The note.
+`
+
+// TestWriters checks that each output format returns the number of
+// bytes written, and the first error of a Writer failing part way.
+func TestWriters(t *testing.T) {
+	d := NewParser(Extensions{Notes: true}).Parse(writersText)
+	for _, c := range writers {
+		var b bytes.Buffer
+		n, err := c.write(d, &b)
+		if err != nil || n != b.Len() || n == 0 {
+			t.Errorf("%s: %d, %v, want %d, nil", c.name, n, err, b.Len())
+			continue
+		}
+		w := &failWriter{max: n / 2}
+		n, err = c.write(d, w)
+		if err != errFull || n != w.Len() {
+			t.Errorf("%s: failing writer: %d, %v, want %d, %v", c.name, n, err, w.Len(), errFull)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package markdown
+
+// Well-formed XHTML output
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+/* elements of HTML without contents or end tag */
+var voidElements = map[string]bool{
+	"area":		true,
+	"base":		true,
+	"br":		true,
+	"col":		true,
+	"embed":	true,
+	"hr":		true,
+	"img":		true,
+	"input":	true,
+	"link":		true,
+	"meta":		true,
+	"param":	true,
+	"source":	true,
+	"track":	true,
+	"wbr":		true,
+}
+
+/* xmlEntity - return the character reference s, like &hellip;, as
+ * a numeric one, unless it is known to XML
+ */
+func xmlEntity(s string) string {
+	name := s[1 : len(s)-1]
+	switch name {
+	case "amp", "lt", "gt", "quot", "apos":
+		return s
+	}
+	if strings.HasPrefix(name, "#") {
+		return s
+	}
+	if c, ok := htmlEntities[name]; ok {
+		return "&#" + strconv.Itoa(c) + ";"
+	}
+	/* not a character reference */
+	return "&amp;" + s[1:]
+}
+
+/* xhtml - make raw HTML well-formed: replace named character
+ * references by numeric ones, and close void elements
+ */
+func xhtml(s string) string {
+	var b bytes.Buffer
+	for {
+		i := strings.IndexAny(s, "&<")
+		if i == -1 {
+			break
+		}
+		b.WriteString(s[:i])
+		s = s[i:]
+		if s[0] == '&' {
+			n := 1
+			for n < len(s) && (isAlnum(s[n]) && s[n] < 0x80 || n == 1 && s[n] == '#') {
+				n++
+			}
+			if n < len(s) && s[n] == ';' && n > 1 {
+				b.WriteString(xmlEntity(s[:n+1]))
+				s = s[n+1:]
+			} else {
+				b.WriteString("&amp;")
+				s = s[1:]
+			}
+			continue
+		}
+		if strings.HasPrefix(s, "<!--") {
+			n := strings.Index(s, "-->")
+			if n == -1 {
+				n = len(s)
+			} else {
+				n += 3
+			}
+			b.WriteString(s[:n])
+			s = s[n:]
+			continue
+		}
+		n := tagEnd(s)
+		if n == -1 {
+			b.WriteString(s)
+			s = ""
+			break
+		}
+		tag := s[:n]
+		name := tag[1:]
+		if k := strings.IndexAny(name, " \t\n/>"); k != -1 {
+			name = name[:k]
+		}
+		if voidElements[strings.ToLower(name)] && !strings.HasSuffix(tag, "/>") {
+			tag = strings.TrimRight(tag[:n-1], " \t\n") + " />"
+		}
+		b.WriteString(tag)
+		s = s[n:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+/* stripTags - remove the tags from HTML text
+ */
+func stripTags(s string) string {
+	var b bytes.Buffer
+	for {
+		i := strings.Index(s, "<")
+		if i == -1 {
+			break
+		}
+		b.WriteString(s[:i])
+		n := tagEnd(s[i:])
+		if n == -1 {
+			s = ""
+			break
+		}
+		s = s[i+n:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+/* tagEnd - return the length of the tag at the start of s, skipping
+ * quoted attribute values, or -1 if it is not terminated
+ */
+func tagEnd(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"', c == '\'':
+			quote = c
+		case c == '>':
+			return i + 1
+		}
+	}
+	return -1
+}